@@ -23,82 +23,214 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/health/": {
+        "/openapi.json": {
             "get": {
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the OpenAPI 3 spec of endpoints served by the gateway, router path params are limited to configured routers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Operations"
                 ],
-                "summary": "Gateway Health",
-                "operationId": "glide-health",
+                "summary": "OpenAPI Spec",
+                "operationId": "glide-openapi",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.HealthSchema"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/v1/language/": {
+        "/v1/admin/audit": {
             "get": {
-                "description": "Retrieve list of configured language routers and their configurations",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the most recent config reloads, admin changes, model health transitions \u0026 exhausted budgets, the newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Language"
+                    "Admin"
                 ],
-                "summary": "Language Router List",
-                "operationId": "glide-language-routers",
+                "summary": "Audit Log",
+                "operationId": "glide-admin-audit",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.RouterListSchema"
+                            "$ref": "#/definitions/http.AuditLogSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
                         }
                     }
                 }
             }
         },
-        "/v1/language/{router}/chat": {
-            "post": {
-                "description": "Talk to different LLM Chat APIs via unified endpoint",
-                "consumes": [
+        "/v1/admin/cache": {
+            "get": {
+                "description": "Retrieve hits, misses \u0026 memory usage of response \u0026 embedding caches per router",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Cache Stats",
+                "operationId": "glide-admin-cache-stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID to describe caches of",
+                        "name": "router",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.CacheStatsSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove cached responses \u0026 embeddings. Filters are combined, no filters purge caches of all routers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Language"
+                    "Admin"
                 ],
-                "summary": "Language Chat",
-                "operationId": "glide-language-chat",
+                "summary": "Purge Cache",
+                "operationId": "glide-admin-cache-purge",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Router ID",
+                        "description": "Router ID to purge caches of",
                         "name": "router",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
-                        "description": "Request Data",
+                        "type": "string",
+                        "description": "Model ID to purge entries produced by",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cache key prefix (e.g. chat:default: or embed:default:openai:)",
+                        "name": "prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CachePurge"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/captures": {
+            "get": {
+                "description": "Retrieve sanitized raw provider requests \u0026 responses captured for sampled or selected requests, the newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Provider Traffic Captures",
+                "operationId": "glide-admin-captures",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request ID to retrieve captures of (the X-Request-ID header)",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider to retrieve captures of",
+                        "name": "provider",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.CaptureLogSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the share of provider calls captured and/or request IDs whose provider calls are always captured until the restart. Zero sample rate \u0026 no request IDs turn capturing off",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Capture Targets",
+                "operationId": "glide-admin-captures-update",
+                "parameters": [
+                    {
+                        "description": "Provider calls to capture",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.ChatRequest"
+                            "$ref": "#/definitions/telemetry.CaptureTargets"
                         }
                     }
                 ],
@@ -106,7 +238,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.ChatResponse"
+                            "$ref": "#/definitions/telemetry.CaptureTargets"
                         }
                     },
                     "400": {
@@ -115,8 +247,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorSchema"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorSchema"
                         }
@@ -124,744 +256,4617 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/language/{router}/chatStream": {
+        "/v1/admin/config": {
             "get": {
-                "description": "Talk to different LLM Stream Chat APIs via a unified websocket endpoint",
-                "consumes": [
+                "description": "Export the current effective config (including routers changed at runtime) in the config file format, so it could be committed back to the source control. Secrets are redacted",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Language"
+                    "Admin"
                 ],
-                "summary": "Language Chat",
-                "operationId": "glide-language-chat-stream",
+                "summary": "Export Config",
+                "operationId": "glide-admin-config-export",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Router ID",
-                        "name": "router",
-                        "in": "path",
-                        "required": true
+                        "description": "yaml (default) or json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Websocket Connection Type",
-                        "name": "Connection",
-                        "in": "header",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Upgrade header",
-                        "name": "Upgrade",
-                        "in": "header",
-                        "required": true
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/errors": {
+            "get": {
+                "description": "Retrieve the most recent warnings \u0026 errors logged by the gateway, the newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Recent Errors",
+                "operationId": "glide-admin-errors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorLogSchema"
+                        }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/language/{router}": {
+            "put": {
+                "description": "Create a new language router or replace the existing one. The payload follows the router config file format (YAML or JSON)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create or Update Language Router",
+                "operationId": "glide-admin-language-router-upsert",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Websocket Security Token",
-                        "name": "Sec-WebSocket-Key",
-                        "in": "header",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/routers.LangRouterConfig"
+                        }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the language router. Requests that are already being served are finished",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Language Router",
+                "operationId": "glide-admin-language-router-delete",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Websocket Security Token",
-                        "name": "Sec-WebSocket-Version",
-                        "in": "header",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorSchema"
                         }
-                    },
-                    "426": {
-                        "description": "Upgrade Required"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "anthropic.Config": {
-            "type": "object",
-            "required": [
-                "apiVersion",
-                "baseUrl",
-                "chatEndpoint",
-                "model"
-            ],
-            "properties": {
-                "apiVersion": {
-                    "type": "string"
-                },
-                "baseUrl": {
+        },
+        "/v1/admin/language/{router}/models/refresh": {
+            "post": {
+                "description": "Re-fetch capabilities and upstream models of the router models bypassing the cache",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Refresh Language Router Models",
+                "operationId": "glide-admin-language-models-refresh",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ModelListSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/language/{router}/models/{model}": {
+            "put": {
+                "description": "Add a new model to the language router pool or replace the existing one. The payload follows the model config file format (YAML or JSON)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create or Update Language Model",
+                "operationId": "glide-admin-language-model-upsert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "model",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/routers.LangRouterConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the model from the language router pool",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Language Model",
+                "operationId": "glide-admin-language-model-delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "model",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/routers.LangRouterConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/log-levels": {
+            "get": {
+                "description": "Retrieve the default log level \u0026 its overrides per module (providers, routing, http, health)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Log Levels",
+                "operationId": "glide-admin-log-levels",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.LogLevelsSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the default log level and/or levels of modules (providers, routing, http, health) until the restart. An empty module level resets it to the default one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update Log Levels",
+                "operationId": "glide-admin-log-levels-update",
+                "parameters": [
+                    {
+                        "description": "Log levels to change",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.LogLevelsSchema"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.LogLevelsSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/metrics": {
+            "get": {
+                "description": "Retrieve current values of the gateway counters (usage, cache hits, rejected requests, etc.)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Gateway Metrics",
+                "operationId": "glide-admin-metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.MetricsSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/usage": {
+            "get": {
+                "description": "Retrieve token usage \u0026 costs of served requests per router, model \u0026 caller since the gateway has started",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Usage \u0026 Costs",
+                "operationId": "glide-admin-usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID to report usage of",
+                        "name": "router",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model ID to report usage of",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller to report usage of (the API key hash or the client IP)",
+                        "name": "caller",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.UsageSchema"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/audio/{router}/transcriptions": {
+            "post": {
+                "description": "Transcribe audio files via different speech-to-text APIs using unified endpoint",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audio"
+                ],
+                "summary": "Speech-to-Text Transcription",
+                "operationId": "glide-audio-transcribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Audio file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the audio (ISO-639-1)",
+                        "name": "language",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hint text to guide the transcription",
+                        "name": "prompt",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TranscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/config/reload/": {
+            "get": {
+                "description": "Retrieve the outcome of the last config reload triggered by SIGHUP or the config file change",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operations"
+                ],
+                "summary": "Config Reload Status",
+                "operationId": "glide-config-reload",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ConfigReloadSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/health/": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operations"
+                ],
+                "summary": "Gateway Health",
+                "operationId": "glide-health",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.HealthSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/image/{router}/generate": {
+            "post": {
+                "description": "Generate images via different image generation APIs using unified endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Image Generation",
+                "operationId": "glide-image-generate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ImageGenerateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ImageGenerateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/": {
+            "get": {
+                "description": "Retrieve list of configured language routers and their configurations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Language Router List",
+                "operationId": "glide-language-routers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.RouterListSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/chat": {
+            "post": {
+                "description": "Talk to different LLM Chat APIs via unified endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Language Chat",
+                "operationId": "glide-language-chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ChatRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Router model to pin the request to",
+                        "name": "X-Glide-Model",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Describe the routing decision in the response",
+                        "name": "X-Glide-Trace",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Break the request latency down in the response",
+                        "name": "X-Glide-Timing",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request priority under load shedding (low, normal or high)",
+                        "name": "X-Glide-Priority",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key are served the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response cache directives (no-cache, no-store or max-age)",
+                        "name": "Cache-Control",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ChatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/chat/batch": {
+            "post": {
+                "description": "Serve a batch of chat requests concurrently. Failures are reported per request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Language Chat Batch",
+                "operationId": "glide-language-chat-batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ChatBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ChatBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/chatStream": {
+            "get": {
+                "description": "Talk to different LLM Stream Chat APIs via a unified websocket endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Language Chat",
+                "operationId": "glide-language-chat-stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Websocket Connection Type",
+                        "name": "Connection",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upgrade header",
+                        "name": "Upgrade",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Websocket Security Token",
+                        "name": "Sec-WebSocket-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Websocket Security Token",
+                        "name": "Sec-WebSocket-Version",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "426": {
+                        "description": "Upgrade Required"
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/embeddings": {
+            "post": {
+                "description": "Get embeddings from different LLM Embedding APIs via unified endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Language Embeddings",
+                "operationId": "glide-language-embed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.EmbedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.EmbedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/feedback": {
+            "post": {
+                "description": "Rate a chat response of a router model, so routers with the bandit strategy could learn which models serve the best responses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Chat Feedback",
+                "operationId": "glide-language-feedback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Feedback Data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ChatFeedback"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/health": {
+            "get": {
+                "description": "Retrieve health, error budgets \u0026 latencies of the router models and which models the router would pick next",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Language Router Health",
+                "operationId": "glide-language-router-health",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RouterHealth"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/models": {
+            "get": {
+                "description": "Retrieve capabilities and upstream models of the router models (cached)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Language Router Models",
+                "operationId": "glide-language-models",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ModelListSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/language/{router}/tokenize": {
+            "post": {
+                "description": "Count prompt tokens for a router model, so clients can check the prompt fits the model context before sending it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language"
+                ],
+                "summary": "Token Counting",
+                "operationId": "glide-language-tokenize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TokenizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TokenizeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/moderation/{router}": {
+            "post": {
+                "description": "Check content via different moderation APIs \u0026 classifiers using unified endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Moderation",
+                "operationId": "glide-moderation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Router ID",
+                        "name": "router",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request Data",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ModerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ModerationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorSchema"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "anthropic.Config": {
+            "type": "object",
+            "required": [
+                "apiVersion",
+                "baseUrl",
+                "chatEndpoint",
+                "model"
+            ],
+            "properties": {
+                "apiVersion": {
+                    "type": "string"
+                },
+                "baseUrl": {
+                    "type": "string"
+                },
+                "chatEndpoint": {
+                    "type": "string"
+                },
+                "defaultParams": {
+                    "$ref": "#/definitions/anthropic.Params"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "tokenEndpoint": {
+                    "type": "string"
+                }
+            }
+        },
+        "anthropic.Params": {
+            "type": "object",
+            "properties": {
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "metadata": {
+                    "type": "string"
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "system": {
+                    "type": "string"
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "top_k": {
+                    "type": "integer"
+                },
+                "top_p": {
+                    "type": "number"
+                }
+            }
+        },
+        "azureopenai.Config": {
+            "type": "object",
+            "required": [
+                "apiVersion",
+                "baseUrl",
+                "model"
+            ],
+            "properties": {
+                "apiVersion": {
+                    "description": "The API version to use for this operation. This follows the YYYY-MM-DD format (e.g 2023-05-15)",
+                    "type": "string"
+                },
+                "baseUrl": {
+                    "description": "The name of your Azure OpenAI Resource (e.g https://glide-test.openai.azure.com/)",
+                    "type": "string"
+                },
+                "chatEndpoint": {
+                    "type": "string"
+                },
+                "defaultParams": {
+                    "$ref": "#/definitions/azureopenai.Params"
+                },
+                "model": {
+                    "description": "This is your deployment name. You're required to first deploy a model before you can make calls (e.g. glide-gpt-35)",
+                    "type": "string"
+                }
+            }
+        },
+        "azureopenai.Params": {
+            "type": "object",
+            "properties": {
+                "frequency_penalty": {
+                    "type": "integer"
+                },
+                "logit_bias": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "n": {
+                    "type": "integer"
+                },
+                "presence_penalty": {
+                    "type": "integer"
+                },
+                "response_format": {
+                    "description": "TODO: should this be a part of the chat request API?"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "tool_choice": {},
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "top_p": {
+                    "type": "number"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "bedrock.Config": {
+            "type": "object",
+            "required": [
+                "awsRegion",
+                "baseUrl",
+                "chatEndpoint",
+                "model"
+            ],
+            "properties": {
+                "awsRegion": {
+                    "type": "string"
+                },
+                "baseUrl": {
+                    "type": "string"
+                },
+                "chatEndpoint": {
+                    "type": "string"
+                },
+                "defaultParams": {
+                    "$ref": "#/definitions/bedrock.Params"
+                },
+                "model": {
+                    "type": "string"
+                }
+            }
+        },
+        "bedrock.Params": {
+            "type": "object",
+            "properties": {
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "top_p": {
+                    "type": "number"
+                }
+            }
+        },
+        "cache.RedisConfig": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "addresses": {
+                    "description": "more cluster nodes to discover the cluster through",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cluster": {
+                    "description": "connects to Redis Cluster, keys are spread over its shards",
+                    "type": "boolean"
+                },
+                "db": {
+                    "description": "must be zero in the cluster mode",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "dial_timeout": {
+                    "type": "string"
+                },
+                "key_prefix": {
+                    "type": "string"
+                },
+                "min_idle_conns": {
+                    "description": "connections kept open when idle",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "pool_size": {
+                    "description": "connections per node, zero means 10 per CPU",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "read_timeout": {
+                    "type": "string"
+                },
+                "tls": {
+                    "$ref": "#/definitions/cache.RedisTLSConfig"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "write_timeout": {
+                    "type": "string"
+                }
+            }
+        },
+        "cache.RedisTLSConfig": {
+            "type": "object",
+            "properties": {
+                "ca_file": {
+                    "description": "verifies the server with this CA instead of the system ones",
+                    "type": "string"
+                },
+                "cert_file": {
+                    "description": "the client certificate for mTLS",
+                    "type": "string"
+                },
+                "insecure_skip_verify": {
+                    "type": "boolean"
+                },
+                "key_file": {
+                    "type": "string"
+                },
+                "server_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "cache.SQLiteConfig": {
+            "type": "object",
+            "required": [
+                "path"
+            ],
+            "properties": {
+                "path": {
+                    "description": "the database file, created if it doesn't exist",
+                    "type": "string"
+                }
+            }
+        },
+        "clients.ClientConfig": {
+            "type": "object",
+            "properties": {
+                "dialer": {
+                    "$ref": "#/definitions/clients.DialerConfig"
+                },
+                "dns": {
+                    "$ref": "#/definitions/clients.DNSConfig"
+                },
+                "timeout": {
+                    "description": "how long a request to the model may take, including reading the response",
+                    "type": "string"
+                },
+                "warmup": {
+                    "$ref": "#/definitions/clients.WarmupConfig"
+                }
+            }
+        },
+        "clients.DNSConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "ttl": {
+                    "description": "overrides TTLs of the DNS records",
+                    "type": "string"
+                }
+            }
+        },
+        "clients.DialerConfig": {
+            "type": "object",
+            "properties": {
+                "connect_timeout": {
+                    "type": "string"
+                },
+                "fallback_delay": {
+                    "description": "FallbackDelay is how long to wait for the preferred IP family before trying the other one in parallel (aka Happy Eyeballs).\n A negative value disables the parallel attempts, so the other family is tried only after the preferred one has failed",
+                    "type": "string"
+                },
+                "ip_preference": {
+                    "enum": [
+                        "auto",
+                        "prefer_ipv4",
+                        "prefer_ipv6",
+                        "ipv4_only",
+                        "ipv6_only"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/clients.IPPreference"
+                        }
+                    ]
+                }
+            }
+        },
+        "clients.IPPreference": {
+            "type": "string",
+            "enum": [
+                "auto",
+                "prefer_ipv4",
+                "prefer_ipv6",
+                "ipv4_only",
+                "ipv6_only"
+            ],
+            "x-enum-comments": {
+                "IPAuto": "the family of the first resolved address is tried first (the standard behaviour)"
+            },
+            "x-enum-varnames": [
+                "IPAuto",
+                "IPPreferV4",
+                "IPPreferV6",
+                "IPv4Only",
+                "IPv6Only"
+            ]
+        },
+        "clients.WarmupConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "interval": {
+                    "description": "how long a connection may stay idle before it's re-warmed",
+                    "type": "string"
+                },
+                "timeout": {
+                    "type": "string"
+                }
+            }
+        },
+        "cohere.Config": {
+            "type": "object",
+            "required": [
+                "baseUrl",
+                "chatEndpoint",
+                "model"
+            ],
+            "properties": {
+                "baseUrl": {
+                    "type": "string"
+                },
+                "chatEndpoint": {
+                    "type": "string"
+                },
+                "defaultParams": {
+                    "$ref": "#/definitions/cohere.Params"
+                },
+                "embedEndpoint": {
+                    "type": "string"
+                },
+                "embedInputType": {
+                    "description": "search_document, search_query, classification, clustering",
+                    "type": "string"
+                },
+                "embedModel": {
+                    "description": "https://docs.cohere.com/docs/models#embed",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "https://docs.cohere.com/docs/models#command",
+                    "type": "string"
+                },
+                "modelEndpoint": {
+                    "type": "string"
+                },
+                "tokenEndpoint": {
+                    "type": "string"
+                }
+            }
+        },
+        "cohere.Params": {
+            "type": "object",
+            "required": [
+                "temperature"
+            ],
+            "properties": {
+                "connectors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "frequency_penalty": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "k": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 0
+                },
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "p": {
+                    "type": "number",
+                    "maximum": 0.99,
+                    "minimum": 0.01
+                },
+                "preamble": {
+                    "type": "string"
+                },
+                "presence_penalty": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "prompt_truncation": {
+                    "type": "string"
+                },
+                "search_queries_only": {
+                    "type": "boolean"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "stop_sequences": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "temperature": {
+                    "type": "number"
+                }
+            }
+        },
+        "events.DeliveryConfig": {
+            "type": "object",
+            "required": [
+                "retry",
+                "url"
+            ],
+            "properties": {
+                "dead_letter_file": {
+                    "description": "JSON Lines file undelivered events are appended to",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "types of events delivered to the URL (e.g. \"model.*\"), all by default",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "retry": {
+                    "$ref": "#/definitions/retry.ExpRetryConfig"
+                },
+                "signing": {
+                    "$ref": "#/definitions/events.SigningConfig"
+                },
+                "timeout": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "events.SigningConfig": {
+            "type": "object"
+        },
+        "health.AdaptiveConcurrencyConfig": {
+            "type": "object",
+            "properties": {
+                "backoff": {
+                    "description": "the limit is multiplied by this on signs of provider overload",
+                    "type": "number"
+                },
+                "initial_limit": {
+                    "description": "the limit the model starts with",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "latency_tolerance": {
+                    "description": "requests slower than the usual latency this many times are signs of overload",
+                    "type": "number",
+                    "minimum": 1
+                },
+                "max_limit": {
+                    "description": "the limit never goes above this",
+                    "type": "integer"
+                },
+                "min_limit": {
+                    "description": "the limit never goes below this",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "health.CircuitBreakerConfig": {
+            "type": "object",
+            "properties": {
+                "failure_rate": {
+                    "description": "the share of requests failed over the last minute that opens the circuit",
+                    "type": "number",
+                    "maximum": 1
+                },
+                "half_open_requests": {
+                    "description": "trial requests let through once the circuit is half open, all of them must succeed to close it",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "min_requests": {
+                    "description": "the number of requests over the last minute required to judge the failure rate",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "probe_interval": {
+                    "description": "how long the circuit stays open before trial requests are let through",
+                    "type": "string"
+                }
+            }
+        },
+        "http.AuditLogSchema": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/telemetry.AuditEntry"
+                    }
+                }
+            }
+        },
+        "http.CacheStatsSchema": {
+            "type": "object",
+            "properties": {
+                "routers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RouterCacheStats"
+                    }
+                }
+            }
+        },
+        "http.CaptureLogSchema": {
+            "type": "object",
+            "properties": {
+                "captures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/telemetry.Capture"
+                    }
+                },
+                "requestIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sampleRate": {
+                    "type": "number"
+                }
+            }
+        },
+        "http.ConfigReloadSchema": {
+            "type": "object",
+            "properties": {
+                "lastReload": {
+                    "$ref": "#/definitions/schemas.ConfigReload"
+                }
+            }
+        },
+        "http.ErrorLogSchema": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/telemetry.LogEntry"
+                    }
+                }
+            }
+        },
+        "http.ErrorSchema": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "stable code of the failure (e.g. rate_limited or context_window_exceeded)",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "queue": {
+                    "description": "set when the request was rejected by the router queue",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.QueueStats"
+                        }
+                    ]
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "unknownFields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.HealthSchema": {
+            "type": "object",
+            "properties": {
+                "healthy": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "http.LogLevelsSchema": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "modules": {
+                    "description": "on update, an empty level resets the module to the default one",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.MetricsSchema": {
+            "type": "object",
+            "properties": {
+                "counters": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "http.ModelListSchema": {
+            "type": "object",
+            "properties": {
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ModelMetadata"
+                    }
+                }
+            }
+        },
+        "http.RouterListSchema": {
+            "type": "object",
+            "properties": {
+                "routers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/routers.LangRouterConfig"
+                    }
+                }
+            }
+        },
+        "http.UsageSchema": {
+            "type": "object",
+            "properties": {
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/telemetry.UsageRecord"
+                    }
+                },
+                "totalCost": {
+                    "description": "in USD",
+                    "type": "number"
+                },
+                "totalRequests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "latency.Config": {
+            "type": "object",
+            "properties": {
+                "decay": {
+                    "description": "Weight of new latency measurements",
+                    "type": "number"
+                },
+                "update_interval": {
+                    "description": "How often gateway should probe models with not the lowest response latency",
+                    "type": "string"
+                },
+                "warmup_samples": {
+                    "description": "The number of latency probes required to init moving average",
+                    "type": "integer"
+                },
+                "window": {
+                    "description": "The number of the latest latency samples percentiles are estimated over",
+                    "type": "integer"
+                }
+            }
+        },
+        "octoml.Config": {
+            "type": "object",
+            "required": [
+                "baseUrl",
+                "chatEndpoint",
+                "model"
+            ],
+            "properties": {
+                "baseUrl": {
+                    "type": "string"
+                },
+                "chatEndpoint": {
+                    "type": "string"
+                },
+                "defaultParams": {
+                    "$ref": "#/definitions/octoml.Params"
+                },
+                "model": {
+                    "type": "string"
+                }
+            }
+        },
+        "octoml.Params": {
+            "type": "object",
+            "properties": {
+                "frequency_penalty": {
+                    "type": "integer"
+                },
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "presence_penalty": {
+                    "type": "integer"
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "top_p": {
+                    "type": "number"
+                }
+            }
+        },
+        "ollama.Config": {
+            "type": "object",
+            "required": [
+                "baseUrl",
+                "chatEndpoint",
+                "model"
+            ],
+            "properties": {
+                "baseUrl": {
+                    "type": "string"
+                },
+                "chatEndpoint": {
+                    "type": "string"
+                },
+                "defaultParams": {
+                    "$ref": "#/definitions/ollama.Params"
+                },
+                "model": {
+                    "type": "string"
+                }
+            }
+        },
+        "ollama.Params": {
+            "type": "object",
+            "properties": {
+                "microstat": {
+                    "type": "integer"
+                },
+                "microstat_eta": {
+                    "type": "number"
+                },
+                "microstat_tau": {
+                    "type": "number"
+                },
+                "num_ctx": {
+                    "type": "integer"
+                },
+                "num_gpu": {
+                    "type": "integer"
+                },
+                "num_gqa": {
+                    "type": "integer"
+                },
+                "num_predict": {
+                    "type": "integer"
+                },
+                "num_thread": {
+                    "type": "integer"
+                },
+                "repeat_last_n": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stream": {
+                    "type": "boolean"
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "tfs_z": {
+                    "type": "number"
+                },
+                "top_k": {
+                    "type": "integer"
+                },
+                "top_p": {
+                    "type": "number"
+                }
+            }
+        },
+        "openai.Config": {
+            "type": "object",
+            "required": [
+                "baseUrl",
+                "chatEndpoint",
+                "model"
+            ],
+            "properties": {
+                "audioEndpoint": {
+                    "type": "string"
+                },
+                "audioModel": {
+                    "type": "string"
+                },
+                "baseUrl": {
+                    "type": "string"
+                },
+                "chatEndpoint": {
+                    "type": "string"
+                },
+                "defaultParams": {
+                    "$ref": "#/definitions/openai.Params"
+                },
+                "embedEndpoint": {
+                    "type": "string"
+                },
+                "embedModel": {
+                    "type": "string"
+                },
+                "imageEndpoint": {
+                    "type": "string"
+                },
+                "imageModel": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "modelEndpoint": {
+                    "type": "string"
+                },
+                "moderationEndpoint": {
+                    "type": "string"
+                },
+                "moderationModel": {
+                    "type": "string"
+                }
+            }
+        },
+        "openai.Params": {
+            "type": "object",
+            "properties": {
+                "frequency_penalty": {
+                    "type": "integer"
+                },
+                "logit_bias": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "n": {
+                    "type": "integer"
+                },
+                "presence_penalty": {
+                    "type": "integer"
+                },
+                "response_format": {
+                    "description": "TODO: should this be a part of the chat request API?"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "tool_choice": {},
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "top_p": {
+                    "type": "number"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "providers.DeprecationConfig": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "extra migration notes for clients",
+                    "type": "string"
+                },
+                "replacement": {
+                    "description": "the model clients should migrate to",
+                    "type": "string"
+                },
+                "sunset": {
+                    "description": "the date (YYYY-MM-DD) the model stops being served",
+                    "type": "string"
+                }
+            }
+        },
+        "providers.LangModelConfig": {
+            "type": "object",
+            "required": [
+                "enabled",
+                "id"
+            ],
+            "properties": {
+                "adaptive_concurrency": {
+                    "description": "the concurrency limit adapts to the provider load, never going above max_concurrency",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/health.AdaptiveConcurrencyConfig"
+                        }
+                    ]
+                },
+                "anthropic": {
+                    "$ref": "#/definitions/anthropic.Config"
+                },
+                "azureopenai": {
+                    "$ref": "#/definitions/azureopenai.Config"
+                },
+                "bedrock": {
+                    "$ref": "#/definitions/bedrock.Config"
+                },
+                "budget": {
+                    "description": "the model stops being selected once it has spent the daily or monthly budget",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.SpendBudgetConfig"
+                        }
+                    ]
+                },
+                "capabilities": {
+                    "description": "features the model supports (e.g. tools), requests depending on them are routed to capable models only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Capability"
+                    }
+                },
+                "circuit_breaker": {
+                    "description": "cuts the model off from traffic once it fails too many requests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/health.CircuitBreakerConfig"
+                        }
+                    ]
+                },
+                "client": {
+                    "$ref": "#/definitions/clients.ClientConfig"
+                },
+                "cohere": {
+                    "$ref": "#/definitions/cohere.Config"
+                },
+                "context_window": {
+                    "description": "the largest prompt the model accepts in tokens, zero means unknown",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "deprecation": {
+                    "description": "the model is still served, but clients are warned to migrate",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.DeprecationConfig"
+                        }
+                    ]
+                },
+                "enabled": {
+                    "description": "Is the model enabled?",
+                    "type": "boolean"
+                },
+                "error_budget": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Model instance ID (unique in scope of the router)",
+                    "type": "string"
+                },
+                "latency": {
+                    "$ref": "#/definitions/latency.Config"
+                },
+                "max_concurrency": {
+                    "description": "the most chat requests the model serves at the same time, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "octoml": {
+                    "$ref": "#/definitions/octoml.Config"
+                },
+                "ollama": {
+                    "$ref": "#/definitions/ollama.Config"
+                },
+                "openai": {
+                    "description": "Add other providers like",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/openai.Config"
+                        }
+                    ]
+                },
+                "params": {
+                    "description": "generation params overriding the provider default params",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.ParamsConfig"
+                        }
+                    ]
+                },
+                "pricing": {
+                    "description": "token prices used to estimate request costs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.Pricing"
+                        }
+                    ]
+                },
+                "probe": {
+                    "description": "actively checks unhealthy models, so they recover without user requests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.ProbeConfig"
+                        }
+                    ]
+                },
+                "rate_limits": {
+                    "description": "the advertised provider limits, models close to them are deprioritized",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.RateLimitConfig"
+                        }
+                    ]
+                },
+                "region": {
+                    "description": "the region of the model endpoint, \"default\" when not set",
+                    "type": "string"
+                },
+                "regions": {
+                    "description": "endpoints of the model in other regions, requests fail over to them in the given order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/providers.RegionConfig"
+                    }
+                },
+                "schedule": {
+                    "description": "time windows the model serves traffic in, always by default",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.ScheduleConfig"
+                        }
+                    ]
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "providers.ParamsConfig": {
+            "type": "object",
+            "properties": {
+                "max_tokens": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "temperature": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "top_p": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
+        },
+        "providers.Pricing": {
+            "type": "object",
+            "properties": {
+                "cache_write_tokens": {
+                    "description": "prompt tokens written to the prompt cache, defaults to the prompt token price",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "cached_prompt_tokens": {
+                    "description": "prompt tokens read from the prompt cache, defaults to the prompt token price",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "prompt_tokens": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "response_tokens": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "providers.ProbeConfig": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "description": "how often unhealthy models are probed",
+                    "type": "string"
+                },
+                "method": {
+                    "enum": [
+                        "chat",
+                        "tokenize",
+                        "models"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.ProbeMethod"
+                        }
+                    ]
+                },
+                "prompt": {
+                    "description": "the message of chat \u0026 tokenize probes",
+                    "type": "string"
+                },
+                "timeout": {
+                    "type": "string"
+                }
+            }
+        },
+        "providers.ProbeMethod": {
+            "type": "string",
+            "enum": [
+                "chat",
+                "tokenize",
+                "models"
+            ],
+            "x-enum-comments": {
+                "ChatProbe": "a tiny chat request",
+                "ModelsProbe": "a model list request, if the provider can list models",
+                "TokenizeProbe": "a token count request, if the provider has a tokenizer endpoint"
+            },
+            "x-enum-varnames": [
+                "ChatProbe",
+                "TokenizeProbe",
+                "ModelsProbe"
+            ]
+        },
+        "providers.RateLimitConfig": {
+            "type": "object",
+            "properties": {
+                "headroom": {
+                    "description": "the share of limits after which the model is deprioritized",
+                    "type": "number",
+                    "maximum": 1
+                },
+                "redis": {
+                    "description": "shares the usage between gateway replicas, so they respect the limits together",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/cache.RedisConfig"
+                        }
+                    ]
+                },
+                "requests_per_minute": {
+                    "description": "zero means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "tokens_per_minute": {
+                    "description": "zero means unlimited",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "providers.RegionConfig": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "anthropic": {
+                    "$ref": "#/definitions/anthropic.Config"
+                },
+                "azureopenai": {
+                    "$ref": "#/definitions/azureopenai.Config"
+                },
+                "bedrock": {
+                    "$ref": "#/definitions/bedrock.Config"
+                },
+                "cohere": {
+                    "$ref": "#/definitions/cohere.Config"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "octoml": {
+                    "$ref": "#/definitions/octoml.Config"
+                },
+                "ollama": {
+                    "$ref": "#/definitions/ollama.Config"
+                },
+                "openai": {
+                    "description": "Add other providers like",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/openai.Config"
+                        }
+                    ]
+                }
+            }
+        },
+        "providers.ScheduleConfig": {
+            "type": "object",
+            "required": [
+                "windows"
+            ],
+            "properties": {
+                "timezone": {
+                    "description": "IANA timezone name the windows are defined in, UTC by default",
+                    "type": "string"
+                },
+                "windows": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/providers.TimeWindowConfig"
+                    }
+                }
+            }
+        },
+        "providers.SpendBudgetConfig": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "zero means unlimited",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "monthly": {
+                    "description": "zero means unlimited",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "providers.TimeWindowConfig": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "days": {
+                    "description": "days the window starts on, every day by default",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "description": "the window start (HH:MM)",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "the window end (HH:MM), windows ending earlier than they start span midnight",
+                    "type": "string"
+                }
+            }
+        },
+        "retry.ExpRetryConfig": {
+            "type": "object",
+            "properties": {
+                "base_multiplier": {
+                    "type": "integer"
+                },
+                "max_delay": {
+                    "type": "integer"
+                },
+                "max_retries": {
+                    "type": "integer"
+                },
+                "min_delay": {
+                    "type": "integer"
+                }
+            }
+        },
+        "routers.ClassificationConfig": {
+            "type": "object",
+            "required": [
+                "labels",
+                "model"
+            ],
+            "properties": {
+                "cache_ttl": {
+                    "description": "how long labels of identical requests are reused",
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "label -\u003e IDs of router models that serve requests with the label",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "max_cache_entries": {
+                    "description": "the classification cache size, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "model": {
+                    "description": "the model that labels requests (e.g. a small hosted or local model)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.LangModelConfig"
+                        }
+                    ]
+                },
+                "prompt": {
+                    "description": "instructions for the model, label names are listed by default",
+                    "type": "string"
+                }
+            }
+        },
+        "routers.EmbedCacheConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_bytes": {
+                    "description": "the in-memory cache memory limit, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "max_entries": {
+                    "description": "the in-memory cache size, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "redis": {
+                    "$ref": "#/definitions/cache.RedisConfig"
+                },
+                "sqlite": {
+                    "$ref": "#/definitions/cache.SQLiteConfig"
+                },
+                "ttl": {
+                    "type": "string"
+                }
+            }
+        },
+        "routers.FallbackConfig": {
+            "type": "object",
+            "properties": {
+                "on_content_filter": {
+                    "description": "try the next model when the provider content filter blocks the request or response",
+                    "type": "boolean"
+                },
+                "on_empty_response": {
+                    "description": "try the next model when the provider returns an empty response",
+                    "type": "boolean"
+                }
+            }
+        },
+        "routers.FallbackStormConfig": {
+            "type": "object",
+            "required": [
+                "threshold",
+                "window"
+            ],
+            "properties": {
+                "threshold": {
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "integer"
+                }
+            }
+        },
+        "routers.GuardrailConfig": {
+            "type": "object",
+            "required": [
+                "moderation"
+            ],
+            "properties": {
+                "fail_open": {
+                    "description": "let requests through when moderation is not available",
+                    "type": "boolean"
+                },
+                "moderation": {
+                    "description": "ID of the moderation router to check chat messages with",
+                    "type": "string"
+                }
+            }
+        },
+        "routers.HedgingConfig": {
+            "type": "object",
+            "properties": {
+                "max_delay": {
+                    "description": "the longest time to wait for the primary model, used until its latency percentiles are known",
+                    "type": "string"
+                },
+                "min_delay": {
+                    "description": "the shortest time to wait for the primary model before hedging",
+                    "type": "string"
+                },
+                "percentile": {
+                    "description": "the hedge is sent once the primary model is slower than this percentile of its recent chat latencies",
+                    "type": "number"
+                }
+            }
+        },
+        "routers.IdempotencyConfig": {
+            "type": "object",
+            "properties": {
+                "max_bytes": {
+                    "description": "the in-memory store memory limit, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "max_entries": {
+                    "description": "the in-memory store size, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "redis": {
+                    "$ref": "#/definitions/cache.RedisConfig"
+                },
+                "sqlite": {
+                    "$ref": "#/definitions/cache.SQLiteConfig"
+                },
+                "ttl": {
+                    "type": "string"
+                }
+            }
+        },
+        "routers.LangRouterConfig": {
+            "type": "object",
+            "required": [
+                "enabled",
+                "retry",
+                "routers",
+                "strategy"
+            ],
+            "properties": {
+                "ab_test": {
+                    "description": "variants of the A/B testing strategy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.ABTestConfig"
+                        }
+                    ]
+                },
+                "bandit": {
+                    "description": "settings of the bandit routing strategy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.BanditConfig"
+                        }
+                    ]
+                },
+                "budget": {
+                    "description": "the router rejects requests once it has spent the daily or monthly budget",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.SpendBudgetConfig"
+                        }
+                    ]
+                },
+                "canary": {
+                    "description": "settings of the canary rollout strategy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.CanaryConfig"
+                        }
+                    ]
+                },
+                "classification": {
+                    "description": "routing of chat requests to model pools by their task type",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.ClassificationConfig"
+                        }
+                    ]
+                },
+                "cost_aware": {
+                    "description": "settings of the cost-aware routing strategy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.CostAwareConfig"
+                        }
+                    ]
+                },
+                "embed_cache": {
+                    "description": "caching of embeddings by the input content",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.EmbedCacheConfig"
+                        }
+                    ]
+                },
+                "enabled": {
+                    "description": "Is router enabled?",
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "where router events (e.g. exhausted budgets) are delivered",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/events.DeliveryConfig"
+                        }
+                    ]
+                },
+                "fallback": {
+                    "description": "which response failures are retried on the next model (all by default)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.FallbackConfig"
+                        }
+                    ]
+                },
+                "fallback_storm": {
+                    "description": "detection of bursts of requests falling back to the next models",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.FallbackStormConfig"
+                        }
+                    ]
+                },
+                "guardrail": {
+                    "description": "moderation of chat requests before they reach models",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.GuardrailConfig"
+                        }
+                    ]
+                },
+                "hedging": {
+                    "description": "hedging of slow chat requests with the next best model",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.HedgingConfig"
+                        }
+                    ]
+                },
+                "idempotency": {
+                    "description": "chat requests retried with the same Idempotency-Key header are served the first response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.IdempotencyConfig"
+                        }
+                    ]
+                },
+                "least_latency": {
+                    "description": "settings of the least latency routing strategy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.LeastLatencyConfig"
+                        }
+                    ]
+                },
+                "models": {
+                    "description": "the list of models that could handle requests",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/providers.LangModelConfig"
+                    }
+                },
+                "nested_routers": {
+                    "description": "language routers to route requests between instead of models",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/routers.NestedRouterConfig"
+                    }
+                },
+                "pinning": {
+                    "description": "pinning of requests to specific models via the request field or the X-Glide-Model header",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.PinningConfig"
+                        }
+                    ]
+                },
+                "queue": {
+                    "description": "chat requests wait for models at their concurrency limits instead of failing right away",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.QueueConfig"
+                        }
+                    ]
+                },
+                "response_cache": {
+                    "description": "caching of chat responses by the request messages",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.ResponseCacheConfig"
+                        }
+                    ]
+                },
+                "retry": {
+                    "description": "retry when no healthy model is available to router",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/retry.ExpRetryConfig"
+                        }
+                    ]
+                },
+                "routers": {
+                    "description": "Unique router ID",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "routing of chat requests to model pools by request attributes",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.RulesConfig"
+                        }
+                    ]
+                },
+                "shadow": {
+                    "description": "mirroring of chat requests to a model under evaluation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.ShadowConfig"
+                        }
+                    ]
+                },
+                "slow_request_threshold": {
+                    "description": "chat requests taking longer are logged with their routing trace",
+                    "type": "integer"
+                },
+                "strategy": {
+                    "description": "strategy on picking the next model to serve the request",
+                    "type": "string"
+                },
+                "stream_resumption": {
+                    "description": "streaming chats failed midway are continued by the next model",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.StreamResumptionConfig"
+                        }
+                    ]
+                },
+                "webhooks": {
+                    "description": "more URLs router events are delivered to (e.g. a paging service subscribed to \"model.*\")",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/events.DeliveryConfig"
+                    }
+                }
+            }
+        },
+        "routers.NestedRouterConfig": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "description": "the referred language router ID",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "the member weight for weighted strategies",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "routers.PinningConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "let clients pin models, pins are ignored otherwise",
+                    "type": "boolean"
+                },
+                "fallback": {
+                    "description": "apply the router strategy when the pinned model cannot serve the request instead of failing it",
+                    "type": "boolean"
+                }
+            }
+        },
+        "routers.QueueConfig": {
+            "type": "object",
+            "properties": {
+                "max_depth": {
+                    "description": "the most requests waiting at the same time",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "max_wait": {
+                    "description": "the longest time a request waits for a model",
+                    "type": "string"
+                }
+            }
+        },
+        "routers.ResponseCacheConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_bytes": {
+                    "description": "the in-memory cache memory limit, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "max_entries": {
+                    "description": "the in-memory cache size, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "mode": {
+                    "enum": [
+                        "exact",
+                        "semantic"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.ResponseCacheMode"
+                        }
+                    ]
+                },
+                "redis": {
+                    "$ref": "#/definitions/cache.RedisConfig"
+                },
+                "semantic": {
+                    "description": "how similar prompts are found in the semantic mode",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.SemanticCacheConfig"
+                        }
+                    ]
+                },
+                "sqlite": {
+                    "$ref": "#/definitions/cache.SQLiteConfig"
+                },
+                "stream_replay": {
+                    "description": "how cached responses are streamed to streaming chat clients",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routers.StreamReplayConfig"
+                        }
+                    ]
+                },
+                "ttl": {
+                    "type": "string"
+                }
+            }
+        },
+        "routers.ResponseCacheMode": {
+            "type": "string",
+            "enum": [
+                "exact",
+                "semantic"
+            ],
+            "x-enum-comments": {
+                "ExactCacheMode": "requests with the same normalized messages",
+                "SemanticCacheMode": "also requests with prompts similar enough by their embeddings"
+            },
+            "x-enum-varnames": [
+                "ExactCacheMode",
+                "SemanticCacheMode"
+            ]
+        },
+        "routers.RuleConfig": {
+            "type": "object",
+            "required": [
+                "models",
+                "name"
+            ],
+            "properties": {
+                "headers": {
+                    "description": "header name -\u003e value, \"*\" matches any value",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "max_tokens": {
+                    "description": "the largest estimated number of prompt tokens, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_tokens": {
+                    "description": "the least estimated number of prompt tokens",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "models": {
+                    "description": "IDs of router models that serve matched requests",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenants": {
+                    "description": "tenant IDs passed in the tenant header",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "routers.RulesConfig": {
+            "type": "object",
+            "required": [
+                "rules",
+                "tenant_header"
+            ],
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/routers.RuleConfig"
+                    }
+                },
+                "tenant_header": {
+                    "description": "the header that identifies the client tenant",
+                    "type": "string"
+                }
+            }
+        },
+        "routers.SemanticCacheConfig": {
+            "type": "object",
+            "required": [
+                "model"
+            ],
+            "properties": {
+                "max_index_entries": {
+                    "description": "the vector index size, zero means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "model": {
+                    "description": "the model that embeds prompts (e.g. a small embedding model)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/providers.LangModelConfig"
+                        }
+                    ]
+                },
+                "threshold": {
+                    "description": "the least cosine similarity of prompts to share the response",
+                    "type": "number",
+                    "maximum": 1
+                }
+            }
+        },
+        "routers.ShadowConfig": {
+            "type": "object",
+            "required": [
+                "model"
+            ],
+            "properties": {
+                "fraction": {
+                    "description": "share of chat requests to mirror",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "model": {
+                    "$ref": "#/definitions/providers.LangModelConfig"
+                },
+                "timeout": {
+                    "description": "how long mirrored requests may take",
+                    "type": "string"
+                }
+            }
+        },
+        "routers.StreamReplayConfig": {
+            "type": "object",
+            "properties": {
+                "chunk_size": {
+                    "description": "characters per chunk, zero sends the whole response in one chunk",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "interval": {
+                    "description": "the pause between chunks, zero sends chunks all at once",
+                    "type": "string"
+                }
+            }
+        },
+        "routers.StreamResumptionConfig": {
+            "type": "object",
+            "required": [
+                "prompt"
+            ],
+            "properties": {
+                "max_resumptions": {
+                    "description": "how many times one stream may switch models",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "prompt": {
+                    "description": "asks the next model to continue the streamed response",
+                    "type": "string"
+                }
+            }
+        },
+        "routing.ABTestConfig": {
+            "type": "object",
+            "required": [
+                "experiment",
+                "variants"
+            ],
+            "properties": {
+                "experiment": {
+                    "description": "the experiment identifier stamped into responses \u0026 metrics",
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/routing.ABVariantConfig"
+                    }
+                }
+            }
+        },
+        "routing.ABVariantConfig": {
+            "type": "object",
+            "required": [
+                "model",
+                "name",
+                "percentage"
+            ],
+            "properties": {
+                "model": {
+                    "description": "ID of the router model that serves the variant",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "description": "share of traffic routed to the variant",
+                    "type": "number",
+                    "maximum": 100
+                }
+            }
+        },
+        "routing.BanditAlgorithm": {
+            "type": "string",
+            "enum": [
+                "epsilon_greedy",
+                "ucb1"
+            ],
+            "x-enum-varnames": [
+                "EpsilonGreedy",
+                "UCB1"
+            ]
+        },
+        "routing.BanditConfig": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "enum": [
+                        "epsilon_greedy",
+                        "ucb1"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.BanditAlgorithm"
+                        }
+                    ]
+                },
+                "decay": {
+                    "description": "weight of new rewards, so the bandit adapts to changing model performance",
+                    "type": "number",
+                    "maximum": 1
+                },
+                "epsilon": {
+                    "description": "share of requests routed to a random model (epsilon_greedy)",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "exploration": {
+                    "description": "weight of the confidence bound (ucb1)",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "reward": {
+                    "enum": [
+                        "latency",
+                        "cost",
+                        "feedback"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.BanditReward"
+                        }
+                    ]
+                },
+                "warmup_samples": {
+                    "description": "the number of rewards averaged to init the model estimate before exploitation starts",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "routing.BanditReward": {
+            "type": "string",
+            "enum": [
+                "latency",
+                "cost",
+                "feedback"
+            ],
+            "x-enum-comments": {
+                "CostReward": "the cheapest requests (requires model pricing)",
+                "FeedbackReward": "the highest feedback score reported by clients",
+                "LatencyReward": "the lowest latency per token"
+            },
+            "x-enum-varnames": [
+                "LatencyReward",
+                "CostReward",
+                "FeedbackReward"
+            ]
+        },
+        "routing.CanaryConfig": {
+            "type": "object",
+            "required": [
+                "baseline",
+                "canary",
+                "window"
+            ],
+            "properties": {
+                "baseline": {
+                    "description": "ID of the model currently serving traffic",
+                    "type": "string"
+                },
+                "canary": {
+                    "description": "ID of the new model",
+                    "type": "string"
+                },
+                "initial_share": {
+                    "description": "percentage of traffic the canary starts with",
+                    "type": "number",
+                    "maximum": 100
+                },
+                "max_error_rate_delta": {
+                    "description": "how much the canary error rate may exceed the baseline one",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "max_latency_ratio": {
+                    "description": "how many times the canary latency may exceed the baseline one",
+                    "type": "number",
+                    "minimum": 1
+                },
+                "min_requests": {
+                    "description": "the window is extended until the canary serves at least this many requests",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "promotion_step": {
+                    "description": "percentage of traffic added to the canary share on each promotion",
+                    "type": "number",
+                    "maximum": 100
+                },
+                "window": {
+                    "description": "how long the canary is compared to the baseline before each promotion",
+                    "type": "integer"
+                }
+            }
+        },
+        "routing.CostAwareConfig": {
+            "type": "object",
+            "required": [
+                "latency_slo"
+            ],
+            "properties": {
+                "latency_slo": {
+                    "description": "LatencySLO is the highest average latency the model may have to be preferred by its cost.\nLatency is measured per token for chat \u0026 embedding requests and per chunk for streaming chat requests",
+                    "type": "integer"
+                }
+            }
+        },
+        "routing.LatencySignal": {
+            "type": "string",
+            "enum": [
+                "latency",
+                "ttft"
+            ],
+            "x-enum-varnames": [
+                "ChunkLatencySignal",
+                "TTFTSignal"
+            ]
+        },
+        "routing.LeastLatencyConfig": {
+            "type": "object",
+            "properties": {
+                "error_penalty": {
+                    "description": "ErrorPenalty inflates model latencies by their error rates (latency * (1 + penalty * error rate)),\nso a fast but flaky model doesn't monopolize traffic. Zero disables the penalty",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "percentile": {
+                    "description": "Percentile of the model latency to route on (e.g. 95 or 99), so models with long tail latency are avoided.\nThe mean latency is used when zero",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "signal": {
+                    "description": "Signal is the latency streaming chats are routed on. Other actions are always routed on their regular latencies",
+                    "enum": [
+                        "latency",
+                        "ttft"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.LatencySignal"
+                        }
+                    ]
+                },
+                "warmup_pattern": {
+                    "description": "WarmupPattern defines how warm-up requests are spread over cold models",
+                    "enum": [
+                        "round_robin",
+                        "sequential"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/routing.WarmupPattern"
+                        }
+                    ]
+                },
+                "warmup_samples": {
+                    "description": "WarmupSamples is the number of latency samples each model should collect before the routing compares\nits latency with other models. Zero relies on warm-up samples of the model latency config",
+                    "type": "integer"
+                },
+                "warmup_share": {
+                    "description": "WarmupShare is the share of traffic (0..1] routed to cold models while some models are already warmed up,\nso the fastest known model keeps serving the rest. All traffic warms up models until any of them is warmed up",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
+        },
+        "routing.WarmupPattern": {
+            "type": "string",
+            "enum": [
+                "round_robin",
+                "sequential"
+            ],
+            "x-enum-varnames": [
+                "WarmupRoundRobin",
+                "WarmupSequential"
+            ]
+        },
+        "schemas.AttemptTiming": {
+            "type": "object",
+            "properties": {
+                "connReused": {
+                    "description": "no DNS lookup, connect \u0026 TLS handshake were needed",
+                    "type": "boolean"
+                },
+                "connectMs": {
+                    "type": "number"
+                },
+                "dnsMs": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "modelId": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "tlsMs": {
+                    "type": "number"
+                },
+                "totalMs": {
+                    "type": "number"
+                },
+                "ttfbMs": {
+                    "description": "from the call start to the first response byte",
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.CacheControl": {
+            "type": "object",
+            "properties": {
+                "maxAge": {
+                    "description": "the oldest cached response to accept (in seconds)",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "noCache": {
+                    "description": "skip cached responses, but cache the fresh one",
+                    "type": "boolean"
+                },
+                "noStore": {
+                    "description": "don't cache the response",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.CachePurge": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.CacheStats": {
+            "type": "object",
+            "properties": {
+                "bypasses": {
+                    "description": "requests that skipped the cache per their cache control",
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "hitRate": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "memory": {
+                    "description": "set when entries are kept in memory",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MemoryStoreStats"
+                        }
+                    ]
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "semanticHits": {
+                    "description": "hits served by similar prompts",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.Capability": {
+            "type": "string",
+            "enum": [
+                "tools",
+                "vision",
+                "json_mode"
+            ],
+            "x-enum-varnames": [
+                "CapabilityTools",
+                "CapabilityVision",
+                "CapabilityJSONMode"
+            ]
+        },
+        "schemas.ChatBatchRequest": {
+            "type": "object",
+            "required": [
+                "requests"
+            ],
+            "properties": {
+                "parallelism": {
+                    "description": "Parallelism limits the number of requests served at the same time (capped by the gateway config)",
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.ChatRequest"
+                    }
+                }
+            }
+        },
+        "schemas.ChatBatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ChatBatchResult"
+                    }
+                },
+                "router": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.ChatBatchResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "response": {
+                    "$ref": "#/definitions/schemas.ChatResponse"
+                }
+            }
+        },
+        "schemas.ChatDryRun": {
+            "type": "object",
+            "properties": {
+                "estimatedCost": {
+                    "description": "the prompt cost in USD (if the model pricing is known), response tokens are not known upfront",
+                    "type": "number"
+                },
+                "label": {
+                    "description": "the request label if the router classifies requests",
+                    "type": "string"
+                },
+                "promptTokens": {
+                    "type": "integer"
+                },
+                "tokensEstimated": {
+                    "type": "boolean"
+                },
+                "transformations": {
+                    "description": "changes applied to the request before it's sent to the model",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schemas.ChatFeedback": {
+            "type": "object",
+            "required": [
+                "model_id"
+            ],
+            "properties": {
+                "model_id": {
+                    "description": "ModelID is the router model that served the chat request (the modelId field of the chat response)",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "from 0 (the worst) to 1 (the best)",
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
+        },
+        "schemas.ChatMessage": {
+            "type": "object",
+            "required": [
+                "content",
+                "role"
+            ],
+            "properties": {
+                "cacheBreakpoint": {
+                    "description": "Marks the end of the prompt prefix the provider should cache, so the following requests with the same prefix are cheaper \u0026 faster.\nApplies to providers with explicit prompt caching (e.g. Anthropic), others cache prompts automatically or not at all",
+                    "type": "boolean"
+                },
+                "content": {
+                    "description": "The content of the message.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "The name of the author of this message. May contain a-z, A-Z, 0-9, and underscores,\nwith a maximum length of 64 characters.",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "The role of the author of this message. One of system, user, or assistant.",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.ChatRequest": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "cache": {
+                    "description": "constrains serving the request from the response cache",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.CacheControl"
+                        }
+                    ]
+                },
+                "dryRun": {
+                    "description": "return the routing decision without calling the model",
+                    "type": "boolean"
+                },
+                "message": {
+                    "$ref": "#/definitions/schemas.ChatMessage"
+                },
+                "messageHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ChatMessage"
+                    }
+                },
+                "modelId": {
+                    "description": "pins the router model to serve the request",
+                    "type": "string"
+                },
+                "override": {
+                    "$ref": "#/definitions/schemas.OverrideChatRequest"
+                },
+                "requires": {
+                    "description": "capabilities the serving model must support",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Capability"
+                    }
+                },
+                "sessionId": {
+                    "description": "routes requests of the same conversation to the same model (the sticky strategy)",
+                    "type": "string"
+                },
+                "timing": {
+                    "description": "break the request latency down in the response",
+                    "type": "boolean"
+                },
+                "trace": {
+                    "description": "describe the routing decision in the response",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.ChatResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean"
+                },
+                "cost": {
+                    "description": "the request cost in USD, set when the model pricing is configured",
+                    "type": "number"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "deprecation": {
+                    "description": "set when the model that served the request is deprecated",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.ModelDeprecation"
+                        }
+                    ]
+                },
+                "dryRun": {
+                    "description": "set instead of the model response for dry run requests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.ChatDryRun"
+                        }
+                    ]
+                },
+                "experiment": {
+                    "description": "set when the request is a part of the A/B test",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Experiment"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "modelResponse": {
+                    "$ref": "#/definitions/schemas.ModelResponse"
+                },
+                "model_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "replayed": {
+                    "description": "served again for a retried request with the same idempotency key",
+                    "type": "boolean"
+                },
+                "router": {
+                    "type": "string"
+                },
+                "routing": {
+                    "description": "set when the routing trace is requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.RoutingTrace"
+                        }
+                    ]
+                },
+                "timing": {
+                    "description": "set when the latency breakdown is requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.ResponseTiming"
+                        }
+                    ]
+                }
+            }
+        },
+        "schemas.ConfigReload": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "IDs of language routers that were added",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "reloadedAt": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "description": "IDs of language routers that were removed or disabled",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "restartRequired": {
+                    "description": "changed config sections that can't be applied without restart",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "description": "IDs of language routers that were rebuilt",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schemas.EmbedModelResponse": {
+            "type": "object",
+            "properties": {
+                "embeddings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Embedding"
+                    }
+                },
+                "tokenCount": {
+                    "$ref": "#/definitions/schemas.TokenUsage"
+                }
+            }
+        },
+        "schemas.EmbedRequest": {
+            "type": "object",
+            "required": [
+                "input"
+            ],
+            "properties": {
+                "input": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schemas.EmbedResponse": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "description": "the request cost in USD, set when the model pricing is configured",
+                    "type": "number"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "deprecation": {
+                    "description": "set when the model that served the request is deprecated",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.ModelDeprecation"
+                        }
+                    ]
+                },
+                "id": {
                     "type": "string"
                 },
-                "chatEndpoint": {
+                "model": {
                     "type": "string"
                 },
-                "defaultParams": {
-                    "$ref": "#/definitions/anthropic.Params"
+                "modelResponse": {
+                    "$ref": "#/definitions/schemas.EmbedModelResponse"
                 },
-                "model": {
+                "model_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "router": {
                     "type": "string"
                 }
             }
         },
-        "anthropic.Params": {
+        "schemas.Embedding": {
             "type": "object",
             "properties": {
-                "max_tokens": {
+                "index": {
+                    "description": "The position of the input the embedding was generated for",
                     "type": "integer"
                 },
-                "metadata": {
-                    "type": "string"
-                },
-                "stop": {
+                "vector": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "number"
                     }
-                },
-                "system": {
-                    "type": "string"
-                },
-                "temperature": {
-                    "type": "number"
-                },
-                "top_k": {
-                    "type": "integer"
-                },
-                "top_p": {
-                    "type": "number"
                 }
             }
         },
-        "azureopenai.Config": {
+        "schemas.Experiment": {
             "type": "object",
-            "required": [
-                "apiVersion",
-                "baseUrl",
-                "model"
-            ],
             "properties": {
-                "apiVersion": {
-                    "description": "The API version to use for this operation. This follows the YYYY-MM-DD format (e.g 2023-05-15)",
+                "id": {
                     "type": "string"
                 },
-                "baseUrl": {
-                    "description": "The name of your Azure OpenAI Resource (e.g https://glide-test.openai.azure.com/)",
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.Image": {
+            "type": "object",
+            "properties": {
+                "b64Json": {
                     "type": "string"
                 },
-                "chatEndpoint": {
+                "revisedPrompt": {
                     "type": "string"
                 },
-                "defaultParams": {
-                    "$ref": "#/definitions/azureopenai.Params"
+                "seed": {
+                    "type": "integer"
                 },
-                "model": {
-                    "description": "This is your deployment name. You're required to first deploy a model before you can make calls (e.g. glide-gpt-35)",
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "azureopenai.Params": {
+        "schemas.ImageGenerateRequest": {
             "type": "object",
+            "required": [
+                "prompt"
+            ],
             "properties": {
-                "frequency_penalty": {
-                    "type": "integer"
-                },
-                "logit_bias": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "number"
-                    }
-                },
-                "max_tokens": {
-                    "type": "integer"
-                },
                 "n": {
+                    "description": "The number of images to generate",
                     "type": "integer"
                 },
-                "presence_penalty": {
-                    "type": "integer"
-                },
-                "response_format": {
-                    "description": "TODO: should this be a part of the chat request API?"
-                },
-                "seed": {
-                    "type": "integer"
-                },
-                "stop": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "temperature": {
-                    "type": "number"
-                },
-                "tool_choice": {},
-                "tools": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "top_p": {
-                    "type": "number"
+                "prompt": {
+                    "type": "string"
                 },
-                "user": {
+                "size": {
+                    "description": "The size of generated images in the \"{width}x{height}\" format (e.g. 1024x1024)",
                     "type": "string"
                 }
             }
         },
-        "bedrock.Config": {
+        "schemas.ImageGenerateResponse": {
             "type": "object",
-            "required": [
-                "awsRegion",
-                "baseUrl",
-                "chatEndpoint",
-                "model"
-            ],
             "properties": {
-                "awsRegion": {
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "baseUrl": {
+                "model": {
                     "type": "string"
                 },
-                "chatEndpoint": {
+                "modelResponse": {
+                    "$ref": "#/definitions/schemas.ImageModelResponse"
+                },
+                "model_id": {
                     "type": "string"
                 },
-                "defaultParams": {
-                    "$ref": "#/definitions/bedrock.Params"
+                "provider": {
+                    "type": "string"
                 },
-                "model": {
+                "router": {
                     "type": "string"
                 }
             }
         },
-        "bedrock.Params": {
+        "schemas.ImageModelResponse": {
             "type": "object",
             "properties": {
-                "max_tokens": {
-                    "type": "integer"
-                },
-                "stop": {
+                "images": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/schemas.Image"
                     }
+                }
+            }
+        },
+        "schemas.MemoryStoreStats": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer"
                 },
-                "temperature": {
-                    "type": "number"
+                "entries": {
+                    "type": "integer"
                 },
-                "top_p": {
-                    "type": "number"
+                "evictions": {
+                    "type": "integer"
                 }
             }
         },
-        "clients.ClientConfig": {
+        "schemas.ModelCapabilities": {
             "type": "object",
             "properties": {
-                "timeout": {
-                    "type": "string"
+                "chat": {
+                    "type": "boolean"
+                },
+                "chatStream": {
+                    "type": "boolean"
+                },
+                "embed": {
+                    "type": "boolean"
+                },
+                "jsonMode": {
+                    "type": "boolean"
+                },
+                "tools": {
+                    "type": "boolean"
+                },
+                "vision": {
+                    "type": "boolean"
                 }
             }
         },
-        "cohere.Config": {
+        "schemas.ModelDeprecation": {
             "type": "object",
-            "required": [
-                "baseUrl",
-                "chatEndpoint",
-                "model"
-            ],
             "properties": {
-                "baseUrl": {
+                "message": {
                     "type": "string"
                 },
-                "chatEndpoint": {
+                "modelId": {
                     "type": "string"
                 },
-                "defaultParams": {
-                    "$ref": "#/definitions/cohere.Params"
+                "replacement": {
+                    "description": "the model to migrate to",
+                    "type": "string"
                 },
-                "model": {
-                    "description": "https://docs.cohere.com/docs/models#command",
+                "sunset": {
+                    "description": "the date (YYYY-MM-DD) the model stops being served",
                     "type": "string"
                 }
             }
         },
-        "cohere.Params": {
+        "schemas.ModelHealth": {
             "type": "object",
-            "required": [
-                "temperature"
-            ],
             "properties": {
-                "connectors": {
+                "active": {
+                    "description": "false when the model is outside of its schedule",
+                    "type": "boolean"
+                },
+                "budgetExhausted": {
+                    "description": "the model has spent its daily or monthly budget",
+                    "type": "boolean"
+                },
+                "circuitState": {
+                    "description": "closed, open or half_open",
+                    "type": "string"
+                },
+                "concurrencyLimit": {
+                    "description": "how many requests the model may serve at the same time at the moment, zero means no limit",
+                    "type": "integer"
+                },
+                "errorBudgetLeft": {
+                    "type": "integer"
+                },
+                "errorRate": {
+                    "description": "the share of requests failed over the last minute",
+                    "type": "number"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "inFlight": {
+                    "description": "the number of requests the model is serving at the moment",
+                    "type": "integer"
+                },
+                "lastProbe": {
+                    "description": "the last active check of the model while it was unhealthy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.ProbeStatus"
+                        }
+                    ]
+                },
+                "latency": {
+                    "$ref": "#/definitions/schemas.ModelLatency"
+                },
+                "modelId": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "rateLimitHits": {
+                    "description": "how many times the provider rejected requests due to rate limits",
+                    "type": "integer"
+                },
+                "rateLimitUsage": {
+                    "description": "the largest share of the advertised rate limits used over the last minute",
+                    "type": "number"
+                },
+                "rateLimitedUntil": {
+                    "type": "integer"
+                },
+                "regions": {
+                    "description": "the health of each regional endpoint of multi-region models",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/schemas.RegionHealth"
                     }
                 },
-                "frequency_penalty": {
-                    "type": "number",
-                    "maximum": 1,
-                    "minimum": 0
+                "saturated": {
+                    "description": "the model serves as many requests as its concurrency limit allows",
+                    "type": "boolean"
                 },
-                "k": {
-                    "type": "integer",
-                    "maximum": 500,
-                    "minimum": 0
+                "unauthorized": {
+                    "type": "boolean"
                 },
-                "max_tokens": {
+                "weight": {
                     "type": "integer"
+                }
+            }
+        },
+        "schemas.ModelLatency": {
+            "type": "object",
+            "properties": {
+                "chat": {
+                    "type": "number"
                 },
-                "p": {
-                    "type": "number",
-                    "maximum": 0.99,
-                    "minimum": 0.01
+                "chatStream": {
+                    "type": "number"
                 },
-                "preamble": {
-                    "type": "string"
+                "embed": {
+                    "type": "number"
                 },
-                "presence_penalty": {
-                    "type": "number",
-                    "maximum": 1,
-                    "minimum": 0
+                "tokensPerSecond": {
+                    "description": "how fast streaming chats are generated after the first token",
+                    "type": "number"
+                },
+                "ttft": {
+                    "description": "the time to the first token of streaming chats",
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.ModelMetadata": {
+            "type": "object",
+            "properties": {
+                "capabilities": {
+                    "$ref": "#/definitions/schemas.ModelCapabilities"
                 },
-                "prompt_truncation": {
+                "deprecation": {
+                    "$ref": "#/definitions/schemas.ModelDeprecation"
+                },
+                "error": {
                     "type": "string"
                 },
-                "search_queries_only": {
+                "fetchedAt": {
+                    "type": "integer"
+                },
+                "healthy": {
                     "type": "boolean"
                 },
-                "seed": {
-                    "type": "integer"
+                "modelId": {
+                    "type": "string"
                 },
-                "stop_sequences": {
+                "provider": {
+                    "type": "string"
+                },
+                "upstreamModels": {
                     "type": "array",
-                    "maxItems": 5,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/schemas.ProviderModel"
                     }
-                },
-                "temperature": {
-                    "type": "number"
                 }
             }
         },
-        "http.ErrorSchema": {
+        "schemas.ModelResponse": {
             "type": "object",
             "properties": {
                 "message": {
-                    "type": "string"
+                    "$ref": "#/definitions/schemas.ChatMessage"
+                },
+                "responseId": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "tokenCount": {
+                    "$ref": "#/definitions/schemas.TokenUsage"
                 }
             }
         },
-        "http.HealthSchema": {
+        "schemas.ModerationCategory": {
             "type": "object",
             "properties": {
-                "healthy": {
+                "flagged": {
                     "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
                 }
             }
         },
-        "http.RouterListSchema": {
+        "schemas.ModerationModelResponse": {
             "type": "object",
             "properties": {
-                "routers": {
+                "results": {
+                    "description": "Results are returned in the same order as the request inputs",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/routers.LangRouterConfig"
+                        "$ref": "#/definitions/schemas.ModerationResult"
                     }
                 }
             }
         },
-        "latency.Config": {
+        "schemas.ModerationRequest": {
             "type": "object",
+            "required": [
+                "input"
+            ],
             "properties": {
-                "decay": {
-                    "description": "Weight of new latency measurements",
-                    "type": "number"
-                },
-                "update_interval": {
-                    "description": "How often gateway should probe models with not the lowest response latency",
-                    "type": "string"
-                },
-                "warmup_samples": {
-                    "description": "The number of latency probes required to init moving average",
-                    "type": "integer"
+                "input": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "octoml.Config": {
+        "schemas.ModerationResponse": {
             "type": "object",
-            "required": [
-                "baseUrl",
-                "chatEndpoint",
-                "model"
-            ],
             "properties": {
-                "baseUrl": {
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "chatEndpoint": {
+                "model": {
                     "type": "string"
                 },
-                "defaultParams": {
-                    "$ref": "#/definitions/octoml.Params"
+                "modelResponse": {
+                    "$ref": "#/definitions/schemas.ModerationModelResponse"
                 },
-                "model": {
+                "model_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "router": {
                     "type": "string"
                 }
             }
         },
-        "octoml.Params": {
+        "schemas.ModerationResult": {
             "type": "object",
             "properties": {
-                "frequency_penalty": {
-                    "type": "integer"
-                },
-                "max_tokens": {
-                    "type": "integer"
-                },
-                "presence_penalty": {
-                    "type": "integer"
-                },
-                "stop": {
+                "categories": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/schemas.ModerationCategory"
                     }
                 },
-                "temperature": {
-                    "type": "number"
+                "flagged": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.NextModels": {
+            "type": "object",
+            "properties": {
+                "chat": {
+                    "type": "string"
                 },
-                "top_p": {
-                    "type": "number"
+                "chatStream": {
+                    "type": "string"
+                },
+                "embed": {
+                    "type": "string"
                 }
             }
         },
-        "ollama.Config": {
+        "schemas.OverrideChatRequest": {
             "type": "object",
             "required": [
-                "baseUrl",
-                "chatEndpoint",
-                "model"
+                "message",
+                "model_id"
             ],
             "properties": {
-                "baseUrl": {
-                    "type": "string"
+                "message": {
+                    "$ref": "#/definitions/schemas.ChatMessage"
                 },
-                "chatEndpoint": {
+                "model_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.ProbeStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
-                "defaultParams": {
-                    "$ref": "#/definitions/ollama.Params"
+                "probedAt": {
+                    "description": "unix time",
+                    "type": "integer"
                 },
-                "model": {
-                    "type": "string"
+                "succeeded": {
+                    "type": "boolean"
                 }
             }
         },
-        "ollama.Params": {
+        "schemas.ProviderModel": {
             "type": "object",
             "properties": {
-                "microstat": {
+                "contextLength": {
                     "type": "integer"
                 },
-                "microstat_eta": {
-                    "type": "number"
+                "created": {
+                    "type": "integer"
                 },
-                "microstat_tau": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "num_ctx": {
+                "ownedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.QueueStats": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "description": "the number of requests waiting at the moment",
                     "type": "integer"
                 },
-                "num_gpu": {
+                "maxDepth": {
                     "type": "integer"
                 },
-                "num_gqa": {
+                "waitedMs": {
+                    "description": "how long the rejected request has waited",
                     "type": "integer"
-                },
-                "num_predict": {
+                }
+            }
+        },
+        "schemas.RegionHealth": {
+            "type": "object",
+            "properties": {
+                "errorBudgetLeft": {
                     "type": "integer"
                 },
-                "num_thread": {
-                    "type": "integer"
+                "errorRate": {
+                    "type": "number"
                 },
-                "repeat_last_n": {
-                    "type": "integer"
+                "healthy": {
+                    "type": "boolean"
                 },
-                "seed": {
-                    "type": "integer"
+                "latency": {
+                    "$ref": "#/definitions/schemas.ModelLatency"
                 },
-                "stop": {
+                "name": {
+                    "type": "string"
+                },
+                "unauthorized": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.ResponseTiming": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "provider calls in order, the last one served the request",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/schemas.AttemptTiming"
                     }
                 },
-                "stream": {
-                    "type": "boolean"
-                },
-                "temperature": {
+                "gatewayMs": {
+                    "description": "spent outside of provider calls (e.g. routing, waiting for healthy models)",
                     "type": "number"
                 },
-                "tfs_z": {
+                "queueWaitMs": {
+                    "description": "waited in the router queue for models to free up",
                     "type": "number"
                 },
-                "top_k": {
+                "retries": {
+                    "description": "times the router waited for a healthy model to retry",
                     "type": "integer"
                 },
-                "top_p": {
+                "totalMs": {
                     "type": "number"
                 }
             }
         },
-        "openai.Config": {
+        "schemas.RouterCacheStats": {
             "type": "object",
-            "required": [
-                "baseUrl",
-                "chatEndpoint",
-                "model"
-            ],
             "properties": {
-                "baseUrl": {
-                    "type": "string"
-                },
-                "chatEndpoint": {
-                    "type": "string"
+                "embed": {
+                    "description": "set when the embedding cache is enabled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.CacheStats"
+                        }
+                    ]
                 },
-                "defaultParams": {
-                    "$ref": "#/definitions/openai.Params"
+                "response": {
+                    "description": "set when the response cache is enabled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.CacheStats"
+                        }
+                    ]
                 },
-                "model": {
+                "routerId": {
                     "type": "string"
                 }
             }
         },
-        "openai.Params": {
+        "schemas.RouterHealth": {
             "type": "object",
             "properties": {
-                "frequency_penalty": {
-                    "type": "integer"
+                "healthy": {
+                    "type": "boolean"
                 },
-                "logit_bias": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "number"
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ModelHealth"
                     }
                 },
-                "max_tokens": {
-                    "type": "integer"
-                },
-                "n": {
-                    "type": "integer"
-                },
-                "presence_penalty": {
-                    "type": "integer"
+                "nextModel": {
+                    "$ref": "#/definitions/schemas.NextModels"
                 },
-                "response_format": {
-                    "description": "TODO: should this be a part of the chat request API?"
+                "queue": {
+                    "description": "set when the router queues requests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.QueueStats"
+                        }
+                    ]
                 },
-                "seed": {
-                    "type": "integer"
+                "router": {
+                    "type": "string"
                 },
-                "stop": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "strategy": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.RoutingAttempt": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
                 },
-                "temperature": {
+                "latencyMs": {
                     "type": "number"
                 },
-                "tool_choice": {},
-                "tools": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "top_p": {
-                    "type": "number"
+                "modelId": {
+                    "type": "string"
                 },
-                "user": {
+                "reason": {
+                    "description": "why the model was picked",
                     "type": "string"
                 }
             }
         },
-        "providers.LangModelConfig": {
+        "schemas.RoutingCandidate": {
             "type": "object",
-            "required": [
-                "enabled",
-                "id"
-            ],
             "properties": {
-                "anthropic": {
-                    "$ref": "#/definitions/anthropic.Config"
-                },
-                "azureopenai": {
-                    "$ref": "#/definitions/azureopenai.Config"
-                },
-                "bedrock": {
-                    "$ref": "#/definitions/bedrock.Config"
+                "errorRate": {
+                    "type": "number"
                 },
-                "client": {
-                    "$ref": "#/definitions/clients.ClientConfig"
+                "healthy": {
+                    "type": "boolean"
                 },
-                "cohere": {
-                    "$ref": "#/definitions/cohere.Config"
+                "inFlight": {
+                    "type": "integer"
                 },
-                "enabled": {
-                    "description": "Is the model enabled?",
-                    "type": "boolean"
+                "latency": {
+                    "description": "the moving average of the chat latency per token (in nanoseconds), zero while warming up",
+                    "type": "number"
                 },
-                "error_budget": {
+                "modelId": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Model instance ID (unique in scope of the router)",
+                "skipped": {
+                    "description": "why the model cannot serve the request (e.g. context_window)",
                     "type": "string"
+                }
+            }
+        },
+        "schemas.RoutingTrace": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "models tried in order, the last one served the request",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RoutingAttempt"
+                    }
                 },
-                "latency": {
-                    "$ref": "#/definitions/latency.Config"
-                },
-                "octoml": {
-                    "$ref": "#/definitions/octoml.Config"
-                },
-                "ollama": {
-                    "$ref": "#/definitions/ollama.Config"
+                "candidates": {
+                    "description": "router models as they were when the request arrived",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RoutingCandidate"
+                    }
                 },
-                "openai": {
-                    "description": "Add other providers like",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/openai.Config"
-                        }
-                    ]
+                "pool": {
+                    "description": "the rule name, the request label or \"pinned\" if the request was routed to a subset of models",
+                    "type": "string"
                 },
-                "weight": {
-                    "type": "integer"
+                "strategy": {
+                    "type": "string"
                 }
             }
         },
-        "retry.ExpRetryConfig": {
+        "schemas.TokenUsage": {
             "type": "object",
             "properties": {
-                "base_multiplier": {
+                "cacheWriteTokens": {
+                    "description": "prompt tokens written to the provider prompt cache (counted in promptTokens too)",
                     "type": "integer"
                 },
-                "max_delay": {
+                "cachedPromptTokens": {
+                    "description": "prompt tokens read from the provider prompt cache (counted in promptTokens too)",
                     "type": "integer"
                 },
-                "max_retries": {
+                "promptTokens": {
                     "type": "integer"
                 },
-                "min_delay": {
+                "responseTokens": {
+                    "type": "integer"
+                },
+                "totalTokens": {
                     "type": "integer"
                 }
             }
         },
-        "routers.LangRouterConfig": {
+        "schemas.TokenizeRequest": {
             "type": "object",
             "required": [
-                "enabled",
-                "models",
-                "retry",
-                "routers",
-                "strategy"
+                "message"
             ],
             "properties": {
-                "enabled": {
-                    "description": "Is router enabled?",
-                    "type": "boolean"
+                "message": {
+                    "$ref": "#/definitions/schemas.ChatMessage"
                 },
-                "models": {
-                    "description": "the list of models that could handle requests",
+                "messageHistory": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/providers.LangModelConfig"
+                        "$ref": "#/definitions/schemas.ChatMessage"
                     }
                 },
-                "retry": {
-                    "description": "retry when no healthy model is available to router",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/retry.ExpRetryConfig"
-                        }
-                    ]
-                },
-                "routers": {
-                    "description": "Unique router ID",
-                    "type": "string"
-                },
-                "strategy": {
-                    "description": "strategy on picking the next model to serve the request",
+                "model_id": {
+                    "description": "ModelID is the router model to count tokens for. The first router model is used if it's not set",
                     "type": "string"
                 }
             }
         },
-        "schemas.ChatMessage": {
+        "schemas.TokenizeResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "role"
-            ],
             "properties": {
-                "content": {
-                    "description": "The content of the message.",
+                "contextLength": {
+                    "description": "ContextLength is the upstream model context window if the provider reports it in the model metadata",
+                    "type": "integer"
+                },
+                "estimated": {
+                    "description": "Estimated is set when the count is approximated rather than computed by the model tokenizer",
+                    "type": "boolean"
+                },
+                "model": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "The name of the author of this message. May contain a-z, A-Z, 0-9, and underscores,\nwith a maximum length of 64 characters.",
+                "model_id": {
                     "type": "string"
                 },
-                "role": {
-                    "description": "The role of the author of this message. One of system, user, or assistant.",
+                "provider": {
+                    "type": "string"
+                },
+                "router": {
                     "type": "string"
+                },
+                "tokens": {
+                    "type": "integer"
                 }
             }
         },
-        "schemas.ChatRequest": {
+        "schemas.TranscriptionModelResponse": {
             "type": "object",
-            "required": [
-                "message"
-            ],
             "properties": {
-                "message": {
-                    "$ref": "#/definitions/schemas.ChatMessage"
+                "duration": {
+                    "description": "in seconds",
+                    "type": "number"
                 },
-                "messageHistory": {
+                "language": {
+                    "type": "string"
+                },
+                "segments": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schemas.ChatMessage"
+                        "$ref": "#/definitions/schemas.TranscriptionSegment"
                     }
                 },
-                "override": {
-                    "$ref": "#/definitions/schemas.OverrideChatRequest"
+                "text": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.ChatResponse": {
+        "schemas.TranscriptionResponse": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean"
-                },
                 "created": {
                     "type": "integer"
                 },
@@ -872,7 +4877,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "modelResponse": {
-                    "$ref": "#/definitions/schemas.ModelResponse"
+                    "$ref": "#/definitions/schemas.TranscriptionModelResponse"
                 },
                 "model_id": {
                     "type": "string"
@@ -885,49 +4890,162 @@ const docTemplate = `{
                 }
             }
         },
-        "schemas.ModelResponse": {
+        "schemas.TranscriptionSegment": {
             "type": "object",
             "properties": {
-                "message": {
-                    "$ref": "#/definitions/schemas.ChatMessage"
+                "end": {
+                    "description": "in seconds",
+                    "type": "number"
                 },
-                "responseId": {
+                "id": {
+                    "type": "integer"
+                },
+                "start": {
+                    "description": "in seconds",
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "telemetry.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "e.g. \"config.reloaded\" or \"model.unhealthy\"",
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "the API key hash, the client IP or \"system\"",
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "target": {
+                    "description": "e.g. the router or the model ID",
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "telemetry.Capture": {
+            "type": "object",
+            "properties": {
+                "durationMs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "requestBody": {
+                    "type": "string"
+                },
+                "requestHeaders": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "tokenCount": {
-                    "$ref": "#/definitions/schemas.TokenUsage"
+                "requestId": {
+                    "type": "string"
+                },
+                "requestTruncated": {
+                    "type": "boolean"
+                },
+                "responseBody": {
+                    "type": "string"
+                },
+                "responseHeaders": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "responseTruncated": {
+                    "type": "boolean"
+                },
+                "statusCode": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.OverrideChatRequest": {
+        "telemetry.CaptureTargets": {
             "type": "object",
-            "required": [
-                "message",
-                "model_id"
-            ],
             "properties": {
+                "requestIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sampleRate": {
+                    "type": "number"
+                }
+            }
+        },
+        "telemetry.LogEntry": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "level": {
+                    "type": "string"
+                },
                 "message": {
-                    "$ref": "#/definitions/schemas.ChatMessage"
+                    "type": "string"
                 },
-                "model_id": {
+                "time": {
                     "type": "string"
                 }
             }
         },
-        "schemas.TokenUsage": {
+        "telemetry.UsageRecord": {
             "type": "object",
             "properties": {
+                "cachedPromptTokens": {
+                    "type": "integer"
+                },
+                "caller": {
+                    "description": "the API key hash or the client IP",
+                    "type": "string"
+                },
+                "cost": {
+                    "description": "in USD, requests to models without pricing cost nothing",
+                    "type": "number"
+                },
+                "modelId": {
+                    "type": "string"
+                },
                 "promptTokens": {
                     "type": "integer"
                 },
-                "responseTokens": {
+                "requests": {
                     "type": "integer"
                 },
-                "totalTokens": {
+                "responseTokens": {
                     "type": "integer"
+                },
+                "routerId": {
+                    "type": "string"
                 }
             }
         }
@@ -5,6 +5,9 @@ import "glide/pkg/api/http"
 // Config defines configuration for all API types we support (e.g. HTTP, gRPC)
 type Config struct {
 	HTTP *http.ServerConfig `yaml:"http" validate:"required"`
+	// Listeners are additional named HTTP listeners with their own middlewares & auth settings
+	// (e.g. the admin API & metrics bound to localhost while the public API is exposed on the main listener)
+	Listeners map[string]*http.ServerConfig `yaml:"listeners" validate:"dive"`
 }
 
 func DefaultConfig() *Config {
@@ -0,0 +1,621 @@
+package http
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+)
+
+// AdminConfig enables the admin API that manages routers at runtime
+type AdminConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	APIKey  fields.Secret `yaml:"api_key"` // admin requests must provide it via the X-API-Key or the bearer Authorization header
+}
+
+// ConfigExporter serializes the current effective gateway config in the given format (yaml or json)
+type ConfigExporter func(format string) ([]byte, error)
+
+// AdminAuthMiddleware rejects requests that don't provide the admin API key
+func AdminAuthMiddleware(apiKey fields.Secret) Handler {
+	return func(c *fiber.Ctx) error {
+		providedKey := APIKey(c.Get)
+
+		if providedKey == "" || subtle.ConstantTimeCompare([]byte(providedKey), []byte(apiKey)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusUnauthorized),
+				Message: "invalid or missing admin API key",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// AdminAuditMiddleware records successful admin changes (e.g. router updates or cache purges) in the audit log
+func AdminAuditMiddleware(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		// fiber reuses request buffers, so values that outlive the request are copied
+		method := strings.Clone(c.Method())
+
+		if method == fiber.MethodGet || method == fiber.MethodHead || c.Response().StatusCode() >= fiber.StatusBadRequest {
+			return err
+		}
+
+		details := map[string]interface{}{
+			"method": method,
+			"status": c.Response().StatusCode(),
+		}
+
+		if query := string(c.Request().URI().QueryString()); query != "" {
+			details["query"] = query
+		}
+
+		tel.Audit.Record(
+			callerID(APIKey(c.Get), c.IP()),
+			"admin."+strings.ToLower(method),
+			strings.Clone(c.Path()),
+			details,
+		)
+
+		return err
+	}
+}
+
+// AdminUpsertLangRouterHandler
+//
+//	@id				glide-admin-language-router-upsert
+//	@Summary		Create or Update Language Router
+//	@Description	Create a new language router or replace the existing one. The payload follows the router config file format (YAML or JSON)
+//	@tags			Admin
+//	@Param			router	path	string	true	"Router ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	routers.LangRouterConfig
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/language/{router} [PUT]
+func AdminUpsertLangRouterHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		routerConfig := routers.DefaultLangRouterConfig()
+
+		if err := yaml.Unmarshal(c.Body(), &routerConfig); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("unable to parse router config: %v", err),
+			})
+		}
+
+		routerID := c.Params("router")
+
+		if routerConfig.ID != "" && routerConfig.ID != routerID {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("router ID in the payload (%v) doesn't match the one in the path (%v)", routerConfig.ID, routerID),
+			})
+		}
+
+		routerConfig.ID = routerID
+
+		router, err := routerManager.UpsertLangRouter(&routerConfig)
+		if err != nil {
+			return adminError(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(router.Config)
+	}
+}
+
+// AdminDeleteLangRouterHandler
+//
+//	@id				glide-admin-language-router-delete
+//	@Summary		Delete Language Router
+//	@Description	Remove the language router. Requests that are already being served are finished
+//	@tags			Admin
+//	@Param			router	path	string	true	"Router ID"
+//	@Produce		json
+//	@Success		204
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/admin/language/{router} [DELETE]
+func AdminDeleteLangRouterHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if err := routerManager.DeleteLangRouter(c.Params("router")); err != nil {
+			return adminError(c, err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// AdminUpsertLangModelHandler
+//
+//	@id				glide-admin-language-model-upsert
+//	@Summary		Create or Update Language Model
+//	@Description	Add a new model to the language router pool or replace the existing one. The payload follows the model config file format (YAML or JSON)
+//	@tags			Admin
+//	@Param			router	path	string	true	"Router ID"
+//	@Param			model	path	string	true	"Model ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	routers.LangRouterConfig
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/admin/language/{router}/models/{model} [PUT]
+func AdminUpsertLangModelHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		modelConfig := providers.DefaultLangModelConfig()
+
+		if err := yaml.Unmarshal(c.Body(), modelConfig); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("unable to parse model config: %v", err),
+			})
+		}
+
+		modelID := c.Params("model")
+
+		if modelConfig.ID != "" && modelConfig.ID != modelID {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("model ID in the payload (%v) doesn't match the one in the path (%v)", modelConfig.ID, modelID),
+			})
+		}
+
+		modelConfig.ID = modelID
+
+		router, err := routerManager.UpsertLangModel(c.Params("router"), modelConfig)
+		if err != nil {
+			return adminError(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(router.Config)
+	}
+}
+
+// AdminDeleteLangModelHandler
+//
+//	@id				glide-admin-language-model-delete
+//	@Summary		Delete Language Model
+//	@Description	Remove the model from the language router pool
+//	@tags			Admin
+//	@Param			router	path	string	true	"Router ID"
+//	@Param			model	path	string	true	"Model ID"
+//	@Produce		json
+//	@Success		200	{object}	routers.LangRouterConfig
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/admin/language/{router}/models/{model} [DELETE]
+func AdminDeleteLangModelHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		router, err := routerManager.DeleteLangModel(c.Params("router"), c.Params("model"))
+		if err != nil {
+			return adminError(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(router.Config)
+	}
+}
+
+// AdminMetricsHandler
+//
+//	@id				glide-admin-metrics
+//	@Summary		Gateway Metrics
+//	@Description	Retrieve current values of the gateway counters (usage, cache hits, rejected requests, etc.)
+//	@tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	http.MetricsSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/metrics [GET]
+func AdminMetricsHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(MetricsSchema{Counters: tel.M().Counters()})
+	}
+}
+
+// AdminErrorsHandler
+//
+//	@id				glide-admin-errors
+//	@Summary		Recent Errors
+//	@Description	Retrieve the most recent warnings & errors logged by the gateway, the newest first
+//	@tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	http.ErrorLogSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/errors [GET]
+func AdminErrorsHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(ErrorLogSchema{Errors: tel.Errors.Entries()})
+	}
+}
+
+// AdminAuditHandler
+//
+//	@id				glide-admin-audit
+//	@Summary		Audit Log
+//	@Description	Retrieve the most recent config reloads, admin changes, model health transitions & exhausted budgets, the newest first
+//	@tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	http.AuditLogSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/audit [GET]
+func AdminAuditHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(AuditLogSchema{Entries: tel.Audit.Entries()})
+	}
+}
+
+// AdminUsageHandler
+//
+//	@id				glide-admin-usage
+//	@Summary		Usage & Costs
+//	@Description	Retrieve token usage & costs of served requests per router, model & caller since the gateway has started
+//	@tags			Admin
+//	@Param			router	query	string	false	"Router ID to report usage of"
+//	@Param			model	query	string	false	"Model ID to report usage of"
+//	@Param			caller	query	string	false	"Caller to report usage of (the API key hash or the client IP)"
+//	@Produce		json
+//	@Success		200	{object}	http.UsageSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/usage [GET]
+func AdminUsageHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		records := tel.Usage.Records(telemetry.UsageFilter{
+			RouterID: c.Query("router"),
+			ModelID:  c.Query("model"),
+			Caller:   c.Query("caller"),
+		})
+
+		usage := UsageSchema{Records: records}
+
+		for _, record := range records {
+			usage.TotalRequests += record.Requests
+			usage.TotalCost += record.Cost
+		}
+
+		return c.Status(fiber.StatusOK).JSON(usage)
+	}
+}
+
+// AdminLogLevelsHandler
+//
+//	@id				glide-admin-log-levels
+//	@Summary		Log Levels
+//	@Description	Retrieve the default log level & its overrides per module (providers, routing, http, health)
+//	@tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	http.LogLevelsSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/log-levels [GET]
+func AdminLogLevelsHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(logLevelsSchema(tel.Levels))
+	}
+}
+
+// AdminUpdateLogLevelsHandler
+//
+//	@id				glide-admin-log-levels-update
+//	@Summary		Update Log Levels
+//	@Description	Change the default log level and/or levels of modules (providers, routing, http, health) until the restart. An empty module level resets it to the default one
+//	@tags			Admin
+//	@Accept			json
+//	@Param			payload	body	http.LogLevelsSchema	true	"Log levels to change"
+//	@Produce		json
+//	@Success		200	{object}	http.LogLevelsSchema
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/log-levels [PUT]
+func AdminUpdateLogLevelsHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		var update LogLevelsSchema
+
+		if err := json.Unmarshal(c.Body(), &update); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("unable to parse log levels: %v", err),
+			})
+		}
+
+		// all levels are validated first, so the update is applied entirely or not at all
+		var level *zapcore.Level
+
+		if update.Level != "" {
+			parsedLevel, err := zapcore.ParseLevel(update.Level)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{Code: errorCode(fiber.StatusBadRequest, err), Message: err.Error()})
+			}
+
+			level = &parsedLevel
+		}
+
+		moduleLevels := make(map[string]*zapcore.Level, len(update.Modules))
+
+		for module, rawLevel := range update.Modules {
+			if !slices.Contains(telemetry.Modules, module) {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+					Code:    statusErrorCode(fiber.StatusBadRequest),
+					Message: fmt.Sprintf("unknown log module %q, supported modules: %v", module, strings.Join(telemetry.Modules, ", ")),
+				})
+			}
+
+			moduleLevels[module] = nil
+
+			if rawLevel == "" {
+				continue
+			}
+
+			parsedLevel, err := zapcore.ParseLevel(rawLevel)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{Code: errorCode(fiber.StatusBadRequest, err), Message: err.Error()})
+			}
+
+			moduleLevels[module] = &parsedLevel
+		}
+
+		if level != nil {
+			tel.Levels.SetLevel(*level)
+		}
+
+		for module, moduleLevel := range moduleLevels {
+			_ = tel.Levels.SetModuleLevel(module, moduleLevel) // modules are validated above
+		}
+
+		return c.Status(fiber.StatusOK).JSON(logLevelsSchema(tel.Levels))
+	}
+}
+
+// AdminCapturesHandler
+//
+//	@id				glide-admin-captures
+//	@Summary		Provider Traffic Captures
+//	@Description	Retrieve sanitized raw provider requests & responses captured for sampled or selected requests, the newest first
+//	@tags			Admin
+//	@Param			request_id	query	string	false	"Request ID to retrieve captures of (the X-Request-ID header)"
+//	@Param			provider	query	string	false	"Provider to retrieve captures of"
+//	@Produce		json
+//	@Success		200	{object}	http.CaptureLogSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/captures [GET]
+func AdminCapturesHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		targets := tel.Capture.Targets()
+
+		return c.Status(fiber.StatusOK).JSON(CaptureLogSchema{
+			SampleRate: targets.SampleRate,
+			RequestIDs: targets.RequestIDs,
+			Captures: tel.Capture.Captures(telemetry.CaptureFilter{
+				RequestID: c.Query("request_id"),
+				Provider:  c.Query("provider"),
+			}),
+		})
+	}
+}
+
+// AdminUpdateCaptureTargetsHandler
+//
+//	@id				glide-admin-captures-update
+//	@Summary		Update Capture Targets
+//	@Description	Change the share of provider calls captured and/or request IDs whose provider calls are always captured until the restart. Zero sample rate & no request IDs turn capturing off
+//	@tags			Admin
+//	@Accept			json
+//	@Param			payload	body	telemetry.CaptureTargets	true	"Provider calls to capture"
+//	@Produce		json
+//	@Success		200	{object}	telemetry.CaptureTargets
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/captures [PUT]
+func AdminUpdateCaptureTargetsHandler(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		var targets telemetry.CaptureTargets
+
+		if err := json.Unmarshal(c.Body(), &targets); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("unable to parse capture targets: %v", err),
+			})
+		}
+
+		if targets.SampleRate < 0 || targets.SampleRate > 1 {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("sample rate should be between 0 and 1, got %v", targets.SampleRate),
+			})
+		}
+
+		tel.Capture.SetTargets(targets)
+
+		return c.Status(fiber.StatusOK).JSON(tel.Capture.Targets())
+	}
+}
+
+func logLevelsSchema(levels *telemetry.LogLevels) LogLevelsSchema {
+	schema := LogLevelsSchema{
+		Level:   levels.Level().String(),
+		Modules: make(map[string]string),
+	}
+
+	for module, level := range levels.ModuleLevels() {
+		schema.Modules[module] = level.String()
+	}
+
+	return schema
+}
+
+// AdminCacheStatsHandler
+//
+//	@id				glide-admin-cache-stats
+//	@Summary		Cache Stats
+//	@Description	Retrieve hits, misses & memory usage of response & embedding caches per router
+//	@tags			Admin
+//	@Param			router	query	string	false	"Router ID to describe caches of"
+//	@Produce		json
+//	@Success		200	{object}	http.CacheStatsSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/admin/cache [GET]
+func AdminCacheStatsHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		langRouters, err := adminLangRouters(routerManager, c.Query("router"))
+		if err != nil {
+			return adminError(c, err)
+		}
+
+		stats := make([]*schemas.RouterCacheStats, 0, len(langRouters))
+
+		for _, router := range langRouters {
+			if routerStats := router.CacheStats(); routerStats != nil {
+				stats = append(stats, routerStats)
+			}
+		}
+
+		return c.Status(fiber.StatusOK).JSON(CacheStatsSchema{Routers: stats})
+	}
+}
+
+// AdminCachePurgeHandler
+//
+//	@id				glide-admin-cache-purge
+//	@Summary		Purge Cache
+//	@Description	Remove cached responses & embeddings. Filters are combined, no filters purge caches of all routers
+//	@tags			Admin
+//	@Param			router	query	string	false	"Router ID to purge caches of"
+//	@Param			model	query	string	false	"Model ID to purge entries produced by"
+//	@Param			prefix	query	string	false	"Cache key prefix (e.g. chat:default: or embed:default:openai:)"
+//	@Produce		json
+//	@Success		200	{object}	schemas.CachePurge
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Failure		500	{object}	http.ErrorSchema
+//	@Router			/v1/admin/cache [DELETE]
+func AdminCachePurgeHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		langRouters, err := adminLangRouters(routerManager, c.Query("router"))
+		if err != nil {
+			return adminError(c, err)
+		}
+
+		filter := routers.CachePurgeFilter{
+			ModelID:   c.Query("model"),
+			KeyPrefix: c.Query("prefix"),
+		}
+
+		purge := schemas.CachePurge{}
+
+		for _, router := range langRouters {
+			purged, err := router.PurgeCache(c.UserContext(), filter)
+			purge.Purged += purged
+
+			if err != nil {
+				return adminError(c, err)
+			}
+		}
+
+		return c.Status(fiber.StatusOK).JSON(purge)
+	}
+}
+
+// adminLangRouters returns the router with the given ID or all routers if no ID is given
+func adminLangRouters(routerManager *routers.RouterManager, routerID string) ([]*routers.LangRouter, error) {
+	if routerID == "" {
+		return routerManager.GetLangRouters(), nil
+	}
+
+	router, err := routerManager.GetLangRouter(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*routers.LangRouter{router}, nil
+}
+
+// AdminConfigExportHandler
+//
+//	@id				glide-admin-config-export
+//	@Summary		Export Config
+//	@Description	Export the current effective config (including routers changed at runtime) in the config file format, so it could be committed back to the source control. Secrets are redacted
+//	@tags			Admin
+//	@Param			format	query	string	false	"yaml (default) or json"
+//	@Produce		json
+//	@Success		200	{object}	string
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Router			/v1/admin/config [GET]
+func AdminConfigExportHandler(exporter ConfigExporter) Handler {
+	return func(c *fiber.Ctx) error {
+		format := c.Query("format", "yaml")
+
+		rawConfig, err := exporter(format)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		contentType := "application/yaml"
+
+		if format == "json" {
+			contentType = fiber.MIMEApplicationJSON
+		}
+
+		c.Set(fiber.HeaderContentType, contentType)
+
+		return c.Status(fiber.StatusOK).Send(rawConfig)
+	}
+}
+
+// adminError maps router management errors to HTTP responses
+func adminError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, routers.ErrRouterNotFound), errors.Is(err, routers.ErrModelNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, routers.ErrInvalidRouterConfig):
+		status = fiber.StatusBadRequest
+	}
+
+	return c.Status(status).JSON(ErrorSchema{
+		Code:    errorCode(status, err),
+		Message: err.Error(),
+	})
+}
+
+// AdminLangModelsRefreshHandler
+//
+//	@id				glide-admin-language-models-refresh
+//	@Summary		Refresh Language Router Models
+//	@Description	Re-fetch capabilities and upstream models of the router models bypassing the cache
+//	@tags			Admin
+//	@Param			router	path	string	true	"Router ID"
+//	@Produce		json
+//	@Success		200	{object}	http.ModelListSchema
+//	@Failure		401	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/admin/language/{router}/models/refresh [POST]
+func AdminLangModelsRefreshHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		metadata, err := routerManager.RefreshModelMetadata(c.Context(), c.Params("router"))
+		if err != nil {
+			return adminError(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(ModelListSchema{Models: metadata})
+	}
+}
@@ -0,0 +1,319 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const adminRouterConfig = `
+strategy: round_robin
+models:
+  - id: openai
+    openai:
+      api_key: ABC
+  - id: another_openai
+    openai:
+      api_key: ABC
+`
+
+func newAdminAppMock(t *testing.T) (*fiber.App, *routers.RouterManager) {
+	manager, err := routers.NewManager(&routers.Config{}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	app := fiber.New()
+	admin := app.Group("/v1/admin", AdminAuthMiddleware("secret"))
+
+	admin.Put("/language/:router/", AdminUpsertLangRouterHandler(manager))
+	admin.Delete("/language/:router/", AdminDeleteLangRouterHandler(manager))
+
+	return app, manager
+}
+
+func TestAdminAuthMiddleware_RejectsInvalidKeys(t *testing.T) {
+	app, _ := newAdminAppMock(t)
+
+	for _, apiKey := range []string{"", "wrong"} {
+		req := httptest.NewRequest(fiber.MethodDelete, "/v1/admin/language/default/", nil)
+		req.Header.Set(apiKeyHeader, apiKey)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestAdminUpsertLangRouterHandler(t *testing.T) {
+	app, manager := newAdminAppMock(t)
+
+	req := httptest.NewRequest(fiber.MethodPut, "/v1/admin/language/default/", strings.NewReader(adminRouterConfig))
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	router, err := manager.GetLangRouter("default")
+	require.NoError(t, err)
+	require.Len(t, router.Config.Models, 2)
+
+	req = httptest.NewRequest(fiber.MethodPut, "/v1/admin/language/default/", strings.NewReader("models: []"))
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	req = httptest.NewRequest(fiber.MethodDelete, "/v1/admin/language/default/", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+}
+
+func TestAdminErrorsHandler(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+	tel.Logger = zap.New(tel.Errors.Core())
+	tel.L().Error("model failed", zap.String("modelID", "openai"))
+
+	app := fiber.New()
+	app.Get("/v1/admin/errors/", AdminErrorsHandler(tel))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/errors/", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var errorLog ErrorLogSchema
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errorLog))
+	require.Len(t, errorLog.Errors, 1)
+	require.Equal(t, "model failed", errorLog.Errors[0].Message)
+	require.Equal(t, "openai", errorLog.Errors[0].Fields["modelID"])
+}
+
+func TestAdminAuditMiddleware(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	manager, err := routers.NewManager(&routers.Config{}, tel)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	admin := app.Group("/v1/admin", AdminAuthMiddleware("secret"), AdminAuditMiddleware(tel))
+	admin.Put("/language/:router/", AdminUpsertLangRouterHandler(manager))
+	admin.Delete("/language/:router/", AdminDeleteLangRouterHandler(manager))
+	admin.Get("/audit/", AdminAuditHandler(tel))
+
+	req := httptest.NewRequest(fiber.MethodPut, "/v1/admin/language/default/", strings.NewReader(adminRouterConfig))
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	req = httptest.NewRequest(fiber.MethodPut, "/v1/admin/language/another/", strings.NewReader("id: default"))
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	req = httptest.NewRequest(fiber.MethodGet, "/v1/admin/audit/", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var auditLog AuditLogSchema
+
+	// failed changes & reads are not audited
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&auditLog))
+	require.Len(t, auditLog.Entries, 1)
+	require.Equal(t, "admin.put", auditLog.Entries[0].Action)
+	require.Equal(t, "/v1/admin/language/default/", auditLog.Entries[0].Target)
+	require.Equal(t, callerID("secret", ""), auditLog.Entries[0].Actor)
+}
+
+func TestAdminUsageHandler(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+	tel.Usage.Record(&telemetry.UsageEvent{RouterID: "router", ModelID: "openai", Caller: "key:abc", PromptTokens: 10, ResponseTokens: 20, Cost: 0.5})
+	tel.Usage.Record(&telemetry.UsageEvent{RouterID: "router", ModelID: "anthropic", Caller: "key:def", PromptTokens: 5, ResponseTokens: 5, Cost: 0.25})
+
+	app := fiber.New()
+	app.Get("/v1/admin/usage/", AdminUsageHandler(tel))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/usage/", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var usage UsageSchema
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&usage))
+	require.Len(t, usage.Records, 2)
+	require.Equal(t, int64(2), usage.TotalRequests)
+	require.InDelta(t, 0.75, usage.TotalCost, 0.0001)
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/usage/?caller=key:def", nil))
+	require.NoError(t, err)
+
+	usage = UsageSchema{}
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&usage))
+	require.Len(t, usage.Records, 1)
+	require.Equal(t, "anthropic", usage.Records[0].ModelID)
+}
+
+func TestAdminLogLevelsHandlers(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	app := fiber.New()
+	app.Get("/v1/admin/log-levels/", AdminLogLevelsHandler(tel))
+	app.Put("/v1/admin/log-levels/", AdminUpdateLogLevelsHandler(tel))
+
+	req := httptest.NewRequest(fiber.MethodPut, "/v1/admin/log-levels/", strings.NewReader(`{"modules": {"providers": "debug"}}`))
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var levels LogLevelsSchema
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&levels))
+	require.Equal(t, "info", levels.Level)
+	require.Equal(t, map[string]string{"providers": "debug"}, levels.Modules)
+
+	// invalid updates are not applied even partially
+	req = httptest.NewRequest(fiber.MethodPut, "/v1/admin/log-levels/", strings.NewReader(`{"level": "debug", "modules": {"router": "debug"}}`))
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	req = httptest.NewRequest(fiber.MethodPut, "/v1/admin/log-levels/", strings.NewReader(`{"modules": {"providers": ""}}`))
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/log-levels/", nil))
+	require.NoError(t, err)
+
+	levels = LogLevelsSchema{}
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&levels))
+	require.Equal(t, "info", levels.Level)
+	require.Empty(t, levels.Modules)
+}
+
+func TestAdminCaptureHandlers(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	app := fiber.New()
+	app.Get("/v1/admin/captures/", AdminCapturesHandler(tel))
+	app.Put("/v1/admin/captures/", AdminUpdateCaptureTargetsHandler(tel))
+
+	req := httptest.NewRequest(fiber.MethodPut, "/v1/admin/captures/", strings.NewReader(`{"requestIds": ["req-1"]}`))
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.True(t, tel.Capture.Wanted(telemetry.WithRequestID(context.Background(), "req-1")))
+
+	req = httptest.NewRequest(fiber.MethodPut, "/v1/admin/captures/", strings.NewReader(`{"sampleRate": 2}`))
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	tel.Capture.Record(&telemetry.Capture{RequestID: "req-1", Provider: "openai"}, nil, nil)
+	tel.Capture.Record(&telemetry.Capture{RequestID: "req-2", Provider: "openai"}, nil, nil)
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/captures/?request_id=req-1", nil))
+	require.NoError(t, err)
+
+	var captureLog CaptureLogSchema
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&captureLog))
+	require.Equal(t, []string{"req-1"}, captureLog.RequestIDs)
+	require.Len(t, captureLog.Captures, 1)
+	require.Equal(t, "req-1", captureLog.Captures[0].RequestID)
+}
+
+func TestAdminConfigExportHandler(t *testing.T) {
+	app := fiber.New()
+	app.Get("/v1/admin/config/", AdminConfigExportHandler(func(format string) ([]byte, error) {
+		if format != "yaml" && format != "json" {
+			return nil, errors.New("unsupported format")
+		}
+
+		return []byte(format), nil
+	}))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/config/", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/yaml", resp.Header.Get(fiber.HeaderContentType))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/config/?format=json", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Equal(t, fiber.MIMEApplicationJSON, resp.Header.Get(fiber.HeaderContentType))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/config/?format=toml", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAdminCacheHandlers(t *testing.T) {
+	app, manager := newAdminAppMock(t)
+
+	admin := app.Group("/v1/admin")
+	admin.Get("/cache/", AdminCacheStatsHandler(manager))
+	admin.Delete("/cache/", AdminCachePurgeHandler(manager))
+
+	req := httptest.NewRequest(fiber.MethodPut, "/v1/admin/language/default/", strings.NewReader(adminRouterConfig+"response_cache:\n  enabled: true\n"))
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	req = httptest.NewRequest(fiber.MethodGet, "/v1/admin/cache/", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var stats CacheStatsSchema
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	require.Len(t, stats.Routers, 1)
+	require.Equal(t, "default", stats.Routers[0].RouterID)
+	require.NotNil(t, stats.Routers[0].Response)
+	require.Nil(t, stats.Routers[0].Embed)
+
+	req = httptest.NewRequest(fiber.MethodDelete, "/v1/admin/cache/?router=default&model=openai", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	req = httptest.NewRequest(fiber.MethodDelete, "/v1/admin/cache/?router=unknown", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
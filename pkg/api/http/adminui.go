@@ -0,0 +1,26 @@
+package http
+
+import (
+	"embed"
+	"io/fs"
+	nethttp "net/http"
+
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
+)
+
+//go:embed ui
+var adminUIAssets embed.FS
+
+// AdminUIHandler serves the admin web UI embedded into the binary.
+// The UI is static, it asks for the admin API key and calls the admin API with it
+func AdminUIHandler() Handler {
+	assets, err := fs.Sub(adminUIAssets, "ui")
+	if err != nil {
+		panic(err)
+	}
+
+	return filesystem.New(filesystem.Config{
+		Root:  nethttp.FS(assets),
+		Index: "index.html",
+	})
+}
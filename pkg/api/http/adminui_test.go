@@ -0,0 +1,28 @@
+package http
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminUIHandler_ServesAssets(t *testing.T) {
+	app := fiber.New()
+	app.Use("/admin", AdminUIHandler())
+
+	for path, content := range map[string]string{
+		"/admin/":       "Glide Admin",
+		"/admin/app.js": "/v1/admin/metrics/",
+	} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode, path)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), content)
+	}
+}
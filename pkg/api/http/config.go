@@ -2,6 +2,7 @@ package http
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,30 +10,96 @@ import (
 )
 
 type ServerConfig struct {
-	Host               string         `yaml:"host"`
-	Port               int            `yaml:"port"`
-	ReadTimeout        *time.Duration `yaml:"read_timeout"`
-	WriteTimeout       *time.Duration `yaml:"write_timeout"`
-	IdleTimeout        *time.Duration `yaml:"idle_timeout"`
-	MaxRequestBodySize *int           `yaml:"max_request_body_size"`
+	Host               string              `yaml:"host"`
+	Port               int                 `yaml:"port"`
+	ReadTimeout        *time.Duration      `yaml:"read_timeout"`
+	WriteTimeout       *time.Duration      `yaml:"write_timeout"`
+	IdleTimeout        *time.Duration      `yaml:"idle_timeout"`
+	DrainTimeout       *time.Duration      `yaml:"drain_timeout"` // how long in-flight requests & streams may take to finish on shutdown
+	MaxRequestBodySize *int                `yaml:"max_request_body_size"`
+	MaxClientTimeout   *time.Duration      `yaml:"max_client_timeout"` // the longest deadline clients may set via the X-Request-Timeout or grpc-timeout headers
+	StrictSchema       bool                `yaml:"strict_schema"`      // reject requests with fields unknown to Glide's schemas
+	Batch              *BatchConfig        `yaml:"batch"`
+	Streams            *StreamLimitConfig  `yaml:"streams"`
+	LoadShedding       *LoadSheddingConfig `yaml:"load_shedding"` // reject new requests while the gateway is under resource pressure
+	Admin              *AdminConfig        `yaml:"admin"`
+	TLS                *TLSConfig          `yaml:"tls"` // serve HTTPS if configured
+	HTTP2              *HTTP2Config        `yaml:"http2"`
+	CORS               *CORSConfig         `yaml:"cors"` // let browser apps call the gateway directly
+	RouteLimits        []RouteLimitConfig  `yaml:"route_limits" validate:"dive"`
+	Docs               *DocsConfig         `yaml:"docs"`
+	Serve              []RouteGroup        `yaml:"serve" validate:"dive,oneof=api admin docs health"` // route groups served by the listener, all groups are served by default
+}
+
+// RouteGroup is a set of routes that could be served by a listener
+type RouteGroup = string
+
+const (
+	APIRoutes    RouteGroup = "api"    // language, image, audio & moderation routers
+	AdminRoutes  RouteGroup = "admin"  // admin API & UI (if the admin API is enabled)
+	DocsRoutes   RouteGroup = "docs"   // OpenAPI spec & Swagger UI
+	HealthRoutes RouteGroup = "health" // the gateway health check
+)
+
+// BatchConfig limits batch requests
+type BatchConfig struct {
+	MaxSize        int `yaml:"max_size" validate:"gte=1"`        // max number of requests in one batch
+	MaxParallelism int `yaml:"max_parallelism" validate:"gte=1"` // max number of batch requests served at the same time
+}
+
+func DefaultBatchConfig() *BatchConfig {
+	return &BatchConfig{
+		MaxSize:        100,
+		MaxParallelism: 4,
+	}
+}
+
+func (cfg *BatchConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultBatchConfig()
+
+	type plain BatchConfig // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
 }
 
 func DefaultServerConfig() *ServerConfig {
-	maxReqBodySizeBytes := 4 * 1024 * 1024 // 4Mb
+	maxReqBodySizeBytes := 4 * 1024 * 1024       // 4Mb
+	maxAudioReqBodySizeBytes := 25 * 1024 * 1024 // 25Mb, audio files are much larger than text prompts
 	readTimeout := 30 * time.Second
 	writeTimeout := 1 * time.Minute
 	idleTimeout := 30 * time.Second
+	drainTimeout := 30 * time.Second
 
 	return &ServerConfig{
 		Host:               "127.0.0.1",
 		Port:               9099,
 		IdleTimeout:        &idleTimeout,
+		DrainTimeout:       &drainTimeout,
 		ReadTimeout:        &readTimeout,
 		WriteTimeout:       &writeTimeout,
 		MaxRequestBodySize: &maxReqBodySizeBytes,
+		Batch:              DefaultBatchConfig(),
+		Streams:            DefaultStreamLimitConfig(),
+		Docs:               DefaultDocsConfig(),
+		RouteLimits: []RouteLimitConfig{
+			{Path: "/v1/audio/", MaxRequestBodySize: &maxAudioReqBodySizeBytes},
+		},
 	}
 }
 
+func (cfg *ServerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultServerConfig()
+
+	type plain ServerConfig // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
+}
+
+// Serves tells if the listener serves the route group
+func (cfg *ServerConfig) Serves(group RouteGroup) bool {
+	return len(cfg.Serve) == 0 || slices.Contains(cfg.Serve, group)
+}
+
 func (cfg *ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%v", cfg.Host, cfg.Port)
 }
@@ -49,6 +116,7 @@ func (cfg *ServerConfig) ToServer() *fiber.App {
 		DisablePreParseMultipartForm: true,
 		EnablePrintRoutes:            false,
 		DisableStartupMessage:        false,
+		ErrorHandler:                 ErrorHandler,
 	}
 
 	if cfg.IdleTimeout != nil {
@@ -63,8 +131,8 @@ func (cfg *ServerConfig) ToServer() *fiber.App {
 		serverConfig.WriteTimeout = *cfg.WriteTimeout
 	}
 
-	if cfg.MaxRequestBodySize != nil {
-		serverConfig.BodyLimit = *cfg.MaxRequestBodySize
+	if bodyLimit := cfg.serverBodyLimit(); bodyLimit != nil {
+		serverConfig.BodyLimit = *bodyLimit
 	}
 
 	return fiber.New(serverConfig)
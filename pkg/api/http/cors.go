@@ -0,0 +1,103 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"glide/pkg/routers"
+)
+
+const anyOrigin = "*"
+
+// CORSConfig lets browser apps from the allowed origins call the gateway directly
+type CORSConfig struct {
+	AllowOrigins     []string      `yaml:"allow_origins" validate:"required,min=1"` // e.g. https://app.example.com or "*" for any origin
+	AllowMethods     []string      `yaml:"allow_methods"`
+	AllowHeaders     []string      `yaml:"allow_headers"`
+	ExposeHeaders    []string      `yaml:"expose_headers"`    // response headers browser apps could read
+	AllowCredentials bool          `yaml:"allow_credentials"` // let browsers send cookies & auth headers, can't be used with "*" origin
+	MaxAge           time.Duration `yaml:"max_age"`           // how long browsers may cache preflight responses
+}
+
+func DefaultCORSConfig() *CORSConfig {
+	return &CORSConfig{
+		AllowMethods: []string{
+			fiber.MethodGet,
+			fiber.MethodPost,
+			fiber.MethodPut,
+			fiber.MethodDelete,
+			fiber.MethodOptions,
+		},
+		AllowHeaders: []string{
+			fiber.HeaderContentType,
+			fiber.HeaderAuthorization,
+			fiber.HeaderXRequestID,
+			apiKeyHeader,
+			PriorityHeader,
+			routers.IdempotencyKeyHeader,
+			RequestTimeoutHeader,
+			routers.CacheControlHeader,
+		},
+		ExposeHeaders: []string{
+			fiber.HeaderXRequestID,
+			idempotentReplayedHeader,
+		},
+		MaxAge: 10 * time.Minute,
+	}
+}
+
+func (cfg *CORSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultCORSConfig()
+
+	type plain CORSConfig // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
+}
+
+// Validate checks the policy is secure & well-formed
+func (cfg *CORSConfig) Validate() error {
+	if len(cfg.AllowOrigins) == 0 {
+		return errors.New("CORS policy must allow at least one origin")
+	}
+
+	if cfg.AllowCredentials && slices.Contains(cfg.AllowOrigins, anyOrigin) {
+		return errors.New("CORS policy can't allow credentials for any origin (\"*\"), list allowed origins explicitly")
+	}
+
+	for _, origin := range cfg.AllowOrigins {
+		if origin == anyOrigin {
+			continue
+		}
+
+		originURL, err := url.Parse(origin)
+		if err != nil || originURL.Scheme == "" || originURL.Host == "" || (originURL.Path != "" && originURL.Path != "/") {
+			return fmt.Errorf("CORS origin \"%v\" is invalid, it should look like https://app.example.com", origin)
+		}
+	}
+
+	return nil
+}
+
+// CORSMiddleware applies the CORS policy and answers preflight requests
+func CORSMiddleware(cfg *CORSConfig) Handler {
+	origins := make([]string, 0, len(cfg.AllowOrigins))
+
+	for _, origin := range cfg.AllowOrigins {
+		origins = append(origins, strings.TrimSuffix(origin, "/"))
+	}
+
+	return cors.New(cors.Config{
+		AllowOrigins:     strings.Join(origins, ","),
+		AllowMethods:     strings.Join(cfg.AllowMethods, ","),
+		AllowHeaders:     strings.Join(cfg.AllowHeaders, ","),
+		ExposeHeaders:    strings.Join(cfg.ExposeHeaders, ","),
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	})
+}
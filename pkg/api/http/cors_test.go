@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.AllowOrigins = []string{"https://app.example.com"}
+	cfg.AllowCredentials = true
+
+	require.NoError(t, cfg.Validate())
+
+	app := fiber.New()
+	app.Use(CORSMiddleware(cfg))
+	app.Post("/v1/language/:router/chat/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	// preflight
+	req := httptest.NewRequest(fiber.MethodOptions, "/v1/language/default/chat/", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodPost)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	require.Equal(t, "https://app.example.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	require.Equal(t, "true", resp.Header.Get(fiber.HeaderAccessControlAllowCredentials))
+	require.Contains(t, resp.Header.Get(fiber.HeaderAccessControlAllowHeaders), apiKeyHeader)
+	require.Equal(t, "600", resp.Header.Get(fiber.HeaderAccessControlMaxAge))
+
+	// actual request
+	req = httptest.NewRequest(fiber.MethodPost, "/v1/language/default/chat/", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Equal(t, "https://app.example.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	require.Equal(t, fiber.HeaderXRequestID+","+idempotentReplayedHeader, resp.Header.Get(fiber.HeaderAccessControlExposeHeaders))
+
+	// origins outside the policy
+	req = httptest.NewRequest(fiber.MethodPost, "/v1/language/default/chat/", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://evil.example.com")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+}
+
+func TestCORSConfig_Validate(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	require.Error(t, cfg.Validate())
+
+	cfg.AllowOrigins = []string{"*"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.AllowCredentials = true
+	require.ErrorContains(t, cfg.Validate(), "can't allow credentials")
+
+	cfg.AllowOrigins = []string{"app.example.com"}
+	require.ErrorContains(t, cfg.Validate(), "is invalid")
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+)
+
+const (
+	deprecationHeader = "Deprecation" // RFC 9745
+	sunsetHeader      = "Sunset"      // RFC 8594
+	warningHeader     = "Warning"
+)
+
+// DeprecationWarning tells the client that the model served the request is deprecated
+// and counts the client, so it's known who is still to migrate before the sunset date
+func DeprecationWarning(c *fiber.Ctx, tel *telemetry.Telemetry, routerID string, deprecation *schemas.ModelDeprecation) {
+	if deprecation == nil {
+		return
+	}
+
+	c.Set(deprecationHeader, "true")
+
+	if sunset, err := time.Parse(time.DateOnly, deprecation.Sunset); err == nil {
+		c.Set(sunsetHeader, sunset.UTC().Format(http.TimeFormat))
+	}
+
+	c.Set(warningHeader, fmt.Sprintf("299 - %q", deprecationMessage(deprecation)))
+
+	countDeprecatedCaller(tel, routerID, deprecation, callerID(APIKey(c.Get), c.IP()))
+}
+
+// countDeprecatedCaller counts requests of the caller served by the deprecated model
+func countDeprecatedCaller(tel *telemetry.Telemetry, routerID string, deprecation *schemas.ModelDeprecation, caller string) {
+	tel.M().Counter(fmt.Sprintf("http.deprecated_models.%v.%v.callers.%v", routerID, deprecation.ModelID, caller)).Inc()
+}
+
+// callerID identifies the client by its API key hash (so keys don't leak into metrics) or by its IP
+func callerID(apiKey string, clientIP string) string {
+	if apiKey == "" {
+		return "ip:" + clientIP
+	}
+
+	keyHash := sha256.Sum256([]byte(apiKey))
+
+	return "key:" + hex.EncodeToString(keyHash[:6])
+}
+
+func deprecationMessage(deprecation *schemas.ModelDeprecation) string {
+	var msg strings.Builder
+
+	fmt.Fprintf(&msg, "model %v is deprecated", deprecation.ModelID)
+
+	if deprecation.Sunset != "" {
+		fmt.Fprintf(&msg, " and will be removed on %v", deprecation.Sunset)
+	}
+
+	if deprecation.Replacement != "" {
+		fmt.Fprintf(&msg, ", use %v instead", deprecation.Replacement)
+	}
+
+	if deprecation.Message != "" {
+		fmt.Fprintf(&msg, ". %v", deprecation.Message)
+	}
+
+	return msg.String()
+}
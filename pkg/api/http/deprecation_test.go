@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+)
+
+func TestDeprecationWarning(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+	deprecation := &schemas.ModelDeprecation{ModelID: "gpt3", Sunset: "2024-12-31", Replacement: "gpt4"}
+
+	app := fiber.New()
+	app.Get("/deprecated", func(c *fiber.Ctx) error {
+		DeprecationWarning(c, tel, "default", deprecation)
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/supported", func(c *fiber.Ctx) error {
+		DeprecationWarning(c, tel, "default", nil)
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/deprecated", nil)
+	req.Header.Set(apiKeyHeader, "secret")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	require.Equal(t, "true", resp.Header.Get(deprecationHeader))
+	require.Equal(t, "Tue, 31 Dec 2024 00:00:00 GMT", resp.Header.Get(sunsetHeader))
+	require.Equal(t, `299 - "model gpt3 is deprecated and will be removed on 2024-12-31, use gpt4 instead"`, resp.Header.Get(warningHeader))
+
+	counters := tel.M().Counters()
+	require.Equal(t, int64(1), counters["http.deprecated_models.default.gpt3.callers."+callerID("secret", "")])
+	require.NotContains(t, counters, "http.deprecated_models.default.gpt3.callers.key:secret")
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/supported", nil))
+	require.NoError(t, err)
+	require.Empty(t, resp.Header.Get(deprecationHeader))
+	require.Empty(t, resp.Header.Get(warningHeader))
+}
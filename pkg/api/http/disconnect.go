@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"net"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const clientDisconnectMetric = "http.client_disconnects"
+
+// ClientDisconnectMiddleware cancels the request context (available via c.UserContext()) as soon as the client disconnects,
+// so upstream provider calls are aborted instead of generating tokens nobody is going to read.
+// Websocket requests are skipped, they detect disconnects on their own
+func ClientDisconnectMiddleware(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		if websocket.IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+
+		if streamCtx, ok := h2StreamContext(c); ok {
+			// the HTTP/2 server cancels the stream context itself when the client resets the stream or disconnects
+			c.SetUserContext(streamCtx)
+
+			return c.Next()
+		}
+
+		// the connection is watched only after the request body is fully read,
+		// so the watcher never consumes the body of the current request
+		_ = c.Body()
+
+		ctx, cancel := context.WithCancel(c.UserContext())
+		defer cancel()
+
+		path, requestID := c.Path(), RequestID(c)
+
+		stopWatching := watchDisconnect(c.Context().Conn(), func() {
+			tel.M().Counter(clientDisconnectMetric).Inc()
+			tel.L().Debug(
+				"client disconnected, cancelling the request",
+				zap.String("path", path),
+				zap.String("requestID", requestID),
+			)
+
+			cancel()
+		})
+		defer stopWatching()
+
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+// netConn unwraps connections like TLS ones to get to the underlying TCP connection
+func netConn(conn net.Conn) net.Conn {
+	for {
+		wrapper, ok := conn.(interface{ NetConn() net.Conn })
+		if !ok {
+			return conn
+		}
+
+		conn = wrapper.NetConn()
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package http
+
+import "net"
+
+// watchDisconnect is not supported on this platform, requests are served till the end regardless of client disconnects
+func watchDisconnect(_ net.Conn, _ func()) (stop func()) {
+	return func() {}
+}
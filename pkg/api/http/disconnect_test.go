@@ -0,0 +1,106 @@
+//go:build unix
+
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+func newDisconnectAppMock(t *testing.T, handler Handler) (string, *telemetry.Telemetry) {
+	tel := telemetry.NewTelemetryMock()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(ClientDisconnectMiddleware(tel))
+	app.Post("/chat/", handler)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		_ = app.Listener(listener)
+	}()
+
+	t.Cleanup(func() {
+		_ = app.Shutdown()
+	})
+
+	return listener.Addr().String(), tel
+}
+
+func TestClientDisconnectMiddleware_CancelsContext(t *testing.T) {
+	cancelledC := make(chan struct{})
+
+	addr, tel := newDisconnectAppMock(t, func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+			close(cancelledC)
+		case <-time.After(5 * time.Second):
+		}
+
+		return nil
+	})
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	_, err = fmt.Fprint(conn, "POST /chat/ HTTP/1.1\r\nHost: glide\r\nContent-Length: 2\r\n\r\n{}")
+	require.NoError(t, err)
+
+	// give the server a moment to start serving the request
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, conn.Close())
+
+	select {
+	case <-cancelledC:
+	case <-time.After(2 * time.Second):
+		t.Fatal("request context was not cancelled on client disconnect")
+	}
+
+	require.Equal(t, int64(1), tel.M().Counter(clientDisconnectMetric).Value())
+}
+
+func TestClientDisconnectMiddleware_KeepAliveConnection(t *testing.T) {
+	addr, _ := newDisconnectAppMock(t, func(c *fiber.Ctx) error {
+		if c.UserContext().Err() != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	// the connection must stay usable for following requests after the watcher is stopped
+	for i := 0; i < 3; i++ {
+		_, err = fmt.Fprint(conn, "POST /chat/ HTTP/1.1\r\nHost: glide\r\nContent-Length: 2\r\n\r\n{}")
+		require.NoError(t, err)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+
+		statusLine, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.Contains(t, statusLine, "200 OK")
+
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+
+			if line == "\r\n" {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+//go:build unix
+
+package http
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// watchDisconnect calls onDisconnect when the peer closes the connection.
+// It waits for the socket to become readable and peeks into it without consuming any data:
+// zero bytes mean the peer has closed the connection, any data (e.g. a pipelined request) stops watching
+func watchDisconnect(conn net.Conn, onDisconnect func()) (stop func()) {
+	conn = netConn(conn)
+
+	sysConn, ok := conn.(syscall.Conn)
+	if !ok {
+		return func() {}
+	}
+
+	rawConn, err := sysConn.SyscallConn()
+	if err != nil {
+		return func() {}
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		buf := make([]byte, 1)
+		disconnected := false
+
+		// Read returns when the callback returns true or the read deadline is exceeded
+		_ = rawConn.Read(func(fd uintptr) bool {
+			n, _, err := syscall.Recvfrom(int(fd), buf, syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+			if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EINTR) {
+				// nothing to read yet, wait until the socket becomes readable
+				return false
+			}
+
+			disconnected = (n == 0 && err == nil) || errors.Is(err, syscall.ECONNRESET)
+
+			return true
+		})
+
+		if disconnected {
+			onDisconnect()
+		}
+	}()
+
+	return func() {
+		// unblock the watcher and restore the deadline, so the server could read following requests from the connection
+		_ = conn.SetReadDeadline(time.Now())
+		wg.Wait()
+		_ = conn.SetReadDeadline(time.Time{})
+	}
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers"
+)
+
+// errorCode maps the failed request into the gateway error code. Errors the router doesn't know about
+// (e.g. malformed payloads) are described by the response status
+func errorCode(status int, err error) schemas.ErrorCode {
+	if code := routers.ErrorCode(err); code != schemas.UnknownError {
+		return code
+	}
+
+	return statusErrorCode(status)
+}
+
+func statusErrorCode(status int) schemas.ErrorCode {
+	switch status {
+	case fiber.StatusBadRequest, fiber.StatusRequestEntityTooLarge, fiber.StatusUnprocessableEntity:
+		return schemas.InvalidRequest
+	case fiber.StatusUnauthorized, fiber.StatusForbidden:
+		return schemas.Unauthorized
+	case fiber.StatusNotFound:
+		return schemas.NotFound
+	case fiber.StatusRequestTimeout, fiber.StatusGatewayTimeout:
+		return schemas.Timeout
+	case fiber.StatusTooManyRequests:
+		return schemas.RateLimited
+	case fiber.StatusBadGateway:
+		return schemas.ModelUnavailable
+	case fiber.StatusServiceUnavailable:
+		return schemas.Overloaded
+	default:
+		return schemas.UnknownError
+	}
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers"
+)
+
+func TestErrorCode(t *testing.T) {
+	// router errors keep their codes regardless of the status they are responded with
+	require.Equal(t, schemas.BudgetExhausted, errorCode(fiber.StatusTooManyRequests, routers.ErrBudgetExhausted))
+	require.Equal(t, schemas.AllModelsUnavailable, errorCode(fiber.StatusInternalServerError, routers.ErrNoModelAvailable))
+
+	// the rest is described by the status
+	require.Equal(t, schemas.InvalidRequest, errorCode(fiber.StatusBadRequest, errors.New("unexpected end of JSON input")))
+	require.Equal(t, schemas.UnknownError, errorCode(fiber.StatusInternalServerError, errors.New("unexpected failure")))
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"sync"
 
 	"glide/pkg/telemetry"
@@ -16,6 +18,21 @@ import (
 
 type Handler = func(c *fiber.Ctx) error
 
+const (
+	// requestHeadersLocal keeps headers of websocket upgrade requests
+	requestHeadersLocal = "requestHeaders"
+	// idempotentReplayedHeader marks responses served again for retried requests with the same idempotency key
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// requestContext passes request headers, the request ID & the caller ID to the router
+func requestContext(c *fiber.Ctx) context.Context {
+	ctx := routers.WithRequestHeaders(c.UserContext(), c.GetReqHeaders())
+	ctx = telemetry.WithRequestID(ctx, RequestID(c))
+
+	return routers.WithCaller(ctx, callerID(APIKey(c.Get), c.IP()))
+}
+
 // Swagger 101:
 // - https://github.com/swaggo/swag/tree/master/example/celler
 
@@ -27,16 +44,27 @@ type Handler = func(c *fiber.Ctx) error
 //	@tags			Language
 //	@Param			router	path	string						true	"Router ID"
 //	@Param			payload	body	schemas.ChatRequest	true	"Request Data"
+//	@Param			X-Glide-Model	header	string	false	"Router model to pin the request to"
+//	@Param			X-Glide-Trace	header	bool	false	"Describe the routing decision in the response"
+//	@Param			X-Glide-Timing	header	bool	false	"Break the request latency down in the response"
+//	@Param			X-Glide-Priority	header	string	false	"Request priority under load shedding (low, normal or high)"
+//	@Param			Idempotency-Key	header	string	false	"Retries with the same key are served the first response"
+//	@Param			Cache-Control	header	string	false	"Response cache directives (no-cache, no-store or max-age)"
 //	@Accept			json
 //	@Produce		json
 //	@Success		200	{object}	schemas.ChatResponse
 //	@Failure		400	{object}	http.ErrorSchema
 //	@Failure		404	{object}	http.ErrorSchema
+//	@Failure		422	{object}	http.ErrorSchema
+//	@Failure		429	{object}	http.ErrorSchema
+//	@Failure		502	{object}	http.ErrorSchema
+//	@Failure		503	{object}	http.ErrorSchema
 //	@Router			/v1/language/{router}/chat [POST]
-func LangChatHandler(routerManager *routers.RouterManager) Handler {
+func LangChatHandler(tel *telemetry.Telemetry, routerManager *routers.RouterManager) Handler {
 	return func(c *fiber.Ctx) error {
 		if !c.Is("json") {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
 				Message: "Glide accepts only JSON payloads",
 			})
 		}
@@ -47,6 +75,7 @@ func LangChatHandler(routerManager *routers.RouterManager) Handler {
 		err := c.BodyParser(&req)
 		if err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
 				Message: err.Error(),
 			})
 		}
@@ -58,24 +87,567 @@ func LangChatHandler(routerManager *routers.RouterManager) Handler {
 		if errors.Is(err, routers.ErrRouterNotFound) {
 			// Return not found error
 			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
 				Message: err.Error(),
 			})
 		}
 
 		// Chat with router
-		resp, err := router.Chat(c.Context(), req)
+		resp, err := router.Chat(requestContext(c), req)
+		if errors.Is(err, routers.ErrContentFlagged) || errors.Is(err, routers.ErrContextWindowExceeded) ||
+			errors.Is(err, routers.ErrCapabilityUnsupported) || errors.Is(err, routers.ErrPinnedModelNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if errors.Is(err, routers.ErrPinnedModelUnavailable) {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusServiceUnavailable, err),
+				Message: err.Error(),
+			})
+		}
+
+		if errors.Is(err, routers.ErrBudgetExhausted) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusTooManyRequests, err),
+				Message: err.Error(),
+			})
+		}
+
+		var queueErr *routers.QueueError
+
+		if errors.As(err, &queueErr) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusTooManyRequests, err),
+				Message: err.Error(),
+				Queue:   &queueErr.Stats,
+			})
+		}
+
+		if errors.Is(err, routers.ErrRouterSaturated) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusTooManyRequests, err),
+				Message: err.Error(),
+			})
+		}
+
+		if errors.Is(err, routers.ErrContentFiltered) || errors.Is(err, routers.ErrIdempotencyKeyReused) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusUnprocessableEntity, err),
+				Message: err.Error(),
+			})
+		}
+
+		if errors.Is(err, routers.ErrEmptyResponse) {
+			return c.Status(fiber.StatusBadGateway).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadGateway, err),
+				Message: err.Error(),
+			})
+		}
+
 		if err != nil {
 			// Return internal server error
 			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusInternalServerError, err),
 				Message: err.Error(),
 			})
 		}
 
+		DeprecationWarning(c, tel, routerID, resp.Deprecation)
+		RoutingSummary(c, resp.Routing)
+
+		if resp.Replayed {
+			c.Set(idempotentReplayedHeader, "true")
+		}
+
 		// Return chat response
 		return c.Status(fiber.StatusOK).JSON(resp)
 	}
 }
 
+// LangChatBatchHandler
+//
+//	@id				glide-language-chat-batch
+//	@Summary		Language Chat Batch
+//	@Description	Serve a batch of chat requests concurrently. Failures are reported per request
+//	@tags			Language
+//	@Param			router	path	string						true	"Router ID"
+//	@Param			payload	body	schemas.ChatBatchRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.ChatBatchResponse
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/language/{router}/chat/batch [POST]
+func LangChatBatchHandler(routerManager *routers.RouterManager, cfg *BatchConfig) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "Glide accepts only JSON payloads",
+			})
+		}
+
+		var req *schemas.ChatBatchRequest
+
+		err := c.BodyParser(&req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if req == nil || len(req.Requests) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "at least one request is required in the batch",
+			})
+		}
+
+		if len(req.Requests) > cfg.MaxSize {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: fmt.Sprintf("batch size %v exceeds the max allowed size of %v requests", len(req.Requests), cfg.MaxSize),
+			})
+		}
+
+		routerID := c.Params("router")
+		router, err := routerManager.GetLangRouter(routerID)
+
+		if errors.Is(err, routers.ErrRouterNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		parallelism := cfg.MaxParallelism
+
+		if req.Parallelism > 0 && req.Parallelism < parallelism {
+			parallelism = req.Parallelism
+		}
+
+		resp := router.ChatBatch(requestContext(c), req, parallelism)
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// LangEmbedHandler
+//
+//	@id				glide-language-embed
+//	@Summary		Language Embeddings
+//	@Description	Get embeddings from different LLM Embedding APIs via unified endpoint
+//	@tags			Language
+//	@Param			router	path	string					true	"Router ID"
+//	@Param			payload	body	schemas.EmbedRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.EmbedResponse
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Failure		429	{object}	http.ErrorSchema
+//	@Failure		502	{object}	http.ErrorSchema
+//	@Router			/v1/language/{router}/embeddings [POST]
+func LangEmbedHandler(tel *telemetry.Telemetry, routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "Glide accepts only JSON payloads",
+			})
+		}
+
+		var req *schemas.EmbedRequest
+
+		err := c.BodyParser(&req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if req == nil || len(req.Input) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "input is required and must contain at least one item",
+			})
+		}
+
+		routerID := c.Params("router")
+		router, err := routerManager.GetLangRouter(routerID)
+
+		if errors.Is(err, routers.ErrRouterNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		resp, err := router.Embed(requestContext(c), req)
+		if errors.Is(err, routers.ErrBudgetExhausted) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusTooManyRequests, err),
+				Message: err.Error(),
+			})
+		}
+
+		if errors.Is(err, routers.ErrEmptyResponse) {
+			return c.Status(fiber.StatusBadGateway).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadGateway, err),
+				Message: err.Error(),
+			})
+		}
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusInternalServerError, err),
+				Message: err.Error(),
+			})
+		}
+
+		DeprecationWarning(c, tel, routerID, resp.Deprecation)
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// LangTokenizeHandler
+//
+//	@id				glide-language-tokenize
+//	@Summary		Token Counting
+//	@Description	Count prompt tokens for a router model, so clients can check the prompt fits the model context before sending it
+//	@tags			Language
+//	@Param			router	path	string					true	"Router ID"
+//	@Param			payload	body	schemas.TokenizeRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.TokenizeResponse
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/language/{router}/tokenize [POST]
+func LangTokenizeHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "Glide accepts only JSON payloads",
+			})
+		}
+
+		var req *schemas.TokenizeRequest
+
+		err := c.BodyParser(&req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if req == nil || req.Message.Content == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "message is required",
+			})
+		}
+
+		resp, err := routerManager.CountTokens(requestContext(c), c.Params("router"), req)
+
+		if errors.Is(err, routers.ErrRouterNotFound) || errors.Is(err, routers.ErrModelNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusInternalServerError, err),
+				Message: err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// LangFeedbackHandler
+//
+//	@id				glide-language-feedback
+//	@Summary		Chat Feedback
+//	@Description	Rate a chat response of a router model, so routers with the bandit strategy could learn which models serve the best responses
+//	@tags			Language
+//	@Param			router	path	string					true	"Router ID"
+//	@Param			payload	body	schemas.ChatFeedback	true	"Feedback Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		202
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/language/{router}/feedback [POST]
+func LangFeedbackHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "Glide accepts only JSON payloads",
+			})
+		}
+
+		var feedback *schemas.ChatFeedback
+
+		err := c.BodyParser(&feedback)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if feedback == nil || feedback.ModelID == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "model_id is required",
+			})
+		}
+
+		if feedback.Score < 0 || feedback.Score > 1 {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "score must be between 0 and 1",
+			})
+		}
+
+		err = routerManager.Feedback(c.Params("router"), feedback)
+
+		if errors.Is(err, routers.ErrRouterNotFound) || errors.Is(err, routers.ErrModelNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		if errors.Is(err, routers.ErrFeedbackNotSupported) {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusInternalServerError, err),
+				Message: err.Error(),
+			})
+		}
+
+		return c.SendStatus(fiber.StatusAccepted)
+	}
+}
+
+// ImageGenerateHandler
+//
+//	@id				glide-image-generate
+//	@Summary		Image Generation
+//	@Description	Generate images via different image generation APIs using unified endpoint
+//	@tags			Image
+//	@Param			router	path	string							true	"Router ID"
+//	@Param			payload	body	schemas.ImageGenerateRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.ImageGenerateResponse
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/image/{router}/generate [POST]
+func ImageGenerateHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "Glide accepts only JSON payloads",
+			})
+		}
+
+		var req *schemas.ImageGenerateRequest
+
+		err := c.BodyParser(&req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if req == nil || req.Prompt == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "prompt is required",
+			})
+		}
+
+		routerID := c.Params("router")
+		router, err := routerManager.GetImageRouter(routerID)
+
+		if errors.Is(err, routers.ErrRouterNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		resp, err := router.Generate(requestContext(c), req)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusInternalServerError, err),
+				Message: err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// TranscriptionHandler
+//
+//	@id				glide-audio-transcribe
+//	@Summary		Speech-to-Text Transcription
+//	@Description	Transcribe audio files via different speech-to-text APIs using unified endpoint
+//	@tags			Audio
+//	@Param			router		path		string	true	"Router ID"
+//	@Param			file		formData	file	true	"Audio file"
+//	@Param			language	formData	string	false	"Language of the audio (ISO-639-1)"
+//	@Param			prompt		formData	string	false	"Hint text to guide the transcription"
+//	@Accept			mpfd
+//	@Produce		json
+//	@Success		200	{object}	schemas.TranscriptionResponse
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/audio/{router}/transcriptions [POST]
+func TranscriptionHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "audio file is required in the \"file\" multipart field",
+			})
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		req := &schemas.TranscriptionRequest{
+			File:        content,
+			Filename:    fileHeader.Filename,
+			ContentType: fileHeader.Header.Get(fiber.HeaderContentType),
+			Language:    c.FormValue("language"),
+			Prompt:      c.FormValue("prompt"),
+		}
+
+		routerID := c.Params("router")
+		router, err := routerManager.GetAudioRouter(routerID)
+
+		if errors.Is(err, routers.ErrRouterNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		resp, err := router.Transcribe(requestContext(c), req)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusInternalServerError, err),
+				Message: err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// ModerationHandler
+//
+//	@id				glide-moderation
+//	@Summary		Moderation
+//	@Description	Check content via different moderation APIs & classifiers using unified endpoint
+//	@tags			Moderation
+//	@Param			router	path	string						true	"Router ID"
+//	@Param			payload	body	schemas.ModerationRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.ModerationResponse
+//	@Failure		400	{object}	http.ErrorSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/moderation/{router} [POST]
+func ModerationHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "Glide accepts only JSON payloads",
+			})
+		}
+
+		var req *schemas.ModerationRequest
+
+		err := c.BodyParser(&req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		if req == nil || len(req.Input) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusBadRequest),
+				Message: "input is required",
+			})
+		}
+
+		routerID := c.Params("router")
+		router, err := routerManager.GetModerationRouter(routerID)
+
+		if errors.Is(err, routers.ErrRouterNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		resp, err := router.Moderate(requestContext(c), req)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusInternalServerError, err),
+				Message: err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
 func LangStreamRouterValidator(routerManager *routers.RouterManager) Handler {
 	return func(c *fiber.Ctx) error {
 		if websocket.IsWebSocketUpgrade(c) {
@@ -84,10 +656,14 @@ func LangStreamRouterValidator(routerManager *routers.RouterManager) Handler {
 			_, err := routerManager.GetLangRouter(routerID)
 			if err != nil {
 				return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+					Code:    errorCode(fiber.StatusNotFound, err),
 					Message: err.Error(),
 				})
 			}
 
+			// the websocket connection doesn't expose all request headers, so they are passed to routing rules this way
+			c.Locals(requestHeadersLocal, c.GetReqHeaders())
+
 			return c.Next()
 		}
 
@@ -111,32 +687,49 @@ func LangStreamRouterValidator(routerManager *routers.RouterManager) Handler {
 //	@Failure		426
 //	@Failure		404	{object}	http.ErrorSchema
 //	@Router			/v1/language/{router}/chatStream [GET]
-func LangStreamChatHandler(tel *telemetry.Telemetry, routerManager *routers.RouterManager) Handler {
+func LangStreamChatHandler(tel *telemetry.Telemetry, routerManager *routers.RouterManager, streamLimiter *StreamLimiter) Handler {
 	// TODO: expose websocket connection configs https://github.com/gofiber/contrib/tree/main/websocket
 	return websocket.New(func(c *websocket.Conn) {
 		routerID := c.Params("router")
+		apiKey, clientIP := APIKey(c.Headers), c.IP()
 		// websocket.Conn bindings https://pkg.go.dev/github.com/fasthttp/websocket?tab=doc#pkg-index
 
 		var (
-			err error
-			wg  sync.WaitGroup
+			writerWG  sync.WaitGroup
+			streamsWG sync.WaitGroup
 		)
 
 		chatStreamC := make(chan *schemas.ChatStreamMessage)
 
 		router, _ := routerManager.GetLangRouter(routerID)
 
-		defer close(chatStreamC)
+		// cancelled when the client disconnects, so in-flight provider streams are aborted right away
+		ctx, cancel := context.WithCancel(routers.WithCaller(context.Background(), callerID(apiKey, clientIP)))
+		defer cancel()
+
+		if headers, ok := c.Locals(requestHeadersLocal).(map[string][]string); ok {
+			ctx = routers.WithRequestHeaders(ctx, headers)
+		}
+
+		if requestID, ok := c.Locals(requestIDKey).(string); ok {
+			ctx = telemetry.WithRequestID(ctx, requestID)
+		}
+
 		defer c.Conn.Close()
 
-		wg.Add(1)
+		writerWG.Add(1)
 
 		go func() {
-			defer wg.Done()
+			defer writerWG.Done()
 
 			for chatStreamMsg := range chatStreamC {
-				if err = c.WriteJSON(chatStreamMsg); err != nil {
-					break
+				if chatStreamMsg.Chunk != nil && chatStreamMsg.Chunk.Deprecation != nil {
+					countDeprecatedCaller(tel, routerID, chatStreamMsg.Chunk.Deprecation, callerID(apiKey, clientIP))
+				}
+
+				if err := c.WriteJSON(chatStreamMsg); err != nil {
+					// the client is gone, keep draining the channel until all streams are cancelled
+					cancel()
 				}
 			}
 		}()
@@ -144,7 +737,7 @@ func LangStreamChatHandler(tel *telemetry.Telemetry, routerManager *routers.Rout
 		for {
 			var chatRequest schemas.ChatStreamRequest
 
-			if err = c.ReadJSON(&chatRequest); err != nil {
+			if err := c.ReadJSON(&chatRequest); err != nil {
 				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
 					tel.L().Warn("Streaming Chat connection is closed", zap.Error(err), zap.String("routerID", routerID))
 				}
@@ -154,17 +747,50 @@ func LangStreamChatHandler(tel *telemetry.Telemetry, routerManager *routers.Rout
 				break
 			}
 
-			// TODO: handle termination gracefully
-			wg.Add(1)
+			releaseStream, limitErr := streamLimiter.Acquire(apiKey, clientIP)
+			if limitErr != nil {
+				tel.M().Counter(streamRejectedMetric).Inc()
+				tel.L().Warn(
+					"Streaming chat request is rejected",
+					zap.Error(limitErr),
+					zap.String("routerID", routerID),
+					zap.String("clientIP", clientIP),
+				)
+
+				errCode := schemas.TooManyStreams
+
+				if errors.Is(limitErr, ErrServerShuttingDown) {
+					errCode = schemas.ServerShuttingDown
+				}
+
+				chatStreamC <- schemas.NewChatStreamError(
+					chatRequest.ID,
+					routerID,
+					errCode,
+					limitErr.Error(),
+					chatRequest.Metadata,
+					&schemas.ErrorReason,
+				)
+
+				continue
+			}
+
+			streamsWG.Add(1)
 
 			go func(chatRequest schemas.ChatStreamRequest) {
-				defer wg.Done()
+				defer streamsWG.Done()
+				defer releaseStream()
+				defer RecoverChatStream(tel, routerID, &chatRequest, chatStreamC)
 
-				router.ChatStream(context.Background(), &chatRequest, chatStreamC)
+				router.ChatStream(ctx, &chatRequest, chatStreamC)
 			}(chatRequest)
 		}
 
-		wg.Wait()
+		cancel()
+		streamsWG.Wait()
+
+		close(chatStreamC)
+		writerWG.Wait()
 	})
 }
 
@@ -191,6 +817,74 @@ func LangRoutersHandler(routerManager *routers.RouterManager) Handler {
 	}
 }
 
+// LangModelsHandler
+//
+//	@id				glide-language-models
+//	@Summary		Language Router Models
+//	@Description	Retrieve capabilities and upstream models of the router models (cached)
+//	@tags			Language
+//	@Param			router	path	string	true	"Router ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	http.ModelListSchema
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/language/{router}/models [GET]
+func LangModelsHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		metadata, err := routerManager.GetModelMetadata(c.Context(), c.Params("router"))
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(ModelListSchema{Models: metadata})
+	}
+}
+
+// LangRouterHealthHandler
+//
+//	@id				glide-language-router-health
+//	@Summary		Language Router Health
+//	@Description	Retrieve health, error budgets & latencies of the router models and which models the router would pick next
+//	@tags			Language
+//	@Param			router	path	string	true	"Router ID"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.RouterHealth
+//	@Failure		404	{object}	http.ErrorSchema
+//	@Router			/v1/language/{router}/health [GET]
+func LangRouterHealthHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		router, err := routerManager.GetLangRouter(c.Params("router"))
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusNotFound, err),
+				Message: err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(router.Health())
+	}
+}
+
+// ConfigReloadHandler
+//
+//	@id				glide-config-reload
+//	@Summary		Config Reload Status
+//	@Description	Retrieve the outcome of the last config reload triggered by SIGHUP or the config file change
+//	@tags			Operations
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	http.ConfigReloadSchema
+//	@Router			/v1/config/reload/ [GET]
+func ConfigReloadHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(ConfigReloadSchema{LastReload: routerManager.LastReload()})
+	}
+}
+
 // HealthHandler
 //
 //	@id			glide-health
@@ -207,6 +901,7 @@ func HealthHandler(c *fiber.Ctx) error {
 
 func NotFoundHandler(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusNotFound).JSON(ErrorSchema{
+		Code:    statusErrorCode(fiber.StatusNotFound),
 		Message: "The route is not found",
 	})
 }
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+func TestLangChatHandler_DryRun(t *testing.T) {
+	routerManager := newOpenAPIRouterManager(t, "default")
+	defer routerManager.Shutdown()
+
+	app := fiber.New()
+	app.Post("/v1/language/:router/chat/", LangChatHandler(telemetry.NewTelemetryMock(), routerManager))
+
+	req := httptest.NewRequest(
+		fiber.MethodPost,
+		"/v1/language/default/chat/",
+		strings.NewReader(`{"message": {"role": "user", "content": "tell me a dad joke"}, "dryRun": true}`),
+	)
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body map[string]any
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "openai", body["model_id"])
+	require.Contains(t, body, "dryRun")
+	require.NotContains(t, body, "dry_run")
+}
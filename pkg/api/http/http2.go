@@ -0,0 +1,419 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+)
+
+// protocolSniffTimeout limits how long a new connection may take to complete the TLS handshake or to send its first bytes
+const protocolSniffTimeout = 10 * time.Second
+
+// HTTP2Config enables HTTP/2 next to HTTP/1.1.
+//
+//	Over TLS, HTTP/2 is negotiated via ALPN. Plaintext HTTP/2 (h2c) is served to clients with prior knowledge
+//	(e.g. gRPC-style clients or `curl --http2-prior-knowledge`), the HTTP/1.1 Upgrade mechanism is not supported.
+//	Websocket streaming always stays on HTTP/1.1 connections
+type HTTP2Config struct {
+	Enabled              bool   `yaml:"enabled"`
+	H2C                  bool   `yaml:"h2c"`                                               // serve HTTP/2 on plaintext connections
+	MaxConcurrentStreams uint32 `yaml:"max_concurrent_streams" validate:"omitempty,gte=1"` // max number of requests served at the same time per connection
+}
+
+func DefaultHTTP2Config() *HTTP2Config {
+	return &HTTP2Config{
+		MaxConcurrentStreams: 250,
+	}
+}
+
+func (cfg *HTTP2Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultHTTP2Config()
+
+	type plain HTTP2Config // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
+}
+
+// h2StreamCtxKey keeps the HTTP/2 stream context in the request locals
+type h2StreamCtxKey struct{}
+
+// h2StreamContext returns the context of the HTTP/2 stream the request came from (if any).
+// The context is cancelled when the client resets the stream or disconnects
+func h2StreamContext(c *fiber.Ctx) (context.Context, bool) {
+	ctx, ok := c.Locals(h2StreamCtxKey{}).(context.Context)
+
+	return ctx, ok
+}
+
+// h2Server serves HTTP/2 connections with the fiber app, while HTTP/1.1 connections are passed to fasthttp as usual
+type h2Server struct {
+	config    *HTTP2Config
+	telemetry *telemetry.Telemetry
+	server    *http2.Server
+
+	mu       sync.Mutex
+	conns    map[net.Conn]struct{}
+	inFlight sync.WaitGroup
+}
+
+func newH2Server(config *HTTP2Config, tel *telemetry.Telemetry) *h2Server {
+	return &h2Server{
+		config:    config,
+		telemetry: tel,
+		server: &http2.Server{
+			MaxConcurrentStreams: config.MaxConcurrentStreams,
+		},
+		conns: make(map[net.Conn]struct{}),
+	}
+}
+
+// Listener wraps the listener, so it only returns HTTP/1.1 connections. HTTP/2 connections are served by the h2Server
+func (s *h2Server) Listener(listener net.Listener, tlsConfig *tls.Config, app *fiber.App) net.Listener {
+	if tlsConfig != nil {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.NextProtos = []string{http2.NextProtoTLS, "http/1.1"}
+	}
+
+	h2Listener := &protocolListener{
+		Listener:  listener,
+		tlsConfig: tlsConfig,
+		h2c:       s.config.H2C,
+		serveH2:   s.serveConn,
+		handler:   s.handler(app),
+		logger:    s.telemetry.L(),
+		connC:     make(chan net.Conn),
+		errC:      make(chan error),
+		closed:    make(chan struct{}),
+	}
+
+	go h2Listener.acceptLoop()
+
+	return h2Listener
+}
+
+func (s *h2Server) serveConn(conn net.Conn, handler http.Handler) {
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	s.server.ServeConn(conn, &http2.ServeConnOpts{Handler: handler})
+}
+
+// Shutdown waits for in-flight HTTP/2 requests to finish and closes HTTP/2 connections
+func (s *h2Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	var err error
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+
+	return err
+}
+
+// handler bridges net/http HTTP/2 requests to the fiber app
+func (s *h2Server) handler(app *fiber.App) http.HandlerFunc {
+	appHandler := app.Handler()
+	logger := zap.NewStdLog(s.telemetry.L())
+	bodyLimit := int64(app.Config().BodyLimit)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.inFlight.Add(1)
+		defer s.inFlight.Done()
+
+		var reqCtx fasthttp.RequestCtx
+
+		reqCtx.Init2(newH2StreamConn(r), logger, false)
+
+		req := &reqCtx.Request
+
+		req.Header.SetMethod(r.Method)
+		req.SetRequestURI(r.RequestURI)
+		req.SetHost(r.Host)
+
+		for name, values := range r.Header {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+
+		if r.Body != nil {
+			bodySize, err := io.Copy(req.BodyWriter(), http.MaxBytesReader(w, r.Body, bodyLimit))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+
+				if errors.As(err, &maxBytesErr) {
+					http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+					return
+				}
+
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+				return
+			}
+
+			req.Header.SetContentLength(int(bodySize))
+		}
+
+		reqCtx.SetUserValue(h2StreamCtxKey{}, r.Context())
+
+		appHandler(&reqCtx)
+
+		resp := &reqCtx.Response
+		body := resp.Body()
+
+		resp.Header.VisitAll(func(name, value []byte) {
+			headerName := string(name)
+
+			switch headerName {
+			case fiber.HeaderConnection, fiber.HeaderTransferEncoding, fiber.HeaderKeepAlive, fiber.HeaderContentLength:
+				// connection-specific headers are not allowed in HTTP/2
+				return
+			}
+
+			w.Header().Add(headerName, string(value))
+		})
+
+		w.Header().Set(fiber.HeaderContentLength, strconv.Itoa(len(body)))
+		w.WriteHeader(resp.StatusCode())
+
+		_, _ = w.Write(body)
+	}
+}
+
+// h2StreamConn represents an HTTP/2 stream as a connection for fasthttp. Reads & writes go through the HTTP/2 server
+type h2StreamConn struct {
+	localAddr  net.Addr
+	remoteAddr net.Addr
+}
+
+func newH2StreamConn(r *http.Request) net.Conn {
+	conn := h2StreamConn{
+		localAddr:  &net.TCPAddr{},
+		remoteAddr: &net.TCPAddr{},
+	}
+
+	if remoteAddr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		conn.remoteAddr = remoteAddr
+	}
+
+	if localAddr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		conn.localAddr = localAddr
+	}
+
+	if r.TLS != nil {
+		return &h2TLSStreamConn{h2StreamConn: conn, state: *r.TLS}
+	}
+
+	return &conn
+}
+
+func (c *h2StreamConn) Read(_ []byte) (int, error)         { return 0, io.EOF }
+func (c *h2StreamConn) Write(_ []byte) (int, error)        { return 0, io.ErrClosedPipe }
+func (c *h2StreamConn) Close() error                       { return nil }
+func (c *h2StreamConn) LocalAddr() net.Addr                { return c.localAddr }
+func (c *h2StreamConn) RemoteAddr() net.Addr               { return c.remoteAddr }
+func (c *h2StreamConn) SetDeadline(_ time.Time) error      { return nil }
+func (c *h2StreamConn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *h2StreamConn) SetWriteDeadline(_ time.Time) error { return nil }
+
+// h2TLSStreamConn exposes the TLS connection state (e.g. client certs) to fasthttp
+type h2TLSStreamConn struct {
+	h2StreamConn
+	state tls.ConnectionState
+}
+
+func (c *h2TLSStreamConn) Handshake() error                     { return nil }
+func (c *h2TLSStreamConn) ConnectionState() tls.ConnectionState { return c.state }
+
+// protocolListener accepts connections, finds out what protocol they speak,
+// passes HTTP/2 connections to the HTTP/2 server and returns HTTP/1.1 ones from Accept()
+type protocolListener struct {
+	net.Listener
+	tlsConfig *tls.Config
+	h2c       bool
+	serveH2   func(conn net.Conn, handler http.Handler)
+	handler   http.Handler
+	logger    *zap.Logger
+
+	connC     chan net.Conn
+	errC      chan error
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *protocolListener) acceptLoop() {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			select {
+			case l.errC <- err:
+			case <-l.closed:
+				return
+			}
+
+			var netErr net.Error
+
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				// temporary errors are retried by the server
+				continue
+			}
+
+			return
+		}
+
+		go l.route(conn)
+	}
+}
+
+func (l *protocolListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.connC:
+		return conn, nil
+	case err := <-l.errC:
+		return nil, err
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *protocolListener) Close() error {
+	var err error
+
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		err = l.Listener.Close()
+	})
+
+	return err
+}
+
+func (l *protocolListener) route(conn net.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(protocolSniffTimeout))
+
+	if l.tlsConfig != nil {
+		tlsConn := tls.Server(conn, l.tlsConfig)
+
+		if err := tlsConn.Handshake(); err != nil {
+			l.logger.Debug("TLS handshake failed", zap.String("remoteAddr", conn.RemoteAddr().String()), zap.Error(err))
+			_ = conn.Close()
+
+			return
+		}
+
+		_ = conn.SetReadDeadline(time.Time{})
+
+		if tlsConn.ConnectionState().NegotiatedProtocol == http2.NextProtoTLS {
+			l.serveH2(tlsConn, l.handler)
+			return
+		}
+
+		l.pass(tlsConn)
+
+		return
+	}
+
+	if !l.h2c {
+		_ = conn.SetReadDeadline(time.Time{})
+		l.pass(conn)
+
+		return
+	}
+
+	preface, err := readPreface(conn)
+
+	_ = conn.SetReadDeadline(time.Time{})
+
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	sniffedConn := &sniffedConn{Conn: conn, reader: io.MultiReader(bytes.NewReader(preface), conn)}
+
+	if bytes.Equal(preface, []byte(http2.ClientPreface)) {
+		l.serveH2(sniffedConn, l.handler)
+		return
+	}
+
+	l.pass(sniffedConn)
+}
+
+func (l *protocolListener) pass(conn net.Conn) {
+	select {
+	case l.connC <- conn:
+	case <-l.closed:
+		_ = conn.Close()
+	}
+}
+
+// readPreface reads the connection until it's clear whether it starts with the HTTP/2 client preface.
+// HTTP/1.1 requests are detected as soon as they diverge from the preface
+func readPreface(conn net.Conn) ([]byte, error) {
+	preface := make([]byte, len(http2.ClientPreface))
+	readBytes := 0
+
+	for readBytes < len(preface) {
+		n, err := conn.Read(preface[readBytes:])
+		readBytes += n
+
+		if !bytes.HasPrefix([]byte(http2.ClientPreface), preface[:readBytes]) {
+			return preface[:readBytes], nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return preface, nil
+}
+
+// sniffedConn replays bytes read while detecting the protocol
+type sniffedConn struct {
+	net.Conn
+	reader io.Reader
+}
+
+func (c *sniffedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// NetConn returns the underlying connection
+func (c *sniffedConn) NetConn() net.Conn {
+	return c.Conn
+}
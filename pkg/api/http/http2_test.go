@@ -0,0 +1,130 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+	"golang.org/x/net/http2"
+)
+
+func newHTTP2TestApp(t *testing.T, tlsConfig *tls.Config) (string, func()) {
+	h2Config := DefaultHTTP2Config()
+	h2Config.Enabled = true
+	h2Config.H2C = true
+
+	h2Srv := newH2Server(h2Config, telemetry.NewTelemetryMock())
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(ClientDisconnectMiddleware(telemetry.NewTelemetryMock()))
+	app.Post("/echo/", func(c *fiber.Ctx) error {
+		_, isH2 := h2StreamContext(c)
+
+		return c.JSON(fiber.Map{
+			"h2":   isH2,
+			"tls":  c.Protocol() == "https",
+			"body": string(c.Body()),
+		})
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		_ = app.Listener(h2Srv.Listener(listener, tlsConfig, app))
+	}()
+
+	return listener.Addr().String(), func() {
+		_ = app.Shutdown()
+		_ = h2Srv.Shutdown(context.Background())
+	}
+}
+
+func postEcho(t *testing.T, client *http.Client, url string) (string, string) {
+	resp, err := client.Post(url, fiber.MIMEApplicationJSON, strings.NewReader(`{"message":"hi"}`))
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	return resp.Proto, string(body)
+}
+
+func TestHTTP2_H2CPriorKnowledge(t *testing.T) {
+	addr, shutdown := newHTTP2TestApp(t, nil)
+	defer shutdown()
+
+	h2cClient := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var dialer net.Dialer
+
+				return dialer.DialContext(ctx, network, addr)
+			},
+		},
+	}
+
+	proto, body := postEcho(t, h2cClient, fmt.Sprintf("http://%v/echo/", addr))
+	require.Equal(t, "HTTP/2.0", proto)
+	require.JSONEq(t, `{"h2":true,"tls":false,"body":"{\"message\":\"hi\"}"}`, body)
+
+	// HTTP/1.1 clients are served on the same port
+	proto, body = postEcho(t, &http.Client{}, fmt.Sprintf("http://%v/echo/", addr))
+	require.Equal(t, "HTTP/1.1", proto)
+	require.JSONEq(t, `{"h2":false,"tls":false,"body":"{\"message\":\"hi\"}"}`, body)
+}
+
+func TestHTTP2_TLS(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir(), "localhost")
+
+	cfg := DefaultTLSConfig()
+	cfg.CertFile, cfg.KeyFile = certFile, keyFile
+
+	reloader, err := NewCertReloader(cfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	tlsConfig, err := cfg.ToTLSConfig(reloader)
+	require.NoError(t, err)
+
+	addr, shutdown := newHTTP2TestApp(t, tlsConfig)
+	defer shutdown()
+
+	rootCAs := x509.NewCertPool()
+	rawCert, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	require.True(t, rootCAs.AppendCertsFromPEM(rawCert))
+
+	url := fmt.Sprintf("https://localhost:%v/echo/", addr[strings.LastIndex(addr, ":")+1:])
+
+	h2Client := &http.Client{
+		Transport: &http2.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}},
+	}
+
+	proto, body := postEcho(t, h2Client, url)
+	require.Equal(t, "HTTP/2.0", proto)
+	require.JSONEq(t, `{"h2":true,"tls":true,"body":"{\"message\":\"hi\"}"}`, body)
+
+	h1Client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12, NextProtos: []string{"http/1.1"}},
+		},
+	}
+
+	proto, body = postEcho(t, h1Client, url)
+	require.Equal(t, "HTTP/1.1", proto)
+	require.JSONEq(t, `{"h2":false,"tls":true,"body":"{\"message\":\"hi\"}"}`, body)
+}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// RequestTimeoutHeader lets clients tell how long they are going to wait for the response (e.g. 30s or 30 for seconds),
+	// so the gateway stops working on requests the clients have given up on
+	RequestTimeoutHeader = "X-Request-Timeout"
+	// grpcTimeoutHeader is the gRPC way to pass the deadline (e.g. 30S or 500m)
+	grpcTimeoutHeader = "Grpc-Timeout"
+)
+
+var ErrInvalidClientTimeout = errors.New("request timeout header must be a positive duration")
+
+// RouteLimitConfig overrides request limits for routes under the path prefix
+type RouteLimitConfig struct {
+	Path               string         `yaml:"path" validate:"required"` // route path prefix (e.g. /v1/audio/)
+	MaxRequestBodySize *int           `yaml:"max_request_body_size"`
+	Timeout            *time.Duration `yaml:"timeout"` // how long requests may be processed, websocket streams are not limited
+}
+
+// routeLimit finds the limits of the route with the longest matching path prefix
+func (cfg *ServerConfig) routeLimit(path string) *RouteLimitConfig {
+	var routeLimit *RouteLimitConfig
+
+	for idx := range cfg.RouteLimits {
+		limit := &cfg.RouteLimits[idx]
+
+		if strings.HasPrefix(path, limit.Path) && (routeLimit == nil || len(limit.Path) > len(routeLimit.Path)) {
+			routeLimit = limit
+		}
+	}
+
+	return routeLimit
+}
+
+// serverBodyLimit is the largest body size allowed on any route.
+// The server enforces it while reading requests, while tighter per-route limits are checked by RequestLimitsMiddleware
+func (cfg *ServerConfig) serverBodyLimit() *int {
+	bodyLimit := cfg.MaxRequestBodySize
+
+	for idx := range cfg.RouteLimits {
+		routeBodyLimit := cfg.RouteLimits[idx].MaxRequestBodySize
+
+		if routeBodyLimit != nil && (bodyLimit == nil || *routeBodyLimit > *bodyLimit) {
+			bodyLimit = routeBodyLimit
+		}
+	}
+
+	return bodyLimit
+}
+
+// RequestLimitsMiddleware enforces the request body size & processing timeout of the route
+func RequestLimitsMiddleware(cfg *ServerConfig) Handler {
+	return func(c *fiber.Ctx) error {
+		bodyLimit := cfg.MaxRequestBodySize
+
+		var timeout *time.Duration
+
+		if routeLimit := cfg.routeLimit(c.Path()); routeLimit != nil {
+			if routeLimit.MaxRequestBodySize != nil {
+				bodyLimit = routeLimit.MaxRequestBodySize
+			}
+
+			timeout = routeLimit.Timeout
+		}
+
+		if bodyLimit != nil && (c.Request().Header.ContentLength() > *bodyLimit || len(c.Body()) > *bodyLimit) {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(ErrorSchema{
+				Code:      statusErrorCode(fiber.StatusRequestEntityTooLarge),
+				Message:   fmt.Sprintf("request body is too large, the limit is %v bytes", *bodyLimit),
+				RequestID: RequestID(c),
+			})
+		}
+
+		if websocket.IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+
+		clientTimeout, err := ClientTimeout(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:      errorCode(fiber.StatusBadRequest, err),
+				Message:   err.Error(),
+				RequestID: RequestID(c),
+			})
+		}
+
+		if clientTimeout != nil {
+			// clients may shorten the request deadline, but not extend it past the server limits
+			for _, limit := range []*time.Duration{clientTimeout, cfg.MaxClientTimeout} {
+				if limit != nil && (timeout == nil || *limit < *timeout) {
+					timeout = limit
+				}
+			}
+		}
+
+		if timeout == nil {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), *timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+
+		err = c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return c.Status(fiber.StatusRequestTimeout).JSON(ErrorSchema{
+				Code:      statusErrorCode(fiber.StatusRequestTimeout),
+				Message:   fmt.Sprintf("request processing took longer than %v", *timeout),
+				RequestID: RequestID(c),
+			})
+		}
+
+		return err
+	}
+}
+
+// ClientTimeout parses the deadline the client has given the request or returns nil if there is none
+func ClientTimeout(c *fiber.Ctx) (*time.Duration, error) {
+	var (
+		timeout time.Duration
+		err     error
+	)
+
+	switch {
+	case c.Get(RequestTimeoutHeader) != "":
+		timeout, err = parseRequestTimeout(c.Get(RequestTimeoutHeader))
+	case c.Get(grpcTimeoutHeader) != "":
+		timeout, err = parseGRPCTimeout(c.Get(grpcTimeoutHeader))
+	default:
+		return nil, nil
+	}
+
+	if err != nil || timeout <= 0 {
+		return nil, ErrInvalidClientTimeout
+	}
+
+	return &timeout, nil
+}
+
+// parseRequestTimeout accepts Go durations (e.g. 1m30s) and seconds (e.g. 2.5)
+func parseRequestTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+var grpcTimeoutUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+// parseGRPCTimeout parses up to 8 digits followed by the unit as the gRPC protocol defines
+func parseGRPCTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, ErrInvalidClientTimeout
+	}
+
+	unit, found := grpcTimeoutUnits[value[len(value)-1]]
+	if !found {
+		return 0, ErrInvalidClientTimeout
+	}
+
+	amount, err := strconv.ParseUint(value[:len(value)-1], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidClientTimeout
+	}
+
+	return time.Duration(amount) * unit, nil
+}
+
+// ErrorHandler responds with structured errors to requests failed before reaching handlers
+// (e.g. too large request bodies or timed out reads)
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	statusCode := fiber.StatusInternalServerError
+
+	var fiberErr *fiber.Error
+
+	if errors.As(err, &fiberErr) {
+		statusCode = fiberErr.Code
+	}
+
+	return c.Status(statusCode).JSON(ErrorSchema{
+		Code:      errorCode(statusCode, err),
+		Message:   err.Error(),
+		RequestID: RequestID(c),
+	})
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+)
+
+func newLimitsTestApp(cfg *ServerConfig) *fiber.App {
+	app := cfg.ToServer()
+	app.Use(RequestLimitsMiddleware(cfg))
+
+	echoLen := func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"size": len(c.Body())})
+	}
+
+	app.Post("/v1/language/:router/chat/", echoLen)
+	app.Post("/v1/audio/:router/transcriptions/", echoLen)
+	app.Post("/v1/language/:router/slow/", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{Message: c.UserContext().Err().Error()})
+	})
+
+	return app
+}
+
+func postBody(t *testing.T, app *fiber.App, path string, size int) (int, ErrorSchema) {
+	req := httptest.NewRequest(fiber.MethodPost, path, bytes.NewReader(make([]byte, size)))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	var errSchema ErrorSchema
+
+	if resp.StatusCode != fiber.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&errSchema))
+	}
+
+	return resp.StatusCode, errSchema
+}
+
+func TestRequestLimitsMiddleware_BodySize(t *testing.T) {
+	bodyLimit, audioBodyLimit := 1024, 4096
+
+	cfg := DefaultServerConfig()
+	cfg.MaxRequestBodySize = &bodyLimit
+	cfg.RouteLimits = []RouteLimitConfig{{Path: "/v1/audio/", MaxRequestBodySize: &audioBodyLimit}}
+
+	app := newLimitsTestApp(cfg)
+
+	status, _ := postBody(t, app, "/v1/language/default/chat/", 512)
+	require.Equal(t, fiber.StatusOK, status)
+
+	status, errSchema := postBody(t, app, "/v1/language/default/chat/", 2048)
+	require.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	require.Contains(t, errSchema.Message, "request body is too large")
+
+	// audio files are allowed to be larger
+	status, _ = postBody(t, app, "/v1/audio/default/transcriptions/", 2048)
+	require.Equal(t, fiber.StatusOK, status)
+}
+
+func TestErrorHandler(t *testing.T) {
+	app := DefaultServerConfig().ToServer()
+	app.Post("/v1/language/:router/chat/", func(_ *fiber.Ctx) error {
+		// the way the server reports bodies over the server-wide limit
+		return fiber.ErrRequestEntityTooLarge
+	})
+
+	status, errSchema := postBody(t, app, "/v1/language/default/chat/", 10)
+	require.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	require.Equal(t, "Request Entity Too Large", errSchema.Message)
+	require.Equal(t, schemas.InvalidRequest, errSchema.Code)
+}
+
+func TestRequestLimitsMiddleware_Timeout(t *testing.T) {
+	timeout := 20 * time.Millisecond
+
+	cfg := DefaultServerConfig()
+	cfg.RouteLimits = append(cfg.RouteLimits, RouteLimitConfig{Path: "/v1/language/", Timeout: &timeout})
+
+	app := newLimitsTestApp(cfg)
+
+	status, errSchema := postBody(t, app, "/v1/language/default/slow/", 10)
+	require.Equal(t, fiber.StatusRequestTimeout, status)
+	require.Contains(t, errSchema.Message, "took longer than 20ms")
+	require.Equal(t, schemas.Timeout, errSchema.Code)
+}
+
+func TestServerConfig_RouteLimit(t *testing.T) {
+	audioLimit, transcriptionLimit := 10, 20
+
+	cfg := DefaultServerConfig()
+	cfg.RouteLimits = []RouteLimitConfig{
+		{Path: "/v1/audio/", MaxRequestBodySize: &audioLimit},
+		{Path: "/v1/audio/default/transcriptions/", MaxRequestBodySize: &transcriptionLimit},
+	}
+
+	require.Equal(t, &transcriptionLimit, cfg.routeLimit("/v1/audio/default/transcriptions/").MaxRequestBodySize)
+	require.Equal(t, &audioLimit, cfg.routeLimit("/v1/audio/other/transcriptions/").MaxRequestBodySize)
+	require.Nil(t, cfg.routeLimit("/v1/language/default/chat/"))
+	require.Equal(t, cfg.MaxRequestBodySize, cfg.serverBodyLimit())
+}
+
+func TestRequestLimitsMiddleware_ClientTimeout(t *testing.T) {
+	routeTimeout, maxClientTimeout := 20*time.Millisecond, time.Second
+
+	cfg := DefaultServerConfig()
+	cfg.MaxClientTimeout = &maxClientTimeout
+	cfg.RouteLimits = append(cfg.RouteLimits, RouteLimitConfig{Path: "/v1/language/other/", Timeout: &routeTimeout})
+
+	app := newLimitsTestApp(cfg)
+
+	tests := []struct {
+		name    string
+		path    string
+		header  string
+		value   string
+		status  int
+		message string
+	}{
+		{"request timeout", "/v1/language/default/slow/", RequestTimeoutHeader, "30ms", fiber.StatusRequestTimeout, "took longer than 30ms"},
+		{"request timeout in seconds", "/v1/language/default/slow/", RequestTimeoutHeader, "0.04", fiber.StatusRequestTimeout, "took longer than 40ms"},
+		{"grpc timeout", "/v1/language/default/slow/", grpcTimeoutHeader, "50m", fiber.StatusRequestTimeout, "took longer than 50ms"},
+		{"capped by server", "/v1/language/default/slow/", RequestTimeoutHeader, "1h", fiber.StatusRequestTimeout, "took longer than 1s"},
+		{"capped by route", "/v1/language/other/slow/", RequestTimeoutHeader, "10s", fiber.StatusRequestTimeout, "took longer than 20ms"},
+		{"invalid", "/v1/language/default/slow/", RequestTimeoutHeader, "soon", fiber.StatusBadRequest, ErrInvalidClientTimeout.Error()},
+		{"negative", "/v1/language/default/slow/", RequestTimeoutHeader, "-1s", fiber.StatusBadRequest, ErrInvalidClientTimeout.Error()},
+		{"invalid grpc unit", "/v1/language/default/slow/", grpcTimeoutHeader, "10x", fiber.StatusBadRequest, ErrInvalidClientTimeout.Error()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, test.path, bytes.NewReader([]byte("{}")))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			req.Header.Set(test.header, test.value)
+
+			resp, err := app.Test(req, -1)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			var errSchema ErrorSchema
+
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&errSchema))
+			require.Equal(t, test.status, resp.StatusCode)
+			require.Contains(t, errSchema.Message, test.message)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const (
+	requestIDKey = "requestid"
+	panicMetric  = "http.panics"
+)
+
+// RequestIDMiddleware assigns a unique ID to each request (or reuses the one provided by the client)
+func RequestIDMiddleware() Handler {
+	return requestid.New(requestid.Config{
+		Header:     fiber.HeaderXRequestID,
+		ContextKey: requestIDKey,
+	})
+}
+
+// RequestID returns the ID assigned to the request by the RequestIDMiddleware
+func RequestID(c *fiber.Ctx) string {
+	if reqID, ok := c.Locals(requestIDKey).(string); ok {
+		return reqID
+	}
+
+	return ""
+}
+
+// PanicRecoveryMiddleware prevents panics in handlers from taking down the request processing.
+//
+//	The panic is logged with the full stack trace & the router/model context (when available),
+//	counted, and the client receives a generic internal server error
+func PanicRecoveryMiddleware(tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+
+			tel.M().Counter(panicMetric).Inc()
+
+			fields := []zap.Field{
+				zap.String("requestID", RequestID(c)),
+				zap.String("method", c.Method()),
+				zap.String("path", c.Path()),
+				zap.Any("panic", value),
+			}
+
+			var modelPanic *routers.ModelPanic
+
+			if panicErr, ok := value.(error); ok && errors.As(panicErr, &modelPanic) {
+				fields = append(
+					fields,
+					zap.String("routerID", modelPanic.RouterID),
+					zap.String("modelID", modelPanic.ModelID),
+					zap.String("provider", modelPanic.Provider),
+					zap.ByteString("stacktrace", modelPanic.Stack),
+				)
+			} else {
+				if routerID := c.Params("router"); routerID != "" {
+					fields = append(fields, zap.String("routerID", routerID))
+				}
+
+				fields = append(fields, zap.ByteString("stacktrace", debug.Stack()))
+			}
+
+			tel.L().Error("Recovered from panic during request processing", fields...)
+
+			err = c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:      statusErrorCode(fiber.StatusInternalServerError),
+				Message:   fmt.Sprintf("internal error occurred while processing the request (request ID: %v)", RequestID(c)),
+				RequestID: RequestID(c),
+			})
+		}()
+
+		return c.Next()
+	}
+}
+
+// RecoverChatStream is the PanicRecoveryMiddleware counterpart for streaming chat requests
+// that are served outside the HTTP handler goroutine. Must be deferred.
+func RecoverChatStream(
+	tel *telemetry.Telemetry,
+	routerID string,
+	req *schemas.ChatStreamRequest,
+	chatStreamC chan<- *schemas.ChatStreamMessage,
+) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	tel.M().Counter(panicMetric).Inc()
+
+	fields := []zap.Field{
+		zap.String("routerID", routerID),
+		zap.String("streamRequestID", req.ID),
+		zap.Any("panic", value),
+	}
+
+	var modelPanic *routers.ModelPanic
+
+	if panicErr, ok := value.(error); ok && errors.As(panicErr, &modelPanic) {
+		fields = append(
+			fields,
+			zap.String("modelID", modelPanic.ModelID),
+			zap.String("provider", modelPanic.Provider),
+			zap.ByteString("stacktrace", modelPanic.Stack),
+		)
+	} else {
+		fields = append(fields, zap.ByteString("stacktrace", debug.Stack()))
+	}
+
+	tel.L().Error("Recovered from panic during streaming chat processing", fields...)
+
+	chatStreamC <- schemas.NewChatStreamError(
+		req.ID,
+		routerID,
+		schemas.UnknownError,
+		"internal error occurred while processing the request",
+		req.Metadata,
+		&schemas.ErrorReason,
+	)
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+)
+
+func TestPanicRecoveryMiddleware_ReturnsInternalError(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Use(PanicRecoveryMiddleware(tel))
+	app.Post("/v1/language/:router/chat/", func(c *fiber.Ctx) error {
+		panic(routers.NewModelPanic(c.Params("router"), "openai", "openai", "index out of range"))
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/language/default/chat/", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-123")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	var errSchema ErrorSchema
+
+	require.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errSchema))
+	require.Equal(t, "req-123", errSchema.RequestID)
+	require.Equal(t, int64(1), tel.M().Counter(panicMetric).Value())
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const anyRouter = "*"
+
+// ClientAuthConfig requires clients to present certificates signed by the given CA (mTLS)
+// and optionally limits which routers each client identity could use
+type ClientAuthConfig struct {
+	CAFile   string           `yaml:"ca_file" validate:"required"`
+	Optional bool             `yaml:"optional"` // let clients without certs in (certs are still verified if presented)
+	Rules    []ClientAuthRule `yaml:"rules" validate:"dive"`
+}
+
+// ClientAuthRule allows the client with the given identity (the cert common name or any of its SANs) to use the routers
+type ClientAuthRule struct {
+	Identity string   `yaml:"identity" validate:"required"`
+	Routers  []string `yaml:"routers" validate:"required,min=1"` // router IDs or "*" for all routers
+}
+
+// clientCAs loads the CA bundle client certs are verified with
+func (cfg *ClientAuthConfig) clientCAs() (*x509.CertPool, error) {
+	rawCAs, err := os.ReadFile(filepath.Clean(cfg.CAFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client CA file: %w", err)
+	}
+
+	certPool := x509.NewCertPool()
+
+	if !certPool.AppendCertsFromPEM(rawCAs) {
+		return nil, errors.New("no valid certs found in the client CA file")
+	}
+
+	return certPool, nil
+}
+
+func (cfg *ClientAuthConfig) clientAuthType() tls.ClientAuthType {
+	if cfg.Optional {
+		return tls.VerifyClientCertIfGiven
+	}
+
+	return tls.RequireAndVerifyClientCert
+}
+
+// allowed checks if any of the client identities is allowed to use the router
+func (cfg *ClientAuthConfig) allowed(identities []string, routerID string) bool {
+	for _, rule := range cfg.Rules {
+		if !slices.Contains(identities, rule.Identity) {
+			continue
+		}
+
+		if slices.Contains(rule.Routers, anyRouter) || slices.Contains(rule.Routers, routerID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientIdentities returns the common name & SANs of the cert
+func clientIdentities(cert *x509.Certificate) []string {
+	identities := make([]string, 0, 1+len(cert.DNSNames)+len(cert.EmailAddresses)+len(cert.URIs))
+
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+
+	identities = append(identities, cert.DNSNames...)
+	identities = append(identities, cert.EmailAddresses...)
+
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+
+	return identities
+}
+
+// ClientCertAuthMiddleware authorizes access to the router by the client cert identity.
+// The cert itself is verified during the TLS handshake, so here it's only matched against the authorization rules
+func ClientCertAuthMiddleware(cfg *ClientAuthConfig) Handler {
+	return func(c *fiber.Ctx) error {
+		if len(cfg.Rules) == 0 {
+			return c.Next()
+		}
+
+		connState := c.Context().TLSConnectionState()
+		if connState == nil || len(connState.PeerCertificates) == 0 {
+			return c.Status(fiber.StatusUnauthorized).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusUnauthorized),
+				Message: "client certificate is required",
+			})
+		}
+
+		routerID := c.Params("router")
+
+		if !cfg.allowed(clientIdentities(connState.PeerCertificates[0]), routerID) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorSchema{
+				Code:    statusErrorCode(fiber.StatusForbidden),
+				Message: fmt.Sprintf("client certificate is not allowed to use router \"%v\"", routerID),
+			})
+		}
+
+		return c.Next()
+	}
+}
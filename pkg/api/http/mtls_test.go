@@ -0,0 +1,120 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+func TestClientAuthConfig_Allowed(t *testing.T) {
+	cfg := &ClientAuthConfig{
+		Rules: []ClientAuthRule{
+			{Identity: "billing-svc", Routers: []string{"billing"}},
+			{Identity: "spiffe://cluster/ns/ops/sa/admin", Routers: []string{"*"}},
+		},
+	}
+
+	require.True(t, cfg.allowed([]string{"billing-svc"}, "billing"))
+	require.False(t, cfg.allowed([]string{"billing-svc"}, "support"))
+	require.True(t, cfg.allowed([]string{"admin", "spiffe://cluster/ns/ops/sa/admin"}, "support"))
+	require.False(t, cfg.allowed([]string{"unknown"}, "billing"))
+}
+
+func TestClientCertAuthMiddleware(t *testing.T) {
+	dir := t.TempDir()
+	serverCertFile, serverKeyFile := writeSelfSignedCert(t, dir, "localhost")
+	clientCertFile, clientKeyFile := writeSelfSignedCert(t, dir, "billing-svc")
+
+	cfg := DefaultTLSConfig()
+	cfg.CertFile, cfg.KeyFile = serverCertFile, serverKeyFile
+	cfg.ClientAuth = &ClientAuthConfig{
+		CAFile: clientCertFile,
+		Rules: []ClientAuthRule{
+			{Identity: "billing-svc", Routers: []string{"billing"}},
+		},
+	}
+
+	reloader, err := NewCertReloader(cfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	tlsConfig, err := cfg.ToTLSConfig(reloader)
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use("/v1/language/:router", ClientCertAuthMiddleware(cfg.ClientAuth))
+	app.Get("/v1/language/:router/", func(c *fiber.Ctx) error {
+		return c.SendString(c.Params("router"))
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		_ = app.Listener(tls.NewListener(listener, tlsConfig))
+	}()
+
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	rootCAs := x509.NewCertPool()
+	rawCert, err := os.ReadFile(serverCertFile)
+	require.NoError(t, err)
+	require.True(t, rootCAs.AppendCertsFromPEM(rawCert))
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	require.NoError(t, err)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:      rootCAs,
+				Certificates: []tls.Certificate{clientCert},
+				MinVersion:   tls.VersionTLS12,
+			},
+		},
+	}
+
+	defer client.CloseIdleConnections()
+
+	get := func(routerID string) (int, string) {
+		resp, err := client.Get(fmt.Sprintf("https://localhost:%v/v1/language/%v/", listener.Addr().(*net.TCPAddr).Port, routerID))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("billing")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "billing", body)
+
+	status, _ = get("support")
+	require.Equal(t, http.StatusForbidden, status)
+
+	// clients without certs are rejected during the handshake
+	anonClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+		},
+	}
+
+	defer anonClient.CloseIdleConnections()
+
+	_, err = anonClient.Get(fmt.Sprintf("https://localhost:%v/v1/language/billing/", listener.Addr().(*net.TCPAddr).Port))
+	require.Error(t, err)
+}
@@ -0,0 +1,312 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"glide/docs"
+	"glide/pkg/routers"
+	"glide/pkg/version"
+)
+
+const (
+	openAPIPath          = "/openapi.json"
+	openAPIVersion       = "3.0.3"
+	swaggerDefinitionRef = "#/definitions/"
+	openAPISchemaRef     = "#/components/schemas/"
+)
+
+// DocsConfig defines how the API docs are served
+type DocsConfig struct {
+	SwaggerUI bool `yaml:"swagger_ui"` // serve Swagger UI at /v1/swagger/
+}
+
+func DefaultDocsConfig() *DocsConfig {
+	return &DocsConfig{
+		SwaggerUI: true,
+	}
+}
+
+func (cfg *DocsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultDocsConfig()
+
+	type plain DocsConfig // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
+}
+
+// OpenAPIHandler
+//
+//	@id				glide-openapi
+//	@Summary		OpenAPI Spec
+//	@Description	Retrieve the OpenAPI 3 spec of endpoints served by the gateway, router path params are limited to configured routers
+//	@tags			Operations
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}
+//	@Router			/openapi.json [GET]
+func OpenAPIHandler(cfg *ServerConfig, routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		spec, err := NewOpenAPISpec(docs.SwaggerJSON, cfg, routerManager, c.BaseURL())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorSchema{
+				Code:      errorCode(fiber.StatusInternalServerError, err),
+				Message:   err.Error(),
+				RequestID: RequestID(c),
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(spec)
+	}
+}
+
+// NewOpenAPISpec converts the Swagger 2.0 spec generated from handler annotations into the OpenAPI 3 spec
+// of the deployed gateway: only endpoints the server serves are kept,
+// and router path params are limited to routers configured at the moment
+func NewOpenAPISpec(swaggerSpec []byte, cfg *ServerConfig, routerManager *routers.RouterManager, serverURL string) (map[string]any, error) {
+	var swagger map[string]any
+
+	if err := json.Unmarshal(swaggerSpec, &swagger); err != nil {
+		return nil, fmt.Errorf("unable to parse the swagger spec: %w", err)
+	}
+
+	swagger = rewriteRefs(swagger).(map[string]any)
+
+	info, _ := swagger["info"].(map[string]any)
+	if info != nil {
+		info["version"] = version.Version
+	}
+
+	routerIDs := configuredRouterIDs(routerManager)
+	paths := make(map[string]any)
+
+	swaggerPaths, _ := swagger["paths"].(map[string]any)
+
+	for path, pathItem := range swaggerPaths {
+		group, routerKind := routeGroupOf(path)
+
+		if !cfg.Serves(group) || (group == AdminRoutes && (cfg.Admin == nil || !cfg.Admin.Enabled)) {
+			continue
+		}
+
+		knownRouterIDs, hasRouterParam := routerIDs[routerKind]
+
+		if hasRouterParam && len(knownRouterIDs) == 0 {
+			// no routers of the kind are configured, so the endpoint cannot serve anything
+			continue
+		}
+
+		operations := make(map[string]any)
+
+		for method, operation := range pathItem.(map[string]any) {
+			operations[method] = toOpenAPIOperation(operation.(map[string]any), knownRouterIDs)
+		}
+
+		paths[path] = operations
+	}
+
+	return map[string]any{
+		"openapi": openAPIVersion,
+		"info":    info,
+		"servers": []any{map[string]any{"url": serverURL}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": swagger["definitions"],
+		},
+	}, nil
+}
+
+// routeGroupOf finds out which route group serves the path & what kind of routers the path refers to (if any)
+func routeGroupOf(path string) (RouteGroup, string) {
+	switch {
+	case path == openAPIPath:
+		return DocsRoutes, ""
+	case strings.HasPrefix(path, "/v1/admin/"):
+		return AdminRoutes, ""
+	case strings.HasPrefix(path, "/v1/health/"):
+		return HealthRoutes, ""
+	case !strings.Contains(path, "{router}"):
+		return APIRoutes, ""
+	}
+
+	routerKind, _, _ := strings.Cut(strings.TrimPrefix(path, "/v1/"), "/")
+
+	return APIRoutes, routerKind
+}
+
+func configuredRouterIDs(routerManager *routers.RouterManager) map[string][]string {
+	routerIDs := map[string][]string{
+		"language":   make([]string, 0),
+		"image":      make([]string, 0),
+		"audio":      make([]string, 0),
+		"moderation": make([]string, 0),
+	}
+
+	for _, router := range routerManager.GetLangRouters() {
+		routerIDs["language"] = append(routerIDs["language"], router.ID())
+	}
+
+	for _, router := range routerManager.GetImageRouters() {
+		routerIDs["image"] = append(routerIDs["image"], router.ID())
+	}
+
+	for _, router := range routerManager.GetAudioRouters() {
+		routerIDs["audio"] = append(routerIDs["audio"], router.ID())
+	}
+
+	for _, router := range routerManager.GetModerationRouters() {
+		routerIDs["moderation"] = append(routerIDs["moderation"], router.ID())
+	}
+
+	for _, kindRouterIDs := range routerIDs {
+		slices.Sort(kindRouterIDs)
+	}
+
+	return routerIDs
+}
+
+func toOpenAPIOperation(operation map[string]any, routerIDs []string) map[string]any {
+	openAPIOperation := make(map[string]any)
+
+	for _, field := range []string{"operationId", "summary", "description", "tags"} {
+		if value, found := operation[field]; found {
+			openAPIOperation[field] = value
+		}
+	}
+
+	requestContentType := firstOr(operation["consumes"], fiber.MIMEApplicationJSON)
+	responseContentType := firstOr(operation["produces"], fiber.MIMEApplicationJSON)
+
+	params := make([]any, 0)
+	formProperties := make(map[string]any)
+	formRequired := make([]any, 0)
+
+	rawParams, _ := operation["parameters"].([]any)
+
+	for _, rawParam := range rawParams {
+		param := rawParam.(map[string]any)
+
+		switch param["in"] {
+		case "body":
+			openAPIOperation["requestBody"] = map[string]any{
+				"description": param["description"],
+				"required":    param["required"] == true,
+				"content": map[string]any{
+					requestContentType: map[string]any{"schema": param["schema"]},
+				},
+			}
+		case "formData":
+			formProperties[param["name"].(string)] = paramSchema(param)
+
+			if param["required"] == true {
+				formRequired = append(formRequired, param["name"])
+			}
+		default:
+			schema := paramSchema(param)
+
+			if param["name"] == "router" && param["in"] == "path" && len(routerIDs) > 0 {
+				schema["enum"] = routerIDs
+			}
+
+			params = append(params, map[string]any{
+				"name":        param["name"],
+				"in":          param["in"],
+				"description": param["description"],
+				"required":    param["required"] == true,
+				"schema":      schema,
+			})
+		}
+	}
+
+	if len(params) > 0 {
+		openAPIOperation["parameters"] = params
+	}
+
+	if len(formProperties) > 0 {
+		formSchema := map[string]any{"type": "object", "properties": formProperties}
+
+		if len(formRequired) > 0 {
+			formSchema["required"] = formRequired
+		}
+
+		openAPIOperation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				requestContentType: map[string]any{"schema": formSchema},
+			},
+		}
+	}
+
+	responses := make(map[string]any)
+	rawResponses, _ := operation["responses"].(map[string]any)
+
+	for statusCode, rawResponse := range rawResponses {
+		response := rawResponse.(map[string]any)
+		openAPIResponse := map[string]any{"description": response["description"]}
+
+		if schema, found := response["schema"]; found {
+			openAPIResponse["content"] = map[string]any{
+				responseContentType: map[string]any{"schema": schema},
+			}
+		}
+
+		responses[statusCode] = openAPIResponse
+	}
+
+	openAPIOperation["responses"] = responses
+
+	return openAPIOperation
+}
+
+// paramSchema moves Swagger 2.0 param type fields into the OpenAPI 3 param schema
+func paramSchema(param map[string]any) map[string]any {
+	schema := make(map[string]any)
+
+	for _, field := range []string{"type", "format", "items", "enum", "default"} {
+		if value, found := param[field]; found {
+			schema[field] = value
+		}
+	}
+
+	if schema["type"] == "file" {
+		schema["type"], schema["format"] = "string", "binary"
+	}
+
+	return schema
+}
+
+// rewriteRefs points Swagger 2.0 definition refs to OpenAPI 3 component schemas
+func rewriteRefs(node any) any {
+	switch value := node.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if ref, ok := item.(string); key == "$ref" && ok {
+				if definition, found := strings.CutPrefix(ref, swaggerDefinitionRef); found {
+					value[key] = openAPISchemaRef + definition
+				}
+
+				continue
+			}
+
+			value[key] = rewriteRefs(item)
+		}
+	case []any:
+		for idx, item := range value {
+			value[idx] = rewriteRefs(item)
+		}
+	}
+
+	return node
+}
+
+func firstOr(values any, defaultValue string) string {
+	if items, ok := values.([]any); ok && len(items) > 0 {
+		if first, ok := items[0].(string); ok {
+			return first
+		}
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/docs"
+	"glide/pkg/providers"
+	"glide/pkg/providers/openai"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+)
+
+func newOpenAPIRouterManager(t *testing.T, routerIDs ...string) *routers.RouterManager {
+	cfg := &routers.Config{}
+
+	for _, routerID := range routerIDs {
+		modelConfig := providers.DefaultLangModelConfig()
+		modelConfig.ID = "openai"
+		modelConfig.OpenAI = openai.DefaultConfig()
+		modelConfig.OpenAI.APIKey = "ABC"
+
+		routerConfig := routers.DefaultLangRouterConfig()
+		routerConfig.ID = routerID
+		routerConfig.Models = []providers.LangModelConfig{*modelConfig}
+
+		cfg.LanguageRouters = append(cfg.LanguageRouters, routerConfig)
+	}
+
+	routerManager, err := routers.NewManager(cfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	return routerManager
+}
+
+func TestNewOpenAPISpec(t *testing.T) {
+	routerManager := newOpenAPIRouterManager(t, "myrouter", "another")
+
+	spec, err := NewOpenAPISpec(docs.SwaggerJSON, DefaultServerConfig(), routerManager, "http://localhost:9099")
+	require.NoError(t, err)
+
+	require.Equal(t, openAPIVersion, spec["openapi"])
+
+	paths := spec["paths"].(map[string]any)
+
+	require.Contains(t, paths, "/v1/language/{router}/chat")
+	require.Contains(t, paths, "/v1/health/")
+	// no image routers are configured
+	require.NotContains(t, paths, "/v1/image/{router}/generate")
+	// the admin API is not enabled
+	require.NotContains(t, paths, "/v1/admin/metrics")
+
+	chat := paths["/v1/language/{router}/chat"].(map[string]any)["post"].(map[string]any)
+
+	routerParam := chat["parameters"].([]any)[0].(map[string]any)
+	require.Equal(t, []string{"another", "myrouter"}, routerParam["schema"].(map[string]any)["enum"])
+
+	requestSchema := chat["requestBody"].(map[string]any)["content"].(map[string]any)[fiber.MIMEApplicationJSON].(map[string]any)["schema"]
+	require.Equal(t, map[string]any{"$ref": "#/components/schemas/schemas.ChatRequest"}, requestSchema)
+
+	require.Contains(t, spec["components"].(map[string]any)["schemas"], "schemas.ChatRequest")
+}
+
+func TestNewOpenAPISpec_RouteGroups(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.Serve = []RouteGroup{AdminRoutes}
+	cfg.Admin = &AdminConfig{Enabled: true, APIKey: "secret"}
+
+	spec, err := NewOpenAPISpec(docs.SwaggerJSON, cfg, newOpenAPIRouterManager(t, "default"), "http://localhost:9099")
+	require.NoError(t, err)
+
+	paths := spec["paths"].(map[string]any)
+
+	require.Contains(t, paths, "/v1/admin/metrics")
+	require.NotContains(t, paths, "/v1/language/{router}/chat")
+	require.NotContains(t, paths, "/v1/health/")
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	app := fiber.New()
+	app.Get(openAPIPath, OpenAPIHandler(DefaultServerConfig(), newOpenAPIRouterManager(t, "default")))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, openAPIPath, nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	var spec map[string]any
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+	require.Equal(t, []any{map[string]any{"url": "http://example.com"}}, spec["servers"])
+}
@@ -1,9 +1,17 @@
 package http
 
-import "glide/pkg/routers"
+import (
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+)
 
 type ErrorSchema struct {
-	Message string `json:"message"`
+	Code          schemas.ErrorCode   `json:"code"` // stable code of the failure (e.g. rate_limited or context_window_exceeded)
+	Message       string              `json:"message"`
+	RequestID     string              `json:"requestId,omitempty"`
+	UnknownFields []string            `json:"unknownFields,omitempty"`
+	Queue         *schemas.QueueStats `json:"queue,omitempty"` // set when the request was rejected by the router queue
 }
 
 type HealthSchema struct {
@@ -13,3 +21,46 @@ type HealthSchema struct {
 type RouterListSchema struct {
 	Routers []*routers.LangRouterConfig `json:"routers"`
 }
+
+type ModelListSchema struct {
+	Models []schemas.ModelMetadata `json:"models"`
+}
+
+type ConfigReloadSchema struct {
+	LastReload *schemas.ConfigReload `json:"lastReload,omitempty"`
+}
+
+type MetricsSchema struct {
+	Counters map[string]int64 `json:"counters"`
+}
+
+type CacheStatsSchema struct {
+	Routers []*schemas.RouterCacheStats `json:"routers"`
+}
+
+type ErrorLogSchema struct {
+	Errors []telemetry.LogEntry `json:"errors"`
+}
+
+type AuditLogSchema struct {
+	Entries []telemetry.AuditEntry `json:"entries"`
+}
+
+// CaptureLogSchema describes which provider calls are captured & the recent captures, the newest first
+type CaptureLogSchema struct {
+	SampleRate float64             `json:"sampleRate"`
+	RequestIDs []string            `json:"requestIds"`
+	Captures   []telemetry.Capture `json:"captures"`
+}
+
+type UsageSchema struct {
+	Records       []telemetry.UsageRecord `json:"records"`
+	TotalRequests int64                   `json:"totalRequests"`
+	TotalCost     float64                 `json:"totalCost"` // in USD
+}
+
+// LogLevelsSchema describes the default log level & its overrides per module (providers, routing, http, health)
+type LogLevelsSchema struct {
+	Level   string            `json:"level,omitempty"`
+	Modules map[string]string `json:"modules,omitempty"` // on update, an empty level resets the module to the default one
+}
@@ -2,8 +2,10 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/gofiber/swagger"
@@ -15,70 +17,282 @@ import (
 	"github.com/gofiber/fiber/v2"
 	_ "glide/docs" // importing docs package to include them into the binary
 
+	"glide/pkg/api/schemas"
 	"glide/pkg/routers"
 
 	"glide/pkg/telemetry"
 )
 
 type Server struct {
-	config        *ServerConfig
-	telemetry     *telemetry.Telemetry
-	routerManager *routers.RouterManager
-	server        *fiber.App
+	config         *ServerConfig
+	telemetry      *telemetry.Telemetry
+	routerManager  *routers.RouterManager
+	streamLimiter  *StreamLimiter
+	loadShedder    *LoadShedder
+	configExporter ConfigExporter
+	certReloader   *CertReloader
+	tlsConfig      *tls.Config
+	h2Server       *h2Server
+	server         *fiber.App
 }
 
 func NewServer(config *ServerConfig, tel *telemetry.Telemetry, routerManager *routers.RouterManager) (*Server, error) {
+	if config.Admin != nil && config.Admin.Enabled && config.Admin.APIKey == "" {
+		return nil, errors.New("admin API is enabled, but no api_key is configured for it")
+	}
+
+	if config.CORS != nil {
+		if err := config.CORS.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	srv := config.ToServer()
 
+	streamLimitConfig := config.Streams
+	if streamLimitConfig == nil {
+		streamLimitConfig = DefaultStreamLimitConfig()
+	}
+
+	var (
+		certReloader *CertReloader
+		tlsConfig    *tls.Config
+	)
+
+	if config.TLS != nil {
+		var err error
+
+		certReloader, err = NewCertReloader(config.TLS, tel)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig, err = config.TLS.ToTLSConfig(certReloader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var h2Srv *h2Server
+
+	if config.HTTP2 != nil && config.HTTP2.Enabled {
+		h2Srv = newH2Server(config.HTTP2, tel)
+	}
+
+	var loadShedder *LoadShedder
+
+	if config.LoadShedding != nil {
+		loadShedder = NewLoadShedder(config.LoadShedding, routerManager.QueueDepth)
+	}
+
 	return &Server{
 		config:        config,
 		telemetry:     tel,
 		routerManager: routerManager,
+		streamLimiter: NewStreamLimiter(streamLimitConfig),
+		loadShedder:   loadShedder,
+		certReloader:  certReloader,
+		tlsConfig:     tlsConfig,
+		h2Server:      h2Srv,
 		server:        srv,
 	}, nil
 }
 
 func (srv *Server) Run() error {
-	// TODO: refactor this when https://github.com/gofiber/contrib/pull/1069 is merged
-	srv.server.Get("/swagger.json", func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusOK).Type("json").Send(docs.SwaggerJSON)
-	})
+	srv.registerRoutes()
 
+	if srv.certReloader == nil && srv.h2Server == nil {
+		return srv.server.Listen(srv.config.Address())
+	}
+
+	listener, err := net.Listen("tcp", srv.config.Address())
+	if err != nil {
+		return err
+	}
+
+	if srv.certReloader != nil {
+		srv.certReloader.Start()
+	}
+
+	if srv.h2Server != nil {
+		return srv.server.Listener(srv.h2Server.Listener(listener, srv.tlsConfig, srv.server))
+	}
+
+	return srv.server.Listener(tls.NewListener(listener, srv.tlsConfig))
+}
+
+// registerRoutes sets up middlewares & routes of the route groups the server is configured to serve
+func (srv *Server) registerRoutes() {
+	if srv.config.Serves(DocsRoutes) {
+		// TODO: refactor this when https://github.com/gofiber/contrib/pull/1069 is merged
+		srv.server.Get("/swagger.json", func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusOK).Type("json").Send(docs.SwaggerJSON)
+		})
+		srv.server.Get(openAPIPath, OpenAPIHandler(srv.config, srv.routerManager))
+	}
+
+	srv.server.Use(RequestIDMiddleware())
 	srv.server.Use(fiberzap.New(fiberzap.Config{
 		Logger: srv.telemetry.Logger,
 	}))
+	srv.server.Use(PanicRecoveryMiddleware(srv.telemetry))
+
+	if srv.config.CORS != nil {
+		srv.server.Use(CORSMiddleware(srv.config.CORS))
+	}
+
+	srv.server.Use(ClientDisconnectMiddleware(srv.telemetry))
+	srv.server.Use(RequestLimitsMiddleware(srv.config))
 
 	v1 := srv.server.Group("/v1")
 
-	v1.Get("/swagger/*", swagger.New(swagger.Config{
-		Title: "Glide API Docs",
-		URL:   "/swagger.json",
-	}))
+	if srv.config.Serves(DocsRoutes) && (srv.config.Docs == nil || srv.config.Docs.SwaggerUI) {
+		v1.Get("/swagger/*", swagger.New(swagger.Config{
+			Title: "Glide API Docs",
+			URL:   openAPIPath,
+		}))
+	}
+
+	if srv.config.Serves(APIRoutes) {
+		srv.registerAPIRoutes(v1)
+	}
+
+	if srv.config.Serves(HealthRoutes) {
+		v1.Get("/health/", HealthHandler)
+	}
+
+	if srv.config.Serves(AdminRoutes) && srv.config.Admin != nil && srv.config.Admin.Enabled {
+		admin := v1.Group("/admin", AdminAuthMiddleware(srv.config.Admin.APIKey), AdminAuditMiddleware(srv.telemetry))
+
+		admin.Put("/language/:router/", AdminUpsertLangRouterHandler(srv.routerManager))
+		admin.Delete("/language/:router/", AdminDeleteLangRouterHandler(srv.routerManager))
+		admin.Put("/language/:router/models/:model/", AdminUpsertLangModelHandler(srv.routerManager))
+		admin.Delete("/language/:router/models/:model/", AdminDeleteLangModelHandler(srv.routerManager))
+		admin.Post("/language/:router/models/refresh/", AdminLangModelsRefreshHandler(srv.routerManager))
+		admin.Get("/metrics/", AdminMetricsHandler(srv.telemetry))
+		admin.Get("/errors/", AdminErrorsHandler(srv.telemetry))
+		admin.Get("/usage/", AdminUsageHandler(srv.telemetry))
+		admin.Get("/audit/", AdminAuditHandler(srv.telemetry))
+		admin.Get("/log-levels/", AdminLogLevelsHandler(srv.telemetry))
+		admin.Put("/log-levels/", AdminUpdateLogLevelsHandler(srv.telemetry))
+		admin.Get("/captures/", AdminCapturesHandler(srv.telemetry))
+		admin.Put("/captures/", AdminUpdateCaptureTargetsHandler(srv.telemetry))
+		admin.Get("/cache/", AdminCacheStatsHandler(srv.routerManager))
+		admin.Delete("/cache/", AdminCachePurgeHandler(srv.routerManager))
+
+		if srv.configExporter != nil {
+			admin.Get("/config/", AdminConfigExportHandler(srv.configExporter))
+		}
+
+		srv.server.Use("/admin", AdminUIHandler())
+	}
+
+	srv.server.Use(NotFoundHandler)
+}
+
+func (srv *Server) registerAPIRoutes(v1 fiber.Router) {
+	if clientAuth := srv.clientAuthConfig(); clientAuth != nil {
+		for _, routerPath := range []string{"/language/:router", "/image/:router", "/audio/:router", "/moderation/:router"} {
+			v1.Use(routerPath, ClientCertAuthMiddleware(clientAuth))
+		}
+	}
+
+	if srv.loadShedder != nil {
+		for _, routerPath := range []string{"/language/:router", "/image/:router", "/audio/:router", "/moderation/:router"} {
+			v1.Use(routerPath, LoadSheddingMiddleware(srv.loadShedder, srv.telemetry))
+		}
+	}
 
 	v1.Get("/language/", LangRoutersHandler(srv.routerManager))
-	v1.Post("/language/:router/chat/", LangChatHandler(srv.routerManager))
+	v1.Post("/language/:router/chat/", srv.withSchemaValidation(schemas.ChatRequest{}, LangChatHandler(srv.telemetry, srv.routerManager))...)
+	v1.Post("/language/:router/chat/batch/", srv.withSchemaValidation(schemas.ChatBatchRequest{}, LangChatBatchHandler(srv.routerManager, srv.batchConfig()))...)
+	v1.Post("/language/:router/embeddings/", srv.withSchemaValidation(schemas.EmbedRequest{}, LangEmbedHandler(srv.telemetry, srv.routerManager))...)
+	v1.Post("/language/:router/tokenize/", srv.withSchemaValidation(schemas.TokenizeRequest{}, LangTokenizeHandler(srv.routerManager))...)
+	v1.Post("/language/:router/feedback/", srv.withSchemaValidation(schemas.ChatFeedback{}, LangFeedbackHandler(srv.routerManager))...)
+
+	v1.Get("/language/:router/models/", LangModelsHandler(srv.routerManager))
+	v1.Get("/language/:router/health/", LangRouterHealthHandler(srv.routerManager))
 
 	v1.Use("/language/:router/chatStream", LangStreamRouterValidator(srv.routerManager))
-	v1.Get("/language/:router/chatStream", LangStreamChatHandler(srv.telemetry, srv.routerManager))
+	v1.Get("/language/:router/chatStream", LangStreamChatHandler(srv.telemetry, srv.routerManager, srv.streamLimiter))
 
-	v1.Get("/health/", HealthHandler)
+	v1.Post("/image/:router/generate/", srv.withSchemaValidation(schemas.ImageGenerateRequest{}, ImageGenerateHandler(srv.routerManager))...)
+	v1.Post("/audio/:router/transcriptions/", TranscriptionHandler(srv.routerManager))
+	v1.Post("/moderation/:router/", srv.withSchemaValidation(schemas.ModerationRequest{}, ModerationHandler(srv.routerManager))...)
 
-	srv.server.Use(NotFoundHandler)
+	v1.Get("/config/reload/", ConfigReloadHandler(srv.routerManager))
+}
+
+// WithConfigExporter enables the config export over the admin API
+func (srv *Server) WithConfigExporter(exporter ConfigExporter) {
+	srv.configExporter = exporter
+}
+
+func (srv *Server) clientAuthConfig() *ClientAuthConfig {
+	if srv.config.TLS == nil {
+		return nil
+	}
+
+	return srv.config.TLS.ClientAuth
+}
 
-	return srv.server.Listen(srv.config.Address())
+func (srv *Server) drainTimeout() time.Duration {
+	if srv.config.DrainTimeout == nil {
+		return *DefaultServerConfig().DrainTimeout
+	}
+
+	return *srv.config.DrainTimeout
 }
 
+func (srv *Server) batchConfig() *BatchConfig {
+	if srv.config.Batch == nil {
+		return DefaultBatchConfig()
+	}
+
+	return srv.config.Batch
+}
+
+// withSchemaValidation prepends the strict schema validation to the handler if the strict mode is enabled
+func (srv *Server) withSchemaValidation(schema any, handler Handler) []Handler {
+	if !srv.config.StrictSchema {
+		return []Handler{handler}
+	}
+
+	return []Handler{StrictSchemaValidator(schema), handler}
+}
+
+// Shutdown stops accepting new connections & streams and waits for in-flight requests & streams to finish
+// for at most the drain timeout. Requests that are still in flight after that are cut off
 func (srv *Server) Shutdown(ctx context.Context) error {
-	exitWaitTime := 5 * time.Second
+	drainTimeout := srv.drainTimeout()
 
 	srv.telemetry.Logger.Info(
-		fmt.Sprintf("Begin graceful shutdown, wait at most %d seconds...", exitWaitTime/time.Second),
+		fmt.Sprintf("Begin graceful shutdown, draining in-flight requests for at most %v...", drainTimeout),
 	)
 
-	c, cancel := context.WithTimeout(ctx, exitWaitTime)
+	c, cancel := context.WithTimeout(ctx, drainTimeout)
 	defer cancel()
 
-	if err := srv.server.ShutdownWithContext(c); err != nil {
+	if srv.certReloader != nil {
+		srv.certReloader.Stop()
+	}
+
+	streamsDrainedC := srv.streamLimiter.Drain()
+
+	err := srv.server.ShutdownWithContext(c)
+
+	if srv.h2Server != nil {
+		err = errors.Join(err, srv.h2Server.Shutdown(c))
+	}
+
+	// websocket connections are hijacked from the server, so their streams are waited for separately
+	select {
+	case <-streamsDrainedC:
+	case <-c.Done():
+		err = errors.Join(err, fmt.Errorf("chat streams are still active: %w", c.Err()))
+	}
+
+	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			srv.telemetry.Logger.Info("Server closed forcefully due to shutdown timeout")
 			return nil
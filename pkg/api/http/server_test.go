@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+)
+
+func TestServer_RouteGroups(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	routerManager, err := routers.NewManager(&routers.Config{}, tel)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		serve    []RouteGroup
+		statuses map[string]int
+	}{
+		"all groups by default": {
+			statuses: map[string]int{
+				"/v1/health/":        fiber.StatusOK,
+				"/v1/language/":      fiber.StatusOK,
+				"/swagger.json":      fiber.StatusOK,
+				"/v1/admin/metrics/": fiber.StatusUnauthorized,
+				"/v1/unknown/route/": fiber.StatusNotFound,
+			},
+		},
+		"admin listener": {
+			serve: []RouteGroup{AdminRoutes, HealthRoutes},
+			statuses: map[string]int{
+				"/v1/health/":        fiber.StatusOK,
+				"/v1/language/":      fiber.StatusNotFound,
+				"/swagger.json":      fiber.StatusNotFound,
+				"/v1/admin/metrics/": fiber.StatusUnauthorized,
+			},
+		},
+		"public listener": {
+			serve: []RouteGroup{APIRoutes, DocsRoutes},
+			statuses: map[string]int{
+				"/v1/health/":        fiber.StatusNotFound,
+				"/v1/language/":      fiber.StatusOK,
+				"/swagger.json":      fiber.StatusOK,
+				"/v1/admin/metrics/": fiber.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultServerConfig()
+			cfg.Serve = test.serve
+			cfg.Admin = &AdminConfig{Enabled: true, APIKey: "secret"}
+
+			srv, err := NewServer(cfg, tel, routerManager)
+			require.NoError(t, err)
+
+			srv.registerRoutes()
+
+			for path, status := range test.statuses {
+				resp, err := srv.server.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+				require.NoError(t, err)
+				require.Equal(t, status, resp.StatusCode, path)
+			}
+		})
+	}
+}
+
+func TestServer_ShutdownDrainsStreams(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	routerManager, err := routers.NewManager(&routers.Config{}, tel)
+	require.NoError(t, err)
+
+	drainTimeout := 5 * time.Second
+
+	cfg := DefaultServerConfig()
+	cfg.DrainTimeout = &drainTimeout
+
+	srv, err := NewServer(cfg, tel, routerManager)
+	require.NoError(t, err)
+
+	releaseStream, err := srv.streamLimiter.Acquire("key", "10.0.0.1")
+	require.NoError(t, err)
+
+	shutdownC := make(chan error, 1)
+
+	go func() {
+		shutdownC <- srv.Shutdown(context.Background())
+	}()
+
+	select {
+	case <-shutdownC:
+		t.Fatal("the server is shut down before the active stream is over")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	releaseStream()
+
+	select {
+	case err := <-shutdownC:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the server is not shut down once the stream is over")
+	}
+}
+
+func TestServer_ShutdownDrainTimeout(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	routerManager, err := routers.NewManager(&routers.Config{}, tel)
+	require.NoError(t, err)
+
+	drainTimeout := 50 * time.Millisecond
+
+	cfg := DefaultServerConfig()
+	cfg.DrainTimeout = &drainTimeout
+
+	srv, err := NewServer(cfg, tel, routerManager)
+	require.NoError(t, err)
+
+	_, err = srv.streamLimiter.Acquire("key", "10.0.0.1")
+	require.NoError(t, err)
+
+	startedAt := time.Now()
+
+	// streams that outlive the drain timeout are cut off
+	require.NoError(t, srv.Shutdown(context.Background()))
+	require.Less(t, time.Since(startedAt), time.Second)
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"glide/pkg/telemetry"
+)
+
+const (
+	PriorityHeader = "X-Glide-Priority"
+	shedMetric     = "http.shed"
+	heapMetricName = "/memory/classes/heap/objects:bytes"
+)
+
+// RequestPriority tells which requests are shed first under resource pressure
+type RequestPriority = int
+
+const (
+	LowPriority RequestPriority = iota
+	NormalPriority
+	HighPriority
+)
+
+var requestPriorities = map[string]RequestPriority{
+	"low":    LowPriority,
+	"normal": NormalPriority,
+	"high":   HighPriority,
+}
+
+// LoadSheddingConfig defines resource thresholds the gateway rejects new requests above. Zero means no threshold
+type LoadSheddingConfig struct {
+	MaxGoroutines    int           `yaml:"max_goroutines" validate:"gte=0"`
+	MaxHeapMB        int           `yaml:"max_heap_mb" validate:"gte=0"`
+	MaxQueueDepth    int           `yaml:"max_queue_depth" validate:"gte=0"`   // requests waiting in queues of all language routers
+	PriorityHeadroom float64       `yaml:"priority_headroom" validate:"gte=0"` // each next priority is shed once the thresholds are exceeded by this much more (e.g. 0.25 sheds normal requests at 125%)
+	RetryAfter       time.Duration `yaml:"retry_after"`                        // suggested to shed clients
+	SampleInterval   time.Duration `yaml:"sample_interval"`                    // how often resource usage is sampled
+}
+
+func DefaultLoadSheddingConfig() *LoadSheddingConfig {
+	return &LoadSheddingConfig{
+		PriorityHeadroom: 0.25,
+		RetryAfter:       5 * time.Second,
+		SampleInterval:   time.Second,
+	}
+}
+
+func (cfg *LoadSheddingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultLoadSheddingConfig()
+
+	type plain LoadSheddingConfig // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
+}
+
+// ResourceUsage is a snapshot of resources the load shedding is based on
+type ResourceUsage struct {
+	Goroutines int
+	HeapBytes  uint64
+	QueueDepth int
+}
+
+// LoadShedder rejects requests of lower priorities first as the gateway gets closer to its resource thresholds,
+// so requests that are already in flight could finish instead of competing with new ones
+type LoadShedder struct {
+	config    *LoadSheddingConfig
+	usage     func() ResourceUsage
+	mu        sync.Mutex
+	pressure  float64
+	sampledAt time.Time
+}
+
+// NewLoadShedder creates a load shedder, queueDepth returns the number of requests waiting in router queues
+func NewLoadShedder(cfg *LoadSheddingConfig, queueDepth func() int) *LoadShedder {
+	return &LoadShedder{
+		config: cfg,
+		usage: func() ResourceUsage {
+			heapSample := []metrics.Sample{{Name: heapMetricName}}
+			metrics.Read(heapSample)
+
+			return ResourceUsage{
+				Goroutines: runtime.NumGoroutine(),
+				HeapBytes:  heapSample[0].Value.Uint64(),
+				QueueDepth: queueDepth(),
+			}
+		},
+	}
+}
+
+// Pressure returns how close the gateway is to its thresholds, values above one mean at least one threshold is exceeded
+func (s *LoadShedder) Pressure() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.sampledAt) < s.config.SampleInterval {
+		return s.pressure
+	}
+
+	usage := s.usage()
+
+	s.pressure = max(
+		usageRatio(float64(usage.Goroutines), float64(s.config.MaxGoroutines)),
+		usageRatio(float64(usage.HeapBytes), float64(s.config.MaxHeapMB)*1024*1024),
+		usageRatio(float64(usage.QueueDepth), float64(s.config.MaxQueueDepth)),
+	)
+	s.sampledAt = time.Now()
+
+	return s.pressure
+}
+
+// Shed tells if the request of the given priority should be rejected
+func (s *LoadShedder) Shed(priority RequestPriority) bool {
+	return s.Pressure() > 1+float64(priority)*s.config.PriorityHeadroom
+}
+
+func usageRatio(used float64, threshold float64) float64 {
+	if threshold <= 0 {
+		return 0
+	}
+
+	return used / threshold
+}
+
+// ParsePriority reads the request priority, requests are of the normal priority by default
+func ParsePriority(value string) RequestPriority {
+	if priority, found := requestPriorities[strings.ToLower(strings.TrimSpace(value))]; found {
+		return priority
+	}
+
+	return NormalPriority
+}
+
+// LoadSheddingMiddleware rejects requests with 503 while the gateway is under resource pressure
+func LoadSheddingMiddleware(shedder *LoadShedder, tel *telemetry.Telemetry) Handler {
+	return func(c *fiber.Ctx) error {
+		priority := ParsePriority(c.Get(PriorityHeader))
+
+		if !shedder.Shed(priority) {
+			return c.Next()
+		}
+
+		tel.M().Counter(shedMetric).Inc()
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(shedder.config.RetryAfter.Seconds()))))
+
+		return c.Status(fiber.StatusServiceUnavailable).JSON(ErrorSchema{
+			Code:      statusErrorCode(fiber.StatusServiceUnavailable),
+			Message:   fmt.Sprintf("gateway is overloaded, %v priority requests are rejected at the moment", priorityName(priority)),
+			RequestID: RequestID(c),
+		})
+	}
+}
+
+func priorityName(priority RequestPriority) string {
+	for name, namedPriority := range requestPriorities {
+		if namedPriority == priority {
+			return name
+		}
+	}
+
+	return strconv.Itoa(priority)
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+func newTestLoadShedder(usage *ResourceUsage) *LoadShedder {
+	cfg := DefaultLoadSheddingConfig()
+	cfg.MaxGoroutines = 100
+	cfg.MaxQueueDepth = 10
+	cfg.SampleInterval = 0
+
+	shedder := NewLoadShedder(cfg, func() int { return 0 })
+	shedder.usage = func() ResourceUsage { return *usage }
+
+	return shedder
+}
+
+func TestLoadShedder_ShedsLowPriorityFirst(t *testing.T) {
+	usage := &ResourceUsage{Goroutines: 50}
+	shedder := newTestLoadShedder(usage)
+
+	require.False(t, shedder.Shed(LowPriority))
+
+	usage.Goroutines = 110
+
+	require.True(t, shedder.Shed(LowPriority))
+	require.False(t, shedder.Shed(NormalPriority))
+	require.False(t, shedder.Shed(HighPriority))
+
+	usage.QueueDepth = 13
+
+	require.True(t, shedder.Shed(LowPriority))
+	require.True(t, shedder.Shed(NormalPriority))
+	require.False(t, shedder.Shed(HighPriority))
+
+	usage.QueueDepth = 20
+
+	require.True(t, shedder.Shed(HighPriority))
+}
+
+func TestLoadShedder_NoThresholds(t *testing.T) {
+	shedder := NewLoadShedder(DefaultLoadSheddingConfig(), func() int { return 1000 })
+
+	require.False(t, shedder.Shed(LowPriority))
+}
+
+func TestParsePriority(t *testing.T) {
+	require.Equal(t, LowPriority, ParsePriority("low"))
+	require.Equal(t, HighPriority, ParsePriority(" High "))
+	require.Equal(t, NormalPriority, ParsePriority(""))
+	require.Equal(t, NormalPriority, ParsePriority("urgent"))
+}
+
+func TestLoadSheddingMiddleware(t *testing.T) {
+	usage := &ResourceUsage{Goroutines: 110}
+
+	app := fiber.New()
+	app.Use(LoadSheddingMiddleware(newTestLoadShedder(usage), telemetry.NewTelemetryMock()))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(PriorityHeader, "low")
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, "5", resp.Header.Get(fiber.HeaderRetryAfter))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
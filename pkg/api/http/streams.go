@@ -0,0 +1,159 @@
+package http
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	apiKeyHeader         = "X-API-Key"
+	streamRejectedMetric = "http.streams.rejected"
+)
+
+var (
+	ErrTooManyStreamsPerAPIKey = errors.New("too many active streams for the API key")
+	ErrTooManyStreamsPerIP     = errors.New("too many active streams for the client IP")
+	ErrServerShuttingDown      = errors.New("server is shutting down and doesn't accept new streams")
+)
+
+// StreamLimitConfig limits simultaneous active chat streams. Zero means no limit
+type StreamLimitConfig struct {
+	MaxPerAPIKey int `yaml:"max_per_api_key" validate:"gte=0"` // max active streams of one API key across all connections
+	MaxPerIP     int `yaml:"max_per_ip" validate:"gte=0"`      // max active streams of one client IP across all connections
+}
+
+func DefaultStreamLimitConfig() *StreamLimitConfig {
+	return &StreamLimitConfig{
+		MaxPerAPIKey: 100,
+		MaxPerIP:     100,
+	}
+}
+
+func (cfg *StreamLimitConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultStreamLimitConfig()
+
+	type plain StreamLimitConfig // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
+}
+
+// StreamLimiter keeps track of active chat streams per API key & client IP,
+// so one misbehaving client cannot exhaust gateway memory by opening unlimited streams
+type StreamLimiter struct {
+	mu       sync.Mutex
+	config   *StreamLimitConfig
+	byAPIKey map[string]int
+	byIP     map[string]int
+	active   int
+	drainedC chan struct{} // set once the server starts shutting down
+}
+
+func NewStreamLimiter(cfg *StreamLimitConfig) *StreamLimiter {
+	return &StreamLimiter{
+		config:   cfg,
+		byAPIKey: make(map[string]int),
+		byIP:     make(map[string]int),
+	}
+}
+
+// Acquire reserves a stream for the client. The returned release func must be called once the stream is over.
+// Requests without API key are limited by the client IP only
+func (l *StreamLimiter) Acquire(apiKey string, ip string) (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.drainedC != nil {
+		return nil, ErrServerShuttingDown
+	}
+
+	if apiKey != "" && l.config.MaxPerAPIKey > 0 && l.byAPIKey[apiKey] >= l.config.MaxPerAPIKey {
+		return nil, ErrTooManyStreamsPerAPIKey
+	}
+
+	if l.config.MaxPerIP > 0 && l.byIP[ip] >= l.config.MaxPerIP {
+		return nil, ErrTooManyStreamsPerIP
+	}
+
+	if apiKey != "" {
+		l.byAPIKey[apiKey]++
+	}
+
+	l.byIP[ip]++
+	l.active++
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			l.release(apiKey, ip)
+		})
+	}, nil
+}
+
+func (l *StreamLimiter) release(apiKey string, ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if apiKey != "" {
+		decrement(l.byAPIKey, apiKey)
+	}
+
+	decrement(l.byIP, ip)
+
+	l.active--
+
+	if l.drainedC != nil && l.active == 0 {
+		close(l.drainedC)
+	}
+}
+
+// Drain stops accepting new streams. The returned channel is closed once all active streams are over
+func (l *StreamLimiter) Drain() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.drainedC == nil {
+		l.drainedC = make(chan struct{})
+
+		if l.active == 0 {
+			close(l.drainedC)
+		}
+	}
+
+	return l.drainedC
+}
+
+// Active returns the number of active streams of the API key & the client IP
+func (l *StreamLimiter) Active(apiKey string, ip string) (perAPIKey int, perIP int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.byAPIKey[apiKey], l.byIP[ip]
+}
+
+// decrement removes counters that drop to zero, so the maps don't grow with every client ever seen
+func decrement(counters map[string]int, key string) {
+	counters[key]--
+
+	if counters[key] <= 0 {
+		delete(counters, key)
+	}
+}
+
+// APIKey extracts the client API key from the X-API-Key or the bearer Authorization header
+func APIKey(headers func(key string, defaultValue ...string) string) string {
+	if apiKey := headers(apiKeyHeader); apiKey != "" {
+		return apiKey
+	}
+
+	authorization := headers(fiber.HeaderAuthorization)
+
+	if token, found := strings.CutPrefix(authorization, "Bearer "); found {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamLimiter_LimitsPerAPIKey(t *testing.T) {
+	limiter := NewStreamLimiter(&StreamLimitConfig{MaxPerAPIKey: 2})
+
+	releaseFirst, err := limiter.Acquire("key", "10.0.0.1")
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire("key", "10.0.0.2")
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire("key", "10.0.0.3")
+	require.ErrorIs(t, err, ErrTooManyStreamsPerAPIKey)
+
+	// other keys are not affected
+	_, err = limiter.Acquire("another-key", "10.0.0.3")
+	require.NoError(t, err)
+
+	releaseFirst()
+	releaseFirst() // releasing twice must not free more slots
+
+	perAPIKey, _ := limiter.Active("key", "10.0.0.1")
+	require.Equal(t, 1, perAPIKey)
+
+	_, err = limiter.Acquire("key", "10.0.0.3")
+	require.NoError(t, err)
+}
+
+func TestStreamLimiter_Drain(t *testing.T) {
+	limiter := NewStreamLimiter(DefaultStreamLimitConfig())
+
+	release, err := limiter.Acquire("key", "10.0.0.1")
+	require.NoError(t, err)
+
+	drainedC := limiter.Drain()
+
+	_, err = limiter.Acquire("key", "10.0.0.1")
+	require.ErrorIs(t, err, ErrServerShuttingDown)
+
+	select {
+	case <-drainedC:
+		t.Fatal("the active stream is not over yet")
+	default:
+	}
+
+	release()
+
+	<-drainedC
+}
+
+func TestStreamLimiter_DrainWithoutStreams(t *testing.T) {
+	limiter := NewStreamLimiter(DefaultStreamLimitConfig())
+
+	<-limiter.Drain()
+}
+
+func TestStreamLimiter_LimitsPerIP(t *testing.T) {
+	limiter := NewStreamLimiter(&StreamLimitConfig{MaxPerIP: 1})
+
+	release, err := limiter.Acquire("", "10.0.0.1")
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire("key", "10.0.0.1")
+	require.ErrorIs(t, err, ErrTooManyStreamsPerIP)
+
+	release()
+
+	perAPIKey, perIP := limiter.Active("key", "10.0.0.1")
+	require.Equal(t, 0, perAPIKey)
+	require.Equal(t, 0, perIP)
+	require.Empty(t, limiter.byIP)
+}
+
+func TestStreamLimiter_Unlimited(t *testing.T) {
+	limiter := NewStreamLimiter(&StreamLimitConfig{})
+
+	for i := 0; i < 1000; i++ {
+		_, err := limiter.Acquire("key", "10.0.0.1")
+		require.NoError(t, err)
+	}
+}
+
+func TestStreamLimiter_APIKey(t *testing.T) {
+	tests := map[string]struct {
+		headers map[string]string
+		apiKey  string
+	}{
+		"no key":        {headers: map[string]string{}, apiKey: ""},
+		"api key":       {headers: map[string]string{apiKeyHeader: "key-1"}, apiKey: "key-1"},
+		"bearer token":  {headers: map[string]string{fiber.HeaderAuthorization: "Bearer key-2"}, apiKey: "key-2"},
+		"basic auth":    {headers: map[string]string{fiber.HeaderAuthorization: "Basic dXNlcjpwYXNz"}, apiKey: ""},
+		"api key first": {headers: map[string]string{apiKeyHeader: "key-1", fiber.HeaderAuthorization: "Bearer key-2"}, apiKey: "key-1"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			headers := func(key string, _ ...string) string {
+				return test.headers[key]
+			}
+
+			require.Equal(t, test.apiKey, APIKey(headers))
+		})
+	}
+}
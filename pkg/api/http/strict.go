@@ -0,0 +1,46 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"glide/pkg/api/schemas"
+)
+
+// StrictSchemaValidator rejects requests that contain fields not defined in the given request schema.
+//
+//	This is useful to catch typos or provider-specific params (e.g. OpenAI ones) that are silently ignored otherwise
+func StrictSchemaValidator(schema any) Handler {
+	schemaType := reflect.TypeOf(schema)
+
+	return func(c *fiber.Ctx) error {
+		if len(c.Body()) == 0 || !c.Is("json") {
+			// let the handler deal with the payload
+			return c.Next()
+		}
+
+		var payload any
+
+		if err := json.Unmarshal(c.Body(), &payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:    errorCode(fiber.StatusBadRequest, err),
+				Message: err.Error(),
+			})
+		}
+
+		unknownFields := schemas.UnknownFields(payload, schemaType)
+
+		if len(unknownFields) > 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorSchema{
+				Code:          statusErrorCode(fiber.StatusBadRequest),
+				Message:       fmt.Sprintf("request contains unrecognized fields: %v", strings.Join(unknownFields, ", ")),
+				UnknownFields: unknownFields,
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,196 @@
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// TLSConfig enables HTTPS on the server, so Glide could be exposed without a TLS-terminating proxy in front of it
+type TLSConfig struct {
+	CertFile       string            `yaml:"cert_file" validate:"required"`
+	KeyFile        string            `yaml:"key_file" validate:"required"`
+	MinVersion     string            `yaml:"min_version" validate:"oneof=1.2 1.3"`
+	ReloadInterval time.Duration     `yaml:"reload_interval"` // how often cert files are checked for changes (e.g. rotated by cert-manager), zero disables reloading
+	ClientAuth     *ClientAuthConfig `yaml:"client_auth"`     // mTLS authentication of clients
+}
+
+func DefaultTLSConfig() *TLSConfig {
+	return &TLSConfig{
+		MinVersion:     "1.2",
+		ReloadInterval: 1 * time.Minute,
+	}
+}
+
+func (cfg *TLSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*cfg = *DefaultTLSConfig()
+
+	type plain TLSConfig // to avoid recursion
+
+	return unmarshal((*plain)(cfg))
+}
+
+// ToTLSConfig builds the server TLS config that takes certificates from the reloader
+func (cfg *TLSConfig) ToTLSConfig(certReloader *CertReloader) (*tls.Config, error) {
+	minVersion := uint16(tls.VersionTLS12)
+
+	if cfg.MinVersion == "1.3" {
+		minVersion = tls.VersionTLS13
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: certReloader.GetCertificate,
+	}
+
+	if cfg.ClientAuth != nil {
+		clientCAs, err := cfg.ClientAuth.clientCAs()
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = cfg.ClientAuth.clientAuthType()
+	}
+
+	return tlsConfig, nil
+}
+
+// CertReloader keeps the current server certificate and reloads it when the cert or key file changes,
+// so rotated certificates are picked up without restart
+type CertReloader struct {
+	mu        sync.RWMutex
+	certFile  string
+	keyFile   string
+	cert      *tls.Certificate
+	modTime   time.Time
+	interval  time.Duration
+	telemetry *telemetry.Telemetry
+	stopC     chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewCertReloader loads the certificate failing if it's not valid, so misconfigurations are caught on startup
+func NewCertReloader(cfg *TLSConfig, tel *telemetry.Telemetry) (*CertReloader, error) {
+	reloader := &CertReloader{
+		certFile:  cfg.CertFile,
+		keyFile:   cfg.KeyFile,
+		interval:  cfg.ReloadInterval,
+		telemetry: tel,
+		stopC:     make(chan struct{}),
+	}
+
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+func (r *CertReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// Start checks cert files for changes in background
+func (r *CertReloader) Start() {
+	if r.interval <= 0 {
+		return
+	}
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.reloadIfChanged()
+			case <-r.stopC:
+				return
+			}
+		}
+	}()
+}
+
+func (r *CertReloader) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopC)
+	})
+
+	r.wg.Wait()
+}
+
+func (r *CertReloader) reloadIfChanged() {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		r.telemetry.L().Warn("failed to check TLS cert files for changes", zap.Error(err))
+		return
+	}
+
+	r.mu.RLock()
+	changed := modTime.After(r.modTime)
+	r.mu.RUnlock()
+
+	if !changed {
+		return
+	}
+
+	if err := r.reload(); err != nil {
+		// the cert & key files may be written one by one, so the pair may be inconsistent for a moment
+		r.telemetry.L().Warn("failed to reload TLS cert, keep serving the current one", zap.Error(err))
+		return
+	}
+
+	r.telemetry.L().Info("TLS cert is reloaded", zap.String("certFile", r.certFile))
+}
+
+func (r *CertReloader) reload() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(filepath.Clean(r.certFile), filepath.Clean(r.keyFile))
+	if err != nil {
+		return fmt.Errorf("unable to load TLS cert: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.modTime = modTime
+
+	return nil
+}
+
+func (r *CertReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+
+	for _, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(filepath.Clean(path))
+		if err != nil {
+			return latest, err
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
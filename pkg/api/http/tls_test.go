@@ -0,0 +1,136 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+// writeSelfSignedCert generates a self-signed cert & key pair and writes them into the directory
+func writeSelfSignedCert(t *testing.T, dir string, commonName string) (certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, commonName+".crt")
+	keyFile = filepath.Join(dir, commonName+".key")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestCertReloader_ReloadsRotatedCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir, "localhost")
+
+	cfg := DefaultTLSConfig()
+	cfg.CertFile, cfg.KeyFile = certFile, keyFile
+
+	reloader, err := NewCertReloader(cfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	initialCert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+
+	// nothing changed yet
+	reloader.reloadIfChanged()
+
+	cert, _ := reloader.GetCertificate(nil)
+	require.Same(t, initialCert, cert)
+
+	writeSelfSignedCert(t, dir, "localhost")
+
+	rotatedAt := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, rotatedAt, rotatedAt))
+
+	reloader.reloadIfChanged()
+
+	cert, _ = reloader.GetCertificate(nil)
+	require.NotSame(t, initialCert, cert)
+	require.NotEqual(t, initialCert.Certificate[0], cert.Certificate[0])
+}
+
+func TestCertReloader_InvalidCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, _ := writeSelfSignedCert(t, dir, "localhost")
+
+	cfg := DefaultTLSConfig()
+	cfg.CertFile, cfg.KeyFile = certFile, certFile
+
+	_, err := NewCertReloader(cfg, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "unable to load TLS cert")
+}
+
+func TestTLSConfig_ServesHTTPS(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir(), "localhost")
+
+	cfg := DefaultTLSConfig()
+	cfg.CertFile, cfg.KeyFile = certFile, keyFile
+
+	reloader, err := NewCertReloader(cfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/v1/health/", HealthHandler)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	tlsConfig, err := cfg.ToTLSConfig(reloader)
+	require.NoError(t, err)
+
+	go func() {
+		_ = app.Listener(tls.NewListener(listener, tlsConfig))
+	}()
+
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	certPool := x509.NewCertPool()
+	rawCert, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	require.True(t, certPool.AppendCertsFromPEM(rawCert))
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{RootCAs: certPool, ServerName: "localhost", MinVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"glide/pkg/api/schemas"
+)
+
+const routingHeader = "X-Glide-Routing"
+
+// RoutingSummary sums up the routing trace in the response header,
+// so clients can see the routing decision without parsing the response body
+func RoutingSummary(c *fiber.Ctx, trace *schemas.RoutingTrace) {
+	if trace == nil || len(trace.Attempts) == 0 {
+		return
+	}
+
+	attemptedModels := make([]string, 0, len(trace.Attempts))
+
+	for _, attempt := range trace.Attempts {
+		attemptedModels = append(attemptedModels, attempt.ModelID)
+	}
+
+	summary := fmt.Sprintf(
+		"strategy=%v; model=%v; attempts=%v",
+		trace.Strategy,
+		trace.Attempts[len(trace.Attempts)-1].ModelID,
+		strings.Join(attemptedModels, ","),
+	)
+
+	if trace.Pool != "" {
+		summary += "; pool=" + trace.Pool
+	}
+
+	c.Set(routingHeader, summary)
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+)
+
+func TestRoutingSummary(t *testing.T) {
+	trace := &schemas.RoutingTrace{
+		Strategy: "priority",
+		Pool:     "pinned",
+		Attempts: []schemas.RoutingAttempt{
+			{ModelID: "first", Error: "timeout"},
+			{ModelID: "second"},
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/traced", func(c *fiber.Ctx) error {
+		RoutingSummary(c, trace)
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/untraced", func(c *fiber.Ctx) error {
+		RoutingSummary(c, nil)
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/traced", nil))
+	require.NoError(t, err)
+	require.Equal(t, "strategy=priority; model=second; attempts=first,second; pool=pinned", resp.Header.Get(routingHeader))
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/untraced", nil))
+	require.NoError(t, err)
+	require.Empty(t, resp.Header.Get(routingHeader))
+}
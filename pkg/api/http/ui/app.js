@@ -0,0 +1,204 @@
+"use strict";
+
+// how often the dashboard is refreshed and how many latency samples are kept for charts
+const refreshInterval = 5000;
+const maxSamples = 60;
+
+const latencyHistory = {};
+
+function apiKey() {
+    return sessionStorage.getItem("glide-admin-api-key") || "";
+}
+
+async function request(method, path, body) {
+    const resp = await fetch(path, {
+        method: method,
+        headers: {"X-API-Key": apiKey(), "Content-Type": "application/json"},
+        body: body,
+    });
+
+    const payload = await resp.json().catch(() => ({}));
+
+    if (!resp.ok) {
+        throw new Error(payload.message || resp.statusText);
+    }
+
+    return payload;
+}
+
+function el(tag, attrs, ...children) {
+    const node = document.createElement(tag);
+
+    Object.entries(attrs || {}).forEach(([name, value]) => {
+        if (name.startsWith("on")) {
+            node.addEventListener(name.slice(2), value);
+        } else {
+            node.setAttribute(name, value);
+        }
+    });
+
+    children.forEach((child) => node.append(child));
+
+    return node;
+}
+
+function setStatus(message) {
+    document.getElementById("status").textContent = message;
+}
+
+function trackLatency(routerID, model) {
+    const key = routerID + "/" + model.modelId;
+    const samples = latencyHistory[key] || [];
+
+    samples.push(model.latency.chat);
+
+    if (samples.length > maxSamples) {
+        samples.shift();
+    }
+
+    latencyHistory[key] = samples;
+
+    return samples;
+}
+
+function latencyChart(samples) {
+    const svgNS = "http://www.w3.org/2000/svg";
+    const svg = document.createElementNS(svgNS, "svg");
+    const line = document.createElementNS(svgNS, "polyline");
+    const maxValue = Math.max(...samples, 1);
+
+    svg.setAttribute("class", "chart");
+    svg.setAttribute("viewBox", "0 0 " + maxSamples + " 32");
+    svg.setAttribute("preserveAspectRatio", "none");
+
+    line.setAttribute("points", samples.map((value, idx) => idx + "," + (32 - (value / maxValue) * 30)).join(" "));
+    svg.append(line);
+
+    return svg;
+}
+
+function formatLatency(value) {
+    return value ? (value / 1e6).toFixed(2) + " ms" : "n/a";
+}
+
+async function action(confirmation, method, path, body) {
+    if (confirmation && !confirm(confirmation)) {
+        return;
+    }
+
+    try {
+        await request(method, path, body);
+        setStatus(method + " " + path + " succeeded");
+        await refresh();
+    } catch (err) {
+        setStatus(method + " " + path + " failed: " + err.message);
+    }
+}
+
+function renderRouter(health) {
+    const routerID = health.router;
+    const rows = health.models.map((model) => el("tr", {},
+        el("td", {}, model.modelId),
+        el("td", {}, model.provider),
+        el("td", {class: model.healthy ? "healthy" : "unhealthy"}, model.healthy ? "healthy" : "unhealthy"),
+        el("td", {}, String(model.errorBudgetLeft)),
+        el("td", {}, formatLatency(model.latency.chat)),
+        el("td", {}, latencyChart(trackLatency(routerID, model))),
+        el("td", {}, el("button", {
+            onclick: () => action(
+                "Remove model " + model.modelId + " from router " + routerID + "?",
+                "DELETE",
+                "/v1/admin/language/" + routerID + "/models/" + model.modelId + "/",
+            ),
+        }, "Remove")),
+    ));
+
+    return el("div", {class: "router"},
+        el("h3", {},
+            el("span", {class: health.healthy ? "healthy" : "unhealthy"}, "●"),
+            routerID + " (" + health.strategy + ")",
+            el("button", {
+                onclick: () => action(null, "POST", "/v1/admin/language/" + routerID + "/models/refresh/"),
+            }, "Refresh models"),
+            el("button", {
+                onclick: () => action("Delete router " + routerID + "?", "DELETE", "/v1/admin/language/" + routerID + "/"),
+            }, "Delete"),
+        ),
+        el("table", {},
+            el("tr", {},
+                ...["Model", "Provider", "Health", "Error budget", "Chat latency", "Latency trend", ""].map((title) => el("th", {}, title)),
+            ),
+            ...rows,
+        ),
+    );
+}
+
+async function refreshRouters() {
+    const routerList = await request("GET", "/v1/language/");
+    const healths = await Promise.all(
+        routerList.routers.map((router) => request("GET", "/v1/language/" + router.routers + "/health/")),
+    );
+
+    document.getElementById("routers").replaceChildren(...healths.map(renderRouter));
+}
+
+async function refreshMetrics() {
+    const metrics = await request("GET", "/v1/admin/metrics/");
+    const rows = Object.keys(metrics.counters).sort().map((name) => el("tr", {},
+        el("td", {}, name),
+        el("td", {}, String(metrics.counters[name])),
+    ));
+
+    document.getElementById("metrics").replaceChildren(
+        el("tr", {}, el("th", {}, "Counter"), el("th", {}, "Value")),
+        ...rows,
+    );
+}
+
+async function refreshErrors() {
+    const errorLog = await request("GET", "/v1/admin/errors/");
+    const rows = errorLog.errors.map((entry) => el("tr", {},
+        el("td", {}, new Date(entry.time).toLocaleTimeString()),
+        el("td", {class: "unhealthy"}, entry.level),
+        el("td", {}, entry.message),
+        el("td", {}, JSON.stringify(entry.fields || {})),
+    ));
+
+    document.getElementById("errors").replaceChildren(
+        el("tr", {}, ...["Time", "Level", "Message", "Details"].map((title) => el("th", {}, title))),
+        ...rows,
+    );
+}
+
+async function refresh() {
+    try {
+        await Promise.all([refreshRouters(), refreshMetrics(), refreshErrors()]);
+    } catch (err) {
+        setStatus("Failed to refresh the dashboard: " + err.message);
+    }
+}
+
+document.getElementById("auth").addEventListener("submit", (event) => {
+    event.preventDefault();
+
+    sessionStorage.setItem("glide-admin-api-key", document.getElementById("api-key").value);
+    setStatus("");
+    refresh();
+});
+
+document.getElementById("router-save").addEventListener("click", () => {
+    const config = document.getElementById("router-config").value;
+    const routerID = (config.match(/^\s*"?id"?\s*:\s*"?([\w-]+)"?/m) || [])[1];
+
+    if (!routerID) {
+        setStatus("The router config must have an id");
+        return;
+    }
+
+    action(null, "PUT", "/v1/admin/language/" + routerID + "/", config);
+});
+
+document.getElementById("api-key").value = apiKey();
+
+refresh();
+setInterval(refresh, refreshInterval);
@@ -0,0 +1,50 @@
+package schemas
+
+// TranscriptionRequest defines Glide's Speech-to-Text Request Schema unified across all audio models
+type TranscriptionRequest struct {
+	// The audio file content
+	File []byte `json:"-"`
+	// The audio file name (some providers detect the audio format by the file extension)
+	Filename string `json:"filename"`
+	// The audio file MIME type (e.g. audio/mpeg)
+	ContentType string `json:"contentType,omitempty"`
+	// The language of the audio in ISO-639-1 format (e.g. en)
+	Language string `json:"language,omitempty"`
+	// The text to guide the model's style or to continue a previous audio segment
+	Prompt string `json:"prompt,omitempty"`
+}
+
+// TranscriptionResponse defines Glide's Speech-to-Text Response Schema unified across all audio models
+type TranscriptionResponse struct {
+	ID            string                     `json:"id,omitempty"`
+	Created       int                        `json:"created,omitempty"`
+	Provider      string                     `json:"provider,omitempty"`
+	RouterID      string                     `json:"router,omitempty"`
+	ModelID       string                     `json:"model_id,omitempty"`
+	ModelName     string                     `json:"model,omitempty"`
+	ModelResponse TranscriptionModelResponse `json:"modelResponse,omitempty"`
+}
+
+func (r *TranscriptionResponse) SetRouterID(routerID string) {
+	r.RouterID = routerID
+}
+
+func (r *TranscriptionResponse) SetModelID(modelID string) {
+	r.ModelID = modelID
+}
+
+// TranscriptionModelResponse is the unified transcription response from the provider
+type TranscriptionModelResponse struct {
+	Text     string                 `json:"text"`
+	Language string                 `json:"language,omitempty"`
+	Duration float64                `json:"duration,omitempty"` // in seconds
+	Segments []TranscriptionSegment `json:"segments"`
+}
+
+// TranscriptionSegment is a timestamped part of the transcription
+type TranscriptionSegment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"` // in seconds
+	End   float64 `json:"end"`   // in seconds
+	Text  string  `json:"text"`
+}
@@ -0,0 +1,31 @@
+package schemas
+
+// RouterCacheStats describes caches of the router
+type RouterCacheStats struct {
+	RouterID string      `json:"routerId"`
+	Response *CacheStats `json:"response,omitempty"` // set when the response cache is enabled
+	Embed    *CacheStats `json:"embed,omitempty"`    // set when the embedding cache is enabled
+}
+
+// CacheStats describes how the cache has been used since the router was created
+type CacheStats struct {
+	Hits         int64             `json:"hits"`
+	Misses       int64             `json:"misses"`
+	HitRate      float64           `json:"hitRate"`
+	SemanticHits int64             `json:"semanticHits,omitempty"` // hits served by similar prompts
+	Bypasses     int64             `json:"bypasses,omitempty"`     // requests that skipped the cache per their cache control
+	Errors       int64             `json:"errors"`
+	Memory       *MemoryStoreStats `json:"memory,omitempty"` // set when entries are kept in memory
+}
+
+// MemoryStoreStats describes the in-memory cache store
+type MemoryStoreStats struct {
+	Entries   int   `json:"entries"`
+	Bytes     int64 `json:"bytes"`
+	Evictions int64 `json:"evictions"`
+}
+
+// CachePurge describes the removed cache entries
+type CachePurge struct {
+	Purged int `json:"purged"`
+}
@@ -5,6 +5,20 @@ type ChatRequest struct {
 	Message        ChatMessage          `json:"message" validate:"required"`
 	MessageHistory []ChatMessage        `json:"messageHistory"`
 	Override       *OverrideChatRequest `json:"override,omitempty"`
+	DryRun         bool                 `json:"dryRun,omitempty"`                                                // return the routing decision without calling the model
+	SessionID      string               `json:"sessionId,omitempty"`                                             // routes requests of the same conversation to the same model (the sticky strategy)
+	Requires       []Capability         `json:"requires,omitempty" validate:"dive,oneof=tools vision json_mode"` // capabilities the serving model must support
+	ModelID        string               `json:"modelId,omitempty"`                                               // pins the router model to serve the request
+	Trace          bool                 `json:"trace,omitempty"`                                                 // describe the routing decision in the response
+	Timing         bool                 `json:"timing,omitempty"`                                                // break the request latency down in the response
+	Cache          *CacheControl        `json:"cache,omitempty"`                                                 // constrains serving the request from the response cache
+}
+
+// CacheControl constrains how the response cache serves the request (like Cache-Control directives do in HTTP)
+type CacheControl struct {
+	NoCache bool `json:"noCache,omitempty"`                           // skip cached responses, but cache the fresh one
+	NoStore bool `json:"noStore,omitempty"`                           // don't cache the response
+	MaxAge  *int `json:"maxAge,omitempty" validate:"omitempty,gte=0"` // the oldest cached response to accept (in seconds)
 }
 
 type OverrideChatRequest struct {
@@ -15,23 +29,93 @@ type OverrideChatRequest struct {
 func NewChatFromStr(message string) *ChatRequest {
 	return &ChatRequest{
 		Message: ChatMessage{
-			"user",
-			message,
-			"glide",
+			Role:    "user",
+			Content: message,
+			Name:    "glide",
 		},
 	}
 }
 
 // ChatResponse defines Glide's Chat Response Schema unified across all language models
 type ChatResponse struct {
-	ID            string        `json:"id,omitempty"`
-	Created       int           `json:"created,omitempty"`
-	Provider      string        `json:"provider,omitempty"`
-	RouterID      string        `json:"router,omitempty"`
-	ModelID       string        `json:"model_id,omitempty"`
-	ModelName     string        `json:"model,omitempty"`
-	Cached        bool          `json:"cached,omitempty"`
-	ModelResponse ModelResponse `json:"modelResponse,omitempty"`
+	ID            string            `json:"id,omitempty"`
+	Created       int               `json:"created,omitempty"`
+	Provider      string            `json:"provider,omitempty"`
+	RouterID      string            `json:"router,omitempty"`
+	ModelID       string            `json:"model_id,omitempty"`
+	ModelName     string            `json:"model,omitempty"`
+	Cached        bool              `json:"cached,omitempty"`
+	Replayed      bool              `json:"replayed,omitempty"` // served again for a retried request with the same idempotency key
+	Cost          *float64          `json:"cost,omitempty"`     // the request cost in USD, set when the model pricing is configured
+	ModelResponse ModelResponse     `json:"modelResponse,omitempty"`
+	Deprecation   *ModelDeprecation `json:"deprecation,omitempty"` // set when the model that served the request is deprecated
+	DryRun        *ChatDryRun       `json:"dryRun,omitempty"`      // set instead of the model response for dry run requests
+	Experiment    *Experiment       `json:"experiment,omitempty"`  // set when the request is a part of the A/B test
+	Routing       *RoutingTrace     `json:"routing,omitempty"`     // set when the routing trace is requested
+	Timing        *ResponseTiming   `json:"timing,omitempty"`      // set when the latency breakdown is requested
+}
+
+// ResponseTiming breaks the request latency down, so the gateway overhead could be told from the provider slowness
+type ResponseTiming struct {
+	TotalMs     float64         `json:"totalMs"`
+	GatewayMs   float64         `json:"gatewayMs"`             // spent outside of provider calls (e.g. routing, waiting for healthy models)
+	QueueWaitMs float64         `json:"queueWaitMs,omitempty"` // waited in the router queue for models to free up
+	Retries     int             `json:"retries,omitempty"`     // times the router waited for a healthy model to retry
+	Attempts    []AttemptTiming `json:"attempts"`              // provider calls in order, the last one served the request
+}
+
+// AttemptTiming breaks the latency of the provider call down
+type AttemptTiming struct {
+	ModelID    string  `json:"modelId"`
+	Provider   string  `json:"provider"`
+	DNSMs      float64 `json:"dnsMs,omitempty"`
+	ConnectMs  float64 `json:"connectMs,omitempty"`
+	TLSMs      float64 `json:"tlsMs,omitempty"`
+	TTFBMs     float64 `json:"ttfbMs,omitempty"`     // from the call start to the first response byte
+	ConnReused bool    `json:"connReused,omitempty"` // no DNS lookup, connect & TLS handshake were needed
+	TotalMs    float64 `json:"totalMs"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// RoutingTrace describes how the router picked the model that served the request
+type RoutingTrace struct {
+	Strategy   string             `json:"strategy"`
+	Pool       string             `json:"pool,omitempty"` // the rule name, the request label or "pinned" if the request was routed to a subset of models
+	Candidates []RoutingCandidate `json:"candidates"`     // router models as they were when the request arrived
+	Attempts   []RoutingAttempt   `json:"attempts"`       // models tried in order, the last one served the request
+}
+
+// RoutingCandidate is the state of the router model the routing decision was based on
+type RoutingCandidate struct {
+	ModelID   string  `json:"modelId"`
+	Healthy   bool    `json:"healthy"`
+	Latency   float64 `json:"latency"` // the moving average of the chat latency per token (in nanoseconds), zero while warming up
+	InFlight  int64   `json:"inFlight"`
+	ErrorRate float64 `json:"errorRate"`
+	Skipped   string  `json:"skipped,omitempty"` // why the model cannot serve the request (e.g. context_window)
+}
+
+// RoutingAttempt is the model the router tried to serve the request with
+type RoutingAttempt struct {
+	ModelID   string  `json:"modelId"`
+	Reason    string  `json:"reason"` // why the model was picked
+	Error     string  `json:"error,omitempty"`
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+// Experiment identifies the A/B test variant that served the request
+type Experiment struct {
+	ID      string `json:"id"`
+	Variant string `json:"variant"`
+}
+
+// ChatDryRun describes how the chat request would be served
+type ChatDryRun struct {
+	Label           string   `json:"label,omitempty"` // the request label if the router classifies requests
+	PromptTokens    int      `json:"promptTokens"`
+	TokensEstimated bool     `json:"tokensEstimated"`
+	EstimatedCost   *float64 `json:"estimatedCost,omitempty"`   // the prompt cost in USD (if the model pricing is known), response tokens are not known upfront
+	Transformations []string `json:"transformations,omitempty"` // changes applied to the request before it's sent to the model
 }
 
 // ModelResponse is the unified response from the provider.
@@ -43,9 +127,11 @@ type ModelResponse struct {
 }
 
 type TokenUsage struct {
-	PromptTokens   int `json:"promptTokens"`
-	ResponseTokens int `json:"responseTokens"`
-	TotalTokens    int `json:"totalTokens"`
+	PromptTokens       int `json:"promptTokens"`
+	ResponseTokens     int `json:"responseTokens"`
+	TotalTokens        int `json:"totalTokens"`
+	CachedPromptTokens int `json:"cachedPromptTokens,omitempty"` // prompt tokens read from the provider prompt cache (counted in promptTokens too)
+	CacheWriteTokens   int `json:"cacheWriteTokens,omitempty"`   // prompt tokens written to the provider prompt cache (counted in promptTokens too)
 }
 
 // ChatMessage is a message in a chat request.
@@ -57,4 +143,7 @@ type ChatMessage struct {
 	// The name of the author of this message. May contain a-z, A-Z, 0-9, and underscores,
 	// with a maximum length of 64 characters.
 	Name string `json:"name,omitempty"`
+	// Marks the end of the prompt prefix the provider should cache, so the following requests with the same prefix are cheaper & faster.
+	// Applies to providers with explicit prompt caching (e.g. Anthropic), others cache prompts automatically or not at all
+	CacheBreakpoint bool `json:"cacheBreakpoint,omitempty"`
 }
@@ -0,0 +1,21 @@
+package schemas
+
+// ChatBatchRequest is a set of chat requests to be served by the router concurrently
+type ChatBatchRequest struct {
+	Requests []ChatRequest `json:"requests" validate:"required,min=1,dive"`
+	// Parallelism limits the number of requests served at the same time (capped by the gateway config)
+	Parallelism int `json:"parallelism,omitempty"`
+}
+
+// ChatBatchResponse contains results of all batch requests in the order they were given
+type ChatBatchResponse struct {
+	RouterID string            `json:"router,omitempty"`
+	Results  []ChatBatchResult `json:"results"`
+}
+
+// ChatBatchResult is either a chat response or an error for the batch request with the given index
+type ChatBatchResult struct {
+	Index    int           `json:"index"`
+	Response *ChatResponse `json:"response,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
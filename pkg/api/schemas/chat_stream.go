@@ -6,7 +6,6 @@ type (
 	Metadata     = map[string]any
 	EventType    = string
 	FinishReason = string
-	ErrorCode    = string
 )
 
 var (
@@ -17,13 +16,6 @@ var (
 	OtherReason     FinishReason = "other"
 )
 
-var (
-	NoModelConfigured    ErrorCode = "no_model_configured"
-	ModelUnavailable     ErrorCode = "model_unavailable"
-	AllModelsUnavailable ErrorCode = "all_models_unavailable"
-	UnknownError         ErrorCode = "unknown_error"
-)
-
 type StreamRequestID = string
 
 // ChatStreamRequest defines a message that requests a new streaming chat
@@ -33,14 +25,18 @@ type ChatStreamRequest struct {
 	MessageHistory []ChatMessage        `json:"messageHistory" validate:"required"`
 	Override       *OverrideChatRequest `json:"overrideMessage,omitempty"`
 	Metadata       *Metadata            `json:"metadata,omitempty"`
+	SessionID      string               `json:"sessionId,omitempty"`                                             // routes requests of the same conversation to the same model (the sticky strategy)
+	Requires       []Capability         `json:"requires,omitempty" validate:"dive,oneof=tools vision json_mode"` // capabilities the serving model must support
+	ModelID        string               `json:"modelId,omitempty"`                                               // pins the router model to serve the request
+	Cache          *CacheControl        `json:"cache,omitempty"`                                                 // constrains serving the request from the response cache
 }
 
 func NewChatStreamFromStr(message string) *ChatStreamRequest {
 	return &ChatStreamRequest{
 		Message: ChatMessage{
-			"user",
-			message,
-			"glide",
+			Role:    "user",
+			Content: message,
+			Name:    "glide",
 		},
 	}
 }
@@ -67,6 +63,16 @@ type ChatStreamChunk struct {
 	Cached        bool               `json:"cached"`
 	ModelResponse ModelChunkResponse `json:"modelResponse"`
 	FinishReason  *FinishReason      `json:"finishReason,omitempty"`
+	Deprecation   *ModelDeprecation  `json:"deprecation,omitempty"` // set on the first chunk when the model is deprecated
+	Experiment    *Experiment        `json:"experiment,omitempty"`  // set on the first chunk when the request is a part of the A/B test
+	Resumption    *StreamResumption  `json:"resumption,omitempty"`  // set on the marker chunk sent when another model continues the failed stream
+}
+
+// StreamResumption tells that the model serving the stream has failed midway and another model continues the response
+type StreamResumption struct {
+	FailedModelID string `json:"failedModelId"`
+	Error         string `json:"error"`
+	StreamedChars int    `json:"streamedChars"` // the size of the response part replayed to the next model
 }
 
 type ChatStreamError struct {
@@ -0,0 +1,38 @@
+package schemas
+
+// EmbedRequest defines Glide's Embedding Request Schema unified across all language models
+type EmbedRequest struct {
+	Input []string `json:"input" validate:"required,min=1"`
+}
+
+func NewEmbedFromStr(input ...string) *EmbedRequest {
+	return &EmbedRequest{
+		Input: input,
+	}
+}
+
+// EmbedResponse defines Glide's Embedding Response Schema unified across all language models
+type EmbedResponse struct {
+	ID            string             `json:"id,omitempty"`
+	Created       int                `json:"created,omitempty"`
+	Provider      string             `json:"provider,omitempty"`
+	RouterID      string             `json:"router,omitempty"`
+	ModelID       string             `json:"model_id,omitempty"`
+	ModelName     string             `json:"model,omitempty"`
+	ModelResponse EmbedModelResponse `json:"modelResponse,omitempty"`
+	Cost          *float64           `json:"cost,omitempty"`        // the request cost in USD, set when the model pricing is configured
+	Deprecation   *ModelDeprecation  `json:"deprecation,omitempty"` // set when the model that served the request is deprecated
+}
+
+// EmbedModelResponse is the unified embedding response from the provider
+type EmbedModelResponse struct {
+	Embeddings []Embedding `json:"embeddings"`
+	TokenUsage TokenUsage  `json:"tokenCount"`
+}
+
+// Embedding is a vector representation of one of the request inputs
+type Embedding struct {
+	// The position of the input the embedding was generated for
+	Index  int       `json:"index"`
+	Vector []float64 `json:"vector"`
+}
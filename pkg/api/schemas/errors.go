@@ -0,0 +1,31 @@
+package schemas
+
+// ErrorCode is the stable, provider-agnostic code of the failed request.
+// Codes are returned in API error bodies & stream errors and label error metrics, so they must never be renamed
+type ErrorCode = string
+
+var (
+	NoModelConfigured      ErrorCode = "no_model_configured"
+	ModelUnavailable       ErrorCode = "model_unavailable" // the provider has failed the request or can't be reached
+	AllModelsUnavailable   ErrorCode = "all_models_unavailable"
+	UnknownError           ErrorCode = "unknown_error"
+	ContentFlagged         ErrorCode = "content_flagged" // the request was flagged by the router guardrail
+	TooManyStreams         ErrorCode = "too_many_streams"
+	ServerShuttingDown     ErrorCode = "server_shutting_down"
+	ContextWindowExceeded  ErrorCode = "context_window_exceeded" // the prompt doesn't fit the model context window
+	CapabilityUnsupported  ErrorCode = "capability_unsupported"
+	PinnedModelNotFound    ErrorCode = "pinned_model_not_found"
+	PinnedModelUnavailable ErrorCode = "pinned_model_unavailable"
+	BudgetExhausted        ErrorCode = "budget_exhausted"
+	RateLimited            ErrorCode = "rate_limited"
+	AuthFailed             ErrorCode = "auth_failed" // the provider has rejected the configured credentials
+	EmptyResponse          ErrorCode = "empty_response"
+	NotImplemented         ErrorCode = "not_implemented" // the provider doesn't support the requested API
+	Timeout                ErrorCode = "timeout"
+	InvalidRequest         ErrorCode = "invalid_request"
+	Unauthorized           ErrorCode = "unauthorized" // the gateway has rejected credentials of the request
+	NotFound               ErrorCode = "not_found"
+	Overloaded             ErrorCode = "overloaded" // router models are at their concurrency limits or the request queue is full
+	IdempotencyConflict    ErrorCode = "idempotency_conflict"
+	// ContentFiltered (the provider content filter has blocked the request or the response) is shared with finish reasons
+)
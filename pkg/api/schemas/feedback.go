@@ -0,0 +1,9 @@
+package schemas
+
+// ChatFeedback defines Glide's Chat Feedback Schema.
+// Clients rate chat responses, so routers that learn from feedback could prefer the best rated models
+type ChatFeedback struct {
+	// ModelID is the router model that served the chat request (the modelId field of the chat response)
+	ModelID string  `json:"model_id" validate:"required"`
+	Score   float64 `json:"score" validate:"gte=0,lte=1"` // from 0 (the worst) to 1 (the best)
+}
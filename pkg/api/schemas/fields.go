@@ -0,0 +1,143 @@
+package schemas
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFields returns paths of all payload fields that are not defined in the schema type
+func UnknownFields(payload any, schemaType reflect.Type) []string {
+	unknownFields := make([]string, 0)
+
+	collectUnknownFields(payload, schemaType, "", &unknownFields)
+
+	sort.Strings(unknownFields)
+
+	return unknownFields
+}
+
+func collectUnknownFields(payload any, schemaType reflect.Type, path string, unknownFields *[]string) {
+	for schemaType.Kind() == reflect.Pointer {
+		schemaType = schemaType.Elem()
+	}
+
+	switch value := payload.(type) {
+	case map[string]any:
+		if schemaType.Kind() != reflect.Struct {
+			// maps & free-form fields accept any keys
+			return
+		}
+
+		fields := jsonFields(schemaType)
+
+		for key, fieldValue := range value {
+			fieldPath := key
+
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+
+			fieldType, found := lookupField(fields, key)
+			if !found {
+				*unknownFields = append(*unknownFields, fieldPath)
+				continue
+			}
+
+			collectUnknownFields(fieldValue, fieldType, fieldPath, unknownFields)
+		}
+	case []any:
+		if schemaType.Kind() != reflect.Slice && schemaType.Kind() != reflect.Array {
+			return
+		}
+
+		for idx, item := range value {
+			collectUnknownFields(item, schemaType.Elem(), fmt.Sprintf("%v[%v]", path, idx), unknownFields)
+		}
+	}
+}
+
+// jsonFields maps JSON field names of the struct type to their types
+func jsonFields(structType reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, structType.NumField())
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		embeddedType := field.Type
+		if embeddedType.Kind() == reflect.Pointer {
+			embeddedType = embeddedType.Elem()
+		}
+
+		// exported fields of embedded structs are promoted even if the struct type itself is unexported
+		embeddedStruct := field.Anonymous && embeddedType.Kind() == reflect.Struct
+
+		if !field.IsExported() && !embeddedStruct {
+			continue
+		}
+
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+
+		if name == "-" {
+			continue
+		}
+
+		if embeddedStruct && name == "" {
+			for embeddedName, embeddedFieldType := range jsonFields(embeddedType) {
+				fields[embeddedName] = embeddedFieldType
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// lookupField finds the field by its JSON name.
+// Like encoding/json, it prefers the exact match, but falls back to a case-insensitive one
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if fieldType, found := fields[key]; found {
+		return fieldType, true
+	}
+
+	for name, fieldType := range fields {
+		if strings.EqualFold(name, key) {
+			return fieldType, true
+		}
+	}
+
+	return nil, false
+}
+
+// MissingFields returns those of the given dot-separated field paths (e.g. "usage.prompt_tokens") that are absent in the payload
+func MissingFields(payload any, paths ...string) []string {
+	missingFields := make([]string, 0)
+
+	for _, path := range paths {
+		value := payload
+
+		for _, key := range strings.Split(path, ".") {
+			object, ok := value.(map[string]any)
+			if !ok {
+				value = nil
+				break
+			}
+
+			value = object[key]
+		}
+
+		if value == nil {
+			missingFields = append(missingFields, path)
+		}
+	}
+
+	return missingFields
+}
@@ -0,0 +1,69 @@
+package schemas
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownFields(t *testing.T) {
+	tests := map[string]struct {
+		payload       string
+		unknownFields []string
+	}{
+		"valid request":         {`{"message": {"role": "user", "content": "hi"}}`, []string{}},
+		"openai params":         {`{"message": {"role": "user", "content": "hi"}, "temperature": 0.3, "model": "gpt-4"}`, []string{"model", "temperature"}},
+		"nested unknown fields": {`{"message": {"role": "user", "content": "hi", "tool_calls": []}}`, []string{"message.tool_calls"}},
+		"unknown fields in list": {
+			`{"message": {"role": "user", "content": "hi"}, "messageHistory": [{"role": "user", "content": "hi", "n": 1}]}`,
+			[]string{"messageHistory[0].n"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var payload any
+
+			require.NoError(t, json.Unmarshal([]byte(tc.payload), &payload))
+			require.Equal(t, tc.unknownFields, UnknownFields(payload, reflect.TypeOf(ChatRequest{})))
+		})
+	}
+}
+
+func TestUnknownFields_CaseInsensitive(t *testing.T) {
+	var payload any
+
+	require.NoError(t, json.Unmarshal([]byte(`{"Message": {"ROLE": "user", "content": "hi"}, "Temperature": 0.3}`), &payload))
+	require.Equal(t, []string{"Temperature"}, UnknownFields(payload, reflect.TypeOf(ChatRequest{})))
+}
+
+type embeddedParams struct {
+	Temperature float64 `json:"temperature"`
+}
+
+type embeddingRequest struct {
+	*embeddedParams
+	Prompt string `json:"prompt"`
+}
+
+func TestUnknownFields_EmbeddedPointer(t *testing.T) {
+	var payload any
+
+	require.NoError(t, json.Unmarshal([]byte(`{"prompt": "hi", "temperature": 0.3, "n": 1}`), &payload))
+	require.Equal(t, []string{"n"}, UnknownFields(payload, reflect.TypeOf(embeddingRequest{})))
+}
+
+func TestMissingFields(t *testing.T) {
+	var payload any
+
+	require.NoError(t, json.Unmarshal([]byte(`{"text": "hi", "meta": {"billed_units": {"input_tokens": 3}}}`), &payload))
+
+	require.Empty(t, MissingFields(payload, "text", "meta.billed_units.input_tokens"))
+	require.Equal(
+		t,
+		[]string{"token_count", "meta.billed_units.output_tokens"},
+		MissingFields(payload, "token_count", "meta.billed_units.output_tokens"),
+	)
+}
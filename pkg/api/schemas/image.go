@@ -0,0 +1,49 @@
+package schemas
+
+// ImageGenerateRequest defines Glide's Image Generation Request Schema unified across all image models
+type ImageGenerateRequest struct {
+	Prompt string `json:"prompt" validate:"required"`
+	// The number of images to generate
+	N int `json:"n,omitempty"`
+	// The size of generated images in the "{width}x{height}" format (e.g. 1024x1024)
+	Size string `json:"size,omitempty"`
+}
+
+func NewImageGenerateFromStr(prompt string) *ImageGenerateRequest {
+	return &ImageGenerateRequest{
+		Prompt: prompt,
+		N:      1,
+	}
+}
+
+// ImageGenerateResponse defines Glide's Image Generation Response Schema unified across all image models
+type ImageGenerateResponse struct {
+	ID            string             `json:"id,omitempty"`
+	Created       int                `json:"created,omitempty"`
+	Provider      string             `json:"provider,omitempty"`
+	RouterID      string             `json:"router,omitempty"`
+	ModelID       string             `json:"model_id,omitempty"`
+	ModelName     string             `json:"model,omitempty"`
+	ModelResponse ImageModelResponse `json:"modelResponse,omitempty"`
+}
+
+func (r *ImageGenerateResponse) SetRouterID(routerID string) {
+	r.RouterID = routerID
+}
+
+func (r *ImageGenerateResponse) SetModelID(modelID string) {
+	r.ModelID = modelID
+}
+
+// ImageModelResponse is the unified image generation response from the provider
+type ImageModelResponse struct {
+	Images []Image `json:"images"`
+}
+
+// Image is a generated image returned either as a URL or as a base64-encoded content
+type Image struct {
+	URL           string `json:"url,omitempty"`
+	B64JSON       string `json:"b64Json,omitempty"`
+	RevisedPrompt string `json:"revisedPrompt,omitempty"`
+	Seed          *int64 `json:"seed,omitempty"`
+}
@@ -0,0 +1,123 @@
+package schemas
+
+// Capability is a model feature chat requests may depend on
+type Capability string
+
+const (
+	CapabilityTools    Capability = "tools"
+	CapabilityVision   Capability = "vision"
+	CapabilityJSONMode Capability = "json_mode"
+)
+
+// ModelCapabilities defines which actions the model can serve & which features it supports
+type ModelCapabilities struct {
+	Chat       bool `json:"chat"`
+	ChatStream bool `json:"chatStream"`
+	Embed      bool `json:"embed"`
+	Tools      bool `json:"tools"`
+	Vision     bool `json:"vision"`
+	JSONMode   bool `json:"jsonMode"`
+}
+
+// ProviderModel is a model the upstream provider exposes
+type ProviderModel struct {
+	ID            string `json:"id"`
+	OwnedBy       string `json:"ownedBy,omitempty"`
+	Created       int    `json:"created,omitempty"`
+	ContextLength int    `json:"contextLength,omitempty"`
+}
+
+// ModelMetadata describes a model configured in the router together with the upstream provider information
+type ModelMetadata struct {
+	ModelID        string            `json:"modelId"`
+	Provider       string            `json:"provider"`
+	Healthy        bool              `json:"healthy"`
+	Capabilities   ModelCapabilities `json:"capabilities"`
+	UpstreamModels []ProviderModel   `json:"upstreamModels,omitempty"`
+	FetchedAt      int               `json:"fetchedAt,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	Deprecation    *ModelDeprecation `json:"deprecation,omitempty"`
+}
+
+// ModelLatency holds current moving averages of the model latencies (in nanoseconds).
+// Chat & embedding latencies are normalized per token, streaming chat latency is measured per chunk.
+// Zero means there is not enough samples yet
+type ModelLatency struct {
+	Chat            float64 `json:"chat"`
+	ChatStream      float64 `json:"chatStream"`
+	Embed           float64 `json:"embed"`
+	TTFT            float64 `json:"ttft"`            // the time to the first token of streaming chats
+	TokensPerSecond float64 `json:"tokensPerSecond"` // how fast streaming chats are generated after the first token
+}
+
+// ModelHealth describes the current health state of the router model
+type ModelHealth struct {
+	ModelID          string         `json:"modelId"`
+	Provider         string         `json:"provider"`
+	Healthy          bool           `json:"healthy"`
+	Active           bool           `json:"active"` // false when the model is outside of its schedule
+	Unauthorized     bool           `json:"unauthorized"`
+	RateLimitedUntil int            `json:"rateLimitedUntil,omitempty"`
+	ErrorBudgetLeft  uint           `json:"errorBudgetLeft"`
+	ErrorRate        float64        `json:"errorRate"` // the share of requests failed over the last minute
+	Weight           int            `json:"weight"`
+	InFlight         int64          `json:"inFlight"`                   // the number of requests the model is serving at the moment
+	Saturated        bool           `json:"saturated,omitempty"`        // the model serves as many requests as its concurrency limit allows
+	ConcurrencyLimit int64          `json:"concurrencyLimit,omitempty"` // how many requests the model may serve at the same time at the moment, zero means no limit
+	RateLimitHits    uint64         `json:"rateLimitHits"`              // how many times the provider rejected requests due to rate limits
+	RateLimitUsage   float64        `json:"rateLimitUsage,omitempty"`   // the largest share of the advertised rate limits used over the last minute
+	BudgetExhausted  bool           `json:"budgetExhausted,omitempty"`  // the model has spent its daily or monthly budget
+	CircuitState     string         `json:"circuitState"`               // closed, open or half_open
+	LastProbe        *ProbeStatus   `json:"lastProbe,omitempty"`        // the last active check of the model while it was unhealthy
+	Latency          ModelLatency   `json:"latency"`
+	Regions          []RegionHealth `json:"regions,omitempty"` // the health of each regional endpoint of multi-region models
+}
+
+// RegionHealth describes the health state & latencies of one regional endpoint of the model
+type RegionHealth struct {
+	Name            string       `json:"name"`
+	Healthy         bool         `json:"healthy"`
+	Unauthorized    bool         `json:"unauthorized"`
+	ErrorBudgetLeft uint         `json:"errorBudgetLeft"`
+	ErrorRate       float64      `json:"errorRate"`
+	Latency         ModelLatency `json:"latency"`
+}
+
+// ProbeStatus describes the outcome of the active model health check
+type ProbeStatus struct {
+	ProbedAt  int64  `json:"probedAt"` // unix time
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NextModels defines which models the router strategy would pick to serve the next request of each action
+type NextModels struct {
+	Chat       string `json:"chat,omitempty"`
+	ChatStream string `json:"chatStream,omitempty"`
+	Embed      string `json:"embed,omitempty"`
+}
+
+// QueueStats describes the router queue of requests waiting for models to free up
+type QueueStats struct {
+	Depth    int   `json:"depth"` // the number of requests waiting at the moment
+	MaxDepth int   `json:"maxDepth"`
+	WaitedMs int64 `json:"waitedMs,omitempty"` // how long the rejected request has waited
+}
+
+// RouterHealth describes the health of the router models and the current routing decisions
+type RouterHealth struct {
+	RouterID  string        `json:"router"`
+	Strategy  string        `json:"strategy"`
+	Healthy   bool          `json:"healthy"`
+	NextModel NextModels    `json:"nextModel"`
+	Models    []ModelHealth `json:"models"`
+	Queue     *QueueStats   `json:"queue,omitempty"` // set when the router queues requests
+}
+
+// ModelDeprecation warns clients that the model serving their requests is deprecated
+type ModelDeprecation struct {
+	ModelID     string `json:"modelId"`
+	Sunset      string `json:"sunset,omitempty"`      // the date (YYYY-MM-DD) the model stops being served
+	Replacement string `json:"replacement,omitempty"` // the model to migrate to
+	Message     string `json:"message,omitempty"`
+}
@@ -0,0 +1,61 @@
+package schemas
+
+// ModerationRequest defines Glide's Moderation Request Schema unified across all moderation models
+type ModerationRequest struct {
+	Input []string `json:"input" validate:"required,min=1"`
+}
+
+func NewModerationFromStr(input ...string) *ModerationRequest {
+	return &ModerationRequest{
+		Input: input,
+	}
+}
+
+// ModerationResponse defines Glide's Moderation Response Schema unified across all moderation models
+type ModerationResponse struct {
+	ID            string                  `json:"id,omitempty"`
+	Created       int                     `json:"created,omitempty"`
+	Provider      string                  `json:"provider,omitempty"`
+	RouterID      string                  `json:"router,omitempty"`
+	ModelID       string                  `json:"model_id,omitempty"`
+	ModelName     string                  `json:"model,omitempty"`
+	ModelResponse ModerationModelResponse `json:"modelResponse,omitempty"`
+}
+
+func (r *ModerationResponse) SetRouterID(routerID string) {
+	r.RouterID = routerID
+}
+
+func (r *ModerationResponse) SetModelID(modelID string) {
+	r.ModelID = modelID
+}
+
+// Flagged tells if any of the inputs was flagged by the model
+func (r *ModerationResponse) Flagged() bool {
+	for _, result := range r.ModelResponse.Results {
+		if result.Flagged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ModerationModelResponse is the unified moderation response from the provider
+type ModerationModelResponse struct {
+	// Results are returned in the same order as the request inputs
+	Results []ModerationResult `json:"results"`
+}
+
+// ModerationResult is the moderation verdict for one input
+type ModerationResult struct {
+	Flagged    bool                 `json:"flagged"`
+	Categories []ModerationCategory `json:"categories"`
+}
+
+// ModerationCategory is a normalized moderation category with its score in the [0, 1] range
+type ModerationCategory struct {
+	Name    string  `json:"name"`
+	Flagged bool    `json:"flagged"`
+	Score   float64 `json:"score"`
+}
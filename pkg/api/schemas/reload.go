@@ -0,0 +1,12 @@
+package schemas
+
+// ConfigReload describes the outcome of the last config reload
+type ConfigReload struct {
+	ReloadedAt      int      `json:"reloadedAt"`
+	Success         bool     `json:"success"`
+	Error           string   `json:"error,omitempty"`
+	Added           []string `json:"added,omitempty"`           // IDs of language routers that were added
+	Updated         []string `json:"updated,omitempty"`         // IDs of language routers that were rebuilt
+	Removed         []string `json:"removed,omitempty"`         // IDs of language routers that were removed or disabled
+	RestartRequired []string `json:"restartRequired,omitempty"` // changed config sections that can't be applied without restart
+}
@@ -0,0 +1,7 @@
+package schemas
+
+// RoutedResponse is a response annotated with the router & the model that served it
+type RoutedResponse interface {
+	SetRouterID(routerID string)
+	SetModelID(modelID string)
+}
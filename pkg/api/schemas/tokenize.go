@@ -0,0 +1,49 @@
+package schemas
+
+// TokenizeRequest defines Glide's Token Counting Request Schema.
+// It mirrors the chat request, so clients can count tokens of the exact prompt they are about to send
+type TokenizeRequest struct {
+	// ModelID is the router model to count tokens for. The first router model is used if it's not set
+	ModelID        string        `json:"model_id,omitempty"`
+	Message        ChatMessage   `json:"message" validate:"required"`
+	MessageHistory []ChatMessage `json:"messageHistory"`
+}
+
+func NewTokenizeFromStr(message string) *TokenizeRequest {
+	return &TokenizeRequest{
+		Message: ChatMessage{
+			Role:    "user",
+			Content: message,
+			Name:    "glide",
+		},
+	}
+}
+
+// Messages returns the message history followed by the new message
+func (r *TokenizeRequest) Messages() []ChatMessage {
+	messages := make([]ChatMessage, 0, len(r.MessageHistory)+1)
+	messages = append(messages, r.MessageHistory...)
+
+	return append(messages, r.Message)
+}
+
+// ChatRequest converts the request into the chat request with the same prompt
+func (r *TokenizeRequest) ChatRequest() *ChatRequest {
+	return &ChatRequest{
+		Message:        r.Message,
+		MessageHistory: r.MessageHistory,
+	}
+}
+
+// TokenizeResponse defines Glide's Token Counting Response Schema
+type TokenizeResponse struct {
+	Provider  string `json:"provider,omitempty"`
+	RouterID  string `json:"router,omitempty"`
+	ModelID   string `json:"model_id,omitempty"`
+	ModelName string `json:"model,omitempty"`
+	Tokens    int    `json:"tokens"`
+	// Estimated is set when the count is approximated rather than computed by the model tokenizer
+	Estimated bool `json:"estimated"`
+	// ContextLength is the upstream model context window if the provider reports it in the model metadata
+	ContextLength int `json:"contextLength,omitempty"`
+}
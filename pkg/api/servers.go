@@ -2,6 +2,9 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
 
 	"go.uber.org/zap"
@@ -13,50 +16,119 @@ import (
 	"glide/pkg/api/http"
 )
 
+// DefaultListener is the name of the main HTTP listener configured by the `http` section
+const DefaultListener = "default"
+
+// httpListener is an HTTP server together with the listener name it was configured under
+type httpListener struct {
+	name   string
+	server *http.Server
+}
+
 type ServerManager struct {
-	httpServer *http.Server
-	shutdownWG *sync.WaitGroup
-	telemetry  *telemetry.Telemetry
+	httpListeners []httpListener
+	shutdownWG    *sync.WaitGroup
+	errC          chan error
+	telemetry     *telemetry.Telemetry
 }
 
 func NewServerManager(cfg *Config, tel *telemetry.Telemetry, router *routers.RouterManager) (*ServerManager, error) {
-	httpServer, err := http.NewServer(cfg.HTTP, tel, router)
-	if err != nil {
-		return nil, err
+	listenerConfigs := map[string]*http.ServerConfig{DefaultListener: cfg.HTTP}
+
+	for name, listenerConfig := range cfg.Listeners {
+		if name == DefaultListener {
+			return nil, fmt.Errorf("listener name \"%v\" is reserved for the main HTTP listener", DefaultListener)
+		}
+
+		listenerConfigs[name] = listenerConfig
+	}
+
+	names := make([]string, 0, len(listenerConfigs))
+	for name := range listenerConfigs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	addresses := make(map[string]string, len(names))
+	httpListeners := make([]httpListener, 0, len(names))
+
+	for _, name := range names {
+		address := listenerConfigs[name].Address()
+
+		if otherName, found := addresses[address]; found {
+			return nil, fmt.Errorf("listeners \"%v\" and \"%v\" are configured on the same address %v", otherName, name, address)
+		}
+
+		addresses[address] = name
+
+		httpServer, err := http.NewServer(listenerConfigs[name], tel, router)
+		if err != nil {
+			return nil, fmt.Errorf("listener \"%v\": %w", name, err)
+		}
+
+		httpListeners = append(httpListeners, httpListener{name: name, server: httpServer})
 	}
 
 	// TODO: init other servers like gRPC in future
 
 	return &ServerManager{
-		httpServer: httpServer,
-		shutdownWG: &sync.WaitGroup{},
-		telemetry:  tel,
+		httpListeners: httpListeners,
+		shutdownWG:    &sync.WaitGroup{},
+		errC:          make(chan error, len(httpListeners)),
+		telemetry:     tel,
 	}, nil
 }
 
+// WithConfigExporter enables the config export over the admin API of servers
+func (mgr *ServerManager) WithConfigExporter(exporter http.ConfigExporter) {
+	for _, listener := range mgr.httpListeners {
+		listener.server.WithConfigExporter(exporter)
+	}
+}
+
+// Start runs servers in the background. Servers that fail to run (e.g. the port is taken) are reported via Errors()
 func (mgr *ServerManager) Start() {
-	if mgr.httpServer != nil {
+	for _, listener := range mgr.httpListeners {
 		mgr.shutdownWG.Add(1)
 
-		go func() {
+		go func(listener httpListener) {
 			defer mgr.shutdownWG.Done()
 
-			err := mgr.httpServer.Run()
+			err := listener.server.Run()
 			if err != nil {
-				mgr.telemetry.Logger.Error("error on running HTTP server", zap.Error(err))
+				mgr.telemetry.Logger.Error("error on running HTTP server", zap.String("listener", listener.name), zap.Error(err))
+				mgr.errC <- fmt.Errorf("listener \"%v\": %w", listener.name, err)
 			}
-		}()
+		}(listener)
 	}
 }
 
+// Errors returns errors of servers that have stopped running on their own
+func (mgr *ServerManager) Errors() <-chan error {
+	return mgr.errC
+}
+
+// Shutdown drains & stops all servers
 func (mgr *ServerManager) Shutdown(ctx context.Context) error {
-	var err error
+	errs := make([]error, len(mgr.httpListeners))
+
+	var wg sync.WaitGroup
 
-	if mgr.httpServer != nil {
-		err = mgr.httpServer.Shutdown(ctx)
+	for idx, listener := range mgr.httpListeners {
+		wg.Add(1)
+
+		go func(idx int, listener httpListener) {
+			defer wg.Done()
+
+			if err := listener.server.Shutdown(ctx); err != nil {
+				errs[idx] = fmt.Errorf("listener \"%v\": %w", listener.name, err)
+			}
+		}(idx, listener)
 	}
 
+	wg.Wait()
 	mgr.shutdownWG.Wait()
 
-	return err
+	return errors.Join(errs...)
 }
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/http"
+	"glide/pkg/routers"
+	"glide/pkg/telemetry"
+)
+
+func TestServerManager_Listeners(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	routerManager, err := routers.NewManager(&routers.Config{}, tel)
+	require.NoError(t, err)
+
+	adminListener := http.DefaultServerConfig()
+	adminListener.Port = 9100
+	adminListener.Serve = []http.RouteGroup{http.AdminRoutes, http.HealthRoutes}
+
+	cfg := DefaultConfig()
+	cfg.Listeners = map[string]*http.ServerConfig{"admin": adminListener}
+
+	serverManager, err := NewServerManager(cfg, tel, routerManager)
+	require.NoError(t, err)
+	require.Len(t, serverManager.httpListeners, 2)
+
+	// listeners must not share the address
+	cfg.Listeners["another"] = http.DefaultServerConfig()
+
+	_, err = NewServerManager(cfg, tel, routerManager)
+	require.ErrorContains(t, err, "are configured on the same address")
+
+	// the main listener name is reserved
+	cfg.Listeners = map[string]*http.ServerConfig{DefaultListener: adminListener}
+
+	_, err = NewServerManager(cfg, tel, routerManager)
+	require.ErrorContains(t, err, "is reserved")
+}
+
+func TestServerManager_ReportsRunErrors(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	routerManager, err := routers.NewManager(&routers.Config{}, tel)
+	require.NoError(t, err)
+
+	// the port is taken by someone else
+	takenListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer takenListener.Close()
+
+	cfg := DefaultConfig()
+	cfg.HTTP.Port = takenListener.Addr().(*net.TCPAddr).Port
+
+	serverManager, err := NewServerManager(cfg, tel, routerManager)
+	require.NoError(t, err)
+
+	serverManager.Start()
+
+	select {
+	case err := <-serverManager.Errors():
+		require.ErrorContains(t, err, DefaultListener)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server error was not reported")
+	}
+
+	require.NoError(t, serverManager.Shutdown(context.Background()))
+}
@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"glide/pkg/telemetry"
+)
+
+// memoryEntryOverhead approximates the memory an entry takes besides its key & value (e.g. the list element & map slot)
+const memoryEntryOverhead = 64
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) size() int64 {
+	return int64(len(e.key) + len(e.value) + memoryEntryOverhead)
+}
+
+// MemoryStoreStats describes the store usage
+type MemoryStoreStats struct {
+	Entries   int
+	Bytes     int64
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// MemoryStore keeps entries in memory evicting the least recently used ones when the store is full.
+// The store is bounded by the number of entries and by the memory they take
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	entries    map[string]*list.Element
+	lru        *list.List
+	hits       *telemetry.Counter
+	misses     *telemetry.Counter
+	evictions  *telemetry.Counter
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates a store that holds at most maxEntries entries (zero means no limit)
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		hits:       &telemetry.Counter{},
+		misses:     &telemetry.Counter{},
+		evictions:  &telemetry.Counter{},
+	}
+}
+
+// WithMaxBytes limits the memory entries may take (zero means no limit).
+// Entries larger than the limit are not stored at all
+func (s *MemoryStore) WithMaxBytes(maxBytes int64) *MemoryStore {
+	s.maxBytes = maxBytes
+
+	return s
+}
+
+// WithMetrics reports hits, misses & evictions of the store as metrics with the given name prefix
+func (s *MemoryStore) WithMetrics(meter *telemetry.Meter, prefix string) *MemoryStore {
+	s.hits = meter.Counter(prefix + ".hits")
+	s.misses = meter.Counter(prefix + ".misses")
+	s.evictions = meter.Counter(prefix + ".evictions")
+
+	return s
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, found := s.entries[key]
+	if !found {
+		s.misses.Inc()
+
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		s.remove(element)
+		s.misses.Inc()
+
+		return nil, false, nil
+	}
+
+	s.lru.MoveToFront(element)
+	s.hits.Inc()
+
+	return entry.value, true, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expiresAt time.Time
+
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	entry := &memoryEntry{key: key, value: value, expiresAt: expiresAt}
+
+	if element, found := s.entries[key]; found {
+		s.remove(element)
+	}
+
+	if s.maxBytes > 0 && entry.size() > s.maxBytes {
+		return nil
+	}
+
+	s.entries[key] = s.lru.PushFront(entry)
+	s.bytes += entry.size()
+
+	for s.full() {
+		s.remove(s.lru.Back())
+		s.evictions.Inc()
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) Purge(_ context.Context, prefix string, match func(value []byte) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+
+	for key, element := range s.entries {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if match != nil && !match(element.Value.(*memoryEntry).value) {
+			continue
+		}
+
+		s.remove(element)
+
+		purged++
+	}
+
+	return purged, nil
+}
+
+// Len returns the number of entries in the store (including expired ones that have not been evicted yet)
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lru.Len()
+}
+
+// Stats returns the current usage of the store
+func (s *MemoryStore) Stats() MemoryStoreStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return MemoryStoreStats{
+		Entries:   s.lru.Len(),
+		Bytes:     s.bytes,
+		Hits:      s.hits.Value(),
+		Misses:    s.misses.Value(),
+		Evictions: s.evictions.Value(),
+	}
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+func (s *MemoryStore) full() bool {
+	return (s.maxEntries > 0 && s.lru.Len() > s.maxEntries) || (s.maxBytes > 0 && s.bytes > s.maxBytes)
+}
+
+func (s *MemoryStore) remove(element *list.Element) {
+	entry := element.Value.(*memoryEntry)
+
+	s.lru.Remove(element)
+	delete(s.entries, entry.key)
+
+	s.bytes -= entry.size()
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+func TestMemoryStore_GetSet(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+
+	_, found, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, store.Set(ctx, "key", []byte("value"), 0))
+
+	value, found, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), value)
+}
+
+func TestMemoryStore_Expiration(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+
+	require.NoError(t, store.Set(ctx, "key", []byte("value"), time.Millisecond))
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, found, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Equal(t, 0, store.Len())
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(2)
+
+	require.NoError(t, store.Set(ctx, "first", []byte("1"), 0))
+	require.NoError(t, store.Set(ctx, "second", []byte("2"), 0))
+
+	// touch the first entry, so the second one becomes the least recently used
+	_, found, _ := store.Get(ctx, "first")
+	require.True(t, found)
+
+	require.NoError(t, store.Set(ctx, "third", []byte("3"), 0))
+	require.Equal(t, 2, store.Len())
+
+	_, found, _ = store.Get(ctx, "second")
+	require.False(t, found)
+
+	_, found, _ = store.Get(ctx, "first")
+	require.True(t, found)
+}
+
+func TestMemoryStore_EvictsByMemory(t *testing.T) {
+	ctx := context.Background()
+	entrySize := int64(len("first") + 100 + memoryEntryOverhead)
+	store := NewMemoryStore(0).WithMaxBytes(2 * entrySize)
+
+	require.NoError(t, store.Set(ctx, "first", make([]byte, 100), 0))
+	require.NoError(t, store.Set(ctx, "other", make([]byte, 100), 0))
+	require.NoError(t, store.Set(ctx, "third", make([]byte, 100), 0))
+
+	_, found, _ := store.Get(ctx, "first")
+	require.False(t, found)
+
+	_, found, _ = store.Get(ctx, "third")
+	require.True(t, found)
+
+	// entries larger than the limit are not stored
+	require.NoError(t, store.Set(ctx, "large", make([]byte, 1000), 0))
+
+	_, found, _ = store.Get(ctx, "large")
+	require.False(t, found)
+
+	require.Equal(t, MemoryStoreStats{Entries: 2, Bytes: 2 * entrySize, Hits: 1, Misses: 2, Evictions: 1}, store.Stats())
+}
+
+func TestMemoryStore_Metrics(t *testing.T) {
+	ctx := context.Background()
+	meter := telemetry.NewMeter()
+	store := NewMemoryStore(1).WithMetrics(meter, "cache")
+
+	require.NoError(t, store.Set(ctx, "first", []byte("1"), 0))
+	require.NoError(t, store.Set(ctx, "second", []byte("2"), 0))
+
+	_, _, _ = store.Get(ctx, "first")
+	_, _, _ = store.Get(ctx, "second")
+
+	require.Equal(t, map[string]int64{"cache.hits": 1, "cache.misses": 1, "cache.evictions": 1}, meter.Counters())
+}
+
+func TestMemoryStore_Purge(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+
+	require.NoError(t, store.Set(ctx, "chat:first", []byte("openai"), 0))
+	require.NoError(t, store.Set(ctx, "chat:second", []byte("cohere"), 0))
+	require.NoError(t, store.Set(ctx, "embed:first", []byte("openai"), 0))
+
+	purged, err := store.Purge(ctx, "chat:", func(value []byte) bool { return string(value) == "openai" })
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	purged, err = store.Purge(ctx, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, purged)
+	require.Equal(t, MemoryStoreStats{}, store.Stats())
+}
@@ -0,0 +1,253 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"glide/pkg/config/fields"
+)
+
+// RedisConfig defines the Redis server (or cluster) the state is shared through between gateway replicas
+type RedisConfig struct {
+	Address      string           `yaml:"address" json:"address" validate:"required"`
+	Addresses    []string         `yaml:"addresses,omitempty" json:"addresses,omitempty"` // more cluster nodes to discover the cluster through
+	Cluster      bool             `yaml:"cluster" json:"cluster"`                         // connects to Redis Cluster, keys are spread over its shards
+	Username     string           `yaml:"username,omitempty" json:"username,omitempty"`
+	Password     fields.Secret    `yaml:"password,omitempty" json:"-"`
+	DB           int              `yaml:"db" json:"db" validate:"gte=0"` // must be zero in the cluster mode
+	KeyPrefix    string           `yaml:"key_prefix" json:"key_prefix"`
+	PoolSize     int              `yaml:"pool_size" json:"pool_size" validate:"gte=0"`           // connections per node, zero means 10 per CPU
+	MinIdleConns int              `yaml:"min_idle_conns" json:"min_idle_conns" validate:"gte=0"` // connections kept open when idle
+	DialTimeout  *fields.Duration `yaml:"dial_timeout" json:"dial_timeout" swaggertype:"primitive,string"`
+	ReadTimeout  *fields.Duration `yaml:"read_timeout" json:"read_timeout" swaggertype:"primitive,string"`
+	WriteTimeout *fields.Duration `yaml:"write_timeout" json:"write_timeout" swaggertype:"primitive,string"`
+	TLS          *RedisTLSConfig  `yaml:"tls,omitempty" json:"tls,omitempty"`
+}
+
+func DefaultRedisConfig() *RedisConfig {
+	dialTimeout := 5 * time.Second
+	readTimeout := 3 * time.Second
+	writeTimeout := 3 * time.Second
+
+	return &RedisConfig{
+		Address:      "localhost:6379",
+		KeyPrefix:    "glide:",
+		DialTimeout:  (*fields.Duration)(&dialTimeout),
+		ReadTimeout:  (*fields.Duration)(&readTimeout),
+		WriteTimeout: (*fields.Duration)(&writeTimeout),
+	}
+}
+
+func (c *RedisConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultRedisConfig()
+
+	type plain RedisConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// RedisTLSConfig enables TLS on Redis connections (e.g. required by managed Redis services)
+type RedisTLSConfig struct {
+	CAFile             string `yaml:"ca_file,omitempty" json:"ca_file,omitempty"`     // verifies the server with this CA instead of the system ones
+	CertFile           string `yaml:"cert_file,omitempty" json:"cert_file,omitempty"` // the client certificate for mTLS
+	KeyFile            string `yaml:"key_file,omitempty" json:"key_file,omitempty" validate:"required_with=CertFile"`
+	ServerName         string `yaml:"server_name,omitempty" json:"server_name,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify" json:"insecure_skip_verify"`
+}
+
+// ToTLSConfig builds the client TLS config, loading the configured certificates
+func (c *RedisTLSConfig) ToTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec
+	}
+
+	if c.CAFile != "" {
+		caCert, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read redis CA file: %w", err)
+		}
+
+		rootCAs := x509.NewCertPool()
+
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("redis CA file %q has no PEM certificates", c.CAFile)
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load redis client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// NewRedisClient connects to the configured Redis server or cluster.
+// Connections are pooled and opened lazily, so Redis being down doesn't prevent the gateway from starting
+func NewRedisClient(cfg *RedisConfig) (redis.UniversalClient, error) {
+	var (
+		tlsConfig *tls.Config
+		err       error
+	)
+
+	if cfg.TLS != nil {
+		tlsConfig, err = cfg.TLS.ToTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var dialTimeout, readTimeout, writeTimeout time.Duration
+
+	if cfg.DialTimeout != nil {
+		dialTimeout = time.Duration(*cfg.DialTimeout)
+	}
+
+	if cfg.ReadTimeout != nil {
+		readTimeout = time.Duration(*cfg.ReadTimeout)
+	}
+
+	if cfg.WriteTimeout != nil {
+		writeTimeout = time.Duration(*cfg.WriteTimeout)
+	}
+
+	if cfg.Cluster {
+		if cfg.DB != 0 {
+			return nil, fmt.Errorf("redis cluster supports the db 0 only, got %v", cfg.DB)
+		}
+
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        append([]string{cfg.Address}, cfg.Addresses...),
+			Username:     cfg.Username,
+			Password:     string(cfg.Password),
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			DialTimeout:  dialTimeout,
+			ReadTimeout:  readTimeout,
+			WriteTimeout: writeTimeout,
+			TLSConfig:    tlsConfig,
+		}), nil
+	}
+
+	if len(cfg.Addresses) > 0 {
+		return nil, errors.New("redis addresses are cluster nodes, so they require the cluster mode")
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:         cfg.Address,
+		Username:     cfg.Username,
+		Password:     string(cfg.Password),
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+		DialTimeout:  dialTimeout,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		TLSConfig:    tlsConfig,
+	}), nil
+}
+
+// RedisStore keeps entries in Redis, so they are shared between gateway instances and survive restarts
+type RedisStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+var _ Store = (*RedisStore)(nil)
+
+func NewRedisStore(cfg *RedisConfig) (*RedisStore, error) {
+	client, err := NewRedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{
+		client:    client,
+		keyPrefix: cfg.KeyPrefix,
+	}, nil
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, s.keyPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.keyPrefix+key, value, ttl).Err()
+}
+
+// Purge scans keys of the prefix, so it's meant for occasional admin requests rather than the request path
+func (s *RedisStore) Purge(ctx context.Context, prefix string, match func(value []byte) bool) (int, error) {
+	var purged atomic.Int64
+
+	purge := func(ctx context.Context, node redis.UniversalClient) error {
+		iter := node.Scan(ctx, 0, s.keyPrefix+prefix+"*", 0).Iterator()
+
+		for iter.Next(ctx) {
+			if match != nil {
+				value, err := node.Get(ctx, iter.Val()).Bytes()
+				if errors.Is(err, redis.Nil) {
+					// expired in between
+					continue
+				}
+
+				if err != nil {
+					return err
+				}
+
+				if !match(value) {
+					continue
+				}
+			}
+
+			deleted, err := node.Del(ctx, iter.Val()).Result()
+			if err != nil {
+				return err
+			}
+
+			purged.Add(deleted)
+		}
+
+		return iter.Err()
+	}
+
+	// keys of the cluster are spread over shards, so each of them is scanned
+	if cluster, ok := s.client.(*redis.ClusterClient); ok {
+		err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return purge(ctx, node)
+		})
+
+		return int(purged.Load()), err
+	}
+
+	err := purge(ctx, s.client)
+
+	return int(purged.Load()), err
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
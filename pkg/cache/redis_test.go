@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisClient_Modes(t *testing.T) {
+	cfg := DefaultRedisConfig()
+
+	client, err := NewRedisClient(cfg)
+	require.NoError(t, err)
+	require.IsType(t, &redis.Client{}, client)
+	require.NoError(t, client.Close())
+
+	cfg.Cluster = true
+	cfg.Addresses = []string{"localhost:6380", "localhost:6381"}
+
+	client, err = NewRedisClient(cfg)
+	require.NoError(t, err)
+	require.IsType(t, &redis.ClusterClient{}, client)
+	require.NoError(t, client.Close())
+
+	cfg.DB = 1
+
+	_, err = NewRedisClient(cfg)
+	require.Error(t, err)
+
+	cfg.Cluster = false
+	cfg.DB = 0
+
+	_, err = NewRedisClient(cfg)
+	require.Error(t, err)
+}
+
+func TestRedisTLSConfig_ToTLSConfig(t *testing.T) {
+	tlsConfig, err := (&RedisTLSConfig{ServerName: "redis.internal"}).ToTLSConfig()
+	require.NoError(t, err)
+	require.Equal(t, "redis.internal", tlsConfig.ServerName)
+	require.Nil(t, tlsConfig.RootCAs)
+
+	_, err = (&RedisTLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}).ToTLSConfig()
+	require.Error(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+
+	_, err = (&RedisTLSConfig{CAFile: caFile}).ToTLSConfig()
+	require.ErrorContains(t, err, "no PEM certificates")
+}
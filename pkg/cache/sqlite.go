@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the pure Go SQLite driver
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS glide_cache (
+	key        TEXT PRIMARY KEY,
+	value      BLOB NOT NULL,
+	expires_at INTEGER
+)`
+
+// SQLiteConfig defines the local SQLite database entries are kept in
+type SQLiteConfig struct {
+	Path string `yaml:"path" json:"path" validate:"required"` // the database file, created if it doesn't exist
+}
+
+// SQLiteStore keeps entries in a local SQLite database, so they survive restarts of a single gateway instance
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+var _ Store = (*SQLiteStore)(nil)
+
+func NewSQLiteStore(cfg *SQLiteConfig) (*SQLiteStore, error) {
+	// several stores may share the database file, so writers wait for each other instead of failing
+	db, err := sql.Open("sqlite", cfg.Path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("unable to open sqlite store %v: %w", cfg.Path, err)
+	}
+
+	// SQLite allows one writer at a time, so serializing access avoids "database is locked" errors
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("unable to init sqlite store %v: %w", cfg.Path, err)
+	}
+
+	if _, err := db.Exec("DELETE FROM glide_cache WHERE expires_at <= ?", time.Now().UnixNano()); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("unable to remove expired entries from sqlite store %v: %w", cfg.Path, err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var value []byte
+
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT value FROM glide_cache WHERE key = ? AND (expires_at IS NULL OR expires_at > ?)",
+		key,
+		time.Now().UnixNano(),
+	).Scan(&value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (s *SQLiteStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expiresAt sql.NullInt64
+
+	if ttl > 0 {
+		expiresAt = sql.NullInt64{Int64: time.Now().Add(ttl).UnixNano(), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(
+		ctx,
+		"INSERT OR REPLACE INTO glide_cache (key, value, expires_at) VALUES (?, ?, ?)",
+		key,
+		value,
+		expiresAt,
+	)
+
+	return err
+}
+
+// Purge removes expired entries of the prefix along the way
+func (s *SQLiteStore) Purge(ctx context.Context, prefix string, match func(value []byte) bool) (int, error) {
+	if match == nil {
+		result, err := s.db.ExecContext(ctx, "DELETE FROM glide_cache WHERE substr(key, 1, ?) = ?", len(prefix), prefix)
+		if err != nil {
+			return 0, err
+		}
+
+		purged, err := result.RowsAffected()
+
+		return int(purged), err
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT key, value, expires_at FROM glide_cache WHERE substr(key, 1, ?) = ?",
+		len(prefix),
+		prefix,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UnixNano()
+	keys := make([]string, 0)
+	purged := 0
+
+	for rows.Next() {
+		var (
+			key       string
+			value     []byte
+			expiresAt sql.NullInt64
+		)
+
+		if err := rows.Scan(&key, &value, &expiresAt); err != nil {
+			_ = rows.Close()
+
+			return 0, err
+		}
+
+		expired := expiresAt.Valid && expiresAt.Int64 <= now
+
+		if expired || match(value) {
+			keys = append(keys, key)
+
+			if !expired {
+				purged++
+			}
+		}
+	}
+
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// the only connection is busy with reading rows until they are closed
+	for _, key := range keys {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM glide_cache WHERE key = ?", key); err != nil {
+			return 0, err
+		}
+	}
+
+	return purged, nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStore_GetSet(t *testing.T) {
+	ctx := context.Background()
+	cfg := &SQLiteConfig{Path: filepath.Join(t.TempDir(), "glide.db")}
+
+	store, err := NewSQLiteStore(cfg)
+	require.NoError(t, err)
+
+	_, found, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, store.Set(ctx, "key", []byte("value"), 0))
+	require.NoError(t, store.Set(ctx, "expiring", []byte("value"), time.Millisecond))
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, found, err = store.Get(ctx, "expiring")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, store.Close())
+
+	// entries survive reopening
+	store, err = NewSQLiteStore(cfg)
+	require.NoError(t, err)
+
+	defer store.Close()
+
+	value, found, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), value)
+}
+
+func TestSQLiteStore_Purge(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewSQLiteStore(&SQLiteConfig{Path: filepath.Join(t.TempDir(), "glide.db")})
+	require.NoError(t, err)
+
+	defer store.Close()
+
+	require.NoError(t, store.Set(ctx, "chat:a:1", []byte("old"), 0))
+	require.NoError(t, store.Set(ctx, "chat:a:2", []byte("new"), 0))
+	require.NoError(t, store.Set(ctx, "chat:b:1", []byte("old"), 0))
+
+	purged, err := store.Purge(ctx, "chat:a:", func(value []byte) bool { return string(value) == "old" })
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	_, found, err := store.Get(ctx, "chat:a:2")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	purged, err = store.Purge(ctx, "chat:", nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, purged)
+}
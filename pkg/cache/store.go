@@ -0,0 +1,19 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store is a key-value storage cached entries are kept in
+type Store interface {
+	// Get returns the value by key. The second return value is false if there is no such key or it has expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value by key for the given TTL. Zero TTL means the value never expires
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Purge removes entries with keys of the given prefix the match func accepts (nil accepts all of them).
+	// It returns the number of removed entries
+	Purge(ctx context.Context, prefix string, match func(value []byte) bool) (int, error)
+	// Close releases resources held by the store
+	Close() error
+}
@@ -73,5 +73,7 @@ func NewCLI() *cobra.Command {
 
 	_ = cli.MarkPersistentFlagRequired("config")
 
+	cli.AddCommand(NewConfigCmd())
+
 	return cli
 }
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+	"glide/pkg/config"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+)
+
+// NewConfigCmd creates commands to work with Glide config files
+func NewConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "🔧Work with Glide config files",
+	}
+
+	configCmd.AddCommand(NewValidateCmd())
+
+	return configCmd
+}
+
+// NewValidateCmd creates a command that checks the config file without starting the gateway
+func NewValidateCmd() *cobra.Command {
+	var (
+		smokeTest bool
+		timeout   time.Duration
+	)
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "✅Validate the config file",
+		Long: "Validate the config file: unknown providers & fields, missing required keys, " +
+			"invalid routing strategies, duplicate router and model IDs. " +
+			"Optionally, check connectivity to configured providers",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configProvider := config.NewProvider()
+
+			if err := configProvider.LoadDotEnv(dotEnvFile); err != nil {
+				log.Println("⚠️failed to load dotenv file: ", err)
+			}
+
+			out := cmd.OutOrStdout()
+			problems := configProvider.Validate(cfgFile)
+
+			for _, problem := range problems {
+				if problem.Line > 0 {
+					fmt.Fprintf(out, "❌%v:%v:%v: %v\n", cfgFile, problem.Line, problem.Column, problem)
+					continue
+				}
+
+				fmt.Fprintf(out, "❌%v: %v\n", cfgFile, problem)
+			}
+
+			if len(problems) > 0 {
+				return fmt.Errorf("config file %v has %v problem(s)", cfgFile, len(problems))
+			}
+
+			fmt.Fprintf(out, "✅config file %v is valid\n", cfgFile)
+
+			if !smokeTest {
+				return nil
+			}
+
+			if _, err := configProvider.Load(cfgFile); err != nil {
+				return err
+			}
+
+			return runSmokeTests(cmd.Context(), out, configProvider.Get(), timeout)
+		},
+	}
+
+	validateCmd.Flags().BoolVar(&smokeTest, "smoke-test", false, "check connectivity to configured providers")
+	validateCmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "timeout of each provider smoke test")
+
+	return validateCmd
+}
+
+// runSmokeTests checks that every enabled language model is reachable with the configured credentials
+func runSmokeTests(ctx context.Context, out io.Writer, cfg *config.Config, timeout time.Duration) error {
+	tel := telemetry.NewTelemetryMock()
+	failed := 0
+
+	for _, routerConfig := range cfg.Routers.LanguageRouters {
+		if !routerConfig.Enabled {
+			continue
+		}
+
+		for _, modelConfig := range routerConfig.Models {
+			if !modelConfig.Enabled {
+				continue
+			}
+
+			model, err := modelConfig.ToModel(tel)
+			if err != nil {
+				failed++
+				fmt.Fprintf(out, "❌%v/%v: %v\n", routerConfig.ID, modelConfig.ID, err)
+
+				continue
+			}
+
+			testCtx, cancel := context.WithTimeout(ctx, timeout)
+			startedAt := time.Now()
+			smokeTest, err := model.SmokeTest(testCtx)
+			elapsed := time.Since(startedAt).Round(time.Millisecond)
+
+			cancel()
+			model.Shutdown()
+
+			if errors.Is(err, providers.ErrSmokeTestUnsupported) {
+				fmt.Fprintf(out, "⚠️%v/%v (%v): skipped, %v\n", routerConfig.ID, model.ID(), model.Provider(), err)
+
+				continue
+			}
+
+			if err != nil {
+				failed++
+				fmt.Fprintf(out, "❌%v/%v (%v): %v\n", routerConfig.ID, model.ID(), model.Provider(), err)
+
+				continue
+			}
+
+			fmt.Fprintf(out, "✅%v/%v (%v): %v check passed in %v\n", routerConfig.ID, model.ID(), model.Provider(), smokeTest, elapsed)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%v model(s) failed the smoke test", failed)
+	}
+
+	return nil
+}
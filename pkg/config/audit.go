@@ -0,0 +1,28 @@
+package config
+
+import (
+	"glide/pkg/events"
+	"glide/pkg/telemetry"
+)
+
+// AuditConfig defines where audit entries (config reloads, admin changes, model health transitions, exhausted budgets) are stored.
+// Recent entries are always available via the admin API
+type AuditConfig struct {
+	File   string                 `yaml:"file,omitempty"`   // JSON Lines file entries are appended to
+	Events *events.DeliveryConfig `yaml:"events,omitempty"` // where entries are delivered as "audit.*" events
+}
+
+// Sinks creates the configured audit sinks
+func (c *AuditConfig) Sinks(tel *telemetry.Telemetry) []telemetry.AuditSink {
+	sinks := make([]telemetry.AuditSink, 0, 2)
+
+	if c.File != "" {
+		sinks = append(sinks, telemetry.NewAuditFile(c.File))
+	}
+
+	if c.Events != nil {
+		sinks = append(sinks, events.NewAuditSink(events.NewDeliverer(c.Events, tel)))
+	}
+
+	return sinks
+}
@@ -2,21 +2,30 @@ package config
 
 import (
 	"glide/pkg/api"
+	"glide/pkg/observability"
 	"glide/pkg/routers"
+	"glide/pkg/secrets"
 	"glide/pkg/telemetry"
+	"glide/pkg/usage"
 )
 
 // Config is a general top-level Glide configuration
 type Config struct {
-	Telemetry *telemetry.Config `yaml:"telemetry" validate:"required"`
-	API       *api.Config       `yaml:"api" validate:"required"`
-	Routers   routers.Config    `yaml:"routers" validate:"required"`
+	Telemetry     *telemetry.Config     `yaml:"telemetry" validate:"required"`
+	API           *api.Config           `yaml:"api" validate:"required"`
+	Routers       routers.Config        `yaml:"routers" validate:"required"`
+	Reload        *ReloadConfig         `yaml:"reload"`
+	Audit         *AuditConfig          `yaml:"audit"`
+	UsageExport   *usage.ExportConfig   `yaml:"usage_export"`
+	Observability *observability.Config `yaml:"observability"`
+	Secrets       *secrets.Config       `yaml:"secrets"` // backends of secrets referenced in config values, e.g. vault:secret/data/openai#api_key
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		Telemetry: telemetry.DefaultConfig(),
 		API:       api.DefaultConfig(),
+		Reload:    DefaultReloadConfig(),
 		// Routers should be defined by users
 	}
 }
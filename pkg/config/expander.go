@@ -1,27 +1,41 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 )
 
-// Expander finds special directives like ${env:ENV_VAR} in the config file and fill them with actual values
+var ErrEnvVarRequired = errors.New("required env var is not set")
+
+// Expander finds special directives like ${env:ENV_VAR} in the config file and fill them with actual values.
+// Env vars could have defaults & be required like in shell:
+//   - ${ENV_VAR:-default} is the default if the var is not set or empty (${ENV_VAR-default} if not set only)
+//   - ${ENV_VAR:?message} fails the expansion if the var is not set or empty (${ENV_VAR?message} if not set only)
 type Expander struct{}
 
-func (e *Expander) Expand(content []byte) []byte {
+func (e *Expander) Expand(content []byte) ([]byte, error) {
+	var errs []error
+
 	expandedContent := string(content)
 
-	expandedContent = e.expandEnvVarDirectives(expandedContent)
+	expandedContent = e.expandEnvVarDirectives(expandedContent, &errs)
 	expandedContent = e.expandFileDirectives(expandedContent)
-	expandedContent = e.expandEnvVars(expandedContent)
+	expandedContent = e.expandEnvVars(expandedContent, &errs)
 
-	return []byte(expandedContent)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return []byte(expandedContent), nil
 }
 
-// expandEnvVars expands $ENVAR
-func (e *Expander) expandEnvVars(content string) string {
+// expandEnvVars expands $ENVAR & ${ENVAR}
+func (e *Expander) expandEnvVars(content string, errs *[]error) string {
 	return os.Expand(content, func(str string) string {
 		// This allows escaping environment variable substitution via $$, e.g.
 		// - $FOO will be substituted with env var FOO
@@ -31,12 +45,72 @@ func (e *Expander) expandEnvVars(content string) string {
 			return "$"
 		}
 
-		return os.Getenv(str)
+		value, _, err := e.lookupEnvVar(str)
+		if err != nil {
+			*errs = append(*errs, err)
+		}
+
+		return value
 	})
 }
 
+// lookupEnvVar resolves the env var expression applying its default or requirement
+func (e *Expander) lookupEnvVar(expr string) (string, bool, error) {
+	name, operator, operand := expr, "", ""
+
+	if idx := strings.IndexFunc(expr, func(r rune) bool { return r != '_' && !isAlphaNum(r) }); idx > 0 {
+		name, operator = expr[:idx], expr[idx:]
+
+		for _, op := range []string{":-", ":?", "-", "?"} {
+			if strings.HasPrefix(operator, op) {
+				operator, operand = op, operator[len(op):]
+
+				break
+			}
+		}
+	}
+
+	value, found := os.LookupEnv(name)
+
+	switch operator {
+	case ":-":
+		if value == "" {
+			return operand, true, nil
+		}
+	case "-":
+		if !found {
+			return operand, true, nil
+		}
+	case ":?":
+		if value == "" {
+			return "", false, requiredEnvVarError(name, operand)
+		}
+	case "?":
+		if !found {
+			return "", false, requiredEnvVarError(name, operand)
+		}
+	default:
+		// not a known operator (e.g. an unresolved ${file:} directive), so it's looked up as it is
+		value, found = os.LookupEnv(expr)
+	}
+
+	return value, found, nil
+}
+
+func requiredEnvVarError(name string, message string) error {
+	if message == "" {
+		return fmt.Errorf("%w: %v", ErrEnvVarRequired, name)
+	}
+
+	return fmt.Errorf("%w: %v (%v)", ErrEnvVarRequired, name, message)
+}
+
+func isAlphaNum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
 // expandEnvVarDirectives expands ${env:ENVAR} directives
-func (e *Expander) expandEnvVarDirectives(content string) string {
+func (e *Expander) expandEnvVarDirectives(content string, errs *[]error) string {
 	dirMatcher := regexp.MustCompile(`\$\{env:(.+?)\}`)
 
 	return dirMatcher.ReplaceAllStringFunc(content, func(match string) string {
@@ -47,7 +121,13 @@ func (e *Expander) expandEnvVarDirectives(content string) string {
 		}
 
 		envVarName := matches[1]
-		value, exists := os.LookupEnv(envVarName)
+
+		value, exists, err := e.lookupEnvVar(envVarName)
+		if err != nil {
+			*errs = append(*errs, err)
+
+			return ""
+		}
 
 		if !exists {
 			log.Printf("could not expand the env var directive: \"%s\" variable is not found", envVarName)
@@ -46,7 +46,8 @@ func TestExpander_EnvVarExpanded(t *testing.T) {
 	require.NoError(t, err)
 
 	expander := Expander{}
-	updatedContent := expander.Expand(content)
+	updatedContent, err := expander.Expand(content)
+	require.NoError(t, err)
 
 	var cfg *sampleConfig
 
@@ -68,7 +69,10 @@ func TestExpander_FileContentExpanded(t *testing.T) {
 	require.NoError(t, err)
 
 	expander := Expander{}
-	updatedContent := string(expander.Expand(content))
+	expandedContent, err := expander.Expand(content)
+	require.NoError(t, err)
+
+	updatedContent := string(expandedContent)
 
 	require.NotContains(t, updatedContent, "${file:")
 	require.Contains(t, updatedContent, "sk-fakeapi-token")
@@ -79,7 +83,51 @@ func TestExpander_FileDoesntExist(t *testing.T) {
 	require.NoError(t, err)
 
 	expander := Expander{}
-	updatedContent := string(expander.Expand(content))
+	expandedContent, err := expander.Expand(content)
+	require.NoError(t, err)
+
+	updatedContent := string(expandedContent)
 
 	require.NotContains(t, updatedContent, "${file:")
 }
+
+func TestExpander_EnvVarDefaults(t *testing.T) {
+	t.Setenv("OPENAPI_KEY", "")
+	t.Setenv("SEED_1", "40")
+	t.Setenv("SEED_2", "")
+
+	content, err := os.ReadFile(filepath.Clean(filepath.Join(".", "testdata", "expander.defaults.yaml")))
+	require.NoError(t, err)
+
+	expander := Expander{}
+	updatedContent, err := expander.Expand(content)
+	require.NoError(t, err)
+
+	var cfg *sampleConfig
+
+	err = yaml.Unmarshal(updatedContent, &cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Glide", cfg.Name)
+	assert.Equal(t, "sk-default", cfg.APIKey)
+	// empty vars get the default with ":-" only
+	assert.Equal(t, []string{"40", "", "42", "43"}, cfg.Seeds)
+}
+
+func TestExpander_RequiredEnvVarMissing(t *testing.T) {
+	t.Setenv("SEED_1", "")
+
+	content := []byte(`
+api_key: "${OPENAPI_KEY:?set the OpenAI API key}"
+seeds:
+  - "${env:SEED_1:?}"
+  - "${SEED_1?}"
+`)
+
+	expander := Expander{}
+	_, err := expander.Expand(content)
+
+	require.ErrorIs(t, err, ErrEnvVarRequired)
+	require.ErrorContains(t, err, "OPENAPI_KEY (set the OpenAI API key)")
+	require.ErrorContains(t, err, "SEED_1")
+}
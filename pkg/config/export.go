@@ -0,0 +1,42 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"glide/pkg/routers"
+	"gopkg.in/yaml.v3"
+)
+
+// Export returns the config with the routers section replaced by the given one (e.g. routers changed at runtime via the admin API).
+// Other sections are the ones loaded from the config file
+func (p *Provider) Export(routersConfig routers.Config) *Config {
+	cfg := *p.Get()
+	cfg.Routers = routersConfig
+
+	return &cfg
+}
+
+// MarshalCanonical serializes the config in the config file format, so it could be committed back to the source control.
+// Secrets are redacted, JSON uses the same field names as YAML
+func MarshalCanonical(cfg *Config, format string) ([]byte, error) {
+	rawConfig, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "yaml", "":
+		return rawConfig, nil
+	case "json":
+		var tree map[string]interface{}
+
+		if err := yaml.Unmarshal(rawConfig, &tree); err != nil {
+			return nil, err
+		}
+
+		return json.MarshalIndent(tree, "", "  ")
+	}
+
+	return nil, fmt.Errorf("unsupported config format \"%v\", use yaml or json", format)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/routers"
+)
+
+func TestConfigExport_RoundTrip(t *testing.T) {
+	configProvider, err := NewProvider().Load("./testdata/provider.fullconfig.yaml")
+	require.NoError(t, err)
+
+	routersConfig := configProvider.Get().Routers
+	addedRouter := routers.DefaultLangRouterConfig()
+	addedRouter.ID = "added"
+	addedRouter.Models = routersConfig.LanguageRouters[0].Models
+	routersConfig.LanguageRouters = append(routersConfig.LanguageRouters, addedRouter)
+
+	rawConfig, err := MarshalCanonical(configProvider.Export(routersConfig), "yaml")
+	require.NoError(t, err)
+	require.NotContains(t, string(rawConfig), "ABSC@124")
+
+	exportedPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(exportedPath, rawConfig, 0o600))
+
+	exportedProvider, err := NewProvider().Load(exportedPath)
+	require.NoError(t, err)
+
+	exportedRouters := exportedProvider.Get().Routers.LanguageRouters
+	require.Len(t, exportedRouters, 2)
+	require.Equal(t, "added", exportedRouters[1].ID)
+	require.Equal(t, "gpt-3.5-turbo", exportedRouters[1].Models[0].OpenAI.Model)
+
+	// the loaded config is not affected by the export
+	require.Len(t, configProvider.Get().Routers.LanguageRouters, 1)
+}
+
+func TestConfigExport_JSON(t *testing.T) {
+	configProvider, err := NewProvider().Load("./testdata/provider.fullconfig.yaml")
+	require.NoError(t, err)
+
+	rawConfig, err := MarshalCanonical(configProvider.Export(configProvider.Get().Routers), "json")
+	require.NoError(t, err)
+
+	var exportedConfig map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(rawConfig, &exportedConfig))
+	require.Contains(t, exportedConfig, "routers")
+	require.Contains(t, exportedConfig, "telemetry")
+
+	_, err = MarshalCanonical(configProvider.Get(), "toml")
+	require.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package fields
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDuration_YAMLRoundTrip(t *testing.T) {
+	type config struct {
+		Interval *Duration `yaml:"interval"`
+	}
+
+	interval := Duration(90 * time.Second)
+
+	rawConfig, err := yaml.Marshal(config{Interval: &interval})
+	require.NoError(t, err)
+	require.Equal(t, "interval: 1m30s\n", string(rawConfig))
+
+	var loadedConfig config
+
+	require.NoError(t, yaml.Unmarshal(rawConfig, &loadedConfig))
+	require.Equal(t, interval, *loadedConfig.Interval)
+
+	require.NoError(t, yaml.Unmarshal([]byte("interval: 1000"), &loadedConfig))
+	require.Equal(t, Duration(1000), *loadedConfig.Interval)
+
+	require.Error(t, yaml.Unmarshal([]byte("interval: soon"), &loadedConfig))
+}
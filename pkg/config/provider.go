@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 
@@ -16,9 +18,13 @@ import (
 
 // Provider reads, collects, validates and process config files
 type Provider struct {
-	expander  *Expander
-	Config    *Config
-	validator *validator.Validate
+	mu         sync.RWMutex
+	expander   *Expander
+	Config     *Config
+	configPath string
+	validator  *validator.Validate
+	// secretsRenewAt is when secrets referenced in the config should be fetched again, zero if they don't expire
+	secretsRenewAt time.Time
 }
 
 // NewProvider creates a instance of Config Provider
@@ -47,29 +53,84 @@ func (p *Provider) LoadDotEnv(envPath string) error {
 }
 
 func (p *Provider) Load(configPath string) (*Provider, error) {
+	cfg, secretsRenewAt, err := p.read(configPath)
+	if err != nil {
+		return p, err
+	}
+
+	p.Config = cfg
+	p.configPath = configPath
+	p.secretsRenewAt = secretsRenewAt
+
+	return p, nil
+}
+
+// Reload re-reads the config file the provider was loaded from.
+// The current config is kept if the file cannot be read or is invalid
+func (p *Provider) Reload() (*Config, error) {
+	cfg, secretsRenewAt, err := p.read(p.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.Config = cfg
+	p.secretsRenewAt = secretsRenewAt
+	p.mu.Unlock()
+
+	return cfg, nil
+}
+
+// SecretsRenewAt returns when secrets referenced in the config should be fetched again by reloading it.
+// Zero time means the secrets don't expire
+func (p *Provider) SecretsRenewAt() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.secretsRenewAt
+}
+
+// Path returns the path of the loaded config file
+func (p *Provider) Path() string {
+	return p.configPath
+}
+
+func (p *Provider) read(configPath string) (*Config, time.Time, error) {
 	content, err := os.ReadFile(filepath.Clean(configPath))
 	if err != nil {
-		return p, fmt.Errorf("unable to read config file %v: %w", configPath, err)
+		return nil, time.Time{}, fmt.Errorf("unable to read config file %v: %w", configPath, err)
 	}
 
 	// process raw config
-	content = p.expander.Expand(content)
+	content, err = p.expander.Expand(content)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to expand config file %v: %w", configPath, err)
+	}
+
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to parse config file %v: %w", configPath, err)
+	}
+
+	secretsRenewAt, err := resolveSecrets(&root)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to resolve secrets in config file %v: %w", configPath, err)
+	}
 
 	// validate the config structure
 	cfg := DefaultConfig()
 
-	if err := yaml.Unmarshal(content, &cfg); err != nil {
-		return p, fmt.Errorf("unable to parse config file %v: %w", configPath, err)
+	if err := root.Decode(cfg); err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to parse config file %v: %w", configPath, err)
 	}
 
 	err = p.validator.Struct(cfg)
 	if err != nil {
-		return p, p.formatValidationError(configPath, err)
+		return nil, time.Time{}, p.formatValidationError(configPath, err)
 	}
 
-	p.Config = cfg
-
-	return p, nil
+	return cfg, secretsRenewAt, nil
 }
 
 func Indent(text string, level int) string {
@@ -104,7 +165,7 @@ func (p *Provider) formatValidationError(configPath string, err error) error {
 }
 
 func (p *Provider) formatFieldError(fieldErr validator.FieldError) string {
-	namespace := strings.TrimLeft(fieldErr.Namespace(), "Config.")
+	namespace := strings.TrimPrefix(fieldErr.Namespace(), "Config.")
 
 	switch fieldErr.Tag() {
 	case "required":
@@ -137,11 +198,14 @@ func (p *Provider) formatFieldError(fieldErr validator.FieldError) string {
 }
 
 func (p *Provider) Get() *Config {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return p.Config
 }
 
 func (p *Provider) GetStr() string {
-	loadedConfig, _ := yaml.Marshal(p.Config)
+	loadedConfig, _ := yaml.Marshal(p.Get())
 
 	return string(loadedConfig)
 }
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -64,3 +66,29 @@ func TestConfigProvider_NoProvider(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorContains(t, err, "none is configured")
 }
+
+func TestConfigProvider_Reload(t *testing.T) {
+	fullConfig, err := os.ReadFile("./testdata/provider.fullconfig.yaml")
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, fullConfig, 0o600))
+
+	configProvider, err := NewProvider().Load(configPath)
+	require.NoError(t, err)
+
+	loadedConfig := configProvider.Get()
+
+	require.NoError(t, os.WriteFile(configPath, []byte("broken: [config"), 0o600))
+
+	_, err = configProvider.Reload()
+	require.ErrorContains(t, err, "unable to parse config file")
+	require.Same(t, loadedConfig, configProvider.Get())
+
+	require.NoError(t, os.WriteFile(configPath, fullConfig, 0o600))
+
+	reloadedConfig, err := configProvider.Reload()
+	require.NoError(t, err)
+	require.Same(t, reloadedConfig, configProvider.Get())
+	require.NotSame(t, loadedConfig, reloadedConfig)
+}
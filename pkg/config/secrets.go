@@ -0,0 +1,61 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"glide/pkg/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// resolveSecrets replaces secret references (e.g. vault:secret/data/openai#api_key) in config values with secrets.
+// It returns when the secrets should be fetched again, zero time if they don't expire
+func resolveSecrets(root *yaml.Node) (time.Time, error) {
+	var section struct {
+		Secrets *secrets.Config `yaml:"secrets"`
+	}
+
+	if err := root.Decode(&section); err != nil {
+		return time.Time{}, err
+	}
+
+	resolver, err := secrets.NewResolver(section.Secrets)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := resolveNodeSecrets(context.Background(), resolver, root); err != nil {
+		return time.Time{}, err
+	}
+
+	return resolver.RenewAt(), nil
+}
+
+func resolveNodeSecrets(ctx context.Context, resolver *secrets.Resolver, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		value, err := resolver.Resolve(ctx, node.Value)
+		if err != nil {
+			return err
+		}
+
+		node.Value = value
+	case yaml.MappingNode:
+		// keys are never secrets, so only values are resolved
+		for idx := 1; idx < len(node.Content); idx += 2 {
+			if err := resolveNodeSecrets(ctx, resolver, node.Content[idx]); err != nil {
+				return err
+			}
+		}
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := resolveNodeSecrets(ctx, resolver, child); err != nil {
+				return err
+			}
+		}
+	case yaml.AliasNode:
+		// resolved where the anchor is defined
+	}
+
+	return nil
+}
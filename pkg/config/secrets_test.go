@@ -0,0 +1,47 @@
+package config
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/secrets"
+)
+
+func TestConfigProvider_VaultSecretsResolved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/secret/data/openai", r.URL.Path)
+		require.Equal(t, "test-token", r.Header.Get("X-Vault-Token"))
+
+		_, _ = w.Write([]byte(`{"lease_duration": 0, "data": {"data": {"api_key": "sk-vault"}, "metadata": {"version": 1}}}`))
+	}))
+
+	defer server.Close()
+
+	t.Setenv("VAULT_TEST_ADDR", server.URL)
+
+	configProvider, err := NewProvider().Load("./testdata/provider.vault.yaml")
+	require.NoError(t, err)
+
+	openAIConfig := configProvider.Get().Routers.LanguageRouters[0].Models[0].OpenAI
+	require.Equal(t, "sk-vault", string(openAIConfig.APIKey))
+	require.True(t, configProvider.SecretsRenewAt().IsZero())
+}
+
+func TestConfigProvider_SecretBackendNotConfigured(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	content, err := os.ReadFile("./testdata/provider.fullconfig.yaml")
+	require.NoError(t, err)
+
+	content = bytes.Replace(content, []byte(`"ABSC@124"`), []byte("vault:secret/data/openai#api_key"), 1)
+	require.NoError(t, os.WriteFile(configPath, content, 0o600))
+
+	_, err = NewProvider().Load(configPath)
+	require.ErrorIs(t, err, secrets.ErrBackendNotConfigured)
+	require.ErrorContains(t, err, "unable to resolve secrets")
+}
@@ -0,0 +1,388 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"glide/pkg/providers"
+	"glide/pkg/routers"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	yamlLineErrPattern  = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	unknownFieldPattern = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+	modelPathPattern    = regexp.MustCompile(`\.models\[\d+\]$`)
+)
+
+// Problem is the config issue found by the validation
+type Problem struct {
+	Line    int    // zero if the position in the file is not known
+	Column  int    // zero if the position in the file is not known
+	Path    string // the config field, e.g. routers.language[0].strategy
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+
+	return fmt.Sprintf("%v: %v", p.Path, p.Message)
+}
+
+// Validate checks the config file like Load does, but keeps going after the first problem.
+// On top of that, it reports unknown fields (e.g. misspelled providers) and builds routers the way the gateway does,
+// so problems otherwise found on the gateway start are reported too
+func (p *Provider) Validate(configPath string) []Problem {
+	content, err := os.ReadFile(filepath.Clean(configPath))
+	if err != nil {
+		return []Problem{{Message: fmt.Sprintf("unable to read config file: %v", err)}}
+	}
+
+	content, err = p.expander.Expand(content)
+	if err != nil {
+		return errorProblems(err)
+	}
+
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return errorProblems(err)
+	}
+
+	checker := &configChecker{root: &root}
+
+	// decoded once again, just to find unknown fields as the config is decoded leniently otherwise
+	strictDecoder := yaml.NewDecoder(bytes.NewReader(content))
+	strictDecoder.KnownFields(true)
+
+	if err := strictDecoder.Decode(DefaultConfig()); err != nil {
+		checker.addDecodeErrors(err)
+	}
+
+	if _, err := resolveSecrets(&root); err != nil {
+		checker.add("secrets", fmt.Sprintf("unable to resolve secrets: %v", err))
+	}
+
+	cfg := DefaultConfig()
+
+	if err := root.Decode(cfg); err != nil {
+		// decode errors are reported by the strict decoding already
+		return checker.problems
+	}
+
+	if err := p.validator.Struct(cfg); err != nil {
+		checker.addValidationErrors(p, err)
+	}
+
+	checker.checkRouters(&cfg.Routers)
+
+	if len(checker.problems) > 0 {
+		// routers could be built only if their configs are valid
+		return checker.problems
+	}
+
+	manager, err := routers.NewManager(&cfg.Routers, telemetry.NewTelemetryMock())
+	if err != nil {
+		return errorProblems(err)
+	}
+
+	manager.Shutdown()
+
+	return nil
+}
+
+type configChecker struct {
+	root     *yaml.Node
+	problems []Problem
+}
+
+// add reports the problem positioning it at the config field
+func (c *configChecker) add(path string, message string) {
+	line, column := c.position(path)
+
+	c.problems = append(c.problems, Problem{Line: line, Column: column, Path: path, Message: message})
+}
+
+func (c *configChecker) addDecodeErrors(err error) {
+	var typeErr *yaml.TypeError
+
+	if !errors.As(err, &typeErr) {
+		c.problems = append(c.problems, errorProblems(err)...)
+
+		return
+	}
+
+	for _, message := range typeErr.Errors {
+		problem := Problem{Message: message}
+
+		if matches := yamlLineErrPattern.FindStringSubmatch(message); matches != nil {
+			problem.Line, _ = strconv.Atoi(matches[1])
+			problem.Message = matches[2]
+		}
+
+		if matches := unknownFieldPattern.FindStringSubmatch(problem.Message); matches != nil {
+			field := matches[1]
+			problem.Message = fmt.Sprintf("unknown field \"%v\"", field)
+
+			if path, keyNode := findKey(c.root, "", problem.Line, field); keyNode != nil {
+				problem.Column = keyNode.Column
+				problem.Path = path
+
+				if modelPathPattern.MatchString(strings.TrimSuffix(path, "."+field)) {
+					problem.Message = fmt.Sprintf("unknown provider or field \"%v\", please make sure there is no typo", field)
+				}
+			}
+		}
+
+		c.problems = append(c.problems, problem)
+	}
+}
+
+func (c *configChecker) addValidationErrors(p *Provider, err error) {
+	var fieldErrs validator.ValidationErrors
+
+	if !errors.As(err, &fieldErrs) {
+		c.problems = append(c.problems, errorProblems(err)...)
+
+		return
+	}
+
+	for _, fieldErr := range fieldErrs {
+		line, column := c.position(strings.TrimPrefix(fieldErr.Namespace(), "Config."))
+
+		// the message names the field already
+		c.problems = append(c.problems, Problem{Line: line, Column: column, Message: p.formatFieldError(fieldErr)})
+	}
+}
+
+// routerEntry is what routers of all kinds have in common for checks
+type routerEntry struct {
+	id       string
+	strategy routing.Strategy
+	modelIDs []string
+}
+
+func modelIDs[M any](models []M, modelID func(model M) string) []string {
+	ids := make([]string, 0, len(models))
+
+	for _, model := range models {
+		ids = append(ids, modelID(model))
+	}
+
+	return ids
+}
+
+// checkRouters finds problems that would stop the gateway from building routers
+func (c *configChecker) checkRouters(cfg *routers.Config) {
+	routerKinds := map[string][]routerEntry{}
+
+	for _, router := range cfg.LanguageRouters {
+		routerKinds["language"] = append(routerKinds["language"], routerEntry{
+			id:       router.ID,
+			strategy: router.RoutingStrategy,
+			modelIDs: modelIDs(router.Models, func(model providers.LangModelConfig) string { return model.ID }),
+		})
+	}
+
+	for _, router := range cfg.ImageRouters {
+		routerKinds["image"] = append(routerKinds["image"], routerEntry{
+			id:       router.ID,
+			strategy: router.RoutingStrategy,
+			modelIDs: modelIDs(router.Models, func(model providers.ImageModelConfig) string { return model.ID }),
+		})
+	}
+
+	for _, router := range cfg.AudioRouters {
+		routerKinds["audio"] = append(routerKinds["audio"], routerEntry{
+			id:       router.ID,
+			strategy: router.RoutingStrategy,
+			modelIDs: modelIDs(router.Models, func(model providers.AudioModelConfig) string { return model.ID }),
+		})
+	}
+
+	for _, router := range cfg.ModerationRouters {
+		routerKinds["moderation"] = append(routerKinds["moderation"], routerEntry{
+			id:       router.ID,
+			strategy: router.RoutingStrategy,
+			modelIDs: modelIDs(router.Models, func(model providers.ModerationModelConfig) string { return model.ID }),
+		})
+	}
+
+	for _, kind := range []string{"language", "image", "audio", "moderation"} {
+		seenRouterIDs := make(map[string]int, len(routerKinds[kind]))
+
+		for idx, router := range routerKinds[kind] {
+			routerPath := fmt.Sprintf("routers.%v[%v]", kind, idx)
+
+			if firstIdx, seen := seenRouterIDs[router.id]; seen {
+				c.add(routerPath+".id", fmt.Sprintf(
+					"%v router ID \"%v\" is already used by routers.%v[%v], each ID should be unique",
+					kind,
+					router.id,
+					kind,
+					firstIdx,
+				))
+			} else {
+				seenRouterIDs[router.id] = idx
+			}
+
+			if router.strategy != "" && !routing.Supported(router.strategy) {
+				c.add(routerPath+".strategy", fmt.Sprintf(
+					"routing strategy \"%v\" is not supported, please make sure there is no typo",
+					router.strategy,
+				))
+			}
+
+			seenModelIDs := make(map[string]bool, len(router.modelIDs))
+
+			for modelIdx, modelID := range router.modelIDs {
+				if seenModelIDs[modelID] {
+					c.add(fmt.Sprintf("%v.models[%v].id", routerPath, modelIdx), fmt.Sprintf(
+						"model ID \"%v\" is used more than once in router \"%v\", each ID should be unique in the router",
+						modelID,
+						router.id,
+					))
+				}
+
+				seenModelIDs[modelID] = true
+			}
+		}
+	}
+}
+
+// position finds the line & the column of the config field, zeros if the field is not in the config
+func (c *configChecker) position(path string) (int, int) {
+	if path == "" {
+		return 0, 0
+	}
+
+	node := nodeAt(c.root, path)
+
+	return node.Line, node.Column
+}
+
+// errorProblems turns the error into problems, one per line (e.g. for joined errors)
+func errorProblems(err error) []Problem {
+	lines := strings.Split(err.Error(), "\n")
+	problems := make([]Problem, 0, len(lines))
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		problem := Problem{Message: line}
+
+		if matches := yamlLineErrPattern.FindStringSubmatch(line); matches != nil {
+			problem.Line, _ = strconv.Atoi(matches[1])
+			problem.Message = matches[2]
+		}
+
+		problems = append(problems, problem)
+	}
+
+	return problems
+}
+
+// nodeAt finds the node of the config field by its path (e.g. routers.language[0].models[1].id).
+// The closest parent node is returned for fields missing in the config
+func nodeAt(root *yaml.Node, path string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if path == "" {
+		return node
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		name, indexes, _ := strings.Cut(segment, "[")
+
+		if name != "" {
+			child := mappingValue(node, name)
+			if child == nil {
+				return node
+			}
+
+			node = child
+		}
+
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			if index == "" {
+				continue
+			}
+
+			idx, err := strconv.Atoi(index)
+
+			switch {
+			case err == nil && node.Kind == yaml.SequenceNode && idx < len(node.Content):
+				node = node.Content[idx]
+			case err != nil && node.Kind == yaml.MappingNode && mappingValue(node, index) != nil:
+				node = mappingValue(node, index)
+			default:
+				return node
+			}
+		}
+	}
+
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			return node.Content[idx+1]
+		}
+	}
+
+	return nil
+}
+
+// findKey finds the mapping key at the line & returns its path
+func findKey(node *yaml.Node, path string, line int, key string) (string, *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			if foundPath, keyNode := findKey(child, path, line, key); keyNode != nil {
+				return foundPath, keyNode
+			}
+		}
+	case yaml.MappingNode:
+		for idx := 0; idx+1 < len(node.Content); idx += 2 {
+			keyNode, valueNode := node.Content[idx], node.Content[idx+1]
+			keyPath := strings.TrimPrefix(path+"."+keyNode.Value, ".")
+
+			if keyNode.Line == line && keyNode.Value == key {
+				return keyPath, keyNode
+			}
+
+			if foundPath, foundNode := findKey(valueNode, keyPath, line, key); foundNode != nil {
+				return foundPath, foundNode
+			}
+		}
+	case yaml.SequenceNode:
+		for idx, child := range node.Content {
+			if foundPath, keyNode := findKey(child, fmt.Sprintf("%v[%v]", path, idx), line, key); keyNode != nil {
+				return foundPath, keyNode
+			}
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigProvider_ValidateValidConfig(t *testing.T) {
+	problems := NewProvider().Validate("./testdata/provider.fullconfig.yaml")
+
+	require.Empty(t, problems)
+}
+
+func TestConfigProvider_ValidateNonExistingConfigFile(t *testing.T) {
+	problems := NewProvider().Validate("./testdata/doesntexist.yaml")
+
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Message, "no such file or directory")
+}
+
+func TestConfigProvider_ValidateUnknownProvider(t *testing.T) {
+	problems := NewProvider().Validate("./testdata/validate.unknownprovider.yaml")
+
+	require.Len(t, problems, 1)
+	require.Equal(t, Problem{
+		Line:    6,
+		Column:  11,
+		Path:    "routers.language[0].models[0].opeanai",
+		Message: "unknown provider or field \"opeanai\", please make sure there is no typo",
+	}, problems[0])
+}
+
+func TestConfigProvider_ValidateRouters(t *testing.T) {
+	problems := NewProvider().Validate("./testdata/validate.routers.yaml")
+
+	require.Len(t, problems, 3)
+
+	require.Equal(t, "routers.language[0].strategy", problems[0].Path)
+	require.Equal(t, 4, problems[0].Line)
+	require.Contains(t, problems[0].Message, "routing strategy \"fastest\" is not supported")
+
+	require.Equal(t, "routers.language[1].id", problems[1].Path)
+	require.Equal(t, 10, problems[1].Line)
+	require.Contains(t, problems[1].Message, "language router ID \"myrouter\" is already used")
+
+	require.Equal(t, "routers.language[1].models[1].id", problems[2].Path)
+	require.Equal(t, 16, problems[2].Line)
+	require.Contains(t, problems[2].Message, "model ID \"openai\" is used more than once")
+}
+
+func TestConfigProvider_ValidateMissingKey(t *testing.T) {
+	problems := NewProvider().Validate("./testdata/validate.nokey.yaml")
+
+	require.Len(t, problems, 1)
+	require.Equal(t, 7, problems[0].Line)
+	require.Contains(t, problems[0].Message, "routers.language[0].models[0].openai.api_key field is required")
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ReloadConfig defines how config changes are picked up at runtime.
+// The config is always reloaded on SIGHUP, the file watching is optional
+type ReloadConfig struct {
+	WatchFile bool          `yaml:"watch_file"` // reload the config when the config file changes
+	Interval  time.Duration `yaml:"interval"`   // how often the config file is checked for changes
+}
+
+func DefaultReloadConfig() *ReloadConfig {
+	return &ReloadConfig{
+		WatchFile: false,
+		Interval:  5 * time.Second,
+	}
+}
+
+func (c *ReloadConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultReloadConfig()
+
+	type plain ReloadConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Watcher polls the config file and notifies when its content changes.
+// Polling is used rather than filesystem events, so atomic file swaps (e.g. Kubernetes ConfigMap symlink updates) are caught too
+type Watcher struct {
+	path     string
+	interval time.Duration
+	checksum [sha256.Size]byte
+	changeC  chan struct{}
+	stopC    chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func NewWatcher(path string, interval time.Duration) *Watcher {
+	return &Watcher{
+		path:     path,
+		interval: interval,
+		changeC:  make(chan struct{}, 1),
+		stopC:    make(chan struct{}),
+	}
+}
+
+// Changes returns the channel that receives a notification each time the file content changes
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changeC
+}
+
+// Start remembers the current file content and starts checking it for changes in background
+func (w *Watcher) Start() {
+	w.checksum, _ = w.fileChecksum()
+
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.check()
+			case <-w.stopC:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops checking the file
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopC)
+	})
+
+	w.wg.Wait()
+}
+
+func (w *Watcher) check() {
+	checksum, err := w.fileChecksum()
+	if err != nil || checksum == w.checksum {
+		// the file may be missing for a moment while it's being replaced
+		return
+	}
+
+	w.checksum = checksum
+
+	select {
+	case w.changeC <- struct{}{}:
+	default:
+		// there is a pending notification already
+	}
+}
+
+func (w *Watcher) fileChecksum() ([sha256.Size]byte, error) {
+	content, err := os.ReadFile(filepath.Clean(w.path))
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return sha256.Sum256(content), nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_NotifiesOnChange(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("routers: {}"), 0o600))
+
+	watcher := NewWatcher(configPath, 5*time.Millisecond)
+	watcher.Start()
+	defer watcher.Stop()
+
+	select {
+	case <-watcher.Changes():
+		t.Fatal("unchanged file should not be reported")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, os.WriteFile(configPath, []byte("routers: {language: []}"), 0o600))
+
+	select {
+	case <-watcher.Changes():
+	case <-time.After(time.Second):
+		t.Fatal("file change was not reported")
+	}
+}
+
+func TestWatcher_IgnoresMissingFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("routers: {}"), 0o600))
+
+	watcher := NewWatcher(configPath, 5*time.Millisecond)
+	watcher.Start()
+	defer watcher.Stop()
+
+	require.NoError(t, os.Remove(configPath))
+
+	select {
+	case <-watcher.Changes():
+		t.Fatal("missing file should not be reported")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestReloadConfig_Defaults(t *testing.T) {
+	cfg := DefaultConfig()
+
+	require.False(t, cfg.Reload.WatchFile)
+	require.Equal(t, 5*time.Second, cfg.Reload.Interval)
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+
+	"glide/pkg/telemetry"
+)
+
+// auditEventPrefix prefixes types of events that carry audit entries (e.g. "audit.config.reloaded")
+const auditEventPrefix = "audit."
+
+// AuditSink delivers audit entries as events, so they could be stored by an external service (e.g. a SIEM)
+type AuditSink struct {
+	deliverer *Deliverer
+}
+
+var _ telemetry.AuditSink = (*AuditSink)(nil)
+
+func NewAuditSink(deliverer *Deliverer) *AuditSink {
+	return &AuditSink{
+		deliverer: deliverer,
+	}
+}
+
+// Write delivers the entry in the background. Failed deliveries are logged & dead-lettered by the deliverer
+func (s *AuditSink) Write(entry *telemetry.AuditEntry) error {
+	eventType := auditEventPrefix + entry.Action
+
+	if !s.deliverer.Subscribed(eventType) {
+		return nil
+	}
+
+	event := NewEvent(eventType, entry)
+
+	go func() {
+		_ = s.deliverer.Deliver(context.Background(), event)
+	}()
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DeadLetter is an event that could not be delivered after all retries
+type DeadLetter struct {
+	FailedAt time.Time `json:"failedAt"`
+	URL      string    `json:"url"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	Event    *Event    `json:"event"`
+}
+
+// DeadLetterFile appends undelivered events (or other records) to a JSON Lines file, so they could be inspected and replayed later
+type DeadLetterFile struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewDeadLetterFile(path string) *DeadLetterFile {
+	return &DeadLetterFile{
+		path: path,
+	}
+}
+
+func (f *DeadLetterFile) Write(deadLetter *DeadLetter) error {
+	line, err := json.Marshal(deadLetter)
+	if err != nil {
+		return err
+	}
+
+	return f.Append(append(line, '\n'))
+}
+
+// Append writes already encoded JSON Lines, e.g. records of other kinds that have failed to be delivered
+func (f *DeadLetterFile) Append(lines []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(filepath.Clean(f.path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(lines); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
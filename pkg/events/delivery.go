@@ -0,0 +1,216 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"glide/pkg/routers/retry"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const (
+	EventTypeHeader       = "X-Glide-Event"
+	EventIDHeader         = "X-Glide-Event-ID"
+	DeliveryAttemptHeader = "X-Glide-Delivery-Attempt"
+)
+
+var ErrDeliveryRejected = errors.New("event delivery is rejected by the receiver")
+
+// DeliveryConfig defines where and how outbound events are delivered
+type DeliveryConfig struct {
+	URL            string                `yaml:"url" json:"url" validate:"required,url"`
+	Timeout        *time.Duration        `yaml:"timeout,omitempty" json:"timeout" swaggertype:"primitive,string"`
+	Retry          *retry.ExpRetryConfig `yaml:"retry" json:"retry" validate:"required"`
+	Signing        *SigningConfig        `yaml:"signing,omitempty" json:"signing,omitempty"`
+	DeadLetterFile string                `yaml:"dead_letter_file,omitempty" json:"dead_letter_file,omitempty"` // JSON Lines file undelivered events are appended to
+	Events         []string              `yaml:"events,omitempty" json:"events,omitempty"`                     // types of events delivered to the URL (e.g. "model.*"), all by default
+}
+
+func DefaultDeliveryConfig() *DeliveryConfig {
+	defaultTimeout := 5 * time.Second
+
+	return &DeliveryConfig{
+		Timeout: &defaultTimeout,
+		Retry:   retry.DefaultExpRetryConfig(),
+	}
+}
+
+func (c *DeliveryConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultDeliveryConfig()
+
+	type plain DeliveryConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Deliverer sends events to the configured URL signing them and retrying failed deliveries with exponential backoff.
+// Events that could not be delivered are written to the dead-letter file (if configured)
+type Deliverer struct {
+	url        string
+	eventTypes []string
+	httpClient *http.Client
+	retry      *retry.ExpRetry
+	signer     *Signer
+	deadLetter *DeadLetterFile
+	tel        *telemetry.Telemetry
+	logger     *zap.Logger
+}
+
+func NewDeliverer(cfg *DeliveryConfig, tel *telemetry.Telemetry) *Deliverer {
+	httpClient := &http.Client{}
+
+	if cfg.Timeout != nil {
+		httpClient.Timeout = *cfg.Timeout
+	}
+
+	deliverer := &Deliverer{
+		url:        cfg.URL,
+		eventTypes: cfg.Events,
+		httpClient: httpClient,
+		retry: retry.NewExpRetry(
+			cfg.Retry.MaxRetries,
+			cfg.Retry.BaseMultiplier,
+			cfg.Retry.MinDelay,
+			cfg.Retry.MaxDelay,
+		),
+		tel:    tel,
+		logger: tel.L().With(zap.String("url", cfg.URL)),
+	}
+
+	if cfg.Signing != nil {
+		deliverer.signer = NewSigner(cfg.Signing)
+	}
+
+	if cfg.DeadLetterFile != "" {
+		deliverer.deadLetter = NewDeadLetterFile(cfg.DeadLetterFile)
+	}
+
+	return deliverer
+}
+
+// Subscribed checks if events of the type should be delivered to the URL.
+// Types ending with ".*" match all events with the prefix (e.g. "model.*" matches "model.unhealthy")
+func (d *Deliverer) Subscribed(eventType string) bool {
+	if len(d.eventTypes) == 0 {
+		return true
+	}
+
+	for _, subscribedType := range d.eventTypes {
+		if prefix, wildcard := strings.CutSuffix(subscribedType, "*"); wildcard && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+
+		if subscribedType == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deliver sends the event retrying on network errors, throttling & server errors.
+// Other client errors are not retried as they would not succeed on the next attempt either
+func (d *Deliverer) Deliver(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	attempt := 1
+	retryIterator := d.retry.Iterator()
+
+	for {
+		err = d.send(ctx, event, payload, attempt)
+		if err == nil {
+			d.tel.M().Counter("events.delivered").Inc()
+
+			return nil
+		}
+
+		d.logger.Warn(
+			"failed to deliver event",
+			zap.String("eventID", event.ID),
+			zap.String("eventType", event.Type),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
+		)
+
+		if errors.Is(err, ErrDeliveryRejected) || !retryIterator.HasNext() {
+			break
+		}
+
+		if waitErr := retryIterator.WaitNext(ctx); waitErr != nil {
+			err = waitErr
+
+			break
+		}
+
+		d.tel.M().Counter("events.retries").Inc()
+		attempt++
+	}
+
+	d.tel.M().Counter("events.failed").Inc()
+	d.writeDeadLetter(event, attempt, err)
+
+	return err
+}
+
+func (d *Deliverer) send(ctx context.Context, event *Event, payload []byte, attempt int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, event.Type)
+	req.Header.Set(EventIDHeader, event.ID)
+	req.Header.Set(DeliveryAttemptHeader, strconv.Itoa(attempt))
+
+	if d.signer != nil {
+		req.Header.Set(SignatureHeader, d.signer.Sign(payload, time.Now()))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("receiver responded with %v status", resp.StatusCode)
+	}
+
+	return fmt.Errorf("%w: receiver responded with %v status", ErrDeliveryRejected, resp.StatusCode)
+}
+
+func (d *Deliverer) writeDeadLetter(event *Event, attempts int, deliveryErr error) {
+	if d.deadLetter == nil {
+		return
+	}
+
+	err := d.deadLetter.Write(&DeadLetter{
+		FailedAt: time.Now().UTC(),
+		URL:      d.url,
+		Attempts: attempts,
+		Error:    deliveryErr.Error(),
+		Event:    event,
+	})
+	if err != nil {
+		d.logger.Error("failed to write event to the dead-letter file", zap.String("eventID", event.ID), zap.Error(err))
+	}
+}
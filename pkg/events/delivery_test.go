@@ -0,0 +1,109 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/config/fields"
+	"glide/pkg/routers/retry"
+	"glide/pkg/telemetry"
+)
+
+func newDeliveryConfig(url string) *DeliveryConfig {
+	maxDelay := 5 * time.Millisecond
+
+	cfg := DefaultDeliveryConfig()
+	cfg.URL = url
+	cfg.Retry = &retry.ExpRetryConfig{MaxRetries: 2, BaseMultiplier: 2, MinDelay: time.Millisecond, MaxDelay: &maxDelay}
+	cfg.Signing = &SigningConfig{Secrets: []fields.Secret{"secret"}}
+
+	return cfg
+}
+
+func TestDeliverer_RetriesAndSigns(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, Verify(r.Header.Get(SignatureHeader), payload, "secret", time.Minute, time.Now()))
+		require.Equal(t, "model.unhealthy", r.Header.Get(EventTypeHeader))
+
+		if attempts.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		require.Equal(t, "2", r.Header.Get(DeliveryAttemptHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	tel := telemetry.NewTelemetryMock()
+	deliverer := NewDeliverer(newDeliveryConfig(server.URL), tel)
+
+	require.NoError(t, deliverer.Deliver(context.Background(), NewEvent("model.unhealthy", map[string]string{"modelID": "openai"})))
+	require.Equal(t, int32(2), attempts.Load())
+	require.Equal(t, int64(1), tel.M().Counter("events.delivered").Value())
+	require.Equal(t, int64(1), tel.M().Counter("events.retries").Value())
+}
+
+func TestDeliverer_DeadLetter(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := newDeliveryConfig(server.URL)
+	cfg.DeadLetterFile = filepath.Join(t.TempDir(), "events.dlq.jsonl")
+
+	deliverer := NewDeliverer(cfg, telemetry.NewTelemetryMock())
+	event := NewEvent("budget.exceeded", nil)
+
+	require.Error(t, deliverer.Deliver(context.Background(), event))
+	require.Equal(t, int32(3), attempts.Load())
+
+	file, err := os.Open(cfg.DeadLetterFile)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	require.True(t, scanner.Scan())
+
+	var deadLetter DeadLetter
+
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &deadLetter))
+	require.Equal(t, event.ID, deadLetter.Event.ID)
+	require.Equal(t, 3, deadLetter.Attempts)
+	require.False(t, scanner.Scan())
+}
+
+func TestDeliverer_RejectedIsNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	deliverer := NewDeliverer(newDeliveryConfig(server.URL), telemetry.NewTelemetryMock())
+
+	err := deliverer.Deliver(context.Background(), NewEvent("budget.exceeded", nil))
+	require.ErrorIs(t, err, ErrDeliveryRejected)
+	require.Equal(t, int32(1), attempts.Load())
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+
+	"glide/pkg/telemetry"
+)
+
+// Dispatcher delivers events to every webhook subscribed to their types (e.g. to page on-call & to notify a chat channel)
+type Dispatcher struct {
+	deliverers []*Deliverer
+}
+
+// NewDispatcher returns nil when no webhooks are configured, so callers could skip building events
+func NewDispatcher(configs []*DeliveryConfig, tel *telemetry.Telemetry) *Dispatcher {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	deliverers := make([]*Deliverer, 0, len(configs))
+
+	for _, cfg := range configs {
+		deliverers = append(deliverers, NewDeliverer(cfg, tel))
+	}
+
+	return &Dispatcher{
+		deliverers: deliverers,
+	}
+}
+
+// Emit delivers the event in the background. Failed deliveries are logged & dead-lettered by deliverers
+func (d *Dispatcher) Emit(event *Event) {
+	if d == nil {
+		return
+	}
+
+	for _, deliverer := range d.deliverers {
+		if !deliverer.Subscribed(event.Type) {
+			continue
+		}
+
+		go func(deliverer *Deliverer) {
+			_ = deliverer.Deliver(context.Background(), event)
+		}(deliverer)
+	}
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+func TestDispatcher_DeliversToSubscribedWebhooks(t *testing.T) {
+	newWebhook := func(eventC chan<- string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event Event
+
+			_ = json.NewDecoder(r.Body).Decode(&event)
+			eventC <- event.Type
+
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	pagerC, chatC := make(chan string, 2), make(chan string, 2)
+
+	pager := newWebhook(pagerC)
+	defer pager.Close()
+
+	chat := newWebhook(chatC)
+	defer chat.Close()
+
+	pagerConfig := newDeliveryConfig(pager.URL)
+	pagerConfig.Events = []string{"model.*", "router.fallback_storm"}
+
+	dispatcher := NewDispatcher([]*DeliveryConfig{pagerConfig, newDeliveryConfig(chat.URL)}, telemetry.NewTelemetryMock())
+
+	dispatcher.Emit(NewEvent("model.unhealthy", nil))
+	dispatcher.Emit(NewEvent("router.budget_exhausted", nil))
+
+	require.Equal(t, "model.unhealthy", receive(t, pagerC))
+	require.ElementsMatch(t, []string{"model.unhealthy", "router.budget_exhausted"}, []string{receive(t, chatC), receive(t, chatC)})
+
+	select {
+	case eventType := <-pagerC:
+		require.Fail(t, "unsubscribed event is delivered", eventType)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDispatcher_IsNilWithoutWebhooks(t *testing.T) {
+	dispatcher := NewDispatcher(nil, telemetry.NewTelemetryMock())
+	require.Nil(t, dispatcher)
+
+	dispatcher.Emit(NewEvent("model.unhealthy", nil)) // emitting without webhooks is a no-op
+}
+
+func receive(t *testing.T, eventC <-chan string) string {
+	t.Helper()
+
+	select {
+	case eventType := <-eventC:
+		return eventType
+	case <-time.After(time.Second):
+		require.Fail(t, "the event is not delivered")
+	}
+
+	return ""
+}
@@ -0,0 +1,24 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is an outbound notification about something that happened in the gateway (e.g. a model became unhealthy)
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt int         `json:"createdAt"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+func NewEvent(eventType string, data interface{}) *Event {
+	return &Event{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		CreatedAt: int(time.Now().UTC().Unix()),
+		Data:      data,
+	}
+}
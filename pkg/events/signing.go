@@ -0,0 +1,111 @@
+package events
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"glide/pkg/config/fields"
+)
+
+const (
+	// SignatureHeader carries the delivery timestamp and HMAC-SHA256 signatures of the payload
+	//  in the "t=<unix timestamp>,v1=<hex signature>[,v1=<hex signature>...]" format
+	SignatureHeader = "X-Glide-Signature"
+	signatureScheme = "v1"
+)
+
+var (
+	ErrInvalidSignatureHeader = errors.New("invalid signature header")
+	ErrSignatureMismatch      = errors.New("no signature matches the payload")
+	ErrSignatureExpired       = errors.New("signature timestamp is out of the tolerance window")
+)
+
+// SigningConfig defines secrets outbound events are signed with.
+// The payload is signed with every secret, so secrets could be rotated without downtime:
+// add a new secret, switch consumers to it, then remove the old one
+type SigningConfig struct {
+	Secrets []fields.Secret `yaml:"secrets" json:"-" validate:"required,min=1,dive,required"`
+}
+
+// Signer signs event payloads with HMAC-SHA256
+type Signer struct {
+	secrets []fields.Secret
+}
+
+func NewSigner(cfg *SigningConfig) *Signer {
+	return &Signer{
+		secrets: cfg.Secrets,
+	}
+}
+
+// Sign returns the signature header value for the payload sent at the given time.
+// The timestamp is signed together with the payload to prevent replay attacks
+func (s *Signer) Sign(payload []byte, sentAt time.Time) string {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	parts := make([]string, 0, len(s.secrets)+1)
+
+	parts = append(parts, "t="+timestamp)
+
+	for _, secret := range s.secrets {
+		parts = append(parts, signatureScheme+"="+computeSignature(string(secret), timestamp, payload))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Verify checks the signature header of the received payload with the secret.
+// It's what event consumers should do, so they could be sure events come from the gateway
+func Verify(header string, payload []byte, secret string, tolerance time.Duration, now time.Time) error {
+	var (
+		timestamp  string
+		signatures []string
+	)
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return ErrInvalidSignatureHeader
+		}
+
+		switch key {
+		case "t":
+			timestamp = value
+		case signatureScheme:
+			signatures = append(signatures, value)
+		}
+	}
+
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignatureHeader
+	}
+
+	if tolerance > 0 && now.Sub(time.Unix(sentAt, 0)).Abs() > tolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := computeSignature(secret, timestamp, payload)
+
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrSignatureMismatch
+}
+
+func computeSignature(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	_, _ = fmt.Fprintf(mac, "%s.", timestamp)
+	_, _ = mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
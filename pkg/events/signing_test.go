@@ -0,0 +1,33 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/config/fields"
+)
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	payload := []byte(`{"type":"model.unhealthy"}`)
+	sentAt := time.Unix(1700000000, 0)
+
+	signer := NewSigner(&SigningConfig{Secrets: []fields.Secret{"new-secret", "old-secret"}})
+	header := signer.Sign(payload, sentAt)
+
+	require.Contains(t, header, "t=1700000000,v1=")
+
+	// consumers could verify with any of the active secrets during rotation
+	require.NoError(t, Verify(header, payload, "new-secret", time.Minute, sentAt))
+	require.NoError(t, Verify(header, payload, "old-secret", time.Minute, sentAt))
+
+	require.ErrorIs(t, Verify(header, payload, "unknown-secret", time.Minute, sentAt), ErrSignatureMismatch)
+	require.ErrorIs(t, Verify(header, []byte(`{"type":"forged"}`), "new-secret", time.Minute, sentAt), ErrSignatureMismatch)
+	require.ErrorIs(t, Verify(header, payload, "new-secret", time.Minute, sentAt.Add(time.Hour)), ErrSignatureExpired)
+}
+
+func TestSigner_VerifyInvalidHeader(t *testing.T) {
+	for _, header := range []string{"", "v1=abc", "t=abc,v1=abc", "t=1700000000"} {
+		require.ErrorIs(t, Verify(header, nil, "secret", 0, time.Now()), ErrInvalidSignatureHeader, header)
+	}
+}
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"reflect"
 	"syscall"
+	"time"
 
 	"glide/pkg/version"
 
+	"glide/pkg/observability"
 	"glide/pkg/routers"
+	"glide/pkg/usage"
 
 	"glide/pkg/config"
 
@@ -20,6 +24,9 @@ import (
 	"go.uber.org/multierr"
 )
 
+// minSecretsRenewalInterval keeps failing secret renewals (e.g. when the secret backend is down) from retrying too often
+const minSecretsRenewalInterval = 10 * time.Second
+
 // Gateway represents an instance of running Glide gateway.
 // It loads configs, start API server(s), and listen to termination signals to shut down
 type Gateway struct {
@@ -27,10 +34,20 @@ type Gateway struct {
 	configProvider *config.Provider
 	// tel holds logger, meter, and tracer
 	tel *telemetry.Telemetry
+	// routerManager holds all routers & their models
+	routerManager *routers.RouterManager
 	// serverManager controls API over different protocols
 	serverManager *api.ServerManager
 	// signalChannel is used to receive termination signals from the OS.
 	signalC chan os.Signal
+	// reloadC is used to receive config reload signals (SIGHUP) from the OS.
+	reloadC chan os.Signal
+	// usageExporter ships per-request usage records to external sinks if the export is enabled
+	usageExporter *usage.Exporter
+	// generationExporter ships prompts & completions to LLM observability services if it's enabled
+	generationExporter *observability.Exporter
+	// configWatcher notifies about config file changes if watching is enabled
+	configWatcher *config.Watcher
 	// shutdownC is used to terminate the gateway
 	shutdownC chan struct{}
 }
@@ -38,11 +55,39 @@ type Gateway struct {
 func NewGateway(configProvider *config.Provider) (*Gateway, error) {
 	cfg := configProvider.Get()
 
-	tel, err := telemetry.NewTelemetry(&telemetry.Config{LogConfig: cfg.Telemetry.LogConfig})
+	tel, err := telemetry.NewTelemetry(cfg.Telemetry)
 	if err != nil {
 		return nil, err
 	}
 
+	if cfg.Audit != nil {
+		for _, sink := range cfg.Audit.Sinks(tel) {
+			tel.Audit.AddSink(sink)
+		}
+	}
+
+	var usageExporter *usage.Exporter
+
+	if cfg.UsageExport != nil {
+		usageExporter, err = usage.NewExporter(cfg.UsageExport, tel)
+		if err != nil {
+			return nil, err
+		}
+
+		tel.Usage.AddSink(usageExporter)
+	}
+
+	var generationExporter *observability.Exporter
+
+	if cfg.Observability != nil {
+		generationExporter, err = observability.NewExporter(cfg.Observability, tel)
+		if err != nil {
+			return nil, err
+		}
+
+		tel.Generations.AddSink(generationExporter)
+	}
+
 	tel.L().Info("🐦Glide is starting up", zap.String("version", version.FullVersion))
 	tel.L().Debug("✅ Config loaded successfully:\n" + configProvider.GetStr())
 
@@ -51,38 +96,83 @@ func NewGateway(configProvider *config.Provider) (*Gateway, error) {
 		return nil, err
 	}
 
-	serverManager, err := api.NewServerManager(cfg.API, tel, routerManager)
+	serverManager, err := api.NewServerManager(cfg.API, tel.Module(telemetry.ModuleHTTP), routerManager)
 	if err != nil {
 		return nil, err
 	}
 
+	serverManager.WithConfigExporter(func(format string) ([]byte, error) {
+		return config.MarshalCanonical(configProvider.Export(routerManager.EffectiveConfig()), format)
+	})
+
 	return &Gateway{
-		configProvider: configProvider,
-		tel:            tel,
-		serverManager:  serverManager,
-		signalC:        make(chan os.Signal, 3), // equal to number of signal types we expect to receive
-		shutdownC:      make(chan struct{}),
+		configProvider:     configProvider,
+		tel:                tel,
+		routerManager:      routerManager,
+		serverManager:      serverManager,
+		usageExporter:      usageExporter,
+		generationExporter: generationExporter,
+		signalC:            make(chan os.Signal, 3), // equal to number of signal types we expect to receive
+		reloadC:            make(chan os.Signal, 1),
+		shutdownC:          make(chan struct{}),
 	}, nil
 }
 
 // Run starts and runs the gateway according to given configuration
 func (gw *Gateway) Run(ctx context.Context) error {
 	gw.configProvider.Start()
+
+	if gw.usageExporter != nil {
+		gw.usageExporter.Start()
+	}
+
+	if gw.generationExporter != nil {
+		gw.generationExporter.Start()
+	}
+
 	gw.serverManager.Start() //nolint:contextcheck
 
 	signal.Notify(gw.signalC, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	defer signal.Stop(gw.signalC)
 
+	signal.Notify(gw.reloadC, syscall.SIGHUP)
+	defer signal.Stop(gw.reloadC)
+
+	var configChangeC <-chan struct{}
+
+	if reloadConfig := gw.configProvider.Get().Reload; reloadConfig != nil && reloadConfig.WatchFile {
+		gw.configWatcher = config.NewWatcher(gw.configProvider.Path(), reloadConfig.Interval)
+		gw.configWatcher.Start()
+
+		defer gw.configWatcher.Stop()
+
+		configChangeC = gw.configWatcher.Changes()
+	}
+
+	secretsRenewalC := gw.secretsRenewal()
+
 LOOP:
 	for {
 		select {
-		// TODO: Watch for config updates
+		case <-gw.reloadC:
+			gw.reload("signal")
+			secretsRenewalC = gw.secretsRenewal()
+		case <-configChangeC:
+			gw.reload("file change")
+			secretsRenewalC = gw.secretsRenewal()
+		case <-secretsRenewalC:
+			gw.reload("secret renewal")
+			secretsRenewalC = gw.secretsRenewal()
 		case sig := <-gw.signalC:
 			gw.tel.L().Info("received signal from os", zap.String("signal", sig.String()))
 			break LOOP
 		case <-gw.shutdownC:
 			gw.tel.L().Info("received shutdown request")
 			break LOOP
+		case err := <-gw.serverManager.Errors():
+			gw.tel.L().Error("server has stopped unexpectedly, shutting down", zap.Error(err))
+
+			return multierr.Append(err, gw.shutdown(ctx))
 		case <-ctx.Done():
 			gw.tel.L().Info("context done, terminating process")
 			// Call shutdown with background context as the passed in context has been canceled
@@ -93,10 +183,82 @@ LOOP:
 	return gw.shutdown(ctx)
 }
 
+// secretsRenewal returns the channel notified when secrets referenced in the config should be fetched again.
+// Secrets are renewed by reloading the config, so changed ones are applied like any other config change
+func (gw *Gateway) secretsRenewal() <-chan time.Time {
+	renewAt := gw.configProvider.SecretsRenewAt()
+	if renewAt.IsZero() {
+		return nil
+	}
+
+	// the failed renewal keeps the previous renewal time, so it's retried with a delay
+	return time.After(max(time.Until(renewAt), minSecretsRenewalInterval))
+}
+
+// reload re-reads the config file and applies router changes to the running gateway
+func (gw *Gateway) reload(trigger string) {
+	gw.tel.L().Info("reloading config", zap.String("trigger", trigger), zap.String("path", gw.configProvider.Path()))
+
+	prevConfig := gw.configProvider.Get()
+
+	cfg, err := gw.configProvider.Reload()
+	if err != nil {
+		gw.routerManager.ReloadFailed(err)
+		gw.tel.L().Error("failed to reload config, keeping the current one", zap.Error(err))
+		gw.auditReload("config.reload_failed", trigger, map[string]interface{}{"error": err.Error()})
+
+		return
+	}
+
+	status, err := gw.routerManager.Reload(&cfg.Routers)
+	if err != nil {
+		gw.tel.L().Error("failed to apply reloaded router config, keeping the current routers", zap.Error(err))
+		gw.auditReload("config.reload_failed", trigger, map[string]interface{}{"error": err.Error()})
+
+		return
+	}
+
+	restartRequired := status.RestartRequired
+
+	if !reflect.DeepEqual(prevConfig.API, cfg.API) {
+		restartRequired = append(restartRequired, "api")
+	}
+
+	if !reflect.DeepEqual(prevConfig.Telemetry, cfg.Telemetry) {
+		restartRequired = append(restartRequired, "telemetry")
+	}
+
+	gw.tel.L().Info(
+		"✅ Config reloaded successfully",
+		zap.Strings("added", status.Added),
+		zap.Strings("updated", status.Updated),
+		zap.Strings("removed", status.Removed),
+	)
+
+	if len(restartRequired) > 0 {
+		gw.tel.L().Warn("some config changes are not applied until restart", zap.Strings("sections", restartRequired))
+	}
+
+	gw.auditReload("config.reloaded", trigger, map[string]interface{}{
+		"added":           status.Added,
+		"updated":         status.Updated,
+		"removed":         status.Removed,
+		"restartRequired": restartRequired,
+	})
+}
+
+// auditReload records the config reload outcome. Reloads are triggered by the operator via the signal or the config file
+func (gw *Gateway) auditReload(action string, trigger string, details map[string]interface{}) {
+	details["trigger"] = trigger
+
+	gw.tel.Audit.Record(telemetry.SystemActor, action, gw.configProvider.Path(), details)
+}
+
 func (gw *Gateway) Shutdown() {
 	close(gw.shutdownC)
 }
 
+// shutdown drains servers first, so in-flight requests could still use routers & provider clients
 func (gw *Gateway) shutdown(ctx context.Context) error {
 	var errs error
 
@@ -104,5 +266,11 @@ func (gw *Gateway) shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown servers: %w", err))
 	}
 
+	gw.routerManager.Shutdown()
+	gw.usageExporter.Stop() // after routers, so usage of the last served requests is exported too
+	gw.generationExporter.Stop()
+
+	gw.tel.Shutdown()
+
 	return errs
 }
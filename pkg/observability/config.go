@@ -0,0 +1,43 @@
+package observability
+
+import (
+	"time"
+
+	"glide/pkg/telemetry"
+)
+
+// Config defines how prompts & completions of served requests are exported to LLM observability services
+// (Langfuse, LangSmith). At least one service should be configured
+type Config struct {
+	SampleRate    float64                    `yaml:"sample_rate" validate:"gte=0,lte=1"`      // share of served requests exported
+	Redaction     *telemetry.RedactionConfig `yaml:"redaction"`                               // removes sensitive data from prompts & completions, disabled when nil
+	BatchSize     int                        `yaml:"batch_size" validate:"required,gt=0"`     // generations are exported once the batch is full
+	FlushInterval time.Duration              `yaml:"flush_interval" validate:"required,gt=0"` // or once the interval has passed
+	BufferSize    int                        `yaml:"buffer_size" validate:"required,gt=0"`    // generations are dropped once the buffer is full
+	Timeout       time.Duration              `yaml:"timeout" validate:"required,gt=0"`
+	Langfuse      *LangfuseConfig            `yaml:"langfuse,omitempty"`
+	LangSmith     *LangSmithConfig           `yaml:"langsmith,omitempty"`
+}
+
+func DefaultConfig() *Config {
+	// contents are kept by default as they are the point of LLM observability, only sensitive patterns are scrubbed
+	redaction := telemetry.DefaultRedactionConfig()
+	redaction.HashContent = false
+
+	return &Config{
+		SampleRate:    1,
+		Redaction:     redaction,
+		BatchSize:     50,
+		FlushInterval: 5 * time.Second,
+		BufferSize:    1_000,
+		Timeout:       10 * time.Second,
+	}
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
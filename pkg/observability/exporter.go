@@ -0,0 +1,218 @@
+package observability
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+var ErrNoServices = errors.New("LLM observability is enabled, but no services are configured")
+
+// Sink sends a batch of generations to the LLM observability service
+type Sink interface {
+	Name() string
+	Export(ctx context.Context, generations []*telemetry.Generation) error
+}
+
+// Exporter samples & redacts served generations and exports them in batches to all configured services.
+// Exports are best effort: generations of failed batches are dropped, so a service outage doesn't pile them up in memory
+type Exporter struct {
+	cfg         *Config
+	sinks       []Sink
+	redactor    *telemetry.Redactor
+	generationC chan *telemetry.Generation
+	stopC       chan struct{}
+	doneC       chan struct{}
+	started     bool
+	stopOnce    sync.Once
+	tel         *telemetry.Telemetry
+	logger      *zap.Logger
+}
+
+var _ telemetry.GenerationSink = (*Exporter)(nil)
+
+func NewExporter(cfg *Config, tel *telemetry.Telemetry) (*Exporter, error) {
+	sinks := make([]Sink, 0, 2)
+
+	if cfg.Langfuse != nil {
+		sinks = append(sinks, NewLangfuseSink(cfg.Langfuse))
+	}
+
+	if cfg.LangSmith != nil {
+		sinks = append(sinks, NewLangSmithSink(cfg.LangSmith))
+	}
+
+	if len(sinks) == 0 {
+		return nil, ErrNoServices
+	}
+
+	return newExporter(cfg, sinks, tel)
+}
+
+func newExporter(cfg *Config, sinks []Sink, tel *telemetry.Telemetry) (*Exporter, error) {
+	exporter := &Exporter{
+		cfg:         cfg,
+		sinks:       sinks,
+		generationC: make(chan *telemetry.Generation, cfg.BufferSize),
+		stopC:       make(chan struct{}),
+		doneC:       make(chan struct{}),
+		tel:         tel,
+		logger:      tel.L().With(zap.String("component", "llm_observability")),
+	}
+
+	if cfg.Redaction != nil && cfg.Redaction.Enabled {
+		redactor, err := telemetry.NewRedactor(cfg.Redaction)
+		if err != nil {
+			return nil, err
+		}
+
+		exporter.redactor = redactor
+	}
+
+	return exporter, nil
+}
+
+// Add samples the generation & queues it for export. It's dropped if the buffer is full
+func (e *Exporter) Add(generation *telemetry.Generation) {
+	if e.cfg.SampleRate < 1 && rand.Float64() >= e.cfg.SampleRate { //nolint:gosec
+		return
+	}
+
+	select {
+	case e.generationC <- generation:
+	default:
+		e.tel.M().Counter("llm_observability.dropped").Inc()
+	}
+}
+
+func (e *Exporter) Start() {
+	e.started = true
+
+	go e.run()
+}
+
+// Stop exports the buffered generations & waits until it's done
+func (e *Exporter) Stop() {
+	if e == nil || !e.started {
+		return
+	}
+
+	e.stopOnce.Do(func() {
+		close(e.stopC)
+	})
+
+	<-e.doneC
+}
+
+func (e *Exporter) run() {
+	defer close(e.doneC)
+
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*telemetry.Generation, 0, e.cfg.BatchSize)
+
+	for {
+		select {
+		case generation := <-e.generationC:
+			batch = append(batch, e.redact(generation))
+
+			if len(batch) >= e.cfg.BatchSize {
+				e.flush(batch)
+				batch = make([]*telemetry.Generation, 0, e.cfg.BatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				e.flush(batch)
+				batch = make([]*telemetry.Generation, 0, e.cfg.BatchSize)
+			}
+		case <-e.stopC:
+			for {
+				select {
+				case generation := <-e.generationC:
+					batch = append(batch, e.redact(generation))
+				default:
+					if len(batch) > 0 {
+						e.flush(batch)
+					}
+
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *Exporter) flush(batch []*telemetry.Generation) {
+	for _, sink := range e.sinks {
+		ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Timeout)
+		err := sink.Export(ctx, batch)
+
+		cancel()
+
+		if err != nil {
+			e.tel.M().Counter(fmt.Sprintf("llm_observability.%v.failed", sink.Name())).Inc()
+			e.logger.Warn("failed to export generations", zap.String("sink", sink.Name()), zap.Int("generations", len(batch)), zap.Error(err))
+
+			continue
+		}
+
+		e.tel.M().Counter(fmt.Sprintf("llm_observability.%v.exported", sink.Name())).Add(int64(len(batch)))
+	}
+}
+
+// redact returns the copy of the generation without sensitive data, the recorded generation is shared with other sinks
+func (e *Exporter) redact(generation *telemetry.Generation) *telemetry.Generation {
+	if e.redactor == nil {
+		return generation
+	}
+
+	redacted := *generation
+	redacted.Input = make([]telemetry.GenerationMessage, 0, len(generation.Input))
+
+	for _, message := range generation.Input {
+		redacted.Input = append(redacted.Input, telemetry.GenerationMessage{
+			Role:    message.Role,
+			Content: e.redactor.Content(message.Content),
+		})
+	}
+
+	redacted.Output = e.redactor.Content(generation.Output)
+
+	return &redacted
+}
+
+// postJSON sends the payload & returns the response body of successful requests
+func postJSON(ctx context.Context, httpClient *http.Client, url string, body []byte, auth func(req *http.Request)) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	auth(req)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("unexpected response status %v: %s", resp.StatusCode, respBody)
+	}
+
+	return respBody, err
+}
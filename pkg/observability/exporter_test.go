@@ -0,0 +1,102 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sinkMock struct {
+	mu      sync.Mutex
+	batches [][]*telemetry.Generation
+}
+
+func (s *sinkMock) Name() string {
+	return "mock"
+}
+
+func (s *sinkMock) Export(_ context.Context, generations []*telemetry.Generation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, generations)
+
+	return nil
+}
+
+func (s *sinkMock) Generations() []*telemetry.Generation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	generations := make([]*telemetry.Generation, 0, len(s.batches))
+
+	for _, batch := range s.batches {
+		generations = append(generations, batch...)
+	}
+
+	return generations
+}
+
+func testConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.BatchSize = 2
+	cfg.FlushInterval = time.Hour
+
+	return cfg
+}
+
+func TestExporter_RedactsGenerations(t *testing.T) {
+	sink := &sinkMock{}
+	exporter, err := newExporter(testConfig(), []Sink{sink}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	generation := &telemetry.Generation{
+		ID:     "1",
+		Input:  []telemetry.GenerationMessage{{Role: "user", Content: "my email is john@example.com"}},
+		Output: "noted, john@example.com",
+	}
+
+	exporter.Start()
+	exporter.Add(generation)
+	exporter.Stop()
+
+	generations := sink.Generations()
+	require.Len(t, generations, 1)
+	require.Equal(t, "user", generations[0].Input[0].Role)
+	require.NotContains(t, generations[0].Input[0].Content, "john@example.com")
+	require.Contains(t, generations[0].Input[0].Content, "my email is")
+	require.NotContains(t, generations[0].Output, "john@example.com")
+
+	// the recorded generation is shared with other sinks, so it's left as is
+	require.Contains(t, generation.Input[0].Content, "john@example.com")
+}
+
+func TestExporter_SamplesGenerations(t *testing.T) {
+	cfg := testConfig()
+	cfg.SampleRate = 0
+
+	sink := &sinkMock{}
+	exporter, err := newExporter(cfg, []Sink{sink}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	exporter.Start()
+
+	for i := 0; i < 10; i++ {
+		exporter.Add(&telemetry.Generation{ID: "1"})
+	}
+
+	exporter.Stop()
+
+	require.Empty(t, sink.Generations())
+}
+
+func TestExporter_RequiresServices(t *testing.T) {
+	_, err := NewExporter(DefaultConfig(), telemetry.NewTelemetryMock())
+
+	require.ErrorIs(t, err, ErrNoServices)
+}
@@ -0,0 +1,169 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/telemetry"
+)
+
+// LangfuseConfig defines the Langfuse project generations are ingested into (Langfuse Cloud or a self-hosted instance)
+type LangfuseConfig struct {
+	Host      string        `yaml:"host" validate:"required,url"` // e.g. https://cloud.langfuse.com
+	PublicKey string        `yaml:"public_key" validate:"required"`
+	SecretKey fields.Secret `yaml:"secret_key" validate:"required"`
+}
+
+// LangfuseSink sends generations via the Langfuse ingestion API, each as a trace with one generation observation
+type LangfuseSink struct {
+	cfg        *LangfuseConfig
+	url        string
+	httpClient *http.Client
+}
+
+func NewLangfuseSink(cfg *LangfuseConfig) *LangfuseSink {
+	return &LangfuseSink{
+		cfg:        cfg,
+		url:        strings.TrimRight(cfg.Host, "/") + "/api/public/ingestion",
+		httpClient: &http.Client{},
+	}
+}
+
+func (s *LangfuseSink) Name() string {
+	return "langfuse"
+}
+
+type langfuseEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Body      interface{} `json:"body"`
+}
+
+type langfuseTrace struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Timestamp time.Time              `json:"timestamp"`
+	UserID    string                 `json:"userId,omitempty"`
+	SessionID string                 `json:"sessionId,omitempty"`
+	Input     interface{}            `json:"input"`
+	Output    interface{}            `json:"output"`
+	Tags      []string               `json:"tags,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type langfuseGeneration struct {
+	ID           string                 `json:"id"`
+	TraceID      string                 `json:"traceId"`
+	Name         string                 `json:"name"`
+	StartTime    time.Time              `json:"startTime"`
+	EndTime      time.Time              `json:"endTime"`
+	Model        string                 `json:"model"`
+	Input        interface{}            `json:"input"`
+	Output       interface{}            `json:"output"`
+	UsageDetails map[string]int         `json:"usageDetails"`
+	CostDetails  map[string]float64     `json:"costDetails,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type langfuseResponse struct {
+	Errors []struct {
+		ID      string `json:"id"`
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (s *LangfuseSink) Export(ctx context.Context, generations []*telemetry.Generation) error {
+	batch := make([]langfuseEvent, 0, len(generations)*2)
+
+	for _, generation := range generations {
+		metadata := generationMetadata(generation)
+
+		batch = append(batch,
+			langfuseEvent{
+				ID:        generation.ID + "-trace",
+				Type:      "trace-create",
+				Timestamp: generation.StartTime,
+				Body: langfuseTrace{
+					ID:        generation.ID,
+					Name:      generation.RouterID,
+					Timestamp: generation.StartTime,
+					UserID:    generation.Caller,
+					SessionID: generation.SessionID,
+					Input:     generation.Input,
+					Output:    generation.Output,
+					Tags:      []string{"glide", generation.Kind},
+					Metadata:  metadata,
+				},
+			},
+			langfuseEvent{
+				ID:        generation.ID + "-generation",
+				Type:      "generation-create",
+				Timestamp: generation.StartTime,
+				Body: langfuseGeneration{
+					ID:        generation.ID + "-generation",
+					TraceID:   generation.ID,
+					Name:      generation.ModelID,
+					StartTime: generation.StartTime,
+					EndTime:   generation.EndTime,
+					Model:     generation.ModelName,
+					Input:     generation.Input,
+					Output:    generation.Output,
+					UsageDetails: map[string]int{
+						"input":  generation.PromptTokens,
+						"output": generation.ResponseTokens,
+					},
+					CostDetails: langfuseCost(generation),
+					Metadata:    metadata,
+				},
+			},
+		)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"batch": batch})
+	if err != nil {
+		return err
+	}
+
+	respBody, err := postJSON(ctx, s.httpClient, s.url, body, func(req *http.Request) {
+		req.SetBasicAuth(s.cfg.PublicKey, string(s.cfg.SecretKey))
+	})
+	if err != nil {
+		return err
+	}
+
+	// Langfuse accepts batches partially, rejected events are listed in the multi-status response
+	var resp langfuseResponse
+
+	if err := json.Unmarshal(respBody, &resp); err == nil && len(resp.Errors) > 0 {
+		return fmt.Errorf("%v of %v events were rejected: %v", len(resp.Errors), len(batch), resp.Errors[0].Message)
+	}
+
+	return nil
+}
+
+func langfuseCost(generation *telemetry.Generation) map[string]float64 {
+	if generation.Cost == nil {
+		return nil
+	}
+
+	return map[string]float64{"total": *generation.Cost}
+}
+
+// generationMetadata describes where the generation was served, it's attached to exported traces as is
+func generationMetadata(generation *telemetry.Generation) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":            generation.Kind,
+		"routerId":        generation.RouterID,
+		"modelId":         generation.ModelID,
+		"provider":        generation.Provider,
+		"latencyMs":       generation.EndTime.Sub(generation.StartTime).Milliseconds(),
+		"tokensEstimated": generation.TokensEstimated,
+	}
+}
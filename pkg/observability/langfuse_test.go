@@ -0,0 +1,90 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testGeneration() *telemetry.Generation {
+	cost := 0.002
+	startTime := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	return &telemetry.Generation{
+		ID:             "5c8e2f1a-3b1d-4c9e-9f1a-2b3c4d5e6f70",
+		Kind:           "chat",
+		RouterID:       "default",
+		ModelID:        "openai",
+		ModelName:      "gpt-3.5-turbo",
+		Provider:       "openai",
+		Caller:         "team-a",
+		Input:          []telemetry.GenerationMessage{{Role: "user", Content: "Hello"}},
+		Output:         "Hi there",
+		StartTime:      startTime,
+		EndTime:        startTime.Add(250 * time.Millisecond),
+		PromptTokens:   5,
+		ResponseTokens: 3,
+		Cost:           &cost,
+	}
+}
+
+func TestLangfuseSink_IngestsTraces(t *testing.T) {
+	var (
+		path    string
+		user    string
+		payload struct {
+			Batch []struct {
+				Type string                 `json:"type"`
+				Body map[string]interface{} `json:"body"`
+			} `json:"batch"`
+		}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		user, _, _ = r.BasicAuth()
+
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	sink := NewLangfuseSink(&LangfuseConfig{Host: server.URL, PublicKey: "pk-lf-1", SecretKey: "sk-lf-1"})
+
+	err := sink.Export(context.Background(), []*telemetry.Generation{testGeneration()})
+	require.NoError(t, err)
+
+	require.Equal(t, "/api/public/ingestion", path)
+	require.Equal(t, "pk-lf-1", user)
+	require.Len(t, payload.Batch, 2)
+	require.Equal(t, "trace-create", payload.Batch[0].Type)
+	require.Equal(t, "generation-create", payload.Batch[1].Type)
+
+	generation := payload.Batch[1].Body
+	require.Equal(t, "gpt-3.5-turbo", generation["model"])
+	require.Equal(t, "Hi there", generation["output"])
+	require.Equal(t, map[string]interface{}{"input": 5.0, "output": 3.0}, generation["usageDetails"])
+	require.Equal(t, map[string]interface{}{"total": 0.002}, generation["costDetails"])
+}
+
+func TestLangfuseSink_FailsOnRejectedEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(`{"successes":[],"errors":[{"id":"1","status":400,"message":"invalid event"}]}`))
+	}))
+	defer server.Close()
+
+	sink := NewLangfuseSink(&LangfuseConfig{Host: server.URL, PublicKey: "pk-lf-1", SecretKey: "sk-lf-1"})
+
+	err := sink.Export(context.Background(), []*telemetry.Generation{testGeneration()})
+	require.ErrorContains(t, err, "invalid event")
+}
@@ -0,0 +1,130 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/telemetry"
+)
+
+// LangSmithConfig defines the LangSmith project generations are logged to as LLM runs
+type LangSmithConfig struct {
+	Endpoint string        `yaml:"endpoint" validate:"required,url"`
+	APIKey   fields.Secret `yaml:"api_key" validate:"required"`
+	Project  string        `yaml:"project" validate:"required"`
+}
+
+func DefaultLangSmithConfig() *LangSmithConfig {
+	return &LangSmithConfig{
+		Endpoint: "https://api.smith.langchain.com",
+		Project:  "glide",
+	}
+}
+
+func (c *LangSmithConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultLangSmithConfig()
+
+	type plain LangSmithConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// LangSmithSink sends generations via the LangSmith batch run API, each as a root LLM run
+type LangSmithSink struct {
+	cfg        *LangSmithConfig
+	url        string
+	httpClient *http.Client
+}
+
+func NewLangSmithSink(cfg *LangSmithConfig) *LangSmithSink {
+	return &LangSmithSink{
+		cfg:        cfg,
+		url:        strings.TrimRight(cfg.Endpoint, "/") + "/runs/batch",
+		httpClient: &http.Client{},
+	}
+}
+
+func (s *LangSmithSink) Name() string {
+	return "langsmith"
+}
+
+type langSmithRun struct {
+	ID          string                 `json:"id"`
+	TraceID     string                 `json:"trace_id"`
+	DottedOrder string                 `json:"dotted_order"`
+	Name        string                 `json:"name"`
+	RunType     string                 `json:"run_type"`
+	StartTime   time.Time              `json:"start_time"`
+	EndTime     time.Time              `json:"end_time"`
+	Inputs      map[string]interface{} `json:"inputs"`
+	Outputs     map[string]interface{} `json:"outputs"`
+	Extra       map[string]interface{} `json:"extra"`
+	Tags        []string               `json:"tags,omitempty"`
+	SessionName string                 `json:"session_name"`
+}
+
+func (s *LangSmithSink) Export(ctx context.Context, generations []*telemetry.Generation) error {
+	runs := make([]langSmithRun, 0, len(generations))
+
+	for _, generation := range generations {
+		metadata := generationMetadata(generation)
+		metadata["ls_provider"] = generation.Provider
+		metadata["ls_model_name"] = generation.ModelName
+
+		if generation.Caller != "" {
+			metadata["caller"] = generation.Caller
+		}
+
+		if generation.SessionID != "" {
+			metadata["session_id"] = generation.SessionID
+		}
+
+		if generation.Cost != nil {
+			metadata["cost"] = *generation.Cost
+		}
+
+		runs = append(runs, langSmithRun{
+			ID:          generation.ID,
+			TraceID:     generation.ID,
+			DottedOrder: langSmithDottedOrder(generation),
+			Name:        generation.RouterID,
+			RunType:     "llm",
+			StartTime:   generation.StartTime,
+			EndTime:     generation.EndTime,
+			Inputs:      map[string]interface{}{"messages": generation.Input},
+			Outputs: map[string]interface{}{
+				"generations": []map[string]string{{"text": generation.Output}},
+				"llm_output": map[string]interface{}{
+					"token_usage": map[string]int{
+						"prompt_tokens":     generation.PromptTokens,
+						"completion_tokens": generation.ResponseTokens,
+						"total_tokens":      generation.PromptTokens + generation.ResponseTokens,
+					},
+				},
+			},
+			Extra:       map[string]interface{}{"metadata": metadata},
+			Tags:        []string{"glide", generation.Kind},
+			SessionName: s.cfg.Project,
+		})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"post": runs})
+	if err != nil {
+		return err
+	}
+
+	_, err = postJSON(ctx, s.httpClient, s.url, body, func(req *http.Request) {
+		req.Header.Set("x-api-key", string(s.cfg.APIKey))
+	})
+
+	return err
+}
+
+// langSmithDottedOrder orders runs within the trace. Generations are root runs, so it's the start time followed by the run ID
+func langSmithDottedOrder(generation *telemetry.Generation) string {
+	return generation.StartTime.UTC().Format("20060102T150405.000000Z") + strings.ReplaceAll(generation.ID, "-", "")
+}
@@ -0,0 +1,48 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLangSmithSink_PostsRuns(t *testing.T) {
+	var (
+		path    string
+		apiKey  string
+		payload struct {
+			Post []map[string]interface{} `json:"post"`
+		}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		apiKey = r.Header.Get("x-api-key")
+
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	cfg := DefaultLangSmithConfig()
+	cfg.Endpoint = server.URL
+	cfg.APIKey = "ls-key"
+
+	err := NewLangSmithSink(cfg).Export(context.Background(), []*telemetry.Generation{testGeneration()})
+	require.NoError(t, err)
+
+	require.Equal(t, "/runs/batch", path)
+	require.Equal(t, "ls-key", apiKey)
+	require.Len(t, payload.Post, 1)
+
+	run := payload.Post[0]
+	require.Equal(t, "llm", run["run_type"])
+	require.Equal(t, "glide", run["session_name"])
+	require.Equal(t, "20240301T100000.000000Z5c8e2f1a3b1d4c9e9f1a2b3c4d5e6f70", run["dotted_order"])
+	require.Equal(t, "gpt-3.5-turbo", run["extra"].(map[string]interface{})["metadata"].(map[string]interface{})["ls_model_name"])
+}
@@ -0,0 +1,31 @@
+package providers
+
+import "glide/pkg/routers/latency"
+
+// Action is the kind of requests models serve.
+// Latencies are tracked per action as they have very different distributions
+// (e.g. streaming chat latency is measured per chunk, while chat latency is normalized per token)
+type Action string
+
+const (
+	ChatAction          Action = "chat"
+	ChatStreamAction    Action = "chat_stream"
+	EmbedAction         Action = "embed"
+	ImageGenerateAction Action = "image_generate"
+	TranscribeAction    Action = "transcribe"
+	ModerateAction      Action = "moderate"
+)
+
+// singleActionModel is a model serving one action, so it tracks one latency
+type singleActionModel interface {
+	Latency() *latency.MovingAverage
+}
+
+// Latency returns the moving average latency of the model action
+func Latency(model Model, action Action) *latency.MovingAverage {
+	if m, ok := model.(singleActionModel); ok {
+		return m.Latency()
+	}
+
+	return model.(*LanguageModel).Latency(action)
+}
@@ -0,0 +1,29 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/routers/latency"
+)
+
+func TestLatency_PerAction(t *testing.T) {
+	model := &LanguageModel{
+		chatLatency:       latency.NewMovingAverage(0.06, 1),
+		chatStreamLatency: latency.NewMovingAverage(0.06, 1),
+		embedLatency:      latency.NewMovingAverage(0.06, 1),
+	}
+
+	for range 2 {
+		Latency(model, ChatAction).Add(100)
+		Latency(model, ChatStreamAction).Add(10)
+	}
+
+	require.InDelta(t, 100, model.ChatLatency().Value(), 0.0001)
+	require.InDelta(t, 10, model.ChatStreamLatency().Value(), 0.0001)
+	require.False(t, Latency(model, EmbedAction).WarmedUp())
+
+	imageModel := &ImageModel{latency: latency.NewMovingAverage(0.06, 1)}
+
+	require.Same(t, imageModel.Latency(), Latency(imageModel, ImageGenerateAction))
+}
@@ -14,8 +14,54 @@ import (
 )
 
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role         string        `json:"role"`
+	Content      string        `json:"content"`
+	CacheControl *CacheControl `json:"-"` // marks the end of the cached prompt prefix
+}
+
+// CacheControl marks the content block the prompt is cached up to
+//
+//	Ref: https://docs.anthropic.com/en/docs/build-with-claude/prompt-caching
+type CacheControl struct {
+	Type string `json:"type"`
+}
+
+var ephemeralCache = &CacheControl{Type: "ephemeral"}
+
+// TextBlock is a text content block of the message
+type TextBlock struct {
+	Type         string        `json:"type"`
+	Text         string        `json:"text"`
+	CacheControl *CacheControl `json:"cache_control,omitempty"`
+}
+
+// MarshalJSON sends the content as a plain string unless the message is a cache breakpoint,
+// as cache control can be set on content blocks only
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	if m.CacheControl == nil {
+		return json.Marshal(struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		}{Role: m.Role, Content: m.Content})
+	}
+
+	return json.Marshal(struct {
+		Role    string      `json:"role"`
+		Content []TextBlock `json:"content"`
+	}{
+		Role:    m.Role,
+		Content: []TextBlock{{Type: "text", Text: m.Content, CacheControl: m.CacheControl}},
+	})
+}
+
+func newChatMessage(message schemas.ChatMessage) ChatMessage {
+	chatMessage := ChatMessage{Role: message.Role, Content: message.Content}
+
+	if message.CacheBreakpoint {
+		chatMessage.CacheControl = ephemeralCache
+	}
+
+	return chatMessage
 }
 
 // ChatRequest is an Anthropic-specific request schema
@@ -52,10 +98,10 @@ func NewChatMessagesFromUnifiedRequest(request *schemas.ChatRequest) []ChatMessa
 
 	// Add items from messageHistory first and the new chat message last
 	for _, message := range request.MessageHistory {
-		messages = append(messages, ChatMessage{Role: message.Role, Content: message.Content})
+		messages = append(messages, newChatMessage(message))
 	}
 
-	messages = append(messages, ChatMessage{Role: request.Message.Role, Content: request.Message.Content})
+	messages = append(messages, newChatMessage(request.Message))
 
 	return messages
 }
@@ -99,7 +145,6 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 	req.Header.Set("anthropic-version", c.apiVersion)
 	req.Header.Set("Content-Type", "application/json")
 
-	// TODO: this could leak information from messages which may not be a desired thing to have
 	c.tel.L().Debug(
 		"Anthropic chat request",
 		zap.String("chat_url", c.chatURL),
@@ -124,6 +169,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	c.driftDetector.Check("chat", bodyBytes, ChatCompletion{}, "content", "stop_reason", "usage")
+
 	// Parse the response JSON
 	var anthropicResponse ChatCompletion
 
@@ -139,6 +186,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 
 	completion := anthropicResponse.Content[0]
 	usage := anthropicResponse.Usage
+	// input tokens don't include the ones read from or written to the prompt cache
+	promptTokens := usage.InputTokens + usage.CacheReadInputTokens + usage.CacheCreationInputTokens
 
 	// Map response to ChatResponse schema
 	response := schemas.ChatResponse{
@@ -154,9 +203,11 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				Content: completion.Text,
 			},
 			TokenUsage: schemas.TokenUsage{
-				PromptTokens:   usage.InputTokens,
-				ResponseTokens: usage.OutputTokens,
-				TotalTokens:    usage.InputTokens + usage.OutputTokens,
+				PromptTokens:       promptTokens,
+				ResponseTokens:     usage.OutputTokens,
+				TotalTokens:        promptTokens + usage.OutputTokens,
+				CachedPromptTokens: usage.CacheReadInputTokens,
+				CacheWriteTokens:   usage.CacheCreationInputTokens,
 			},
 		},
 	}
@@ -1,7 +1,7 @@
 package anthropic
 
 import (
-	"errors"
+	"context"
 	"net/http"
 	"net/url"
 
@@ -15,15 +15,17 @@ const (
 
 // ErrEmptyResponse is returned when the OpenAI API returns an empty response.
 var (
-	ErrEmptyResponse = errors.New("empty response")
+	ErrEmptyResponse = clients.ErrEmptyResponse
 )
 
 // Client is a client for accessing OpenAI API
 type Client struct {
 	baseURL             string
 	chatURL             string
+	tokenURL            string
 	apiVersion          string
 	chatRequestTemplate *ChatRequest
+	driftDetector       *clients.SchemaDriftDetector
 	errMapper           *ErrorMapper
 	config              *Config
 	httpClient          *http.Client
@@ -37,22 +39,22 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		return nil, err
 	}
 
+	tokenURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.TokenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Client{
 		baseURL:             providerConfig.BaseURL,
 		chatURL:             chatURL,
+		tokenURL:            tokenURL,
 		apiVersion:          providerConfig.APIVersion,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		driftDetector:       clients.NewSchemaDriftDetector(providerName, tel),
 		errMapper:           NewErrorMapper(tel),
-		httpClient: &http.Client{
-			Timeout: *clientConfig.Timeout,
-			// TODO: use values from the config
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 2,
-			},
-		},
-		tel: tel,
+		httpClient:          clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		tel:                 tel,
 	}
 
 	return c, nil
@@ -61,3 +63,8 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 func (c *Client) Provider() string {
 	return providerName
 }
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
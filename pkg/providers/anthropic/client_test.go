@@ -102,3 +102,107 @@ func TestAnthropicClient_BadChatRequest(t *testing.T) {
 	// Assert that the response is nil
 	require.Nil(t, response)
 }
+
+func TestAnthropicClient_ContextTooLong(t *testing.T) {
+	AnthropicServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type": "error", "error": {"type": "invalid_request_error", "message": "prompt is too long: 201234 tokens > 200000 maximum"}}`))
+	}))
+	defer AnthropicServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = AnthropicServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	_, err = client.Chat(context.Background(), schemas.NewChatFromStr("What's the biggest animal?"))
+	require.ErrorIs(t, err, clients.ErrContextTooLong)
+}
+
+func TestAnthropicClient_CountTokens(t *testing.T) {
+	// Anthropic Token Counting API: https://docs.anthropic.com/en/api/messages-count-tokens
+	AnthropicMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tokenCountRequest TokenCountRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&tokenCountRequest); err != nil {
+			t.Errorf("error decoding payload: %v", err)
+		}
+
+		require.Equal(t, "/messages/count_tokens", r.URL.Path)
+		require.Equal(t, "claude-instant-1.2", tokenCountRequest.Model)
+		require.Equal(t, "You are a helpful assistant.", tokenCountRequest.System)
+		require.Len(t, tokenCountRequest.Messages, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err := w.Write([]byte(`{"input_tokens": 21}`))
+		if err != nil {
+			t.Errorf("error on sending token count response: %v", err)
+		}
+	})
+
+	AnthropicServer := httptest.NewServer(AnthropicMock)
+	defer AnthropicServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = AnthropicServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.CountTokens(context.Background(), schemas.NewTokenizeFromStr("What's the biggest animal?"))
+	require.NoError(t, err)
+
+	require.Equal(t, 21, response.Tokens)
+	require.False(t, response.Estimated)
+	require.Equal(t, "claude-instant-1.2", response.ModelName)
+}
+
+func TestAnthropicClient_PromptCaching(t *testing.T) {
+	var payload map[string]any
+
+	AnthropicMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(rawPayload, &payload))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err := w.Write([]byte(`{
+			"id": "msg_01", "type": "message", "model": "claude-3-5-sonnet", "role": "assistant",
+			"content": [{"type": "text", "text": "Sure"}],
+			"usage": {"input_tokens": 10, "output_tokens": 5, "cache_creation_input_tokens": 0, "cache_read_input_tokens": 2000}
+		}`))
+		require.NoError(t, err)
+	})
+
+	AnthropicServer := httptest.NewServer(AnthropicMock)
+	defer AnthropicServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = AnthropicServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	request := schemas.NewChatFromStr("Summarize the document")
+	request.MessageHistory = []schemas.ChatMessage{{Role: "user", Content: "<a long document>", CacheBreakpoint: true}}
+
+	response, err := client.Chat(context.Background(), request)
+	require.NoError(t, err)
+
+	messages := payload["messages"].([]any)
+	require.Equal(t, []any{map[string]any{
+		"type":          "text",
+		"text":          "<a long document>",
+		"cache_control": map[string]any{"type": "ephemeral"},
+	}}, messages[0].(map[string]any)["content"])
+	require.Equal(t, "Summarize the document", messages[1].(map[string]any)["content"])
+
+	require.Equal(t, schemas.TokenUsage{
+		PromptTokens:       2010,
+		ResponseTokens:     5,
+		TotalTokens:        2015,
+		CachedPromptTokens: 2000,
+	}, response.ModelResponse.TokenUsage)
+}
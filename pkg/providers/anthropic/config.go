@@ -39,6 +39,7 @@ type Config struct {
 	BaseURL       string        `yaml:"baseUrl" json:"baseUrl" validate:"required"`
 	APIVersion    string        `yaml:"apiVersion" json:"apiVersion" validate:"required"`
 	ChatEndpoint  string        `yaml:"chatEndpoint" json:"chatEndpoint" validate:"required"`
+	TokenEndpoint string        `yaml:"tokenEndpoint" json:"tokenEndpoint"`
 	Model         string        `yaml:"model" json:"model" validate:"required"`
 	APIKey        fields.Secret `yaml:"api_key" json:"-" validate:"required"`
 	DefaultParams *Params       `yaml:"defaultParams,omitempty" json:"defaultParams"`
@@ -52,6 +53,7 @@ func DefaultConfig() *Config {
 		BaseURL:       "https://api.anthropic.com/v1",
 		APIVersion:    "2023-06-01",
 		ChatEndpoint:  "/messages",
+		TokenEndpoint: "/messages/count_tokens",
 		Model:         "claude-instant-1.2",
 		DefaultParams: &defaultParams,
 	}
@@ -6,8 +6,10 @@ type Content struct {
 }
 
 type Usage struct {
-	InputTokens  int `json:"input_tokens"`
-	OutputTokens int `json:"output_tokens"`
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
 }
 
 // ChatCompletion is an Anthropic Chat Response
@@ -21,3 +23,15 @@ type ChatCompletion struct {
 	StopSequence string    `json:"stop_sequence"`
 	Usage        Usage     `json:"usage"`
 }
+
+// TokenCountRequest is an Anthropic token counting request schema
+type TokenCountRequest struct {
+	Model    string        `json:"model"`
+	Messages []ChatMessage `json:"messages"`
+	System   string        `json:"system,omitempty"`
+}
+
+// TokenCount is an Anthropic token counting response
+type TokenCount struct {
+	InputTokens int `json:"input_tokens"`
+}
@@ -0,0 +1,59 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"glide/pkg/api/schemas"
+)
+
+// CountTokens counts prompt tokens with the Anthropic token counting API.
+//
+//	Ref: https://docs.anthropic.com/en/api/messages-count-tokens
+func (c *Client) CountTokens(ctx context.Context, request *schemas.TokenizeRequest) (*schemas.TokenizeResponse, error) {
+	tokenCountRequest := &TokenCountRequest{
+		Model:    c.config.Model,
+		Messages: NewChatMessagesFromUnifiedRequest(request.ChatRequest()),
+		System:   c.chatRequestTemplate.System,
+	}
+
+	rawPayload, err := json.Marshal(tokenCountRequest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal anthropic token count request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create anthropic token count request: %w", err)
+	}
+
+	req.Header.Set("x-api-key", string(c.config.APIKey)) // must be in lower case
+	req.Header.Set("anthropic-version", c.apiVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send anthropic token count request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	var tokenCount TokenCount
+
+	if err := json.NewDecoder(resp.Body).Decode(&tokenCount); err != nil {
+		return nil, fmt.Errorf("unable to parse anthropic token count response: %w", err)
+	}
+
+	return &schemas.TokenizeResponse{
+		Provider:  providerName,
+		ModelName: c.config.Model,
+		Tokens:    tokenCount.InputTokens,
+	}, nil
+}
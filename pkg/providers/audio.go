@@ -0,0 +1,28 @@
+package providers
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+)
+
+// TranscriptionProvider defines an interface a provider should fulfill to be able to serve speech-to-text requests
+type TranscriptionProvider interface {
+	ModelProvider
+
+	Transcribe(ctx context.Context, req *schemas.TranscriptionRequest) (*schemas.TranscriptionResponse, error)
+}
+
+// AudioModel serves speech-to-text requests
+type AudioModel = SingleActionModel[*schemas.TranscriptionRequest, *schemas.TranscriptionResponse]
+
+func NewAudioModel(modelID string, client TranscriptionProvider, budget *health.ErrorBudget, latencyConfig latency.Config, weight int) *AudioModel {
+	return newSingleActionModel(modelID, client, client.Transcribe, audioUnits, budget, latencyConfig, weight)
+}
+
+// audioUnits records latency per second of audio to normalize measurements
+func audioUnits(_ *schemas.TranscriptionRequest, resp *schemas.TranscriptionResponse) float64 {
+	return resp.ModelResponse.Duration
+}
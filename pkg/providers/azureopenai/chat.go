@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
 	"glide/pkg/providers/openai"
 
 	"go.uber.org/zap"
@@ -83,7 +84,6 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 	req.Header.Set("api-key", string(c.config.APIKey))
 	req.Header.Set("Content-Type", "application/json")
 
-	// TODO: this could leak information from messages which may not be a desired thing to have
 	c.tel.Logger.Debug(
 		"azure openai chat request",
 		zap.String("chat_url", c.chatURL),
@@ -108,6 +108,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	c.driftDetector.Check("chat", bodyBytes, openai.ChatCompletion{}, "choices", "usage")
+
 	// Parse the response JSON
 	var openAICompletion openai.ChatCompletion
 
@@ -119,6 +121,10 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 
 	openAICompletion.SystemFingerprint = "" // Azure OpenAI doesn't return this
 
+	if len(openAICompletion.Choices) > 0 && openAICompletion.Choices[0].FinishReason == openai.FilteredReason {
+		return nil, clients.ErrContentFiltered
+	}
+
 	// Map response to UnifiedChatResponse schema
 	response := schemas.ChatResponse{
 		ID:        openAICompletion.ID,
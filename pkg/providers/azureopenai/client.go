@@ -1,7 +1,7 @@
 package azureopenai
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"net/http"
 
@@ -17,7 +17,7 @@ const (
 
 // ErrEmptyResponse is returned when the OpenAI API returns an empty response.
 var (
-	ErrEmptyResponse = errors.New("empty response")
+	ErrEmptyResponse = clients.ErrEmptyResponse
 )
 
 // Client is a client for accessing Azure OpenAI API
@@ -25,6 +25,7 @@ type Client struct {
 	baseURL             string // The name of your Azure OpenAI Resource (e.g https://glide-test.openai.azure.com/)
 	chatURL             string
 	chatRequestTemplate *ChatRequest
+	driftDetector       *clients.SchemaDriftDetector
 	finishReasonMapper  *openai.FinishReasonMapper
 	errMapper           *ErrorMapper
 	config              *Config
@@ -46,17 +47,11 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		chatURL:             chatURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		driftDetector:       clients.NewSchemaDriftDetector(providerName, tel),
 		finishReasonMapper:  openai.NewFinishReasonMapper(tel),
 		errMapper:           NewErrorMapper(tel),
-		httpClient: &http.Client{
-			// TODO: use values from the config
-			Timeout: *clientConfig.Timeout,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 2,
-			},
-		},
-		tel: tel,
+		httpClient:          clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		tel:                 tel,
 	}
 
 	return c, nil
@@ -65,3 +60,8 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 func (c *Client) Provider() string {
 	return providerName
 }
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
@@ -1,12 +1,11 @@
 package azureopenai
 
 import (
-	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"glide/pkg/providers/clients"
+	"glide/pkg/providers/openai"
 	"glide/pkg/telemetry"
 	"go.uber.org/zap"
 )
@@ -35,22 +34,21 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 	)
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		// Read the value of the "Retry-After" header to get the cooldown delay
-		retryAfter := resp.Header.Get("Retry-After")
-
-		// Parse the value to get the duration
-		cooldownDelay, err := time.ParseDuration(retryAfter)
-		if err != nil {
-			return fmt.Errorf("failed to parse cooldown delay from headers: %w", err)
-		}
-
-		return clients.NewRateLimitError(&cooldownDelay)
+		return clients.NewRateLimitErrorFromHeaders(resp.Header)
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
 		return clients.ErrUnauthorized
 	}
 
+	if resp.StatusCode == http.StatusBadRequest && openai.ContentFiltered(bodyBytes) {
+		return clients.ErrContentFiltered
+	}
+
+	if resp.StatusCode == http.StatusBadRequest && openai.ContextTooLong(bodyBytes) {
+		return clients.ErrContextTooLong
+	}
+
 	// Server & client errors result in the same error to keep gateway resilient
 	return clients.ErrProviderUnavailable
 }
@@ -94,6 +94,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	c.driftDetector.Check("chat", result.Body, ChatCompletion{}, "results", "inputTextTokenCount")
+
 	var bedrockCompletion ChatCompletion
 
 	err = json.Unmarshal(result.Body, &bedrockCompletion)
@@ -2,7 +2,6 @@ package bedrock
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"net/url"
 
@@ -20,7 +19,7 @@ const (
 
 // ErrEmptyResponse is returned when the OpenAI API returns an empty response.
 var (
-	ErrEmptyResponse = errors.New("empty response")
+	ErrEmptyResponse = clients.ErrEmptyResponse
 )
 
 // Client is a client for accessing OpenAI API
@@ -29,6 +28,7 @@ type Client struct {
 	bedrockClient       *bedrockruntime.Client
 	chatURL             string
 	chatRequestTemplate *ChatRequest
+	driftDetector       *clients.SchemaDriftDetector
 	config              *Config
 	httpClient          *http.Client
 	telemetry           *telemetry.Telemetry
@@ -54,15 +54,9 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		chatURL:             chatURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
-		httpClient: &http.Client{
-			Timeout: *clientConfig.Timeout,
-			// TODO: use values from the config
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 2,
-			},
-		},
-		telemetry: tel,
+		driftDetector:       clients.NewSchemaDriftDetector(providerName, tel),
+		httpClient:          clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		telemetry:           tel,
 	}
 
 	return c, nil
@@ -0,0 +1,16 @@
+package bedrock
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+)
+
+func (c *Client) SupportEmbed() bool {
+	return false
+}
+
+func (c *Client) Embed(_ context.Context, _ *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	return nil, clients.ErrEmbedNotImplemented
+}
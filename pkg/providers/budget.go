@@ -0,0 +1,98 @@
+package providers
+
+import (
+	"sync"
+	"time"
+)
+
+// SpendBudgetConfig limits how much may be spent on requests (in USD) per UTC calendar day & month
+type SpendBudgetConfig struct {
+	Daily   float64 `yaml:"daily,omitempty" json:"daily,omitempty" validate:"gte=0"`     // zero means unlimited
+	Monthly float64 `yaml:"monthly,omitempty" json:"monthly,omitempty" validate:"gte=0"` // zero means unlimited
+}
+
+// SpendBudget tracks spending against daily & monthly budgets. Spending is reset when the day or the month is over
+type SpendBudget struct {
+	mu           sync.Mutex
+	config       *SpendBudgetConfig
+	day          time.Time
+	month        time.Time
+	dailySpent   float64
+	monthlySpent float64
+	now          func() time.Time
+}
+
+func NewSpendBudget(config *SpendBudgetConfig) *SpendBudget {
+	return &SpendBudget{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Track records the spent amount. It returns true when the spending has just exhausted the budget
+func (b *SpendBudget) Track(cost float64) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollover()
+
+	wasExhausted := b.exhausted()
+
+	b.dailySpent += cost
+	b.monthlySpent += cost
+
+	return !wasExhausted && b.exhausted()
+}
+
+// Exhausted checks if the daily or the monthly budget is spent
+func (b *SpendBudget) Exhausted() bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollover()
+
+	return b.exhausted()
+}
+
+// Spent returns how much has been spent over the current day & month
+func (b *SpendBudget) Spent() (float64, float64) {
+	if b == nil {
+		return 0, 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollover()
+
+	return b.dailySpent, b.monthlySpent
+}
+
+func (b *SpendBudget) exhausted() bool {
+	return (b.config.Daily > 0 && b.dailySpent >= b.config.Daily) ||
+		(b.config.Monthly > 0 && b.monthlySpent >= b.config.Monthly)
+}
+
+// rollover resets spending when the day or the month is over
+func (b *SpendBudget) rollover() {
+	now := b.now().UTC()
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if !day.Equal(b.day) {
+		b.day, b.dailySpent = day, 0
+	}
+
+	if !month.Equal(b.month) {
+		b.month, b.monthlySpent = month, 0
+	}
+}
@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendBudget_Track(t *testing.T) {
+	now := time.Date(2024, time.May, 31, 12, 0, 0, 0, time.UTC)
+
+	budget := NewSpendBudget(&SpendBudgetConfig{Daily: 10, Monthly: 15})
+	budget.now = func() time.Time { return now }
+
+	require.False(t, budget.Track(6))
+	require.False(t, budget.Exhausted())
+
+	// only the spending that exhausts the budget is reported
+	require.True(t, budget.Track(6))
+	require.True(t, budget.Exhausted())
+	require.False(t, budget.Track(1))
+
+	// the daily budget is reset on the next day, which is in the next month as well
+	now = now.Add(24 * time.Hour)
+
+	require.False(t, budget.Exhausted())
+
+	dailySpent, monthlySpent := budget.Spent()
+	require.Zero(t, dailySpent)
+	require.Zero(t, monthlySpent)
+
+	require.False(t, budget.Track(8))
+
+	// the monthly budget is exhausted by spending over several days
+	now = now.Add(24 * time.Hour)
+
+	require.True(t, budget.Track(8))
+
+	var unlimited *SpendBudget
+
+	require.False(t, unlimited.Track(100))
+	require.False(t, unlimited.Exhausted())
+}
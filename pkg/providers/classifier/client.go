@@ -0,0 +1,57 @@
+package classifier
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+const (
+	providerName = "classifier"
+)
+
+// ErrEmptyResponse is returned when the classifier returns an empty response.
+var (
+	ErrEmptyResponse = clients.ErrEmptyResponse
+)
+
+// Client is a client for accessing HTTP text classifiers
+type Client struct {
+	baseURL     string
+	classifyURL string
+	errMapper   *ErrorMapper
+	config      *Config
+	httpClient  *http.Client
+	tel         *telemetry.Telemetry
+}
+
+// NewClient creates a new classifier client.
+func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *telemetry.Telemetry) (*Client, error) {
+	classifyURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ClassifyEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:     providerConfig.BaseURL,
+		classifyURL: classifyURL,
+		config:      providerConfig,
+		errMapper:   NewErrorMapper(tel),
+		httpClient:  clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		tel:         tel,
+	}
+
+	return c, nil
+}
+
+func (c *Client) Provider() string {
+	return providerName
+}
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
@@ -0,0 +1,64 @@
+package classifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestClassifierClient_ModerationRequest(t *testing.T) {
+	classifierMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var classifyRequest ClassifyRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&classifyRequest); err != nil {
+			t.Errorf("error decoding payload: %v", err)
+		}
+
+		require.Equal(t, "/classify", r.URL.Path)
+		require.Len(t, classifyRequest.Input, 2)
+
+		classifyResponse, err := os.ReadFile(filepath.Clean("./testdata/classify.success.json"))
+		if err != nil {
+			t.Errorf("error reading classifier mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(classifyResponse)
+		if err != nil {
+			t.Errorf("error on sending classifier response: %v", err)
+		}
+	})
+
+	classifierServer := httptest.NewServer(classifierMock)
+	defer classifierServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = classifierServer.URL
+	providerCfg.Thresholds = map[string]float64{"insult": 0.4}
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Moderate(context.Background(), schemas.NewModerationFromStr("you idiot", "hello"))
+	require.NoError(t, err)
+
+	results := response.ModelResponse.Results
+	require.Len(t, results, 2)
+
+	require.True(t, results[0].Flagged)
+	require.Equal(t, "insult", results[0].Categories[0].Name)
+	require.True(t, results[0].Categories[0].Flagged)
+	require.True(t, results[0].Categories[1].Flagged)
+
+	require.False(t, results[1].Flagged)
+}
@@ -0,0 +1,43 @@
+package classifier
+
+import (
+	"glide/pkg/config/fields"
+)
+
+// Config defines a self-hosted (or any other) text classifier exposed over HTTP
+//
+//	The classifier is expected to accept {"model": "...", "input": ["..."]} and
+//	respond with {"results": [{"scores": {"<category>": <score>}}]} with one result per input
+type Config struct {
+	BaseURL          string             `yaml:"base_url" json:"baseUrl" validate:"required,http_url"`
+	ClassifyEndpoint string             `yaml:"classify_endpoint" json:"classifyEndpoint" validate:"required"`
+	Model            string             `yaml:"model" json:"model"`
+	APIKey           fields.Secret      `yaml:"api_key,omitempty" json:"-"`
+	Threshold        float64            `yaml:"threshold" json:"threshold" validate:"gt=0,lte=1"` // score from which a category is flagged
+	Thresholds       map[string]float64 `yaml:"thresholds,omitempty" json:"thresholds"`           // per-category threshold overrides
+}
+
+// DefaultConfig for classifier models
+func DefaultConfig() *Config {
+	return &Config{
+		ClassifyEndpoint: "/classify",
+		Threshold:        0.5,
+	}
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// threshold returns the score threshold for the given category
+func (c *Config) threshold(category string) float64 {
+	if threshold, found := c.Thresholds[category]; found {
+		return threshold
+	}
+
+	return c.Threshold
+}
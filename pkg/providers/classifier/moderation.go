@@ -0,0 +1,103 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// Moderate classifies the inputs and flags categories which scores reach the configured thresholds
+func (c *Client) Moderate(ctx context.Context, request *schemas.ModerationRequest) (*schemas.ModerationResponse, error) {
+	rawPayload, err := json.Marshal(&ClassifyRequest{
+		Model: c.config.Model,
+		Input: request.Input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal classifier request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.classifyURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create classifier request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.config.APIKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send classifier request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tel.Logger.Error("failed to read classifier response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var classifyResult ClassifyResult
+
+	err = json.Unmarshal(bodyBytes, &classifyResult)
+	if err != nil {
+		c.tel.Logger.Error("failed to parse classifier response", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(classifyResult.Results) != len(request.Input) {
+		return nil, ErrEmptyResponse
+	}
+
+	results := make([]schemas.ModerationResult, 0, len(classifyResult.Results))
+
+	for _, value := range classifyResult.Results {
+		result := schemas.ModerationResult{
+			Categories: make([]schemas.ModerationCategory, 0, len(value.Scores)),
+		}
+
+		for name, score := range value.Scores {
+			flagged := score >= c.config.threshold(name)
+			result.Flagged = result.Flagged || flagged
+
+			result.Categories = append(result.Categories, schemas.ModerationCategory{
+				Name:    name,
+				Flagged: flagged,
+				Score:   score,
+			})
+		}
+
+		sort.Slice(result.Categories, func(i, j int) bool {
+			return result.Categories[i].Name < result.Categories[j].Name
+		})
+
+		results = append(results, result)
+	}
+
+	return &schemas.ModerationResponse{
+		ID:        classifyResult.ID,
+		Created:   int(time.Now().UTC().Unix()),
+		Provider:  providerName,
+		ModelName: c.config.Model,
+		ModelResponse: schemas.ModerationModelResponse{
+			Results: results,
+		},
+	}, nil
+}
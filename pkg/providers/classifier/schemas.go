@@ -0,0 +1,17 @@
+package classifier
+
+// ClassifyRequest is the classifier request schema
+type ClassifyRequest struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+// ClassifyResult is the classifier response schema
+type ClassifyResult struct {
+	ID      string        `json:"id,omitempty"`
+	Results []ClassScores `json:"results"`
+}
+
+type ClassScores struct {
+	Scores map[string]float64 `json:"scores"`
+}
@@ -0,0 +1,170 @@
+package clients
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"glide/pkg/telemetry"
+)
+
+// CaptureTraffic makes the HTTP client record raw requests & responses of the calls selected by the capture log.
+// Responses are recorded once their bodies are closed, so streamed responses are captured as a whole
+func CaptureTraffic(httpClient *http.Client, provider string, captureLog *telemetry.CaptureLog) *http.Client {
+	if captureLog == nil {
+		return httpClient
+	}
+
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	capturingClient := *httpClient
+	capturingClient.Transport = &captureTransport{
+		provider:   provider,
+		captureLog: captureLog,
+		next:       next,
+	}
+
+	return &capturingClient
+}
+
+type captureTransport struct {
+	provider   string
+	captureLog *telemetry.CaptureLog
+	next       http.RoundTripper
+}
+
+func (t *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.captureLog.Wanted(req.Context()) {
+		return t.next.RoundTrip(req)
+	}
+
+	maxBodySize := t.captureLog.MaxBodySize()
+
+	capture := &telemetry.Capture{
+		Time:      time.Now().UTC(),
+		RequestID: telemetry.RequestID(req.Context()),
+		Provider:  t.provider,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+	}
+
+	requestBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	capture.RequestBody, capture.RequestTruncated = truncateBody(requestBody, maxBodySize)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		capture.DurationMs = time.Since(capture.Time).Milliseconds()
+		capture.Error = err.Error()
+		t.captureLog.Record(capture, req.Header, nil)
+
+		return nil, err
+	}
+
+	capture.StatusCode = resp.StatusCode
+
+	resp.Body = &capturedBody{
+		ReadCloser: resp.Body,
+		onClose: func(body []byte, truncated bool, readErr error) {
+			capture.DurationMs = time.Since(capture.Time).Milliseconds()
+			capture.ResponseBody = string(body)
+			capture.ResponseTruncated = truncated
+
+			if readErr != nil && !errors.Is(readErr, io.EOF) {
+				capture.Error = readErr.Error()
+			}
+
+			t.captureLog.Record(capture, req.Header, resp.Header)
+		},
+		maxSize: maxBodySize,
+	}
+
+	return resp, nil
+}
+
+// peekRequestBody reads the request body, leaving it readable for the transport
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		defer body.Close()
+
+		return io.ReadAll(body)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func truncateBody(body []byte, maxSize int) (string, bool) {
+	if len(body) > maxSize {
+		return string(body[:maxSize]), true
+	}
+
+	return string(body), false
+}
+
+// capturedBody copies what's read from the response body up to the max size
+type capturedBody struct {
+	io.ReadCloser
+	onClose   func(body []byte, truncated bool, readErr error)
+	maxSize   int
+	buf       bytes.Buffer
+	truncated bool
+	readErr   error
+	closeOnce sync.Once
+}
+
+func (b *capturedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	if n > 0 {
+		remaining := b.maxSize - b.buf.Len()
+
+		if n > remaining {
+			b.truncated = true
+		}
+
+		b.buf.Write(p[:min(n, max(remaining, 0))])
+	}
+
+	if err != nil {
+		b.readErr = err
+	}
+
+	return n, err
+}
+
+func (b *capturedBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	b.closeOnce.Do(func() {
+		b.onClose(b.buf.Bytes(), b.truncated, b.readErr)
+	})
+
+	return err
+}
@@ -3,7 +3,10 @@ package clients
 import "time"
 
 type ClientConfig struct {
-	Timeout *time.Duration `yaml:"timeout,omitempty" json:"timeout" swaggertype:"primitive,string"`
+	Timeout *time.Duration `yaml:"timeout,omitempty" json:"timeout" swaggertype:"primitive,string"` // how long a request to the model may take, including reading the response
+	Warmup  *WarmupConfig  `yaml:"warmup,omitempty" json:"warmup"`
+	DNS     *DNSConfig     `yaml:"dns,omitempty" json:"dns"`
+	Dialer  *DialerConfig  `yaml:"dialer,omitempty" json:"dialer"`
 }
 
 func DefaultClientConfig() *ClientConfig {
@@ -11,5 +14,8 @@ func DefaultClientConfig() *ClientConfig {
 
 	return &ClientConfig{
 		Timeout: &defaultTimeout,
+		Warmup:  DefaultWarmupConfig(),
+		DNS:     DefaultDNSConfig(),
+		Dialer:  DefaultDialerConfig(),
 	}
 }
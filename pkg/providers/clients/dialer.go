@@ -0,0 +1,257 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// IPPreference defines what IP family should be used to connect to provider hosts
+type IPPreference = string
+
+const (
+	IPAuto     IPPreference = "auto" // the family of the first resolved address is tried first (the standard behaviour)
+	IPPreferV4 IPPreference = "prefer_ipv4"
+	IPPreferV6 IPPreference = "prefer_ipv6"
+	IPv4Only   IPPreference = "ipv4_only"
+	IPv6Only   IPPreference = "ipv6_only"
+)
+
+const noIPVersion = 0
+
+// DialerConfig controls how connections to provider hosts are established
+type DialerConfig struct {
+	IPPreference IPPreference `yaml:"ip_preference" json:"ip_preference" validate:"oneof=auto prefer_ipv4 prefer_ipv6 ipv4_only ipv6_only"`
+	// FallbackDelay is how long to wait for the preferred IP family before trying the other one in parallel (aka Happy Eyeballs).
+	//  A negative value disables the parallel attempts, so the other family is tried only after the preferred one has failed
+	FallbackDelay  *time.Duration `yaml:"fallback_delay,omitempty" json:"fallback_delay" swaggertype:"primitive,string"`
+	ConnectTimeout *time.Duration `yaml:"connect_timeout,omitempty" json:"connect_timeout" swaggertype:"primitive,string"`
+}
+
+func DefaultDialerConfig() *DialerConfig {
+	defaultFallbackDelay := 300 * time.Millisecond
+	defaultConnectTimeout := 30 * time.Second
+
+	return &DialerConfig{
+		IPPreference:   IPAuto,
+		FallbackDelay:  &defaultFallbackDelay,
+		ConnectTimeout: &defaultConnectTimeout,
+	}
+}
+
+func (c *DialerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultDialerConfig()
+
+	type plain DialerConfig // to avoid recursion
+
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+
+	switch c.IPPreference {
+	case IPAuto, IPPreferV4, IPPreferV6, IPv4Only, IPv6Only:
+		return nil
+	default:
+		return fmt.Errorf("unknown IP preference \"%v\"", c.IPPreference)
+	}
+}
+
+// Dialer connects to provider hosts according to the IP family preference
+type Dialer struct {
+	dialer        *net.Dialer
+	resolver      HostResolver
+	preference    IPPreference
+	fallbackDelay time.Duration
+}
+
+func NewDialer(config *DialerConfig, resolver HostResolver) *Dialer {
+	return &Dialer{
+		dialer: &net.Dialer{
+			Timeout:   *config.ConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		},
+		resolver:      resolver,
+		preference:    config.IPPreference,
+		fallbackDelay: *config.FallbackDelay,
+	}
+}
+
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, address)
+	}
+
+	addrs, err := d.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	primaries, fallbacks := d.partition(addrs)
+
+	if len(primaries) == 0 {
+		return nil, fmt.Errorf("%w: %v has no addresses allowed by the \"%v\" IP preference", ErrNoAddresses, host, d.preference)
+	}
+
+	return d.dialParallel(ctx, network, port, primaries, fallbacks)
+}
+
+// partition splits addresses into the preferred & the fallback IP families
+func (d *Dialer) partition(addrs []string) ([]string, []string) {
+	primaryVersion := noIPVersion
+
+	switch d.preference {
+	case IPPreferV4, IPv4Only:
+		primaryVersion = 4
+	case IPPreferV6, IPv6Only:
+		primaryVersion = 6
+	default:
+		if len(addrs) > 0 {
+			primaryVersion = ipVersion(addrs[0])
+		}
+	}
+
+	primaries := make([]string, 0, len(addrs))
+	fallbacks := make([]string, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if ipVersion(addr) == primaryVersion {
+			primaries = append(primaries, addr)
+			continue
+		}
+
+		fallbacks = append(fallbacks, addr)
+	}
+
+	if d.preference == IPv4Only || d.preference == IPv6Only {
+		return primaries, nil
+	}
+
+	if len(primaries) == 0 {
+		return fallbacks, nil
+	}
+
+	return primaries, fallbacks
+}
+
+// dialParallel races the preferred & the fallback addresses giving the preferred ones a head start
+func (d *Dialer) dialParallel(ctx context.Context, network, port string, primaries, fallbacks []string) (net.Conn, error) {
+	if len(fallbacks) == 0 {
+		return d.dialSerial(ctx, network, port, primaries)
+	}
+
+	if d.fallbackDelay < 0 {
+		conn, err := d.dialSerial(ctx, network, port, primaries)
+		if err == nil {
+			return conn, nil
+		}
+
+		return d.dialSerial(ctx, network, port, fallbacks)
+	}
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resultC := make(chan dialResult, 2)
+
+	startDial := func(addrs []string) {
+		go func() {
+			conn, err := d.dialSerial(ctx, network, port, addrs)
+			resultC <- dialResult{conn: conn, err: err}
+		}()
+	}
+
+	startDial(primaries)
+
+	fallbackTimer := time.NewTimer(d.fallbackDelay)
+	defer fallbackTimer.Stop()
+
+	started, finished := 1, 0
+	fallbackStarted := false
+
+	var firstErr error
+
+	for {
+		select {
+		case <-fallbackTimer.C:
+			if !fallbackStarted {
+				fallbackStarted = true
+				started++
+
+				startDial(fallbacks)
+			}
+		case result := <-resultC:
+			finished++
+
+			if result.err == nil {
+				// close connections of attempts that may still succeed after we have a winner
+				go func(pending int) {
+					for ; pending > 0; pending-- {
+						if loser := <-resultC; loser.conn != nil {
+							loser.conn.Close()
+						}
+					}
+				}(started - finished)
+
+				return result.conn, nil
+			}
+
+			if firstErr == nil {
+				firstErr = result.err
+			}
+
+			if !fallbackStarted {
+				// the preferred family has failed, no reason to wait for the fallback delay
+				fallbackStarted = true
+				started++
+
+				startDial(fallbacks)
+
+				continue
+			}
+
+			if finished == started {
+				return nil, firstErr
+			}
+		}
+	}
+}
+
+func (d *Dialer) dialSerial(ctx context.Context, network, port string, addrs []string) (net.Conn, error) {
+	var dialErr error
+
+	for _, addr := range addrs {
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(addr, port))
+		if err == nil {
+			return conn, nil
+		}
+
+		dialErr = err
+	}
+
+	return nil, dialErr
+}
+
+func ipVersion(addr string) int {
+	ip := net.ParseIP(addr)
+
+	if ip == nil {
+		return noIPVersion
+	}
+
+	if ip.To4() != nil {
+		return 4
+	}
+
+	return 6
+}
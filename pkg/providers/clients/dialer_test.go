@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDialer(preference IPPreference, fallbackDelay time.Duration) *Dialer {
+	config := DefaultDialerConfig()
+	config.IPPreference = preference
+	config.FallbackDelay = &fallbackDelay
+
+	return NewDialer(config, &resolverMock{})
+}
+
+func TestDialer_Partition(t *testing.T) {
+	addrs := []string{"2001:db8::1", "10.0.0.1", "2001:db8::2", "10.0.0.2"}
+
+	tests := map[IPPreference]struct {
+		primaries []string
+		fallbacks []string
+	}{
+		IPAuto:     {[]string{"2001:db8::1", "2001:db8::2"}, []string{"10.0.0.1", "10.0.0.2"}},
+		IPPreferV4: {[]string{"10.0.0.1", "10.0.0.2"}, []string{"2001:db8::1", "2001:db8::2"}},
+		IPPreferV6: {[]string{"2001:db8::1", "2001:db8::2"}, []string{"10.0.0.1", "10.0.0.2"}},
+		IPv4Only:   {[]string{"10.0.0.1", "10.0.0.2"}, nil},
+		IPv6Only:   {[]string{"2001:db8::1", "2001:db8::2"}, nil},
+	}
+
+	for preference, expected := range tests {
+		t.Run(preference, func(t *testing.T) {
+			primaries, fallbacks := newTestDialer(preference, 0).partition(addrs)
+
+			require.Equal(t, expected.primaries, primaries)
+			require.Equal(t, expected.fallbacks, fallbacks)
+		})
+	}
+}
+
+func TestDialer_PreferredFamilyIsMissing(t *testing.T) {
+	primaries, fallbacks := newTestDialer(IPPreferV6, 0).partition([]string{"10.0.0.1"})
+
+	require.Equal(t, []string{"10.0.0.1"}, primaries)
+	require.Empty(t, fallbacks)
+
+	primaries, _ = newTestDialer(IPv6Only, 0).partition([]string{"10.0.0.1"})
+	require.Empty(t, primaries)
+}
+
+func TestDialer_FallsBackWhenPreferredHangs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	dialer := newTestDialer(IPAuto, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 192.0.2.0/24 is reserved for documentation, so connection attempts there are not answered
+	conn, err := dialer.dialParallel(ctx, "tcp", port, []string{"192.0.2.1"}, []string{"127.0.0.1"})
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestDialer_DialContextWithResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	resolver := &resolverMock{addrs: []string{"127.0.0.1"}}
+	dialer := NewDialer(DefaultDialerConfig(), NewDNSCache(resolver, time.Hour))
+
+	conn, err := dialer.DialContext(context.Background(), "tcp", net.JoinHostPort("provider.local", port))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
@@ -0,0 +1,130 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrNoAddresses = errors.New("no addresses resolved for the host")
+
+// DNSConfig controls caching of provider host DNS records
+type DNSConfig struct {
+	Enabled bool           `yaml:"enabled" json:"enabled"`
+	TTL     *time.Duration `yaml:"ttl,omitempty" json:"ttl" swaggertype:"primitive,string"` // overrides TTLs of the DNS records
+}
+
+func DefaultDNSConfig() *DNSConfig {
+	defaultTTL := 1 * time.Minute
+
+	return &DNSConfig{
+		Enabled: false,
+		TTL:     &defaultTTL,
+	}
+}
+
+func (c *DNSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultDNSConfig()
+
+	type plain DNSConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// HostResolver resolves hosts into IP addresses
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+type dnsRecord struct {
+	addrs      []string
+	resolvedAt time.Time
+	refreshing bool
+}
+
+// DNSCache caches resolved provider host addresses.
+//
+//	Expired records are re-resolved in the background while the last known addresses keep being served,
+//	so short-living DNS failures don't affect provider availability.
+//	A record is dropped only if the host can't be resolved when there is nothing cached for it yet.
+type DNSCache struct {
+	resolver HostResolver
+	ttl      time.Duration
+	mu       sync.Mutex
+	records  map[string]*dnsRecord
+}
+
+func NewDNSCache(resolver HostResolver, ttl time.Duration) *DNSCache {
+	return &DNSCache{
+		resolver: resolver,
+		ttl:      ttl,
+		records:  make(map[string]*dnsRecord),
+	}
+}
+
+// LookupHost returns addresses of the host from cache resolving them if needed
+func (c *DNSCache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	record, found := c.records[host]
+
+	if found {
+		if time.Since(record.resolvedAt) >= c.ttl && !record.refreshing {
+			record.refreshing = true
+
+			go c.refresh(host)
+		}
+
+		addrs := record.addrs
+		c.mu.Unlock()
+
+		return addrs, nil
+	}
+
+	c.mu.Unlock()
+
+	addrs, err := c.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.records[host] = &dnsRecord{addrs: addrs, resolvedAt: time.Now()}
+	c.mu.Unlock()
+
+	return addrs, nil
+}
+
+func (c *DNSCache) resolve(ctx context.Context, host string) ([]string, error) {
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, ErrNoAddresses
+	}
+
+	return addrs, nil
+}
+
+func (c *DNSCache) refresh(host string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addrs, err := c.resolve(ctx, host)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	record := c.records[host]
+	record.refreshing = false
+
+	if err != nil {
+		// keep serving the last known addresses, retry on the next lookup
+		return
+	}
+
+	record.addrs = addrs
+	record.resolvedAt = time.Now()
+}
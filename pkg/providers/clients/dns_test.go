@@ -0,0 +1,89 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type resolverMock struct {
+	mu    sync.Mutex
+	calls int
+	addrs []string
+	err   error
+}
+
+func (r *resolverMock) LookupHost(_ context.Context, _ string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+
+	return r.addrs, r.err
+}
+
+func (r *resolverMock) set(addrs []string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.addrs = addrs
+	r.err = err
+}
+
+func (r *resolverMock) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.calls
+}
+
+func TestDNSCache_CachesRecords(t *testing.T) {
+	resolver := &resolverMock{addrs: []string{"10.0.0.1"}}
+	cache := NewDNSCache(resolver, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		addrs, err := cache.LookupHost(context.Background(), "api.openai.com")
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.1"}, addrs)
+	}
+
+	require.Equal(t, 1, resolver.callCount())
+}
+
+func TestDNSCache_ServesStaleRecordsOnResolutionFailures(t *testing.T) {
+	resolver := &resolverMock{addrs: []string{"10.0.0.1"}}
+	cache := NewDNSCache(resolver, time.Millisecond)
+
+	_, err := cache.LookupHost(context.Background(), "api.openai.com")
+	require.NoError(t, err)
+
+	resolver.set(nil, errors.New("no such host"))
+	time.Sleep(2 * time.Millisecond)
+
+	addrs, err := cache.LookupHost(context.Background(), "api.openai.com")
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	require.Eventually(t, func() bool { return resolver.callCount() == 2 }, time.Second, time.Millisecond)
+
+	resolver.set([]string{"10.0.0.2"}, nil)
+
+	require.Eventually(t, func() bool {
+		addrs, err = cache.LookupHost(context.Background(), "api.openai.com")
+
+		return err == nil && addrs[0] == "10.0.0.2"
+	}, time.Second, time.Millisecond)
+}
+
+func TestDNSCache_FailsWhenNothingIsCached(t *testing.T) {
+	resolver := &resolverMock{}
+	cache := NewDNSCache(resolver, time.Hour)
+
+	_, err := cache.LookupHost(context.Background(), "api.openai.com")
+	require.ErrorIs(t, err, ErrNoAddresses)
+}
@@ -0,0 +1,86 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// SchemaDriftDetector compares raw provider responses with the schemas they are mapped from.
+//
+//	Unknown fields may be new information we are not mapping yet, while missing expected fields
+//	are likely to turn into empty values in the unified schema. Both are counted on every response,
+//	but each drifted field is logged only once to keep logs readable
+type SchemaDriftDetector struct {
+	provider string
+	tel      *telemetry.Telemetry
+	logger   *zap.Logger
+	mu       sync.Mutex
+	reported map[string]struct{}
+}
+
+func NewSchemaDriftDetector(provider string, tel *telemetry.Telemetry) *SchemaDriftDetector {
+	return &SchemaDriftDetector{
+		provider: provider,
+		tel:      tel,
+		logger:   tel.L().With(zap.String("provider", provider)),
+		reported: make(map[string]struct{}),
+	}
+}
+
+// Check reports fields of the response body that are unknown to the schema or missing from the expected ones
+func (d *SchemaDriftDetector) Check(action string, body []byte, schema any, expectedFields ...string) {
+	if d == nil {
+		return
+	}
+
+	var payload any
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		// the response parsing is going to fail & report the error anyway
+		return
+	}
+
+	unknownFields := schemas.UnknownFields(payload, reflect.TypeOf(schema))
+	missingFields := schemas.MissingFields(payload, expectedFields...)
+
+	if len(unknownFields) > 0 {
+		d.tel.M().Counter(fmt.Sprintf("providers.%v.%v.schema_drift.unknown_fields", d.provider, action)).Inc()
+		d.report(action, "Provider response contains fields unknown to Glide", "unknown", unknownFields)
+	}
+
+	if len(missingFields) > 0 {
+		d.tel.M().Counter(fmt.Sprintf("providers.%v.%v.schema_drift.missing_fields", d.provider, action)).Inc()
+		d.report(action, "Provider response misses expected fields", "missing", missingFields)
+	}
+}
+
+func (d *SchemaDriftDetector) report(action string, msg string, kind string, fields []string) {
+	newFields := make([]string, 0, len(fields))
+
+	d.mu.Lock()
+
+	for _, field := range fields {
+		key := action + ":" + kind + ":" + field
+
+		if _, found := d.reported[key]; found {
+			continue
+		}
+
+		d.reported[key] = struct{}{}
+		newFields = append(newFields, field)
+	}
+
+	d.mu.Unlock()
+
+	if len(newFields) == 0 {
+		return
+	}
+
+	d.logger.Warn(msg, zap.String("action", action), zap.Strings("fields", newFields))
+}
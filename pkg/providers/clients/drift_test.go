@@ -0,0 +1,36 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+type usageMock struct {
+	PromptTokens int `json:"prompt_tokens"`
+}
+
+type completionMock struct {
+	Text  string    `json:"text"`
+	Usage usageMock `json:"usage"`
+}
+
+func TestSchemaDriftDetector_Check(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+	detector := NewSchemaDriftDetector("cohere", tel)
+
+	detector.Check("chat", []byte(`{"text": "hi", "usage": {"prompt_tokens": 1}}`), completionMock{}, "text", "usage")
+	require.Empty(t, tel.M().Counters())
+
+	// the usage envelope has changed
+	for i := 0; i < 2; i++ {
+		detector.Check("chat", []byte(`{"text": "hi", "meta": {"billed_units": {"input_tokens": 1}}}`), completionMock{}, "text", "usage")
+	}
+
+	counters := tel.M().Counters()
+
+	require.Equal(t, int64(2), counters["providers.cohere.chat.schema_drift.unknown_fields"])
+	require.Equal(t, int64(2), counters["providers.cohere.chat.schema_drift.missing_fields"])
+	require.Len(t, detector.reported, 2)
+}
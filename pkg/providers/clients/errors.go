@@ -1,17 +1,52 @@
 package clients
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
+
+	"glide/pkg/api/schemas"
 )
 
 var (
 	ErrProviderUnavailable      = errors.New("provider is not available")
 	ErrUnauthorized             = errors.New("API key is wrong or not set")
 	ErrChatStreamNotImplemented = errors.New("streaming chat API is not implemented for provider")
+	ErrEmbedNotImplemented      = errors.New("embedding API is not implemented for provider")
+	ErrModelListNotImplemented  = errors.New("model listing API is not implemented for provider")
+	ErrEmptyResponse            = errors.New("empty response")
+	ErrContentFiltered          = errors.New("provider content filter has blocked the request or response")
+	ErrContextTooLong           = errors.New("prompt exceeds the model context window")
 )
 
+// ErrorCode maps the provider error into the gateway error code.
+// Errors that tell nothing specific about the failure (e.g. server errors or network failures) mean the model is unavailable
+func ErrorCode(err error) schemas.ErrorCode {
+	var rateLimitErr *RateLimitError
+
+	switch {
+	case errors.As(err, &rateLimitErr):
+		return schemas.RateLimited
+	case errors.Is(err, ErrUnauthorized):
+		return schemas.AuthFailed
+	case errors.Is(err, ErrContentFiltered):
+		return schemas.ContentFiltered
+	case errors.Is(err, ErrContextTooLong):
+		return schemas.ContextWindowExceeded
+	case errors.Is(err, ErrEmptyResponse):
+		return schemas.EmptyResponse
+	case errors.Is(err, ErrChatStreamNotImplemented),
+		errors.Is(err, ErrEmbedNotImplemented),
+		errors.Is(err, ErrModelListNotImplemented):
+		return schemas.NotImplemented
+	case errors.Is(err, context.DeadlineExceeded):
+		return schemas.Timeout
+	default:
+		return schemas.ModelUnavailable
+	}
+}
+
 type RateLimitError struct {
 	untilReset time.Duration
 }
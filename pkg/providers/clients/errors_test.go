@@ -1,9 +1,14 @@
 package clients
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"glide/pkg/api/schemas"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,3 +19,26 @@ func TestRateLimitError(t *testing.T) {
 	require.Equal(t, duration, err.UntilReset())
 	require.Contains(t, err.Error(), "rate limit reached")
 }
+
+func TestErrorCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code schemas.ErrorCode
+	}{
+		"rate limit":       {NewRateLimitError(nil), schemas.RateLimited},
+		"unauthorized":     {ErrUnauthorized, schemas.AuthFailed},
+		"content filter":   {ErrContentFiltered, schemas.ContentFiltered},
+		"context too long": {fmt.Errorf("chat failed: %w", ErrContextTooLong), schemas.ContextWindowExceeded},
+		"empty response":   {ErrEmptyResponse, schemas.EmptyResponse},
+		"not implemented":  {ErrEmbedNotImplemented, schemas.NotImplemented},
+		"timeout":          {fmt.Errorf("failed to send request: %w", context.DeadlineExceeded), schemas.Timeout},
+		"unavailable":      {ErrProviderUnavailable, schemas.ModelUnavailable},
+		"unknown":          {errors.New("connection reset by peer"), schemas.ModelUnavailable},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.code, ErrorCode(test.err))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// rateLimitResetHeaders are headers providers use to tell when their rate limits reset
+var rateLimitResetHeaders = []string{
+	"x-ratelimit-reset-requests", // OpenAI & Azure OpenAI
+	"x-ratelimit-reset-tokens",
+	"anthropic-ratelimit-requests-reset",
+	"anthropic-ratelimit-tokens-reset",
+	"anthropic-ratelimit-input-tokens-reset",
+	"anthropic-ratelimit-output-tokens-reset",
+	"x-ratelimit-reset",
+}
+
+// unixTimeThreshold tells reset timestamps from reset delays given in seconds
+const unixTimeThreshold = 1_000_000_000
+
+// NewRateLimitErrorFromHeaders creates the rate limit error of the 429 response.
+// The default cooldown is used when the response doesn't tell when the rate limit resets
+func NewRateLimitErrorFromHeaders(header http.Header) *RateLimitError {
+	untilReset, found := RateLimitReset(header, time.Now())
+	if !found {
+		return NewRateLimitError(nil)
+	}
+
+	return NewRateLimitError(&untilReset)
+}
+
+// RateLimitReset finds out how long to wait before sending requests again.
+// Retry-After headers take precedence over rate limit reset headers.
+// When several rate limits reset at different times, the latest one is used as it's not known which one was hit
+func RateLimitReset(header http.Header, now time.Time) (time.Duration, bool) {
+	if retryAfterMs, err := strconv.ParseFloat(header.Get("retry-after-ms"), 64); err == nil {
+		return max(time.Duration(retryAfterMs*float64(time.Millisecond)), 0), true
+	}
+
+	if untilReset, found := parseResetValue(header.Get("Retry-After"), now); found {
+		return untilReset, true
+	}
+
+	var untilReset time.Duration
+
+	found := false
+
+	for _, headerName := range rateLimitResetHeaders {
+		headerReset, headerFound := parseResetValue(header.Get(headerName), now)
+		if !headerFound {
+			continue
+		}
+
+		untilReset = max(untilReset, headerReset)
+		found = true
+	}
+
+	return untilReset, found
+}
+
+// parseResetValue supports delays in seconds (e.g. 20), durations (e.g. 6m0s),
+// unix timestamps, RFC 3339 timestamps & HTTP dates
+func parseResetValue(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds > unixTimeThreshold {
+			return max(time.Unix(int64(seconds), 0).Sub(now), 0), true
+		}
+
+		return max(time.Duration(seconds*float64(time.Second)), 0), true
+	}
+
+	if delay, err := time.ParseDuration(value); err == nil {
+		return max(delay, 0), true
+	}
+
+	if resetAt, err := time.Parse(time.RFC3339, value); err == nil {
+		return max(resetAt.Sub(now), 0), true
+	}
+
+	if resetAt, err := http.ParseTime(value); err == nil {
+		return max(resetAt.Sub(now), 0), true
+	}
+
+	return 0, false
+}
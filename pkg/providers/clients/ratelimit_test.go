@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitReset(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		headers    map[string]string
+		untilReset time.Duration
+		found      bool
+	}{
+		"retry after seconds":   {map[string]string{"Retry-After": "20"}, 20 * time.Second, true},
+		"retry after date":      {map[string]string{"Retry-After": "Wed, 01 May 2024 12:01:00 GMT"}, time.Minute, true},
+		"retry after duration":  {map[string]string{"Retry-After": "5m"}, 5 * time.Minute, true},
+		"retry after ms":        {map[string]string{"retry-after-ms": "1500", "Retry-After": "2"}, 1500 * time.Millisecond, true},
+		"openai reset headers":  {map[string]string{"x-ratelimit-reset-requests": "1s", "x-ratelimit-reset-tokens": "6m0s"}, 6 * time.Minute, true},
+		"anthropic reset":       {map[string]string{"anthropic-ratelimit-tokens-reset": "2024-05-01T12:00:30Z"}, 30 * time.Second, true},
+		"unix timestamp reset":  {map[string]string{"x-ratelimit-reset": "1714564810"}, 10 * time.Second, true},
+		"retry after wins":      {map[string]string{"Retry-After": "3", "x-ratelimit-reset-tokens": "6m0s"}, 3 * time.Second, true},
+		"reset in the past":     {map[string]string{"Retry-After": "Wed, 01 May 2024 11:00:00 GMT"}, 0, true},
+		"no headers":            {map[string]string{}, 0, false},
+		"unparsable reset time": {map[string]string{"Retry-After": "soon"}, 0, false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			header := make(http.Header)
+
+			for key, value := range test.headers {
+				header.Set(key, value)
+			}
+
+			untilReset, found := RateLimitReset(header, now)
+
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.untilReset, untilReset)
+		})
+	}
+}
+
+func TestNewRateLimitErrorFromHeaders(t *testing.T) {
+	header := make(http.Header)
+	header.Set("Retry-After", "20")
+
+	require.Equal(t, 20*time.Second, NewRateLimitErrorFromHeaders(header).UntilReset())
+
+	// the default cooldown is used when the provider doesn't tell when to retry
+	require.Equal(t, time.Minute, NewRateLimitErrorFromHeaders(make(http.Header)).UntilReset())
+}
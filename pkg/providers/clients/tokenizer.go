@@ -0,0 +1,74 @@
+package clients
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkoukk/tiktoken-go"
+	tiktokenloader "github.com/pkoukk/tiktoken-go-loader"
+	"glide/pkg/api/schemas"
+)
+
+const (
+	// tokensPerMessage is the overhead OpenAI-family chat models add to wrap each message
+	//  Ref: https://github.com/openai/openai-cookbook/blob/main/examples/How_to_count_tokens_with_tiktoken.ipynb
+	tokensPerMessage = 3
+	// tokensPerName is added when the message has the author name
+	tokensPerName = 1
+	// tokensPerReply primes the assistant reply
+	tokensPerReply = 3
+	// charsPerToken is a rough average for English texts used when the model tokenizer is unknown
+	charsPerToken = 4
+)
+
+var loadBPEOnce sync.Once
+
+// CountTiktokens counts tokens of chat messages the way OpenAI-family models do.
+// Models unknown to tiktoken (e.g. Azure deployment names) are counted with the cl100k_base encoding
+// and reported as estimated
+func CountTiktokens(modelName string, messages []schemas.ChatMessage) (tokens int, estimated bool, err error) {
+	loadBPEOnce.Do(func() {
+		// BPE ranks are embedded in the binary, so the gateway doesn't fetch them from the internet on the first request
+		tiktoken.SetBpeLoader(tiktokenloader.NewOfflineLoader())
+	})
+
+	encoding, err := tiktoken.EncodingForModel(modelName)
+	if err != nil {
+		estimated = true
+
+		encoding, err = tiktoken.GetEncoding(tiktoken.MODEL_CL100K_BASE)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	for _, message := range messages {
+		tokens += tokensPerMessage
+		tokens += len(encoding.EncodeOrdinary(message.Role))
+		tokens += len(encoding.EncodeOrdinary(message.Content))
+
+		if message.Name != "" {
+			tokens += tokensPerName
+			tokens += len(encoding.EncodeOrdinary(message.Name))
+		}
+	}
+
+	return tokens + tokensPerReply, estimated, nil
+}
+
+// EstimateTokens approximates token count of chat messages when the model tokenizer is not available
+func EstimateTokens(messages []schemas.ChatMessage) int {
+	tokens := 0
+
+	for _, message := range messages {
+		chars := utf8.RuneCountInString(message.Role) + utf8.RuneCountInString(message.Content)
+		tokens += tokensPerMessage + (chars+charsPerToken-1)/charsPerToken
+	}
+
+	return tokens + tokensPerReply
+}
+
+// EstimateTextTokens approximates token count of the text (e.g. a streamed response) when the model tokenizer is not available
+func EstimateTextTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
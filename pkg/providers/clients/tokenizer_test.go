@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+)
+
+func TestTokenizer_CountTiktokens(t *testing.T) {
+	messages := []schemas.ChatMessage{
+		{Role: "system", Content: "You are a helpful assistant."},
+		{Role: "user", Content: "Hello world"},
+	}
+
+	tokens, estimated, err := CountTiktokens("gpt-3.5-turbo", messages)
+	require.NoError(t, err)
+	require.False(t, estimated)
+
+	// system(1) + content(6) + user(1) + content(2) + 2 messages * 3 + reply priming(3)
+	require.Equal(t, 19, tokens)
+}
+
+func TestTokenizer_CountTiktokensWithName(t *testing.T) {
+	messages := []schemas.ChatMessage{
+		{Role: "user", Content: "Hello world", Name: "glide"},
+	}
+
+	withoutName, _, err := CountTiktokens("gpt-4o", []schemas.ChatMessage{{Role: "user", Content: "Hello world"}})
+	require.NoError(t, err)
+
+	withName, _, err := CountTiktokens("gpt-4o", messages)
+	require.NoError(t, err)
+
+	require.Greater(t, withName, withoutName+tokensPerName)
+}
+
+func TestTokenizer_CountTiktokensUnknownModel(t *testing.T) {
+	messages := []schemas.ChatMessage{
+		{Role: "user", Content: "Hello world"},
+	}
+
+	tokens, estimated, err := CountTiktokens("my-azure-deployment", messages)
+	require.NoError(t, err)
+	require.True(t, estimated)
+	require.Equal(t, 9, tokens)
+}
+
+func TestTokenizer_EstimateTokens(t *testing.T) {
+	messages := []schemas.ChatMessage{
+		{Role: "user", Content: "Hello world"},
+	}
+
+	// ceil((4 + 11) / 4) + 3 per message + 3 reply priming
+	require.Equal(t, 10, EstimateTokens(messages))
+	require.Equal(t, tokensPerReply, EstimateTokens(nil))
+}
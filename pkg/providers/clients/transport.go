@@ -0,0 +1,76 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// NewHTTPClient creates an HTTP client to talk to provider APIs according to the client config
+func NewHTTPClient(config *ClientConfig) *http.Client {
+	dialerConfig := config.Dialer
+	if dialerConfig == nil {
+		dialerConfig = DefaultDialerConfig()
+	}
+
+	var resolver HostResolver = net.DefaultResolver
+
+	if config.DNS != nil && config.DNS.Enabled {
+		resolver = NewDNSCache(resolver, *config.DNS.TTL)
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           NewDialer(dialerConfig, resolver).DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   2,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if config.Timeout == nil {
+		return &http.Client{Transport: transport}
+	}
+
+	return &http.Client{
+		Transport: &timeoutTransport{timeout: *config.Timeout, next: transport},
+	}
+}
+
+// timeoutTransport enforces the model timeout via the request context deadline.
+// Unlike the HTTP client timeout, the deadline is combined with the one of the incoming request,
+// so the tighter of them applies. The deadline covers reading the response body as well
+type timeoutTransport struct {
+	timeout time.Duration
+	next    http.RoundTripper
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnClose releases the request context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTimeoutTestClient(timeout time.Duration) *http.Client {
+	config := DefaultClientConfig()
+	config.Timeout = &timeout
+
+	return NewHTTPClient(config)
+}
+
+func TestHTTPClient_PerModelTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+			_, _ = w.Write([]byte("ok"))
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	// the slow model is given enough time
+	resp, err := newTimeoutTestClient(time.Second).Get(server.URL)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "ok", string(body))
+
+	// while the fast one is not
+	_, err = newTimeoutTestClient(50 * time.Millisecond).Get(server.URL) //nolint:bodyclose
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHTTPClient_TimeoutCoversResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	resp, err := newTimeoutTestClient(50 * time.Millisecond).Get(server.URL)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	_, err = io.ReadAll(resp.Body)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHTTPClient_RequestDeadlineApplies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	startedAt := time.Now()
+
+	_, err = newTimeoutTestClient(time.Minute).Do(req) //nolint:bodyclose
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(startedAt), time.Second)
+}
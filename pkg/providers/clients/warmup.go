@@ -0,0 +1,152 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WarmupConfig controls connection pre-establishment to the provider API host
+type WarmupConfig struct {
+	Enabled  bool           `yaml:"enabled" json:"enabled"`
+	Interval *time.Duration `yaml:"interval,omitempty" json:"interval" swaggertype:"primitive,string"` // how long a connection may stay idle before it's re-warmed
+	Timeout  *time.Duration `yaml:"timeout,omitempty" json:"timeout" swaggertype:"primitive,string"`
+}
+
+func DefaultWarmupConfig() *WarmupConfig {
+	defaultInterval := 30 * time.Second
+	defaultTimeout := 5 * time.Second
+
+	return &WarmupConfig{
+		Enabled:  false,
+		Interval: &defaultInterval,
+		Timeout:  &defaultTimeout,
+	}
+}
+
+func (c *WarmupConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultWarmupConfig()
+
+	type plain WarmupConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// WarmUp establishes (or reuses) a connection to the given URL, so it's kept in the client's idle pool.
+//
+//	Any HTTP response means the TCP & TLS handshakes went through, so the status code is not checked
+func WarmUp(ctx context.Context, httpClient *http.Client, targetURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, targetURL, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create warmup request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send warmup request: %w", err)
+	}
+
+	// the body must be fully read & closed for the connection to be returned to the idle pool
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.Body.Close()
+}
+
+// ConnWarmer keeps provider connections warm.
+//
+//	It warms up the connection right away and then again every time the connection
+//	has been idle for longer than the configured interval
+type ConnWarmer struct {
+	warmFn       func(ctx context.Context) error
+	config       *WarmupConfig
+	logger       *zap.Logger
+	lastActivity atomic.Int64
+	stopC        chan struct{}
+	stopOnce     sync.Once
+	doneC        chan struct{}
+}
+
+func NewConnWarmer(warmFn func(ctx context.Context) error, config *WarmupConfig, logger *zap.Logger) *ConnWarmer {
+	return &ConnWarmer{
+		warmFn: warmFn,
+		config: config,
+		logger: logger,
+		stopC:  make(chan struct{}),
+		doneC:  make(chan struct{}),
+	}
+}
+
+// Start warms up the connection in the background
+func (w *ConnWarmer) Start() {
+	go w.run()
+}
+
+// Touch marks the connection as recently used, so it doesn't need re-warming yet
+func (w *ConnWarmer) Touch() {
+	if w == nil {
+		return
+	}
+
+	w.lastActivity.Store(time.Now().UnixNano())
+}
+
+// Stop terminates the background warming and waits for it to finish
+func (w *ConnWarmer) Stop() {
+	if w == nil {
+		return
+	}
+
+	w.stopOnce.Do(func() {
+		close(w.stopC)
+	})
+
+	<-w.doneC
+}
+
+func (w *ConnWarmer) idle() bool {
+	lastActivity := time.Unix(0, w.lastActivity.Load())
+
+	return time.Since(lastActivity) >= *w.config.Interval
+}
+
+func (w *ConnWarmer) warm() {
+	ctx, cancel := context.WithTimeout(context.Background(), *w.config.Timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+
+	if err := w.warmFn(ctx); err != nil {
+		w.logger.Warn("Failed to warm up provider connection", zap.Error(err))
+		return
+	}
+
+	w.Touch()
+
+	w.logger.Debug("Provider connection is warmed up", zap.Duration("took", time.Since(startedAt)))
+}
+
+func (w *ConnWarmer) run() {
+	defer close(w.doneC)
+
+	w.warm()
+
+	ticker := time.NewTicker(*w.config.Interval / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopC:
+			return
+		case <-ticker.C:
+			if w.idle() {
+				w.warm()
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/telemetry"
+)
+
+func TestWarmUp_HeadRequest(t *testing.T) {
+	var method atomic.Value
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method.Store(r.Method)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := WarmUp(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodHead, method.Load())
+}
+
+func TestConnWarmer_WarmsOnStartAndAfterIdle(t *testing.T) {
+	interval := 20 * time.Millisecond
+	config := DefaultWarmupConfig()
+	config.Enabled = true
+	config.Interval = &interval
+
+	var warmups atomic.Int32
+
+	warmer := NewConnWarmer(func(_ context.Context) error {
+		warmups.Add(1)
+		return nil
+	}, config, telemetry.NewLoggerMock())
+
+	warmer.Start()
+
+	require.Eventually(t, func() bool {
+		return warmups.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	warmer.Stop()
+	warmer.Stop() // safe to call twice
+}
+
+func TestConnWarmer_SkipsWhenActive(t *testing.T) {
+	interval := time.Hour
+	config := DefaultWarmupConfig()
+	config.Interval = &interval
+
+	warmer := NewConnWarmer(func(_ context.Context) error { return nil }, config, telemetry.NewLoggerMock())
+
+	require.True(t, warmer.idle())
+
+	warmer.Touch()
+
+	require.False(t, warmer.idle())
+}
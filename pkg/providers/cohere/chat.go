@@ -83,7 +83,6 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
 	req.Header.Set("Content-Type", "application/json")
 
-	// TODO: this could leak information from messages which may not be a desired thing to have
 	c.tel.Logger.Debug(
 		"cohere chat request",
 		zap.String("chat_url", c.chatURL),
@@ -125,6 +124,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	c.driftDetector.Check("chat", bodyBytes, ChatCompletion{}, "text", "token_count")
+
 	// Parse the response JSON
 	var cohereCompletion ChatCompletion
 
@@ -1,7 +1,7 @@
 package cohere
 
 import (
-	"errors"
+	"context"
 	"net/http"
 	"net/url"
 
@@ -15,14 +15,18 @@ const (
 
 // ErrEmptyResponse is returned when the Cohere API returns an empty response.
 var (
-	ErrEmptyResponse = errors.New("empty response")
+	ErrEmptyResponse = clients.ErrEmptyResponse
 )
 
 // Client is a client for accessing Cohere API
 type Client struct {
 	baseURL             string
 	chatURL             string
+	embedURL            string
+	modelURL            string
+	tokenURL            string
 	chatRequestTemplate *ChatRequest
+	driftDetector       *clients.SchemaDriftDetector
 	finishReasonMapper  *FinishReasonMapper
 	errMapper           *ErrorMapper
 	config              *Config
@@ -37,22 +41,34 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		return nil, err
 	}
 
+	embedURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.EmbedEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	modelURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ModelEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.TokenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Client{
 		baseURL:             providerConfig.BaseURL,
 		chatURL:             chatURL,
+		embedURL:            embedURL,
+		modelURL:            modelURL,
+		tokenURL:            tokenURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
-		httpClient: &http.Client{
-			Timeout: *clientConfig.Timeout,
-			// TODO: use values from the config
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 2,
-			},
-		},
-		errMapper:          NewErrorMapper(tel),
-		finishReasonMapper: NewFinishReasonMapper(tel),
-		tel:                tel,
+		driftDetector:       clients.NewSchemaDriftDetector(providerName, tel),
+		httpClient:          clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		errMapper:           NewErrorMapper(tel),
+		finishReasonMapper:  NewFinishReasonMapper(tel),
+		tel:                 tel,
 	}
 
 	return c, nil
@@ -61,3 +77,8 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 func (c *Client) Provider() string {
 	return providerName
 }
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
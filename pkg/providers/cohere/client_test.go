@@ -65,3 +65,44 @@ func TestCohereClient_ChatRequest(t *testing.T) {
 
 	require.Equal(t, "ec9eb88b-2da5-462e-8f0f-0899d243aa2e", response.ID)
 }
+
+func TestCohereClient_EmbedRequest(t *testing.T) {
+	cohereMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var embedRequest EmbedRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&embedRequest); err != nil {
+			t.Errorf("error decoding payload: %v", err)
+		}
+
+		require.Equal(t, "/embed", r.URL.Path)
+		require.Equal(t, []string{"hello", "goodbye"}, embedRequest.Texts)
+
+		embedResponse, err := os.ReadFile(filepath.Clean("./testdata/embed.success.json"))
+		if err != nil {
+			t.Errorf("error reading cohere embed mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(embedResponse)
+		if err != nil {
+			t.Errorf("error on sending embed response: %v", err)
+		}
+	})
+
+	cohereServer := httptest.NewServer(cohereMock)
+	defer cohereServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = cohereServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Embed(context.Background(), schemas.NewEmbedFromStr("hello", "goodbye"))
+	require.NoError(t, err)
+
+	require.Equal(t, "da6e531f-54c6-4a73-bf92-f60566d8d753", response.ID)
+	require.Len(t, response.ModelResponse.Embeddings, 2)
+	require.Equal(t, 1, response.ModelResponse.Embeddings[1].Index)
+}
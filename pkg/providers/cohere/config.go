@@ -41,7 +41,12 @@ func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type Config struct {
 	BaseURL       string        `yaml:"base_url" json:"baseUrl" validate:"required,http_url"`
 	ChatEndpoint  string        `yaml:"chat_endpoint" json:"chatEndpoint" validate:"required"`
+	EmbedEndpoint string        `yaml:"embed_endpoint" json:"embedEndpoint"`
+	ModelEndpoint string        `yaml:"model_endpoint" json:"modelEndpoint"`
+	TokenEndpoint string        `yaml:"token_endpoint" json:"tokenEndpoint"`
 	Model         string        `yaml:"model" json:"model" validate:"required"` // https://docs.cohere.com/docs/models#command
+	EmbedModel    string        `yaml:"embed_model" json:"embedModel"`          // https://docs.cohere.com/docs/models#embed
+	EmbedInput    string        `yaml:"embed_input_type" json:"embedInputType"` // search_document, search_query, classification, clustering
 	APIKey        fields.Secret `yaml:"api_key" json:"-" validate:"required"`
 	DefaultParams *Params       `yaml:"default_params,omitempty" json:"defaultParams"`
 }
@@ -53,7 +58,12 @@ func DefaultConfig() *Config {
 	return &Config{
 		BaseURL:       "https://api.cohere.ai/v1",
 		ChatEndpoint:  "/chat",
+		EmbedEndpoint: "/embed",
+		ModelEndpoint: "/models",
+		TokenEndpoint: "/tokenize",
 		Model:         "command-light",
+		EmbedModel:    "embed-english-v3.0",
+		EmbedInput:    "search_document",
 		DefaultParams: &defaultParams,
 	}
 }
@@ -0,0 +1,94 @@
+package cohere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+func (c *Client) SupportEmbed() bool {
+	return true
+}
+
+// Embed sends an embedding request to the specified Cohere model.
+func (c *Client) Embed(ctx context.Context, request *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	embedRequest := &EmbedRequest{
+		Texts:     request.Input,
+		Model:     c.config.EmbedModel,
+		InputType: c.config.EmbedInput,
+	}
+
+	rawPayload, err := json.Marshal(embedRequest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal cohere embed request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cohere embed request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send cohere embed request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tel.Logger.Error("failed to read cohere embed response", zap.Error(err))
+		return nil, err
+	}
+
+	var embedResult EmbedResult
+
+	err = json.Unmarshal(bodyBytes, &embedResult)
+	if err != nil {
+		c.tel.Logger.Error("failed to parse cohere embed response", zap.Error(err))
+		return nil, err
+	}
+
+	if len(embedResult.Embeddings) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	embeddings := make([]schemas.Embedding, 0, len(embedResult.Embeddings))
+
+	for idx, vector := range embedResult.Embeddings {
+		embeddings = append(embeddings, schemas.Embedding{
+			Index:  idx,
+			Vector: vector,
+		})
+	}
+
+	inputTokens := embedResult.Meta.BilledUnits.InputTokens
+
+	return &schemas.EmbedResponse{
+		ID:        embedResult.ID,
+		Created:   int(time.Now().UTC().Unix()), // Cohere doesn't provide this
+		Provider:  providerName,
+		ModelName: c.config.EmbedModel,
+		ModelResponse: schemas.EmbedModelResponse{
+			Embeddings: embeddings,
+			TokenUsage: schemas.TokenUsage{
+				PromptTokens: inputTokens,
+				TotalTokens:  inputTokens,
+			},
+		},
+	}, nil
+}
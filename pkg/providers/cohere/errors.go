@@ -1,10 +1,10 @@
 package cohere
 
 import (
-	"fmt"
+	"encoding/json"
 	"io"
 	"net/http"
-	"time"
+	"strings"
 
 	"glide/pkg/providers/clients"
 	"glide/pkg/telemetry"
@@ -21,6 +21,19 @@ func NewErrorMapper(tel *telemetry.Telemetry) *ErrorMapper {
 	}
 }
 
+// contextTooLong checks if the error response tells the prompt doesn't fit the model context window
+func contextTooLong(errorBody []byte) bool {
+	var errResp struct {
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(errorBody, &errResp); err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(errResp.Message, "too many tokens")
+}
+
 func (m *ErrorMapper) Map(resp *http.Response) error {
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -43,22 +56,17 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 	)
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		// Read the value of the "Retry-After" header to get the cooldown delay
-		retryAfter := resp.Header.Get("Retry-After")
-
-		// Parse the value to get the duration
-		cooldownDelay, err := time.ParseDuration(retryAfter)
-		if err != nil {
-			return fmt.Errorf("failed to parse cooldown delay from headers: %w", err)
-		}
-
-		return clients.NewRateLimitError(&cooldownDelay)
+		return clients.NewRateLimitErrorFromHeaders(resp.Header)
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
 		return clients.ErrUnauthorized
 	}
 
+	if resp.StatusCode == http.StatusBadRequest && contextTooLong(bodyBytes) {
+		return clients.ErrContextTooLong
+	}
+
 	// Server & client errors result in the same error to keep gateway resilient
 	return clients.ErrProviderUnavailable
 }
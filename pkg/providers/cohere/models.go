@@ -0,0 +1,49 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"glide/pkg/api/schemas"
+)
+
+// ListModels lists models available in Cohere
+func (c *Client) ListModels(ctx context.Context) ([]schemas.ProviderModel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.modelURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cohere model list request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send cohere model list request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	var modelList ModelList
+
+	if err := json.NewDecoder(resp.Body).Decode(&modelList); err != nil {
+		return nil, fmt.Errorf("unable to parse cohere model list: %w", err)
+	}
+
+	models := make([]schemas.ProviderModel, 0, len(modelList.Models))
+
+	for _, model := range modelList.Models {
+		models = append(models, schemas.ProviderModel{
+			ID:            model.Name,
+			OwnedBy:       providerName,
+			ContextLength: model.ContextLength,
+		})
+	}
+
+	return models, nil
+}
@@ -117,3 +117,42 @@ type Connectors struct {
 	ContOnFail      string            `json:"continue_on_failure"`
 	Options         map[string]string `json:"options"`
 }
+
+// EmbedRequest is a Cohere-specific embedding request schema
+// Ref: https://docs.cohere.com/reference/embed
+type EmbedRequest struct {
+	Texts     []string `json:"texts"`
+	Model     string   `json:"model"`
+	InputType string   `json:"input_type,omitempty"`
+}
+
+type EmbedResult struct {
+	ID         string      `json:"id"`
+	Embeddings [][]float64 `json:"embeddings"`
+	Texts      []string    `json:"texts"`
+	Meta       Meta        `json:"meta"`
+}
+
+// ModelList
+// Ref: https://docs.cohere.com/reference/list-models
+type ModelList struct {
+	Models []ModelInfo `json:"models"`
+}
+
+type ModelInfo struct {
+	Name          string   `json:"name"`
+	Endpoints     []string `json:"endpoints"`
+	ContextLength int      `json:"context_length"`
+}
+
+// TokenizeRequest
+// Ref: https://docs.cohere.com/reference/tokenize
+type TokenizeRequest struct {
+	Text  string `json:"text"`
+	Model string `json:"model"`
+}
+
+type TokenizeResponse struct {
+	Tokens       []int    `json:"tokens"`
+	TokenStrings []string `json:"token_strings"`
+}
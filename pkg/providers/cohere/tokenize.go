@@ -0,0 +1,62 @@
+package cohere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"glide/pkg/api/schemas"
+)
+
+// CountTokens counts prompt tokens with the Cohere tokenize API.
+// The API tokenizes plain text, so the count doesn't include tokens Cohere adds to wrap chat messages
+func (c *Client) CountTokens(ctx context.Context, request *schemas.TokenizeRequest) (*schemas.TokenizeResponse, error) {
+	messages := request.Messages()
+	texts := make([]string, 0, len(messages))
+
+	for _, message := range messages {
+		texts = append(texts, message.Content)
+	}
+
+	rawPayload, err := json.Marshal(&TokenizeRequest{
+		Text:  strings.Join(texts, "\n"),
+		Model: c.config.Model,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal cohere tokenize request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cohere tokenize request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send cohere tokenize request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	var tokenizeResponse TokenizeResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&tokenizeResponse); err != nil {
+		return nil, fmt.Errorf("unable to parse cohere tokenize response: %w", err)
+	}
+
+	return &schemas.TokenizeResponse{
+		Provider:  providerName,
+		ModelName: c.config.Model,
+		Tokens:    len(tokenizeResponse.Tokens),
+	}, nil
+}
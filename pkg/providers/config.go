@@ -12,23 +12,41 @@ import (
 
 	"glide/pkg/routers/health"
 
+	"glide/pkg/api/schemas"
 	"glide/pkg/providers/anthropic"
 	"glide/pkg/providers/azureopenai"
+	"glide/pkg/providers/classifier"
 	"glide/pkg/providers/cohere"
+	"glide/pkg/providers/deepgram"
 	"glide/pkg/providers/octoml"
 	"glide/pkg/providers/openai"
+	"glide/pkg/providers/stability"
 	"glide/pkg/telemetry"
 )
 
 var ErrProviderNotFound = errors.New("provider not found")
 
 type LangModelConfig struct {
-	ID          string                `yaml:"id" json:"id" validate:"required"`           // Model instance ID (unique in scope of the router)
-	Enabled     bool                  `yaml:"enabled" json:"enabled" validate:"required"` // Is the model enabled?
-	ErrorBudget *health.ErrorBudget   `yaml:"error_budget" json:"error_budget" swaggertype:"primitive,string"`
-	Latency     *latency.Config       `yaml:"latency" json:"latency"`
-	Weight      int                   `yaml:"weight" json:"weight"`
-	Client      *clients.ClientConfig `yaml:"client" json:"client"`
+	ID                  string                            `yaml:"id" json:"id" validate:"required"`           // Model instance ID (unique in scope of the router)
+	Enabled             bool                              `yaml:"enabled" json:"enabled" validate:"required"` // Is the model enabled?
+	ErrorBudget         *health.ErrorBudget               `yaml:"error_budget" json:"error_budget" swaggertype:"primitive,string"`
+	CircuitBreaker      *health.CircuitBreakerConfig      `yaml:"circuit_breaker,omitempty" json:"circuit_breaker,omitempty"` // cuts the model off from traffic once it fails too many requests
+	Probe               *ProbeConfig                      `yaml:"probe,omitempty" json:"probe,omitempty"`                     // actively checks unhealthy models, so they recover without user requests
+	Latency             *latency.Config                   `yaml:"latency" json:"latency"`
+	Weight              int                               `yaml:"weight" json:"weight"`
+	Pricing             *Pricing                          `yaml:"pricing,omitempty" json:"pricing,omitempty"`                                                        // token prices used to estimate request costs
+	Params              *ParamsConfig                     `yaml:"params,omitempty" json:"params,omitempty"`                                                          // generation params overriding the provider default params
+	Deprecation         *DeprecationConfig                `yaml:"deprecation,omitempty" json:"deprecation,omitempty"`                                                // the model is still served, but clients are warned to migrate
+	ContextWindow       int                               `yaml:"context_window,omitempty" json:"context_window,omitempty" validate:"gte=0"`                         // the largest prompt the model accepts in tokens, zero means unknown
+	Schedule            *ScheduleConfig                   `yaml:"schedule,omitempty" json:"schedule,omitempty"`                                                      // time windows the model serves traffic in, always by default
+	RateLimits          *RateLimitConfig                  `yaml:"rate_limits,omitempty" json:"rate_limits,omitempty"`                                                // the advertised provider limits, models close to them are deprioritized
+	Capabilities        []schemas.Capability              `yaml:"capabilities,omitempty" json:"capabilities,omitempty" validate:"dive,oneof=tools vision json_mode"` // features the model supports (e.g. tools), requests depending on them are routed to capable models only
+	Budget              *SpendBudgetConfig                `yaml:"budget,omitempty" json:"budget,omitempty"`                                                          // the model stops being selected once it has spent the daily or monthly budget
+	MaxConcurrency      int                               `yaml:"max_concurrency,omitempty" json:"max_concurrency,omitempty" validate:"gte=0"`                       // the most chat requests the model serves at the same time, zero means no limit
+	AdaptiveConcurrency *health.AdaptiveConcurrencyConfig `yaml:"adaptive_concurrency,omitempty" json:"adaptive_concurrency,omitempty"`                              // the concurrency limit adapts to the provider load, never going above max_concurrency
+	Region              string                            `yaml:"region,omitempty" json:"region,omitempty"`                                                          // the region of the model endpoint, "default" when not set
+	Regions             []*RegionConfig                   `yaml:"regions,omitempty" json:"regions,omitempty" validate:"dive"`                                        // endpoints of the model in other regions, requests fail over to them in the given order
+	Client              *clients.ClientConfig             `yaml:"client" json:"client"`
 	// Add other providers like
 	OpenAI      *openai.Config      `yaml:"openai,omitempty" json:"openai,omitempty"`
 	AzureOpenAI *azureopenai.Config `yaml:"azureopenai,omitempty" json:"azureopenai,omitempty"`
@@ -50,12 +68,76 @@ func DefaultLangModelConfig() *LangModelConfig {
 }
 
 func (c *LangModelConfig) ToModel(tel *telemetry.Telemetry) (*LanguageModel, error) {
-	client, err := c.initClient(tel)
+	tel = tel.Module(telemetry.ModuleProviders)
+
+	client, err := c.withParams().initClient(tel)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing client: %v", err)
 	}
 
-	return NewLangModel(c.ID, client, c.ErrorBudget, *c.Latency, c.Weight), nil
+	if len(c.Regions) > 0 {
+		client, err = c.initRegionalClient(client, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	model := NewLangModel(c.ID, client, c.ErrorBudget, *c.Latency, c.Weight)
+	model.warmer = newConnWarmer(c.ID, client, c.Client, tel)
+	model.pricing = c.Pricing
+	model.params = c.Params
+	model.contextWindow = c.ContextWindow
+	model.capabilities = c.Capabilities
+	model.maxConcurrency = int64(c.MaxConcurrency)
+
+	if c.AdaptiveConcurrency != nil {
+		model.concurrencyLimiter = health.NewConcurrencyLimiter(c.AdaptiveConcurrency)
+	}
+
+	if c.CircuitBreaker != nil {
+		model.healthTracker.WithCircuitBreaker(c.CircuitBreaker)
+	}
+
+	if c.RateLimits != nil {
+		model.rateLimiter = newRateLimiter(c.RateLimits)
+
+		if c.RateLimits.Redis != nil {
+			model.rateLimiter.usage, err = newSharedUsageWindow(client.Provider(), c.ID, c.RateLimits.Redis, tel)
+			if err != nil {
+				return nil, fmt.Errorf("model \"%v\" shared rate limits: %w", c.ID, err)
+			}
+		}
+	}
+
+	if c.Deprecation != nil {
+		model.deprecation = c.Deprecation.Schema(c.ID)
+	}
+
+	if c.Budget != nil {
+		if c.Pricing == nil {
+			return nil, fmt.Errorf("model \"%v\" has a spend budget, but no pricing to calculate request costs with", c.ID)
+		}
+
+		model.spendBudget = NewSpendBudget(c.Budget)
+	}
+
+	if c.Schedule != nil {
+		model.schedule, err = NewSchedule(c.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("model \"%v\": %w", c.ID, err)
+		}
+	}
+
+	if c.Probe != nil {
+		model.prober, err = newProber(model, c.Probe, tel.Module(telemetry.ModuleHealth))
+		if err != nil {
+			return nil, err
+		}
+
+		model.prober.Start()
+	}
+
+	return model, nil
 }
 
 // initClient initializes the language model client based on the provided configuration.
@@ -135,5 +217,279 @@ func (c *LangModelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 		return err
 	}
 
+	if err := c.validateOneProvider(); err != nil {
+		return err
+	}
+
+	return c.validateRegions()
+}
+
+type ImageModelConfig struct {
+	ID             string                       `yaml:"id" json:"id" validate:"required"`           // Model instance ID (unique in scope of the router)
+	Enabled        bool                         `yaml:"enabled" json:"enabled" validate:"required"` // Is the model enabled?
+	ErrorBudget    *health.ErrorBudget          `yaml:"error_budget" json:"error_budget" swaggertype:"primitive,string"`
+	CircuitBreaker *health.CircuitBreakerConfig `yaml:"circuit_breaker,omitempty" json:"circuit_breaker,omitempty"` // cuts the model off from traffic once it fails too many requests
+	Latency        *latency.Config              `yaml:"latency" json:"latency"`
+	Weight         int                          `yaml:"weight" json:"weight"`
+	Client         *clients.ClientConfig        `yaml:"client" json:"client"`
+	// Add other providers like
+	OpenAI    *openai.Config    `yaml:"openai,omitempty" json:"openai,omitempty"`
+	Stability *stability.Config `yaml:"stability,omitempty" json:"stability,omitempty"`
+}
+
+func DefaultImageModelConfig() *ImageModelConfig {
+	return &ImageModelConfig{
+		Enabled:     true,
+		Client:      clients.DefaultClientConfig(),
+		ErrorBudget: health.DefaultErrorBudget(),
+		Latency:     latency.DefaultConfig(),
+		Weight:      1,
+	}
+}
+
+func (c *ImageModelConfig) ToModel(tel *telemetry.Telemetry) (*ImageModel, error) {
+	tel = tel.Module(telemetry.ModuleProviders)
+
+	client, err := c.initClient(tel)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing client: %v", err)
+	}
+
+	model := NewImageModel(c.ID, client, c.ErrorBudget, *c.Latency, c.Weight)
+	model.warmer = newConnWarmer(c.ID, client, c.Client, tel)
+
+	if c.CircuitBreaker != nil {
+		model.healthTracker.WithCircuitBreaker(c.CircuitBreaker)
+	}
+
+	return model, nil
+}
+
+func (c *ImageModelConfig) initClient(tel *telemetry.Telemetry) (ImageProvider, error) {
+	switch {
+	case c.OpenAI != nil:
+		return openai.NewClient(c.OpenAI, c.Client, tel)
+	case c.Stability != nil:
+		return stability.NewClient(c.Stability, c.Client, tel)
+	default:
+		return nil, ErrProviderNotFound
+	}
+}
+
+func (c *ImageModelConfig) validateOneProvider() error {
+	providersConfigured := 0
+
+	if c.OpenAI != nil {
+		providersConfigured++
+	}
+
+	if c.Stability != nil {
+		providersConfigured++
+	}
+
+	if providersConfigured == 0 {
+		return fmt.Errorf("exactly one provider must be configured for model \"%v\", none is configured", c.ID)
+	}
+
+	if providersConfigured > 1 {
+		return fmt.Errorf(
+			"exactly one provider must be configured for model \"%v\", %v are configured",
+			c.ID,
+			providersConfigured,
+		)
+	}
+
+	return nil
+}
+
+func (c *ImageModelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultImageModelConfig()
+
+	type plain ImageModelConfig // to avoid recursion
+
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+
+	return c.validateOneProvider()
+}
+
+type AudioModelConfig struct {
+	ID             string                       `yaml:"id" json:"id" validate:"required"`           // Model instance ID (unique in scope of the router)
+	Enabled        bool                         `yaml:"enabled" json:"enabled" validate:"required"` // Is the model enabled?
+	ErrorBudget    *health.ErrorBudget          `yaml:"error_budget" json:"error_budget" swaggertype:"primitive,string"`
+	CircuitBreaker *health.CircuitBreakerConfig `yaml:"circuit_breaker,omitempty" json:"circuit_breaker,omitempty"` // cuts the model off from traffic once it fails too many requests
+	Latency        *latency.Config              `yaml:"latency" json:"latency"`
+	Weight         int                          `yaml:"weight" json:"weight"`
+	Client         *clients.ClientConfig        `yaml:"client" json:"client"`
+	// Add other providers like
+	OpenAI   *openai.Config   `yaml:"openai,omitempty" json:"openai,omitempty"`
+	Deepgram *deepgram.Config `yaml:"deepgram,omitempty" json:"deepgram,omitempty"`
+}
+
+func DefaultAudioModelConfig() *AudioModelConfig {
+	return &AudioModelConfig{
+		Enabled:     true,
+		Client:      clients.DefaultClientConfig(),
+		ErrorBudget: health.DefaultErrorBudget(),
+		Latency:     latency.DefaultConfig(),
+		Weight:      1,
+	}
+}
+
+func (c *AudioModelConfig) ToModel(tel *telemetry.Telemetry) (*AudioModel, error) {
+	tel = tel.Module(telemetry.ModuleProviders)
+
+	client, err := c.initClient(tel)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing client: %v", err)
+	}
+
+	model := NewAudioModel(c.ID, client, c.ErrorBudget, *c.Latency, c.Weight)
+	model.warmer = newConnWarmer(c.ID, client, c.Client, tel)
+
+	if c.CircuitBreaker != nil {
+		model.healthTracker.WithCircuitBreaker(c.CircuitBreaker)
+	}
+
+	return model, nil
+}
+
+func (c *AudioModelConfig) initClient(tel *telemetry.Telemetry) (TranscriptionProvider, error) {
+	switch {
+	case c.OpenAI != nil:
+		return openai.NewClient(c.OpenAI, c.Client, tel)
+	case c.Deepgram != nil:
+		return deepgram.NewClient(c.Deepgram, c.Client, tel)
+	default:
+		return nil, ErrProviderNotFound
+	}
+}
+
+func (c *AudioModelConfig) validateOneProvider() error {
+	providersConfigured := 0
+
+	if c.OpenAI != nil {
+		providersConfigured++
+	}
+
+	if c.Deepgram != nil {
+		providersConfigured++
+	}
+
+	if providersConfigured == 0 {
+		return fmt.Errorf("exactly one provider must be configured for model \"%v\", none is configured", c.ID)
+	}
+
+	if providersConfigured > 1 {
+		return fmt.Errorf(
+			"exactly one provider must be configured for model \"%v\", %v are configured",
+			c.ID,
+			providersConfigured,
+		)
+	}
+
+	return nil
+}
+
+func (c *AudioModelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultAudioModelConfig()
+
+	type plain AudioModelConfig // to avoid recursion
+
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+
+	return c.validateOneProvider()
+}
+
+type ModerationModelConfig struct {
+	ID             string                       `yaml:"id" json:"id" validate:"required"`           // Model instance ID (unique in scope of the router)
+	Enabled        bool                         `yaml:"enabled" json:"enabled" validate:"required"` // Is the model enabled?
+	ErrorBudget    *health.ErrorBudget          `yaml:"error_budget" json:"error_budget" swaggertype:"primitive,string"`
+	CircuitBreaker *health.CircuitBreakerConfig `yaml:"circuit_breaker,omitempty" json:"circuit_breaker,omitempty"` // cuts the model off from traffic once it fails too many requests
+	Latency        *latency.Config              `yaml:"latency" json:"latency"`
+	Weight         int                          `yaml:"weight" json:"weight"`
+	Client         *clients.ClientConfig        `yaml:"client" json:"client"`
+	// Add other providers like
+	OpenAI     *openai.Config     `yaml:"openai,omitempty" json:"openai,omitempty"`
+	Classifier *classifier.Config `yaml:"classifier,omitempty" json:"classifier,omitempty"`
+}
+
+func DefaultModerationModelConfig() *ModerationModelConfig {
+	return &ModerationModelConfig{
+		Enabled:     true,
+		Client:      clients.DefaultClientConfig(),
+		ErrorBudget: health.DefaultErrorBudget(),
+		Latency:     latency.DefaultConfig(),
+		Weight:      1,
+	}
+}
+
+func (c *ModerationModelConfig) ToModel(tel *telemetry.Telemetry) (*ModerationModel, error) {
+	tel = tel.Module(telemetry.ModuleProviders)
+
+	client, err := c.initClient(tel)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing client: %v", err)
+	}
+
+	model := NewModerationModel(c.ID, client, c.ErrorBudget, *c.Latency, c.Weight)
+	model.warmer = newConnWarmer(c.ID, client, c.Client, tel)
+
+	if c.CircuitBreaker != nil {
+		model.healthTracker.WithCircuitBreaker(c.CircuitBreaker)
+	}
+
+	return model, nil
+}
+
+func (c *ModerationModelConfig) initClient(tel *telemetry.Telemetry) (ModerationProvider, error) {
+	switch {
+	case c.OpenAI != nil:
+		return openai.NewClient(c.OpenAI, c.Client, tel)
+	case c.Classifier != nil:
+		return classifier.NewClient(c.Classifier, c.Client, tel)
+	default:
+		return nil, ErrProviderNotFound
+	}
+}
+
+func (c *ModerationModelConfig) validateOneProvider() error {
+	providersConfigured := 0
+
+	if c.OpenAI != nil {
+		providersConfigured++
+	}
+
+	if c.Classifier != nil {
+		providersConfigured++
+	}
+
+	if providersConfigured == 0 {
+		return fmt.Errorf("exactly one provider must be configured for model \"%v\", none is configured", c.ID)
+	}
+
+	if providersConfigured > 1 {
+		return fmt.Errorf(
+			"exactly one provider must be configured for model \"%v\", %v are configured",
+			c.ID,
+			providersConfigured,
+		)
+	}
+
+	return nil
+}
+
+func (c *ModerationModelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultModerationModelConfig()
+
+	type plain ModerationModelConfig // to avoid recursion
+
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+
 	return c.validateOneProvider()
 }
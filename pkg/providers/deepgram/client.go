@@ -0,0 +1,57 @@
+package deepgram
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+const (
+	providerName = "deepgram"
+)
+
+// ErrEmptyResponse is returned when the Deepgram API returns an empty response.
+var (
+	ErrEmptyResponse = clients.ErrEmptyResponse
+)
+
+// Client is a client for accessing Deepgram API
+type Client struct {
+	baseURL    string
+	listenURL  string
+	errMapper  *ErrorMapper
+	config     *Config
+	httpClient *http.Client
+	tel        *telemetry.Telemetry
+}
+
+// NewClient creates a new Deepgram client for the Deepgram API.
+func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *telemetry.Telemetry) (*Client, error) {
+	listenURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ListenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:    providerConfig.BaseURL,
+		listenURL:  listenURL,
+		config:     providerConfig,
+		errMapper:  NewErrorMapper(tel),
+		httpClient: clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		tel:        tel,
+	}
+
+	return c, nil
+}
+
+func (c *Client) Provider() string {
+	return providerName
+}
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
@@ -0,0 +1,59 @@
+package deepgram
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestDeepgramClient_TranscribeRequest(t *testing.T) {
+	deepgramMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		audio, _ := io.ReadAll(r.Body)
+
+		require.Equal(t, "/listen", r.URL.Path)
+		require.Equal(t, "nova-2", r.URL.Query().Get("model"))
+		require.Equal(t, "audio/wav", r.Header.Get("Content-Type"))
+		require.Equal(t, "RIFF", string(audio))
+
+		transcriptionResponse, err := os.ReadFile(filepath.Clean("./testdata/transcription.success.json"))
+		if err != nil {
+			t.Errorf("error reading deepgram transcription mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(transcriptionResponse)
+		if err != nil {
+			t.Errorf("error on sending transcription response: %v", err)
+		}
+	})
+
+	deepgramServer := httptest.NewServer(deepgramMock)
+	defer deepgramServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = deepgramServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Transcribe(context.Background(), &schemas.TranscriptionRequest{
+		File:        []byte("RIFF"),
+		Filename:    "speech.wav",
+		ContentType: "audio/wav",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "a847f427-4ad5-4d67-9b95-db801e58251c", response.ID)
+	require.Len(t, response.ModelResponse.Segments, 2)
+	require.Equal(t, 1.52, response.ModelResponse.Segments[1].Start)
+}
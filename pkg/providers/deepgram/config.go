@@ -0,0 +1,55 @@
+package deepgram
+
+import (
+	"glide/pkg/config/fields"
+)
+
+// Params defines Deepgram-specific model params
+type Params struct {
+	SmartFormat bool `yaml:"smart_format,omitempty" json:"smart_format"`
+	Punctuate   bool `yaml:"punctuate,omitempty" json:"punctuate"`
+	DetectLang  bool `yaml:"detect_language,omitempty" json:"detect_language"`
+}
+
+func DefaultParams() Params {
+	return Params{
+		SmartFormat: true,
+		Punctuate:   true,
+	}
+}
+
+func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*p = DefaultParams()
+
+	type plain Params // to avoid recursion
+
+	return unmarshal((*plain)(p))
+}
+
+type Config struct {
+	BaseURL        string        `yaml:"base_url" json:"baseUrl" validate:"required,http_url"`
+	ListenEndpoint string        `yaml:"listen_endpoint" json:"listenEndpoint" validate:"required"`
+	Model          string        `yaml:"model" json:"model" validate:"required"` // https://developers.deepgram.com/docs/models-languages-overview
+	APIKey         fields.Secret `yaml:"api_key" json:"-" validate:"required"`
+	DefaultParams  *Params       `yaml:"default_params,omitempty" json:"defaultParams"`
+}
+
+// DefaultConfig for Deepgram models
+func DefaultConfig() *Config {
+	defaultParams := DefaultParams()
+
+	return &Config{
+		BaseURL:        "https://api.deepgram.com/v1",
+		ListenEndpoint: "/listen",
+		Model:          "nova-2",
+		DefaultParams:  &defaultParams,
+	}
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -0,0 +1,37 @@
+package deepgram
+
+// Deepgram Pre-recorded Audio Response
+// Ref: https://developers.deepgram.com/reference/listen-file
+type ListenResult struct {
+	Metadata Metadata `json:"metadata"`
+	Results  Results  `json:"results"`
+}
+
+type Metadata struct {
+	RequestID string  `json:"request_id"`
+	Created   string  `json:"created"`
+	Duration  float64 `json:"duration"`
+	Channels  int     `json:"channels"`
+}
+
+type Results struct {
+	Channels   []Channel   `json:"channels"`
+	Utterances []Utterance `json:"utterances"`
+}
+
+type Channel struct {
+	Alternatives     []Alternative `json:"alternatives"`
+	DetectedLanguage string        `json:"detected_language"`
+}
+
+type Alternative struct {
+	Transcript string  `json:"transcript"`
+	Confidence float64 `json:"confidence"`
+}
+
+type Utterance struct {
+	ID         string  `json:"id"`
+	Start      float64 `json:"start"`
+	End        float64 `json:"end"`
+	Transcript string  `json:"transcript"`
+}
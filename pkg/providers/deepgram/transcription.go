@@ -0,0 +1,111 @@
+package deepgram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// Transcribe sends a pre-recorded audio to the configured Deepgram model.
+func (c *Client) Transcribe(ctx context.Context, request *schemas.TranscriptionRequest) (*schemas.TranscriptionResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.listenURL, bytes.NewReader(request.File))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create deepgram transcription request: %w", err)
+	}
+
+	req.URL.RawQuery = c.createQuery(request).Encode()
+
+	contentType := request.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	req.Header.Set("Authorization", "Token "+string(c.config.APIKey))
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send deepgram transcription request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tel.Logger.Error("failed to read deepgram transcription response", zap.Error(err))
+		return nil, err
+	}
+
+	var listenResult ListenResult
+
+	err = json.Unmarshal(bodyBytes, &listenResult)
+	if err != nil {
+		c.tel.Logger.Error("failed to parse deepgram transcription response", zap.Error(err))
+		return nil, err
+	}
+
+	channels := listenResult.Results.Channels
+	if len(channels) == 0 || len(channels[0].Alternatives) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	segments := make([]schemas.TranscriptionSegment, 0, len(listenResult.Results.Utterances))
+
+	for idx, utterance := range listenResult.Results.Utterances {
+		segments = append(segments, schemas.TranscriptionSegment{
+			ID:    idx,
+			Start: utterance.Start,
+			End:   utterance.End,
+			Text:  utterance.Transcript,
+		})
+	}
+
+	language := channels[0].DetectedLanguage
+	if language == "" {
+		language = request.Language
+	}
+
+	return &schemas.TranscriptionResponse{
+		ID:        listenResult.Metadata.RequestID,
+		Created:   int(time.Now().UTC().Unix()),
+		Provider:  providerName,
+		ModelName: c.config.Model,
+		ModelResponse: schemas.TranscriptionModelResponse{
+			Text:     channels[0].Alternatives[0].Transcript,
+			Language: language,
+			Duration: listenResult.Metadata.Duration,
+			Segments: segments,
+		},
+	}, nil
+}
+
+func (c *Client) createQuery(request *schemas.TranscriptionRequest) url.Values {
+	params := c.config.DefaultParams
+	query := url.Values{}
+
+	query.Set("model", c.config.Model)
+	query.Set("utterances", "true")
+	query.Set("smart_format", strconv.FormatBool(params.SmartFormat))
+	query.Set("punctuate", strconv.FormatBool(params.Punctuate))
+
+	if request.Language != "" {
+		query.Set("language", request.Language)
+	} else if params.DetectLang {
+		query.Set("detect_language", "true")
+	}
+
+	return query
+}
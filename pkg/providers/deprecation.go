@@ -0,0 +1,21 @@
+package providers
+
+import "glide/pkg/api/schemas"
+
+// DeprecationConfig flags the model as deprecated. Deprecated models are still served,
+// but clients are warned about the sunset date & the suggested replacement, so they have time to migrate
+type DeprecationConfig struct {
+	Sunset      string `yaml:"sunset,omitempty" json:"sunset,omitempty" validate:"omitempty,datetime=2006-01-02"` // the date (YYYY-MM-DD) the model stops being served
+	Replacement string `yaml:"replacement,omitempty" json:"replacement,omitempty"`                                // the model clients should migrate to
+	Message     string `yaml:"message,omitempty" json:"message,omitempty"`                                        // extra migration notes for clients
+}
+
+// Schema builds the deprecation notice clients receive along with responses of the model
+func (c *DeprecationConfig) Schema(modelID string) *schemas.ModelDeprecation {
+	return &schemas.ModelDeprecation{
+		ModelID:     modelID,
+		Sunset:      c.Sunset,
+		Replacement: c.Replacement,
+		Message:     c.Message,
+	}
+}
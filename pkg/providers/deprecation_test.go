@@ -0,0 +1,27 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/openai"
+	"glide/pkg/telemetry"
+)
+
+func TestLangModelConfig_Deprecation(t *testing.T) {
+	modelConfig := DefaultLangModelConfig()
+	modelConfig.ID = "gpt3"
+	modelConfig.OpenAI = openai.DefaultConfig()
+	modelConfig.OpenAI.APIKey = "ABC"
+
+	model, err := modelConfig.ToModel(telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.Nil(t, model.Deprecation())
+
+	modelConfig.Deprecation = &DeprecationConfig{Sunset: "2024-12-31", Replacement: "gpt4"}
+
+	model, err = modelConfig.ToModel(telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.Equal(t, &schemas.ModelDeprecation{ModelID: "gpt3", Sunset: "2024-12-31", Replacement: "gpt4"}, model.Deprecation())
+}
@@ -0,0 +1,28 @@
+package providers
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+)
+
+// ImageProvider defines an interface a provider should fulfill to be able to serve image generation requests
+type ImageProvider interface {
+	ModelProvider
+
+	GenerateImage(ctx context.Context, req *schemas.ImageGenerateRequest) (*schemas.ImageGenerateResponse, error)
+}
+
+// ImageModel serves image generation requests
+type ImageModel = SingleActionModel[*schemas.ImageGenerateRequest, *schemas.ImageGenerateResponse]
+
+func NewImageModel(modelID string, client ImageProvider, budget *health.ErrorBudget, latencyConfig latency.Config, weight int) *ImageModel {
+	return newSingleActionModel(modelID, client, client.GenerateImage, imageUnits, budget, latencyConfig, weight)
+}
+
+// imageUnits records latency per image to normalize measurements
+func imageUnits(_ *schemas.ImageGenerateRequest, resp *schemas.ImageGenerateResponse) float64 {
+	return float64(len(resp.ModelResponse.Images))
+}
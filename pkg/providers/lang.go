@@ -3,6 +3,8 @@ package providers
 import (
 	"context"
 	"io"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"glide/pkg/config/fields"
@@ -19,9 +21,11 @@ type LangProvider interface {
 	ModelProvider
 
 	SupportChatStream() bool
+	SupportEmbed() bool
 
 	Chat(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error)
 	ChatStream(ctx context.Context, req *schemas.ChatStreamRequest) (clients.ChatStream, error)
+	Embed(ctx context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error)
 }
 
 type LangModel interface {
@@ -29,6 +33,8 @@ type LangModel interface {
 	Provider() string
 	Chat(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error)
 	ChatStream(ctx context.Context, req *schemas.ChatStreamRequest) (<-chan *clients.ChatStreamResult, error)
+	Embed(ctx context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error)
+	Deprecation() *schemas.ModelDeprecation
 }
 
 // LanguageModel wraps provider client and expend it with health & latency tracking
@@ -42,7 +48,27 @@ type LanguageModel struct {
 	healthTracker         *health.Tracker
 	chatLatency           *latency.MovingAverage
 	chatStreamLatency     *latency.MovingAverage
+	embedLatency          *latency.MovingAverage
+	chatQuantiles         *latency.Quantiles
+	chatStreamQuantiles   *latency.Quantiles
+	embedQuantiles        *latency.Quantiles
+	ttft                  *latency.MovingAverage
+	ttftQuantiles         *latency.Quantiles
+	tokenRate             *latency.MovingAverage
 	latencyUpdateInterval *fields.Duration
+	warmer                *clients.ConnWarmer
+	prober                *prober
+	pricing               *Pricing
+	spendBudget           *SpendBudget
+	params                *ParamsConfig
+	deprecation           *schemas.ModelDeprecation
+	contextWindow         int
+	capabilities          []schemas.Capability
+	schedule              *Schedule
+	rateLimiter           *rateLimiter
+	inFlight              *atomic.Int64
+	maxConcurrency        int64
+	concurrencyLimiter    *health.ConcurrencyLimiter
 }
 
 func NewLangModel(modelID string, client LangProvider, budget *health.ErrorBudget, latencyConfig latency.Config, weight int) *LanguageModel {
@@ -52,8 +78,16 @@ func NewLangModel(modelID string, client LangProvider, budget *health.ErrorBudge
 		healthTracker:         health.NewTracker(budget),
 		chatLatency:           latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
 		chatStreamLatency:     latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		embedLatency:          latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		chatQuantiles:         latency.NewQuantiles(latencyConfig.Window, latencyConfig.WarmupSamples),
+		chatStreamQuantiles:   latency.NewQuantiles(latencyConfig.Window, latencyConfig.WarmupSamples),
+		embedQuantiles:        latency.NewQuantiles(latencyConfig.Window, latencyConfig.WarmupSamples),
+		ttft:                  latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		ttftQuantiles:         latency.NewQuantiles(latencyConfig.Window, latencyConfig.WarmupSamples),
+		tokenRate:             latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
 		latencyUpdateInterval: latencyConfig.UpdateInterval,
 		weight:                weight,
+		inFlight:              &atomic.Int64{},
 	}
 }
 
@@ -61,14 +95,102 @@ func (m LanguageModel) ID() string {
 	return m.modelID
 }
 
+// Healthy checks if the model can serve requests at the moment.
+// Models are not picked outside of their schedules the same way as unhealthy models are not
 func (m LanguageModel) Healthy() bool {
-	return m.healthTracker.Healthy()
+	return m.healthTracker.Healthy() && m.Active() && !m.spendBudget.Exhausted()
+}
+
+// Active checks if the model schedule allows it to serve traffic at the moment
+func (m LanguageModel) Active() bool {
+	return m.schedule == nil || m.schedule.Active(time.Now())
 }
 
 func (m LanguageModel) Weight() int {
 	return m.weight
 }
 
+// TrackSpend records the cost of the served request against the model spend budget.
+// It returns true when the request has just exhausted the budget, so the model stops being selected
+func (m *LanguageModel) TrackSpend(usage schemas.TokenUsage) bool {
+	if m.pricing == nil {
+		return false
+	}
+
+	return m.spendBudget.Track(m.pricing.Cost(usage))
+}
+
+// SpendBudget returns the model spend budget or nil if it's not configured
+func (m LanguageModel) SpendBudget() *SpendBudget {
+	return m.spendBudget
+}
+
+// Pricing returns the model token prices or nil if they are not configured
+func (m LanguageModel) Pricing() *Pricing {
+	return m.pricing
+}
+
+// Params returns the generation params the model overrides or nil if there are none
+func (m LanguageModel) Params() *ParamsConfig {
+	return m.params
+}
+
+// Deprecation returns the deprecation notice or nil if the model is not deprecated
+func (m LanguageModel) Deprecation() *schemas.ModelDeprecation {
+	return m.deprecation
+}
+
+// InFlight returns the number of requests the model is serving at the moment
+func (m LanguageModel) InFlight() int64 {
+	return m.inFlight.Load()
+}
+
+// Saturated checks if the model is serving as many requests as its concurrency limit allows.
+// The limit is checked when the model is picked, so it's not exceeded by more than requests picking the model at the same moment
+func (m LanguageModel) Saturated() bool {
+	limit := m.ConcurrencyLimit()
+
+	return limit > 0 && m.inFlight.Load() >= limit
+}
+
+// ConcurrencyLimit returns how many requests the model may serve at the same time at the moment, zero means no limit.
+// The adaptive limit never goes above the static one
+func (m LanguageModel) ConcurrencyLimit() int64 {
+	limit := int64(m.concurrencyLimiter.Limit())
+
+	if m.maxConcurrency > 0 && (limit == 0 || m.maxConcurrency < limit) {
+		limit = m.maxConcurrency
+	}
+
+	return limit
+}
+
+// ErrorRate returns the share of requests the model failed over the last minute
+func (m LanguageModel) ErrorRate() float64 {
+	return m.healthTracker.ErrorRate()
+}
+
+// RateLimitUsage returns the largest share of the advertised rate limits the model has used over the last minute
+func (m LanguageModel) RateLimitUsage() float64 {
+	return m.rateLimiter.Usage()
+}
+
+// NearRateLimit checks if the model is close to or over its advertised rate limits,
+// so routing may prefer other models before the provider starts rejecting requests
+func (m LanguageModel) NearRateLimit() bool {
+	return m.rateLimiter.NearLimit()
+}
+
+// ContextWindow returns the largest prompt the model accepts in tokens or zero if it's unknown
+func (m LanguageModel) ContextWindow() int {
+	return m.contextWindow
+}
+
+// FitsContextWindow checks if the prompt of the given size is accepted by the model
+func (m LanguageModel) FitsContextWindow(promptTokens int) bool {
+	return m.contextWindow == 0 || promptTokens <= m.contextWindow
+}
+
 func (m LanguageModel) LatencyUpdateInterval() *fields.Duration {
 	return m.latencyUpdateInterval
 }
@@ -77,6 +199,103 @@ func (m *LanguageModel) SupportChatStream() bool {
 	return m.client.SupportChatStream()
 }
 
+func (m *LanguageModel) SupportEmbed() bool {
+	return m.client.SupportEmbed()
+}
+
+func (m *LanguageModel) Capabilities() schemas.ModelCapabilities {
+	return schemas.ModelCapabilities{
+		Chat:       true,
+		ChatStream: m.client.SupportChatStream(),
+		Embed:      m.client.SupportEmbed(),
+		Tools:      slices.Contains(m.capabilities, schemas.CapabilityTools),
+		Vision:     slices.Contains(m.capabilities, schemas.CapabilityVision),
+		JSONMode:   slices.Contains(m.capabilities, schemas.CapabilityJSONMode),
+	}
+}
+
+// Supports checks if the model supports all the required capabilities
+func (m *LanguageModel) Supports(required []schemas.Capability) bool {
+	for _, capability := range required {
+		if !slices.Contains(m.capabilities, capability) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListModels lists models available in the upstream provider (if the provider supports that)
+func (m *LanguageModel) ListModels(ctx context.Context) ([]schemas.ProviderModel, error) {
+	lister, ok := m.client.(ModelLister)
+	if !ok {
+		return nil, clients.ErrModelListNotImplemented
+	}
+
+	return lister.ListModels(ctx)
+}
+
+// CountTokens counts prompt tokens with the provider tokenizer falling back to an estimation if the provider has none
+func (m *LanguageModel) CountTokens(ctx context.Context, req *schemas.TokenizeRequest) (*schemas.TokenizeResponse, error) {
+	counter, ok := m.client.(TokenCounter)
+	if !ok {
+		return &schemas.TokenizeResponse{
+			Provider:  m.Provider(),
+			ModelID:   m.modelID,
+			Tokens:    clients.EstimateTokens(req.Messages()),
+			Estimated: true,
+		}, nil
+	}
+
+	resp, err := counter.CountTokens(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.ModelID = m.modelID
+
+	return resp, nil
+}
+
+// Health returns the current health state & latencies of the model
+func (m *LanguageModel) Health() schemas.ModelHealth {
+	modelHealth := schemas.ModelHealth{
+		ModelID:          m.modelID,
+		Provider:         m.Provider(),
+		Healthy:          m.healthTracker.Healthy(),
+		Active:           m.Active(),
+		Unauthorized:     m.healthTracker.Unauthorized(),
+		ErrorBudgetLeft:  m.healthTracker.ErrBudgetLeft(),
+		Weight:           m.weight,
+		InFlight:         m.inFlight.Load(),
+		Saturated:        m.Saturated(),
+		ConcurrencyLimit: m.ConcurrencyLimit(),
+		RateLimitHits:    m.healthTracker.RateLimitHits(),
+		ErrorRate:        m.healthTracker.ErrorRate(),
+		RateLimitUsage:   m.rateLimiter.Usage(),
+		BudgetExhausted:  m.spendBudget.Exhausted(),
+		CircuitState:     string(m.healthTracker.CircuitState()),
+		LastProbe:        m.prober.Status(),
+		Latency: schemas.ModelLatency{
+			Chat:            m.chatLatency.Value(),
+			ChatStream:      m.chatStreamLatency.Value(),
+			Embed:           m.embedLatency.Value(),
+			TTFT:            m.ttft.Value(),
+			TokensPerSecond: m.tokenRate.Value(),
+		},
+	}
+
+	if limitedUntil := m.healthTracker.RateLimitedUntil(); limitedUntil != nil {
+		modelHealth.RateLimitedUntil = int(limitedUntil.UTC().Unix())
+	}
+
+	if regional, ok := m.client.(*regionalProvider); ok {
+		modelHealth.Regions = regional.Health()
+	}
+
+	return modelHealth
+}
+
 func (m LanguageModel) ChatLatency() *latency.MovingAverage {
 	return m.chatLatency
 }
@@ -85,18 +304,93 @@ func (m LanguageModel) ChatStreamLatency() *latency.MovingAverage {
 	return m.chatStreamLatency
 }
 
+func (m LanguageModel) EmbedLatency() *latency.MovingAverage {
+	return m.embedLatency
+}
+
+// TTFT returns the moving average time to the first token of streaming chats
+func (m LanguageModel) TTFT() *latency.MovingAverage {
+	return m.ttft
+}
+
+// TTFTQuantiles returns percentiles of the time to the first token of streaming chats
+func (m LanguageModel) TTFTQuantiles() *latency.Quantiles {
+	return m.ttftQuantiles
+}
+
+// TokenRate returns the moving average number of tokens per second streaming chats generate after the first token
+func (m LanguageModel) TokenRate() *latency.MovingAverage {
+	return m.tokenRate
+}
+
+// Latency returns the moving average latency of the given action
+func (m LanguageModel) Latency(action Action) *latency.MovingAverage {
+	switch action {
+	case ChatStreamAction:
+		return m.chatStreamLatency
+	case EmbedAction:
+		return m.embedLatency
+	default:
+		return m.chatLatency
+	}
+}
+
+// Quantiles returns latency percentiles of the given action
+func (m LanguageModel) Quantiles(action Action) *latency.Quantiles {
+	switch action {
+	case ChatStreamAction:
+		return m.chatStreamQuantiles
+	case EmbedAction:
+		return m.embedQuantiles
+	default:
+		return m.chatQuantiles
+	}
+}
+
+func (m LanguageModel) ChatQuantiles() *latency.Quantiles {
+	return m.chatQuantiles
+}
+
+func (m LanguageModel) ChatStreamQuantiles() *latency.Quantiles {
+	return m.chatStreamQuantiles
+}
+
+func (m LanguageModel) EmbedQuantiles() *latency.Quantiles {
+	return m.embedQuantiles
+}
+
 func (m *LanguageModel) Chat(ctx context.Context, request *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	if err := m.healthTracker.Allow(); err != nil {
+		return nil, err
+	}
+
+	m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+
+	if m.rateLimiter != nil {
+		m.rateLimiter.Track(1, clients.EstimateTokens(append(slices.Clone(request.MessageHistory), request.Message)))
+	}
+
 	startedAt := time.Now()
 
 	resp, err := m.client.Chat(ctx, request)
 	if err != nil {
-		m.healthTracker.TrackErr(err)
+		m.healthTracker.TrackErr(healthErr(ctx, err))
+		m.concurrencyLimiter.TrackErr(healthErr(ctx, err))
 
 		return resp, err
 	}
 
+	m.warmer.Touch()
+	m.healthTracker.TrackSuccess()
+	m.rateLimiter.Track(0, resp.ModelResponse.TokenUsage.ResponseTokens)
+
 	// record latency per token to normalize measurements
-	m.chatLatency.Add(float64(time.Since(startedAt)) / float64(resp.ModelResponse.TokenUsage.ResponseTokens))
+	tokenLatency := float64(time.Since(startedAt)) / float64(resp.ModelResponse.TokenUsage.ResponseTokens)
+
+	m.concurrencyLimiter.TrackSuccess(m.inFlight.Load(), tokenLatency, m.chatLatency.Value())
+	m.chatLatency.Add(tokenLatency)
+	m.chatQuantiles.Add(tokenLatency)
 
 	// successful response
 	resp.ModelID = m.modelID
@@ -105,9 +399,23 @@ func (m *LanguageModel) Chat(ctx context.Context, request *schemas.ChatRequest)
 }
 
 func (m *LanguageModel) ChatStream(ctx context.Context, req *schemas.ChatStreamRequest) (<-chan *clients.ChatStreamResult, error) {
+	if err := m.healthTracker.Allow(); err != nil {
+		return nil, err
+	}
+
+	m.inFlight.Add(1)
+
+	if m.rateLimiter != nil {
+		m.rateLimiter.Track(1, clients.EstimateTokens(append(slices.Clone(req.MessageHistory), req.Message)))
+	}
+
+	requestedAt := time.Now()
+
 	stream, err := m.client.ChatStream(ctx, req)
 	if err != nil {
-		m.healthTracker.TrackErr(err)
+		m.inFlight.Add(-1)
+		m.healthTracker.TrackErr(healthErr(ctx, err))
+		m.concurrencyLimiter.TrackErr(healthErr(ctx, err))
 
 		return nil, err
 	}
@@ -116,11 +424,16 @@ func (m *LanguageModel) ChatStream(ctx context.Context, req *schemas.ChatStreamR
 	err = stream.Open()
 	chunkLatency := time.Since(startedAt)
 
+	m.warmer.Touch()
+
 	// the first chunk latency
 	m.chatStreamLatency.Add(float64(chunkLatency))
+	m.chatStreamQuantiles.Add(float64(chunkLatency))
 
 	if err != nil {
-		m.healthTracker.TrackErr(err)
+		m.inFlight.Add(-1)
+		m.healthTracker.TrackErr(healthErr(ctx, err))
+		m.concurrencyLimiter.TrackErr(healthErr(ctx, err))
 
 		// if connection was not even open, we should not send our clients any messages about this failure
 
@@ -130,9 +443,21 @@ func (m *LanguageModel) ChatStream(ctx context.Context, req *schemas.ChatStreamR
 	streamResultC := make(chan *clients.ChatStreamResult)
 
 	go func() {
+		defer m.inFlight.Add(-1) // the stream is in flight until it's fully read
 		defer close(streamResultC)
+		defer func() {
+			// the goroutine is out of reach of the panic recovery middleware, so the panic ends the stream with an error
+			if value := recover(); value != nil {
+				streamResultC <- clients.NewChatStreamResult(nil, NewStreamPanic(value))
+			}
+		}()
 		defer stream.Close()
 
+		var (
+			firstTokenAt time.Time
+			tokens       int
+		)
+
 		for {
 			startedAt = time.Now()
 			chunk, err := stream.Recv()
@@ -141,18 +466,36 @@ func (m *LanguageModel) ChatStream(ctx context.Context, req *schemas.ChatStreamR
 			if err != nil {
 				if err == io.EOF {
 					// end of the stream
+					m.healthTracker.TrackSuccess()
+					m.trackTokenRate(firstTokenAt, tokens)
+
 					return
 				}
 
 				streamResultC <- clients.NewChatStreamResult(nil, err)
 
-				m.healthTracker.TrackErr(err)
+				m.healthTracker.TrackErr(healthErr(ctx, err))
 
 				return
 			}
 
 			chunk.ModelID = m.modelID
 
+			if content := chunk.ModelResponse.Message.Content; content != "" {
+				if firstTokenAt.IsZero() {
+					firstTokenAt = time.Now()
+
+					// the time to the first token includes connecting & sending the request,
+					// so it's what users of interactive apps wait for before they see anything
+					ttft := float64(firstTokenAt.Sub(requestedAt))
+
+					m.ttft.Add(ttft)
+					m.ttftQuantiles.Add(ttft)
+				}
+
+				tokens += clients.EstimateTextTokens(content)
+			}
+
 			streamResultC <- clients.NewChatStreamResult(chunk, nil)
 
 			if chunkLatency > 1*time.Millisecond {
@@ -161,6 +504,7 @@ func (m *LanguageModel) ChatStream(ctx context.Context, req *schemas.ChatStreamR
 				//  So we assume that if we spent more than 1ms waiting for a chunk it's likely
 				//  we were trying to read from the connection (otherwise, it would take nanoseconds)
 				m.chatStreamLatency.Add(float64(chunkLatency))
+				m.chatStreamQuantiles.Add(float64(chunkLatency))
 			}
 		}
 	}()
@@ -168,14 +512,94 @@ func (m *LanguageModel) ChatStream(ctx context.Context, req *schemas.ChatStreamR
 	return streamResultC, nil
 }
 
+// trackTokenRate records how fast the model has generated the streamed response after its first token
+func (m *LanguageModel) trackTokenRate(firstTokenAt time.Time, tokens int) {
+	if firstTokenAt.IsZero() {
+		return
+	}
+
+	generationTime := time.Since(firstTokenAt)
+
+	if generationTime <= 0 || tokens <= 1 {
+		return
+	}
+
+	m.tokenRate.Add(float64(tokens-1) / generationTime.Seconds())
+}
+
+func (m *LanguageModel) Embed(ctx context.Context, request *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	if err := m.healthTracker.Allow(); err != nil {
+		return nil, err
+	}
+
+	m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+
+	m.rateLimiter.Track(1, 0)
+
+	startedAt := time.Now()
+
+	resp, err := m.client.Embed(ctx, request)
+	if err != nil {
+		m.healthTracker.TrackErr(healthErr(ctx, err))
+		m.concurrencyLimiter.TrackErr(healthErr(ctx, err))
+
+		return resp, err
+	}
+
+	m.warmer.Touch()
+	m.healthTracker.TrackSuccess()
+	m.rateLimiter.Track(0, resp.ModelResponse.TokenUsage.PromptTokens)
+
+	// record latency per input token to normalize measurements
+	tokenLatency := float64(time.Since(startedAt)) / float64(max(resp.ModelResponse.TokenUsage.PromptTokens, 1))
+
+	m.concurrencyLimiter.TrackSuccess(m.inFlight.Load(), tokenLatency, m.embedLatency.Value())
+	m.embedLatency.Add(tokenLatency)
+	m.embedQuantiles.Add(tokenLatency)
+
+	resp.ModelID = m.modelID
+
+	return resp, err
+}
+
 func (m *LanguageModel) Provider() string {
 	return m.client.Provider()
 }
 
-func ChatLatency(model Model) *latency.MovingAverage {
-	return model.(LanguageModel).ChatLatency()
+// Shutdown stops background activities of the model
+func (m *LanguageModel) Shutdown() {
+	m.warmer.Stop()
+	m.prober.Stop()
+	m.rateLimiter.Stop()
+}
+
+func LatencyQuantiles(model Model, action Action) *latency.Quantiles {
+	return model.(*LanguageModel).Quantiles(action)
+}
+
+// TTFT returns the moving average time to the first token of the model streaming chats regardless of the action
+func TTFT(model Model, _ Action) *latency.MovingAverage {
+	return model.(*LanguageModel).TTFT()
+}
+
+// TTFTQuantiles returns percentiles of the time to the first token of the model streaming chats regardless of the action
+func TTFTQuantiles(model Model, _ Action) *latency.Quantiles {
+	return model.(*LanguageModel).TTFTQuantiles()
+}
+
+func InFlight(model Model) int64 {
+	return model.(*LanguageModel).InFlight()
+}
+
+func ErrorRate(model Model) float64 {
+	return model.(*LanguageModel).ErrorRate()
+}
+
+func NearRateLimit(model Model) bool {
+	return model.(*LanguageModel).NearRateLimit()
 }
 
-func ChatStreamLatency(model Model) *latency.MovingAverage {
-	return model.(LanguageModel).ChatStreamLatency()
+func ModelPricing(model Model) *Pricing {
+	return model.(*LanguageModel).Pricing()
 }
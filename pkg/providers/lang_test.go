@@ -0,0 +1,188 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+)
+
+type timedChunk struct {
+	content string
+	delay   time.Duration
+	panic   any // panics instead of returning the chunk
+}
+
+// timedStreamMock streams chunks each after its delay
+type timedStreamMock struct {
+	chunks []timedChunk
+}
+
+func (p *timedStreamMock) Provider() string { return "timed_stream_mock" }
+
+func (p *timedStreamMock) SupportChatStream() bool { return true }
+
+func (p *timedStreamMock) SupportEmbed() bool { return false }
+
+func (p *timedStreamMock) Chat(_ context.Context, _ *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	return nil, clients.ErrProviderUnavailable
+}
+
+func (p *timedStreamMock) ChatStream(_ context.Context, _ *schemas.ChatStreamRequest) (clients.ChatStream, error) {
+	return &timedStream{chunks: p.chunks}, nil
+}
+
+func (p *timedStreamMock) Embed(_ context.Context, _ *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	return nil, clients.ErrEmbedNotImplemented
+}
+
+type timedStream struct {
+	chunks []timedChunk
+}
+
+func (s *timedStream) Open() error { return nil }
+
+func (s *timedStream) Recv() (*schemas.ChatStreamChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+
+	time.Sleep(chunk.delay)
+
+	if chunk.panic != nil {
+		panic(chunk.panic)
+	}
+
+	return &schemas.ChatStreamChunk{
+		ModelResponse: schemas.ModelChunkResponse{Message: schemas.ChatMessage{Role: "assistant", Content: chunk.content}},
+	}, nil
+}
+
+func (s *timedStream) Close() error { return nil }
+
+func TestLanguageModel_ChatStream_TracksTTFT(t *testing.T) {
+	latencyConfig := latency.DefaultConfig()
+	latencyConfig.WarmupSamples = 1
+
+	model := NewLangModel(
+		"openai",
+		&timedStreamMock{chunks: []timedChunk{
+			{content: "", delay: 10 * time.Millisecond}, // role announcements carry no tokens
+			{content: "Knock", delay: 10 * time.Millisecond},
+			{content: " knock. Who's there?", delay: 20 * time.Millisecond},
+		}},
+		health.DefaultErrorBudget(),
+		*latencyConfig,
+		1,
+	)
+
+	// the second stream warms up the latency stats
+	for range 2 {
+		streamResultC, err := model.ChatStream(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"))
+		require.NoError(t, err)
+
+		for result := range streamResultC {
+			require.NoError(t, result.Error())
+		}
+	}
+
+	require.GreaterOrEqual(t, model.TTFT().Value(), float64(20*time.Millisecond))
+	require.Less(t, model.TTFT().Value(), float64(200*time.Millisecond))
+	require.True(t, model.TTFTQuantiles().Percentile(95).WarmedUp())
+
+	// 5 tokens after the first one in ~20ms
+	require.Greater(t, model.TokenRate().Value(), 10.0)
+	require.Less(t, model.TokenRate().Value(), 300.0)
+
+	modelLatency := model.Health().Latency
+	require.Equal(t, model.TTFT().Value(), modelLatency.TTFT)
+	require.Equal(t, model.TokenRate().Value(), modelLatency.TokensPerSecond)
+
+	require.Same(t, model.TTFT(), TTFT(model, ChatStreamAction))
+}
+
+func TestLanguageModel_ChatStream_RecoversPanics(t *testing.T) {
+	model := NewLangModel(
+		"openai",
+		&timedStreamMock{chunks: []timedChunk{
+			{content: "Knock"},
+			{panic: "index out of range"},
+		}},
+		health.DefaultErrorBudget(),
+		*latency.DefaultConfig(),
+		1,
+	)
+
+	streamResultC, err := model.ChatStream(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+
+	result := <-streamResultC
+	require.NoError(t, result.Error())
+	require.Equal(t, "Knock", result.Chunk().ModelResponse.Message.Content)
+
+	result = <-streamResultC
+
+	var streamPanic *StreamPanic
+
+	require.ErrorAs(t, result.Error(), &streamPanic)
+	require.Equal(t, "index out of range", streamPanic.Value)
+	require.NotEmpty(t, streamPanic.Stack)
+
+	_, open := <-streamResultC
+	require.False(t, open)
+}
+
+// hangingProviderMock never responds in time, so requests end once their deadline has passed
+type hangingProviderMock struct {
+	probedProviderMock
+	timeout time.Duration // the model timeout
+}
+
+func (p *hangingProviderMock) Chat(ctx context.Context, _ *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	<-ctx.Done()
+
+	return nil, fmt.Errorf("failed to send the request: %w", ctx.Err())
+}
+
+func TestLanguageModel_Chat_ClientDeadlineKeepsHealthy(t *testing.T) {
+	model := NewLangModel(
+		"openai",
+		&hangingProviderMock{timeout: time.Minute},
+		health.NewErrorBudget(2, health.HOUR),
+		*latency.DefaultConfig(),
+		1,
+	)
+
+	// the client has given the request a deadline too short for any model to make it
+	for range 5 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+
+		_, err := model.Chat(ctx, schemas.NewChatFromStr("hello"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		cancel()
+	}
+
+	require.True(t, model.Healthy())
+	require.Equal(t, uint(2), model.healthTracker.ErrBudgetLeft())
+
+	// the model timeout is still charged to the model
+	model.client = &hangingProviderMock{timeout: time.Millisecond}
+
+	_, err := model.Chat(context.Background(), schemas.NewChatFromStr("hello"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, uint(1), model.healthTracker.ErrBudgetLeft())
+}
@@ -0,0 +1,28 @@
+package providers
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+)
+
+// ModerationProvider defines an interface a provider should fulfill to be able to serve moderation requests
+type ModerationProvider interface {
+	ModelProvider
+
+	Moderate(ctx context.Context, req *schemas.ModerationRequest) (*schemas.ModerationResponse, error)
+}
+
+// ModerationModel serves moderation requests
+type ModerationModel = SingleActionModel[*schemas.ModerationRequest, *schemas.ModerationResponse]
+
+func NewModerationModel(modelID string, client ModerationProvider, budget *health.ErrorBudget, latencyConfig latency.Config, weight int) *ModerationModel {
+	return newSingleActionModel(modelID, client, client.Moderate, moderationUnits, budget, latencyConfig, weight)
+}
+
+// moderationUnits records latency per input to normalize measurements
+func moderationUnits(req *schemas.ModerationRequest, _ *schemas.ModerationResponse) float64 {
+	return float64(len(req.Input))
+}
@@ -99,7 +99,6 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
 	req.Header.Set("Content-Type", "application/json")
 
-	// TODO: this could leak information from messages which may not be a desired thing to have
 	c.telemetry.Logger.Debug(
 		"octoml chat request",
 		zap.String("chat_url", c.chatURL),
@@ -124,6 +123,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	c.driftDetector.Check("chat", bodyBytes, openai.ChatCompletion{}, "choices", "usage")
+
 	// Parse the response JSON
 	var openAICompletion openai.ChatCompletion // Octo uses the same response schema as OpenAI
 
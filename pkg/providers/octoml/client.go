@@ -1,7 +1,7 @@
 package octoml
 
 import (
-	"errors"
+	"context"
 	"net/http"
 	"net/url"
 
@@ -15,7 +15,7 @@ const (
 
 // ErrEmptyResponse is returned when the OctoML API returns an empty response.
 var (
-	ErrEmptyResponse = errors.New("empty response")
+	ErrEmptyResponse = clients.ErrEmptyResponse
 )
 
 // Client is a client for accessing OctoML API
@@ -23,6 +23,7 @@ type Client struct {
 	baseURL             string
 	chatURL             string
 	chatRequestTemplate *ChatRequest
+	driftDetector       *clients.SchemaDriftDetector
 	errMapper           *ErrorMapper
 	config              *Config
 	httpClient          *http.Client
@@ -41,16 +42,10 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		chatURL:             chatURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		driftDetector:       clients.NewSchemaDriftDetector(providerName, tel),
 		errMapper:           NewErrorMapper(tel),
-		httpClient: &http.Client{
-			Timeout: *clientConfig.Timeout,
-			// TODO: use values from the config
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 2,
-			},
-		},
-		telemetry: tel,
+		httpClient:          clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		telemetry:           tel,
 	}
 
 	return c, nil
@@ -59,3 +54,8 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 func (c *Client) Provider() string {
 	return providerName
 }
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
@@ -1,10 +1,8 @@
 package octoml
 
 import (
-	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"glide/pkg/providers/clients"
 	"glide/pkg/telemetry"
@@ -35,16 +33,7 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 	)
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		// Read the value of the "Retry-After" header to get the cooldown delay
-		retryAfter := resp.Header.Get("Retry-After")
-
-		// Parse the value to get the duration
-		cooldownDelay, err := time.ParseDuration(retryAfter)
-		if err != nil {
-			return fmt.Errorf("failed to parse cooldown delay from headers: %w", err)
-		}
-
-		return clients.NewRateLimitError(&cooldownDelay)
+		return clients.NewRateLimitErrorFromHeaders(resp.Header)
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
@@ -119,7 +119,6 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 
 	req.Header.Set("Content-Type", "application/json")
 
-	// TODO: this could leak information from messages which may not be a desired thing to have
 	c.telemetry.Logger.Debug(
 		"ollama chat request",
 		zap.String("chat_url", c.chatURL),
@@ -147,16 +146,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		)
 
 		if resp.StatusCode == http.StatusTooManyRequests {
-			// Read the value of the "Retry-After" header to get the cooldown delay
-			retryAfter := resp.Header.Get("Retry-After")
-
-			// Parse the value to get the duration
-			cooldownDelay, err := time.ParseDuration(retryAfter)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse cooldown delay from headers: %w", err)
-			}
-
-			return nil, clients.NewRateLimitError(&cooldownDelay)
+			return nil, clients.NewRateLimitErrorFromHeaders(resp.Header)
 		}
 
 		// Server & client errors result in the same error to keep gateway resilient
@@ -170,6 +160,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	c.driftDetector.Check("chat", bodyBytes, ChatCompletion{}, "message", "done")
+
 	// Parse the response JSON
 	var ollamaCompletion ChatCompletion
 
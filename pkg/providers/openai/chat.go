@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
 	"go.uber.org/zap"
 )
 
@@ -83,7 +84,6 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
 
-	// TODO: this could leak information from messages which may not be a desired thing to have
 	c.logger.Debug(
 		"Chat Request",
 		zap.String("chatURL", c.chatURL),
@@ -113,6 +113,8 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	c.driftDetector.Check("chat", bodyBytes, ChatCompletion{}, "choices", "usage")
+
 	// Parse the response JSON
 	var chatCompletion ChatCompletion
 
@@ -127,6 +129,10 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
+	if len(chatCompletion.Choices) > 0 && chatCompletion.Choices[0].FinishReason == FilteredReason {
+		return nil, clients.ErrContentFiltered
+	}
+
 	// Map response to ChatResponse schema
 	response := schemas.ChatResponse{
 		ID:        chatCompletion.ID,
@@ -143,9 +149,10 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				Content: chatCompletion.Choices[0].Message.Content,
 			},
 			TokenUsage: schemas.TokenUsage{
-				PromptTokens:   chatCompletion.Usage.PromptTokens,
-				ResponseTokens: chatCompletion.Usage.CompletionTokens,
-				TotalTokens:    chatCompletion.Usage.TotalTokens,
+				PromptTokens:       chatCompletion.Usage.PromptTokens,
+				ResponseTokens:     chatCompletion.Usage.CompletionTokens,
+				TotalTokens:        chatCompletion.Usage.TotalTokens,
+				CachedPromptTokens: chatCompletion.Usage.CachedTokens(),
 			},
 		},
 	}
@@ -194,7 +194,6 @@ func (c *Client) makeStreamReq(ctx context.Context, req *schemas.ChatStreamReque
 	request.Header.Set("Accept", "text/event-stream")
 	request.Header.Set("Connection", "keep-alive")
 
-	// TODO: this could leak information from messages which may not be a desired thing to have
 	c.logger.Debug(
 		"Stream chat request",
 		zap.String("chatURL", c.chatURL),
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"glide/pkg/api/schemas"
 	"glide/pkg/providers/clients"
@@ -94,3 +95,82 @@ func TestOpenAIClient_RateLimit(t *testing.T) {
 	require.Error(t, err)
 	require.IsType(t, &clients.RateLimitError{}, err)
 }
+
+func TestOpenAIClient_RateLimitResetHeaders(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("x-ratelimit-reset-requests", "1s")
+		w.Header().Set("x-ratelimit-reset-tokens", "6m0s")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	_, err = client.Chat(context.Background(), schemas.NewChatFromStr("What's the biggest animal?"))
+
+	var rateLimitErr *clients.RateLimitError
+
+	require.ErrorAs(t, err, &rateLimitErr)
+	require.Equal(t, 6*time.Minute, rateLimitErr.UntilReset())
+}
+
+func TestOpenAIClient_ContentFiltered(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"filtered response": func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": "chatcmpl-123", "choices": [{"index": 0, "message": {"role": "assistant", "content": ""}, "finish_reason": "content_filter"}]}`))
+		},
+		"filtered request": func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "The response was filtered", "code": "content_filter", "status": 400}}`))
+		},
+	}
+
+	for name, openAIMock := range tests {
+		t.Run(name, func(t *testing.T) {
+			openAIServer := httptest.NewServer(openAIMock)
+			defer openAIServer.Close()
+
+			providerCfg := DefaultConfig()
+			providerCfg.BaseURL = openAIServer.URL
+
+			client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			_, err = client.Chat(context.Background(), schemas.NewChatFromStr("What's the biggest animal?"))
+			require.ErrorIs(t, err, clients.ErrContentFiltered)
+		})
+	}
+}
+
+func TestOpenAIClient_ContextTooLong(t *testing.T) {
+	openAIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": {"message": "This model's maximum context length is 4097 tokens", "code": "context_length_exceeded"}}`))
+	}))
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	_, err = client.Chat(context.Background(), schemas.NewChatFromStr("What's the biggest animal?"))
+	require.ErrorIs(t, err, clients.ErrContextTooLong)
+}
+
+func TestUsage_CachedTokens(t *testing.T) {
+	var usage Usage
+
+	require.Zero(t, usage.CachedTokens())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"prompt_tokens": 2048, "prompt_tokens_details": {"cached_tokens": 1920}}`), &usage))
+	require.Equal(t, 1920, usage.CachedTokens())
+}
@@ -1,7 +1,7 @@
 package openai
 
 import (
-	"errors"
+	"context"
 	"net/http"
 	"net/url"
 
@@ -17,14 +17,20 @@ const (
 
 // ErrEmptyResponse is returned when the OpenAI API returns an empty response.
 var (
-	ErrEmptyResponse = errors.New("empty response")
+	ErrEmptyResponse = clients.ErrEmptyResponse
 )
 
 // Client is a client for accessing OpenAI API
 type Client struct {
 	baseURL             string
 	chatURL             string
+	embedURL            string
+	imageURL            string
+	modelURL            string
+	audioURL            string
+	moderationURL       string
 	chatRequestTemplate *ChatRequest
+	driftDetector       *clients.SchemaDriftDetector
 	errMapper           *ErrorMapper
 	finishReasonMapper  *FinishReasonMapper
 	config              *Config
@@ -40,6 +46,31 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		return nil, err
 	}
 
+	embedURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.EmbedEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ImageEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	modelURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ModelEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	audioURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.AudioEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	moderationURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ModerationEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	logger := tel.L().With(
 		zap.String("provider", providerName),
 	)
@@ -47,20 +78,19 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 	c := &Client{
 		baseURL:             providerConfig.BaseURL,
 		chatURL:             chatURL,
+		embedURL:            embedURL,
+		imageURL:            imageURL,
+		modelURL:            modelURL,
+		audioURL:            audioURL,
+		moderationURL:       moderationURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		driftDetector:       clients.NewSchemaDriftDetector(providerName, tel),
 		finishReasonMapper:  NewFinishReasonMapper(tel),
 		errMapper:           NewErrorMapper(tel),
-		httpClient: &http.Client{
-			Timeout: *clientConfig.Timeout,
-			// TODO: use values from the config
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 2,
-			},
-		},
-		tel:    tel,
-		logger: logger,
+		httpClient:          clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		tel:                 tel,
+		logger:              logger,
 	}
 
 	return c, nil
@@ -69,3 +99,8 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 func (c *Client) Provider() string {
 	return providerName
 }
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
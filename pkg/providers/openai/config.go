@@ -42,11 +42,20 @@ func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 type Config struct {
-	BaseURL       string        `yaml:"baseUrl" json:"baseUrl" validate:"required"`
-	ChatEndpoint  string        `yaml:"chatEndpoint" json:"chatEndpoint" validate:"required"`
-	Model         string        `yaml:"model" json:"model" validate:"required"`
-	APIKey        fields.Secret `yaml:"api_key" json:"-" validate:"required"`
-	DefaultParams *Params       `yaml:"defaultParams,omitempty" json:"defaultParams"`
+	BaseURL            string        `yaml:"baseUrl" json:"baseUrl" validate:"required"`
+	ChatEndpoint       string        `yaml:"chatEndpoint" json:"chatEndpoint" validate:"required"`
+	EmbedEndpoint      string        `yaml:"embedEndpoint" json:"embedEndpoint"`
+	ImageEndpoint      string        `yaml:"imageEndpoint" json:"imageEndpoint"`
+	ModelEndpoint      string        `yaml:"modelEndpoint" json:"modelEndpoint"`
+	AudioEndpoint      string        `yaml:"audioEndpoint" json:"audioEndpoint"`
+	ModerationEndpoint string        `yaml:"moderationEndpoint" json:"moderationEndpoint"`
+	Model              string        `yaml:"model" json:"model" validate:"required"`
+	EmbedModel         string        `yaml:"embedModel" json:"embedModel"`
+	ImageModel         string        `yaml:"imageModel" json:"imageModel"`
+	AudioModel         string        `yaml:"audioModel" json:"audioModel"`
+	ModerationModel    string        `yaml:"moderationModel" json:"moderationModel"`
+	APIKey             fields.Secret `yaml:"api_key" json:"-" validate:"required"`
+	DefaultParams      *Params       `yaml:"defaultParams,omitempty" json:"defaultParams"`
 }
 
 // DefaultConfig for OpenAI models
@@ -54,10 +63,19 @@ func DefaultConfig() *Config {
 	defaultParams := DefaultParams()
 
 	return &Config{
-		BaseURL:       "https://api.openai.com/v1",
-		ChatEndpoint:  "/chat/completions",
-		Model:         "gpt-3.5-turbo",
-		DefaultParams: &defaultParams,
+		BaseURL:            "https://api.openai.com/v1",
+		ChatEndpoint:       "/chat/completions",
+		EmbedEndpoint:      "/embeddings",
+		ImageEndpoint:      "/images/generations",
+		ModelEndpoint:      "/models",
+		AudioEndpoint:      "/audio/transcriptions",
+		ModerationEndpoint: "/moderations",
+		Model:              "gpt-3.5-turbo",
+		EmbedModel:         "text-embedding-3-small",
+		ImageModel:         "dall-e-3",
+		AudioModel:         "whisper-1",
+		ModerationModel:    "omni-moderation-latest",
+		DefaultParams:      &defaultParams,
 	}
 }
 
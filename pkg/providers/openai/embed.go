@@ -0,0 +1,98 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+func (c *Client) SupportEmbed() bool {
+	return true
+}
+
+// Embed sends an embedding request to the specified OpenAI model.
+func (c *Client) Embed(ctx context.Context, request *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	embedRequest := &EmbedRequest{
+		Model:          c.config.EmbedModel,
+		Input:          request.Input,
+		EncodingFormat: "float",
+		User:           c.config.DefaultParams.User,
+	}
+
+	rawPayload, err := json.Marshal(embedRequest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal openai embed request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai embed request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai embed request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("Failed to read embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var embedResult EmbedResult
+
+	err = json.Unmarshal(bodyBytes, &embedResult)
+	if err != nil {
+		c.logger.Error(
+			"Failed to unmarshal embed response",
+			zap.ByteString("rawResponse", bodyBytes),
+			zap.Error(err),
+		)
+
+		return nil, err
+	}
+
+	if len(embedResult.Data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	embeddings := make([]schemas.Embedding, 0, len(embedResult.Data))
+
+	for _, value := range embedResult.Data {
+		embeddings = append(embeddings, schemas.Embedding{
+			Index:  value.Index,
+			Vector: value.Embedding,
+		})
+	}
+
+	return &schemas.EmbedResponse{
+		Created:   int(time.Now().UTC().Unix()),
+		Provider:  providerName,
+		ModelName: embedResult.ModelName,
+		ModelResponse: schemas.EmbedModelResponse{
+			Embeddings: embeddings,
+			TokenUsage: schemas.TokenUsage{
+				PromptTokens: embedResult.Usage.PromptTokens,
+				TotalTokens:  embedResult.Usage.TotalTokens,
+			},
+		},
+	}, nil
+}
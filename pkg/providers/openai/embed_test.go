@@ -0,0 +1,58 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestOpenAIClient_EmbedRequest(t *testing.T) {
+	// OpenAI Embedding API: https://platform.openai.com/docs/api-reference/embeddings/create
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var embedRequest EmbedRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&embedRequest); err != nil {
+			t.Errorf("error decoding payload: %v", err)
+		}
+
+		require.Equal(t, "/embeddings", r.URL.Path)
+		require.Equal(t, []string{"The food was delicious"}, embedRequest.Input)
+
+		embedResponse, err := os.ReadFile(filepath.Clean("./testdata/embed.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai embed mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(embedResponse)
+		if err != nil {
+			t.Errorf("error on sending embed response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Embed(context.Background(), schemas.NewEmbedFromStr("The food was delicious"))
+	require.NoError(t, err)
+
+	require.Len(t, response.ModelResponse.Embeddings, 1)
+	require.Len(t, response.ModelResponse.Embeddings[0].Vector, 3)
+	require.Equal(t, 8, response.ModelResponse.TokenUsage.PromptTokens)
+}
@@ -1,10 +1,9 @@
 package openai
 
 import (
-	"fmt"
+	"encoding/json"
 	"io"
 	"net/http"
-	"time"
 
 	"glide/pkg/providers/clients"
 	"glide/pkg/telemetry"
@@ -21,6 +20,36 @@ func NewErrorMapper(tel *telemetry.Telemetry) *ErrorMapper {
 	}
 }
 
+// ContentFiltered checks if the error response tells the request was blocked by the content filter
+func ContentFiltered(errorBody []byte) bool {
+	var errResp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(errorBody, &errResp); err != nil {
+		return false
+	}
+
+	return errResp.Error.Code == FilteredReason
+}
+
+// ContextTooLong checks if the error response tells the prompt doesn't fit the model context window
+func ContextTooLong(errorBody []byte) bool {
+	var errResp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(errorBody, &errResp); err != nil {
+		return false
+	}
+
+	return errResp.Error.Code == "context_length_exceeded"
+}
+
 func (m *ErrorMapper) Map(resp *http.Response) error {
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -43,22 +72,21 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 	)
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		// Read the value of the "Retry-After" header to get the cooldown delay
-		retryAfter := resp.Header.Get("Retry-After")
-
-		// Parse the value to get the duration
-		cooldownDelay, err := time.ParseDuration(retryAfter)
-		if err != nil {
-			return fmt.Errorf("failed to parse cooldown delay from headers: %w", err)
-		}
-
-		return clients.NewRateLimitError(&cooldownDelay)
+		return clients.NewRateLimitErrorFromHeaders(resp.Header)
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
 		return clients.ErrUnauthorized
 	}
 
+	if resp.StatusCode == http.StatusBadRequest && ContentFiltered(bodyBytes) {
+		return clients.ErrContentFiltered
+	}
+
+	if resp.StatusCode == http.StatusBadRequest && ContextTooLong(bodyBytes) {
+		return clients.ErrContextTooLong
+	}
+
 	// Server & client errors result in the same error to keep gateway resilient
 	return clients.ErrProviderUnavailable
 }
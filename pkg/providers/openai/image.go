@@ -0,0 +1,87 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// GenerateImage sends an image generation request to the specified OpenAI model.
+func (c *Client) GenerateImage(ctx context.Context, request *schemas.ImageGenerateRequest) (*schemas.ImageGenerateResponse, error) {
+	imageRequest := &ImageRequest{
+		Model:  c.config.ImageModel,
+		Prompt: request.Prompt,
+		N:      request.N,
+		Size:   request.Size,
+		User:   c.config.DefaultParams.User,
+	}
+
+	rawPayload, err := json.Marshal(imageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal openai image request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.imageURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai image request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai image request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("Failed to read image response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var imageResult ImageResult
+
+	err = json.Unmarshal(bodyBytes, &imageResult)
+	if err != nil {
+		c.logger.Error("Failed to unmarshal image response", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(imageResult.Data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	images := make([]schemas.Image, 0, len(imageResult.Data))
+
+	for _, value := range imageResult.Data {
+		images = append(images, schemas.Image{
+			URL:           value.URL,
+			B64JSON:       value.B64JSON,
+			RevisedPrompt: value.RevisedPrompt,
+		})
+	}
+
+	return &schemas.ImageGenerateResponse{
+		Created:   imageResult.Created,
+		Provider:  providerName,
+		ModelName: c.config.ImageModel,
+		ModelResponse: schemas.ImageModelResponse{
+			Images: images,
+		},
+	}, nil
+}
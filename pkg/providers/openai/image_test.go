@@ -0,0 +1,57 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestOpenAIClient_GenerateImageRequest(t *testing.T) {
+	// OpenAI Images API: https://platform.openai.com/docs/api-reference/images/create
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var imageRequest ImageRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&imageRequest); err != nil {
+			t.Errorf("error decoding payload: %v", err)
+		}
+
+		require.Equal(t, "/images/generations", r.URL.Path)
+		require.Equal(t, "dall-e-3", imageRequest.Model)
+
+		imageResponse, err := os.ReadFile(filepath.Clean("./testdata/image.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai image mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(imageResponse)
+		if err != nil {
+			t.Errorf("error on sending image response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.GenerateImage(context.Background(), schemas.NewImageGenerateFromStr("a baby sea otter"))
+	require.NoError(t, err)
+
+	require.Len(t, response.ModelResponse.Images, 1)
+	require.Equal(t, "https://example.com/img-1.png", response.ModelResponse.Images[0].URL)
+}
@@ -0,0 +1,49 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"glide/pkg/api/schemas"
+)
+
+// ListModels lists models available for the configured API key
+func (c *Client) ListModels(ctx context.Context) ([]schemas.ProviderModel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.modelURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai model list request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai model list request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	var modelList ModelList
+
+	if err := json.NewDecoder(resp.Body).Decode(&modelList); err != nil {
+		return nil, fmt.Errorf("unable to parse openai model list: %w", err)
+	}
+
+	models := make([]schemas.ProviderModel, 0, len(modelList.Data))
+
+	for _, model := range modelList.Data {
+		models = append(models, schemas.ProviderModel{
+			ID:      model.ID,
+			OwnedBy: model.OwnedBy,
+			Created: model.Created,
+		})
+	}
+
+	return models, nil
+}
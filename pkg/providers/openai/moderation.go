@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// Moderate sends a moderation request to the specified OpenAI model.
+func (c *Client) Moderate(ctx context.Context, request *schemas.ModerationRequest) (*schemas.ModerationResponse, error) {
+	moderationRequest := &ModerationRequest{
+		Model: c.config.ModerationModel,
+		Input: request.Input,
+	}
+
+	rawPayload, err := json.Marshal(moderationRequest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal openai moderation request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.moderationURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai moderation request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai moderation request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("Failed to read moderation response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var moderationResult ModerationResult
+
+	err = json.Unmarshal(bodyBytes, &moderationResult)
+	if err != nil {
+		c.logger.Error("Failed to unmarshal moderation response", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(moderationResult.Results) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	results := make([]schemas.ModerationResult, 0, len(moderationResult.Results))
+
+	for _, value := range moderationResult.Results {
+		categories := make([]schemas.ModerationCategory, 0, len(value.CategoryScores))
+
+		for name, score := range value.CategoryScores {
+			categories = append(categories, schemas.ModerationCategory{
+				Name:    name,
+				Flagged: value.Categories[name],
+				Score:   score,
+			})
+		}
+
+		sort.Slice(categories, func(i, j int) bool {
+			return categories[i].Name < categories[j].Name
+		})
+
+		results = append(results, schemas.ModerationResult{
+			Flagged:    value.Flagged,
+			Categories: categories,
+		})
+	}
+
+	return &schemas.ModerationResponse{
+		ID:        moderationResult.ID,
+		Created:   int(time.Now().UTC().Unix()), // OpenAI doesn't provide this
+		Provider:  providerName,
+		ModelName: moderationResult.Model,
+		ModelResponse: schemas.ModerationModelResponse{
+			Results: results,
+		},
+	}, nil
+}
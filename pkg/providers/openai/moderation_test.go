@@ -0,0 +1,64 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestOpenAIClient_ModerationRequest(t *testing.T) {
+	// OpenAI Moderation API: https://platform.openai.com/docs/api-reference/moderations/create
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var moderationRequest ModerationRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&moderationRequest); err != nil {
+			t.Errorf("error decoding payload: %v", err)
+		}
+
+		require.Equal(t, "/moderations", r.URL.Path)
+		require.Equal(t, "omni-moderation-latest", moderationRequest.Model)
+		require.Equal(t, []string{"I will hurt you"}, moderationRequest.Input)
+
+		moderationResponse, err := os.ReadFile(filepath.Clean("./testdata/moderation.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai moderation mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(moderationResponse)
+		if err != nil {
+			t.Errorf("error on sending moderation response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Moderate(context.Background(), schemas.NewModerationFromStr("I will hurt you"))
+	require.NoError(t, err)
+
+	require.True(t, response.Flagged())
+	require.Len(t, response.ModelResponse.Results, 1)
+
+	categories := response.ModelResponse.Results[0].Categories
+	require.Len(t, categories, 3)
+	require.Equal(t, "violence", categories[2].Name)
+	require.True(t, categories[2].Flagged)
+	require.InDelta(t, 0.9712, categories[2].Score, 0.0001)
+}
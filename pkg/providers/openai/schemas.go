@@ -47,9 +47,24 @@ type Choice struct {
 }
 
 type Usage struct {
-	PromptTokens     int `json:"prompt_tokens"`
-	CompletionTokens int `json:"completion_tokens"`
-	TotalTokens      int `json:"total_tokens"`
+	PromptTokens        int                  `json:"prompt_tokens"`
+	CompletionTokens    int                  `json:"completion_tokens"`
+	TotalTokens         int                  `json:"total_tokens"`
+	PromptTokensDetails *PromptTokensDetails `json:"prompt_tokens_details,omitempty"`
+}
+
+// PromptTokensDetails breaks down prompt tokens, e.g. tells how many of them were served from the automatic prompt cache
+type PromptTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+// CachedTokens returns the number of prompt tokens read from the prompt cache
+func (u Usage) CachedTokens() int {
+	if u.PromptTokensDetails == nil {
+		return 0
+	}
+
+	return u.PromptTokensDetails.CachedTokens
 }
 
 // ChatCompletionChunk represents SSEvent a chat response is broken down on chat streaming
@@ -69,3 +84,102 @@ type StreamChoice struct {
 	Logprobs     interface{} `json:"logprobs"`
 	FinishReason string      `json:"finish_reason"`
 }
+
+// EmbedRequest is an OpenAI-specific embedding request schema
+// Ref: https://platform.openai.com/docs/api-reference/embeddings/create
+type EmbedRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format,omitempty"`
+	User           *string  `json:"user,omitempty"`
+}
+
+// EmbedResult
+// Ref: https://platform.openai.com/docs/api-reference/embeddings/object
+type EmbedResult struct {
+	Object    string           `json:"object"`
+	Data      []EmbeddingValue `json:"data"`
+	ModelName string           `json:"model"`
+	Usage     Usage            `json:"usage"`
+}
+
+type EmbeddingValue struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+// ImageRequest is an OpenAI-specific image generation request schema
+// Ref: https://platform.openai.com/docs/api-reference/images/create
+type ImageRequest struct {
+	Model  string  `json:"model"`
+	Prompt string  `json:"prompt"`
+	N      int     `json:"n,omitempty"`
+	Size   string  `json:"size,omitempty"`
+	User   *string `json:"user,omitempty"`
+}
+
+// ImageResult
+// Ref: https://platform.openai.com/docs/api-reference/images/object
+type ImageResult struct {
+	Created int          `json:"created"`
+	Data    []ImageValue `json:"data"`
+}
+
+type ImageValue struct {
+	URL           string `json:"url"`
+	B64JSON       string `json:"b64_json"`
+	RevisedPrompt string `json:"revised_prompt"`
+}
+
+// ModelList
+// Ref: https://platform.openai.com/docs/api-reference/models/list
+type ModelList struct {
+	Object string      `json:"object"`
+	Data   []ModelInfo `json:"data"`
+}
+
+type ModelInfo struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int    `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// Transcription is a verbose transcription result
+// Ref: https://platform.openai.com/docs/api-reference/audio/verbose-json-object
+type Transcription struct {
+	Task     string                 `json:"task"`
+	Language string                 `json:"language"`
+	Duration float64                `json:"duration"`
+	Text     string                 `json:"text"`
+	Segments []TranscriptionSegment `json:"segments"`
+}
+
+type TranscriptionSegment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// ModerationRequest is an OpenAI-specific moderation request schema
+// Ref: https://platform.openai.com/docs/api-reference/moderations/create
+type ModerationRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// ModerationResult
+// Ref: https://platform.openai.com/docs/api-reference/moderations/object
+type ModerationResult struct {
+	ID      string            `json:"id"`
+	Model   string            `json:"model"`
+	Results []ModerationValue `json:"results"`
+}
+
+type ModerationValue struct {
+	Flagged        bool               `json:"flagged"`
+	Categories     map[string]bool    `json:"categories"`
+	CategoryScores map[string]float64 `json:"category_scores"`
+}
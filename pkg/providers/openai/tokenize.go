@@ -0,0 +1,23 @@
+package openai
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+)
+
+// CountTokens counts prompt tokens locally with the tiktoken tokenizer of the OpenAI model.
+func (c *Client) CountTokens(_ context.Context, request *schemas.TokenizeRequest) (*schemas.TokenizeResponse, error) {
+	tokens, estimated, err := clients.CountTiktokens(c.config.Model, request.Messages())
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemas.TokenizeResponse{
+		Provider:  providerName,
+		ModelName: c.config.Model,
+		Tokens:    tokens,
+		Estimated: estimated,
+	}, nil
+}
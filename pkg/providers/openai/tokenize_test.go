@@ -0,0 +1,27 @@
+package openai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestOpenAIClient_CountTokens(t *testing.T) {
+	client, err := NewClient(DefaultConfig(), clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	request := &schemas.TokenizeRequest{
+		Message: schemas.ChatMessage{Role: "user", Content: "Hello world"},
+	}
+
+	response, err := client.CountTokens(context.Background(), request)
+	require.NoError(t, err)
+
+	require.Equal(t, 9, response.Tokens)
+	require.False(t, response.Estimated)
+	require.Equal(t, "gpt-3.5-turbo", response.ModelName)
+}
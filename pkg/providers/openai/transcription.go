@@ -0,0 +1,111 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// Transcribe sends a speech-to-text request to the specified OpenAI Whisper model.
+func (c *Client) Transcribe(ctx context.Context, request *schemas.TranscriptionRequest) (*schemas.TranscriptionResponse, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	fileWriter, err := writer.CreateFormFile("file", request.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai transcription request payload: %w", err)
+	}
+
+	if _, err = fileWriter.Write(request.File); err != nil {
+		return nil, fmt.Errorf("unable to create openai transcription request payload: %w", err)
+	}
+
+	fields := map[string]string{
+		"model":                     c.config.AudioModel,
+		"response_format":           "verbose_json",
+		"timestamp_granularities[]": "segment",
+		"language":                  request.Language,
+		"prompt":                    request.Prompt,
+	}
+
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+
+		if err = writer.WriteField(name, value); err != nil {
+			return nil, fmt.Errorf("unable to create openai transcription request payload: %w", err)
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("unable to create openai transcription request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.audioURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai transcription request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai transcription request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("Failed to read transcription response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var transcription Transcription
+
+	err = json.Unmarshal(bodyBytes, &transcription)
+	if err != nil {
+		c.logger.Error("Failed to unmarshal transcription response", zap.Error(err))
+
+		return nil, err
+	}
+
+	segments := make([]schemas.TranscriptionSegment, 0, len(transcription.Segments))
+
+	for _, segment := range transcription.Segments {
+		segments = append(segments, schemas.TranscriptionSegment{
+			ID:    segment.ID,
+			Start: segment.Start,
+			End:   segment.End,
+			Text:  strings.TrimSpace(segment.Text),
+		})
+	}
+
+	return &schemas.TranscriptionResponse{
+		Created:   int(time.Now().UTC().Unix()), // OpenAI doesn't provide this
+		Provider:  providerName,
+		ModelName: c.config.AudioModel,
+		ModelResponse: schemas.TranscriptionModelResponse{
+			Text:     transcription.Text,
+			Language: transcription.Language,
+			Duration: transcription.Duration,
+			Segments: segments,
+		},
+	}, nil
+}
@@ -0,0 +1,60 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestOpenAIClient_TranscribeRequest(t *testing.T) {
+	// OpenAI Audio API: https://platform.openai.com/docs/api-reference/audio/createTranscription
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/audio/transcriptions", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1024))
+		require.Equal(t, "whisper-1", r.FormValue("model"))
+		require.Equal(t, "verbose_json", r.FormValue("response_format"))
+
+		_, fileHeader, err := r.FormFile("file")
+		require.NoError(t, err)
+		require.Equal(t, "speech.mp3", fileHeader.Filename)
+
+		transcriptionResponse, err := os.ReadFile(filepath.Clean("./testdata/transcription.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai transcription mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(transcriptionResponse)
+		if err != nil {
+			t.Errorf("error on sending transcription response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Transcribe(context.Background(), &schemas.TranscriptionRequest{
+		File:     []byte("ID3"),
+		Filename: "speech.mp3",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 8.47, response.ModelResponse.Duration)
+	require.Len(t, response.ModelResponse.Segments, 1)
+	require.Equal(t, "The beach was a popular spot on a hot summer day.", response.ModelResponse.Segments[0].Text)
+}
@@ -0,0 +1,23 @@
+package providers
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// StreamPanic is a panic that happened while the model chat stream was read & mapped
+type StreamPanic struct {
+	Value any
+	Stack []byte
+}
+
+func NewStreamPanic(value any) *StreamPanic {
+	return &StreamPanic{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+}
+
+func (p *StreamPanic) Error() string {
+	return fmt.Sprintf("chat stream panicked: %v", p.Value)
+}
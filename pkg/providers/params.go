@@ -0,0 +1,189 @@
+package providers
+
+import (
+	"fmt"
+
+	"glide/pkg/providers/anthropic"
+	"glide/pkg/providers/azureopenai"
+	"glide/pkg/providers/bedrock"
+	"glide/pkg/providers/cohere"
+	"glide/pkg/providers/octoml"
+	"glide/pkg/providers/ollama"
+	"glide/pkg/providers/openai"
+)
+
+// ParamsConfig defines provider-agnostic generation params of the model.
+// They override the provider-specific default params, so the same logical request could be tuned per model
+// (e.g. different temperatures on different models to produce comparable output)
+type ParamsConfig struct {
+	Temperature *float64 `yaml:"temperature,omitempty" json:"temperature,omitempty" validate:"omitempty,gte=0"`
+	TopP        *float64 `yaml:"top_p,omitempty" json:"top_p,omitempty" validate:"omitempty,gte=0,lte=1"`
+	MaxTokens   *int     `yaml:"max_tokens,omitempty" json:"max_tokens,omitempty" validate:"omitempty,gte=1"`
+	Stop        []string `yaml:"stop,omitempty" json:"stop,omitempty"`
+}
+
+// withParams returns a copy of the model config where the params overrides are applied to the provider default params.
+// The original config is left untouched, so the effective config still shows what users have configured
+func (c *LangModelConfig) withParams() *LangModelConfig { //nolint: cyclop
+	if c.Params == nil {
+		return c
+	}
+
+	params := c.Params
+	modelConfig := *c
+
+	switch {
+	case c.OpenAI != nil:
+		providerConfig := *c.OpenAI
+		providerParams := openai.DefaultParams()
+
+		if providerConfig.DefaultParams != nil {
+			providerParams = *providerConfig.DefaultParams
+		}
+
+		override(&providerParams.Temperature, params.Temperature)
+		override(&providerParams.TopP, params.TopP)
+		override(&providerParams.MaxTokens, params.MaxTokens)
+		override(&providerParams.StopWords, stopWords(params.Stop))
+
+		providerConfig.DefaultParams = &providerParams
+		modelConfig.OpenAI = &providerConfig
+	case c.AzureOpenAI != nil:
+		providerConfig := *c.AzureOpenAI
+		providerParams := azureopenai.DefaultParams()
+
+		if providerConfig.DefaultParams != nil {
+			providerParams = *providerConfig.DefaultParams
+		}
+
+		override(&providerParams.Temperature, params.Temperature)
+		override(&providerParams.TopP, params.TopP)
+		override(&providerParams.MaxTokens, params.MaxTokens)
+		override(&providerParams.StopWords, stopWords(params.Stop))
+
+		providerConfig.DefaultParams = &providerParams
+		modelConfig.AzureOpenAI = &providerConfig
+	case c.Cohere != nil:
+		providerConfig := *c.Cohere
+		providerParams := cohere.DefaultParams()
+
+		if providerConfig.DefaultParams != nil {
+			providerParams = *providerConfig.DefaultParams
+		}
+
+		override(&providerParams.Temperature, params.Temperature)
+
+		if params.TopP != nil {
+			providerParams.P = float32(*params.TopP)
+		}
+
+		if params.MaxTokens != nil {
+			providerParams.MaxTokens = params.MaxTokens
+		}
+
+		override(&providerParams.StopSequences, stopWords(params.Stop))
+
+		providerConfig.DefaultParams = &providerParams
+		modelConfig.Cohere = &providerConfig
+	case c.OctoML != nil:
+		providerConfig := *c.OctoML
+		providerParams := octoml.DefaultParams()
+
+		if providerConfig.DefaultParams != nil {
+			providerParams = *providerConfig.DefaultParams
+		}
+
+		override(&providerParams.Temperature, params.Temperature)
+		override(&providerParams.TopP, params.TopP)
+		override(&providerParams.MaxTokens, params.MaxTokens)
+		override(&providerParams.StopWords, stopWords(params.Stop))
+
+		providerConfig.DefaultParams = &providerParams
+		modelConfig.OctoML = &providerConfig
+	case c.Anthropic != nil:
+		providerConfig := *c.Anthropic
+		providerParams := anthropic.DefaultParams()
+
+		if providerConfig.DefaultParams != nil {
+			providerParams = *providerConfig.DefaultParams
+		}
+
+		override(&providerParams.Temperature, params.Temperature)
+		override(&providerParams.TopP, params.TopP)
+		override(&providerParams.MaxTokens, params.MaxTokens)
+		override(&providerParams.StopSequences, stopWords(params.Stop))
+
+		providerConfig.DefaultParams = &providerParams
+		modelConfig.Anthropic = &providerConfig
+	case c.Bedrock != nil:
+		providerConfig := *c.Bedrock
+		providerParams := bedrock.DefaultParams()
+
+		if providerConfig.DefaultParams != nil {
+			providerParams = *providerConfig.DefaultParams
+		}
+
+		override(&providerParams.Temperature, params.Temperature)
+		override(&providerParams.TopP, params.TopP)
+		override(&providerParams.MaxTokens, params.MaxTokens)
+		override(&providerParams.StopSequence, stopWords(params.Stop))
+
+		providerConfig.DefaultParams = &providerParams
+		modelConfig.Bedrock = &providerConfig
+	case c.Ollama != nil:
+		providerConfig := *c.Ollama
+		providerParams := ollama.DefaultParams()
+
+		if providerConfig.DefaultParams != nil {
+			providerParams = *providerConfig.DefaultParams
+		}
+
+		override(&providerParams.Temperature, params.Temperature)
+		override(&providerParams.TopP, params.TopP)
+		override(&providerParams.NumPredict, params.MaxTokens)
+		override(&providerParams.StopWords, stopWords(params.Stop))
+
+		providerConfig.DefaultParams = &providerParams
+		modelConfig.Ollama = &providerConfig
+	}
+
+	return &modelConfig
+}
+
+// override sets the param if the override is provided
+func override[T any](param *T, value *T) {
+	if value != nil {
+		*param = *value
+	}
+}
+
+func stopWords(stop []string) *[]string {
+	if stop == nil {
+		return nil
+	}
+
+	return &stop
+}
+
+// Overrides describes the params the model overrides (e.g. temperature=0.2)
+func (p *ParamsConfig) Overrides() []string {
+	overrides := make([]string, 0, 4)
+
+	if p.Temperature != nil {
+		overrides = append(overrides, fmt.Sprintf("temperature=%v", *p.Temperature))
+	}
+
+	if p.TopP != nil {
+		overrides = append(overrides, fmt.Sprintf("top_p=%v", *p.TopP))
+	}
+
+	if p.MaxTokens != nil {
+		overrides = append(overrides, fmt.Sprintf("max_tokens=%v", *p.MaxTokens))
+	}
+
+	if len(p.Stop) > 0 {
+		overrides = append(overrides, fmt.Sprintf("stop=%q", p.Stop))
+	}
+
+	return overrides
+}
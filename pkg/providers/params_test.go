@@ -0,0 +1,53 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers/cohere"
+	"glide/pkg/providers/openai"
+)
+
+func TestLangModelConfig_WithParams(t *testing.T) {
+	temperature, maxTokens := 0.2, 512
+
+	modelConfig := DefaultLangModelConfig()
+	modelConfig.OpenAI = openai.DefaultConfig()
+	modelConfig.Params = &ParamsConfig{
+		Temperature: &temperature,
+		MaxTokens:   &maxTokens,
+		Stop:        []string{"###"},
+	}
+
+	overriddenConfig := modelConfig.withParams()
+
+	require.InDelta(t, 0.2, overriddenConfig.OpenAI.DefaultParams.Temperature, 0.0001)
+	require.Equal(t, 512, overriddenConfig.OpenAI.DefaultParams.MaxTokens)
+	require.Equal(t, []string{"###"}, overriddenConfig.OpenAI.DefaultParams.StopWords)
+	// params without overrides are kept
+	require.InDelta(t, 1.0, overriddenConfig.OpenAI.DefaultParams.TopP, 0.0001)
+
+	// the original config is untouched
+	require.InDelta(t, 0.8, modelConfig.OpenAI.DefaultParams.Temperature, 0.0001)
+	require.Equal(t, 100, modelConfig.OpenAI.DefaultParams.MaxTokens)
+}
+
+func TestLangModelConfig_WithParams_ProviderSpecificNames(t *testing.T) {
+	topP, maxTokens := 0.5, 256
+
+	modelConfig := DefaultLangModelConfig()
+	modelConfig.Cohere = cohere.DefaultConfig()
+	modelConfig.Params = &ParamsConfig{TopP: &topP, MaxTokens: &maxTokens}
+
+	overriddenConfig := modelConfig.withParams()
+
+	require.InDelta(t, 0.5, overriddenConfig.Cohere.DefaultParams.P, 0.0001)
+	require.Equal(t, 256, *overriddenConfig.Cohere.DefaultParams.MaxTokens)
+}
+
+func TestLangModelConfig_WithoutParams(t *testing.T) {
+	modelConfig := DefaultLangModelConfig()
+	modelConfig.OpenAI = openai.DefaultConfig()
+
+	require.Same(t, modelConfig, modelConfig.withParams())
+}
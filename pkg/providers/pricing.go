@@ -0,0 +1,43 @@
+package providers
+
+import "glide/pkg/api/schemas"
+
+// Pricing defines model token prices in USD per 1M tokens
+type Pricing struct {
+	PromptTokens       float64  `yaml:"prompt_tokens" json:"prompt_tokens" validate:"gte=0"`
+	ResponseTokens     float64  `yaml:"response_tokens" json:"response_tokens" validate:"gte=0"`
+	CachedPromptTokens *float64 `yaml:"cached_prompt_tokens,omitempty" json:"cached_prompt_tokens,omitempty" validate:"omitempty,gte=0"` // prompt tokens read from the prompt cache, defaults to the prompt token price
+	CacheWriteTokens   *float64 `yaml:"cache_write_tokens,omitempty" json:"cache_write_tokens,omitempty" validate:"omitempty,gte=0"`     // prompt tokens written to the prompt cache, defaults to the prompt token price
+}
+
+// Cost calculates how much the given token usage costs in USD
+func (p *Pricing) Cost(usage schemas.TokenUsage) float64 {
+	uncachedTokens := usage.PromptTokens - usage.CachedPromptTokens - usage.CacheWriteTokens
+
+	promptCost := float64(uncachedTokens)*p.PromptTokens +
+		float64(usage.CachedPromptTokens)*p.cachedPromptPrice() +
+		float64(usage.CacheWriteTokens)*p.cacheWritePrice()
+
+	return (promptCost + float64(usage.ResponseTokens)*p.ResponseTokens) / 1_000_000
+}
+
+// CacheSavings calculates how much reading prompt tokens from the prompt cache has saved in USD
+func (p *Pricing) CacheSavings(usage schemas.TokenUsage) float64 {
+	return float64(usage.CachedPromptTokens) * (p.PromptTokens - p.cachedPromptPrice()) / 1_000_000
+}
+
+func (p *Pricing) cachedPromptPrice() float64 {
+	if p.CachedPromptTokens == nil {
+		return p.PromptTokens
+	}
+
+	return *p.CachedPromptTokens
+}
+
+func (p *Pricing) cacheWritePrice() float64 {
+	if p.CacheWriteTokens == nil {
+		return p.PromptTokens
+	}
+
+	return *p.CacheWriteTokens
+}
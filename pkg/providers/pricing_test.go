@@ -0,0 +1,25 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+)
+
+func TestPricing_PromptCache(t *testing.T) {
+	cachedPrice, writePrice := 0.3, 3.75
+	pricing := &Pricing{PromptTokens: 3, ResponseTokens: 15}
+
+	usage := schemas.TokenUsage{PromptTokens: 1_000_000, ResponseTokens: 1_000_000, CachedPromptTokens: 500_000, CacheWriteTokens: 100_000}
+
+	// cached tokens cost as much as the rest of the prompt unless their price is set
+	require.InDelta(t, 18.0, pricing.Cost(usage), 0.0001)
+	require.Zero(t, pricing.CacheSavings(usage))
+
+	pricing.CachedPromptTokens = &cachedPrice
+	pricing.CacheWriteTokens = &writePrice
+
+	require.InDelta(t, 1.2+0.15+0.375+15, pricing.Cost(usage), 0.0001)
+	require.InDelta(t, 1.35, pricing.CacheSavings(usage), 0.0001)
+}
@@ -0,0 +1,164 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// ProbeMethod is how unhealthy models are checked for recovery
+type ProbeMethod = string
+
+const (
+	ChatProbe     ProbeMethod = "chat"     // a tiny chat request
+	TokenizeProbe ProbeMethod = "tokenize" // a token count request, if the provider has a tokenizer endpoint
+	ModelsProbe   ProbeMethod = "models"   // a model list request, if the provider can list models
+)
+
+// ProbeConfig defines active checks that let unhealthy models recover without risking user requests
+type ProbeConfig struct {
+	Method   ProbeMethod   `yaml:"method" json:"method" validate:"oneof=chat tokenize models"`
+	Interval time.Duration `yaml:"interval" json:"interval" swaggertype:"primitive,string" validate:"gt=0"` // how often unhealthy models are probed
+	Timeout  time.Duration `yaml:"timeout" json:"timeout" swaggertype:"primitive,string" validate:"gt=0"`
+	Prompt   string        `yaml:"prompt" json:"prompt"` // the message of chat & tokenize probes
+}
+
+func DefaultProbeConfig() *ProbeConfig {
+	return &ProbeConfig{
+		Method:   ChatProbe,
+		Interval: 30 * time.Second,
+		Timeout:  10 * time.Second,
+		Prompt:   "ping",
+	}
+}
+
+func (c *ProbeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultProbeConfig()
+
+	type plain ProbeConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// prober checks unhealthy models in the background and marks them healthy once the probe goes through.
+// Healthy models are left alone, as real traffic tells enough about them
+type prober struct {
+	model    *LanguageModel
+	config   *ProbeConfig
+	logger   *zap.Logger
+	mu       sync.Mutex
+	status   *schemas.ProbeStatus
+	stopC    chan struct{}
+	stopOnce sync.Once
+	doneC    chan struct{}
+}
+
+// newProber checks that the provider supports the probe method
+func newProber(model *LanguageModel, config *ProbeConfig, tel *telemetry.Telemetry) (*prober, error) {
+	switch config.Method {
+	case TokenizeProbe:
+		if _, ok := model.client.(TokenCounter); !ok {
+			return nil, fmt.Errorf("model \"%v\": provider has no tokenizer to probe with", model.modelID)
+		}
+	case ModelsProbe:
+		if _, ok := model.client.(ModelLister); !ok {
+			return nil, fmt.Errorf("model \"%v\": provider can't list models to probe with", model.modelID)
+		}
+	}
+
+	return &prober{
+		model:  model,
+		config: config,
+		logger: tel.L().With(
+			zap.String("model", model.modelID),
+			zap.String("provider", model.Provider()),
+		),
+		stopC: make(chan struct{}),
+		doneC: make(chan struct{}),
+	}, nil
+}
+
+// Start probes the model in the background
+func (p *prober) Start() {
+	go p.run()
+}
+
+// Stop terminates the background probing and waits for it to finish
+func (p *prober) Stop() {
+	if p == nil {
+		return
+	}
+
+	p.stopOnce.Do(func() {
+		close(p.stopC)
+	})
+
+	<-p.doneC
+}
+
+// Status returns the outcome of the last probe or nil if the model hasn't been probed yet
+func (p *prober) Status() *schemas.ProbeStatus {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.status
+}
+
+func (p *prober) probe() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.Timeout)
+	defer cancel()
+
+	var err error
+
+	switch p.config.Method {
+	case TokenizeProbe:
+		_, err = p.model.client.(TokenCounter).CountTokens(ctx, schemas.NewTokenizeFromStr(p.config.Prompt))
+	case ModelsProbe:
+		_, err = p.model.client.(ModelLister).ListModels(ctx)
+	default:
+		_, err = p.model.client.Chat(ctx, schemas.NewChatFromStr(p.config.Prompt))
+	}
+
+	status := &schemas.ProbeStatus{ProbedAt: time.Now().UTC().Unix(), Succeeded: err == nil}
+
+	if err != nil {
+		status.Error = err.Error()
+
+		p.logger.Debug("Model probe has failed", zap.Error(err))
+	} else {
+		p.model.healthTracker.Recover()
+
+		p.logger.Info("Model has recovered according to the probe")
+	}
+
+	p.mu.Lock()
+	p.status = status
+	p.mu.Unlock()
+}
+
+func (p *prober) run() {
+	defer close(p.doneC)
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopC:
+			return
+		case <-ticker.C:
+			if !p.model.healthTracker.Healthy() {
+				p.probe()
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package providers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/telemetry"
+)
+
+// probedProviderMock fails chat requests with the given errors one by one and succeeds afterward
+type probedProviderMock struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (p *probedProviderMock) Provider() string { return "probed_mock" }
+
+func (p *probedProviderMock) SupportChatStream() bool { return false }
+
+func (p *probedProviderMock) SupportEmbed() bool { return false }
+
+func (p *probedProviderMock) Chat(_ context.Context, _ *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = p.errs[1:]
+
+		return nil, err
+	}
+
+	return &schemas.ChatResponse{}, nil
+}
+
+func (p *probedProviderMock) ChatStream(_ context.Context, _ *schemas.ChatStreamRequest) (clients.ChatStream, error) {
+	return nil, clients.ErrChatStreamNotImplemented
+}
+
+func (p *probedProviderMock) Embed(_ context.Context, _ *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	return nil, clients.ErrEmbedNotImplemented
+}
+
+func newProbedModel(t *testing.T, errs []error, method ProbeMethod) (*LanguageModel, *prober) {
+	model := NewLangModel("probed", &probedProviderMock{errs: errs}, health.DefaultErrorBudget(), *latency.DefaultConfig(), 1)
+
+	config := DefaultProbeConfig()
+	config.Method = method
+
+	modelProber, err := newProber(model, config, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	model.prober = modelProber
+
+	return model, modelProber
+}
+
+func TestProber_RecoversModel(t *testing.T) {
+	model, modelProber := newProbedModel(t, []error{clients.ErrProviderUnavailable}, ChatProbe)
+
+	model.healthTracker.TrackErr(clients.ErrUnauthorized)
+	require.False(t, model.Healthy())
+
+	modelProber.probe()
+
+	require.False(t, model.Healthy())
+	require.False(t, model.Health().LastProbe.Succeeded)
+	require.Equal(t, clients.ErrProviderUnavailable.Error(), model.Health().LastProbe.Error)
+
+	modelProber.probe()
+
+	require.True(t, model.Healthy())
+	require.True(t, model.Health().LastProbe.Succeeded)
+	require.Empty(t, model.Health().LastProbe.Error)
+}
+
+func TestProber_ProbesUnhealthyModelsOnly(t *testing.T) {
+	model, modelProber := newProbedModel(t, nil, ChatProbe)
+	modelProber.config.Interval = time.Millisecond
+
+	modelProber.Start()
+	defer model.Shutdown()
+
+	time.Sleep(20 * time.Millisecond)
+	require.Nil(t, modelProber.Status())
+
+	model.healthTracker.TrackErr(clients.ErrUnauthorized)
+
+	require.Eventually(t, model.Healthy, time.Second, time.Millisecond)
+	require.True(t, modelProber.Status().Succeeded)
+}
+
+func TestProber_MethodNotSupported(t *testing.T) {
+	model := NewLangModel("probed", &probedProviderMock{}, health.DefaultErrorBudget(), *latency.DefaultConfig(), 1)
+
+	config := DefaultProbeConfig()
+	config.Method = TokenizeProbe
+
+	_, err := newProber(model, config, telemetry.NewTelemetryMock())
+	require.Error(t, err)
+}
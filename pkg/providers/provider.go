@@ -1,6 +1,9 @@
 package providers
 
 import (
+	"context"
+
+	"glide/pkg/api/schemas"
 	"glide/pkg/config/fields"
 )
 
@@ -9,6 +12,21 @@ type ModelProvider interface {
 	Provider() string
 }
 
+// ModelLister is implemented by providers that can list models available upstream
+type ModelLister interface {
+	ListModels(ctx context.Context) ([]schemas.ProviderModel, error)
+}
+
+// ConnWarmer is implemented by providers that can pre-establish connections to their API hosts
+type ConnWarmer interface {
+	WarmUp(ctx context.Context) error
+}
+
+// TokenCounter is implemented by providers that can count prompt tokens with the model tokenizer
+type TokenCounter interface {
+	CountTokens(ctx context.Context, req *schemas.TokenizeRequest) (*schemas.TokenizeResponse, error)
+}
+
 // Model represent a configured external modality-agnostic model with its routing properties and status
 type Model interface {
 	ID() string
@@ -16,3 +34,14 @@ type Model interface {
 	LatencyUpdateInterval() *fields.Duration
 	Weight() int
 }
+
+// healthErr returns the error the model health should be judged by.
+// Once the caller's context is done (e.g. the client-given deadline has passed or the client has gone),
+// the failure says nothing about the model, so it's tracked as a cancellation
+func healthErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return context.Canceled
+	}
+
+	return err
+}
@@ -0,0 +1,84 @@
+package providers
+
+import (
+	"glide/pkg/cache"
+	"glide/pkg/routers/health"
+)
+
+// RateLimitConfig defines the rate limits the provider advertises for the model (e.g. the account tier limits)
+type RateLimitConfig struct {
+	RequestsPerMinute int                `yaml:"requests_per_minute,omitempty" json:"requests_per_minute,omitempty" validate:"gte=0"` // zero means unlimited
+	TokensPerMinute   int                `yaml:"tokens_per_minute,omitempty" json:"tokens_per_minute,omitempty" validate:"gte=0"`     // zero means unlimited
+	Headroom          float64            `yaml:"headroom" json:"headroom" validate:"gt=0,lte=1"`                                      // the share of limits after which the model is deprioritized
+	Redis             *cache.RedisConfig `yaml:"redis,omitempty" json:"redis,omitempty"`                                              // shares the usage between gateway replicas, so they respect the limits together
+}
+
+func DefaultRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		Headroom: 0.9,
+	}
+}
+
+func (c *RateLimitConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultRateLimitConfig()
+
+	type plain RateLimitConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// rateLimiter compares the model usage over the last minute with the advertised rate limits
+type rateLimiter struct {
+	config *RateLimitConfig
+	usage  usageCounter
+}
+
+func newRateLimiter(config *RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		config: config,
+		usage:  health.NewUsageWindow(),
+	}
+}
+
+// Usage returns the largest share of the rate limits used over the last minute
+func (l *rateLimiter) Usage() float64 {
+	if l == nil {
+		return 0
+	}
+
+	requests, tokens := l.usage.Usage()
+	usage := 0.0
+
+	if l.config.RequestsPerMinute > 0 {
+		usage = max(usage, float64(requests)/float64(l.config.RequestsPerMinute))
+	}
+
+	if l.config.TokensPerMinute > 0 {
+		usage = max(usage, float64(tokens)/float64(l.config.TokensPerMinute))
+	}
+
+	return usage
+}
+
+// NearLimit checks if the model is close to or over its rate limits
+func (l *rateLimiter) NearLimit() bool {
+	return l != nil && l.Usage() >= l.config.Headroom
+}
+
+// Track records requests & tokens sent to the provider
+func (l *rateLimiter) Track(requests int, tokens int) {
+	if l != nil {
+		l.usage.Track(requests, tokens)
+	}
+}
+
+// Stop terminates syncing of the shared usage
+func (l *rateLimiter) Stop() {
+	if l == nil {
+		return
+	}
+
+	if usage, ok := l.usage.(*sharedUsageWindow); ok {
+		usage.Stop()
+	}
+}
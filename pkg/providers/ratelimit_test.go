@@ -0,0 +1,24 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Usage(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitConfig{RequestsPerMinute: 10, TokensPerMinute: 1000, Headroom: 0.9})
+
+	limiter.Track(1, 500)
+	require.InDelta(t, 0.5, limiter.Usage(), 0.0001)
+	require.False(t, limiter.NearLimit())
+
+	limiter.Track(1, 400)
+	require.InDelta(t, 0.9, limiter.Usage(), 0.0001)
+	require.True(t, limiter.NearLimit())
+
+	var unlimited *rateLimiter
+
+	require.Zero(t, unlimited.Usage())
+	require.False(t, unlimited.NearLimit())
+}
@@ -0,0 +1,352 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/anthropic"
+	"glide/pkg/providers/azureopenai"
+	"glide/pkg/providers/bedrock"
+	"glide/pkg/providers/clients"
+	"glide/pkg/providers/cohere"
+	"glide/pkg/providers/octoml"
+	"glide/pkg/providers/ollama"
+	"glide/pkg/providers/openai"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// DefaultRegion is the name of the model's own endpoint when the model doesn't name its region
+const DefaultRegion = "default"
+
+var ErrNoHealthyRegions = fmt.Errorf("%w: no healthy regions left", clients.ErrProviderUnavailable)
+
+// RegionConfig defines another regional endpoint of the same model (e.g. Azure westus next to eastus).
+// It's configured the same way as the model provider and shares the rest of the model config
+type RegionConfig struct {
+	Name string `yaml:"name" json:"name" validate:"required"`
+	// Add other providers like
+	OpenAI      *openai.Config      `yaml:"openai,omitempty" json:"openai,omitempty"`
+	AzureOpenAI *azureopenai.Config `yaml:"azureopenai,omitempty" json:"azureopenai,omitempty"`
+	Cohere      *cohere.Config      `yaml:"cohere,omitempty" json:"cohere,omitempty"`
+	OctoML      *octoml.Config      `yaml:"octoml,omitempty" json:"octoml,omitempty"`
+	Anthropic   *anthropic.Config   `yaml:"anthropic,omitempty" json:"anthropic,omitempty"`
+	Bedrock     *bedrock.Config     `yaml:"bedrock,omitempty" json:"bedrock,omitempty"`
+	Ollama      *ollama.Config      `yaml:"ollama,omitempty" json:"ollama,omitempty"`
+}
+
+// regionName returns the name of the model's own endpoint
+func (c *LangModelConfig) regionName() string {
+	if c.Region == "" {
+		return DefaultRegion
+	}
+
+	return c.Region
+}
+
+// forRegion returns a copy of the model config pointing to the regional endpoint
+func (c *LangModelConfig) forRegion(region *RegionConfig) *LangModelConfig {
+	regionConfig := *c
+
+	regionConfig.Region = region.Name
+	regionConfig.Regions = nil
+	regionConfig.OpenAI = region.OpenAI
+	regionConfig.AzureOpenAI = region.AzureOpenAI
+	regionConfig.Cohere = region.Cohere
+	regionConfig.OctoML = region.OctoML
+	regionConfig.Anthropic = region.Anthropic
+	regionConfig.Bedrock = region.Bedrock
+	regionConfig.Ollama = region.Ollama
+
+	return &regionConfig
+}
+
+func (c *LangModelConfig) validateRegions() error {
+	names := map[string]struct{}{c.regionName(): {}}
+
+	for _, region := range c.Regions {
+		if _, found := names[region.Name]; found {
+			return fmt.Errorf("model \"%v\" has more than one region named \"%v\"", c.ID, region.Name)
+		}
+
+		names[region.Name] = struct{}{}
+
+		if err := c.forRegion(region).validateOneProvider(); err != nil {
+			return fmt.Errorf("region \"%v\": %w", region.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// initRegionalClient puts the model client and clients of its regional endpoints behind one provider that fails over between them
+func (c *LangModelConfig) initRegionalClient(client LangProvider, tel *telemetry.Telemetry) (LangProvider, error) {
+	regions := make([]*region, 0, len(c.Regions)+1)
+	regions = append(regions, newRegion(c.regionName(), client, c.ErrorBudget, *c.Latency))
+
+	for _, regionConfig := range c.Regions {
+		regionClient, err := c.forRegion(regionConfig).withParams().initClient(tel)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing client of region \"%v\": %v", regionConfig.Name, err)
+		}
+
+		if regionClient.Provider() != client.Provider() {
+			return nil, fmt.Errorf(
+				"region \"%v\" of model \"%v\" must use the model provider (%v), not %v",
+				regionConfig.Name,
+				c.ID,
+				client.Provider(),
+				regionClient.Provider(),
+			)
+		}
+
+		regions = append(regions, newRegion(regionConfig.Name, regionClient, c.ErrorBudget, *c.Latency))
+	}
+
+	return newRegionalProvider(c.ID, regions, tel), nil
+}
+
+// region is one endpoint of the regional model with its own health & latency tracking
+type region struct {
+	name              string
+	client            LangProvider
+	healthTracker     *health.Tracker
+	chatLatency       *latency.MovingAverage
+	chatStreamLatency *latency.MovingAverage
+	embedLatency      *latency.MovingAverage
+}
+
+func newRegion(name string, client LangProvider, budget *health.ErrorBudget, latencyConfig latency.Config) *region {
+	return &region{
+		name:              name,
+		client:            client,
+		healthTracker:     health.NewTracker(budget),
+		chatLatency:       latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		chatStreamLatency: latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		embedLatency:      latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+	}
+}
+
+func (r *region) Health() schemas.RegionHealth {
+	return schemas.RegionHealth{
+		Name:            r.name,
+		Healthy:         r.healthTracker.Healthy(),
+		Unauthorized:    r.healthTracker.Unauthorized(),
+		ErrorBudgetLeft: r.healthTracker.ErrBudgetLeft(),
+		ErrorRate:       r.healthTracker.ErrorRate(),
+		Latency: schemas.ModelLatency{
+			Chat:       r.chatLatency.Value(),
+			ChatStream: r.chatStreamLatency.Value(),
+			Embed:      r.embedLatency.Value(),
+		},
+	}
+}
+
+// regionalProvider serves requests from the first healthy region in the configured order
+// and fails over to the next one when the region fails the request.
+// To the router, the regional model is one model that fails only when all its regions do
+type regionalProvider struct {
+	regions   []*region
+	failovers *telemetry.Counter
+	logger    *zap.Logger
+}
+
+func newRegionalProvider(modelID string, regions []*region, tel *telemetry.Telemetry) *regionalProvider {
+	return &regionalProvider{
+		regions:   regions,
+		failovers: tel.M().Counter(fmt.Sprintf("models.%v.region_failovers", modelID)),
+		logger: tel.L().With(
+			zap.String("model", modelID),
+			zap.String("provider", regions[0].client.Provider()),
+		),
+	}
+}
+
+func (p *regionalProvider) Provider() string {
+	return p.regions[0].client.Provider()
+}
+
+func (p *regionalProvider) SupportChatStream() bool {
+	return p.regions[0].client.SupportChatStream()
+}
+
+func (p *regionalProvider) SupportEmbed() bool {
+	return p.regions[0].client.SupportEmbed()
+}
+
+// Health returns the current health state & latencies of each region
+func (p *regionalProvider) Health() []schemas.RegionHealth {
+	regionHealth := make([]schemas.RegionHealth, 0, len(p.regions))
+
+	for _, region := range p.regions {
+		regionHealth = append(regionHealth, region.Health())
+	}
+
+	return regionHealth
+}
+
+// WarmUp keeps connections of all regions warm, so failovers don't pay for new connections
+func (p *regionalProvider) WarmUp(ctx context.Context) error {
+	errs := make([]error, 0, len(p.regions))
+
+	for _, region := range p.regions {
+		if warmer, ok := region.client.(ConnWarmer); ok {
+			errs = append(errs, warmer.WarmUp(ctx))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *regionalProvider) Chat(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	err := ErrNoHealthyRegions
+
+	for _, region := range p.healthyRegions() {
+		startedAt := time.Now()
+
+		var resp *schemas.ChatResponse
+
+		resp, err = region.client.Chat(ctx, req)
+		if err == nil {
+			region.healthTracker.TrackSuccess()
+			region.chatLatency.Add(float64(time.Since(startedAt)) / float64(max(resp.ModelResponse.TokenUsage.ResponseTokens, 1)))
+
+			return resp, nil
+		}
+
+		if !p.failover(ctx, region, err) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+func (p *regionalProvider) ChatStream(ctx context.Context, req *schemas.ChatStreamRequest) (clients.ChatStream, error) {
+	return &regionalChatStream{ctx: ctx, req: req, provider: p}, nil
+}
+
+func (p *regionalProvider) Embed(ctx context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	err := ErrNoHealthyRegions
+
+	for _, region := range p.healthyRegions() {
+		startedAt := time.Now()
+
+		var resp *schemas.EmbedResponse
+
+		resp, err = region.client.Embed(ctx, req)
+		if err == nil {
+			region.healthTracker.TrackSuccess()
+			region.embedLatency.Add(float64(time.Since(startedAt)) / float64(max(resp.ModelResponse.TokenUsage.PromptTokens, 1)))
+
+			return resp, nil
+		}
+
+		if !p.failover(ctx, region, err) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+func (p *regionalProvider) healthyRegions() []*region {
+	regions := make([]*region, 0, len(p.regions))
+
+	for _, region := range p.regions {
+		if region.healthTracker.Healthy() {
+			regions = append(regions, region)
+		}
+	}
+
+	return regions
+}
+
+// failover records the region error and tells if the request should be retried in the next region.
+// Errors other regions would fail the same way with (e.g. blocked content or cancelled requests) are returned right away
+func (p *regionalProvider) failover(ctx context.Context, region *region, err error) bool {
+	region.healthTracker.TrackErr(healthErr(ctx, err))
+
+	if errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, clients.ErrContentFiltered) ||
+		errors.Is(err, clients.ErrContextTooLong) ||
+		errors.Is(err, clients.ErrChatStreamNotImplemented) ||
+		errors.Is(err, clients.ErrEmbedNotImplemented) {
+		return false
+	}
+
+	p.failovers.Inc()
+	p.logger.Warn("Region has failed the request, failing over", zap.String("region", region.name), zap.Error(err))
+
+	return true
+}
+
+// regionalChatStream opens the stream in the first healthy region that accepts it.
+// Once chunks have been sent, the stream stays in its region, as the client has received a part of the response already
+type regionalChatStream struct {
+	ctx      context.Context
+	req      *schemas.ChatStreamRequest
+	provider *regionalProvider
+	region   *region
+	stream   clients.ChatStream
+}
+
+func (s *regionalChatStream) Open() error {
+	err := ErrNoHealthyRegions
+
+	for _, region := range s.provider.healthyRegions() {
+		var stream clients.ChatStream
+
+		startedAt := time.Now()
+
+		stream, err = region.client.ChatStream(s.ctx, s.req)
+		if err == nil {
+			err = stream.Open()
+		}
+
+		if err == nil {
+			region.chatStreamLatency.Add(float64(time.Since(startedAt)))
+
+			s.region = region
+			s.stream = stream
+
+			return nil
+		}
+
+		if !s.provider.failover(s.ctx, region, err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (s *regionalChatStream) Recv() (*schemas.ChatStreamChunk, error) {
+	chunk, err := s.stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.region.healthTracker.TrackSuccess()
+		} else {
+			s.region.healthTracker.TrackErr(healthErr(s.ctx, err))
+		}
+
+		return nil, err
+	}
+
+	return chunk, nil
+}
+
+func (s *regionalChatStream) Close() error {
+	if s.stream == nil {
+		return nil
+	}
+
+	return s.stream.Close()
+}
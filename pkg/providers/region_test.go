@@ -0,0 +1,156 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/providers/openai"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/telemetry"
+)
+
+// regionMock fails all requests with the given error or serves them if there is none
+type regionMock struct {
+	err   error
+	calls int
+}
+
+func (p *regionMock) Provider() string { return "region_mock" }
+
+func (p *regionMock) SupportChatStream() bool { return true }
+
+func (p *regionMock) SupportEmbed() bool { return false }
+
+func (p *regionMock) Chat(_ context.Context, _ *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	p.calls++
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &schemas.ChatResponse{ModelResponse: schemas.ModelResponse{TokenUsage: schemas.TokenUsage{ResponseTokens: 1}}}, nil
+}
+
+func (p *regionMock) ChatStream(_ context.Context, _ *schemas.ChatStreamRequest) (clients.ChatStream, error) {
+	p.calls++
+
+	return &regionStreamMock{err: p.err}, nil
+}
+
+func (p *regionMock) Embed(_ context.Context, _ *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	return nil, clients.ErrEmbedNotImplemented
+}
+
+type regionStreamMock struct {
+	err error
+}
+
+func (s *regionStreamMock) Open() error { return s.err }
+
+func (s *regionStreamMock) Recv() (*schemas.ChatStreamChunk, error) { return nil, io.EOF }
+
+func (s *regionStreamMock) Close() error { return nil }
+
+func newRegionalMock(clients ...*regionMock) *regionalProvider {
+	regions := make([]*region, 0, len(clients))
+
+	for i, client := range clients {
+		regions = append(regions, newRegion(string(rune('a'+i)), client, health.DefaultErrorBudget(), *latency.DefaultConfig()))
+	}
+
+	return newRegionalProvider("regional", regions, telemetry.NewTelemetryMock())
+}
+
+func TestRegionalProvider_ChatFailsOver(t *testing.T) {
+	eastus := &regionMock{err: clients.ErrProviderUnavailable}
+	westus := &regionMock{}
+
+	provider := newRegionalMock(eastus, westus)
+
+	_, err := provider.Chat(context.Background(), schemas.NewChatFromStr("hello"))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, eastus.calls)
+	require.Equal(t, 1, westus.calls)
+
+	regionHealth := provider.Health()
+	require.Len(t, regionHealth, 2)
+	require.Less(t, regionHealth[0].ErrorBudgetLeft, regionHealth[1].ErrorBudgetLeft)
+}
+
+func TestRegionalProvider_SkipsUnhealthyRegions(t *testing.T) {
+	eastus := &regionMock{err: clients.ErrUnauthorized}
+	westus := &regionMock{}
+
+	provider := newRegionalMock(eastus, westus)
+
+	for range 2 {
+		_, err := provider.Chat(context.Background(), schemas.NewChatFromStr("hello"))
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, eastus.calls)
+	require.Equal(t, 2, westus.calls)
+	require.False(t, provider.Health()[0].Healthy)
+
+	westus.err = clients.ErrUnauthorized
+
+	_, err := provider.Chat(context.Background(), schemas.NewChatFromStr("hello"))
+	require.ErrorIs(t, err, clients.ErrUnauthorized)
+
+	_, err = provider.Chat(context.Background(), schemas.NewChatFromStr("hello"))
+	require.ErrorIs(t, err, ErrNoHealthyRegions)
+	require.ErrorIs(t, err, clients.ErrProviderUnavailable)
+}
+
+func TestRegionalProvider_NoFailoverOnFilteredContent(t *testing.T) {
+	eastus := &regionMock{err: clients.ErrContentFiltered}
+	westus := &regionMock{}
+
+	provider := newRegionalMock(eastus, westus)
+
+	_, err := provider.Chat(context.Background(), schemas.NewChatFromStr("hello"))
+	require.ErrorIs(t, err, clients.ErrContentFiltered)
+	require.Equal(t, 0, westus.calls)
+}
+
+func TestRegionalProvider_ChatStreamFailsOver(t *testing.T) {
+	eastus := &regionMock{err: clients.ErrProviderUnavailable}
+	westus := &regionMock{}
+
+	model := NewLangModel("regional", newRegionalMock(eastus, westus), health.DefaultErrorBudget(), *latency.DefaultConfig(), 1)
+
+	streamResultC, err := model.ChatStream(context.Background(), schemas.NewChatStreamFromStr("hello"))
+	require.NoError(t, err)
+
+	for range streamResultC {
+	}
+
+	require.Equal(t, 1, westus.calls)
+
+	modelHealth := model.Health()
+	require.True(t, modelHealth.Healthy)
+	require.Len(t, modelHealth.Regions, 2)
+	require.Equal(t, "a", modelHealth.Regions[0].Name)
+}
+
+func TestLangModelConfig_ValidateRegions(t *testing.T) {
+	config := DefaultLangModelConfig()
+	config.ID = "gpt4"
+	config.Region = "eastus"
+	config.OpenAI = openai.DefaultConfig()
+	config.Regions = []*RegionConfig{{Name: "westus", OpenAI: openai.DefaultConfig()}}
+
+	require.NoError(t, config.validateRegions())
+
+	config.Regions = append(config.Regions, &RegionConfig{Name: "eastus", OpenAI: openai.DefaultConfig()})
+	require.Error(t, config.validateRegions())
+
+	config.Regions = []*RegionConfig{{Name: "westus"}}
+	require.Error(t, config.validateRegions())
+}
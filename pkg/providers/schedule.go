@@ -0,0 +1,130 @@
+package providers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const timeOfDayLayout = "15:04"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// TimeWindowConfig defines a daily period the model serves traffic in
+type TimeWindowConfig struct {
+	Days []string `yaml:"days,omitempty" json:"days,omitempty" validate:"dive,oneof=mon tue wed thu fri sat sun"` // days the window starts on, every day by default
+	From string   `yaml:"from" json:"from" validate:"required,datetime=15:04"`                                    // the window start (HH:MM)
+	To   string   `yaml:"to" json:"to" validate:"required,datetime=15:04"`                                        // the window end (HH:MM), windows ending earlier than they start span midnight
+}
+
+// ScheduleConfig limits the model to serve traffic in the given time windows only
+// (e.g. use the self-hosted cluster during business hours & burst to SaaS providers overnight)
+type ScheduleConfig struct {
+	Timezone string             `yaml:"timezone,omitempty" json:"timezone,omitempty"` // IANA timezone name the windows are defined in, UTC by default
+	Windows  []TimeWindowConfig `yaml:"windows" json:"windows" validate:"required,min=1,dive"`
+}
+
+type timeWindow struct {
+	days []time.Weekday
+	from time.Duration // since midnight
+	to   time.Duration // since midnight
+}
+
+// Schedule tells whether the model is active at the given moment
+type Schedule struct {
+	location *time.Location
+	windows  []timeWindow
+}
+
+func NewSchedule(config *ScheduleConfig) (*Schedule, error) {
+	location := time.UTC
+
+	if config.Timezone != "" {
+		var err error
+
+		location, err = time.LoadLocation(config.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule timezone \"%v\": %w", config.Timezone, err)
+		}
+	}
+
+	windows := make([]timeWindow, 0, len(config.Windows))
+
+	for _, windowConfig := range config.Windows {
+		window := timeWindow{days: make([]time.Weekday, 0, len(windowConfig.Days))}
+
+		for _, day := range windowConfig.Days {
+			weekday, found := weekdays[strings.ToLower(day)]
+			if !found {
+				return nil, fmt.Errorf("invalid schedule day \"%v\"", day)
+			}
+
+			window.days = append(window.days, weekday)
+		}
+
+		from, err := parseTimeOfDay(windowConfig.From)
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := parseTimeOfDay(windowConfig.To)
+		if err != nil {
+			return nil, err
+		}
+
+		window.from, window.to = from, to
+		windows = append(windows, window)
+	}
+
+	return &Schedule{
+		location: location,
+		windows:  windows,
+	}, nil
+}
+
+// Active checks if the moment falls into any of the schedule windows
+func (s *Schedule) Active(moment time.Time) bool {
+	moment = moment.In(s.location)
+
+	sinceMidnight := time.Duration(moment.Hour())*time.Hour + time.Duration(moment.Minute())*time.Minute
+	today, yesterday := moment.Weekday(), (moment.Weekday()+6)%7
+
+	for _, window := range s.windows {
+		if window.from <= window.to {
+			if window.startsOn(today) && sinceMidnight >= window.from && sinceMidnight < window.to {
+				return true
+			}
+
+			continue
+		}
+
+		// the window spans midnight, so it may have started either today or yesterday
+		if (window.startsOn(today) && sinceMidnight >= window.from) || (window.startsOn(yesterday) && sinceMidnight < window.to) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w timeWindow) startsOn(day time.Weekday) bool {
+	return len(w.days) == 0 || slices.Contains(w.days, day)
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	timeOfDay, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schedule time \"%v\", expected HH:MM: %w", value, err)
+	}
+
+	return time.Duration(timeOfDay.Hour())*time.Hour + time.Duration(timeOfDay.Minute())*time.Minute, nil
+}
@@ -0,0 +1,62 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers/openai"
+	"glide/pkg/telemetry"
+)
+
+func TestSchedule_Active(t *testing.T) {
+	schedule, err := NewSchedule(&ScheduleConfig{
+		Timezone: "Europe/Berlin",
+		Windows: []TimeWindowConfig{
+			{Days: []string{"mon", "tue", "wed", "thu", "fri"}, From: "09:00", To: "18:00"},
+			{Days: []string{"sat"}, From: "22:00", To: "02:00"},
+		},
+	})
+	require.NoError(t, err)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		moment time.Time
+		active bool
+	}{
+		"business hours":            {time.Date(2024, 3, 4, 10, 30, 0, 0, berlin), true},
+		"business hours in UTC":     {time.Date(2024, 3, 4, 16, 30, 0, 0, time.UTC), true},
+		"after business hours":      {time.Date(2024, 3, 4, 18, 0, 0, 0, berlin), false},
+		"weekend":                   {time.Date(2024, 3, 9, 10, 30, 0, 0, berlin), false},
+		"overnight window start":    {time.Date(2024, 3, 9, 23, 0, 0, 0, berlin), true},
+		"overnight window next day": {time.Date(2024, 3, 10, 1, 0, 0, 0, berlin), true},
+		"overnight window end":      {time.Date(2024, 3, 10, 2, 0, 0, 0, berlin), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.active, schedule.Active(tc.moment))
+		})
+	}
+}
+
+func TestLangModelConfig_Schedule(t *testing.T) {
+	modelConfig := DefaultLangModelConfig()
+	modelConfig.ID = "gpt3"
+	modelConfig.OpenAI = openai.DefaultConfig()
+	modelConfig.OpenAI.APIKey = "ABC"
+	modelConfig.Schedule = &ScheduleConfig{Timezone: "Mars/Olympus", Windows: []TimeWindowConfig{{From: "09:00", To: "18:00"}}}
+
+	_, err := modelConfig.ToModel(telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "invalid schedule timezone")
+
+	// windows starting & ending at the same time are empty
+	modelConfig.Schedule = &ScheduleConfig{Windows: []TimeWindowConfig{{From: "00:00", To: "00:00"}}}
+
+	model, err := modelConfig.ToModel(telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.False(t, model.Healthy())
+	require.False(t, model.Health().Active)
+}
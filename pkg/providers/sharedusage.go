@@ -0,0 +1,170 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"glide/pkg/cache"
+	"glide/pkg/routers/health"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const usageSyncInterval = 1 * time.Second
+
+// usageCounter counts requests & tokens sent to the model over the last minute
+type usageCounter interface {
+	Track(requests int, tokens int)
+	Usage() (int, int)
+}
+
+var _ usageCounter = (*health.UsageWindow)(nil)
+
+// sharedUsageWindow counts the model usage of all gateway replicas in Redis, so each replica compares
+// the total usage with the rate limits of the provider account.
+// Usage is synced in the background, so routing never waits for Redis.
+// When Redis is unavailable, the replica falls back to its own usage
+type sharedUsageWindow struct {
+	client          redis.UniversalClient
+	keyPrefix       string
+	local           *health.UsageWindow
+	pendingRequests atomic.Int64 // tracked, but not synced yet
+	pendingTokens   atomic.Int64
+	sharedRequests  atomic.Int64 // the usage of all replicas as of the last sync
+	sharedTokens    atomic.Int64
+	logger          *zap.Logger
+	stopC           chan struct{}
+	stopOnce        sync.Once
+	doneC           chan struct{}
+}
+
+var _ usageCounter = (*sharedUsageWindow)(nil)
+
+// newSharedUsageWindow starts syncing the usage of the model.
+// Models with the same provider & ID share their usage, as the limits belong to the provider account
+func newSharedUsageWindow(provider string, modelID string, cfg *cache.RedisConfig, tel *telemetry.Telemetry) (*sharedUsageWindow, error) {
+	client, err := cache.NewRedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &sharedUsageWindow{
+		client: client,
+		// the hash tag keeps counters of the model on the same cluster shard, so they are synced in one pipeline
+		keyPrefix: fmt.Sprintf("%vratelimit:{%v/%v}", cfg.KeyPrefix, provider, modelID),
+		local:     health.NewUsageWindow(),
+		logger: tel.L().With(
+			zap.String("model", modelID),
+			zap.String("provider", provider),
+		),
+		stopC: make(chan struct{}),
+		doneC: make(chan struct{}),
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+func (w *sharedUsageWindow) Track(requests int, tokens int) {
+	w.local.Track(requests, tokens)
+	w.pendingRequests.Add(int64(requests))
+	w.pendingTokens.Add(int64(tokens))
+}
+
+// Usage returns the shared usage unless the replica's own usage is larger (e.g. Redis is unavailable)
+func (w *sharedUsageWindow) Usage() (int, int) {
+	localRequests, localTokens := w.local.Usage()
+
+	requests := int(w.sharedRequests.Load() + w.pendingRequests.Load())
+	tokens := int(w.sharedTokens.Load() + w.pendingTokens.Load())
+
+	return max(requests, localRequests), max(tokens, localTokens)
+}
+
+// Stop terminates the background syncing and closes the Redis client
+func (w *sharedUsageWindow) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopC)
+	})
+
+	<-w.doneC
+}
+
+func (w *sharedUsageWindow) run() {
+	defer close(w.doneC)
+
+	ticker := time.NewTicker(usageSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopC:
+			w.sync()
+
+			if err := w.client.Close(); err != nil {
+				w.logger.Warn("failed to close redis client", zap.Error(err))
+			}
+
+			return
+		case <-ticker.C:
+			w.sync()
+		}
+	}
+}
+
+// sync adds the pending usage to the counters of the current minute & reads the usage of the last minute back.
+// The last minute usage is estimated from counters of the current & previous minutes
+func (w *sharedUsageWindow) sync() {
+	ctx, cancel := context.WithTimeout(context.Background(), usageSyncInterval)
+	defer cancel()
+
+	requests, tokens := w.pendingRequests.Swap(0), w.pendingTokens.Swap(0)
+
+	now := time.Now()
+	minute := now.Unix() / 60
+	elapsed := now.Sub(time.Unix(minute*60, 0))
+
+	var currRequests, currTokens, prevRequests, prevTokens *redis.IntCmd
+
+	_, err := w.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		currRequests = pipe.IncrBy(ctx, w.key(minute, "requests"), requests)
+		currTokens = pipe.IncrBy(ctx, w.key(minute, "tokens"), tokens)
+		pipe.Expire(ctx, w.key(minute, "requests"), 2*time.Minute)
+		pipe.Expire(ctx, w.key(minute, "tokens"), 2*time.Minute)
+		// adding zero reads counters of the previous minute even if they don't exist, unlike GET failing the pipeline
+		prevRequests = pipe.IncrBy(ctx, w.key(minute-1, "requests"), 0)
+		prevTokens = pipe.IncrBy(ctx, w.key(minute-1, "tokens"), 0)
+		pipe.Expire(ctx, w.key(minute-1, "requests"), 1*time.Minute)
+		pipe.Expire(ctx, w.key(minute-1, "tokens"), 1*time.Minute)
+
+		return nil
+	})
+	if err != nil {
+		// the usage is retried on the next sync
+		w.pendingRequests.Add(requests)
+		w.pendingTokens.Add(tokens)
+
+		w.logger.Warn("failed to sync rate limit usage with redis", zap.Error(err))
+
+		return
+	}
+
+	w.sharedRequests.Store(slidingCount(prevRequests.Val(), currRequests.Val(), elapsed))
+	w.sharedTokens.Store(slidingCount(prevTokens.Val(), currTokens.Val(), elapsed))
+}
+
+func (w *sharedUsageWindow) key(minute int64, counter string) string {
+	return fmt.Sprintf("%v:%v:%v", w.keyPrefix, minute, counter)
+}
+
+// slidingCount estimates the count over the last minute assuming the previous minute count was spread evenly
+func slidingCount(previous int64, current int64, elapsed time.Duration) int64 {
+	previousShare := 1 - min(elapsed.Seconds()/60, 1)
+
+	return current + int64(float64(previous)*previousShare)
+}
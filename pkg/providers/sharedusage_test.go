@@ -0,0 +1,40 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/cache"
+	"glide/pkg/config/fields"
+	"glide/pkg/telemetry"
+)
+
+func TestSlidingCount(t *testing.T) {
+	require.Equal(t, int64(15), slidingCount(10, 10, 30*time.Second))
+	require.Equal(t, int64(20), slidingCount(10, 10, 0))
+	require.Equal(t, int64(10), slidingCount(10, 10, 90*time.Second))
+}
+
+func TestSharedUsageWindow_FallsBackToLocalUsage(t *testing.T) {
+	dialTimeout := 100 * time.Millisecond
+
+	cfg := cache.DefaultRedisConfig()
+	cfg.Address = "127.0.0.1:1" // nothing listens there
+	cfg.DialTimeout = (*fields.Duration)(&dialTimeout)
+
+	usage, err := newSharedUsageWindow("openai", "gpt-4o", cfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	usage.Track(1, 100)
+	usage.sync()
+
+	requests, tokens := usage.Usage()
+	require.Equal(t, 1, requests)
+	require.Equal(t, 100, tokens)
+
+	// the failed sync keeps the usage to retry it
+	require.Equal(t, int64(1), usage.pendingRequests.Load())
+
+	usage.Stop()
+}
@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers/clients"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+)
+
+// SingleActionModel wraps a provider client serving one action (e.g. image generation)
+// and expend it with health & latency tracking
+type SingleActionModel[Req any, Resp schemas.RoutedResponse] struct {
+	modelID               string
+	weight                int
+	provider              ModelProvider
+	serve                 func(ctx context.Context, req Req) (Resp, error)
+	units                 func(req Req, resp Resp) float64
+	healthTracker         *health.Tracker
+	latency               *latency.MovingAverage
+	latencyUpdateInterval *fields.Duration
+	warmer                *clients.ConnWarmer
+}
+
+// newSingleActionModel creates a model calling serve on requests.
+// The latency is normalized by the number of units (e.g. images) returned by units
+func newSingleActionModel[Req any, Resp schemas.RoutedResponse](
+	modelID string,
+	provider ModelProvider,
+	serve func(ctx context.Context, req Req) (Resp, error),
+	units func(req Req, resp Resp) float64,
+	budget *health.ErrorBudget,
+	latencyConfig latency.Config,
+	weight int,
+) *SingleActionModel[Req, Resp] {
+	return &SingleActionModel[Req, Resp]{
+		modelID:               modelID,
+		provider:              provider,
+		serve:                 serve,
+		units:                 units,
+		healthTracker:         health.NewTracker(budget),
+		latency:               latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		latencyUpdateInterval: latencyConfig.UpdateInterval,
+		weight:                weight,
+	}
+}
+
+func (m SingleActionModel[Req, Resp]) ID() string {
+	return m.modelID
+}
+
+func (m SingleActionModel[Req, Resp]) Healthy() bool {
+	return m.healthTracker.Healthy()
+}
+
+func (m SingleActionModel[Req, Resp]) Weight() int {
+	return m.weight
+}
+
+func (m SingleActionModel[Req, Resp]) LatencyUpdateInterval() *fields.Duration {
+	return m.latencyUpdateInterval
+}
+
+// Latency returns the moving average latency of the model action
+func (m SingleActionModel[Req, Resp]) Latency() *latency.MovingAverage {
+	return m.latency
+}
+
+func (m *SingleActionModel[Req, Resp]) Provider() string {
+	return m.provider.Provider()
+}
+
+// Shutdown stops background activities of the model
+func (m *SingleActionModel[Req, Resp]) Shutdown() {
+	m.warmer.Stop()
+}
+
+func (m *SingleActionModel[Req, Resp]) Serve(ctx context.Context, req Req) (Resp, error) {
+	if err := m.healthTracker.Allow(); err != nil {
+		var resp Resp
+
+		return resp, err
+	}
+
+	startedAt := time.Now()
+
+	resp, err := m.serve(ctx, req)
+	if err != nil {
+		m.healthTracker.TrackErr(healthErr(ctx, err))
+
+		return resp, err
+	}
+
+	m.warmer.Touch()
+	m.healthTracker.TrackSuccess()
+
+	m.latency.Add(float64(time.Since(startedAt)) / max(m.units(req, resp), 1))
+
+	resp.SetModelID(m.modelID)
+
+	return resp, err
+}
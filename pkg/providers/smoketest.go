@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrSmokeTestUnsupported = errors.New("provider can't be checked without sending a chat request")
+
+// SmokeTest is how the model provider was checked
+type SmokeTest string
+
+const (
+	ModelsSmokeTest     SmokeTest = "models"     // listed upstream models, so both the connection & the API key work
+	ConnectionSmokeTest SmokeTest = "connection" // connected to the provider API host, the API key is not checked
+)
+
+// SmokeTest checks the model provider is reachable without spending tokens
+func (m *LanguageModel) SmokeTest(ctx context.Context) (SmokeTest, error) {
+	if lister, ok := m.client.(ModelLister); ok {
+		_, err := lister.ListModels(ctx)
+
+		return ModelsSmokeTest, err
+	}
+
+	if warmer, ok := m.client.(ConnWarmer); ok {
+		return ConnectionSmokeTest, warmer.WarmUp(ctx)
+	}
+
+	return "", ErrSmokeTestUnsupported
+}
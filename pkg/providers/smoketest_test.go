@@ -0,0 +1,53 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+)
+
+type listingProviderMock struct {
+	probedProviderMock
+	err error
+}
+
+func (p *listingProviderMock) ListModels(_ context.Context) ([]schemas.ProviderModel, error) {
+	return nil, p.err
+}
+
+type warmingProviderMock struct {
+	probedProviderMock
+}
+
+func (p *warmingProviderMock) WarmUp(_ context.Context) error {
+	return nil
+}
+
+func TestLanguageModel_SmokeTest(t *testing.T) {
+	newModel := func(client LangProvider) *LanguageModel {
+		return NewLangModel("smoke", client, health.DefaultErrorBudget(), *latency.DefaultConfig(), 1)
+	}
+
+	ctx := context.Background()
+	errUnauthorized := errors.New("invalid API key")
+
+	smokeTest, err := newModel(&listingProviderMock{}).SmokeTest(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ModelsSmokeTest, smokeTest)
+
+	smokeTest, err = newModel(&listingProviderMock{err: errUnauthorized}).SmokeTest(ctx)
+	require.ErrorIs(t, err, errUnauthorized)
+	require.Equal(t, ModelsSmokeTest, smokeTest)
+
+	smokeTest, err = newModel(&warmingProviderMock{}).SmokeTest(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ConnectionSmokeTest, smokeTest)
+
+	_, err = newModel(&probedProviderMock{}).SmokeTest(ctx)
+	require.ErrorIs(t, err, ErrSmokeTestUnsupported)
+}
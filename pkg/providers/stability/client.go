@@ -0,0 +1,57 @@
+package stability
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+const (
+	providerName = "stability"
+)
+
+// ErrEmptyResponse is returned when the Stability API returns an empty response.
+var (
+	ErrEmptyResponse = clients.ErrEmptyResponse
+)
+
+// Client is a client for accessing Stability AI API
+type Client struct {
+	baseURL    string
+	imageURL   string
+	errMapper  *ErrorMapper
+	config     *Config
+	httpClient *http.Client
+	tel        *telemetry.Telemetry
+}
+
+// NewClient creates a new Stability client for the Stability AI API.
+func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *telemetry.Telemetry) (*Client, error) {
+	imageURL, err := url.JoinPath(providerConfig.BaseURL, "/v1/generation", providerConfig.Engine, "/text-to-image")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:    providerConfig.BaseURL,
+		imageURL:   imageURL,
+		config:     providerConfig,
+		errMapper:  NewErrorMapper(tel),
+		httpClient: clients.CaptureTraffic(clients.NewHTTPClient(clientConfig), providerName, tel.Capture),
+		tel:        tel,
+	}
+
+	return c, nil
+}
+
+func (c *Client) Provider() string {
+	return providerName
+}
+
+// WarmUp pre-establishes a connection to the provider API host
+func (c *Client) WarmUp(ctx context.Context) error {
+	return clients.WarmUp(ctx, c.httpClient, c.baseURL)
+}
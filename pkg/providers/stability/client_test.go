@@ -0,0 +1,79 @@
+package stability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+)
+
+func TestStabilityClient_GenerateImageRequest(t *testing.T) {
+	stabilityMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var imageRequest ImageRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&imageRequest); err != nil {
+			t.Errorf("error decoding payload: %v", err)
+		}
+
+		require.Equal(t, "/v1/generation/stable-diffusion-xl-1024-v1-0/text-to-image", r.URL.Path)
+		require.Equal(t, 512, imageRequest.Width)
+		require.Equal(t, 768, imageRequest.Height)
+
+		imageResponse, err := os.ReadFile(filepath.Clean("./testdata/image.success.json"))
+		if err != nil {
+			t.Errorf("error reading stability image mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(imageResponse)
+		if err != nil {
+			t.Errorf("error on sending image response: %v", err)
+		}
+	})
+
+	stabilityServer := httptest.NewServer(stabilityMock)
+	defer stabilityServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = stabilityServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	request := schemas.NewImageGenerateFromStr("a lighthouse on a cliff")
+	request.Size = "512x768"
+
+	response, err := client.GenerateImage(context.Background(), request)
+	require.NoError(t, err)
+
+	require.Len(t, response.ModelResponse.Images, 1)
+	require.NotEmpty(t, response.ModelResponse.Images[0].B64JSON)
+	require.Equal(t, int64(1050625087), *response.ModelResponse.Images[0].Seed)
+}
+
+func TestStabilityClient_Unauthorized(t *testing.T) {
+	stabilityMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	stabilityServer := httptest.NewServer(stabilityMock)
+	defer stabilityServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = stabilityServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	_, err = client.GenerateImage(context.Background(), schemas.NewImageGenerateFromStr("a lighthouse"))
+	require.ErrorIs(t, err, clients.ErrUnauthorized)
+}
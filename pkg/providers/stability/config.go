@@ -0,0 +1,60 @@
+package stability
+
+import (
+	"glide/pkg/config/fields"
+)
+
+// Params defines Stability-specific model params with the specific validation of values
+type Params struct {
+	CfgScale float64 `yaml:"cfg_scale,omitempty" json:"cfg_scale" validate:"gte=0,lte=35"`
+	Steps    int     `yaml:"steps,omitempty" json:"steps" validate:"gte=10,lte=50"`
+	Height   int     `yaml:"height,omitempty" json:"height"`
+	Width    int     `yaml:"width,omitempty" json:"width"`
+	Samples  int     `yaml:"samples,omitempty" json:"samples" validate:"gte=1,lte=10"`
+	Sampler  string  `yaml:"sampler,omitempty" json:"sampler,omitempty"`
+	Style    string  `yaml:"style_preset,omitempty" json:"style_preset,omitempty"`
+}
+
+func DefaultParams() Params {
+	return Params{
+		CfgScale: 7,
+		Steps:    30,
+		Height:   1024,
+		Width:    1024,
+		Samples:  1,
+	}
+}
+
+func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*p = DefaultParams()
+
+	type plain Params // to avoid recursion
+
+	return unmarshal((*plain)(p))
+}
+
+type Config struct {
+	BaseURL       string        `yaml:"base_url" json:"baseUrl" validate:"required,http_url"`
+	Engine        string        `yaml:"engine" json:"engine" validate:"required"` // https://platform.stability.ai/docs/api-reference#tag/v1engines
+	APIKey        fields.Secret `yaml:"api_key" json:"-" validate:"required"`
+	DefaultParams *Params       `yaml:"default_params,omitempty" json:"defaultParams"`
+}
+
+// DefaultConfig for Stability models
+func DefaultConfig() *Config {
+	defaultParams := DefaultParams()
+
+	return &Config{
+		BaseURL:       "https://api.stability.ai",
+		Engine:        "stable-diffusion-xl-1024-v1-0",
+		DefaultParams: &defaultParams,
+	}
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -0,0 +1,46 @@
+package stability
+
+import (
+	"io"
+	"net/http"
+
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+type ErrorMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func NewErrorMapper(tel *telemetry.Telemetry) *ErrorMapper {
+	return &ErrorMapper{
+		tel: tel,
+	}
+}
+
+func (m *ErrorMapper) Map(resp *http.Response) error {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		m.tel.Logger.Error("failed to read stability image response", zap.Error(err))
+	}
+
+	m.tel.Logger.Error(
+		"stability image request failed",
+		zap.Int("status_code", resp.StatusCode),
+		zap.String("response", string(bodyBytes)),
+		zap.Any("headers", resp.Header),
+	)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Stability doesn't provide the cooldown period, so the default one is used
+		return clients.NewRateLimitError(nil)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return clients.ErrUnauthorized
+	}
+
+	// Server & client errors result in the same error to keep gateway resilient
+	return clients.ErrProviderUnavailable
+}
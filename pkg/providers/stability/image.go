@@ -0,0 +1,131 @@
+package stability
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// GenerateImage sends a text-to-image request to the configured Stability engine.
+func (c *Client) GenerateImage(ctx context.Context, request *schemas.ImageGenerateRequest) (*schemas.ImageGenerateResponse, error) {
+	imageRequest := c.createRequestSchema(request)
+
+	rawPayload, err := json.Marshal(imageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal stability image request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.imageURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create stability image request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send stability image request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tel.Logger.Error("failed to read stability image response", zap.Error(err))
+		return nil, err
+	}
+
+	var imageResult ImageResult
+
+	err = json.Unmarshal(bodyBytes, &imageResult)
+	if err != nil {
+		c.tel.Logger.Error("failed to parse stability image response", zap.Error(err))
+		return nil, err
+	}
+
+	if len(imageResult.Artifacts) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	images := make([]schemas.Image, 0, len(imageResult.Artifacts))
+
+	for _, artifact := range imageResult.Artifacts {
+		seed := artifact.Seed
+
+		images = append(images, schemas.Image{
+			B64JSON: artifact.Base64,
+			Seed:    &seed,
+		})
+	}
+
+	return &schemas.ImageGenerateResponse{
+		Created:   int(time.Now().UTC().Unix()), // Stability doesn't provide this
+		Provider:  providerName,
+		ModelName: c.config.Engine,
+		ModelResponse: schemas.ImageModelResponse{
+			Images: images,
+		},
+	}, nil
+}
+
+func (c *Client) createRequestSchema(request *schemas.ImageGenerateRequest) *ImageRequest {
+	params := c.config.DefaultParams
+
+	imageRequest := &ImageRequest{
+		TextPrompts: []TextPrompt{{Text: request.Prompt, Weight: 1}},
+		CfgScale:    params.CfgScale,
+		Height:      params.Height,
+		Width:       params.Width,
+		Samples:     params.Samples,
+		Steps:       params.Steps,
+		Sampler:     params.Sampler,
+		StylePreset: params.Style,
+	}
+
+	if request.N > 0 {
+		imageRequest.Samples = request.N
+	}
+
+	if width, height, ok := parseSize(request.Size); ok {
+		imageRequest.Width = width
+		imageRequest.Height = height
+	}
+
+	return imageRequest
+}
+
+// parseSize parses sizes in the "{width}x{height}" format
+func parseSize(size string) (int, int, bool) {
+	dimensions := strings.Split(size, "x")
+
+	if len(dimensions) != 2 {
+		return 0, 0, false
+	}
+
+	width, err := strconv.Atoi(dimensions[0])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	height, err := strconv.Atoi(dimensions[1])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return width, height, true
+}
@@ -0,0 +1,30 @@
+package stability
+
+// TextPrompt is a weighted prompt used to generate images
+type TextPrompt struct {
+	Text   string  `json:"text"`
+	Weight float64 `json:"weight,omitempty"`
+}
+
+// ImageRequest is a Stability-specific text-to-image request schema
+// Ref: https://platform.stability.ai/docs/api-reference#tag/v1generation/operation/textToImage
+type ImageRequest struct {
+	TextPrompts []TextPrompt `json:"text_prompts"`
+	CfgScale    float64      `json:"cfg_scale,omitempty"`
+	Height      int          `json:"height,omitempty"`
+	Width       int          `json:"width,omitempty"`
+	Samples     int          `json:"samples,omitempty"`
+	Steps       int          `json:"steps,omitempty"`
+	Sampler     string       `json:"sampler,omitempty"`
+	StylePreset string       `json:"style_preset,omitempty"`
+}
+
+type ImageResult struct {
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+type Artifact struct {
+	Base64       string `json:"base64"`
+	Seed         int64  `json:"seed"`
+	FinishReason string `json:"finishReason"`
+}
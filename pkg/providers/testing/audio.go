@@ -0,0 +1,48 @@
+package testing
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+)
+
+// AudioProviderMock mocks a speech-to-text model provider
+type AudioProviderMock struct {
+	idx   int
+	resps *[]RespMock
+}
+
+func NewAudioProviderMock(responses []RespMock) *AudioProviderMock {
+	return &AudioProviderMock{
+		idx:   0,
+		resps: &responses,
+	}
+}
+
+func (c *AudioProviderMock) Transcribe(_ context.Context, _ *schemas.TranscriptionRequest) (*schemas.TranscriptionResponse, error) {
+	if c.resps == nil {
+		return nil, clients.ErrProviderUnavailable
+	}
+
+	responses := *c.resps
+
+	response := responses[c.idx]
+	c.idx++
+
+	if response.Err != nil {
+		return nil, *response.Err
+	}
+
+	return &schemas.TranscriptionResponse{
+		ID: "rsp0001",
+		ModelResponse: schemas.TranscriptionModelResponse{
+			Text:     response.Msg,
+			Duration: 1,
+		},
+	}, nil
+}
+
+func (c *AudioProviderMock) Provider() string {
+	return "provider_mock"
+}
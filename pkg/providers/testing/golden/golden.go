@@ -0,0 +1,89 @@
+// Package golden checks provider response mappings against golden files.
+//
+//	Golden files hold the unified responses produced out of recorded provider responses,
+//	so any mapping change shows up as a golden file diff. Run tests with the UPDATE_GOLDEN env var to (re)generate them:
+//
+//	UPDATE_GOLDEN=1 go test ./pkg/providers/... -run Golden
+package golden
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// updateEnvVar makes Assert update golden files with the current mapping results instead of checking them
+const updateEnvVar = "UPDATE_GOLDEN"
+
+// ServeRecording starts a server that responds to any request with the recorded provider response
+func ServeRecording(t *testing.T, recordingPath string) *httptest.Server {
+	t.Helper()
+
+	recording, err := os.ReadFile(filepath.Clean(recordingPath))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err := w.Write(recording)
+		if err != nil {
+			t.Errorf("error on sending the recorded response: %v", err)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Path returns the golden file path of the given recording (e.g. chat.success.json -> chat.success.golden.json)
+func Path(recordingPath string) string {
+	return strings.TrimSuffix(recordingPath, filepath.Ext(recordingPath)) + ".golden.json"
+}
+
+// Assert compares the response with the golden file ignoring volatile top-level fields (e.g. creation timestamps)
+func Assert(t *testing.T, goldenPath string, response any, volatileFields ...string) {
+	t.Helper()
+
+	actual := normalize(t, response, volatileFields)
+
+	if os.Getenv(updateEnvVar) != "" {
+		require.NoError(t, os.WriteFile(goldenPath, actual, 0o600))
+
+		return
+	}
+
+	expected, err := os.ReadFile(filepath.Clean(goldenPath))
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %v doesn't exist, run the test with %v=1 to create it", goldenPath, updateEnvVar)
+	}
+
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(actual), "mapping doesn't match %v", goldenPath)
+}
+
+func normalize(t *testing.T, response any, volatileFields []string) []byte {
+	t.Helper()
+
+	rawResponse, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var fields map[string]any
+
+	require.NoError(t, json.Unmarshal(rawResponse, &fields))
+
+	for _, field := range volatileFields {
+		delete(fields, field)
+	}
+
+	normalized, err := json.MarshalIndent(fields, "", "  ")
+	require.NoError(t, err)
+
+	return append(normalized, '\n')
+}
@@ -0,0 +1,47 @@
+package testing
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+)
+
+// ImageProviderMock mocks an image model provider
+type ImageProviderMock struct {
+	idx   int
+	resps *[]RespMock
+}
+
+func NewImageProviderMock(responses []RespMock) *ImageProviderMock {
+	return &ImageProviderMock{
+		idx:   0,
+		resps: &responses,
+	}
+}
+
+func (c *ImageProviderMock) GenerateImage(_ context.Context, _ *schemas.ImageGenerateRequest) (*schemas.ImageGenerateResponse, error) {
+	if c.resps == nil {
+		return nil, clients.ErrProviderUnavailable
+	}
+
+	responses := *c.resps
+
+	response := responses[c.idx]
+	c.idx++
+
+	if response.Err != nil {
+		return nil, *response.Err
+	}
+
+	return &schemas.ImageGenerateResponse{
+		ID: "rsp0001",
+		ModelResponse: schemas.ImageModelResponse{
+			Images: []schemas.Image{{URL: response.Msg}},
+		},
+	}, nil
+}
+
+func (c *ImageProviderMock) Provider() string {
+	return "provider_mock"
+}
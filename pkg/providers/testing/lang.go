@@ -3,6 +3,7 @@ package testing
 import (
 	"context"
 	"io"
+	"time"
 
 	"glide/pkg/api/schemas"
 	"glide/pkg/providers/clients"
@@ -10,8 +11,10 @@ import (
 
 // RespMock mocks a chat response or a streaming chat chunk
 type RespMock struct {
-	Msg string
-	Err *error
+	Msg   string
+	Err   *error
+	Delay time.Duration // how long the chat response takes
+	Panic any           // panics with the value instead of responding (e.g. a response mapping bug)
 }
 
 func (m *RespMock) Resp() *schemas.ChatResponse {
@@ -38,6 +41,15 @@ func (m *RespMock) RespChunk() *schemas.ChatStreamChunk {
 	}
 }
 
+func (m *RespMock) EmbedResp() *schemas.EmbedResponse {
+	return &schemas.EmbedResponse{
+		ID: "rsp0001",
+		ModelResponse: schemas.EmbedModelResponse{
+			Embeddings: []schemas.Embedding{{Index: 0, Vector: []float64{float64(len(m.Msg))}}},
+		},
+	}
+}
+
 // RespStreamMock mocks a chat stream
 type RespStreamMock struct {
 	idx     int
@@ -79,6 +91,10 @@ func (m *RespStreamMock) Recv() (*schemas.ChatStreamChunk, error) {
 	chunk := chunks[m.idx]
 	m.idx++
 
+	if chunk.Panic != nil {
+		panic(chunk.Panic)
+	}
+
 	if chunk.Err != nil {
 		return nil, *chunk.Err
 	}
@@ -96,6 +112,7 @@ type ProviderMock struct {
 	chatResps        *[]RespMock
 	chatStreams      *[]RespStreamMock
 	supportStreaming bool
+	supportEmbed     bool
 }
 
 func NewProviderMock(responses []RespMock) *ProviderMock {
@@ -114,11 +131,23 @@ func NewStreamProviderMock(chatStreams []RespStreamMock) *ProviderMock {
 	}
 }
 
+func NewEmbedProviderMock(responses []RespMock) *ProviderMock {
+	return &ProviderMock{
+		idx:          0,
+		chatResps:    &responses,
+		supportEmbed: true,
+	}
+}
+
+func (c *ProviderMock) SupportEmbed() bool {
+	return c.supportEmbed
+}
+
 func (c *ProviderMock) SupportChatStream() bool {
 	return c.supportStreaming
 }
 
-func (c *ProviderMock) Chat(_ context.Context, _ *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+func (c *ProviderMock) Chat(ctx context.Context, _ *schemas.ChatRequest) (*schemas.ChatResponse, error) {
 	if c.chatResps == nil {
 		return nil, clients.ErrProviderUnavailable
 	}
@@ -128,6 +157,18 @@ func (c *ProviderMock) Chat(_ context.Context, _ *schemas.ChatRequest) (*schemas
 	response := responses[c.idx]
 	c.idx++
 
+	if response.Delay > 0 {
+		select {
+		case <-time.After(response.Delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if response.Panic != nil {
+		panic(response.Panic)
+	}
+
 	if response.Err != nil {
 		return nil, *response.Err
 	}
@@ -148,6 +189,23 @@ func (c *ProviderMock) ChatStream(_ context.Context, _ *schemas.ChatStreamReques
 	return &stream, nil
 }
 
+func (c *ProviderMock) Embed(_ context.Context, _ *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	if !c.supportEmbed || c.chatResps == nil {
+		return nil, clients.ErrEmbedNotImplemented
+	}
+
+	responses := *c.chatResps
+
+	response := responses[c.idx]
+	c.idx++
+
+	if response.Err != nil {
+		return nil, *response.Err
+	}
+
+	return response.EmbedResp(), nil
+}
+
 func (c *ProviderMock) Provider() string {
 	return "provider_mock"
 }
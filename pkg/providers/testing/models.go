@@ -54,6 +54,6 @@ func (m LangModelMock) Weight() int {
 	return m.weight
 }
 
-func ChatMockLatency(model providers.Model) *latency.MovingAverage {
+func ChatMockLatency(model providers.Model, _ providers.Action) *latency.MovingAverage {
 	return model.(LangModelMock).chatLatency
 }
@@ -0,0 +1,57 @@
+package testing
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+)
+
+// ModerationProviderMock mocks a moderation model provider
+type ModerationProviderMock struct {
+	idx    int
+	resps  *[]RespMock
+	Inputs [][]string // inputs of received requests
+}
+
+func NewModerationProviderMock(responses []RespMock) *ModerationProviderMock {
+	return &ModerationProviderMock{
+		idx:   0,
+		resps: &responses,
+	}
+}
+
+func (c *ModerationProviderMock) Moderate(_ context.Context, req *schemas.ModerationRequest) (*schemas.ModerationResponse, error) {
+	c.Inputs = append(c.Inputs, req.Input)
+
+	if c.resps == nil {
+		return nil, clients.ErrProviderUnavailable
+	}
+
+	responses := *c.resps
+
+	response := responses[c.idx]
+	c.idx++
+
+	if response.Err != nil {
+		return nil, *response.Err
+	}
+
+	// the message is the flagged category if any
+	result := schemas.ModerationResult{Flagged: response.Msg != ""}
+
+	if result.Flagged {
+		result.Categories = []schemas.ModerationCategory{{Name: response.Msg, Flagged: true, Score: 1}}
+	}
+
+	return &schemas.ModerationResponse{
+		ID: "rsp0001",
+		ModelResponse: schemas.ModerationModelResponse{
+			Results: []schemas.ModerationResult{result},
+		},
+	}, nil
+}
+
+func (c *ModerationProviderMock) Provider() string {
+	return "provider_mock"
+}
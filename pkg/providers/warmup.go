@@ -0,0 +1,30 @@
+package providers
+
+import (
+	"glide/pkg/providers/clients"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// newConnWarmer starts keeping provider connections warm if that's enabled for the model
+func newConnWarmer(modelID string, client ModelProvider, clientConfig *clients.ClientConfig, tel *telemetry.Telemetry) *clients.ConnWarmer {
+	if clientConfig == nil || clientConfig.Warmup == nil || !clientConfig.Warmup.Enabled {
+		return nil
+	}
+
+	logger := tel.L().With(
+		zap.String("model", modelID),
+		zap.String("provider", client.Provider()),
+	)
+
+	connWarmer, ok := client.(ConnWarmer)
+	if !ok {
+		logger.Warn("Provider doesn't support connection warmup, skipping")
+		return nil
+	}
+
+	warmer := clients.NewConnWarmer(connWarmer.WarmUp, clientConfig.Warmup, logger)
+	warmer.Start()
+
+	return warmer
+}
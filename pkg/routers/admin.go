@@ -0,0 +1,258 @@
+package routers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"glide/pkg/providers"
+)
+
+var ErrInvalidRouterConfig = errors.New("invalid router config")
+
+// configValidator checks router configs submitted at runtime the same way the config file is checked on startup
+var configValidator = newConfigValidator()
+
+func newConfigValidator() *validator.Validate {
+	configValidator := validator.New(validator.WithRequiredStructEnabled())
+
+	configValidator.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("yaml"), ",", 2)[0]
+
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	return configValidator
+}
+
+// UpsertLangRouter creates a new language router or replaces the existing one with the same ID.
+// Requests already being served by the replaced router are finished by it
+func (r *RouterManager) UpsertLangRouter(cfg *LangRouterConfig) (*LangRouter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.applyLangRouterConfig(cfg)
+}
+
+// DeleteLangRouter removes the language router from the manager
+func (r *RouterManager) DeleteLangRouter(routerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// nested routers would keep routing to models of the deleted router
+	for nestingID := range nestingRouterIDs(r.Config.LanguageRouters, func(id RouterID) bool { return id == routerID }) {
+		if nestingID != routerID {
+			return fmt.Errorf("%w: router \"%v\" is nested into router \"%v\"", ErrInvalidRouterConfig, routerID, nestingID)
+		}
+	}
+
+	routerConfigs := make([]LangRouterConfig, 0, len(r.Config.LanguageRouters))
+
+	for _, routerConfig := range r.Config.LanguageRouters {
+		if routerConfig.ID != routerID {
+			routerConfigs = append(routerConfigs, routerConfig)
+		}
+	}
+
+	if len(routerConfigs) == len(r.Config.LanguageRouters) {
+		return ErrRouterNotFound
+	}
+
+	r.Config.LanguageRouters = routerConfigs
+	r.replaceLangRouter(routerID, nil)
+
+	return nil
+}
+
+// UpsertLangModel adds a new model to the language router pool or replaces the existing one with the same ID
+func (r *RouterManager) UpsertLangModel(routerID string, modelConfig *providers.LangModelConfig) (*LangRouter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	routerConfig, err := r.langRouterConfig(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]providers.LangModelConfig, 0, len(routerConfig.Models)+1)
+	replaced := false
+
+	for _, model := range routerConfig.Models {
+		if model.ID == modelConfig.ID {
+			model, replaced = *modelConfig, true
+		}
+
+		models = append(models, model)
+	}
+
+	if !replaced {
+		models = append(models, *modelConfig)
+	}
+
+	routerConfig.Models = models
+
+	return r.applyLangRouterConfig(routerConfig)
+}
+
+// DeleteLangModel removes the model from the language router pool
+func (r *RouterManager) DeleteLangModel(routerID string, modelID string) (*LangRouter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	routerConfig, err := r.langRouterConfig(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]providers.LangModelConfig, 0, len(routerConfig.Models))
+
+	for _, model := range routerConfig.Models {
+		if model.ID != modelID {
+			models = append(models, model)
+		}
+	}
+
+	if len(models) == len(routerConfig.Models) {
+		return nil, ErrModelNotFound
+	}
+
+	routerConfig.Models = models
+
+	return r.applyLangRouterConfig(routerConfig)
+}
+
+// langRouterConfig returns a copy of the language router config, so it could be modified without affecting the running router
+func (r *RouterManager) langRouterConfig(routerID string) (*LangRouterConfig, error) {
+	for _, routerConfig := range r.Config.LanguageRouters {
+		if routerConfig.ID == routerID {
+			return &routerConfig, nil
+		}
+	}
+
+	return nil, ErrRouterNotFound
+}
+
+// applyLangRouterConfig validates the config, builds the router out of it and swaps it with the current one.
+// Must be called with the manager lock held
+func (r *RouterManager) applyLangRouterConfig(cfg *LangRouterConfig) (*LangRouter, error) {
+	if err := configValidator.Struct(cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRouterConfig, err)
+	}
+
+	router, err := NewLangRouter(cfg, r.tel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRouterConfig, err)
+	}
+
+	routerConfigs := make([]LangRouterConfig, 0, len(r.Config.LanguageRouters)+1)
+	replaced := false
+
+	for _, routerConfig := range r.Config.LanguageRouters {
+		if routerConfig.ID == cfg.ID {
+			routerConfig, replaced = *cfg, true
+		}
+
+		routerConfigs = append(routerConfigs, routerConfig)
+	}
+
+	if !replaced {
+		routerConfigs = append(routerConfigs, *cfg)
+	}
+
+	// nested routers referring to the router route between its models, so they are rebuilt with the new ones
+	builtRouters := []*LangRouter{router}
+	langRouterMap := make(map[string]*LangRouter, len(*r.langRouterMap)+1)
+
+	for routerID, existingRouter := range *r.langRouterMap {
+		langRouterMap[routerID] = existingRouter
+	}
+
+	langRouterMap[cfg.ID] = router
+
+	nestingIDs := nestingRouterIDs(routerConfigs, func(routerID RouterID) bool { return routerID == cfg.ID })
+
+	shutdownBuilt := func() {
+		for _, builtRouter := range builtRouters {
+			builtRouter.Shutdown()
+		}
+	}
+
+	for idx, routerConfig := range routerConfigs {
+		if _, nesting := nestingIDs[routerConfig.ID]; !nesting || routerConfig.ID == cfg.ID {
+			continue
+		}
+
+		nestingRouter, err := NewLangRouter(&routerConfigs[idx], r.tel)
+		if err != nil {
+			shutdownBuilt()
+
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRouterConfig, err)
+		}
+
+		builtRouters = append(builtRouters, nestingRouter)
+		langRouterMap[routerConfig.ID] = nestingRouter
+	}
+
+	resolving := make(map[string]struct{})
+
+	for _, builtRouter := range builtRouters {
+		if err := withNestedRouters(builtRouter, langRouterMap, resolving); err != nil {
+			shutdownBuilt()
+
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRouterConfig, err)
+		}
+
+		if err := withGuardrail(builtRouter, *r.moderationRouterMap); err != nil {
+			shutdownBuilt()
+
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRouterConfig, err)
+		}
+	}
+
+	r.Config.LanguageRouters = routerConfigs
+
+	for _, builtRouter := range builtRouters {
+		r.replaceLangRouter(builtRouter.ID(), builtRouter)
+	}
+
+	return router, nil
+}
+
+// replaceLangRouter swaps the running router with the given one or removes it if nil is given.
+// Router collections are copied rather than modified, so readers are never affected by the swap
+func (r *RouterManager) replaceLangRouter(routerID string, router *LangRouter) {
+	langRouters := make([]*LangRouter, 0, len(r.langRouters)+1)
+	langRouterMap := make(map[string]*LangRouter, len(r.langRouters)+1)
+	replaced := false
+
+	for _, existingRouter := range r.langRouters {
+		if existingRouter.ID() == routerID {
+			existingRouter.Shutdown()
+
+			if router == nil {
+				continue
+			}
+
+			existingRouter, replaced = router, true
+		}
+
+		langRouters = append(langRouters, existingRouter)
+		langRouterMap[existingRouter.ID()] = existingRouter
+	}
+
+	if router != nil && !replaced {
+		langRouters = append(langRouters, router)
+		langRouterMap[router.ID()] = router
+	}
+
+	r.langRouters = langRouters
+	r.langRouterMap = &langRouterMap
+
+	r.metadataCache.Invalidate(routerID)
+}
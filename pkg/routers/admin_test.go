@@ -0,0 +1,190 @@
+package routers
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	"glide/pkg/providers/openai"
+	"glide/pkg/telemetry"
+)
+
+func newOpenAIModelConfig(modelID string) providers.LangModelConfig {
+	modelConfig := providers.DefaultLangModelConfig()
+	modelConfig.ID = modelID
+	modelConfig.OpenAI = openai.DefaultConfig()
+	modelConfig.OpenAI.APIKey = "ABC"
+
+	return *modelConfig
+}
+
+func newLangRouterConfig(routerID string, modelIDs ...string) LangRouterConfig {
+	routerConfig := DefaultLangRouterConfig()
+	routerConfig.ID = routerID
+
+	for _, modelID := range modelIDs {
+		routerConfig.Models = append(routerConfig.Models, newOpenAIModelConfig(modelID))
+	}
+
+	return routerConfig
+}
+
+func newAdminManagerMock(t *testing.T) *RouterManager {
+	manager, err := NewManager(&Config{
+		LanguageRouters: []LangRouterConfig{newLangRouterConfig("default", "openai")},
+	}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	return manager
+}
+
+func TestNewManager_FailureShutsDownRouters(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	routerConfig := newLangRouterConfig("default", "openai")
+	routerConfig.Guardrail = &GuardrailConfig{Moderation: "unknown"}
+
+	_, err := NewManager(&Config{LanguageRouters: []LangRouterConfig{routerConfig}}, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "guardrail")
+
+	// background activities of the router built before the failure are stopped
+	// (polled in place, as require.Eventually runs its own goroutines)
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > goroutines && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	require.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
+
+func TestRouterManager_UpsertLangRouter(t *testing.T) {
+	manager := newAdminManagerMock(t)
+	defaultRouter, err := manager.GetLangRouter("default")
+	require.NoError(t, err)
+
+	newRouterConfig := newLangRouterConfig("new", "openai")
+
+	router, err := manager.UpsertLangRouter(&newRouterConfig)
+	require.NoError(t, err)
+	require.Equal(t, "new", router.ID())
+
+	updatedRouterConfig := newLangRouterConfig("default", "openai", "another_openai")
+
+	updatedRouter, err := manager.UpsertLangRouter(&updatedRouterConfig)
+	require.NoError(t, err)
+	require.Len(t, updatedRouter.chatModels, 2)
+
+	foundRouter, err := manager.GetLangRouter("default")
+	require.NoError(t, err)
+	require.Same(t, updatedRouter, foundRouter)
+	require.NotSame(t, defaultRouter, foundRouter)
+
+	require.Len(t, manager.GetLangRouters(), 2)
+	require.Len(t, manager.Config.LanguageRouters, 2)
+	require.Equal(t, "default", manager.GetLangRouters()[0].ID())
+}
+
+func TestRouterManager_UpsertInvalidLangRouter(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	noModelsConfig := newLangRouterConfig("new")
+
+	_, err := manager.UpsertLangRouter(&noModelsConfig)
+	require.ErrorIs(t, err, ErrInvalidRouterConfig)
+
+	duplicatedModelsConfig := newLangRouterConfig("new", "openai", "openai")
+
+	_, err = manager.UpsertLangRouter(&duplicatedModelsConfig)
+	require.ErrorIs(t, err, ErrInvalidRouterConfig)
+
+	_, err = manager.GetLangRouter("new")
+	require.ErrorIs(t, err, ErrRouterNotFound)
+	require.Len(t, manager.Config.LanguageRouters, 1)
+}
+
+func TestRouterManager_DeleteLangRouter(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	require.NoError(t, manager.DeleteLangRouter("default"))
+
+	_, err := manager.GetLangRouter("default")
+	require.ErrorIs(t, err, ErrRouterNotFound)
+	require.Empty(t, manager.GetLangRouters())
+	require.Empty(t, manager.Config.LanguageRouters)
+
+	require.ErrorIs(t, manager.DeleteLangRouter("default"), ErrRouterNotFound)
+}
+
+func TestRouterManager_UpsertNestedRouterMember(t *testing.T) {
+	manager, err := NewManager(&Config{
+		LanguageRouters: []LangRouterConfig{
+			newNestedRouterConfig("chain", "pool"),
+			newLangRouterConfig("pool", "openai"),
+		},
+	}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	modelConfig := newOpenAIModelConfig("another-openai")
+
+	poolRouter, err := manager.UpsertLangModel("pool", &modelConfig)
+	require.NoError(t, err)
+
+	chainRouter, err := manager.GetLangRouter("chain")
+	require.NoError(t, err)
+	require.Len(t, chainRouter.chatModels, 2)
+	require.Same(t, poolRouter.chatModels[1], chainRouter.chatModels[1])
+
+	require.ErrorIs(t, manager.DeleteLangRouter("pool"), ErrInvalidRouterConfig)
+
+	_, err = manager.GetLangRouter("pool")
+	require.NoError(t, err)
+}
+
+func TestRouterManager_UpsertAndDeleteLangModel(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	modelConfig := newOpenAIModelConfig("another_openai")
+
+	router, err := manager.UpsertLangModel("default", &modelConfig)
+	require.NoError(t, err)
+	require.Len(t, router.chatModels, 2)
+
+	modelConfig.Weight = 5
+
+	router, err = manager.UpsertLangModel("default", &modelConfig)
+	require.NoError(t, err)
+	require.Len(t, router.chatModels, 2)
+	require.Equal(t, 5, router.Config.Models[1].Weight)
+
+	router, err = manager.DeleteLangModel("default", "openai")
+	require.NoError(t, err)
+	require.Len(t, router.chatModels, 1)
+	require.Equal(t, "another_openai", router.chatModels[0].ID())
+
+	_, err = manager.DeleteLangModel("default", "openai")
+	require.ErrorIs(t, err, ErrModelNotFound)
+
+	// routers must have at least one model
+	_, err = manager.DeleteLangModel("default", "another_openai")
+	require.ErrorIs(t, err, ErrInvalidRouterConfig)
+
+	_, err = manager.UpsertLangModel("unknown", &modelConfig)
+	require.ErrorIs(t, err, ErrRouterNotFound)
+}
+
+func TestRouterManager_EffectiveConfig(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	newRouterConfig := newLangRouterConfig("new", "openai")
+
+	_, err := manager.UpsertLangRouter(&newRouterConfig)
+	require.NoError(t, err)
+
+	cfg := manager.EffectiveConfig()
+	require.Len(t, cfg.LanguageRouters, 2)
+
+	// the copy is not affected by further changes
+	require.NoError(t, manager.DeleteLangRouter("new"))
+	require.Len(t, cfg.LanguageRouters, 2)
+}
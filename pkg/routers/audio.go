@@ -0,0 +1,38 @@
+package routers
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+)
+
+// AudioRouter routes transcription requests across audio models
+type AudioRouter struct {
+	*singleActionRouter[*schemas.TranscriptionRequest, *schemas.TranscriptionResponse]
+	Config *AudioRouterConfig
+}
+
+func NewAudioRouter(cfg *AudioRouterConfig, tel *telemetry.Telemetry) (*AudioRouter, error) {
+	models, err := cfg.BuildModels(tel)
+	if err != nil {
+		return nil, err
+	}
+
+	modelRouting, err := cfg.BuildRouting(models)
+	if err != nil {
+		return nil, err
+	}
+
+	router := &AudioRouter{
+		singleActionRouter: newSingleActionRouter(cfg.ID, providers.TranscribeAction, models, modelRouting, cfg.BuildRetry(), tel),
+		Config:             cfg,
+	}
+
+	return router, err
+}
+
+func (r *AudioRouter) Transcribe(ctx context.Context, req *schemas.TranscriptionRequest) (*schemas.TranscriptionResponse, error) {
+	return r.serve(ctx, req)
+}
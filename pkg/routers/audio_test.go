@@ -0,0 +1,66 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestAudioRouter_Transcribe_FallbackToHealthy(t *testing.T) {
+	budget := health.NewErrorBudget(1, health.MIN)
+	latConfig := latency.DefaultConfig()
+
+	audioModels := []*providers.AudioModel{
+		providers.NewAudioModel(
+			"first",
+			ptesting.NewAudioProviderMock([]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}, {Msg: "3"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+		providers.NewAudioModel(
+			"second",
+			ptesting.NewAudioProviderMock([]ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(audioModels))
+	for _, model := range audioModels {
+		models = append(models, model)
+	}
+
+	router := AudioRouter{
+		singleActionRouter: newSingleActionRouter(
+			"test_router",
+			providers.TranscribeAction,
+			audioModels,
+			routing.NewPriority(models),
+			retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+			telemetry.NewTelemetryMock(),
+		),
+		Config: &AudioRouterConfig{},
+	}
+
+	for _, text := range []string{"1", "2"} {
+		resp, err := router.Transcribe(context.Background(), &schemas.TranscriptionRequest{File: []byte("audio")})
+
+		require.NoError(t, err)
+		require.Equal(t, "second", resp.ModelID)
+		require.Equal(t, text, resp.ModelResponse.Text)
+		require.Equal(t, "test_router", resp.RouterID)
+	}
+}
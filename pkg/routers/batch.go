@@ -0,0 +1,84 @@
+package routers
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// ChatBatch serves the batch chat requests concurrently keeping at most parallelism requests in flight.
+//
+//	Failures of separate requests don't fail the whole batch, they are reported per request instead
+func (r *LangRouter) ChatBatch(ctx context.Context, req *schemas.ChatBatchRequest, parallelism int) *schemas.ChatBatchResponse {
+	results := make([]schemas.ChatBatchResult, len(req.Requests))
+	semaphore := make(chan struct{}, max(parallelism, 1))
+
+	var wg sync.WaitGroup
+
+	for idx := range req.Requests {
+		results[idx].Index = idx
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			results[idx].Error = ctx.Err().Error()
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(idx int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			resp, err := r.chatBatchItem(ctx, &req.Requests[idx])
+			if err != nil {
+				results[idx].Error = err.Error()
+				return
+			}
+
+			results[idx].Response = resp
+		}(idx)
+	}
+
+	wg.Wait()
+
+	return &schemas.ChatBatchResponse{
+		RouterID: r.routerID,
+		Results:  results,
+	}
+}
+
+// chatBatchItem serves one batch request. Panics are reported as the request error
+// as they could not be handled by the HTTP middleware outside the handler goroutine
+func (r *LangRouter) chatBatchItem(ctx context.Context, req *schemas.ChatRequest) (resp *schemas.ChatResponse, err error) {
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
+		}
+
+		fields := []zap.Field{zap.Any("panic", value)}
+
+		if modelPanic, ok := value.(*ModelPanic); ok {
+			fields = append(
+				fields,
+				zap.String("modelID", modelPanic.ModelID),
+				zap.String("provider", modelPanic.Provider),
+				zap.ByteString("stacktrace", modelPanic.Stack),
+			)
+		} else {
+			fields = append(fields, zap.ByteString("stacktrace", debug.Stack()))
+		}
+
+		r.logger.Error("Recovered from panic during batch chat processing", fields...)
+
+		resp, err = nil, ErrInternal
+	}()
+
+	// batch items share the batch request headers, so they are not deduplicated by the idempotency key
+	return r.cachedChat(ctx, req)
+}
@@ -0,0 +1,68 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_ChatBatch(t *testing.T) {
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			// the mock panics once responses are over
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			health.NewErrorBudget(3, health.SEC),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:    "test_router",
+		Config:      &LangRouterConfig{},
+		retry:       retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+		chatRouting: routing.NewPriority(models),
+		chatModels:  langModels,
+		tel:         telemetry.NewTelemetryMock(),
+		logger:      telemetry.NewLoggerMock(),
+	}
+
+	req := &schemas.ChatBatchRequest{
+		Requests: []schemas.ChatRequest{
+			*schemas.NewChatFromStr("tell me a dad joke"),
+			*schemas.NewChatFromStr("tell me another one"),
+			*schemas.NewChatFromStr("and one more"),
+		},
+	}
+
+	resp := router.ChatBatch(context.Background(), req, 1)
+
+	require.Equal(t, "test_router", resp.RouterID)
+	require.Len(t, resp.Results, 3)
+
+	for idx, result := range resp.Results[:2] {
+		require.Equal(t, idx, result.Index)
+		require.Empty(t, result.Error)
+		require.Equal(t, "first", result.Response.ModelID)
+	}
+
+	require.Equal(t, 2, resp.Results[2].Index)
+	require.Nil(t, resp.Results[2].Response)
+	require.Equal(t, ErrInternal.Error(), resp.Results[2].Error)
+}
@@ -0,0 +1,83 @@
+package routers
+
+import (
+	"errors"
+	"fmt"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/events"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+var ErrBudgetExhausted = errors.New("router has spent its daily or monthly budget")
+
+const (
+	ModelBudgetExhaustedEvent  = "model.budget_exhausted"
+	RouterBudgetExhaustedEvent = "router.budget_exhausted"
+)
+
+// BudgetExhaustedEventData describes the router or the model that has spent its budget
+type BudgetExhaustedEventData struct {
+	RouterID     string  `json:"routerId"`
+	ModelID      string  `json:"modelId,omitempty"` // empty when the router budget is exhausted
+	DailySpent   float64 `json:"dailySpent"`        // in USD
+	MonthlySpent float64 `json:"monthlySpent"`      // in USD
+}
+
+// trackSpend records the cost of the served request against the model & router spend budgets
+func (r *LangRouter) trackSpend(langModel providers.LangModel, usage schemas.TokenUsage) {
+	model, ok := langModel.(*providers.LanguageModel)
+	if !ok || model.Pricing() == nil {
+		return
+	}
+
+	if model.TrackSpend(usage) {
+		r.notifyBudgetExhausted(ModelBudgetExhaustedEvent, model.ID(), model.SpendBudget())
+	}
+
+	if r.spendBudget.Track(model.Pricing().Cost(usage)) {
+		r.notifyBudgetExhausted(RouterBudgetExhaustedEvent, "", r.spendBudget)
+	}
+}
+
+// notifyBudgetExhausted reports the exhausted budget in logs & metrics and emits the event if delivery is configured
+func (r *LangRouter) notifyBudgetExhausted(eventType string, modelID string, budget *providers.SpendBudget) {
+	dailySpent, monthlySpent := budget.Spent()
+
+	r.logger.Warn(
+		"Spend budget is exhausted",
+		zap.String("modelID", modelID),
+		zap.Float64("dailySpent", dailySpent),
+		zap.Float64("monthlySpent", monthlySpent),
+	)
+
+	target := r.routerID
+
+	if modelID == "" {
+		r.tel.M().Counter(fmt.Sprintf("routers.%v.budget_exhausted", r.routerID)).Inc()
+	} else {
+		target = modelID
+		r.tel.M().Counter(fmt.Sprintf("routers.%v.models.%v.budget_exhausted", r.routerID, modelID)).Inc()
+	}
+
+	r.tel.Audit.Record(telemetry.SystemActor, eventType, target, map[string]interface{}{
+		"routerId":     r.routerID,
+		"dailySpent":   dailySpent,
+		"monthlySpent": monthlySpent,
+	})
+
+	if r.events == nil {
+		return
+	}
+
+	event := events.NewEvent(eventType, &BudgetExhaustedEventData{
+		RouterID:     r.routerID,
+		ModelID:      modelID,
+		DailySpent:   dailySpent,
+		MonthlySpent: monthlySpent,
+	})
+
+	r.events.Emit(event)
+}
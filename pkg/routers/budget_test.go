@@ -0,0 +1,100 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/events"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_SpendBudgets(t *testing.T) {
+	chatResp, err := os.ReadFile(filepath.Clean("../providers/openai/testdata/chat.success.json"))
+	require.NoError(t, err)
+
+	providerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(chatResp)
+	}))
+	defer providerServer.Close()
+
+	var mu sync.Mutex
+
+	eventTypes := make([]string, 0, 2)
+
+	eventServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event events.Event
+
+		_ = json.NewDecoder(r.Body).Decode(&event)
+
+		mu.Lock()
+		eventTypes = append(eventTypes, event.Type)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer eventServer.Close()
+
+	routerConfig := newLangRouterConfig("budget", "first", "second")
+	routerConfig.Budget = &providers.SpendBudgetConfig{Monthly: 15}
+	routerConfig.Events = events.DefaultDeliveryConfig()
+	routerConfig.Events.URL = eventServer.URL
+	// the budget exhaustion makes the model unhealthy, which is a separate event
+	routerConfig.Events.Events = []string{ModelBudgetExhaustedEvent, RouterBudgetExhaustedEvent}
+
+	for idx := range routerConfig.Models {
+		routerConfig.Models[idx].OpenAI.BaseURL = providerServer.URL
+		// each response costs $9 (9 prompt tokens)
+		routerConfig.Models[idx].Pricing = &providers.Pricing{PromptTokens: 1_000_000}
+	}
+
+	routerConfig.Models[0].Budget = &providers.SpendBudgetConfig{Daily: 5}
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	defer router.Shutdown()
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+
+	// the first model has spent its budget, so the rest of traffic goes to the second one
+	require.False(t, router.chatModels[0].Healthy())
+	require.True(t, router.chatModels[0].Health().BudgetExhausted)
+
+	resp, err = router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	// the router has spent its budget now
+	_, err = router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.ErrorIs(t, err, ErrBudgetExhausted)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(eventTypes) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.ElementsMatch(t, []string{ModelBudgetExhaustedEvent, RouterBudgetExhaustedEvent}, eventTypes)
+}
+
+func TestLangRouterConfig_BudgetRequiresPricing(t *testing.T) {
+	routerConfig := newLangRouterConfig("budget", "first")
+	routerConfig.Models[0].Budget = &providers.SpendBudgetConfig{Daily: 5}
+
+	_, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "no pricing")
+}
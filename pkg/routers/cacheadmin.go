@@ -0,0 +1,150 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/cache"
+)
+
+// CachePurgeFilter selects cache entries to remove. Empty filter selects all entries of the router
+type CachePurgeFilter struct {
+	ModelID   string // entries produced by the model
+	KeyPrefix string // entries with cache keys of the prefix (e.g. "chat:default:" or "embed:default:openai:")
+}
+
+// CacheStats describes the router caches or returns nil if the router caches nothing
+func (r *LangRouter) CacheStats() *schemas.RouterCacheStats {
+	if r.responseCache == nil && r.embedCache == nil {
+		return nil
+	}
+
+	stats := &schemas.RouterCacheStats{RouterID: r.routerID}
+
+	if r.responseCache != nil {
+		stats.Response = r.responseCache.Stats()
+	}
+
+	if r.embedCache != nil {
+		stats.Embed = r.embedCache.Stats()
+	}
+
+	return stats
+}
+
+// PurgeCache removes cache entries selected by the filter and returns how many of them were removed
+func (r *LangRouter) PurgeCache(ctx context.Context, filter CachePurgeFilter) (int, error) {
+	purged := 0
+
+	if r.responseCache != nil {
+		removed, err := r.responseCache.Purge(ctx, filter)
+		if err != nil {
+			return purged, err
+		}
+
+		purged += removed
+	}
+
+	if r.embedCache != nil {
+		removed, err := r.embedCache.Purge(ctx, filter)
+		if err != nil {
+			return purged, err
+		}
+
+		purged += removed
+	}
+
+	return purged, nil
+}
+
+// Stats describes how the cache has been used
+func (c *ResponseCache) Stats() *schemas.CacheStats {
+	return &schemas.CacheStats{
+		Hits:         c.hits.Value(),
+		Misses:       c.misses.Value(),
+		HitRate:      c.HitRate(),
+		SemanticHits: c.semanticHits.Value(),
+		Bypasses:     c.bypasses.Value(),
+		Errors:       c.errors.Value(),
+		Memory:       memoryStoreStats(c.store),
+	}
+}
+
+// Purge removes cached responses selected by the filter.
+// Responses are keyed by requests, so they are filtered by the model that served them one by one
+func (c *ResponseCache) Purge(ctx context.Context, filter CachePurgeFilter) (int, error) {
+	prefix, overlaps := narrowPrefix(fmt.Sprintf("chat:%v:", c.routerID), filter.KeyPrefix)
+	if !overlaps {
+		return 0, nil
+	}
+
+	var match func(value []byte) bool
+
+	if filter.ModelID != "" {
+		match = func(value []byte) bool {
+			var cached cachedChatResponse
+
+			return json.Unmarshal(value, &cached) == nil && cached.Response != nil && cached.Response.ModelID == filter.ModelID
+		}
+	}
+
+	return c.store.Purge(ctx, prefix, match)
+}
+
+// Stats describes how the cache has been used
+func (c *EmbedCache) Stats() *schemas.CacheStats {
+	return &schemas.CacheStats{
+		Hits:    c.hits.Value(),
+		Misses:  c.misses.Value(),
+		HitRate: c.HitRate(),
+		Errors:  c.errors.Value(),
+		Memory:  memoryStoreStats(c.store),
+	}
+}
+
+// Purge removes cached embeddings selected by the filter. Keys include model IDs, so the filter is a key prefix
+func (c *EmbedCache) Purge(ctx context.Context, filter CachePurgeFilter) (int, error) {
+	namespace := fmt.Sprintf("embed:%v:", c.routerID)
+
+	if filter.ModelID != "" {
+		namespace = fmt.Sprintf("embed:%v:%v:", c.routerID, filter.ModelID)
+	}
+
+	prefix, overlaps := narrowPrefix(namespace, filter.KeyPrefix)
+	if !overlaps {
+		return 0, nil
+	}
+
+	return c.store.Purge(ctx, prefix, nil)
+}
+
+func memoryStoreStats(store cache.Store) *schemas.MemoryStoreStats {
+	memoryStore, ok := store.(*cache.MemoryStore)
+	if !ok {
+		return nil
+	}
+
+	stats := memoryStore.Stats()
+
+	return &schemas.MemoryStoreStats{
+		Entries:   stats.Entries,
+		Bytes:     stats.Bytes,
+		Evictions: stats.Evictions,
+	}
+}
+
+// narrowPrefix returns the longer of the cache namespace & the requested key prefix if one of them contains the other.
+// Otherwise, the requested keys are out of the namespace
+func narrowPrefix(namespace string, keyPrefix string) (string, bool) {
+	switch {
+	case strings.HasPrefix(keyPrefix, namespace):
+		return keyPrefix, true
+	case strings.HasPrefix(namespace, keyPrefix):
+		return namespace, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,51 @@
+package routers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+)
+
+func TestNarrowPrefix(t *testing.T) {
+	prefix, overlaps := narrowPrefix("chat:default:", "")
+	require.True(t, overlaps)
+	require.Equal(t, "chat:default:", prefix)
+
+	prefix, overlaps = narrowPrefix("chat:default:", "chat:default:ab")
+	require.True(t, overlaps)
+	require.Equal(t, "chat:default:ab", prefix)
+
+	_, overlaps = narrowPrefix("chat:default:", "embed:")
+	require.False(t, overlaps)
+}
+
+func TestResponseCache_PurgeByModel(t *testing.T) {
+	ctx := context.Background()
+	responseCache, err := NewResponseCache("router", DefaultResponseCacheConfig(), nil, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+
+	_, err = responseCache.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"), countingChat(&calls, 0))
+	require.NoError(t, err)
+
+	purged, err := responseCache.Purge(ctx, CachePurgeFilter{ModelID: "another"})
+	require.NoError(t, err)
+	require.Zero(t, purged)
+
+	purged, err = responseCache.Purge(ctx, CachePurgeFilter{ModelID: "served", KeyPrefix: "chat:"})
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	resp, err := responseCache.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	stats := responseCache.Stats()
+	require.Equal(t, int64(2), stats.Misses)
+	require.Equal(t, 1, stats.Memory.Entries)
+}
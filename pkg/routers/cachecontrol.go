@@ -0,0 +1,77 @@
+package routers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"glide/pkg/api/schemas"
+)
+
+// CacheControlHeader lets clients constrain the response cache with standard directives (no-cache, no-store & max-age)
+const CacheControlHeader = "Cache-Control"
+
+// cacheControl is what the request allows the response cache to do
+type cacheControl struct {
+	noCache bool
+	noStore bool
+	maxAge  *time.Duration
+}
+
+// fresh checks if the response cached at the given moment is recent enough to be served
+func (c cacheControl) fresh(cachedAt time.Time) bool {
+	return c.maxAge == nil || time.Since(cachedAt) <= *c.maxAge
+}
+
+// requestCacheControl merges directives of the request field & the header, the stricter ones win
+func requestCacheControl(ctx context.Context, req *schemas.ChatRequest) cacheControl {
+	var control cacheControl
+
+	if header, found := requestHeader(ctx, CacheControlHeader); found {
+		control = parseCacheControl(header)
+	}
+
+	if req.Cache == nil {
+		return control
+	}
+
+	control.noCache = control.noCache || req.Cache.NoCache
+	control.noStore = control.noStore || req.Cache.NoStore
+
+	if req.Cache.MaxAge != nil {
+		maxAge := time.Duration(*req.Cache.MaxAge) * time.Second
+
+		if control.maxAge == nil || maxAge < *control.maxAge {
+			control.maxAge = &maxAge
+		}
+	}
+
+	return control
+}
+
+// parseCacheControl reads known directives of the Cache-Control header, the rest are ignored as HTTP caches do
+func parseCacheControl(header string) cacheControl {
+	var control cacheControl
+
+	for _, directive := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+
+		switch strings.ToLower(name) {
+		case "no-cache":
+			control.noCache = true
+		case "no-store":
+			control.noStore = true
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(value, "\""))
+			if err != nil || seconds < 0 {
+				continue
+			}
+
+			maxAge := time.Duration(seconds) * time.Second
+			control.maxAge = &maxAge
+		}
+	}
+
+	return control
+}
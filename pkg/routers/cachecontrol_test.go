@@ -0,0 +1,88 @@
+package routers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+)
+
+func TestParseCacheControl(t *testing.T) {
+	control := parseCacheControl("No-Cache, max-age=60, private")
+
+	require.True(t, control.noCache)
+	require.False(t, control.noStore)
+	require.Equal(t, 60*time.Second, *control.maxAge)
+
+	control = parseCacheControl("no-store, max-age=soon")
+
+	require.True(t, control.noStore)
+	require.Nil(t, control.maxAge)
+}
+
+func TestRequestCacheControl_StricterWins(t *testing.T) {
+	maxAge := 10
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.Cache = &schemas.CacheControl{NoStore: true, MaxAge: &maxAge}
+
+	ctx := WithRequestHeaders(context.Background(), map[string][]string{"Cache-Control": {"max-age=60"}})
+	control := requestCacheControl(ctx, req)
+
+	require.True(t, control.noStore)
+	require.False(t, control.noCache)
+	require.Equal(t, 10*time.Second, *control.maxAge)
+
+	require.True(t, control.fresh(time.Now().Add(-5*time.Second)))
+	require.False(t, control.fresh(time.Now().Add(-time.Minute)))
+}
+
+func TestResponseCache_CacheControl(t *testing.T) {
+	ctx := context.Background()
+	responseCache, err := NewResponseCache("router", DefaultResponseCacheConfig(), nil, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+
+	// no-store responses are not cached
+	noStoreReq := schemas.NewChatFromStr("tell me a dad joke")
+	noStoreReq.Cache = &schemas.CacheControl{NoStore: true}
+
+	_, err = responseCache.Chat(ctx, noStoreReq, countingChat(&calls, 0))
+	require.NoError(t, err)
+
+	resp, err := responseCache.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	// no-cache requests skip the cached response
+	noCacheReq := schemas.NewChatFromStr("tell me a dad joke")
+	noCacheReq.Cache = &schemas.CacheControl{NoCache: true}
+
+	resp, err = responseCache.Chat(ctx, noCacheReq, countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	// zero max age accepts no cached responses, while a large one accepts the fresh cached response
+	maxAge := 0
+	maxAgeReq := schemas.NewChatFromStr("tell me a dad joke")
+	maxAgeReq.Cache = &schemas.CacheControl{MaxAge: &maxAge}
+
+	time.Sleep(10 * time.Millisecond)
+
+	resp, err = responseCache.Chat(ctx, maxAgeReq, countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	maxAge = 60
+
+	resp, err = responseCache.Chat(ctx, maxAgeReq, countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+
+	require.Equal(t, int32(4), calls.Load())
+	require.Equal(t, int64(1), responseCache.bypasses.Value())
+}
@@ -0,0 +1,283 @@
+package routers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/cache"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const defaultClassificationPrompt = "Classify the user request into exactly one of these categories: %v. " +
+	"Reply with the category name only."
+
+// ClassificationConfig defines labeling of chat requests by task type (e.g. code, summarization, creative, extraction)
+// with a small/cheap model, so requests with each label are routed to their own pool of router models
+type ClassificationConfig struct {
+	Model      *providers.LangModelConfig `yaml:"model" json:"model" validate:"required"`                      // the model that labels requests (e.g. a small hosted or local model)
+	Labels     map[string][]string        `yaml:"labels" json:"labels" validate:"required,min=1"`              // label -> IDs of router models that serve requests with the label
+	Prompt     string                     `yaml:"prompt,omitempty" json:"prompt,omitempty"`                    // instructions for the model, label names are listed by default
+	CacheTTL   *fields.Duration           `yaml:"cache_ttl" json:"cache_ttl" swaggertype:"primitive,string"`   // how long labels of identical requests are reused
+	MaxEntries int                        `yaml:"max_cache_entries" json:"max_cache_entries" validate:"gte=0"` // the classification cache size, zero means no limit
+}
+
+func DefaultClassificationConfig() *ClassificationConfig {
+	defaultTTL := 1 * time.Hour
+
+	return &ClassificationConfig{
+		CacheTTL:   (*fields.Duration)(&defaultTTL),
+		MaxEntries: 10_000,
+	}
+}
+
+func (c *ClassificationConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultClassificationConfig()
+
+	type plain ClassificationConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// poolRouting routes requests over a pool of router models (e.g. requests with the same label)
+type poolRouting struct {
+	chat       routing.LangModelRouting
+	chatStream routing.LangModelRouting
+}
+
+// newPoolRouting builds routings over the router models with the given IDs
+func newPoolRouting(
+	cfg *LangRouterConfig,
+	poolName string,
+	modelIDs []string,
+	chatModels []*providers.LanguageModel,
+	chatStreamModels []*providers.LanguageModel,
+) (*poolRouting, error) {
+	poolChatModels := make([]*providers.LanguageModel, 0, len(modelIDs))
+	poolChatStreamModels := make([]*providers.LanguageModel, 0, len(modelIDs))
+
+	for _, modelID := range modelIDs {
+		idx := slices.IndexFunc(chatModels, func(model *providers.LanguageModel) bool { return model.ID() == modelID })
+		if idx == -1 {
+			return nil, fmt.Errorf(
+				"router \"%v\" %v refers to model \"%v\" which is not found or disabled",
+				cfg.ID,
+				poolName,
+				modelID,
+			)
+		}
+
+		poolChatModels = append(poolChatModels, chatModels[idx])
+
+		if slices.Contains(chatStreamModels, chatModels[idx]) {
+			poolChatStreamModels = append(poolChatStreamModels, chatModels[idx])
+		}
+	}
+
+	chatRouting, chatStreamRouting, err := cfg.BuildRouting(poolChatModels, poolChatStreamModels)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(poolChatStreamModels) == 0 {
+		// streaming chat requests are routed over all models
+		chatStreamRouting = nil
+	}
+
+	return &poolRouting{chat: chatRouting, chatStream: chatStreamRouting}, nil
+}
+
+// Classifier labels chat requests and picks the routing of the label pool.
+// Requests the classifier failed to label are routed over all router models
+type Classifier struct {
+	routerID RouterID
+	config   *ClassificationConfig
+	model    *providers.LanguageModel
+	labels   []string
+	routings map[string]*poolRouting
+	cache    *cache.MemoryStore
+	ttl      time.Duration
+	tel      *telemetry.Telemetry
+	logger   *zap.Logger
+}
+
+func NewClassifier(
+	cfg *LangRouterConfig,
+	chatModels []*providers.LanguageModel,
+	chatStreamModels []*providers.LanguageModel,
+	tel *telemetry.Telemetry,
+) (*Classifier, error) {
+	classificationConfig := cfg.Classification
+
+	labels := make([]string, 0, len(classificationConfig.Labels))
+	routings := make(map[string]*poolRouting, len(classificationConfig.Labels))
+
+	for label, modelIDs := range classificationConfig.Labels {
+		labelRouting, err := newPoolRouting(
+			cfg,
+			fmt.Sprintf("classification label \"%v\"", label),
+			modelIDs,
+			chatModels,
+			chatStreamModels,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, strings.ToLower(label))
+		routings[strings.ToLower(label)] = labelRouting
+	}
+
+	// longer labels go first, so labels that are a part of other labels are not matched by mistake
+	slices.SortFunc(labels, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	model, err := classificationConfig.Model.ToModel(tel)
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl time.Duration
+
+	if classificationConfig.CacheTTL != nil {
+		ttl = time.Duration(*classificationConfig.CacheTTL)
+	}
+
+	return &Classifier{
+		routerID: cfg.ID,
+		config:   classificationConfig,
+		model:    model,
+		labels:   labels,
+		routings: routings,
+		cache:    cache.NewMemoryStore(classificationConfig.MaxEntries),
+		ttl:      ttl,
+		tel:      tel,
+		logger:   tel.L().With(zap.String("routerID", cfg.ID)),
+	}, nil
+}
+
+// ChatRouting returns the routing of the chat request label or the default routing if the request could not be labeled
+func (c *Classifier) ChatRouting(ctx context.Context, message string, defaultRouting routing.LangModelRouting) routing.LangModelRouting {
+	return c.chatRouting(c.Classify(ctx, message), defaultRouting)
+}
+
+func (c *Classifier) chatRouting(label string, defaultRouting routing.LangModelRouting) routing.LangModelRouting {
+	if labelRouting, found := c.routings[label]; found {
+		return labelRouting.chat
+	}
+
+	return defaultRouting
+}
+
+// ChatStreamRouting is the streaming chat counterpart of ChatRouting
+func (c *Classifier) ChatStreamRouting(ctx context.Context, message string, defaultRouting routing.LangModelRouting) routing.LangModelRouting {
+	if labelRouting, found := c.routings[c.Classify(ctx, message)]; found && labelRouting.chatStream != nil {
+		return labelRouting.chatStream
+	}
+
+	return defaultRouting
+}
+
+// Classify labels the message. An empty label is returned if the message could not be labeled
+func (c *Classifier) Classify(ctx context.Context, message string) string {
+	key := c.key(message)
+
+	if cachedLabel, found, _ := c.cache.Get(ctx, key); found {
+		c.metric("cache_hits").Inc()
+
+		return string(cachedLabel)
+	}
+
+	c.metric("requests").Inc()
+
+	resp, err := c.model.Chat(ctx, c.request(message))
+	if err != nil {
+		c.metric("errors").Inc()
+		c.logger.Warn(
+			"Classification model failed to label the request, routing it over all models",
+			zap.String("modelID", c.model.ID()),
+			zap.Error(err),
+		)
+
+		return ""
+	}
+
+	c.metric("tokens").Add(int64(resp.ModelResponse.TokenUsage.TotalTokens))
+
+	label := c.parseLabel(resp.ModelResponse.Message.Content)
+	if label == "" {
+		c.metric("unknown_labels").Inc()
+		c.logger.Debug(
+			"Classification model replied with an unknown label, routing the request over all models",
+			zap.String("reply", resp.ModelResponse.Message.Content),
+		)
+
+		return ""
+	}
+
+	c.metric(fmt.Sprintf("labels.%v", label)).Inc()
+
+	_ = c.cache.Set(ctx, key, []byte(label), c.ttl)
+
+	return label
+}
+
+// Shutdown stops background activities of the classification model
+func (c *Classifier) Shutdown() {
+	c.model.Shutdown()
+}
+
+func (c *Classifier) request(message string) *schemas.ChatRequest {
+	prompt := c.config.Prompt
+	if prompt == "" {
+		prompt = fmt.Sprintf(defaultClassificationPrompt, strings.Join(c.labels, ", "))
+	}
+
+	return &schemas.ChatRequest{
+		Message: schemas.ChatMessage{Role: "user", Content: message},
+		MessageHistory: []schemas.ChatMessage{
+			{Role: "system", Content: prompt},
+		},
+	}
+}
+
+// parseLabel finds the label in the model reply that may have extra words or punctuation around it
+func (c *Classifier) parseLabel(reply string) string {
+	reply = strings.ToLower(strings.TrimSpace(reply))
+
+	if _, found := c.routings[reply]; found {
+		return reply
+	}
+
+	for _, label := range c.labels {
+		if strings.Contains(reply, label) {
+			return label
+		}
+	}
+
+	return ""
+}
+
+func (c *Classifier) key(message string) string {
+	contentHash := sha256.Sum256([]byte(message))
+
+	return hex.EncodeToString(contentHash[:])
+}
+
+func (c *Classifier) metric(name string) *telemetry.Counter {
+	return c.tel.M().Counter(fmt.Sprintf("routers.%v.classification.%v", c.routerID, name))
+}
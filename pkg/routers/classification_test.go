@@ -0,0 +1,86 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newClassificationRouterConfig() LangRouterConfig {
+	routerConfig := newLangRouterConfig("classified", "gpt4", "haiku", "llama")
+
+	classifierModel := newOpenAIModelConfig("classifier")
+
+	routerConfig.Classification = DefaultClassificationConfig()
+	routerConfig.Classification.Model = &classifierModel
+	routerConfig.Classification.Labels = map[string][]string{
+		"code":          {"gpt4"},
+		"summarization": {"haiku", "llama"},
+	}
+
+	return routerConfig
+}
+
+func nextModelID(t *testing.T, modelRouting routing.LangModelRouting) string {
+	model, err := modelRouting.Iterator().Next()
+	require.NoError(t, err)
+
+	return model.ID()
+}
+
+func TestClassifier_RoutesByLabel(t *testing.T) {
+	routerConfig := newClassificationRouterConfig()
+	tel := telemetry.NewTelemetryMock()
+
+	router, err := NewLangRouter(&routerConfig, tel)
+	require.NoError(t, err)
+
+	classifierErr := errors.New("classifier is down")
+
+	router.classifier.model = providers.NewLangModel(
+		"classifier",
+		ptesting.NewProviderMock([]ptesting.RespMock{
+			{Msg: "Code."},
+			{Msg: "summarization"},
+			{Msg: "poetry"},
+			{Err: &classifierErr},
+		}),
+		health.NewErrorBudget(10, health.SEC),
+		*latency.DefaultConfig(),
+		1,
+	)
+
+	ctx := context.Background()
+
+	require.Equal(t, "gpt4", nextModelID(t, router.classifier.ChatRouting(ctx, "fix my golang code", router.chatRouting)))
+	require.Equal(t, "haiku", nextModelID(t, router.classifier.ChatRouting(ctx, "summarize the article", router.chatRouting)))
+
+	// unknown labels & classifier errors fall back to all router models
+	require.Same(t, router.chatRouting, router.classifier.ChatRouting(ctx, "write a poem", router.chatRouting))
+	require.Same(t, router.chatRouting, router.classifier.ChatRouting(ctx, "translate it", router.chatRouting))
+
+	// labels of identical requests are cached
+	require.Equal(t, "gpt4", nextModelID(t, router.classifier.ChatRouting(ctx, "fix my golang code", router.chatRouting)))
+
+	require.Equal(t, int64(1), tel.M().Counter("routers.classified.classification.cache_hits").Value())
+	require.Equal(t, int64(4), tel.M().Counter("routers.classified.classification.requests").Value())
+	require.Equal(t, int64(1), tel.M().Counter("routers.classified.classification.labels.code").Value())
+	require.Equal(t, int64(1), tel.M().Counter("routers.classified.classification.unknown_labels").Value())
+	require.Equal(t, int64(1), tel.M().Counter("routers.classified.classification.errors").Value())
+}
+
+func TestClassifier_UnknownLabelModel(t *testing.T) {
+	routerConfig := newClassificationRouterConfig()
+	routerConfig.Classification.Labels["extraction"] = []string{"mistral"}
+
+	_, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "refers to model \"mistral\"")
+}
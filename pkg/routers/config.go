@@ -2,7 +2,10 @@ package routers
 
 import (
 	"fmt"
+	"math"
+	"time"
 
+	"glide/pkg/events"
 	"glide/pkg/providers"
 	"glide/pkg/routers/retry"
 	"glide/pkg/routers/routing"
@@ -12,7 +15,11 @@ import (
 )
 
 type Config struct {
-	LanguageRouters []LangRouterConfig `yaml:"language" validate:"required,gte=1,dive"` // the list of language routers
+	LanguageRouters   []LangRouterConfig       `yaml:"language" validate:"required,gte=1,dive"` // the list of language routers
+	ImageRouters      []ImageRouterConfig      `yaml:"image" validate:"dive"`                   // the list of image routers
+	AudioRouters      []AudioRouterConfig      `yaml:"audio" validate:"dive"`                   // the list of audio routers
+	ModerationRouters []ModerationRouterConfig `yaml:"moderation" validate:"dive"`              // the list of moderation routers
+	Metadata          *MetadataConfig          `yaml:"metadata"`                                // caching of upstream model lists & capabilities
 }
 
 func (c *Config) BuildLangRouters(tel *telemetry.Telemetry) ([]*LangRouter, error) {
@@ -51,15 +58,146 @@ func (c *Config) BuildLangRouters(tel *telemetry.Telemetry) ([]*LangRouter, erro
 	return routers, nil
 }
 
+func (c *Config) BuildImageRouters(tel *telemetry.Telemetry) ([]*ImageRouter, error) {
+	seenIDs := make(map[string]bool, len(c.ImageRouters))
+	routers := make([]*ImageRouter, 0, len(c.ImageRouters))
+
+	var errs error
+
+	for idx, routerConfig := range c.ImageRouters {
+		if _, ok := seenIDs[routerConfig.ID]; ok {
+			return nil, fmt.Errorf("ID \"%v\" is specified for more than one image router while each ID should be unique", routerConfig.ID)
+		}
+
+		seenIDs[routerConfig.ID] = true
+
+		if !routerConfig.Enabled {
+			tel.L().Info(fmt.Sprintf("Image router \"%v\" is disabled, skipping", routerConfig.ID))
+			continue
+		}
+
+		tel.L().Debug("Init image router", zap.String("routerID", routerConfig.ID))
+
+		router, err := NewImageRouter(&c.ImageRouters[idx], tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		routers = append(routers, router)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return routers, nil
+}
+
+func (c *Config) BuildModerationRouters(tel *telemetry.Telemetry) ([]*ModerationRouter, error) {
+	seenIDs := make(map[string]bool, len(c.ModerationRouters))
+	routers := make([]*ModerationRouter, 0, len(c.ModerationRouters))
+
+	var errs error
+
+	for idx, routerConfig := range c.ModerationRouters {
+		if _, ok := seenIDs[routerConfig.ID]; ok {
+			return nil, fmt.Errorf("ID \"%v\" is specified for more than one moderation router while each ID should be unique", routerConfig.ID)
+		}
+
+		seenIDs[routerConfig.ID] = true
+
+		if !routerConfig.Enabled {
+			tel.L().Info(fmt.Sprintf("Moderation router \"%v\" is disabled, skipping", routerConfig.ID))
+			continue
+		}
+
+		tel.L().Debug("Init moderation router", zap.String("routerID", routerConfig.ID))
+
+		router, err := NewModerationRouter(&c.ModerationRouters[idx], tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		routers = append(routers, router)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return routers, nil
+}
+
+func (c *Config) BuildAudioRouters(tel *telemetry.Telemetry) ([]*AudioRouter, error) {
+	seenIDs := make(map[string]bool, len(c.AudioRouters))
+	routers := make([]*AudioRouter, 0, len(c.AudioRouters))
+
+	var errs error
+
+	for idx, routerConfig := range c.AudioRouters {
+		if _, ok := seenIDs[routerConfig.ID]; ok {
+			return nil, fmt.Errorf("ID \"%v\" is specified for more than one audio router while each ID should be unique", routerConfig.ID)
+		}
+
+		seenIDs[routerConfig.ID] = true
+
+		if !routerConfig.Enabled {
+			tel.L().Info(fmt.Sprintf("Audio router \"%v\" is disabled, skipping", routerConfig.ID))
+			continue
+		}
+
+		tel.L().Debug("Init audio router", zap.String("routerID", routerConfig.ID))
+
+		router, err := NewAudioRouter(&c.AudioRouters[idx], tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		routers = append(routers, router)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return routers, nil
+}
+
 // TODO: how to specify other backoff strategies?
 // TODO: Had to keep RoutingStrategy because of https://github.com/swaggo/swag/issues/1738
 // LangRouterConfig
 type LangRouterConfig struct {
-	ID              string                      `yaml:"id" json:"routers" validate:"required"`                                       // Unique router ID
-	Enabled         bool                        `yaml:"enabled" json:"enabled" validate:"required"`                                  // Is router enabled?
-	Retry           *retry.ExpRetryConfig       `yaml:"retry" json:"retry" validate:"required"`                                      // retry when no healthy model is available to router
-	RoutingStrategy routing.Strategy            `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"` // strategy on picking the next model to serve the request
-	Models          []providers.LangModelConfig `yaml:"models" json:"models" validate:"required,min=1,dive"`                         // the list of models that could handle requests
+	ID                   string                       `yaml:"id" json:"routers" validate:"required"`                                                                    // Unique router ID
+	Enabled              bool                         `yaml:"enabled" json:"enabled" validate:"required"`                                                               // Is router enabled?
+	Retry                *retry.ExpRetryConfig        `yaml:"retry" json:"retry" validate:"required"`                                                                   // retry when no healthy model is available to router
+	RoutingStrategy      routing.Strategy             `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"`                              // strategy on picking the next model to serve the request
+	Models               []providers.LangModelConfig  `yaml:"models" json:"models" validate:"required_without=Routers,dive"`                                            // the list of models that could handle requests
+	Routers              []NestedRouterConfig         `yaml:"nested_routers,omitempty" json:"nested_routers,omitempty" validate:"excluded_with=Models,dive"`            // language routers to route requests between instead of models
+	Guardrail            *GuardrailConfig             `yaml:"guardrail,omitempty" json:"guardrail,omitempty"`                                                           // moderation of chat requests before they reach models
+	EmbedCache           *EmbedCacheConfig            `yaml:"embed_cache,omitempty" json:"embed_cache,omitempty"`                                                       // caching of embeddings by the input content
+	ResponseCache        *ResponseCacheConfig         `yaml:"response_cache,omitempty" json:"response_cache,omitempty"`                                                 // caching of chat responses by the request messages
+	Bandit               *routing.BanditConfig        `yaml:"bandit,omitempty" json:"bandit,omitempty"`                                                                 // settings of the bandit routing strategy
+	CostAware            *routing.CostAwareConfig     `yaml:"cost_aware,omitempty" json:"cost_aware,omitempty"`                                                         // settings of the cost-aware routing strategy
+	LeastLatency         *routing.LeastLatencyConfig  `yaml:"least_latency,omitempty" json:"least_latency,omitempty"`                                                   // settings of the least latency routing strategy
+	ABTest               *routing.ABTestConfig        `yaml:"ab_test,omitempty" json:"ab_test,omitempty"`                                                               // variants of the A/B testing strategy
+	Canary               *routing.CanaryConfig        `yaml:"canary,omitempty" json:"canary,omitempty"`                                                                 // settings of the canary rollout strategy
+	Shadow               *ShadowConfig                `yaml:"shadow,omitempty" json:"shadow,omitempty"`                                                                 // mirroring of chat requests to a model under evaluation
+	Rules                *RulesConfig                 `yaml:"rules,omitempty" json:"rules,omitempty"`                                                                   // routing of chat requests to model pools by request attributes
+	Pinning              *PinningConfig               `yaml:"pinning,omitempty" json:"pinning,omitempty"`                                                               // pinning of requests to specific models via the request field or the X-Glide-Model header
+	Classification       *ClassificationConfig        `yaml:"classification,omitempty" json:"classification,omitempty"`                                                 // routing of chat requests to model pools by their task type
+	Budget               *providers.SpendBudgetConfig `yaml:"budget,omitempty" json:"budget,omitempty"`                                                                 // the router rejects requests once it has spent the daily or monthly budget
+	Events               *events.DeliveryConfig       `yaml:"events,omitempty" json:"events,omitempty"`                                                                 // where router events (e.g. exhausted budgets) are delivered
+	Webhooks             []*events.DeliveryConfig     `yaml:"webhooks,omitempty" json:"webhooks,omitempty" validate:"dive"`                                             // more URLs router events are delivered to (e.g. a paging service subscribed to "model.*")
+	FallbackStorm        *FallbackStormConfig         `yaml:"fallback_storm,omitempty" json:"fallback_storm,omitempty"`                                                 // detection of bursts of requests falling back to the next models
+	Hedging              *HedgingConfig               `yaml:"hedging,omitempty" json:"hedging,omitempty"`                                                               // hedging of slow chat requests with the next best model
+	Queue                *QueueConfig                 `yaml:"queue,omitempty" json:"queue,omitempty"`                                                                   // chat requests wait for models at their concurrency limits instead of failing right away
+	Fallback             *FallbackConfig              `yaml:"fallback,omitempty" json:"fallback,omitempty"`                                                             // which response failures are retried on the next model (all by default)
+	Idempotency          *IdempotencyConfig           `yaml:"idempotency,omitempty" json:"idempotency,omitempty"`                                                       // chat requests retried with the same Idempotency-Key header are served the first response
+	StreamResumption     *StreamResumptionConfig      `yaml:"stream_resumption,omitempty" json:"stream_resumption,omitempty"`                                           // streaming chats failed midway are continued by the next model
+	SlowRequestThreshold *time.Duration               `yaml:"slow_request_threshold,omitempty" json:"slow_request_threshold,omitempty" swaggertype:"primitive,integer"` // chat requests taking longer are logged with their routing trace
 }
 
 // BuildModels creates LanguageModel slice out of the given config
@@ -174,31 +312,215 @@ func (c *LangRouterConfig) BuildRouting(
 	chatModels []*providers.LanguageModel,
 	chatStreamModels []*providers.LanguageModel,
 ) (routing.LangModelRouting, routing.LangModelRouting, error) {
-	chatModelPool := make([]providers.Model, 0, len(chatModels))
-	chatStreamModelPool := make([]providers.Model, 0, len(chatStreamModels))
+	chatRouting, err := c.buildRouting(chatModels, providers.ChatAction)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	for _, model := range chatModels {
-		chatModelPool = append(chatModelPool, model)
+	chatStreamRouting, err := c.buildRouting(chatStreamModels, providers.ChatStreamAction)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	for _, model := range chatStreamModels {
-		chatStreamModelPool = append(chatStreamModelPool, model)
+	return chatRouting, chatStreamRouting, nil
+}
+
+// BuildEmbedRouting creates routing for models that support embeddings
+func (c *LangRouterConfig) BuildEmbedRouting(embedModels []*providers.LanguageModel) (routing.LangModelRouting, error) {
+	return c.buildRouting(embedModels, providers.EmbedAction)
+}
+
+// buildRouting creates the routing of the action over the models
+func (c *LangRouterConfig) buildRouting(models []*providers.LanguageModel, action providers.Action) (routing.LangModelRouting, error) {
+	modelPool := make([]providers.Model, 0, len(models))
+
+	for _, model := range models {
+		modelPool = append(modelPool, model)
+	}
+
+	if c.RoutingStrategy == routing.CostAware {
+		return c.buildCostAwareRouting(models, modelPool, action)
+	}
+
+	if c.RoutingStrategy == routing.LeastLatency {
+		return c.buildLeastLatencyRouting(modelPool, action), nil
 	}
 
-	switch c.RoutingStrategy {
+	if c.RoutingStrategy == routing.ABTest {
+		return c.buildABTestRouting(modelPool)
+	}
+
+	if c.RoutingStrategy == routing.Canary {
+		return c.buildCanaryRouting(modelPool)
+	}
+
+	if c.RoutingStrategy == routing.LeastBusy {
+		return routing.NewLeastBusyRouting(providers.InFlight, modelPool), nil
+	}
+
+	if c.RoutingStrategy == routing.PowerOfTwo {
+		return routing.NewPowerOfTwoRouting(providers.InFlight, providers.Latency, action, modelPool), nil
+	}
+
+	if c.RoutingStrategy != routing.Bandit {
+		return newRouting(c.RoutingStrategy, modelPool, providers.Latency, action)
+	}
+
+	banditConfig := c.Bandit
+	if banditConfig == nil {
+		banditConfig = routing.DefaultBanditConfig()
+	}
+
+	if banditConfig.Reward == routing.CostReward {
+		for _, model := range models {
+			if model.Pricing() == nil {
+				return nil, fmt.Errorf(
+					"model \"%v\" in router \"%v\" has no pricing configured, while the bandit strategy optimizes the request cost",
+					model.ID(),
+					c.ID,
+				)
+			}
+		}
+	}
+
+	return routing.NewBanditRouting(banditConfig, modelPool), nil
+}
+
+func (c *LangRouterConfig) buildCostAwareRouting(
+	models []*providers.LanguageModel,
+	modelPool []providers.Model,
+	action providers.Action,
+) (routing.LangModelRouting, error) {
+	for _, model := range models {
+		if model.Pricing() == nil {
+			return nil, fmt.Errorf(
+				"model \"%v\" in router \"%v\" has no pricing configured, while the cost-aware strategy routes by the model cost",
+				model.ID(),
+				c.ID,
+			)
+		}
+	}
+
+	costAwareConfig := c.CostAware
+	if costAwareConfig == nil {
+		costAwareConfig = routing.DefaultCostAwareConfig()
+	}
+
+	return routing.NewCostAwareRouting(costAwareConfig, providers.Latency, action, providers.ModelPricing, modelPool), nil
+}
+
+func (c *LangRouterConfig) buildABTestRouting(modelPool []providers.Model) (routing.LangModelRouting, error) {
+	if c.ABTest == nil {
+		return nil, fmt.Errorf("router \"%v\" uses the A/B testing strategy, but has no variants configured", c.ID)
+	}
+
+	seenModelIDs := make(map[string]bool, len(c.Models))
+
+	for _, modelConfig := range c.Models {
+		seenModelIDs[modelConfig.ID] = true
+	}
+
+	total := 0.0
+
+	for _, variant := range c.ABTest.Variants {
+		if !seenModelIDs[variant.Model] {
+			return nil, fmt.Errorf(
+				"variant \"%v\" of router \"%v\" refers to the unknown model \"%v\"",
+				variant.Name,
+				c.ID,
+				variant.Model,
+			)
+		}
+
+		total += variant.Percentage
+	}
+
+	if math.Abs(total-100) > 0.001 {
+		return nil, fmt.Errorf("variant percentages of router \"%v\" should sum up to 100, got %v", c.ID, total)
+	}
+
+	return routing.NewABTestRouting(c.ABTest, modelPool), nil
+}
+
+func (c *LangRouterConfig) buildCanaryRouting(modelPool []providers.Model) (routing.LangModelRouting, error) {
+	if c.Canary == nil {
+		return nil, fmt.Errorf("router \"%v\" uses the canary strategy, but has no baseline & canary models configured", c.ID)
+	}
+
+	seenModelIDs := make(map[string]bool, len(c.Models))
+
+	for _, modelConfig := range c.Models {
+		seenModelIDs[modelConfig.ID] = true
+	}
+
+	for _, modelID := range []string{c.Canary.Baseline, c.Canary.Canary} {
+		if !seenModelIDs[modelID] {
+			return nil, fmt.Errorf("canary rollout of router \"%v\" refers to the unknown model \"%v\"", c.ID, modelID)
+		}
+	}
+
+	if c.Canary.Baseline == c.Canary.Canary {
+		return nil, fmt.Errorf("canary rollout of router \"%v\" should use different baseline & canary models", c.ID)
+	}
+
+	return routing.NewCanaryRouting(c.Canary, modelPool), nil
+}
+
+// buildLeastLatencyRouting creates the least latency routing of the action over the model pool
+func (c *LangRouterConfig) buildLeastLatencyRouting(modelPool []providers.Model, action providers.Action) *routing.LeastLatencyRouting {
+	config := c.LeastLatency
+
+	if config == nil {
+		config = routing.DefaultLeastLatencyConfig()
+	}
+
+	latencyGetter, quantilesGetter := providers.Latency, providers.LatencyQuantiles
+
+	if config.Signal == routing.TTFTSignal && action == providers.ChatStreamAction {
+		latencyGetter, quantilesGetter = providers.TTFT, providers.TTFTQuantiles
+	}
+
+	leastLatency := routing.NewLeastLatencyRouting(latencyGetter, action, modelPool)
+
+	if config.Percentile > 0 {
+		leastLatency = routing.NewPercentileLatencyRouting(quantilesGetter, action, config.Percentile, modelPool)
+	}
+
+	if config.ErrorPenalty > 0 {
+		leastLatency.PenalizeErrors(providers.ErrorRate, config.ErrorPenalty)
+	}
+
+	leastLatency.WithWarmup(config.WarmupSamples, config.WarmupPattern, config.WarmupShare)
+
+	return leastLatency
+}
+
+func newRouting(
+	strategy routing.Strategy,
+	modelPool []providers.Model,
+	latencyGetter routing.LatencyGetter,
+	action providers.Action,
+) (routing.LangModelRouting, error) {
+	switch strategy {
+	case routing.Bandit, routing.CostAware, routing.LeastBusy, routing.PowerOfTwo, routing.ABTest, routing.Canary:
+		return nil, fmt.Errorf("routing strategy \"%v\" is supported by language routers only", strategy)
 	case routing.Priority:
-		return routing.NewPriority(chatModelPool), routing.NewPriority(chatStreamModelPool), nil
+		return routing.NewPriority(modelPool), nil
 	case routing.RoundRobin:
-		return routing.NewRoundRobinRouting(chatModelPool), routing.NewRoundRobinRouting(chatStreamModelPool), nil
+		return routing.NewRoundRobinRouting(modelPool), nil
 	case routing.WeightedRoundRobin:
-		return routing.NewWeightedRoundRobin(chatModelPool), routing.NewWeightedRoundRobin(chatStreamModelPool), nil
+		return routing.NewWeightedRoundRobin(modelPool), nil
 	case routing.LeastLatency:
-		return routing.NewLeastLatencyRouting(providers.ChatLatency, chatModelPool),
-			routing.NewLeastLatencyRouting(providers.ChatStreamLatency, chatStreamModelPool),
-			nil
+		return routing.NewLeastLatencyRouting(latencyGetter, action, modelPool), nil
+	case routing.Sticky:
+		return routing.NewStickyRouting(modelPool), nil
+	}
+
+	if factory, found := routing.Lookup(strategy); found {
+		return factory(modelPool, latencyGetter, action)
 	}
 
-	return nil, nil, fmt.Errorf("routing strategy \"%v\" is not supported, please make sure there is no typo", c.RoutingStrategy)
+	return nil, fmt.Errorf("routing strategy \"%v\" is not supported, please make sure there is no typo", strategy)
 }
 
 func DefaultLangRouterConfig() LangRouterConfig {
@@ -216,3 +538,303 @@ func (c *LangRouterConfig) UnmarshalYAML(unmarshal func(interface{}) error) erro
 
 	return unmarshal((*plain)(c))
 }
+
+// ImageRouterConfig
+type ImageRouterConfig struct {
+	ID              string                       `yaml:"id" json:"routers" validate:"required"`                                       // Unique router ID
+	Enabled         bool                         `yaml:"enabled" json:"enabled" validate:"required"`                                  // Is router enabled?
+	Retry           *retry.ExpRetryConfig        `yaml:"retry" json:"retry" validate:"required"`                                      // retry when no healthy model is available to router
+	RoutingStrategy routing.Strategy             `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"` // strategy on picking the next model to serve the request
+	Models          []providers.ImageModelConfig `yaml:"models" json:"models" validate:"required,min=1,dive"`                         // the list of models that could handle requests
+}
+
+// BuildModels creates ImageModel slice out of the given config
+func (c *ImageRouterConfig) BuildModels(tel *telemetry.Telemetry) ([]*providers.ImageModel, error) {
+	var errs error
+
+	seenIDs := make(map[string]bool, len(c.Models))
+	models := make([]*providers.ImageModel, 0, len(c.Models))
+
+	for _, modelConfig := range c.Models {
+		if _, ok := seenIDs[modelConfig.ID]; ok {
+			return nil, fmt.Errorf(
+				"ID \"%v\" is specified for more than one model in router \"%v\", while it should be unique in scope of that pool",
+				modelConfig.ID,
+				c.ID,
+			)
+		}
+
+		seenIDs[modelConfig.ID] = true
+
+		if !modelConfig.Enabled {
+			tel.L().Info(
+				"Model is disabled, skipping",
+				zap.String("router", c.ID),
+				zap.String("model", modelConfig.ID),
+			)
+
+			continue
+		}
+
+		tel.L().Debug(
+			"Init image model",
+			zap.String("router", c.ID),
+			zap.String("model", modelConfig.ID),
+		)
+
+		model, err := modelConfig.ToModel(tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	if len(models) == 0 {
+		return nil, fmt.Errorf("router \"%v\" must have at least one active model, zero defined", c.ID)
+	}
+
+	return models, nil
+}
+
+func (c *ImageRouterConfig) BuildRetry() *retry.ExpRetry {
+	retryConfig := c.Retry
+
+	return retry.NewExpRetry(
+		retryConfig.MaxRetries,
+		retryConfig.BaseMultiplier,
+		retryConfig.MinDelay,
+		retryConfig.MaxDelay,
+	)
+}
+
+func (c *ImageRouterConfig) BuildRouting(models []*providers.ImageModel) (routing.LangModelRouting, error) {
+	modelPool := make([]providers.Model, 0, len(models))
+
+	for _, model := range models {
+		modelPool = append(modelPool, model)
+	}
+
+	return newRouting(c.RoutingStrategy, modelPool, providers.Latency, providers.ImageGenerateAction)
+}
+
+func DefaultImageRouterConfig() ImageRouterConfig {
+	return ImageRouterConfig{
+		Enabled:         true,
+		RoutingStrategy: routing.Priority,
+		Retry:           retry.DefaultExpRetryConfig(),
+	}
+}
+
+func (c *ImageRouterConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultImageRouterConfig()
+
+	type plain ImageRouterConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// AudioRouterConfig
+type AudioRouterConfig struct {
+	ID              string                       `yaml:"id" json:"routers" validate:"required"`                                       // Unique router ID
+	Enabled         bool                         `yaml:"enabled" json:"enabled" validate:"required"`                                  // Is router enabled?
+	Retry           *retry.ExpRetryConfig        `yaml:"retry" json:"retry" validate:"required"`                                      // retry when no healthy model is available to router
+	RoutingStrategy routing.Strategy             `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"` // strategy on picking the next model to serve the request
+	Models          []providers.AudioModelConfig `yaml:"models" json:"models" validate:"required,min=1,dive"`                         // the list of models that could handle requests
+}
+
+// BuildModels creates AudioModel slice out of the given config
+func (c *AudioRouterConfig) BuildModels(tel *telemetry.Telemetry) ([]*providers.AudioModel, error) {
+	var errs error
+
+	seenIDs := make(map[string]bool, len(c.Models))
+	models := make([]*providers.AudioModel, 0, len(c.Models))
+
+	for _, modelConfig := range c.Models {
+		if _, ok := seenIDs[modelConfig.ID]; ok {
+			return nil, fmt.Errorf(
+				"ID \"%v\" is specified for more than one model in router \"%v\", while it should be unique in scope of that pool",
+				modelConfig.ID,
+				c.ID,
+			)
+		}
+
+		seenIDs[modelConfig.ID] = true
+
+		if !modelConfig.Enabled {
+			tel.L().Info(
+				"Model is disabled, skipping",
+				zap.String("router", c.ID),
+				zap.String("model", modelConfig.ID),
+			)
+
+			continue
+		}
+
+		tel.L().Debug(
+			"Init audio model",
+			zap.String("router", c.ID),
+			zap.String("model", modelConfig.ID),
+		)
+
+		model, err := modelConfig.ToModel(tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	if len(models) == 0 {
+		return nil, fmt.Errorf("router \"%v\" must have at least one active model, zero defined", c.ID)
+	}
+
+	return models, nil
+}
+
+func (c *AudioRouterConfig) BuildRetry() *retry.ExpRetry {
+	retryConfig := c.Retry
+
+	return retry.NewExpRetry(
+		retryConfig.MaxRetries,
+		retryConfig.BaseMultiplier,
+		retryConfig.MinDelay,
+		retryConfig.MaxDelay,
+	)
+}
+
+func (c *AudioRouterConfig) BuildRouting(models []*providers.AudioModel) (routing.LangModelRouting, error) {
+	modelPool := make([]providers.Model, 0, len(models))
+
+	for _, model := range models {
+		modelPool = append(modelPool, model)
+	}
+
+	return newRouting(c.RoutingStrategy, modelPool, providers.Latency, providers.TranscribeAction)
+}
+
+func DefaultAudioRouterConfig() AudioRouterConfig {
+	return AudioRouterConfig{
+		Enabled:         true,
+		RoutingStrategy: routing.Priority,
+		Retry:           retry.DefaultExpRetryConfig(),
+	}
+}
+
+func (c *AudioRouterConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultAudioRouterConfig()
+
+	type plain AudioRouterConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// ModerationRouterConfig
+type ModerationRouterConfig struct {
+	ID              string                            `yaml:"id" json:"routers" validate:"required"`                                       // Unique router ID
+	Enabled         bool                              `yaml:"enabled" json:"enabled" validate:"required"`                                  // Is router enabled?
+	Retry           *retry.ExpRetryConfig             `yaml:"retry" json:"retry" validate:"required"`                                      // retry when no healthy model is available to router
+	RoutingStrategy routing.Strategy                  `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"` // strategy on picking the next model to serve the request
+	Models          []providers.ModerationModelConfig `yaml:"models" json:"models" validate:"required,min=1,dive"`                         // the list of models that could handle requests
+}
+
+// BuildModels creates ModerationModel slice out of the given config
+func (c *ModerationRouterConfig) BuildModels(tel *telemetry.Telemetry) ([]*providers.ModerationModel, error) {
+	var errs error
+
+	seenIDs := make(map[string]bool, len(c.Models))
+	models := make([]*providers.ModerationModel, 0, len(c.Models))
+
+	for _, modelConfig := range c.Models {
+		if _, ok := seenIDs[modelConfig.ID]; ok {
+			return nil, fmt.Errorf(
+				"ID \"%v\" is specified for more than one model in router \"%v\", while it should be unique in scope of that pool",
+				modelConfig.ID,
+				c.ID,
+			)
+		}
+
+		seenIDs[modelConfig.ID] = true
+
+		if !modelConfig.Enabled {
+			tel.L().Info(
+				"Model is disabled, skipping",
+				zap.String("router", c.ID),
+				zap.String("model", modelConfig.ID),
+			)
+
+			continue
+		}
+
+		tel.L().Debug(
+			"Init moderation model",
+			zap.String("router", c.ID),
+			zap.String("model", modelConfig.ID),
+		)
+
+		model, err := modelConfig.ToModel(tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	if len(models) == 0 {
+		return nil, fmt.Errorf("router \"%v\" must have at least one active model, zero defined", c.ID)
+	}
+
+	return models, nil
+}
+
+func (c *ModerationRouterConfig) BuildRetry() *retry.ExpRetry {
+	retryConfig := c.Retry
+
+	return retry.NewExpRetry(
+		retryConfig.MaxRetries,
+		retryConfig.BaseMultiplier,
+		retryConfig.MinDelay,
+		retryConfig.MaxDelay,
+	)
+}
+
+func (c *ModerationRouterConfig) BuildRouting(models []*providers.ModerationModel) (routing.LangModelRouting, error) {
+	modelPool := make([]providers.Model, 0, len(models))
+
+	for _, model := range models {
+		modelPool = append(modelPool, model)
+	}
+
+	return newRouting(c.RoutingStrategy, modelPool, providers.Latency, providers.ModerateAction)
+}
+
+func DefaultModerationRouterConfig() ModerationRouterConfig {
+	return ModerationRouterConfig{
+		Enabled:         true,
+		RoutingStrategy: routing.Priority,
+		Retry:           retry.DefaultExpRetryConfig(),
+	}
+}
+
+func (c *ModerationRouterConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultModerationRouterConfig()
+
+	type plain ModerationRouterConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -2,6 +2,7 @@ package routers
 
 import (
 	"testing"
+	"time"
 
 	"glide/pkg/providers/cohere"
 
@@ -226,3 +227,109 @@ func TestRouterConfig_InvalidSetups(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestLangRouterConfig_CostAwareRequiresPricing(t *testing.T) {
+	routerConfig := newLangRouterConfig("cost", "openai")
+	routerConfig.RoutingStrategy = routing.CostAware
+
+	_, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "has no pricing configured")
+
+	routerConfig.Models[0].Pricing = &providers.Pricing{PromptTokens: 0.5, ResponseTokens: 1.5}
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.IsType(t, &routing.CostAwareRouting{}, router.chatRouting)
+}
+
+func TestLangRouterConfig_PercentileLatency(t *testing.T) {
+	routerConfig := newLangRouterConfig("percentile", "openai")
+	routerConfig.RoutingStrategy = routing.LeastLatency
+	routerConfig.LeastLatency = &routing.LeastLatencyConfig{Percentile: 95}
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	model, err := router.chatRouting.Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "openai", model.ID())
+}
+
+func TestLangRouterConfig_TTFTLatency(t *testing.T) {
+	routerConfig := newLangRouterConfig("ttft", "openai", "another_openai")
+	routerConfig.RoutingStrategy = routing.LeastLatency
+	routerConfig.LeastLatency = routing.DefaultLeastLatencyConfig()
+	routerConfig.LeastLatency.Signal = routing.TTFTSignal
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	defer router.Shutdown()
+
+	// the first model streams chunks faster, but starts responding later
+	for range 4 {
+		router.chatStreamModels[0].ChatStreamLatency().Add(float64(10 * time.Millisecond))
+		router.chatStreamModels[0].TTFT().Add(float64(900 * time.Millisecond))
+		router.chatStreamModels[1].ChatStreamLatency().Add(float64(30 * time.Millisecond))
+		router.chatStreamModels[1].TTFT().Add(float64(300 * time.Millisecond))
+	}
+
+	model, err := router.chatStreamRouting.Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "another_openai", model.ID())
+}
+
+func TestLangRouterConfig_Canary(t *testing.T) {
+	routerConfig := newLangRouterConfig("canary", "openai", "another_openai")
+	routerConfig.RoutingStrategy = routing.Canary
+
+	_, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "has no baseline & canary models configured")
+
+	routerConfig.Canary = routing.DefaultCanaryConfig()
+	routerConfig.Canary.Baseline, routerConfig.Canary.Canary = "openai", "unknown"
+
+	_, err = NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "refers to the unknown model")
+
+	routerConfig.Canary.Canary = "another_openai"
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.IsType(t, &routing.CanaryRouting{}, router.chatRouting)
+}
+
+func TestLangRouterConfig_CustomStrategy(t *testing.T) {
+	var poolSize int
+
+	actions := make([]providers.Action, 0, 3)
+
+	err := routing.Register(
+		"custom_config_test",
+		func(models []providers.Model, _ routing.LatencyGetter, action providers.Action) (routing.LangModelRouting, error) {
+			poolSize = len(models)
+			actions = append(actions, action)
+
+			return routing.NewPriority(models), nil
+		},
+	)
+	require.NoError(t, err)
+
+	routerConfig := newLangRouterConfig("custom", "first", "second")
+	routerConfig.RoutingStrategy = "custom_config_test"
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.Equal(t, 2, poolSize)
+	require.Contains(t, actions, providers.ChatAction)
+	require.Contains(t, actions, providers.ChatStreamAction)
+
+	model, err := router.chatRouting.Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "first", model.ID())
+
+	routerConfig.RoutingStrategy = "unknown"
+
+	_, err = NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "is not supported")
+}
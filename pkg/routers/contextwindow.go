@@ -0,0 +1,128 @@
+package routers
+
+import (
+	"errors"
+	"fmt"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+var (
+	ErrContextWindowExceeded = errors.New("prompt is too long for context windows of all router models")
+	ErrCapabilityUnsupported = errors.New("none of router models supports capabilities the request requires")
+)
+
+// requestNeeds describes what models should support to serve the request
+type requestNeeds struct {
+	promptTokens int
+	capabilities []schemas.Capability
+}
+
+// contextWindowIterator skips models with context windows too small for the prompt
+// or without capabilities the request requires, so requests are not sent to providers just to be rejected.
+//
+//	Some strategies keep picking the same model (e.g. the fastest one) until it becomes unhealthy,
+//	so when the strategy doesn't offer a fitting model for a while,
+//	healthy fitting models of the router are tried in the order of definition
+type contextWindowIterator struct {
+	routerID   RouterID
+	iterator   routing.LangModelIterator
+	models     []*providers.LanguageModel
+	needs      requestNeeds
+	skipsInRow int
+	fallback   bool
+	returned   map[string]struct{}
+	tel        *telemetry.Telemetry
+}
+
+func (r *LangRouter) fittingModels(
+	iterator routing.LangModelIterator,
+	models []*providers.LanguageModel,
+	needs requestNeeds,
+) routing.LangModelIterator {
+	return &contextWindowIterator{
+		routerID: r.routerID,
+		iterator: iterator,
+		models:   models,
+		needs:    needs,
+		returned: make(map[string]struct{}, len(models)),
+		tel:      r.tel,
+	}
+}
+
+func (i *contextWindowIterator) Next() (providers.Model, error) {
+	for !i.fallback {
+		model, err := i.iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		langModel, ok := model.(*providers.LanguageModel)
+		if !ok {
+			return model, nil
+		}
+
+		skipReason := i.skipReason(langModel)
+		if skipReason == "" {
+			i.skipsInRow = 0
+			i.returned[model.ID()] = struct{}{}
+
+			return model, nil
+		}
+
+		i.tel.M().Counter(fmt.Sprintf("routers.%v.models.%v.%v_skips", i.routerID, model.ID(), skipReason)).Inc()
+
+		i.skipsInRow++
+		i.fallback = i.skipsInRow > len(i.models)
+	}
+
+	for _, model := range i.models {
+		if _, found := i.returned[model.ID()]; found || !model.Healthy() || i.skipReason(model) != "" {
+			continue
+		}
+
+		i.returned[model.ID()] = struct{}{}
+
+		return model, nil
+	}
+
+	return nil, routing.ErrNoHealthyModels
+}
+
+// skipReason tells why the model cannot serve the request or returns an empty string if it can
+func (i *contextWindowIterator) skipReason(model *providers.LanguageModel) string {
+	if !model.FitsContextWindow(i.needs.promptTokens) {
+		return "context_window"
+	}
+
+	if !model.Supports(i.needs.capabilities) {
+		return "capability"
+	}
+
+	return ""
+}
+
+// fitsAnyModel checks if any of the models accepts the prompt of the given size
+func fitsAnyModel(models []*providers.LanguageModel, promptTokens int) bool {
+	for _, model := range models {
+		if model.FitsContextWindow(promptTokens) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// supportedByAnyModel checks if any of the models supports all the required capabilities
+func supportedByAnyModel(models []*providers.LanguageModel, capabilities []schemas.Capability) bool {
+	for _, model := range models {
+		if model.Supports(capabilities) {
+			return true
+		}
+	}
+
+	return false
+}
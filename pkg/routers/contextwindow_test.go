@@ -0,0 +1,105 @@
+package routers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+// stuckIterator keeps picking the same model like strategies that stick to the best model do
+type stuckIterator struct {
+	model providers.Model
+}
+
+func (i stuckIterator) Next() (providers.Model, error) {
+	return i.model, nil
+}
+
+func newContextWindowRouter(t *testing.T) *LangRouter {
+	routerConfig := newLangRouterConfig("windowed", "small", "large")
+	routerConfig.Models[0].ContextWindow = 100
+	routerConfig.Models[1].ContextWindow = 1000
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	return router
+}
+
+func TestLangRouter_ContextWindowIterator(t *testing.T) {
+	router := newContextWindowRouter(t)
+
+	iterator := router.fittingModels(router.chatRouting.Iterator(), router.chatModels, requestNeeds{promptTokens: 500})
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "large", model.ID())
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, routing.ErrNoHealthyModels)
+
+	require.Equal(t, int64(1), router.tel.M().Counter("routers.windowed.models.small.context_window_skips").Value())
+}
+
+func TestLangRouter_ContextWindowFallback(t *testing.T) {
+	router := newContextWindowRouter(t)
+
+	iterator := router.fittingModels(stuckIterator{model: router.chatModels[0]}, router.chatModels, requestNeeds{promptTokens: 500})
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "large", model.ID())
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, routing.ErrNoHealthyModels)
+}
+
+func TestLangRouter_Chat_ContextWindowExceeded(t *testing.T) {
+	router := newContextWindowRouter(t)
+
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr(strings.Repeat("long text ", 1000)))
+	require.ErrorIs(t, err, ErrContextWindowExceeded)
+}
+
+func newCapabilityRouter(t *testing.T) *LangRouter {
+	routerConfig := newLangRouterConfig("capable", "text", "multimodal")
+	routerConfig.Models[1].Capabilities = []schemas.Capability{schemas.CapabilityTools, schemas.CapabilityVision}
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	return router
+}
+
+func TestLangRouter_CapabilityIterator(t *testing.T) {
+	router := newCapabilityRouter(t)
+
+	needs := requestNeeds{capabilities: []schemas.Capability{schemas.CapabilityVision}}
+	iterator := router.fittingModels(router.chatRouting.Iterator(), router.chatModels, needs)
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "multimodal", model.ID())
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, routing.ErrNoHealthyModels)
+
+	require.Equal(t, int64(1), router.tel.M().Counter("routers.capable.models.text.capability_skips").Value())
+	require.True(t, router.chatModels[1].Capabilities().Vision)
+}
+
+func TestLangRouter_Chat_CapabilityUnsupported(t *testing.T) {
+	router := newCapabilityRouter(t)
+
+	req := schemas.NewChatFromStr("hello")
+	req.Requires = []schemas.Capability{schemas.CapabilityJSONMode}
+
+	_, err := router.Chat(context.Background(), req)
+	require.ErrorIs(t, err, ErrCapabilityUnsupported)
+}
@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"fmt"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+)
+
+// deprecation returns the notice to attach to the response if the model is deprecated.
+// Requests served by deprecated models are counted, so it's clear how much traffic is left to migrate
+func (r *LangRouter) deprecation(langModel providers.LangModel) *schemas.ModelDeprecation {
+	deprecation := langModel.Deprecation()
+	if deprecation == nil {
+		return nil
+	}
+
+	r.tel.M().Counter(fmt.Sprintf("routers.%v.models.%v.deprecated_requests", r.routerID, langModel.ID())).Inc()
+
+	return deprecation
+}
@@ -0,0 +1,91 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	"glide/pkg/routers/routing"
+	"go.uber.org/zap"
+)
+
+// dryRun finds the model that would serve the chat request and estimates the request size & cost without calling the model.
+// The routing state is not changed if the routing can tell the next model upfront
+func (r *LangRouter) dryRun(
+	ctx context.Context,
+	chatRouting routing.LangModelRouting,
+	label string,
+	req *schemas.ChatRequest,
+) (*schemas.ChatResponse, error) {
+	var (
+		model providers.Model
+		err   error
+	)
+
+	if sessionRouting, ok := chatRouting.(routing.SessionRouting); ok && req.SessionID != "" {
+		model, err = sessionRouting.SessionIterator(req.SessionID).Next()
+	} else if peeker, ok := chatRouting.(routing.ModelPeeker); ok {
+		model, err = peeker.Peek()
+	} else {
+		model, err = chatRouting.Iterator().Next()
+	}
+
+	if err != nil {
+		return nil, ErrNoModelAvailable
+	}
+
+	langModel := r.findModel(model.ID())
+	if langModel == nil {
+		return nil, ErrModelNotFound
+	}
+
+	decision := &schemas.ChatDryRun{
+		Label:           label,
+		Transformations: make([]string, 0),
+	}
+
+	if label != "" {
+		decision.Transformations = append(decision.Transformations, fmt.Sprintf("classified as %v", label))
+	}
+
+	message := req.Message
+
+	if req.Override != nil && req.Override.Model == langModel.ID() {
+		message = req.Override.Message
+		decision.Transformations = append(decision.Transformations, "message overridden for the model")
+	}
+
+	if params := langModel.Params(); params != nil {
+		for _, override := range params.Overrides() {
+			decision.Transformations = append(decision.Transformations, fmt.Sprintf("param %v", override))
+		}
+	}
+
+	tokenizeReq := &schemas.TokenizeRequest{Message: message, MessageHistory: req.MessageHistory}
+
+	tokens, err := langModel.CountTokens(ctx, tokenizeReq)
+	if err != nil {
+		r.logger.Warn("Failed to count dry run prompt tokens, estimating them", zap.String("modelID", langModel.ID()), zap.Error(err))
+
+		tokens = &schemas.TokenizeResponse{Tokens: clients.EstimateTokens(tokenizeReq.Messages()), Estimated: true}
+	}
+
+	decision.PromptTokens = tokens.Tokens
+	decision.TokensEstimated = tokens.Estimated
+
+	if pricing := langModel.Pricing(); pricing != nil {
+		cost := pricing.Cost(schemas.TokenUsage{PromptTokens: tokens.Tokens})
+		decision.EstimatedCost = &cost
+	}
+
+	return &schemas.ChatResponse{
+		RouterID:    r.routerID,
+		Provider:    langModel.Provider(),
+		ModelID:     langModel.ID(),
+		ModelName:   tokens.ModelName,
+		Deprecation: langModel.Deprecation(),
+		DryRun:      decision,
+	}, nil
+}
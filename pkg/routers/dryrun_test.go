@@ -0,0 +1,68 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_Chat_DryRun(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}), budget, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "2"}}), budget, *latConfig, 1),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:          "test_router",
+		Config:            &LangRouterConfig{},
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:       routing.NewRoundRobinRouting(models),
+		chatStreamRouting: routing.NewRoundRobinRouting(models),
+		chatModels:        langModels,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.DryRun = true
+	req.Override = &schemas.OverrideChatRequest{Model: "first", Message: schemas.ChatMessage{Role: "user", Content: "tell me a joke"}}
+
+	for i := 0; i < 2; i++ {
+		resp, err := router.Chat(context.Background(), req)
+		require.NoError(t, err)
+
+		// the routing state is kept
+		require.Equal(t, "first", resp.ModelID)
+		require.Equal(t, "test_router", resp.RouterID)
+		require.Empty(t, resp.ModelResponse.Message.Content)
+
+		require.NotNil(t, resp.DryRun)
+		require.Positive(t, resp.DryRun.PromptTokens)
+		require.True(t, resp.DryRun.TokensEstimated)
+		require.Nil(t, resp.DryRun.EstimatedCost)
+		require.Equal(t, []string{"message overridden for the model"}, resp.DryRun.Transformations)
+	}
+
+	// the model hasn't been called
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "1", resp.ModelResponse.Message.Content)
+}
@@ -0,0 +1,217 @@
+package routers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/cache"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// EmbedCacheConfig defines caching of embeddings, so identical texts are not re-embedded by the same model.
+// Entries are kept in memory unless Redis or SQLite is configured
+type EmbedCacheConfig struct {
+	Enabled    bool                `yaml:"enabled" json:"enabled"`
+	TTL        *fields.Duration    `yaml:"ttl" json:"ttl" swaggertype:"primitive,string"`
+	MaxEntries int                 `yaml:"max_entries" json:"max_entries" validate:"gte=0"` // the in-memory cache size, zero means no limit
+	MaxBytes   int64               `yaml:"max_bytes" json:"max_bytes" validate:"gte=0"`     // the in-memory cache memory limit, zero means no limit
+	Redis      *cache.RedisConfig  `yaml:"redis,omitempty" json:"redis,omitempty"`
+	SQLite     *cache.SQLiteConfig `yaml:"sqlite,omitempty" json:"sqlite,omitempty"`
+}
+
+func DefaultEmbedCacheConfig() *EmbedCacheConfig {
+	defaultTTL := 24 * time.Hour
+
+	return &EmbedCacheConfig{
+		Enabled:    false,
+		TTL:        (*fields.Duration)(&defaultTTL),
+		MaxEntries: 10_000,
+		MaxBytes:   128 << 20, // 128MiB
+	}
+}
+
+func (c *EmbedCacheConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultEmbedCacheConfig()
+
+	type plain EmbedCacheConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// cachedEmbedding is an embedding vector of one input text as it's kept in the cache store
+type cachedEmbedding struct {
+	ModelName string    `json:"model"`
+	Vector    []float64 `json:"vector"`
+}
+
+// EmbedCache caches embedding vectors keyed by the input content hash.
+// Entries are namespaced by the router & model IDs, so vectors of different models never mix up
+type EmbedCache struct {
+	routerID RouterID
+	store    cache.Store
+	ttl      time.Duration
+	hits     *telemetry.Counter
+	misses   *telemetry.Counter
+	errors   *telemetry.Counter
+	logger   *zap.Logger
+}
+
+func NewEmbedCache(routerID RouterID, cfg *EmbedCacheConfig, tel *telemetry.Telemetry) (*EmbedCache, error) {
+	store, err := newStore(routerID, "embed_cache", cfg.MaxEntries, cfg.MaxBytes, cfg.Redis, cfg.SQLite, tel)
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl time.Duration
+
+	if cfg.TTL != nil {
+		ttl = time.Duration(*cfg.TTL)
+	}
+
+	return &EmbedCache{
+		routerID: routerID,
+		store:    store,
+		ttl:      ttl,
+		hits:     tel.M().Counter(fmt.Sprintf("routers.%v.embed_cache.hits", routerID)),
+		misses:   tel.M().Counter(fmt.Sprintf("routers.%v.embed_cache.misses", routerID)),
+		errors:   tel.M().Counter(fmt.Sprintf("routers.%v.embed_cache.errors", routerID)),
+		logger:   tel.L().With(zap.String("routerID", routerID)),
+	}, nil
+}
+
+// HitRate returns the share of inputs served from the cache
+func (c *EmbedCache) HitRate() float64 {
+	hits, misses := c.hits.Value(), c.misses.Value()
+
+	if hits+misses == 0 {
+		return 0
+	}
+
+	return float64(hits) / float64(hits+misses)
+}
+
+// Embed serves inputs from the cache and embeds only the missing ones with the model.
+// Cache store failures are logged and treated as misses, so they never fail the request
+func (c *EmbedCache) Embed(
+	ctx context.Context,
+	langModel providers.LangModel,
+	req *schemas.EmbedRequest,
+	embed func(ctx context.Context, langModel providers.LangModel, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error),
+) (*schemas.EmbedResponse, error) {
+	keys := make([]string, len(req.Input))
+	embeddings := make([]schemas.Embedding, len(req.Input))
+	missingIdx := make([]int, 0, len(req.Input))
+	modelName := ""
+
+	for idx, input := range req.Input {
+		keys[idx] = c.key(langModel.ID(), input)
+
+		cached := c.get(ctx, keys[idx])
+		if cached == nil {
+			missingIdx = append(missingIdx, idx)
+			continue
+		}
+
+		embeddings[idx] = schemas.Embedding{Index: idx, Vector: cached.Vector}
+		modelName = cached.ModelName
+	}
+
+	c.hits.Add(int64(len(req.Input) - len(missingIdx)))
+	c.misses.Add(int64(len(missingIdx)))
+
+	if len(missingIdx) == 0 {
+		return &schemas.EmbedResponse{
+			Created:   int(time.Now().UTC().Unix()),
+			Provider:  langModel.Provider(),
+			ModelID:   langModel.ID(),
+			ModelName: modelName,
+			ModelResponse: schemas.EmbedModelResponse{
+				Embeddings: embeddings,
+			},
+		}, nil
+	}
+
+	missingInputs := make([]string, 0, len(missingIdx))
+
+	for _, idx := range missingIdx {
+		missingInputs = append(missingInputs, req.Input[idx])
+	}
+
+	resp, err := embed(ctx, langModel, schemas.NewEmbedFromStr(missingInputs...))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, embedding := range resp.ModelResponse.Embeddings {
+		if embedding.Index < 0 || embedding.Index >= len(missingIdx) {
+			continue
+		}
+
+		idx := missingIdx[embedding.Index]
+		embeddings[idx] = schemas.Embedding{Index: idx, Vector: embedding.Vector}
+
+		c.set(ctx, keys[idx], &cachedEmbedding{ModelName: resp.ModelName, Vector: embedding.Vector})
+	}
+
+	resp.ModelResponse.Embeddings = embeddings
+
+	return resp, nil
+}
+
+// Close releases the cache store
+func (c *EmbedCache) Close() error {
+	return c.store.Close()
+}
+
+func (c *EmbedCache) key(modelID string, input string) string {
+	contentHash := sha256.Sum256([]byte(input))
+
+	return fmt.Sprintf("embed:%v:%v:%v", c.routerID, modelID, hex.EncodeToString(contentHash[:]))
+}
+
+func (c *EmbedCache) get(ctx context.Context, key string) *cachedEmbedding {
+	value, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.errors.Inc()
+		c.logger.Warn("failed to read from embedding cache", zap.Error(err))
+
+		return nil
+	}
+
+	if !found {
+		return nil
+	}
+
+	var cached cachedEmbedding
+
+	if err := json.Unmarshal(value, &cached); err != nil {
+		c.errors.Inc()
+		c.logger.Warn("failed to decode cached embedding", zap.Error(err))
+
+		return nil
+	}
+
+	return &cached
+}
+
+func (c *EmbedCache) set(ctx context.Context, key string, cached *cachedEmbedding) {
+	value, err := json.Marshal(cached)
+	if err != nil {
+		c.errors.Inc()
+
+		return
+	}
+
+	if err := c.store.Set(ctx, key, value, c.ttl); err != nil {
+		c.errors.Inc()
+		c.logger.Warn("failed to write to embedding cache", zap.Error(err))
+	}
+}
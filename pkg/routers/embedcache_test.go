@@ -0,0 +1,146 @@
+package routers
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/cache"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/telemetry"
+)
+
+// embedInputLength embeds each input as a vector of its length
+func embedInputLength(embeddedInputs *[]string) func(context.Context, providers.LangModel, *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	return func(_ context.Context, langModel providers.LangModel, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+		*embeddedInputs = append(*embeddedInputs, req.Input...)
+
+		embeddings := make([]schemas.Embedding, 0, len(req.Input))
+
+		for idx, input := range req.Input {
+			embeddings = append(embeddings, schemas.Embedding{Index: idx, Vector: []float64{float64(len(input))}})
+		}
+
+		return &schemas.EmbedResponse{
+			ModelID:       langModel.ID(),
+			ModelName:     "text-embedding-3-small",
+			ModelResponse: schemas.EmbedModelResponse{Embeddings: embeddings},
+		}, nil
+	}
+}
+
+func newEmbedModelMock(modelID string) *providers.LanguageModel {
+	return providers.NewLangModel(
+		modelID,
+		ptesting.NewEmbedProviderMock(nil),
+		health.NewErrorBudget(1, health.MIN),
+		*latency.DefaultConfig(),
+		1,
+	)
+}
+
+func TestEmbedCache_ServesCachedInputs(t *testing.T) {
+	ctx := context.Background()
+	tel := telemetry.NewTelemetryMock()
+	embedCache, err := NewEmbedCache("rag", DefaultEmbedCacheConfig(), tel)
+	require.NoError(t, err)
+
+	model := newEmbedModelMock("openai")
+
+	var embeddedInputs []string
+
+	resp, err := embedCache.Embed(ctx, model, schemas.NewEmbedFromStr("a", "bb"), embedInputLength(&embeddedInputs))
+	require.NoError(t, err)
+	require.Len(t, resp.ModelResponse.Embeddings, 2)
+	require.Equal(t, []string{"a", "bb"}, embeddedInputs)
+
+	resp, err = embedCache.Embed(ctx, model, schemas.NewEmbedFromStr("ccc", "bb", "a"), embedInputLength(&embeddedInputs))
+	require.NoError(t, err)
+
+	// only the new input reaches the model
+	require.Equal(t, []string{"a", "bb", "ccc"}, embeddedInputs)
+	require.Equal(t, []schemas.Embedding{
+		{Index: 0, Vector: []float64{3}},
+		{Index: 1, Vector: []float64{2}},
+		{Index: 2, Vector: []float64{1}},
+	}, resp.ModelResponse.Embeddings)
+
+	resp, err = embedCache.Embed(ctx, model, schemas.NewEmbedFromStr("bb"), embedInputLength(&embeddedInputs))
+	require.NoError(t, err)
+	require.Len(t, embeddedInputs, 3)
+	require.Equal(t, "openai", resp.ModelID)
+	require.Equal(t, "text-embedding-3-small", resp.ModelName)
+
+	require.Equal(t, int64(3), tel.M().Counter("routers.rag.embed_cache.hits").Value())
+	require.Equal(t, int64(3), tel.M().Counter("routers.rag.embed_cache.misses").Value())
+	require.InDelta(t, 0.5, embedCache.HitRate(), 0.001)
+}
+
+func TestEmbedCache_SQLiteStore(t *testing.T) {
+	ctx := context.Background()
+	tel := telemetry.NewTelemetryMock()
+
+	cfg := DefaultEmbedCacheConfig()
+	cfg.SQLite = &cache.SQLiteConfig{Path: filepath.Join(t.TempDir(), "glide.db")}
+
+	embedCache, err := NewEmbedCache("rag", cfg, tel)
+	require.NoError(t, err)
+
+	model := newEmbedModelMock("openai")
+
+	var embeddedInputs []string
+
+	_, err = embedCache.Embed(ctx, model, schemas.NewEmbedFromStr("a"), embedInputLength(&embeddedInputs))
+	require.NoError(t, err)
+	require.NoError(t, embedCache.Close())
+
+	// cached vectors survive restarts
+	embedCache, err = NewEmbedCache("rag", cfg, tel)
+	require.NoError(t, err)
+
+	defer embedCache.Close()
+
+	resp, err := embedCache.Embed(ctx, model, schemas.NewEmbedFromStr("a"), embedInputLength(&embeddedInputs))
+	require.NoError(t, err)
+	require.Len(t, resp.ModelResponse.Embeddings, 1)
+	require.Equal(t, []string{"a"}, embeddedInputs)
+
+	cfg.Redis = cache.DefaultRedisConfig()
+
+	_, err = NewEmbedCache("rag", cfg, tel)
+	require.ErrorContains(t, err, "either redis or sqlite")
+}
+
+func TestEmbedCache_NamespacedPerModel(t *testing.T) {
+	ctx := context.Background()
+	embedCache, err := NewEmbedCache("rag", DefaultEmbedCacheConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var embeddedInputs []string
+
+	_, err = embedCache.Embed(ctx, newEmbedModelMock("openai"), schemas.NewEmbedFromStr("a"), embedInputLength(&embeddedInputs))
+	require.NoError(t, err)
+
+	_, err = embedCache.Embed(ctx, newEmbedModelMock("cohere"), schemas.NewEmbedFromStr("a"), embedInputLength(&embeddedInputs))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"a", "a"}, embeddedInputs)
+}
+
+func TestLangRouter_EmbedWithCache(t *testing.T) {
+	routerConfig := newLangRouterConfig("rag", "openai")
+	routerConfig.EmbedCache = DefaultEmbedCacheConfig()
+	routerConfig.EmbedCache.Enabled = true
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	defer router.Shutdown()
+
+	require.NotNil(t, router.embedCache)
+}
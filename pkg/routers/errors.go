@@ -0,0 +1,128 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+)
+
+// NoModelAvailableError is returned when all models have failed the request for the same reason (e.g. they are all rate limited),
+// so the request could be reported with that reason rather than with the generic unavailability
+type NoModelAvailableError struct {
+	Code schemas.ErrorCode
+}
+
+func (e *NoModelAvailableError) Error() string {
+	return ErrNoModelAvailable.Error()
+}
+
+func (e *NoModelAvailableError) Is(target error) bool {
+	return target == ErrNoModelAvailable //nolint:errorlint
+}
+
+// modelFailures collects error codes of models that have failed the request
+type modelFailures struct {
+	code  schemas.ErrorCode
+	mixed bool
+}
+
+func (f *modelFailures) add(err error) {
+	code := clients.ErrorCode(err)
+
+	if f.code != "" && f.code != code {
+		f.mixed = true
+	}
+
+	f.code = code
+}
+
+// Code returns the code all models have failed with or the generic unavailability if they failed differently
+func (f *modelFailures) Code() schemas.ErrorCode {
+	if f.code == "" || f.mixed || f.code == schemas.ModelUnavailable {
+		return schemas.AllModelsUnavailable
+	}
+
+	return f.code
+}
+
+func (f *modelFailures) Err() error {
+	if code := f.Code(); code != schemas.AllModelsUnavailable {
+		return &NoModelAvailableError{Code: code}
+	}
+
+	return ErrNoModelAvailable
+}
+
+// ErrorCode maps the router error into the gateway error code
+func ErrorCode(err error) schemas.ErrorCode {
+	var (
+		noModelErr *NoModelAvailableError
+		queueErr   *QueueError
+	)
+
+	switch {
+	case errors.As(err, &noModelErr):
+		return noModelErr.Code
+	case errors.Is(err, ErrNoModelAvailable):
+		return schemas.AllModelsUnavailable
+	case errors.Is(err, ErrNoModels):
+		return schemas.NoModelConfigured
+	case errors.Is(err, ErrRouterNotFound), errors.Is(err, ErrModelNotFound):
+		return schemas.NotFound
+	case errors.Is(err, ErrPinnedModelNotFound):
+		return schemas.PinnedModelNotFound
+	case errors.Is(err, ErrPinnedModelUnavailable):
+		return schemas.PinnedModelUnavailable
+	case errors.Is(err, ErrContentFlagged):
+		return schemas.ContentFlagged
+	case errors.Is(err, ErrContextWindowExceeded):
+		return schemas.ContextWindowExceeded
+	case errors.Is(err, ErrCapabilityUnsupported):
+		return schemas.CapabilityUnsupported
+	case errors.Is(err, ErrBudgetExhausted):
+		return schemas.BudgetExhausted
+	case errors.Is(err, ErrRouterSaturated), errors.As(err, &queueErr):
+		return schemas.Overloaded
+	case errors.Is(err, ErrIdempotencyKeyReused):
+		return schemas.IdempotencyConflict
+	case errors.Is(err, ErrFeedbackNotSupported):
+		return schemas.NotImplemented
+	case errors.Is(err, ErrInvalidRouterConfig):
+		return schemas.InvalidRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return schemas.Timeout
+	case errors.Is(err, ErrInternal):
+		return schemas.UnknownError
+	case errors.Is(err, clients.ErrProviderUnavailable):
+		return schemas.ModelUnavailable
+	}
+
+	// model errors may be returned as is (e.g. when the fallback on content filtering is disabled)
+	if code := clients.ErrorCode(err); code != schemas.ModelUnavailable {
+		return code
+	}
+
+	return schemas.UnknownError
+}
+
+// countFailure counts the failed request by its error code
+func (r *LangRouter) countFailure(err error) {
+	if err == nil {
+		return
+	}
+
+	r.tel.M().Counter(fmt.Sprintf("routers.%v.errors.%v", r.routerID, ErrorCode(err))).Inc()
+}
+
+// countModelError counts the model failure by its error code. Cancelled requests tell nothing about the model, so they are skipped
+func (r *LangRouter) countModelError(langModel providers.LangModel, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	r.tel.M().Counter(fmt.Sprintf("routers.%v.models.%v.errors.%v", r.routerID, langModel.ID(), clients.ErrorCode(err))).Inc()
+}
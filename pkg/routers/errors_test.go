@@ -0,0 +1,88 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newFailingRouter(firstErr error, secondErr error) *LangRouter {
+	budget := health.NewErrorBudget(10, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock([]ptesting.RespMock{{Err: &firstErr}, {Err: &firstErr}}), budget, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock([]ptesting.RespMock{{Err: &secondErr}, {Err: &secondErr}}), budget, *latConfig, 1),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:    "test_router",
+		Config:      &LangRouterConfig{},
+		retry:       retry.NewExpRetry(1, 2, 1*time.Millisecond, nil),
+		chatRouting: routing.NewPriority(models),
+		chatModels:  langModels,
+		tel:         telemetry.NewTelemetryMock(),
+		logger:      telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_ReportsSharedFailureCode(t *testing.T) {
+	router := newFailingRouter(clients.ErrContextTooLong, clients.ErrContextTooLong)
+
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+
+	require.ErrorIs(t, err, ErrNoModelAvailable)
+	require.Equal(t, schemas.ContextWindowExceeded, ErrorCode(err))
+
+	counters := router.tel.M().Counters()
+	require.Equal(t, int64(1), counters["routers.test_router.errors.context_window_exceeded"])
+	require.Positive(t, counters["routers.test_router.models.first.errors.context_window_exceeded"])
+}
+
+func TestLangRouter_Chat_ReportsMixedFailures(t *testing.T) {
+	router := newFailingRouter(clients.ErrContextTooLong, clients.ErrProviderUnavailable)
+
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+
+	require.ErrorIs(t, err, ErrNoModelAvailable)
+	require.Equal(t, schemas.AllModelsUnavailable, ErrorCode(err))
+	require.Positive(t, router.tel.M().Counters()["routers.test_router.models.second.errors.model_unavailable"])
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		code schemas.ErrorCode
+	}{
+		"router not found": {ErrRouterNotFound, schemas.NotFound},
+		"budget":           {ErrBudgetExhausted, schemas.BudgetExhausted},
+		"queue":            {&QueueError{}, schemas.Overloaded},
+		"no models":        {ErrNoModelAvailable, schemas.AllModelsUnavailable},
+		"model error":      {fmt.Errorf("fallback is disabled: %w", clients.ErrContentFiltered), schemas.ContentFiltered},
+		"provider error":   {clients.ErrProviderUnavailable, schemas.ModelUnavailable},
+		"cancelled":        {context.Canceled, schemas.UnknownError},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.code, ErrorCode(test.err))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package routers
+
+import (
+	"fmt"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+)
+
+// experiment returns the A/B test variant to stamp into the response if the request is a part of the experiment.
+// Per-variant usage & latency are counted, so variants can be compared
+// (e.g. the average latency is latency_ms divided by requests)
+func (r *LangRouter) experiment(
+	modelRouting routing.LangModelRouting,
+	langModel providers.LangModel,
+	latency time.Duration,
+	usage *schemas.TokenUsage,
+) *schemas.Experiment {
+	experimentRouting, ok := modelRouting.(routing.ExperimentRouting)
+	if !ok {
+		return nil
+	}
+
+	experimentID, variant, found := experimentRouting.Variant(langModel)
+	if !found {
+		return nil
+	}
+
+	prefix := fmt.Sprintf("routers.%v.experiments.%v.variants.%v", r.routerID, experimentID, variant)
+	meter := r.tel.M()
+
+	meter.Counter(prefix + ".requests").Inc()
+
+	if usage != nil {
+		meter.Counter(prefix + ".latency_ms").Add(latency.Milliseconds())
+		meter.Counter(prefix + ".prompt_tokens").Add(int64(usage.PromptTokens))
+		meter.Counter(prefix + ".response_tokens").Add(int64(usage.ResponseTokens))
+	}
+
+	return &schemas.Experiment{
+		ID:      experimentID,
+		Variant: variant,
+	}
+}
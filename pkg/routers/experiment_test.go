@@ -0,0 +1,89 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_Chat_Experiment(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"control",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := []providers.Model{langModels[0]}
+	abTestConfig := &routing.ABTestConfig{
+		Experiment: "prompt-v2",
+		Variants:   []routing.ABVariantConfig{{Name: "baseline", Model: "control", Percentage: 100}},
+	}
+
+	tel := telemetry.NewTelemetryMock()
+	router := LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewABTestRouting(abTestConfig, models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              tel,
+		logger:           telemetry.NewLoggerMock(),
+	}
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, &schemas.Experiment{ID: "prompt-v2", Variant: "baseline"}, resp.Experiment)
+
+	counters := tel.M().Counters()
+	prefix := "routers.test_router.experiments.prompt-v2.variants.baseline"
+
+	require.Equal(t, int64(1), counters[prefix+".requests"])
+	require.Equal(t, int64(resp.ModelResponse.TokenUsage.ResponseTokens), counters[prefix+".response_tokens"])
+}
+
+func TestLangRouterConfig_ABTestVariants(t *testing.T) {
+	routerConfig := newLangRouterConfig("ab", "openai", "another_openai")
+	routerConfig.RoutingStrategy = routing.ABTest
+
+	_, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "has no variants configured")
+
+	routerConfig.ABTest = &routing.ABTestConfig{
+		Experiment: "switch",
+		Variants: []routing.ABVariantConfig{
+			{Name: "control", Model: "openai", Percentage: 50},
+			{Name: "treatment", Model: "unknown", Percentage: 50},
+		},
+	}
+
+	_, err = NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "refers to the unknown model")
+
+	routerConfig.ABTest.Variants[1] = routing.ABVariantConfig{Name: "treatment", Model: "another_openai", Percentage: 40}
+
+	_, err = NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "should sum up to 100")
+
+	routerConfig.ABTest.Variants[1].Percentage = 50
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.IsType(t, &routing.ABTestRouting{}, router.chatRouting)
+}
@@ -0,0 +1,51 @@
+package routers
+
+import (
+	"errors"
+
+	"glide/pkg/providers/clients"
+)
+
+var (
+	ErrContentFiltered = clients.ErrContentFiltered
+	ErrEmptyResponse   = clients.ErrEmptyResponse
+)
+
+// FallbackConfig defines if requests failed due to the response content are retried on the next router model.
+// Models may still fail such requests for other reasons, which are always retried
+type FallbackConfig struct {
+	OnContentFilter bool `yaml:"on_content_filter" json:"on_content_filter"` // try the next model when the provider content filter blocks the request or response
+	OnEmptyResponse bool `yaml:"on_empty_response" json:"on_empty_response"` // try the next model when the provider returns an empty response
+}
+
+func DefaultFallbackConfig() *FallbackConfig {
+	return &FallbackConfig{
+		OnContentFilter: true,
+		OnEmptyResponse: true,
+	}
+}
+
+func (c *FallbackConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultFallbackConfig()
+
+	type plain FallbackConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Allows checks if the request failed with the error should be sent to the next model
+func (c *FallbackConfig) Allows(err error) bool {
+	if c == nil {
+		c = DefaultFallbackConfig()
+	}
+
+	if errors.Is(err, clients.ErrContentFiltered) {
+		return c.OnContentFilter
+	}
+
+	if errors.Is(err, clients.ErrEmptyResponse) {
+		return c.OnEmptyResponse
+	}
+
+	return true
+}
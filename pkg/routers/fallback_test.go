@@ -0,0 +1,76 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newFallbackRouter(err error, fallback *FallbackConfig) *LangRouter {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock([]ptesting.RespMock{{Err: &err}}), budget, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "2"}}), budget, *latConfig, 1),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{RoutingStrategy: routing.Priority, Fallback: fallback},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              telemetry.NewTelemetryMock(),
+		logger:           telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_FallbackOnResponseErrors(t *testing.T) {
+	for _, err := range []error{clients.ErrContentFiltered, clients.ErrEmptyResponse} {
+		t.Run(err.Error(), func(t *testing.T) {
+			router := newFallbackRouter(err, nil)
+
+			resp, chatErr := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+			require.NoError(t, chatErr)
+			require.Equal(t, "second", resp.ModelID)
+		})
+	}
+}
+
+func TestLangRouter_Chat_NoFallbackOnResponseErrors(t *testing.T) {
+	fallback := &FallbackConfig{OnContentFilter: false, OnEmptyResponse: false}
+
+	for _, err := range []error{clients.ErrContentFiltered, clients.ErrEmptyResponse} {
+		t.Run(err.Error(), func(t *testing.T) {
+			router := newFallbackRouter(err, fallback)
+
+			_, chatErr := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+			require.ErrorIs(t, chatErr, err)
+		})
+	}
+
+	// other failures are still retried on the next model
+	router := newFallbackRouter(clients.ErrProviderUnavailable, fallback)
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+}
@@ -0,0 +1,124 @@
+package routers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"glide/pkg/events"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const RouterFallbackStormEvent = "router.fallback_storm"
+
+// FallbackStormConfig defines how many requests falling back to the next models within the window make a storm.
+// Storms usually mean that a provider is failing broadly, so on-call should look into it
+type FallbackStormConfig struct {
+	Threshold int           `yaml:"threshold" json:"threshold" validate:"required,gt=0"`
+	Window    time.Duration `yaml:"window" json:"window" validate:"required,gt=0" swaggertype:"primitive,integer"`
+}
+
+func DefaultFallbackStormConfig() *FallbackStormConfig {
+	return &FallbackStormConfig{
+		Threshold: 50,
+		Window:    time.Minute,
+	}
+}
+
+func (c *FallbackStormConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultFallbackStormConfig()
+
+	type plain FallbackStormConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// FallbackStormEventData describes the burst of fallbacks
+type FallbackStormEventData struct {
+	RouterID    string  `json:"routerId"`
+	Fallbacks   int     `json:"fallbacks"`
+	WindowSec   float64 `json:"windowSec"`
+	LastModelID string  `json:"lastModelId"` // the model that has failed the latest request
+	LastError   string  `json:"lastError"`
+}
+
+// FallbackStormDetector counts fallbacks in the sliding window. The storm is reported once per window
+type FallbackStormDetector struct {
+	mu         sync.Mutex
+	cfg        *FallbackStormConfig
+	fallbacks  []time.Time
+	quietUntil time.Time
+}
+
+func NewFallbackStormDetector(cfg *FallbackStormConfig) *FallbackStormDetector {
+	return &FallbackStormDetector{
+		cfg:       cfg,
+		fallbacks: make([]time.Time, 0, cfg.Threshold),
+	}
+}
+
+// Track records the fallback & returns the number of fallbacks in the window if they have just made a storm
+func (d *FallbackStormDetector) Track(now time.Time) (int, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	windowStart := now.Add(-d.cfg.Window)
+
+	expired := 0
+	for expired < len(d.fallbacks) && d.fallbacks[expired].Before(windowStart) {
+		expired++
+	}
+
+	// only the latest threshold fallbacks matter, so the memory is bounded
+	d.fallbacks = append(d.fallbacks[expired:], now)
+
+	if len(d.fallbacks) > d.cfg.Threshold {
+		d.fallbacks = d.fallbacks[len(d.fallbacks)-d.cfg.Threshold:]
+	}
+
+	if len(d.fallbacks) < d.cfg.Threshold || now.Before(d.quietUntil) {
+		return len(d.fallbacks), false
+	}
+
+	d.quietUntil = now.Add(d.cfg.Window)
+
+	return len(d.fallbacks), true
+}
+
+// trackFallback counts the request that is sent to the next model after the model has failed it
+func (r *LangRouter) trackFallback(langModel providers.LangModel, err error) {
+	r.tel.M().Counter(fmt.Sprintf("routers.%v.fallbacks", r.routerID)).Inc()
+
+	if r.fallbackStorm == nil {
+		return
+	}
+
+	fallbacks, storm := r.fallbackStorm.Track(time.Now())
+	if !storm {
+		return
+	}
+
+	r.logger.Warn(
+		"Fallback storm is detected",
+		zap.Int("fallbacks", fallbacks),
+		zap.Duration("window", r.fallbackStorm.cfg.Window),
+		zap.String("lastModelID", langModel.ID()),
+		zap.Error(err),
+	)
+
+	r.tel.M().Counter(fmt.Sprintf("routers.%v.fallback_storms", r.routerID)).Inc()
+	r.tel.Audit.Record(telemetry.SystemActor, RouterFallbackStormEvent, r.routerID, map[string]interface{}{
+		"fallbacks":   fallbacks,
+		"lastModelId": langModel.ID(),
+	})
+
+	r.events.Emit(events.NewEvent(RouterFallbackStormEvent, &FallbackStormEventData{
+		RouterID:    r.routerID,
+		Fallbacks:   fallbacks,
+		WindowSec:   r.fallbackStorm.cfg.Window.Seconds(),
+		LastModelID: langModel.ID(),
+		LastError:   err.Error(),
+	}))
+}
@@ -0,0 +1,58 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+)
+
+func TestFallbackStormDetector_ReportsStormOncePerWindow(t *testing.T) {
+	detector := NewFallbackStormDetector(&FallbackStormConfig{Threshold: 3, Window: time.Minute})
+	now := time.Now()
+
+	_, storm := detector.Track(now)
+	require.False(t, storm)
+
+	// the first fallback has left the window
+	_, storm = detector.Track(now.Add(61 * time.Second))
+	require.False(t, storm)
+
+	_, storm = detector.Track(now.Add(62 * time.Second))
+	require.False(t, storm)
+
+	fallbacks, storm := detector.Track(now.Add(63 * time.Second))
+	require.True(t, storm)
+	require.Equal(t, 3, fallbacks)
+
+	// the ongoing storm is not reported again within the window
+	_, storm = detector.Track(now.Add(64 * time.Second))
+	require.False(t, storm)
+
+	_, storm = detector.Track(now.Add(124 * time.Second))
+	require.False(t, storm)
+
+	_, storm = detector.Track(now.Add(125 * time.Second))
+	require.False(t, storm)
+
+	_, storm = detector.Track(now.Add(126 * time.Second))
+	require.True(t, storm)
+}
+
+func TestLangRouter_Chat_TracksFallbacks(t *testing.T) {
+	router := newTracingRouter()
+	router.fallbackStorm = NewFallbackStormDetector(&FallbackStormConfig{Threshold: 1, Window: time.Minute})
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	require.Equal(t, int64(1), router.tel.M().Counter("routers.test_router.fallbacks").Value())
+	require.Equal(t, int64(1), router.tel.M().Counter("routers.test_router.fallback_storms").Value())
+
+	entries := router.tel.Audit.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, RouterFallbackStormEvent, entries[0].Action)
+}
@@ -0,0 +1,74 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+)
+
+var ErrFeedbackNotSupported = errors.New("router doesn't learn from feedback, configure the bandit routing strategy with the feedback reward to use it")
+
+// Feedback passes the client score of the model chat response to routings that learn from it
+func (r *LangRouter) Feedback(feedback *schemas.ChatFeedback) error {
+	model := r.findModel(feedback.ModelID)
+	if model == nil {
+		return ErrModelNotFound
+	}
+
+	observed := false
+
+	for _, modelRouting := range []routing.LangModelRouting{r.chatRouting, r.chatStreamRouting} {
+		if observer, ok := modelRouting.(routing.RewardObserver); ok {
+			observer.Observe(model, routing.Outcome{Score: &feedback.Score})
+			observed = true
+		}
+	}
+
+	if !observed {
+		return ErrFeedbackNotSupported
+	}
+
+	return nil
+}
+
+// observe reports the outcome of the served request to the routing if it learns from outcomes
+func (r *LangRouter) observe(
+	modelRouting routing.LangModelRouting,
+	langModel providers.LangModel,
+	latency time.Duration,
+	tokens int,
+	usage schemas.TokenUsage,
+) {
+	observer, ok := modelRouting.(routing.RewardObserver)
+	if !ok {
+		return
+	}
+
+	outcome := routing.Outcome{
+		Latency: latency,
+		Tokens:  tokens,
+	}
+
+	if model, ok := langModel.(*providers.LanguageModel); ok && model.Pricing() != nil {
+		cost := model.Pricing().Cost(usage)
+		outcome.Cost = &cost
+	}
+
+	observer.Observe(langModel, outcome)
+}
+
+// observeError reports the failed request to the routing if it learns from failures.
+// Cancelled requests (e.g. the client went away or a hedged request won) tell nothing about the model, so they are skipped
+func (r *LangRouter) observeError(modelRouting routing.LangModelRouting, langModel providers.LangModel, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	if observer, ok := modelRouting.(routing.ErrorObserver); ok {
+		observer.ObserveError(langModel, err)
+	}
+}
@@ -0,0 +1,130 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_Feedback(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	responses := make([]ptesting.RespMock, 0, 10)
+	for i := 0; i < 10; i++ {
+		responses = append(responses, ptesting.RespMock{Msg: "joke"})
+	}
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock(responses), budget, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock(responses), budget, *latConfig, 1),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	banditConfig := routing.DefaultBanditConfig()
+	banditConfig.Reward = routing.FeedbackReward
+	banditConfig.Epsilon = 0
+	banditConfig.WarmupSamples = 1
+
+	router := LangRouter{
+		routerID:          "test_router",
+		Config:            &LangRouterConfig{},
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:       routing.NewBanditRouting(banditConfig, models),
+		chatStreamRouting: routing.NewPriority(models),
+		chatModels:        langModels,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, router.Feedback(&schemas.ChatFeedback{ModelID: "first", Score: 0.2}))
+		require.NoError(t, router.Feedback(&schemas.ChatFeedback{ModelID: "second", Score: 0.9}))
+	}
+
+	for i := 0; i < 3; i++ {
+		resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+
+		require.NoError(t, err)
+		require.Equal(t, "second", resp.ModelID)
+	}
+
+	err := router.Feedback(&schemas.ChatFeedback{ModelID: "unknown", Score: 1})
+	require.ErrorIs(t, err, ErrModelNotFound)
+}
+
+func TestLangRouter_Feedback_NotSupported(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	err := manager.Feedback("default", &schemas.ChatFeedback{ModelID: "openai", Score: 1})
+	require.ErrorIs(t, err, ErrFeedbackNotSupported)
+}
+
+func TestLangRouterConfig_BanditCostRewardRequiresPricing(t *testing.T) {
+	routerConfig := newLangRouterConfig("bandit", "openai")
+	routerConfig.RoutingStrategy = routing.Bandit
+	routerConfig.Bandit = routing.DefaultBanditConfig()
+	routerConfig.Bandit.Reward = routing.CostReward
+
+	_, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "has no pricing configured")
+
+	routerConfig.Models[0].Pricing = &providers.Pricing{PromptTokens: 0.5, ResponseTokens: 1.5}
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.IsType(t, &routing.BanditRouting{}, router.chatRouting)
+}
+
+func TestLangRouter_CancelledRequestsKeepCanary(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	baseline := providers.NewLangModel("baseline", ptesting.NewProviderMock(nil), budget, *latConfig, 1)
+	canary := providers.NewLangModel("canary", ptesting.NewProviderMock(nil), budget, *latConfig, 1)
+
+	canaryConfig := routing.DefaultCanaryConfig()
+	canaryConfig.Baseline, canaryConfig.Canary = "baseline", "canary"
+	canaryConfig.Window = 0
+	canaryConfig.MinRequests = 10
+
+	canaryRouting := routing.NewCanaryRouting(canaryConfig, []providers.Model{baseline, canary})
+
+	router := LangRouter{
+		routerID:    "test_router",
+		Config:      &LangRouterConfig{},
+		chatRouting: canaryRouting,
+		tel:         telemetry.NewTelemetryMock(),
+		logger:      telemetry.NewLoggerMock(),
+	}
+
+	for range 10 {
+		router.observe(canaryRouting, baseline, 100*time.Millisecond, 10, schemas.TokenUsage{})
+		router.observeError(canaryRouting, canary, context.Canceled)
+	}
+
+	state, _ := canaryRouting.State()
+	require.Equal(t, routing.CanaryInProgress, state)
+
+	for range 10 {
+		router.observeError(canaryRouting, canary, clients.ErrProviderUnavailable)
+	}
+
+	state, _ = canaryRouting.State()
+	require.Equal(t, routing.CanaryEjected, state)
+}
@@ -0,0 +1,33 @@
+package routers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+)
+
+const (
+	ChatGeneration       = "chat"
+	ChatStreamGeneration = "chat_stream"
+)
+
+// recordGeneration passes the served request with its prompt & completion to LLM observability exporters (if any)
+func (r *LangRouter) recordGeneration(ctx context.Context, kind string, messages []schemas.ChatMessage, generation *telemetry.Generation) {
+	input := make([]telemetry.GenerationMessage, 0, len(messages))
+
+	for _, message := range messages {
+		input = append(input, telemetry.GenerationMessage{Role: message.Role, Content: message.Content})
+	}
+
+	generation.ID = uuid.NewString()
+	generation.Kind = kind
+	generation.RouterID = r.routerID
+	generation.Caller = requestCaller(ctx)
+	generation.Input = input
+	generation.EndTime = time.Now().UTC()
+
+	r.tel.Generations.Record(generation)
+}
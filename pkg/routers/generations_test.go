@@ -0,0 +1,40 @@
+package routers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/telemetry"
+)
+
+type generationSinkMock struct {
+	generations []*telemetry.Generation
+}
+
+func (s *generationSinkMock) Add(generation *telemetry.Generation) {
+	s.generations = append(s.generations, generation)
+}
+
+func TestLangRouter_Chat_RecordsGenerations(t *testing.T) {
+	router := newTracingRouter()
+
+	sink := &generationSinkMock{}
+	router.tel.Generations.AddSink(sink)
+
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+
+	// only the served attempt is recorded
+	require.Len(t, sink.generations, 1)
+
+	generation := sink.generations[0]
+	require.NotEmpty(t, generation.ID)
+	require.Equal(t, ChatGeneration, generation.Kind)
+	require.Equal(t, "test_router", generation.RouterID)
+	require.Equal(t, "second", generation.ModelID)
+	require.Equal(t, "tell me a dad joke", generation.Input[0].Content)
+	require.Equal(t, "2", generation.Output)
+	require.False(t, generation.EndTime.Before(generation.StartTime))
+}
@@ -0,0 +1,87 @@
+package routers
+
+import (
+	"context"
+	"errors"
+
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+var ErrContentFlagged = errors.New("request content was flagged by moderation")
+
+// GuardrailConfig defines checks applied to chat requests before they are routed to models
+type GuardrailConfig struct {
+	Moderation string `yaml:"moderation" json:"moderation" validate:"required"` // ID of the moderation router to check chat messages with
+	FailOpen   bool   `yaml:"fail_open" json:"fail_open"`                       // let requests through when moderation is not available
+}
+
+// Guardrail checks chat requests with a moderation router.
+// The whole conversation is checked as clients send the message history with every request
+type Guardrail struct {
+	config    *GuardrailConfig
+	moderator *ModerationRouter
+	logger    *zap.Logger
+}
+
+func NewGuardrail(config *GuardrailConfig, moderator *ModerationRouter, logger *zap.Logger) *Guardrail {
+	return &Guardrail{
+		config:    config,
+		moderator: moderator,
+		logger:    logger,
+	}
+}
+
+func (g *Guardrail) CheckChat(ctx context.Context, req *schemas.ChatRequest) error {
+	return g.check(ctx, req.MessageHistory, req.Message)
+}
+
+func (g *Guardrail) CheckChatStream(ctx context.Context, req *schemas.ChatStreamRequest) error {
+	return g.check(ctx, req.MessageHistory, req.Message)
+}
+
+// check moderates all messages in one request
+func (g *Guardrail) check(ctx context.Context, history []schemas.ChatMessage, message schemas.ChatMessage) error {
+	inputs := make([]string, 0, len(history)+1)
+
+	for _, historyMessage := range history {
+		if historyMessage.Content != "" {
+			inputs = append(inputs, historyMessage.Content)
+		}
+	}
+
+	inputs = append(inputs, message.Content)
+
+	resp, err := g.moderator.Moderate(ctx, schemas.NewModerationFromStr(inputs...))
+	if err != nil {
+		if g.config.FailOpen {
+			g.logger.Warn("Moderation is not available, letting the request through", zap.Error(err))
+
+			return nil
+		}
+
+		return err
+	}
+
+	if !resp.Flagged() {
+		return nil
+	}
+
+	flaggedCategories := make([]string, 0)
+
+	for _, result := range resp.ModelResponse.Results {
+		for _, category := range result.Categories {
+			if category.Flagged {
+				flaggedCategories = append(flaggedCategories, category.Name)
+			}
+		}
+	}
+
+	g.logger.Info(
+		"Chat request was flagged by moderation",
+		zap.String("moderationRouterID", g.moderator.ID()),
+		zap.Strings("categories", flaggedCategories),
+	)
+
+	return ErrContentFlagged
+}
@@ -0,0 +1,223 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/providers/clients"
+)
+
+var ErrCircuitOpen = errors.New("model circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // requests flow to the provider
+	CircuitOpen     CircuitState = "open"      // requests are rejected without reaching the provider
+	CircuitHalfOpen CircuitState = "half_open" // a few trial requests check if the provider has recovered
+)
+
+// CircuitBreakerConfig defines when the model is cut off from traffic & how it's probed afterward
+type CircuitBreakerConfig struct {
+	FailureRate      float64         `yaml:"failure_rate" json:"failure_rate" validate:"gt=0,lte=1"`                              // the share of requests failed over the last minute that opens the circuit
+	MinRequests      int             `yaml:"min_requests" json:"min_requests" validate:"gte=1"`                                   // the number of requests over the last minute required to judge the failure rate
+	ProbeInterval    fields.Duration `yaml:"probe_interval" json:"probe_interval" swaggertype:"primitive,string" validate:"gt=0"` // how long the circuit stays open before trial requests are let through
+	HalfOpenRequests int             `yaml:"half_open_requests" json:"half_open_requests" validate:"gte=1"`                       // trial requests let through once the circuit is half open, all of them must succeed to close it
+}
+
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureRate:      0.5,
+		MinRequests:      10,
+		ProbeInterval:    fields.Duration(30 * time.Second),
+		HalfOpenRequests: 1,
+	}
+}
+
+func (c *CircuitBreakerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultCircuitBreakerConfig()
+
+	type plain CircuitBreakerConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// CircuitBreaker cuts the model off once it fails too many requests, so a dying provider doesn't burn
+// the full request timeout over and over. After the probe interval, a few trial requests are let through:
+// the circuit closes once all of them succeed and opens again on the first failure
+type CircuitBreaker struct {
+	mu             sync.Mutex
+	config         *CircuitBreakerConfig
+	state          CircuitState
+	window         *slidingWindow
+	openedAt       time.Time
+	trials         int
+	trialSuccesses int
+	now            func() time.Time
+}
+
+func NewCircuitBreaker(config *CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		config: config,
+		state:  CircuitClosed,
+		window: newSlidingWindow(),
+		now:    time.Now,
+	}
+}
+
+// Ready checks if the breaker would let the next request through
+func (b *CircuitBreaker) Ready() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		return b.probeDue()
+	case CircuitHalfOpen:
+		return b.trials < b.halfOpenRequests() // trials may still be let through
+	default:
+		return true
+	}
+}
+
+// Allow lets the request through or rejects it when the circuit is open.
+// The first requests after the probe interval become trials
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if !b.probeDue() {
+			return ErrCircuitOpen
+		}
+
+		b.state = CircuitHalfOpen
+		b.trials = 1
+		b.trialSuccesses = 0
+
+		return nil
+	case CircuitHalfOpen:
+		if b.trials >= b.halfOpenRequests() {
+			return ErrCircuitOpen
+		}
+
+		b.trials++
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *CircuitBreaker) TrackSuccess() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.trialSuccesses++
+
+		if b.trialSuccesses >= b.halfOpenRequests() {
+			// the provider has recovered
+			b.close()
+		}
+
+		return
+	}
+
+	b.window.add(1, 0)
+}
+
+func (b *CircuitBreaker) TrackErr(err error) {
+	if b == nil {
+		return
+	}
+
+	var rateLimitErr *clients.RateLimitError
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) || errors.As(err, &rateLimitErr) {
+		// rate limits & cancelled requests don't tell anything about the provider availability,
+		// so the trial is given back to be taken by another request
+		if b.state == CircuitHalfOpen && b.trials > 0 {
+			b.trials--
+		}
+
+		return
+	}
+
+	switch b.state {
+	case CircuitHalfOpen:
+		b.open()
+	case CircuitClosed:
+		b.window.add(1, 1)
+
+		requests, errs := b.window.sum()
+
+		if requests >= b.config.MinRequests && float64(errs)/float64(requests) >= b.config.FailureRate {
+			b.open()
+		}
+	}
+}
+
+// Reset closes the circuit and forgets the tracked requests
+func (b *CircuitBreaker) Reset() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.close()
+}
+
+// State returns the current circuit state
+func (b *CircuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *CircuitBreaker) close() {
+	b.state = CircuitClosed
+	b.window = newSlidingWindow()
+	b.window.now = b.now
+}
+
+// halfOpenRequests treats the unset number of trials as a single trial
+func (b *CircuitBreaker) halfOpenRequests() int {
+	return max(b.config.HalfOpenRequests, 1)
+}
+
+func (b *CircuitBreaker) open() {
+	b.state = CircuitOpen
+	b.openedAt = b.now()
+}
+
+func (b *CircuitBreaker) probeDue() bool {
+	return !b.now().Before(b.openedAt.Add(time.Duration(b.config.ProbeInterval)))
+}
@@ -0,0 +1,148 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers/clients"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCircuitBreaker_OpensOnFailureRate(t *testing.T) {
+	now := time.Now()
+
+	breaker := NewCircuitBreaker(&CircuitBreakerConfig{FailureRate: 0.5, MinRequests: 4, ProbeInterval: fields.Duration(10 * time.Second)})
+	breaker.now = func() time.Time { return now }
+	breaker.window.now = breaker.now
+
+	errProvider := errors.New("provider is down")
+
+	breaker.TrackSuccess()
+	breaker.TrackErr(errProvider)
+	breaker.TrackErr(errProvider)
+
+	// not enough requests to judge the failure rate
+	require.Equal(t, CircuitClosed, breaker.State())
+	require.True(t, breaker.Ready())
+
+	// rate limits & cancellations don't count
+	breaker.TrackErr(clients.NewRateLimitError(nil))
+	breaker.TrackErr(context.Canceled)
+	require.Equal(t, CircuitClosed, breaker.State())
+
+	breaker.TrackErr(errProvider)
+
+	require.Equal(t, CircuitOpen, breaker.State())
+	require.False(t, breaker.Ready())
+	require.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	// a single probe is let through once the probe interval is over
+	now = now.Add(10 * time.Second)
+
+	require.True(t, breaker.Ready())
+	require.NoError(t, breaker.Allow())
+	require.Equal(t, CircuitHalfOpen, breaker.State())
+	require.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	breaker.TrackErr(errProvider)
+	require.Equal(t, CircuitOpen, breaker.State())
+
+	now = now.Add(10 * time.Second)
+
+	require.NoError(t, breaker.Allow())
+
+	breaker.TrackSuccess()
+	require.Equal(t, CircuitClosed, breaker.State())
+	require.NoError(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	var breaker *CircuitBreaker
+
+	breaker.TrackErr(errors.New("provider is down"))
+
+	require.True(t, breaker.Ready())
+	require.NoError(t, breaker.Allow())
+	require.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_SeveralTrialRequests(t *testing.T) {
+	now := time.Now()
+
+	breaker := NewCircuitBreaker(&CircuitBreakerConfig{FailureRate: 0.5, MinRequests: 1, ProbeInterval: fields.Duration(10 * time.Second), HalfOpenRequests: 2})
+	breaker.now = func() time.Time { return now }
+	breaker.window.now = breaker.now
+
+	errProvider := errors.New("provider is down")
+
+	breaker.TrackErr(errProvider)
+	require.Equal(t, CircuitOpen, breaker.State())
+
+	now = now.Add(10 * time.Second)
+
+	// two trials are let through
+	require.NoError(t, breaker.Allow())
+	require.True(t, breaker.Ready())
+	require.NoError(t, breaker.Allow())
+	require.False(t, breaker.Ready())
+	require.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	// the circuit is closed only once all trials have succeeded
+	breaker.TrackSuccess()
+	require.Equal(t, CircuitHalfOpen, breaker.State())
+
+	breaker.TrackSuccess()
+	require.Equal(t, CircuitClosed, breaker.State())
+
+	// a single failed trial opens the circuit again
+	breaker.TrackErr(errProvider)
+	now = now.Add(10 * time.Second)
+
+	require.NoError(t, breaker.Allow())
+	breaker.TrackSuccess()
+	breaker.TrackErr(errProvider)
+
+	require.Equal(t, CircuitOpen, breaker.State())
+}
+
+func TestCircuitBreaker_IgnoredTrialIsReleased(t *testing.T) {
+	now := time.Now()
+
+	breaker := NewCircuitBreaker(&CircuitBreakerConfig{FailureRate: 0.5, MinRequests: 1, ProbeInterval: fields.Duration(10 * time.Second)})
+	breaker.now = func() time.Time { return now }
+	breaker.window.now = breaker.now
+
+	breaker.TrackErr(errors.New("provider is down"))
+	require.Equal(t, CircuitOpen, breaker.State())
+
+	now = now.Add(10 * time.Second)
+
+	// the client has gone before the trial finished
+	require.NoError(t, breaker.Allow())
+	breaker.TrackErr(context.Canceled)
+	require.Equal(t, CircuitHalfOpen, breaker.State())
+
+	// the rate limited trial is not counted either
+	require.True(t, breaker.Ready())
+	require.NoError(t, breaker.Allow())
+	breaker.TrackErr(clients.NewRateLimitError(nil))
+
+	require.NoError(t, breaker.Allow())
+	breaker.TrackSuccess()
+	require.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerConfig_ParsesDurations(t *testing.T) {
+	var config CircuitBreakerConfig
+
+	require.NoError(t, yaml.Unmarshal([]byte("probe_interval: 45s\nhalf_open_requests: 2"), &config))
+	require.Equal(t, fields.Duration(45*time.Second), config.ProbeInterval)
+	require.Equal(t, 2, config.HalfOpenRequests)
+	require.Equal(t, 10, config.MinRequests)
+
+	require.Error(t, yaml.Unmarshal([]byte("probe_interval: soon"), &config))
+}
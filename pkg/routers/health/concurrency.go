@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+
+	"glide/pkg/providers/clients"
+)
+
+// AdaptiveConcurrencyConfig defines how the model concurrency limit adapts to the provider load
+type AdaptiveConcurrencyConfig struct {
+	InitialLimit     int     `yaml:"initial_limit" json:"initial_limit" validate:"gte=1"`         // the limit the model starts with
+	MinLimit         int     `yaml:"min_limit" json:"min_limit" validate:"gte=1"`                 // the limit never goes below this
+	MaxLimit         int     `yaml:"max_limit" json:"max_limit" validate:"gtefield=MinLimit"`     // the limit never goes above this
+	Backoff          float64 `yaml:"backoff" json:"backoff" validate:"gt=0,lt=1"`                 // the limit is multiplied by this on signs of provider overload
+	LatencyTolerance float64 `yaml:"latency_tolerance" json:"latency_tolerance" validate:"gte=1"` // requests slower than the usual latency this many times are signs of overload
+}
+
+func DefaultAdaptiveConcurrencyConfig() *AdaptiveConcurrencyConfig {
+	return &AdaptiveConcurrencyConfig{
+		InitialLimit:     10,
+		MinLimit:         1,
+		MaxLimit:         200,
+		Backoff:          0.9,
+		LatencyTolerance: 2,
+	}
+}
+
+func (c *AdaptiveConcurrencyConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultAdaptiveConcurrencyConfig()
+
+	type plain AdaptiveConcurrencyConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// ConcurrencyLimiter discovers how many requests the provider can serve at the same time (AIMD).
+// The limit grows by one per limit-worth of requests served in time while the model is busy
+// and shrinks multiplicatively once the provider slows down, rate limits or fails requests
+type ConcurrencyLimiter struct {
+	mu     sync.Mutex
+	config *AdaptiveConcurrencyConfig
+	limit  float64
+}
+
+func NewConcurrencyLimiter(config *AdaptiveConcurrencyConfig) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		config: config,
+		limit:  float64(config.InitialLimit),
+	}
+}
+
+// Limit returns the current concurrency limit, zero means no limit
+func (l *ConcurrencyLimiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(math.Floor(l.limit))
+}
+
+// TrackSuccess adjusts the limit by the latency of the served request compared to the usual model latency.
+// The limit is not grown while the model is far from using it, as that tells nothing about the provider capacity
+func (l *ConcurrencyLimiter) TrackSuccess(inFlight int64, latency float64, usualLatency float64) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if usualLatency > 0 && latency > usualLatency*l.config.LatencyTolerance {
+		l.decrease()
+
+		return
+	}
+
+	if float64(inFlight)*2 < l.limit {
+		return
+	}
+
+	l.limit = min(l.limit+1/l.limit, float64(l.config.MaxLimit))
+}
+
+// TrackErr shrinks the limit if the error is a sign of the provider overload
+func (l *ConcurrencyLimiter) TrackErr(err error) {
+	var rateLimitErr *clients.RateLimitError
+
+	if l == nil {
+		return
+	}
+
+	if !errors.As(err, &rateLimitErr) && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, clients.ErrProviderUnavailable) {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.decrease()
+}
+
+func (l *ConcurrencyLimiter) decrease() {
+	l.limit = max(l.limit*l.config.Backoff, float64(l.config.MinLimit))
+}
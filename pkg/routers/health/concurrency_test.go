@@ -0,0 +1,99 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers/clients"
+)
+
+func newTestConcurrencyLimiter(initialLimit int) *ConcurrencyLimiter {
+	config := DefaultAdaptiveConcurrencyConfig()
+	config.InitialLimit = initialLimit
+	config.MinLimit = 2
+	config.MaxLimit = 12
+
+	return NewConcurrencyLimiter(config)
+}
+
+func TestConcurrencyLimiter_GrowsWhileBusy(t *testing.T) {
+	limiter := newTestConcurrencyLimiter(10)
+
+	for range 10 {
+		limiter.TrackSuccess(10, 1.0, 1.0)
+	}
+
+	require.Equal(t, 10, limiter.Limit())
+
+	for range 11 {
+		limiter.TrackSuccess(10, 1.0, 1.0)
+	}
+
+	require.Equal(t, 11, limiter.Limit())
+}
+
+func TestConcurrencyLimiter_NotGrowingWhileIdle(t *testing.T) {
+	limiter := newTestConcurrencyLimiter(10)
+
+	for range 100 {
+		limiter.TrackSuccess(1, 1.0, 1.0)
+	}
+
+	require.Equal(t, 10, limiter.Limit())
+}
+
+func TestConcurrencyLimiter_CappedByMaxLimit(t *testing.T) {
+	limiter := newTestConcurrencyLimiter(10)
+
+	for range 1000 {
+		limiter.TrackSuccess(12, 1.0, 0)
+	}
+
+	require.Equal(t, 12, limiter.Limit())
+}
+
+func TestConcurrencyLimiter_BacksOffOnSlowdowns(t *testing.T) {
+	limiter := newTestConcurrencyLimiter(10)
+
+	limiter.TrackSuccess(10, 1.5, 1.0)
+	require.Equal(t, 10, limiter.Limit())
+
+	limiter.TrackSuccess(10, 3.0, 1.0)
+	require.Equal(t, 9, limiter.Limit())
+}
+
+func TestConcurrencyLimiter_BacksOffOnOverloadErrors(t *testing.T) {
+	limiter := newTestConcurrencyLimiter(10)
+
+	limiter.TrackErr(clients.NewRateLimitError(nil))
+	limiter.TrackErr(context.DeadlineExceeded)
+	limiter.TrackErr(clients.ErrProviderUnavailable)
+
+	require.Equal(t, 7, limiter.Limit())
+
+	limiter.TrackErr(errors.New("bad request"))
+	limiter.TrackErr(clients.ErrUnauthorized)
+
+	require.Equal(t, 7, limiter.Limit())
+}
+
+func TestConcurrencyLimiter_FlooredByMinLimit(t *testing.T) {
+	limiter := newTestConcurrencyLimiter(10)
+
+	for range 100 {
+		limiter.TrackErr(clients.ErrProviderUnavailable)
+	}
+
+	require.Equal(t, 2, limiter.Limit())
+}
+
+func TestConcurrencyLimiter_NoLimitByDefault(t *testing.T) {
+	var limiter *ConcurrencyLimiter
+
+	limiter.TrackSuccess(10, 1.0, 1.0)
+	limiter.TrackErr(clients.ErrProviderUnavailable)
+
+	require.Equal(t, 0, limiter.Limit())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const budgetSeparator = "/"
@@ -19,7 +20,8 @@ const (
 )
 
 // ErrorBudget parses human-friendly error budget representation and return it as errors & update rate pair
-// Error budgets could be set as a string in the following format: "10/s", "5/ms", "100/m" "1500/h"
+// Error budgets could be set as a string in the following format: "10/s", "5/ms", "100/m" "1500/h".
+// Other budget windows are set as durations (e.g. "50/10m" or "3/30s")
 type ErrorBudget struct {
 	budget uint
 	unit   Unit
@@ -74,7 +76,10 @@ func (b *ErrorBudget) UnmarshalText(text []byte) error {
 	unit := Unit(parts[1])
 
 	if unit != MILLI && unit != SEC && unit != MIN && unit != HOUR {
-		return errors.New("invalid unit (supported: ms, s, m, h)")
+		window, err := time.ParseDuration(parts[1])
+		if err != nil || window < time.Millisecond {
+			return errors.New("invalid unit (supported: ms, s, m, h or a window duration like 10m)")
+		}
 	}
 
 	b.budget = uint(budget)
@@ -94,7 +99,12 @@ func (b *ErrorBudget) unitToMicro(unit Unit) uint {
 	case HOUR:
 		return 3_600_000_000 // 1 h = 3,600,000,000 microseconds
 	default:
-		return 1
+		window, err := time.ParseDuration(string(unit))
+		if err != nil {
+			return 1
+		}
+
+		return uint(window.Microseconds())
 	}
 }
 
@@ -17,6 +17,8 @@ func TestErrorBudget_ParseValidString(t *testing.T) {
 		"10/ms":    {input: "10/ms", errors: 10, unit: MILLI},
 		"1000/m":   {input: "1000/m", errors: 1000, unit: MIN},
 		"100000/h": {input: "100000/h", errors: 100000, unit: HOUR},
+		"50/10m":   {input: "50/10m", errors: 50, unit: Unit("10m")},
+		"3/30s":    {input: "3/30s", errors: 3, unit: Unit("30s")},
 	}
 
 	for name, tc := range tests {
@@ -41,6 +43,8 @@ func TestErrorBudget_ParseInvalidString(t *testing.T) {
 		"1,9/s":  {input: "1,9/s"},
 		"100/d":  {input: "100/d"},
 		"100/mo": {input: "100/mo"},
+		"100/0s": {input: "100/0s"},
+		"100/-m": {input: "100/-1m"},
 	}
 
 	for name, tc := range tests {
@@ -51,3 +55,10 @@ func TestErrorBudget_ParseInvalidString(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorBudget_WindowDuration(t *testing.T) {
+	budget := DefaultErrorBudget()
+
+	require.NoError(t, budget.UnmarshalText([]byte("50/10m")))
+	require.Equal(t, uint(12_000_000), budget.TimePerTokenMicro())
+}
@@ -0,0 +1,34 @@
+package health
+
+// ErrorRate tracks the share of failed requests over the last minute.
+//
+//	Unlike the error budget, it doesn't make the model unhealthy,
+//	but lets routing strategies prefer reliable models over flaky ones
+type ErrorRate struct {
+	window *slidingWindow
+}
+
+func NewErrorRate() *ErrorRate {
+	return &ErrorRate{
+		window: newSlidingWindow(),
+	}
+}
+
+func (r *ErrorRate) TrackSuccess() {
+	r.window.add(1, 0)
+}
+
+func (r *ErrorRate) TrackErr() {
+	r.window.add(1, 1)
+}
+
+// Value returns the share of requests failed over the last minute or zero if there were no requests
+func (r *ErrorRate) Value() float64 {
+	requests, errs := r.window.sum()
+
+	if requests == 0 {
+		return 0
+	}
+
+	return float64(errs) / float64(requests)
+}
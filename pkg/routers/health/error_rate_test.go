@@ -0,0 +1,29 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorRate_SlidesOverMinute(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	errRate := NewErrorRate()
+	errRate.window.now = func() time.Time { return now }
+
+	require.Zero(t, errRate.Value())
+
+	errRate.TrackErr()
+	errRate.TrackSuccess()
+
+	now = now.Add(30 * time.Second)
+	errRate.TrackSuccess()
+	errRate.TrackSuccess()
+
+	require.InDelta(t, 0.25, errRate.Value(), 0.0001)
+
+	now = now.Add(30 * time.Second)
+	require.Zero(t, errRate.Value())
+}
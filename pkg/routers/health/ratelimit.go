@@ -1,29 +1,48 @@
 package health
 
-import "time"
+import (
+	"sync/atomic"
+	"time"
+)
 
 // RateLimitTracker handles rate/quota limits that often represented via 429 errors and
 // has some well-defined cooldown period
 type RateLimitTracker struct {
-	resetAt *time.Time
+	resetAt atomic.Pointer[time.Time]
 }
 
 func NewRateLimitTracker() *RateLimitTracker {
-	return &RateLimitTracker{
-		resetAt: nil,
-	}
+	return &RateLimitTracker{}
 }
 
 func (t *RateLimitTracker) Limited() bool {
-	if t.resetAt != nil && time.Now().After(*t.resetAt) {
-		t.resetAt = nil
+	resetAt := t.resetAt.Load()
+
+	if resetAt != nil && time.Now().After(*resetAt) {
+		t.resetAt.CompareAndSwap(resetAt, nil)
+
+		return false
 	}
 
-	return t.resetAt != nil
+	return resetAt != nil
 }
 
 func (t *RateLimitTracker) SetLimited(untilReset time.Duration) {
 	resetAt := time.Now().Add(untilReset)
 
-	t.resetAt = &resetAt
+	t.resetAt.Store(&resetAt)
+}
+
+// Reset forgets the active rate limit
+func (t *RateLimitTracker) Reset() {
+	t.resetAt.Store(nil)
+}
+
+// ResetAt returns when the rate limit is over or nil if there is no active rate limit
+func (t *RateLimitTracker) ResetAt() *time.Time {
+	if !t.Limited() {
+		return nil
+	}
+
+	return t.resetAt.Load()
 }
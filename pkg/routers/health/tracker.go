@@ -1,40 +1,122 @@
 package health
 
 import (
+	"context"
 	"errors"
+	"math"
+	"sync/atomic"
+	"time"
 
 	"glide/pkg/providers/clients"
 )
 
 // Tracker tracks errors and general health of model provider
 type Tracker struct {
-	unauthorized bool
-	errBudget    *TokenBucket
-	rateLimit    *RateLimitTracker
+	unauthorized  atomic.Bool
+	errBudget     *TokenBucket
+	rateLimit     *RateLimitTracker
+	errRate       *ErrorRate
+	breaker       *CircuitBreaker
+	rateLimitHits atomic.Uint64
 }
 
 func NewTracker(budget *ErrorBudget) *Tracker {
 	return &Tracker{
-		unauthorized: false,
-		rateLimit:    NewRateLimitTracker(),
-		errBudget:    NewTokenBucket(budget.TimePerTokenMicro(), budget.Budget()),
+		rateLimit: NewRateLimitTracker(),
+		errRate:   NewErrorRate(),
+		errBudget: NewTokenBucket(budget.TimePerTokenMicro(), budget.Budget()),
 	}
 }
 
+// WithCircuitBreaker cuts the model off from traffic once it fails too many requests
+func (t *Tracker) WithCircuitBreaker(config *CircuitBreakerConfig) *Tracker {
+	t.breaker = NewCircuitBreaker(config)
+
+	return t
+}
+
 func (t *Tracker) Healthy() bool {
-	return !t.unauthorized && !t.rateLimit.Limited() && t.errBudget.HasTokens()
+	return !t.unauthorized.Load() && !t.rateLimit.Limited() && t.errBudget.HasTokens() && t.breaker.Ready()
+}
+
+// Allow checks if the request may be sent to the provider. It is rejected right away when the circuit is open
+func (t *Tracker) Allow() error {
+	return t.breaker.Allow()
+}
+
+// CircuitState returns the state of the model circuit breaker (closed when there is no breaker)
+func (t *Tracker) CircuitState() CircuitState {
+	return t.breaker.State()
+}
+
+// Unauthorized tells if the provider rejected the model credentials
+func (t *Tracker) Unauthorized() bool {
+	return t.unauthorized.Load()
+}
+
+// RateLimitedUntil returns when the provider rate limit is over (if the model is rate limited at the moment)
+func (t *Tracker) RateLimitedUntil() *time.Time {
+	return t.rateLimit.ResetAt()
+}
+
+// RateLimitHits returns how many times the provider rejected requests due to rate limits
+func (t *Tracker) RateLimitHits() uint64 {
+	return t.rateLimitHits.Load()
+}
+
+// ErrBudgetLeft returns how many errors the model can make before it's considered unhealthy
+func (t *Tracker) ErrBudgetLeft() uint {
+	return uint(math.Floor(t.errBudget.Tokens()))
+}
+
+// ErrorRate returns the share of requests failed over the last minute
+func (t *Tracker) ErrorRate() float64 {
+	return t.errRate.Value()
+}
+
+// TrackSuccess records the successfully served request
+func (t *Tracker) TrackSuccess() {
+	t.errRate.TrackSuccess()
+	t.breaker.TrackSuccess()
+}
+
+// Recover marks the model healthy again once an out-of-band check (e.g. an active probe) has shown that the provider is back
+func (t *Tracker) Recover() {
+	t.unauthorized.Store(false)
+	t.rateLimit.Reset()
+	t.errBudget.Reset()
+	t.breaker.Reset()
 }
 
 func (t *Tracker) TrackErr(err error) {
 	var rateLimitErr *clients.RateLimitError
 
+	if errors.Is(err, context.Canceled) {
+		// the caller has given up on the request (e.g. the client went away or a hedged request won),
+		// so it tells nothing about the model health. A cancelled trial is given back to the breaker though
+		t.breaker.TrackErr(err)
+
+		return
+	}
+
+	if errors.Is(err, clients.ErrContentFiltered) || errors.Is(err, clients.ErrContextTooLong) {
+		// the provider is up and has processed the request, it's the content that was rejected
+		t.TrackSuccess()
+
+		return
+	}
+
+	t.errRate.TrackErr()
+	t.breaker.TrackErr(err)
+
 	if errors.Is(err, clients.ErrUnauthorized) {
-		t.unauthorized = true
+		t.unauthorized.Store(true)
 
 		return
 	}
 
 	if errors.As(err, &rateLimitErr) {
+		t.rateLimitHits.Add(1)
 		t.rateLimit.SetLimited(rateLimitErr.UntilReset())
 
 		return
@@ -1,10 +1,12 @@
 package health
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"glide/pkg/config/fields"
 	"glide/pkg/providers/clients"
 )
 
@@ -35,3 +37,84 @@ func TestHealthTracker_RateLimited(t *testing.T) {
 
 	require.False(t, tracker.Healthy())
 }
+
+func TestHealthTracker_ErrBudgetLeft(t *testing.T) {
+	budget := NewErrorBudget(3, HOUR)
+	tracker := NewTracker(budget)
+
+	require.Equal(t, uint(3), tracker.ErrBudgetLeft())
+
+	tracker.TrackErr(clients.ErrProviderUnavailable)
+
+	require.Equal(t, uint(2), tracker.ErrBudgetLeft())
+	require.False(t, tracker.Unauthorized())
+	require.Nil(t, tracker.RateLimitedUntil())
+}
+
+func TestHealthTracker_Status(t *testing.T) {
+	budget := NewErrorBudget(3, SEC)
+	tracker := NewTracker(budget)
+
+	limitedUntil := 10 * time.Minute
+	tracker.TrackErr(clients.NewRateLimitError(&limitedUntil))
+
+	require.NotNil(t, tracker.RateLimitedUntil())
+	require.WithinDuration(t, time.Now().Add(limitedUntil), *tracker.RateLimitedUntil(), time.Second)
+
+	tracker.TrackErr(clients.ErrUnauthorized)
+
+	require.True(t, tracker.Unauthorized())
+}
+
+func TestHealthTracker_ContentFilteredKeepsHealthy(t *testing.T) {
+	budget := NewErrorBudget(3, HOUR)
+	tracker := NewTracker(budget)
+
+	for range 5 {
+		tracker.TrackErr(clients.ErrContentFiltered)
+	}
+
+	require.True(t, tracker.Healthy())
+	require.Equal(t, uint(3), tracker.ErrBudgetLeft())
+	require.Zero(t, tracker.ErrorRate())
+}
+
+func TestHealthTracker_Recover(t *testing.T) {
+	tracker := NewTracker(NewErrorBudget(1, SEC))
+
+	limitedUntil := 10 * time.Minute
+
+	tracker.TrackErr(clients.ErrUnauthorized)
+	tracker.TrackErr(clients.NewRateLimitError(&limitedUntil))
+	tracker.TrackErr(clients.ErrProviderUnavailable)
+
+	require.False(t, tracker.Healthy())
+
+	tracker.Recover()
+
+	require.True(t, tracker.Healthy())
+	require.False(t, tracker.Unauthorized())
+	require.Nil(t, tracker.RateLimitedUntil())
+	require.Equal(t, uint(1), tracker.ErrBudgetLeft())
+}
+
+func TestHealthTracker_CancelledTrialIsReleased(t *testing.T) {
+	now := time.Now()
+
+	tracker := NewTracker(NewErrorBudget(3, SEC))
+	tracker.WithCircuitBreaker(&CircuitBreakerConfig{FailureRate: 0.5, MinRequests: 1, ProbeInterval: fields.Duration(10 * time.Second)})
+	tracker.breaker.now = func() time.Time { return now }
+	tracker.breaker.window.now = tracker.breaker.now
+
+	tracker.TrackErr(clients.ErrProviderUnavailable)
+	require.Equal(t, CircuitOpen, tracker.CircuitState())
+
+	now = now.Add(10 * time.Second)
+
+	// the client has gone before the trial finished
+	require.NoError(t, tracker.Allow())
+	tracker.TrackErr(context.Canceled)
+
+	require.True(t, tracker.Healthy())
+	require.NoError(t, tracker.Allow())
+}
@@ -0,0 +1,23 @@
+package health
+
+// UsageWindow counts requests & tokens sent to the provider over the last minute
+// to compare them with the provider rate limits (e.g. RPM & TPM) before the provider starts rejecting requests
+type UsageWindow struct {
+	window *slidingWindow
+}
+
+func NewUsageWindow() *UsageWindow {
+	return &UsageWindow{
+		window: newSlidingWindow(),
+	}
+}
+
+// Track records requests & tokens sent at the moment
+func (w *UsageWindow) Track(requests int, tokens int) {
+	w.window.add(requests, tokens)
+}
+
+// Usage returns the number of requests & tokens sent over the last minute
+func (w *UsageWindow) Usage() (int, int) {
+	return w.window.sum()
+}
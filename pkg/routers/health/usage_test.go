@@ -0,0 +1,37 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageWindow_SlidesOverMinute(t *testing.T) {
+	now := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	window := NewUsageWindow()
+	window.window.now = func() time.Time { return now }
+
+	window.Track(1, 100)
+	window.Track(1, 50)
+
+	now = now.Add(30 * time.Second)
+	window.Track(1, 10)
+
+	requests, tokens := window.Usage()
+	require.Equal(t, 3, requests)
+	require.Equal(t, 160, tokens)
+
+	now = now.Add(30 * time.Second)
+
+	requests, tokens = window.Usage()
+	require.Equal(t, 1, requests)
+	require.Equal(t, 10, tokens)
+
+	now = now.Add(time.Hour)
+
+	requests, tokens = window.Usage()
+	require.Zero(t, requests)
+	require.Zero(t, tokens)
+}
@@ -0,0 +1,61 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+const windowBuckets = 60
+
+type windowBucket struct {
+	at     int64 // the unix second the bucket counts
+	counts [2]int
+}
+
+// slidingWindow sums pairs of counters (e.g. requests & tokens) over the last minute.
+//
+//	The minute is split into per-second buckets, so the window slides with a second precision
+type slidingWindow struct {
+	mu      sync.Mutex
+	buckets [windowBuckets]windowBucket
+	now     func() time.Time
+}
+
+func newSlidingWindow() *slidingWindow {
+	return &slidingWindow{
+		now: time.Now,
+	}
+}
+
+func (w *slidingWindow) add(first int, second int) {
+	at := w.now().Unix()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	bucket := &w.buckets[at%windowBuckets]
+
+	if bucket.at != at {
+		*bucket = windowBucket{at: at}
+	}
+
+	bucket.counts[0] += first
+	bucket.counts[1] += second
+}
+
+func (w *slidingWindow) sum() (int, int) {
+	at := w.now().Unix()
+	first, second := 0, 0
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, bucket := range w.buckets {
+		if at-bucket.at < windowBuckets {
+			first += bucket.counts[0]
+			second += bucket.counts[1]
+		}
+	}
+
+	return first, second
+}
@@ -0,0 +1,167 @@
+package routers
+
+import (
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/events"
+	"glide/pkg/providers"
+	"glide/pkg/routers/health"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+const (
+	// healthWatchInterval is how often model health is checked for transitions.
+	// Models recover over time (e.g. once rate limits reset), so transitions are not tied to requests
+	healthWatchInterval = 1 * time.Second
+
+	ModelUnhealthyAction     = "model.unhealthy"
+	ModelHealthyAction       = "model.healthy"
+	ModelCircuitOpenedAction = "model.circuit_opened"
+)
+
+// ModelHealthEventData describes the model health transition
+type ModelHealthEventData struct {
+	RouterID     string `json:"routerId"`
+	ModelID      string `json:"modelId"`
+	Healthy      bool   `json:"healthy"`
+	Reason       string `json:"reason,omitempty"` // why the model is unhealthy (e.g. rate_limited or circuit_open)
+	CircuitState string `json:"circuitState,omitempty"`
+}
+
+// HealthWatcher records model health transitions in the audit log & emits them as router events
+type HealthWatcher struct {
+	routerID RouterID
+	models   []*providers.LanguageModel
+	healthy  map[string]bool
+	circuits map[string]string
+	events   *events.Dispatcher
+	tel      *telemetry.Telemetry
+	logger   *zap.Logger
+	stopC    chan struct{}
+	stopOnce sync.Once
+}
+
+func NewHealthWatcher(
+	routerID RouterID,
+	models []*providers.LanguageModel,
+	dispatcher *events.Dispatcher,
+	tel *telemetry.Telemetry,
+) *HealthWatcher {
+	healthy := make(map[string]bool, len(models))
+	circuits := make(map[string]string, len(models))
+
+	for _, model := range models {
+		healthy[model.ID()] = model.Healthy()
+		circuits[model.ID()] = model.Health().CircuitState
+	}
+
+	return &HealthWatcher{
+		routerID: routerID,
+		models:   models,
+		healthy:  healthy,
+		circuits: circuits,
+		events:   dispatcher,
+		tel:      tel,
+		logger:   tel.Module(telemetry.ModuleHealth).L().With(zap.String("routerID", routerID)),
+		stopC:    make(chan struct{}),
+	}
+}
+
+// Start checks model health in the background until the watcher is stopped
+func (w *HealthWatcher) Start() {
+	go func() {
+		ticker := time.NewTicker(healthWatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.Check()
+			case <-w.stopC:
+				return
+			}
+		}
+	}()
+}
+
+// Check records models which health has changed since the previous check
+func (w *HealthWatcher) Check() {
+	for _, model := range w.models {
+		modelHealth := model.Health()
+
+		w.checkCircuit(model, modelHealth)
+
+		healthy := model.Healthy()
+
+		if healthy == w.healthy[model.ID()] {
+			continue
+		}
+
+		w.healthy[model.ID()] = healthy
+
+		action := ModelHealthyAction
+		details := map[string]interface{}{"routerId": w.routerID}
+		eventData := &ModelHealthEventData{RouterID: w.routerID, ModelID: model.ID(), Healthy: healthy}
+
+		if !healthy {
+			action = ModelUnhealthyAction
+			eventData.Reason = unhealthyReason(modelHealth)
+			details["reason"] = eventData.Reason
+		}
+
+		w.logger.Info("Model health has changed", zap.String("modelID", model.ID()), zap.Bool("healthy", healthy))
+		w.tel.Audit.Record(telemetry.SystemActor, action, model.ID(), details)
+		w.events.Emit(events.NewEvent(action, eventData))
+	}
+}
+
+// checkCircuit reports the model circuit breaker that has opened since the previous check
+func (w *HealthWatcher) checkCircuit(model *providers.LanguageModel, modelHealth schemas.ModelHealth) {
+	prevState := w.circuits[model.ID()]
+	w.circuits[model.ID()] = modelHealth.CircuitState
+
+	if modelHealth.CircuitState != string(health.CircuitOpen) || prevState == modelHealth.CircuitState {
+		return
+	}
+
+	w.logger.Warn("Model circuit breaker has opened", zap.String("modelID", model.ID()))
+	w.tel.Audit.Record(telemetry.SystemActor, ModelCircuitOpenedAction, model.ID(), map[string]interface{}{"routerId": w.routerID})
+	w.events.Emit(events.NewEvent(ModelCircuitOpenedAction, &ModelHealthEventData{
+		RouterID:     w.routerID,
+		ModelID:      model.ID(),
+		Healthy:      false,
+		Reason:       "circuit_open",
+		CircuitState: modelHealth.CircuitState,
+	}))
+}
+
+func (w *HealthWatcher) Stop() {
+	if w == nil {
+		return
+	}
+
+	w.stopOnce.Do(func() {
+		close(w.stopC)
+	})
+}
+
+// unhealthyReason tells why the model is not picked by routing
+func unhealthyReason(modelHealth schemas.ModelHealth) string {
+	switch {
+	case modelHealth.Unauthorized:
+		return "unauthorized"
+	case modelHealth.RateLimitedUntil > 0:
+		return "rate_limited"
+	case modelHealth.BudgetExhausted:
+		return "budget_exhausted"
+	case !modelHealth.Active:
+		return "inactive"
+	case modelHealth.CircuitState == string(health.CircuitOpen):
+		return "circuit_open"
+	default:
+		return "error_budget_exhausted"
+	}
+}
@@ -0,0 +1,74 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/events"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/telemetry"
+)
+
+func TestHealthWatcher_RecordsTransitions(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+
+	model := providers.NewLangModel(
+		"openai",
+		ptesting.NewProviderMock([]ptesting.RespMock{{Err: &clients.ErrUnauthorized}}),
+		health.DefaultErrorBudget(),
+		*latency.DefaultConfig(),
+		1,
+	)
+
+	eventC := make(chan *events.Event, 1)
+
+	eventServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event events.Event
+
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		eventC <- &event
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer eventServer.Close()
+
+	webhook := events.DefaultDeliveryConfig()
+	webhook.URL = eventServer.URL
+	webhook.Events = []string{"model.*"}
+
+	watcher := NewHealthWatcher("router", []*providers.LanguageModel{model}, events.NewDispatcher([]*events.DeliveryConfig{webhook}, tel), tel)
+	defer watcher.Stop()
+
+	watcher.Check()
+	require.Empty(t, tel.Audit.Entries())
+
+	_, err := model.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.ErrorIs(t, err, clients.ErrUnauthorized)
+
+	watcher.Check()
+	watcher.Check()
+
+	entries := tel.Audit.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, ModelUnhealthyAction, entries[0].Action)
+	require.Equal(t, "openai", entries[0].Target)
+	require.Equal(t, "unauthorized", entries[0].Details["reason"])
+
+	select {
+	case event := <-eventC:
+		require.Equal(t, ModelUnhealthyAction, event.Type)
+		require.Equal(t, "unauthorized", event.Data.(map[string]interface{})["reason"])
+	case <-time.After(time.Second):
+		require.Fail(t, "the model unhealthy event is not delivered")
+	}
+}
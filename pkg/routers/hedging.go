@@ -0,0 +1,209 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+var ErrHedgeLost = errors.New("cancelled as another model has served the hedged request first")
+
+const (
+	hedgingWindow        = 100
+	hedgingWarmupSamples = 10
+)
+
+// HedgingConfig defines when slow chat requests are hedged with the next best model
+type HedgingConfig struct {
+	Percentile float64          `yaml:"percentile" json:"percentile" validate:"gt=0,lt=100"`       // the hedge is sent once the primary model is slower than this percentile of its recent chat latencies
+	MinDelay   *fields.Duration `yaml:"min_delay" json:"min_delay" swaggertype:"primitive,string"` // the shortest time to wait for the primary model before hedging
+	MaxDelay   *fields.Duration `yaml:"max_delay" json:"max_delay" swaggertype:"primitive,string"` // the longest time to wait for the primary model, used until its latency percentiles are known
+}
+
+func DefaultHedgingConfig() *HedgingConfig {
+	defaultMinDelay, defaultMaxDelay := 50*time.Millisecond, 5*time.Second
+
+	return &HedgingConfig{
+		Percentile: 95,
+		MinDelay:   (*fields.Duration)(&defaultMinDelay),
+		MaxDelay:   (*fields.Duration)(&defaultMaxDelay),
+	}
+}
+
+func (c *HedgingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultHedgingConfig()
+
+	type plain HedgingConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Hedger decides when to hedge chat requests based on recent chat latencies of router models.
+// Unlike model latencies normalized per token, it tracks the time the whole request takes
+type Hedger struct {
+	config    *HedgingConfig
+	mu        sync.Mutex
+	latencies map[string]*latency.Quantiles
+	prefix    string
+	tel       *telemetry.Telemetry
+}
+
+func NewHedger(routerID RouterID, config *HedgingConfig, tel *telemetry.Telemetry) *Hedger {
+	return &Hedger{
+		config:    config,
+		latencies: make(map[string]*latency.Quantiles),
+		prefix:    fmt.Sprintf("routers.%v.hedging", routerID),
+		tel:       tel,
+	}
+}
+
+// Delay returns how long to wait for the model before hedging the request
+func (h *Hedger) Delay(model providers.Model) time.Duration {
+	minDelay, maxDelay := time.Duration(*h.config.MinDelay), time.Duration(*h.config.MaxDelay)
+
+	modelLatency := h.latency(model)
+	if !modelLatency.WarmedUp() {
+		return maxDelay
+	}
+
+	delay := time.Duration(modelLatency.Quantile(h.config.Percentile / 100))
+
+	return min(max(delay, minDelay), maxDelay)
+}
+
+// Track records how long the model took to serve the request
+func (h *Hedger) Track(model providers.Model, took time.Duration) {
+	h.latency(model).Add(float64(took))
+}
+
+func (h *Hedger) latency(model providers.Model) *latency.Quantiles {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	modelLatency, found := h.latencies[model.ID()]
+	if !found {
+		modelLatency = latency.NewQuantiles(hedgingWindow, hedgingWarmupSamples)
+		h.latencies[model.ID()] = modelLatency
+	}
+
+	return modelLatency
+}
+
+type hedgeResult struct {
+	model     providers.LangModel
+	resp      *schemas.ChatResponse
+	err       error
+	panic     any // the model panic is re-raised on the request goroutine, so the HTTP middleware could handle it
+	startedAt time.Time
+}
+
+// hedgedChat sends the request to the primary model and, if it's not served within the hedging delay,
+// to the next model of the iterator as well. The first response wins, while the other request is cancelled.
+// Failures of all models but the returned one are reported here
+func (r *LangRouter) hedgedChat(
+	ctx context.Context,
+	chatRouting routing.LangModelRouting,
+	primary providers.LangModel,
+	modelIterator routing.LangModelIterator,
+	req *schemas.ChatRequest,
+	tracer *routingTracer,
+	timer *responseTimer,
+) (*schemas.ChatResponse, providers.LangModel, error) {
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel() // cancels the losing request
+
+	resultC := make(chan hedgeResult, 2)
+	inFlight := make([]providers.LangModel, 0, 2)
+
+	send := func(model providers.LangModel, req *schemas.ChatRequest) {
+		startedAt := time.Now()
+		inFlight = append(inFlight, model)
+
+		go func() {
+			defer func() {
+				if value := recover(); value != nil {
+					resultC <- hedgeResult{model: model, panic: value, startedAt: startedAt}
+				}
+			}()
+
+			resp, err := r.chat(hedgeCtx, chatRouting, model, req, timer)
+			resultC <- hedgeResult{model: model, resp: resp, err: err, startedAt: startedAt}
+		}()
+	}
+
+	primaryStartedAt := time.Now()
+
+	send(primary, req)
+
+	hedgeTimer := time.NewTimer(r.hedger.Delay(primary))
+	defer hedgeTimer.Stop()
+
+	pending := 1
+
+	for {
+		select {
+		case <-hedgeTimer.C:
+			hedgeModel, err := modelIterator.Next()
+			if err != nil || hedgeModel.ID() == primary.ID() {
+				// there is no model to hedge with, so keep waiting for the primary one
+				continue
+			}
+
+			langModel := hedgeModel.(providers.LangModel)
+			hedgeReq := *req
+
+			if req.Override != nil && langModel.ID() == req.Override.Model {
+				hedgeReq.Message = req.Override.Message
+			}
+
+			r.hedger.tel.M().Counter(r.hedger.prefix + ".hedges").Inc()
+			tracer.attempt(langModel, fmt.Sprintf("hedging the slow %v model", primary.ID()))
+			send(langModel, &hedgeReq)
+
+			pending++
+		case result := <-resultC:
+			if result.panic != nil {
+				panic(result.panic)
+			}
+
+			pending--
+
+			inFlight = slices.DeleteFunc(inFlight, func(model providers.LangModel) bool {
+				return model.ID() == result.model.ID()
+			})
+
+			if result.err == nil {
+				for _, loser := range inFlight {
+					tracer.failed(loser, ErrHedgeLost)
+				}
+
+				r.hedger.Track(result.model, time.Since(result.startedAt))
+
+				if result.model.ID() != primary.ID() {
+					r.hedger.tel.M().Counter(r.hedger.prefix + ".hedge_wins").Inc()
+					// the primary model is at least as slow as the hedge has made it look
+					r.hedger.Track(primary, time.Since(primaryStartedAt))
+				}
+
+				return result.resp, result.model, nil
+			}
+
+			if pending == 0 {
+				// all sent requests have failed, so the regular fallback takes over
+				return nil, result.model, result.err
+			}
+
+			r.chatFailed(chatRouting, result.model, result.err, tracer)
+		}
+	}
+}
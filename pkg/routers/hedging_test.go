@@ -0,0 +1,164 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newHedgingConfig(minDelay, maxDelay time.Duration) *HedgingConfig {
+	cfg := DefaultHedgingConfig()
+	cfg.MinDelay = (*fields.Duration)(&minDelay)
+	cfg.MaxDelay = (*fields.Duration)(&maxDelay)
+
+	return cfg
+}
+
+func newHedgingRouter(firstResps []ptesting.RespMock, secondResps []ptesting.RespMock) *LangRouter {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+	tel := telemetry.NewTelemetryMock()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock(firstResps), budget, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock(secondResps), budget, *latConfig, 1),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{RoutingStrategy: routing.Priority},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		hedger:           NewHedger("test_router", newHedgingConfig(10*time.Millisecond, 20*time.Millisecond), tel),
+		tel:              tel,
+		logger:           telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_HedgesSlowModel(t *testing.T) {
+	router := newHedgingRouter(
+		[]ptesting.RespMock{{Msg: "1", Delay: 5 * time.Second}},
+		[]ptesting.RespMock{{Msg: "2"}},
+	)
+
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.Trace = true
+
+	startedAt := time.Now()
+
+	resp, err := router.Chat(context.Background(), req)
+	require.NoError(t, err)
+	require.Less(t, time.Since(startedAt), time.Second)
+	require.Equal(t, "second", resp.ModelID)
+	require.Equal(t, "2", resp.ModelResponse.Message.Content)
+
+	attempts := resp.Routing.Attempts
+	require.Len(t, attempts, 2)
+	require.Equal(t, "first", attempts[0].ModelID)
+	require.Equal(t, ErrHedgeLost.Error(), attempts[0].Error)
+	require.Equal(t, "second", attempts[1].ModelID)
+	require.Equal(t, "hedging the slow first model", attempts[1].Reason)
+
+	// the cancelled request doesn't count against the model health
+	require.True(t, router.chatModels[0].Healthy())
+}
+
+func TestLangRouter_Chat_NoHedgeForFastModel(t *testing.T) {
+	router := newHedgingRouter(
+		[]ptesting.RespMock{{Msg: "1"}},
+		[]ptesting.RespMock{},
+	)
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+}
+
+func TestLangRouter_Chat_HedgedModelsFail(t *testing.T) {
+	router := newHedgingRouter(
+		[]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable, Delay: 50 * time.Millisecond}, {Msg: "1"}},
+		[]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}, {Msg: "2"}},
+	)
+
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.Trace = true
+
+	// both models fail the first round, so the router retries
+	resp, err := router.Chat(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+
+	attempts := resp.Routing.Attempts
+	require.Len(t, attempts, 3)
+	require.Equal(t, "second", attempts[1].ModelID)
+	require.NotEmpty(t, attempts[1].Error)
+	require.NotEmpty(t, attempts[0].Error)
+}
+
+func TestLangRouter_Chat_HedgedModelPanics(t *testing.T) {
+	router := newHedgingRouter(
+		[]ptesting.RespMock{{Msg: "1", Delay: 5 * time.Second}},
+		[]ptesting.RespMock{{Panic: "index out of range"}},
+	)
+
+	var recovered any
+
+	// the panic reaches the request goroutine instead of crashing the gateway
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+
+		_, _ = router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	}()
+
+	modelPanic, ok := recovered.(*ModelPanic)
+	require.True(t, ok)
+	require.Equal(t, "second", modelPanic.ModelID)
+	require.Equal(t, "index out of range", modelPanic.Value)
+}
+
+func TestHedger_Delay(t *testing.T) {
+	hedger := NewHedger("test_router", newHedgingConfig(10*time.Millisecond, 200*time.Millisecond), telemetry.NewTelemetryMock())
+	model := providers.NewLangModel("first", ptesting.NewProviderMock(nil), health.NewErrorBudget(3, health.SEC), *latency.DefaultConfig(), 1)
+
+	// the model latency is not known yet
+	require.Equal(t, 200*time.Millisecond, hedger.Delay(model))
+
+	for range hedgingWarmupSamples + 1 {
+		hedger.Track(model, 50*time.Millisecond)
+	}
+
+	require.Equal(t, 50*time.Millisecond, hedger.Delay(model))
+
+	for range hedgingWindow {
+		hedger.Track(model, time.Millisecond)
+	}
+
+	require.Equal(t, 10*time.Millisecond, hedger.Delay(model))
+
+	for range hedgingWindow {
+		hedger.Track(model, time.Second)
+	}
+
+	require.Equal(t, 200*time.Millisecond, hedger.Delay(model))
+}
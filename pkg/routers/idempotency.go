@@ -0,0 +1,219 @@
+package routers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/cache"
+	"glide/pkg/config/fields"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// IdempotencyKeyHeader lets clients retry chat requests without them being served (and charged) twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+
+// IdempotencyConfig defines how long chat responses are kept for retries with the same idempotency key.
+// Responses are kept in memory unless Redis or SQLite is configured
+type IdempotencyConfig struct {
+	TTL        *fields.Duration    `yaml:"ttl" json:"ttl" swaggertype:"primitive,string"`
+	MaxEntries int                 `yaml:"max_entries" json:"max_entries" validate:"gte=0"` // the in-memory store size, zero means no limit
+	MaxBytes   int64               `yaml:"max_bytes" json:"max_bytes" validate:"gte=0"`     // the in-memory store memory limit, zero means no limit
+	Redis      *cache.RedisConfig  `yaml:"redis,omitempty" json:"redis,omitempty"`
+	SQLite     *cache.SQLiteConfig `yaml:"sqlite,omitempty" json:"sqlite,omitempty"`
+}
+
+func DefaultIdempotencyConfig() *IdempotencyConfig {
+	defaultTTL := 24 * time.Hour
+
+	return &IdempotencyConfig{
+		TTL:        (*fields.Duration)(&defaultTTL),
+		MaxEntries: 10_000,
+		MaxBytes:   128 << 20, // 128MiB
+	}
+}
+
+func (c *IdempotencyConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultIdempotencyConfig()
+
+	type plain IdempotencyConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// idempotentResponse is the chat response as it's kept in the store together with the request it was served for
+type idempotentResponse struct {
+	RequestHash string                `json:"requestHash"`
+	Response    *schemas.ChatResponse `json:"response"`
+}
+
+// Idempotency serves retried chat requests with the response of the first request sent with the same key.
+// Retries that arrive while the first request is in flight wait for it instead of reaching models.
+// Failed requests are not remembered, so they could be retried
+type Idempotency struct {
+	routerID RouterID
+	store    cache.Store
+	ttl      time.Duration
+	mu       sync.Mutex
+	inFlight map[string]chan struct{}
+	replays  *telemetry.Counter
+	errors   *telemetry.Counter
+	logger   *zap.Logger
+}
+
+func NewIdempotency(routerID RouterID, cfg *IdempotencyConfig, tel *telemetry.Telemetry) (*Idempotency, error) {
+	store, err := newStore(routerID, "idempotency", cfg.MaxEntries, cfg.MaxBytes, cfg.Redis, cfg.SQLite, tel)
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl time.Duration
+
+	if cfg.TTL != nil {
+		ttl = time.Duration(*cfg.TTL)
+	}
+
+	return &Idempotency{
+		routerID: routerID,
+		store:    store,
+		ttl:      ttl,
+		inFlight: make(map[string]chan struct{}),
+		replays:  tel.M().Counter(fmt.Sprintf("routers.%v.idempotency.replays", routerID)),
+		errors:   tel.M().Counter(fmt.Sprintf("routers.%v.idempotency.errors", routerID)),
+		logger:   tel.L().With(zap.String("routerID", routerID)),
+	}, nil
+}
+
+// Chat serves the request with the chat func unless a response to the request with the same key is stored.
+// Keys are scoped by the caller, so clients that happen to pick the same key never get each other's responses
+func (i *Idempotency) Chat(
+	ctx context.Context,
+	idempotencyKey string,
+	req *schemas.ChatRequest,
+	chat func(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error),
+) (*schemas.ChatResponse, error) {
+	key := fmt.Sprintf("idempotency:%v:%v:%v", i.routerID, requestCaller(ctx), idempotencyKey)
+	requestHash := i.requestHash(req)
+
+	for {
+		if stored := i.get(ctx, key); stored != nil {
+			if stored.RequestHash != requestHash {
+				return nil, ErrIdempotencyKeyReused
+			}
+
+			i.replays.Inc()
+
+			stored.Response.Replayed = true
+
+			return stored.Response, nil
+		}
+
+		doneC, first := i.join(key)
+		if first {
+			break
+		}
+
+		select {
+		case <-doneC:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	defer i.leave(key)
+
+	resp, err := chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	i.set(ctx, key, &idempotentResponse{RequestHash: requestHash, Response: resp})
+
+	return resp, nil
+}
+
+// Close releases the store
+func (i *Idempotency) Close() error {
+	return i.store.Close()
+}
+
+// join marks the key as in flight. If it's in flight already, the returned channel is closed once it's done
+func (i *Idempotency) join(key string) (<-chan struct{}, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if doneC, found := i.inFlight[key]; found {
+		return doneC, false
+	}
+
+	i.inFlight[key] = make(chan struct{})
+
+	return nil, true
+}
+
+func (i *Idempotency) leave(key string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	close(i.inFlight[key])
+	delete(i.inFlight, key)
+}
+
+func (i *Idempotency) requestHash(req *schemas.ChatRequest) string {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return ""
+	}
+
+	contentHash := sha256.Sum256(body)
+
+	return hex.EncodeToString(contentHash[:])
+}
+
+func (i *Idempotency) get(ctx context.Context, key string) *idempotentResponse {
+	value, found, err := i.store.Get(ctx, key)
+	if err != nil {
+		i.errors.Inc()
+		i.logger.Warn("failed to read from idempotency store", zap.Error(err))
+
+		return nil
+	}
+
+	if !found {
+		return nil
+	}
+
+	var stored idempotentResponse
+
+	if err := json.Unmarshal(value, &stored); err != nil {
+		i.errors.Inc()
+		i.logger.Warn("failed to decode stored chat response", zap.Error(err))
+
+		return nil
+	}
+
+	return &stored
+}
+
+func (i *Idempotency) set(ctx context.Context, key string, stored *idempotentResponse) {
+	value, err := json.Marshal(stored)
+	if err != nil {
+		i.errors.Inc()
+
+		return
+	}
+
+	if err := i.store.Set(ctx, key, value, i.ttl); err != nil {
+		i.errors.Inc()
+		i.logger.Warn("failed to write to idempotency store", zap.Error(err))
+	}
+}
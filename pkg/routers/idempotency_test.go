@@ -0,0 +1,174 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+// countingChat serves chat requests after the delay and counts them
+func countingChat(calls *atomic.Int32, delay time.Duration) func(context.Context, *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	return func(_ context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+		calls.Add(1)
+		time.Sleep(delay)
+
+		return &schemas.ChatResponse{ModelID: "served", ModelResponse: schemas.ModelResponse{Message: req.Message}}, nil
+	}
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	ctx := context.Background()
+	idempotency, err := NewIdempotency("router", DefaultIdempotencyConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+
+	resp, err := idempotency.Chat(ctx, "key", schemas.NewChatFromStr("hello"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Replayed)
+
+	resp, err = idempotency.Chat(ctx, "key", schemas.NewChatFromStr("hello"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.True(t, resp.Replayed)
+	require.Equal(t, "hello", resp.ModelResponse.Message.Content)
+
+	// other keys are served as usual
+	resp, err = idempotency.Chat(ctx, "another-key", schemas.NewChatFromStr("hello"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Replayed)
+
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotency_KeysAreScopedByCaller(t *testing.T) {
+	idempotency, err := NewIdempotency("router", DefaultIdempotencyConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+
+	firstCtx := WithCaller(context.Background(), "key:first")
+	secondCtx := WithCaller(context.Background(), "key:second")
+
+	resp, err := idempotency.Chat(firstCtx, "key", schemas.NewChatFromStr("my secret"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.Equal(t, "my secret", resp.ModelResponse.Message.Content)
+
+	// another caller picking the same key is served its own response
+	resp, err = idempotency.Chat(secondCtx, "key", schemas.NewChatFromStr("hello"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Replayed)
+	require.Equal(t, "hello", resp.ModelResponse.Message.Content)
+
+	resp, err = idempotency.Chat(firstCtx, "key", schemas.NewChatFromStr("my secret"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.True(t, resp.Replayed)
+	require.Equal(t, "my secret", resp.ModelResponse.Message.Content)
+
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotency_KeyReusedForAnotherRequest(t *testing.T) {
+	ctx := context.Background()
+	idempotency, err := NewIdempotency("router", DefaultIdempotencyConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+
+	_, err = idempotency.Chat(ctx, "key", schemas.NewChatFromStr("hello"), countingChat(&calls, 0))
+	require.NoError(t, err)
+
+	_, err = idempotency.Chat(ctx, "key", schemas.NewChatFromStr("bye"), countingChat(&calls, 0))
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+}
+
+func TestIdempotency_FailuresAreNotRemembered(t *testing.T) {
+	ctx := context.Background()
+	idempotency, err := NewIdempotency("router", DefaultIdempotencyConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	errProvider := errors.New("provider is down")
+
+	_, err = idempotency.Chat(ctx, "key", schemas.NewChatFromStr("hello"), func(context.Context, *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+		return nil, errProvider
+	})
+	require.ErrorIs(t, err, errProvider)
+
+	var calls atomic.Int32
+
+	resp, err := idempotency.Chat(ctx, "key", schemas.NewChatFromStr("hello"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Replayed)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_RetriesWaitForFirstRequest(t *testing.T) {
+	ctx := context.Background()
+	idempotency, err := NewIdempotency("router", DefaultIdempotencyConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+
+	firstC := make(chan *schemas.ChatResponse, 1)
+
+	go func() {
+		resp, _ := idempotency.Chat(ctx, "key", schemas.NewChatFromStr("hello"), countingChat(&calls, 100*time.Millisecond))
+		firstC <- resp
+	}()
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	resp, err := idempotency.Chat(ctx, "key", schemas.NewChatFromStr("hello"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.True(t, resp.Replayed)
+	require.False(t, (<-firstC).Replayed)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestLangRouter_Chat_IdempotencyKey(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}), budget, *latConfig, 1),
+	}
+
+	idempotency, err := NewIdempotency("test_router", DefaultIdempotencyConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	router := &LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{RoutingStrategy: routing.Priority},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority([]providers.Model{langModels[0]}),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		idempotency:      idempotency,
+		tel:              telemetry.NewTelemetryMock(),
+		logger:           telemetry.NewLoggerMock(),
+	}
+
+	ctx := WithRequestHeaders(context.Background(), map[string][]string{IdempotencyKeyHeader: {"retry-me"}})
+
+	for range 2 {
+		resp, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+		require.NoError(t, err)
+		require.Equal(t, "1", resp.ModelResponse.Message.Content)
+	}
+
+	// requests without the key are not deduplicated
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "2", resp.ModelResponse.Message.Content)
+}
@@ -0,0 +1,38 @@
+package routers
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+)
+
+// ImageRouter routes image generation requests across image models
+type ImageRouter struct {
+	*singleActionRouter[*schemas.ImageGenerateRequest, *schemas.ImageGenerateResponse]
+	Config *ImageRouterConfig
+}
+
+func NewImageRouter(cfg *ImageRouterConfig, tel *telemetry.Telemetry) (*ImageRouter, error) {
+	models, err := cfg.BuildModels(tel)
+	if err != nil {
+		return nil, err
+	}
+
+	modelRouting, err := cfg.BuildRouting(models)
+	if err != nil {
+		return nil, err
+	}
+
+	router := &ImageRouter{
+		singleActionRouter: newSingleActionRouter(cfg.ID, providers.ImageGenerateAction, models, modelRouting, cfg.BuildRetry(), tel),
+		Config:             cfg,
+	}
+
+	return router, err
+}
+
+func (r *ImageRouter) Generate(ctx context.Context, req *schemas.ImageGenerateRequest) (*schemas.ImageGenerateResponse, error) {
+	return r.serve(ctx, req)
+}
@@ -0,0 +1,65 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestImageRouter_Generate_FallbackToHealthy(t *testing.T) {
+	budget := health.NewErrorBudget(1, health.MIN)
+	latConfig := latency.DefaultConfig()
+
+	imageModels := []*providers.ImageModel{
+		providers.NewImageModel(
+			"first",
+			ptesting.NewImageProviderMock([]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}, {Msg: "3"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+		providers.NewImageModel(
+			"second",
+			ptesting.NewImageProviderMock([]ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(imageModels))
+	for _, model := range imageModels {
+		models = append(models, model)
+	}
+
+	router := ImageRouter{
+		singleActionRouter: newSingleActionRouter(
+			"test_router",
+			providers.ImageGenerateAction,
+			imageModels,
+			routing.NewPriority(models),
+			retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+			telemetry.NewTelemetryMock(),
+		),
+		Config: &ImageRouterConfig{},
+	}
+
+	for _, modelID := range []string{"second", "second"} {
+		resp, err := router.Generate(context.Background(), schemas.NewImageGenerateFromStr("a lighthouse"))
+
+		require.NoError(t, err)
+		require.Equal(t, modelID, resp.ModelID)
+		require.Equal(t, "test_router", resp.RouterID)
+	}
+}
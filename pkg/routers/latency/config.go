@@ -11,6 +11,7 @@ type Config struct {
 	Decay          float64          `yaml:"decay" json:"decay"`                                                              // Weight of new latency measurements
 	WarmupSamples  uint8            `yaml:"warmup_samples" json:"warmup_samples"`                                            // The number of latency probes required to init moving average
 	UpdateInterval *fields.Duration `yaml:"update_interval,omitempty" json:"update_interval" swaggertype:"primitive,string"` // How often gateway should probe models with not the lowest response latency
+	Window         int              `yaml:"window" json:"window"`                                                            // The number of the latest latency samples percentiles are estimated over
 }
 
 func DefaultConfig() *Config {
@@ -20,5 +21,6 @@ func DefaultConfig() *Config {
 		Decay:          0.06,
 		WarmupSamples:  3,
 		UpdateInterval: (*fields.Duration)(&defaultUpdateInterval),
+		Window:         100,
 	}
 }
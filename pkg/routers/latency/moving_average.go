@@ -11,6 +11,8 @@ type MovingAverage struct {
 	value float64
 	// The number of samples added to this instance.
 	count uint8
+	// The number of samples added to this instance over its lifetime
+	samples uint64
 	// The number of samples required to start estimating average
 	warmupSamples uint8
 }
@@ -30,6 +32,8 @@ func (e *MovingAverage) Add(value float64) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	e.samples++
+
 	switch {
 	case e.count < e.warmupSamples:
 		e.count++
@@ -50,6 +54,14 @@ func (e *MovingAverage) WarmedUp() bool {
 	return e.count > e.warmupSamples
 }
 
+// Samples returns the number of samples added to the series
+func (e *MovingAverage) Samples() uint64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.samples
+}
+
 // Value returns the current value of the average, or 0.0 if the series hasn't
 // warmed up yet
 func (e *MovingAverage) Value() float64 {
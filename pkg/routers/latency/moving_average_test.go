@@ -35,3 +35,13 @@ func TestMovingAverage_SetValue(t *testing.T) {
 	require.True(t, movingAverage.WarmedUp())
 	require.InDelta(t, 200.0, movingAverage.Value(), 0.0001)
 }
+
+func TestMovingAverage_Samples(t *testing.T) {
+	movingAverage := NewMovingAverage(0.9, 1)
+
+	for range 5 {
+		movingAverage.Add(100)
+	}
+
+	require.Equal(t, uint64(5), movingAverage.Samples())
+}
@@ -0,0 +1,128 @@
+package latency
+
+import (
+	"math"
+	"slices"
+	"sync"
+)
+
+// Estimator estimates the latency of the model out of observed samples
+type Estimator interface {
+	WarmedUp() bool
+	Value() float64
+	Samples() uint64
+}
+
+// Quantiles estimates latency percentiles over a sliding window of the latest samples.
+// Unlike the moving average, percentiles don't hide tail latency.
+// The window keeps the estimation responsive to changes in the latency distribution the same way decay does for the average
+type Quantiles struct {
+	mu sync.RWMutex
+	// The latest samples in the ring buffer
+	samples []float64
+	// The position in the ring buffer the next sample goes to
+	next int
+	// The number of samples added to this instance (capped by the window size)
+	count int
+	// The number of samples added to this instance over its lifetime
+	total uint64
+	// The number of samples required to start estimating percentiles
+	warmupSamples uint8
+	// Samples sorted on demand, reset when a new sample is added
+	sorted []float64
+}
+
+func NewQuantiles(window int, warmupSamples uint8) *Quantiles {
+	return &Quantiles{
+		samples:       make([]float64, max(window, 1)),
+		warmupSamples: warmupSamples,
+	}
+}
+
+// Add a value to the window evicting the oldest one if the window is full
+func (q *Quantiles) Add(value float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.samples[q.next] = value
+	q.next = (q.next + 1) % len(q.samples)
+	q.count = min(q.count+1, len(q.samples))
+	q.total++
+	q.sorted = nil
+}
+
+// Samples returns the number of samples added over the lifetime of the window
+func (q *Quantiles) Samples() uint64 {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.total
+}
+
+func (q *Quantiles) WarmedUp() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.count > int(q.warmupSamples)
+}
+
+// Quantile returns the value below which the given share (in [0, 1]) of samples fall, or 0.0 if the window hasn't
+// warmed up yet. Values between samples are linearly interpolated
+func (q *Quantiles) Quantile(quantile float64) float64 {
+	if !q.WarmedUp() {
+		return 0.0
+	}
+
+	sorted := q.sortedSamples()
+
+	rank := math.Min(math.Max(quantile, 0), 1) * float64(len(sorted)-1)
+	lowerIdx := int(math.Floor(rank))
+	upperIdx := int(math.Ceil(rank))
+
+	return sorted[lowerIdx] + (sorted[upperIdx]-sorted[lowerIdx])*(rank-float64(lowerIdx))
+}
+
+// Percentile returns an estimator of the given percentile (e.g. 95 or 99)
+func (q *Quantiles) Percentile(percentile float64) Estimator {
+	return &percentileEstimator{
+		quantiles: q,
+		quantile:  percentile / 100,
+	}
+}
+
+func (q *Quantiles) sortedSamples() []float64 {
+	q.mu.RLock()
+	sorted := q.sorted
+	q.mu.RUnlock()
+
+	if sorted != nil {
+		return sorted
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.sorted == nil {
+		q.sorted = slices.Clone(q.samples[:q.count])
+		slices.Sort(q.sorted)
+	}
+
+	return q.sorted
+}
+
+type percentileEstimator struct {
+	quantiles *Quantiles
+	quantile  float64
+}
+
+func (e *percentileEstimator) WarmedUp() bool {
+	return e.quantiles.WarmedUp()
+}
+
+func (e *percentileEstimator) Samples() uint64 {
+	return e.quantiles.Samples()
+}
+
+func (e *percentileEstimator) Value() float64 {
+	return e.quantiles.Quantile(e.quantile)
+}
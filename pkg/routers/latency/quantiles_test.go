@@ -0,0 +1,44 @@
+package latency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantiles_WarmUpAndPercentiles(t *testing.T) {
+	quantiles := NewQuantiles(100, 3)
+
+	for _, latency := range []float64{10, 20, 30} {
+		quantiles.Add(latency)
+
+		require.False(t, quantiles.WarmedUp())
+		require.InDelta(t, 0.0, quantiles.Quantile(0.5), 0.0001)
+	}
+
+	for latency := 4; latency <= 100; latency++ {
+		quantiles.Add(float64(latency * 10))
+	}
+
+	require.True(t, quantiles.WarmedUp())
+	require.InDelta(t, 505.0, quantiles.Quantile(0.5), 0.0001)
+	require.InDelta(t, 950.5, quantiles.Percentile(95).Value(), 0.0001)
+	require.InDelta(t, 1000.0, quantiles.Quantile(1), 0.0001)
+}
+
+func TestQuantiles_SlidingWindow(t *testing.T) {
+	quantiles := NewQuantiles(4, 1)
+
+	for _, latency := range []float64{1000, 1000, 1000, 1000} {
+		quantiles.Add(latency)
+	}
+
+	require.InDelta(t, 1000.0, quantiles.Percentile(99).Value(), 0.0001)
+
+	// old tail latencies are evicted
+	for _, latency := range []float64{10, 20, 30, 40} {
+		quantiles.Add(latency)
+	}
+
+	require.InDelta(t, 39.7, quantiles.Percentile(99).Value(), 0.0001)
+}
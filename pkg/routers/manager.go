@@ -1,7 +1,12 @@
 package routers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
+
+	"glide/pkg/api/schemas"
 
 	"glide/pkg/telemetry"
 )
@@ -9,44 +14,294 @@ import (
 var ErrRouterNotFound = errors.New("no router found with given ID")
 
 type RouterManager struct {
-	Config        *Config
-	tel           *telemetry.Telemetry
-	langRouterMap *map[string]*LangRouter
-	langRouters   []*LangRouter
+	mu                  sync.RWMutex
+	Config              *Config
+	tel                 *telemetry.Telemetry
+	langRouterMap       *map[string]*LangRouter
+	langRouters         []*LangRouter
+	imageRouterMap      *map[string]*ImageRouter
+	imageRouters        []*ImageRouter
+	audioRouterMap      *map[string]*AudioRouter
+	audioRouters        []*AudioRouter
+	moderationRouterMap *map[string]*ModerationRouter
+	moderationRouters   []*ModerationRouter
+	metadataCache       *MetadataCache
+	lastReload          *schemas.ConfigReload
 }
 
 // NewManager creates a new instance of Router Manager that creates, holds and returns all routers
 func NewManager(cfg *Config, tel *telemetry.Telemetry) (*RouterManager, error) {
-	langRouters, err := cfg.BuildLangRouters(tel)
-	if err != nil {
+	tel = tel.Module(telemetry.ModuleRouting)
+
+	metadataConfig := cfg.Metadata
+	if metadataConfig == nil || metadataConfig.TTL == nil {
+		metadataConfig = DefaultMetadataConfig()
+	}
+
+	manager := &RouterManager{
+		Config:        cfg,
+		tel:           tel,
+		metadataCache: NewMetadataCache(metadataConfig),
+	}
+
+	if err := manager.build(); err != nil {
+		// routers built so far have started their background activities already
+		manager.Shutdown()
+
 		return nil, err
 	}
 
-	langRouterMap := make(map[string]*LangRouter, len(langRouters))
+	return manager, nil
+}
+
+// build creates all configured routers and wires the ones they refer to
+func (r *RouterManager) build() error {
+	var err error
+
+	r.langRouters, err = r.Config.BuildLangRouters(r.tel)
+	if err != nil {
+		return err
+	}
+
+	langRouterMap := make(map[string]*LangRouter, len(r.langRouters))
 
-	for _, router := range langRouters {
+	for _, router := range r.langRouters {
 		langRouterMap[router.ID()] = router
 	}
 
-	manager := RouterManager{
-		Config:        cfg,
-		tel:           tel,
-		langRouters:   langRouters,
-		langRouterMap: &langRouterMap,
+	r.langRouterMap = &langRouterMap
+
+	resolving := make(map[string]struct{})
+
+	for _, router := range r.langRouters {
+		if err := withNestedRouters(router, langRouterMap, resolving); err != nil {
+			return err
+		}
+	}
+
+	r.imageRouters, err = r.Config.BuildImageRouters(r.tel)
+	if err != nil {
+		return err
+	}
+
+	imageRouterMap := make(map[string]*ImageRouter, len(r.imageRouters))
+
+	for _, router := range r.imageRouters {
+		imageRouterMap[router.ID()] = router
+	}
+
+	r.imageRouterMap = &imageRouterMap
+
+	r.audioRouters, err = r.Config.BuildAudioRouters(r.tel)
+	if err != nil {
+		return err
+	}
+
+	audioRouterMap := make(map[string]*AudioRouter, len(r.audioRouters))
+
+	for _, router := range r.audioRouters {
+		audioRouterMap[router.ID()] = router
+	}
+
+	r.audioRouterMap = &audioRouterMap
+
+	r.moderationRouters, err = r.Config.BuildModerationRouters(r.tel)
+	if err != nil {
+		return err
+	}
+
+	moderationRouterMap := make(map[string]*ModerationRouter, len(r.moderationRouters))
+
+	for _, router := range r.moderationRouters {
+		moderationRouterMap[router.ID()] = router
+	}
+
+	r.moderationRouterMap = &moderationRouterMap
+
+	for _, router := range r.langRouters {
+		if err := withGuardrail(router, moderationRouterMap); err != nil {
+			return err
+		}
 	}
 
-	return &manager, err
+	return nil
+}
+
+// EffectiveConfig returns a copy of the routers config the manager currently runs with (including runtime changes)
+func (r *RouterManager) EffectiveConfig() Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cfg := *r.Config
+	cfg.LanguageRouters = append([]LangRouterConfig(nil), r.Config.LanguageRouters...)
+
+	return cfg
+}
+
+// withGuardrail wires the moderation router the language router guardrail refers to (if any)
+func withGuardrail(router *LangRouter, moderationRouterMap map[string]*ModerationRouter) error {
+	guardrailConfig := router.Config.Guardrail
+	if guardrailConfig == nil {
+		return nil
+	}
+
+	moderator, found := moderationRouterMap[guardrailConfig.Moderation]
+	if !found {
+		return fmt.Errorf(
+			"router \"%v\" guardrail refers to moderation router \"%v\" which is not found or disabled",
+			router.ID(),
+			guardrailConfig.Moderation,
+		)
+	}
+
+	router.WithGuardrail(moderator)
+
+	return nil
 }
 
 func (r *RouterManager) GetLangRouters() []*LangRouter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	return r.langRouters
 }
 
+// QueueDepth returns the number of requests waiting in queues of all language routers
+func (r *RouterManager) QueueDepth() int {
+	depth := 0
+
+	for _, router := range r.GetLangRouters() {
+		if stats := router.queue.Stats(); stats != nil {
+			depth += stats.Depth
+		}
+	}
+
+	return depth
+}
+
 // GetLangRouter returns a router by type and ID
 func (r *RouterManager) GetLangRouter(routerID string) (*LangRouter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	if router, found := (*r.langRouterMap)[routerID]; found {
 		return router, nil
 	}
 
 	return nil, ErrRouterNotFound
 }
+
+func (r *RouterManager) GetImageRouters() []*ImageRouter {
+	return r.imageRouters
+}
+
+// GetImageRouter returns an image router by ID
+func (r *RouterManager) GetImageRouter(routerID string) (*ImageRouter, error) {
+	if router, found := (*r.imageRouterMap)[routerID]; found {
+		return router, nil
+	}
+
+	return nil, ErrRouterNotFound
+}
+
+func (r *RouterManager) GetAudioRouters() []*AudioRouter {
+	return r.audioRouters
+}
+
+// GetAudioRouter returns an audio router by ID
+func (r *RouterManager) GetAudioRouter(routerID string) (*AudioRouter, error) {
+	if router, found := (*r.audioRouterMap)[routerID]; found {
+		return router, nil
+	}
+
+	return nil, ErrRouterNotFound
+}
+
+func (r *RouterManager) GetModerationRouters() []*ModerationRouter {
+	return r.moderationRouters
+}
+
+// GetModerationRouter returns a moderation router by ID
+func (r *RouterManager) GetModerationRouter(routerID string) (*ModerationRouter, error) {
+	if router, found := (*r.moderationRouterMap)[routerID]; found {
+		return router, nil
+	}
+
+	return nil, ErrRouterNotFound
+}
+
+// Shutdown stops background activities of all routers
+func (r *RouterManager) Shutdown() {
+	for _, router := range r.GetLangRouters() {
+		router.Shutdown()
+	}
+
+	for _, router := range r.imageRouters {
+		router.Shutdown()
+	}
+
+	for _, router := range r.audioRouters {
+		router.Shutdown()
+	}
+
+	for _, router := range r.moderationRouters {
+		router.Shutdown()
+	}
+}
+
+// GetModelMetadata returns cached capabilities & upstream models of the router models
+func (r *RouterManager) GetModelMetadata(ctx context.Context, routerID string) ([]schemas.ModelMetadata, error) {
+	router, err := r.GetLangRouter(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.metadataCache.Get(ctx, router), nil
+}
+
+// RefreshModelMetadata re-fetches capabilities & upstream models of the router models
+func (r *RouterManager) RefreshModelMetadata(ctx context.Context, routerID string) ([]schemas.ModelMetadata, error) {
+	router, err := r.GetLangRouter(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.metadataCache.Refresh(ctx, router), nil
+}
+
+// Feedback passes the client feedback on the chat response to the language router
+func (r *RouterManager) Feedback(routerID string, feedback *schemas.ChatFeedback) error {
+	router, err := r.GetLangRouter(routerID)
+	if err != nil {
+		return err
+	}
+
+	return router.Feedback(feedback)
+}
+
+// CountTokens counts prompt tokens for the router model adding the model context length from the cached model metadata
+func (r *RouterManager) CountTokens(ctx context.Context, routerID string, req *schemas.TokenizeRequest) (*schemas.TokenizeResponse, error) {
+	router, err := r.GetLangRouter(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := router.CountTokens(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, modelMetadata := range r.metadataCache.Get(ctx, router) {
+		if modelMetadata.ModelID != resp.ModelID {
+			continue
+		}
+
+		for _, upstreamModel := range modelMetadata.UpstreamModels {
+			if upstreamModel.ID == resp.ModelName {
+				resp.ContextLength = upstreamModel.ContextLength
+			}
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,183 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers/clients"
+)
+
+// MetadataConfig defines how long upstream model lists & capabilities are kept before being re-fetched
+type MetadataConfig struct {
+	TTL *fields.Duration `yaml:"ttl" json:"ttl" swaggertype:"primitive,string"`
+}
+
+func DefaultMetadataConfig() *MetadataConfig {
+	defaultTTL := 1 * time.Hour
+
+	return &MetadataConfig{
+		TTL: (*fields.Duration)(&defaultTTL),
+	}
+}
+
+// metadataErrorTTL is how long metadata with upstream errors is kept, so transient errors are retried soon
+const metadataErrorTTL = 30 * time.Second
+
+type metadataEntry struct {
+	metadata  []schemas.ModelMetadata
+	expiresAt time.Time
+}
+
+// metadataFetch is the metadata fetch in flight that concurrent requests wait for instead of fetching it again
+type metadataFetch struct {
+	done     chan struct{}
+	metadata []schemas.ModelMetadata
+}
+
+// MetadataCache caches upstream model lists and provider capabilities per router,
+// so discovery doesn't add latency or rate-limit pressure to each request.
+// The model health changes all the time, so it's never cached
+type MetadataCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[RouterID]*metadataEntry
+	fetches map[RouterID]*metadataFetch
+}
+
+func NewMetadataCache(cfg *MetadataConfig) *MetadataCache {
+	return &MetadataCache{
+		ttl:     time.Duration(*cfg.TTL),
+		entries: make(map[RouterID]*metadataEntry),
+		fetches: make(map[RouterID]*metadataFetch),
+	}
+}
+
+// Get returns cached router model metadata fetching it if it's missing or expired
+func (c *MetadataCache) Get(ctx context.Context, router *LangRouter) []schemas.ModelMetadata {
+	c.mu.Lock()
+	entry, found := c.entries[router.ID()]
+	c.mu.Unlock()
+
+	if found && time.Now().Before(entry.expiresAt) {
+		return withModelHealth(router, entry.metadata)
+	}
+
+	return c.Refresh(ctx, router)
+}
+
+// Refresh re-fetches router model metadata regardless of its expiration.
+// Requests that come while metadata is being fetched share the fetch
+func (c *MetadataCache) Refresh(ctx context.Context, router *LangRouter) []schemas.ModelMetadata {
+	c.mu.Lock()
+
+	fetch, inFlight := c.fetches[router.ID()]
+	if !inFlight {
+		fetch = &metadataFetch{done: make(chan struct{})}
+		c.fetches[router.ID()] = fetch
+	}
+
+	c.mu.Unlock()
+
+	if !inFlight {
+		// the fetch is shared, so it's not cancelled when the request that has started it is
+		go c.fetch(context.WithoutCancel(ctx), router, fetch)
+	}
+
+	select {
+	case <-fetch.done:
+		return withModelHealth(router, fetch.metadata)
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (c *MetadataCache) fetch(ctx context.Context, router *LangRouter, fetch *metadataFetch) {
+	fetch.metadata = router.ModelMetadata(ctx)
+
+	ttl := c.ttl
+
+	for _, modelMetadata := range fetch.metadata {
+		if modelMetadata.Error != "" {
+			ttl = min(ttl, metadataErrorTTL)
+			break
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the router may have been invalidated while its metadata was being fetched
+	if c.fetches[router.ID()] == fetch {
+		delete(c.fetches, router.ID())
+
+		c.entries[router.ID()] = &metadataEntry{
+			metadata:  fetch.metadata,
+			expiresAt: time.Now().Add(ttl),
+		}
+	}
+
+	close(fetch.done)
+}
+
+// Invalidate drops the cached router model metadata, so it's fetched again on the next request
+func (c *MetadataCache) Invalidate(routerID RouterID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, routerID)
+	delete(c.fetches, routerID)
+}
+
+// withModelHealth copies cached metadata filling in the current health of the router models
+func withModelHealth(router *LangRouter, metadata []schemas.ModelMetadata) []schemas.ModelMetadata {
+	healthy := make(map[string]bool, len(router.chatModels))
+
+	for _, model := range router.chatModels {
+		healthy[model.ID()] = model.Healthy()
+	}
+
+	metadata = slices.Clone(metadata)
+
+	for idx := range metadata {
+		metadata[idx].Healthy = healthy[metadata[idx].ModelID]
+	}
+
+	return metadata
+}
+
+// ModelMetadata collects capabilities & upstream models of all router models
+func (r *LangRouter) ModelMetadata(ctx context.Context) []schemas.ModelMetadata {
+	metadata := make([]schemas.ModelMetadata, 0, len(r.chatModels))
+	fetchedAt := int(time.Now().UTC().Unix())
+
+	for _, model := range r.chatModels {
+		modelMetadata := schemas.ModelMetadata{
+			ModelID:      model.ID(),
+			Provider:     model.Provider(),
+			Healthy:      model.Healthy(),
+			Capabilities: model.Capabilities(),
+			FetchedAt:    fetchedAt,
+			Deprecation:  model.Deprecation(),
+		}
+
+		upstreamModels, err := model.ListModels(ctx)
+
+		switch {
+		case errors.Is(err, clients.ErrModelListNotImplemented):
+			// nothing to add
+		case err != nil:
+			modelMetadata.Error = err.Error()
+		default:
+			modelMetadata.UpstreamModels = upstreamModels
+		}
+
+		metadata = append(metadata, modelMetadata)
+	}
+
+	return metadata
+}
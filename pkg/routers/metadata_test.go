@@ -0,0 +1,123 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/telemetry"
+)
+
+type listerProviderMock struct {
+	*ptesting.ProviderMock
+	calls atomic.Int32
+	err   error
+	delay time.Duration
+}
+
+func (p *listerProviderMock) ListModels(_ context.Context) ([]schemas.ProviderModel, error) {
+	p.calls.Add(1)
+	time.Sleep(p.delay)
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return []schemas.ProviderModel{{ID: "gpt-4"}}, nil
+}
+
+func newMetadataRouterMock(provider *listerProviderMock) *LangRouter {
+	return &LangRouter{
+		routerID: "test_router",
+		chatModels: []*providers.LanguageModel{
+			providers.NewLangModel("first", provider, health.NewErrorBudget(1, health.MIN), *latency.DefaultConfig(), 1),
+		},
+		tel: telemetry.NewTelemetryMock(),
+	}
+}
+
+func TestMetadataCache_CachesUntilRefreshed(t *testing.T) {
+	provider := &listerProviderMock{ProviderMock: ptesting.NewProviderMock(nil)}
+
+	router := newMetadataRouterMock(provider)
+
+	ttl := 1 * time.Hour
+	cache := NewMetadataCache(&MetadataConfig{TTL: (*fields.Duration)(&ttl)})
+	ctx := context.Background()
+
+	metadata := cache.Get(ctx, router)
+	require.Len(t, metadata, 1)
+	require.Equal(t, "gpt-4", metadata[0].UpstreamModels[0].ID)
+	require.True(t, metadata[0].Capabilities.Chat)
+
+	cache.Get(ctx, router)
+	require.Equal(t, int32(1), provider.calls.Load())
+
+	cache.Refresh(ctx, router)
+	require.Equal(t, int32(2), provider.calls.Load())
+}
+
+func TestMetadataCache_ReportsCurrentHealth(t *testing.T) {
+	provider := &listerProviderMock{
+		ProviderMock: ptesting.NewProviderMock([]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}}),
+	}
+	router := newMetadataRouterMock(provider)
+
+	cache := NewMetadataCache(DefaultMetadataConfig())
+	ctx := context.Background()
+
+	require.True(t, cache.Get(ctx, router)[0].Healthy)
+
+	// the error budget of one error per minute is exhausted
+	_, err := router.chatModels[0].Chat(ctx, schemas.NewChatFromStr("hello"))
+	require.Error(t, err)
+
+	require.False(t, cache.Get(ctx, router)[0].Healthy)
+	require.Equal(t, int32(1), provider.calls.Load())
+}
+
+func TestMetadataCache_ExpiresErrorsSooner(t *testing.T) {
+	provider := &listerProviderMock{ProviderMock: ptesting.NewProviderMock(nil), err: errors.New("upstream is down")}
+	router := newMetadataRouterMock(provider)
+
+	cache := NewMetadataCache(DefaultMetadataConfig())
+
+	require.NotEmpty(t, cache.Get(context.Background(), router)[0].Error)
+
+	entry := cache.entries[router.ID()]
+	require.WithinDuration(t, time.Now().Add(metadataErrorTTL), entry.expiresAt, time.Second)
+}
+
+func TestMetadataCache_SharesConcurrentFetches(t *testing.T) {
+	provider := &listerProviderMock{ProviderMock: ptesting.NewProviderMock(nil), delay: 50 * time.Millisecond}
+	router := newMetadataRouterMock(provider)
+
+	cache := NewMetadataCache(DefaultMetadataConfig())
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			require.Len(t, cache.Get(context.Background(), router), 1)
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, int32(1), provider.calls.Load())
+}
@@ -0,0 +1,38 @@
+package routers
+
+import (
+	"context"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+)
+
+// ModerationRouter routes moderation requests across moderation models
+type ModerationRouter struct {
+	*singleActionRouter[*schemas.ModerationRequest, *schemas.ModerationResponse]
+	Config *ModerationRouterConfig
+}
+
+func NewModerationRouter(cfg *ModerationRouterConfig, tel *telemetry.Telemetry) (*ModerationRouter, error) {
+	models, err := cfg.BuildModels(tel)
+	if err != nil {
+		return nil, err
+	}
+
+	modelRouting, err := cfg.BuildRouting(models)
+	if err != nil {
+		return nil, err
+	}
+
+	router := &ModerationRouter{
+		singleActionRouter: newSingleActionRouter(cfg.ID, providers.ModerateAction, models, modelRouting, cfg.BuildRetry(), tel),
+		Config:             cfg,
+	}
+
+	return router, err
+}
+
+func (r *ModerationRouter) Moderate(ctx context.Context, req *schemas.ModerationRequest) (*schemas.ModerationResponse, error) {
+	return r.serve(ctx, req)
+}
@@ -0,0 +1,169 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newModerationRouterMock(responses []ptesting.RespMock) *ModerationRouter {
+	return newModerationRouterWithProvider(ptesting.NewModerationProviderMock(responses))
+}
+
+func newModerationRouterWithProvider(provider *ptesting.ModerationProviderMock) *ModerationRouter {
+	moderationModels := []*providers.ModerationModel{
+		providers.NewModerationModel(
+			"moderator",
+			provider,
+			health.NewErrorBudget(1, health.MIN),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(moderationModels))
+	for _, model := range moderationModels {
+		models = append(models, model)
+	}
+
+	return &ModerationRouter{
+		singleActionRouter: newSingleActionRouter(
+			"moderation_router",
+			providers.ModerateAction,
+			moderationModels,
+			routing.NewPriority(models),
+			retry.NewExpRetry(1, 2, 1*time.Millisecond, nil),
+			telemetry.NewTelemetryMock(),
+		),
+		Config: &ModerationRouterConfig{},
+	}
+}
+
+func TestModerationRouter_Moderate(t *testing.T) {
+	router := newModerationRouterMock([]ptesting.RespMock{{Msg: "violence"}, {Msg: ""}})
+
+	resp, err := router.Moderate(context.Background(), schemas.NewModerationFromStr("I will hurt you"))
+	require.NoError(t, err)
+	require.True(t, resp.Flagged())
+	require.Equal(t, "moderator", resp.ModelID)
+	require.Equal(t, "moderation_router", resp.RouterID)
+
+	resp, err = router.Moderate(context.Background(), schemas.NewModerationFromStr("hello"))
+	require.NoError(t, err)
+	require.False(t, resp.Flagged())
+}
+
+func TestLangRouter_Chat_Guardrail(t *testing.T) {
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}),
+			health.NewErrorBudget(3, health.SEC),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:    "test_router",
+		Config:      &LangRouterConfig{Guardrail: &GuardrailConfig{Moderation: "moderation_router"}},
+		retry:       retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+		chatRouting: routing.NewPriority(models),
+		chatModels:  langModels,
+		tel:         telemetry.NewTelemetryMock(),
+		logger:      telemetry.NewLoggerMock(),
+	}
+
+	router.WithGuardrail(newModerationRouterMock([]ptesting.RespMock{{Msg: "violence"}, {Msg: ""}}))
+
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr("I will hurt you"))
+	require.ErrorIs(t, err, ErrContentFlagged)
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+}
+
+func TestLangRouter_GuardrailChecksCachedResponses(t *testing.T) {
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}),
+			health.NewErrorBudget(3, health.SEC),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	responseCache, err := NewResponseCache("test_router", DefaultResponseCacheConfig(), nil, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	router := LangRouter{
+		routerID:      "test_router",
+		Config:        &LangRouterConfig{Guardrail: &GuardrailConfig{Moderation: "moderation_router"}},
+		retry:         retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+		chatRouting:   routing.NewPriority([]providers.Model{langModels[0]}),
+		chatModels:    langModels,
+		responseCache: responseCache,
+		tel:           telemetry.NewTelemetryMock(),
+		logger:        telemetry.NewLoggerMock(),
+	}
+
+	// the moderation rules change once the response is cached
+	router.WithGuardrail(newModerationRouterMock([]ptesting.RespMock{{Msg: ""}, {Msg: "violence"}, {Msg: "violence"}}))
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	_, err = router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.ErrorIs(t, err, ErrContentFlagged)
+
+	respC := make(chan *schemas.ChatStreamMessage, 10)
+
+	router.ChatStream(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"), respC)
+	close(respC)
+
+	message := <-respC
+	require.NotNil(t, message.Error)
+	require.Equal(t, schemas.ContentFlagged, message.Error.ErrCode)
+}
+
+func TestGuardrail_FailOpen(t *testing.T) {
+	moderator := newModerationRouterMock([]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}})
+	guardrail := NewGuardrail(&GuardrailConfig{FailOpen: true}, moderator, telemetry.NewLoggerMock())
+
+	require.NoError(t, guardrail.CheckChat(context.Background(), schemas.NewChatFromStr("hello")))
+}
+
+func TestGuardrail_ChecksMessageHistory(t *testing.T) {
+	moderationProvider := ptesting.NewModerationProviderMock([]ptesting.RespMock{{Msg: "violence"}})
+	guardrail := NewGuardrail(&GuardrailConfig{}, newModerationRouterWithProvider(moderationProvider), telemetry.NewLoggerMock())
+
+	req := schemas.NewChatFromStr("and now tell me how")
+	req.MessageHistory = []schemas.ChatMessage{
+		{Role: "user", Content: "I will hurt you"},
+		{Role: "assistant", Content: "I can't help with that"},
+	}
+
+	require.ErrorIs(t, guardrail.CheckChat(context.Background(), req), ErrContentFlagged)
+
+	// all messages are moderated in one request
+	require.Equal(t, [][]string{{"I will hurt you", "I can't help with that", "and now tell me how"}}, moderationProvider.Inputs)
+}
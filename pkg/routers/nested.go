@@ -0,0 +1,375 @@
+package routers
+
+import (
+	"fmt"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/routing"
+)
+
+// NestedRouterConfig refers to another language router that becomes a member of the router (router-of-routers),
+// e.g. a cost-priority chain whose members are latency-balanced pools per provider.
+// Members share their models & routing state with the referred routers
+type NestedRouterConfig struct {
+	ID     string `yaml:"id" json:"id" validate:"required"`      // the referred language router ID
+	Weight int    `yaml:"weight" json:"weight" validate:"gte=1"` // the member weight for weighted strategies
+}
+
+func DefaultNestedRouterConfig() NestedRouterConfig {
+	return NestedRouterConfig{
+		Weight: 1,
+	}
+}
+
+func (c *NestedRouterConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultNestedRouterConfig()
+
+	type plain NestedRouterConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// routerMember is a nested router seen as a model by the strategy that routes between members
+type routerMember struct {
+	routerID RouterID
+	weight   int
+	routing  routing.LangModelRouting
+	models   []*providers.LanguageModel
+}
+
+func (m *routerMember) ID() string {
+	return m.routerID
+}
+
+// Healthy checks if any of the member models can serve requests
+func (m *routerMember) Healthy() bool {
+	return m.firstHealthy(nil) != nil
+}
+
+func (m *routerMember) Weight() int {
+	return m.weight
+}
+
+func (m *routerMember) LatencyUpdateInterval() *fields.Duration {
+	return m.models[0].LatencyUpdateInterval()
+}
+
+// next returns the model the member routing would pick next
+func (m *routerMember) next() providers.Model {
+	if peeker, ok := m.routing.(routing.ModelPeeker); ok {
+		if model, err := peeker.Peek(); err == nil {
+			return model
+		}
+	}
+
+	if model := m.firstHealthy(nil); model != nil {
+		return model
+	}
+
+	return m.models[0]
+}
+
+func (m *routerMember) firstHealthy(returned map[string]struct{}) providers.Model {
+	for _, model := range m.models {
+		if _, found := returned[model.ID()]; !found && model.Healthy() {
+			return model
+		}
+	}
+
+	return nil
+}
+
+func (m *routerMember) owns(model providers.Model) bool {
+	for _, memberModel := range m.models {
+		if memberModel.ID() == model.ID() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nestedRouting picks the member router with the router strategy & then the model with the member strategy.
+// Requests fall down to the next member only when all healthy models of the current one have failed
+type nestedRouting struct {
+	routing routing.LangModelRouting
+	members []*routerMember
+}
+
+func newNestedRouting(
+	strategy routing.Strategy,
+	members []*routerMember,
+	action providers.Action,
+) (*nestedRouting, error) {
+	memberPool := make([]providers.Model, 0, len(members))
+
+	for _, member := range members {
+		memberPool = append(memberPool, member)
+	}
+
+	// the member latency is the latency of the model the member would route the request to
+	memberLatency := func(model providers.Model, action providers.Action) *latency.MovingAverage {
+		return providers.Latency(model.(*routerMember).next(), action)
+	}
+
+	memberRouting, err := newRouting(strategy, memberPool, memberLatency, action)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nestedRouting{
+		routing: memberRouting,
+		members: members,
+	}, nil
+}
+
+func (r *nestedRouting) Iterator() routing.LangModelIterator {
+	return &nestedIterator{
+		routing:      r,
+		members:      r.routing.Iterator(),
+		triedMembers: make(map[string]struct{}, len(r.members)),
+		returned:     make(map[string]struct{}),
+	}
+}
+
+// Peek returns the model the next request would be routed to
+func (r *nestedRouting) Peek() (providers.Model, error) {
+	peeker, ok := r.routing.(routing.ModelPeeker)
+	if !ok {
+		return nil, routing.ErrNoHealthyModels
+	}
+
+	member, err := peeker.Peek()
+	if err != nil {
+		return nil, err
+	}
+
+	return member.(*routerMember).next(), nil
+}
+
+// Observe passes the request outcome to the member routing that owns the model
+func (r *nestedRouting) Observe(model providers.Model, outcome routing.Outcome) {
+	if observer, ok := r.memberRouting(model).(routing.RewardObserver); ok {
+		observer.Observe(model, outcome)
+	}
+}
+
+// ObserveError passes the request failure to the member routing that owns the model
+func (r *nestedRouting) ObserveError(model providers.Model, err error) {
+	if observer, ok := r.memberRouting(model).(routing.ErrorObserver); ok {
+		observer.ObserveError(model, err)
+	}
+}
+
+// Variant finds out the experiment variant the model serves in the member routing that owns the model
+func (r *nestedRouting) Variant(model providers.Model) (string, string, bool) {
+	if experiment, ok := r.memberRouting(model).(routing.ExperimentRouting); ok {
+		return experiment.Variant(model)
+	}
+
+	return "", "", false
+}
+
+func (r *nestedRouting) memberRouting(model providers.Model) routing.LangModelRouting {
+	for _, member := range r.members {
+		if member.owns(model) {
+			return member.routing
+		}
+	}
+
+	return nil
+}
+
+// nestedIterator walks models of the current member & switches to the next member once they are exhausted.
+//
+//	Some strategies keep picking the same member or model (e.g. the fastest one) until it becomes unhealthy,
+//	so when a strategy offers something that has been tried already, the rest is tried in the order of definition
+type nestedIterator struct {
+	routing      *nestedRouting
+	members      routing.LangModelIterator
+	member       *routerMember
+	models       routing.LangModelIterator
+	triedMembers map[string]struct{}
+	returned     map[string]struct{}
+}
+
+func (i *nestedIterator) Next() (providers.Model, error) {
+	for {
+		if i.member != nil {
+			if model := i.nextModel(); model != nil {
+				i.returned[model.ID()] = struct{}{}
+
+				return model, nil
+			}
+		}
+
+		member, err := i.nextMember()
+		if err != nil {
+			return nil, err
+		}
+
+		i.member, i.models = member, member.routing.Iterator()
+		i.triedMembers[member.ID()] = struct{}{}
+	}
+}
+
+func (i *nestedIterator) nextModel() providers.Model {
+	if i.models != nil {
+		model, err := i.models.Next()
+		if err == nil {
+			if _, found := i.returned[model.ID()]; !found {
+				return model
+			}
+		}
+
+		// the member strategy is either exhausted or sticks to the tried model
+		i.models = nil
+	}
+
+	return i.member.firstHealthy(i.returned)
+}
+
+func (i *nestedIterator) nextMember() (*routerMember, error) {
+	member, err := i.members.Next()
+	if err == nil {
+		if _, found := i.triedMembers[member.ID()]; !found {
+			return member.(*routerMember), nil
+		}
+	}
+
+	for _, member := range i.routing.members {
+		if _, found := i.triedMembers[member.ID()]; !found && member.Healthy() {
+			return member, nil
+		}
+	}
+
+	return nil, routing.ErrNoHealthyModels
+}
+
+// nestingRouterIDs finds enabled routers that refer to the changed routers directly or through other nested routers.
+// Such routers share models & routings of their members, so they have to be built anew when members change
+func nestingRouterIDs(configs []LangRouterConfig, changed func(routerID RouterID) bool) map[RouterID]struct{} {
+	nesting := make(map[RouterID]struct{})
+
+	for found := true; found; {
+		found = false
+
+		for _, routerConfig := range configs {
+			if _, seen := nesting[routerConfig.ID]; seen || !routerConfig.Enabled {
+				continue
+			}
+
+			for _, memberConfig := range routerConfig.Routers {
+				if _, nested := nesting[memberConfig.ID]; nested || changed(memberConfig.ID) {
+					nesting[routerConfig.ID] = struct{}{}
+					found = true
+
+					break
+				}
+			}
+		}
+	}
+
+	return nesting
+}
+
+// withNestedRouters makes the router route requests between the routers it refers to (if any).
+// Referred routers that are nested themselves are wired first
+func withNestedRouters(router *LangRouter, langRouterMap map[string]*LangRouter, resolving map[string]struct{}) error {
+	if len(router.Config.Routers) == 0 || router.chatRouting != nil {
+		return nil
+	}
+
+	if _, found := resolving[router.ID()]; found {
+		return fmt.Errorf("router \"%v\" is nested into itself", router.ID())
+	}
+
+	resolving[router.ID()] = struct{}{}
+	defer delete(resolving, router.ID())
+
+	chatMembers := make([]*routerMember, 0, len(router.Config.Routers))
+	chatStreamMembers := make([]*routerMember, 0, len(router.Config.Routers))
+	embedMembers := make([]*routerMember, 0, len(router.Config.Routers))
+	modelRouters := make(map[string]RouterID)
+
+	for _, memberConfig := range router.Config.Routers {
+		if memberConfig.ID == router.ID() {
+			return fmt.Errorf("router \"%v\" is nested into itself", router.ID())
+		}
+
+		memberRouter, found := langRouterMap[memberConfig.ID]
+		if !found {
+			return fmt.Errorf(
+				"router \"%v\" refers to language router \"%v\" which is not found or disabled",
+				router.ID(),
+				memberConfig.ID,
+			)
+		}
+
+		if err := withNestedRouters(memberRouter, langRouterMap, resolving); err != nil {
+			return err
+		}
+
+		for _, model := range memberRouter.chatModels {
+			if routerID, found := modelRouters[model.ID()]; found {
+				return fmt.Errorf(
+					"model \"%v\" is defined in both routers \"%v\" and \"%v\" nested into router \"%v\", while model IDs should be unique in scope of that router",
+					model.ID(),
+					routerID,
+					memberRouter.ID(),
+					router.ID(),
+				)
+			}
+
+			modelRouters[model.ID()] = memberRouter.ID()
+			router.chatModels = append(router.chatModels, model)
+		}
+
+		router.chatStreamModels = append(router.chatStreamModels, memberRouter.chatStreamModels...)
+		router.embedModels = append(router.embedModels, memberRouter.embedModels...)
+
+		chatMembers = appendMember(chatMembers, memberConfig, memberRouter.chatRouting, memberRouter.chatModels)
+		chatStreamMembers = appendMember(chatStreamMembers, memberConfig, memberRouter.chatStreamRouting, memberRouter.chatStreamModels)
+		embedMembers = appendMember(embedMembers, memberConfig, memberRouter.embedRouting, memberRouter.embedModels)
+	}
+
+	chatRouting, err := newNestedRouting(router.Config.RoutingStrategy, chatMembers, providers.ChatAction)
+	if err != nil {
+		return fmt.Errorf("router \"%v\": %w", router.ID(), err)
+	}
+
+	chatStreamRouting, err := newNestedRouting(router.Config.RoutingStrategy, chatStreamMembers, providers.ChatStreamAction)
+	if err != nil {
+		return fmt.Errorf("router \"%v\": %w", router.ID(), err)
+	}
+
+	embedRouting, err := newNestedRouting(router.Config.RoutingStrategy, embedMembers, providers.EmbedAction)
+	if err != nil {
+		return fmt.Errorf("router \"%v\": %w", router.ID(), err)
+	}
+
+	router.chatRouting, router.chatStreamRouting, router.embedRouting = chatRouting, chatStreamRouting, embedRouting
+
+	return nil
+}
+
+// appendMember adds the member router if it has models serving the action
+func appendMember(
+	members []*routerMember,
+	config NestedRouterConfig,
+	memberRouting routing.LangModelRouting,
+	models []*providers.LanguageModel,
+) []*routerMember {
+	if len(models) == 0 {
+		return members
+	}
+
+	return append(members, &routerMember{
+		routerID: config.ID,
+		weight:   config.Weight,
+		routing:  memberRouting,
+		models:   models,
+	})
+}
@@ -0,0 +1,102 @@
+package routers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newNestedRouterConfig(routerID string, memberIDs ...string) LangRouterConfig {
+	routerConfig := DefaultLangRouterConfig()
+	routerConfig.ID = routerID
+
+	for _, memberID := range memberIDs {
+		memberConfig := DefaultNestedRouterConfig()
+		memberConfig.ID = memberID
+
+		routerConfig.Routers = append(routerConfig.Routers, memberConfig)
+	}
+
+	return routerConfig
+}
+
+func TestLangRouter_NestedRouters(t *testing.T) {
+	openAIPool := newLangRouterConfig("openai-pool", "gpt-a", "gpt-b")
+	openAIPool.RoutingStrategy = routing.LeastLatency
+
+	chain := newNestedRouterConfig("chain", "openai-pool", "backup-pool")
+	require.NoError(t, configValidator.Struct(&chain))
+
+	manager, err := NewManager(&Config{
+		LanguageRouters: []LangRouterConfig{
+			chain,
+			openAIPool,
+			newLangRouterConfig("backup-pool", "backup"),
+		},
+	}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	router, err := manager.GetLangRouter("chain")
+	require.NoError(t, err)
+	require.Len(t, router.chatModels, 3)
+
+	iterator := router.chatRouting.Iterator()
+	modelIDs := make([]string, 0, 3)
+
+	for range 3 {
+		model, err := iterator.Next()
+		require.NoError(t, err)
+
+		modelIDs = append(modelIDs, model.ID())
+	}
+
+	// all models of the first pool are tried before the backup one
+	require.ElementsMatch(t, []string{"gpt-a", "gpt-b"}, modelIDs[:2])
+	require.Equal(t, "backup", modelIDs[2])
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, routing.ErrNoHealthyModels)
+
+	// models are shared with the nested routers
+	openAIRouter, err := manager.GetLangRouter("openai-pool")
+	require.NoError(t, err)
+	require.Same(t, openAIRouter.chatModels[0], router.chatModels[0])
+}
+
+func TestLangRouter_NestedRoutersInvalidSetups(t *testing.T) {
+	tests := map[string][]LangRouterConfig{
+		"unknown router": {newNestedRouterConfig("chain", "unknown")},
+		"cycle": {
+			newNestedRouterConfig("first", "second"),
+			newNestedRouterConfig("second", "first"),
+		},
+		"duplicate models": {
+			newNestedRouterConfig("chain", "first-pool", "second-pool"),
+			newLangRouterConfig("first-pool", "gpt"),
+			newLangRouterConfig("second-pool", "gpt"),
+		},
+		"lang-only strategy": {
+			func() LangRouterConfig {
+				routerConfig := newNestedRouterConfig("chain", "pool")
+				routerConfig.RoutingStrategy = routing.LeastBusy
+
+				return routerConfig
+			}(),
+			newLangRouterConfig("pool", "gpt"),
+		},
+	}
+
+	for name, routerConfigs := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewManager(&Config{LanguageRouters: routerConfigs}, telemetry.NewTelemetryMock())
+			require.Error(t, err)
+		})
+	}
+
+	routerConfig := newNestedRouterConfig("chain", "pool")
+	routerConfig.Models = newLangRouterConfig("pool", "gpt").Models
+
+	require.Error(t, configValidator.Struct(&routerConfig))
+}
@@ -0,0 +1,30 @@
+package routers
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// ModelPanic wraps a panic that happened while a model was serving a request,
+// so it could be reported with the router & model context upstream
+type ModelPanic struct {
+	RouterID RouterID
+	ModelID  string
+	Provider string
+	Value    any
+	Stack    []byte
+}
+
+func NewModelPanic(routerID RouterID, modelID string, provider string, value any) *ModelPanic {
+	return &ModelPanic{
+		RouterID: routerID,
+		ModelID:  modelID,
+		Provider: provider,
+		Value:    value,
+		Stack:    debug.Stack(),
+	}
+}
+
+func (p *ModelPanic) Error() string {
+	return fmt.Sprintf("model \"%v\" (%v) panicked in router \"%v\": %v", p.ModelID, p.Provider, p.RouterID, p.Value)
+}
@@ -0,0 +1,126 @@
+package routers
+
+import (
+	"context"
+	"errors"
+
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+)
+
+// PinnedModelHeader lets clients pin the model within the router when they cannot set the request field
+const PinnedModelHeader = "X-Glide-Model"
+
+var (
+	ErrPinnedModelNotFound    = errors.New("pinned model is not found in the router")
+	ErrPinnedModelUnavailable = errors.New("pinned model is unhealthy or cannot serve the request")
+)
+
+// PinningConfig defines how the router treats requests pinned to a specific model
+type PinningConfig struct {
+	Enabled  bool `yaml:"enabled" json:"enabled"`   // let clients pin models, pins are ignored otherwise
+	Fallback bool `yaml:"fallback" json:"fallback"` // apply the router strategy when the pinned model cannot serve the request instead of failing it
+}
+
+func DefaultPinningConfig() *PinningConfig {
+	return &PinningConfig{
+		Enabled:  true,
+		Fallback: false,
+	}
+}
+
+func (c *PinningConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultPinningConfig()
+
+	type plain PinningConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// pinnedRouting routes requests to the pinned model & then, if fallback is allowed, to models of the router strategy
+type pinnedRouting struct {
+	model    providers.Model
+	fallback routing.LangModelRouting // nil if fallback is not allowed
+}
+
+func (r *pinnedRouting) Iterator() routing.LangModelIterator {
+	return &pinnedIterator{routing: r}
+}
+
+// Peek returns the pinned model
+func (r *pinnedRouting) Peek() (providers.Model, error) {
+	return r.model, nil
+}
+
+type pinnedIterator struct {
+	routing  *pinnedRouting
+	fallback routing.LangModelIterator
+	pinned   bool
+}
+
+func (i *pinnedIterator) Next() (providers.Model, error) {
+	if !i.pinned {
+		i.pinned = true
+
+		if i.routing.model.Healthy() {
+			return i.routing.model, nil
+		}
+	}
+
+	if i.routing.fallback == nil {
+		return nil, routing.ErrNoHealthyModels
+	}
+
+	if i.fallback == nil {
+		i.fallback = i.routing.fallback.Iterator()
+	}
+
+	return i.fallback.Next()
+}
+
+// pinnedRouting finds the model the request is pinned to via the request field or the header
+// and makes sure it can serve the request.
+// Nil routing is returned when the request is not pinned or the router falls back to its strategy
+func (r *LangRouter) pinnedRouting(
+	ctx context.Context,
+	modelID string,
+	models []*providers.LanguageModel,
+	needs requestNeeds,
+	strategyRouting routing.LangModelRouting,
+) (routing.LangModelRouting, error) {
+	config := r.Config.Pinning
+	if config == nil {
+		config = DefaultPinningConfig()
+	}
+
+	if modelID == "" {
+		modelID, _ = requestHeader(ctx, PinnedModelHeader)
+	}
+
+	if !config.Enabled || modelID == "" {
+		return nil, nil
+	}
+
+	var pinnedModel *providers.LanguageModel
+
+	for _, model := range models {
+		if model.ID() == modelID {
+			pinnedModel = model
+
+			break
+		}
+	}
+
+	switch {
+	case pinnedModel == nil && config.Fallback:
+		return nil, nil
+	case pinnedModel == nil:
+		return nil, ErrPinnedModelNotFound
+	case config.Fallback:
+		return &pinnedRouting{model: pinnedModel, fallback: strategyRouting}, nil
+	case !pinnedModel.Healthy() || !pinnedModel.FitsContextWindow(needs.promptTokens) || !pinnedModel.Supports(needs.capabilities):
+		return nil, ErrPinnedModelUnavailable
+	}
+
+	return &pinnedRouting{model: pinnedModel}, nil
+}
@@ -0,0 +1,98 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newPinningRouter(t *testing.T) *LangRouter {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}, {Msg: "1"}, {Msg: "1"}}), budget, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "2"}, {Msg: "2"}}), budget, *latConfig, 1),
+		providers.NewLangModel("broken", ptesting.NewProviderMock([]ptesting.RespMock{{Err: &clients.ErrUnauthorized}}), budget, *latConfig, 1),
+	}
+
+	// the broken model is unhealthy from now on
+	_, err := langModels[2].Chat(context.Background(), schemas.NewChatFromStr("hello"))
+	require.ErrorIs(t, err, clients.ErrUnauthorized)
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              telemetry.NewTelemetryMock(),
+		logger:           telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_PinnedModel(t *testing.T) {
+	router := newPinningRouter(t)
+
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.ModelID = "second"
+
+	resp, err := router.Chat(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	ctx := WithRequestHeaders(context.Background(), map[string][]string{PinnedModelHeader: {"second"}})
+
+	resp, err = router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	req.ModelID = "unknown"
+
+	_, err = router.Chat(context.Background(), req)
+	require.ErrorIs(t, err, ErrPinnedModelNotFound)
+
+	req.ModelID = "broken"
+
+	_, err = router.Chat(context.Background(), req)
+	require.ErrorIs(t, err, ErrPinnedModelUnavailable)
+}
+
+func TestLangRouter_Chat_PinnedModelFallback(t *testing.T) {
+	router := newPinningRouter(t)
+	router.Config.Pinning = &PinningConfig{Enabled: true, Fallback: true}
+
+	for _, modelID := range []string{"unknown", "broken"} {
+		req := schemas.NewChatFromStr("tell me a dad joke")
+		req.ModelID = modelID
+
+		resp, err := router.Chat(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, "first", resp.ModelID)
+	}
+
+	router.Config.Pinning = &PinningConfig{Enabled: false}
+
+	// pins are ignored
+	ctx := WithRequestHeaders(context.Background(), map[string][]string{PinnedModelHeader: {"unknown"}})
+
+	_, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"fmt"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+)
+
+// trackPromptCache reports prompt tokens served from the provider prompt cache & how much they have saved
+func (r *LangRouter) trackPromptCache(langModel providers.LangModel, usage schemas.TokenUsage) {
+	if usage.CachedPromptTokens == 0 && usage.CacheWriteTokens == 0 {
+		return
+	}
+
+	prefix := fmt.Sprintf("routers.%v.models.%v.prompt_cache", r.routerID, langModel.ID())
+
+	r.tel.M().Counter(prefix + ".cached_tokens").Add(int64(usage.CachedPromptTokens))
+	r.tel.M().Counter(prefix + ".write_tokens").Add(int64(usage.CacheWriteTokens))
+
+	if model, ok := langModel.(*providers.LanguageModel); ok && model.Pricing() != nil {
+		// counters are integer, so savings are counted in micro dollars
+		savings := model.Pricing().CacheSavings(usage) * 1_000_000
+
+		r.tel.M().Counter(prefix + ".savings_micro_usd").Add(int64(savings))
+	}
+}
@@ -0,0 +1,230 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+var ErrRouterSaturated = errors.New("all router models are serving as many requests as their concurrency limits allow")
+
+// queueRecheckInterval is how often waiting requests check models on their own
+// in case the model has freed up without waking up the queue (e.g. a stream was closed by the client)
+const queueRecheckInterval = 100 * time.Millisecond
+
+// QueueError is returned when the request cannot wait in the router queue any longer
+type QueueError struct {
+	Stats    schemas.QueueStats
+	TimedOut bool
+}
+
+func (e *QueueError) Error() string {
+	if e.TimedOut {
+		return fmt.Sprintf(
+			"request has waited in the router queue for %vms with no model freed up (%v of %v requests are waiting)",
+			e.Stats.WaitedMs,
+			e.Stats.Depth,
+			e.Stats.MaxDepth,
+		)
+	}
+
+	return fmt.Sprintf("router queue is full (%v of %v requests are waiting)", e.Stats.Depth, e.Stats.MaxDepth)
+}
+
+func (e *QueueError) Unwrap() error {
+	return ErrRouterSaturated
+}
+
+// QueueConfig defines how many chat requests may wait for router models to free up and for how long
+type QueueConfig struct {
+	MaxDepth int              `yaml:"max_depth" json:"max_depth" validate:"gte=1"`             // the most requests waiting at the same time
+	MaxWait  *fields.Duration `yaml:"max_wait" json:"max_wait" swaggertype:"primitive,string"` // the longest time a request waits for a model
+}
+
+func DefaultQueueConfig() *QueueConfig {
+	defaultMaxWait := 30 * time.Second
+
+	return &QueueConfig{
+		MaxDepth: 100,
+		MaxWait:  (*fields.Duration)(&defaultMaxWait),
+	}
+}
+
+func (c *QueueConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultQueueConfig()
+
+	type plain QueueConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// RequestQueue holds chat requests while all router models are at their concurrency limits.
+// Waiting requests are woken up every time a model finishes serving a request
+// and try to get a model again, so the queue is not strictly FIFO
+type RequestQueue struct {
+	config *QueueConfig
+	mu     sync.Mutex
+	depth  int
+	freed  chan struct{}
+	prefix string
+	tel    *telemetry.Telemetry
+}
+
+func NewRequestQueue(routerID RouterID, config *QueueConfig, tel *telemetry.Telemetry) *RequestQueue {
+	return &RequestQueue{
+		config: config,
+		freed:  make(chan struct{}),
+		prefix: fmt.Sprintf("routers.%v.queue", routerID),
+		tel:    tel,
+	}
+}
+
+// queueTicket keeps the place of the request in the queue while it waits for models to free up
+type queueTicket struct {
+	queue    *RequestQueue
+	joinedAt time.Time
+}
+
+// Join takes a place in the queue or fails if the queue is full
+func (q *RequestQueue) Join() (*queueTicket, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.depth >= q.config.MaxDepth {
+		q.tel.M().Counter(q.prefix + ".rejected").Inc()
+
+		return nil, &QueueError{Stats: schemas.QueueStats{Depth: q.depth, MaxDepth: q.config.MaxDepth}}
+	}
+
+	q.depth++
+	q.tel.M().Counter(q.prefix + ".queued").Inc()
+
+	return &queueTicket{queue: q, joinedAt: time.Now()}, nil
+}
+
+// Release wakes up waiting requests as a model has finished serving a request
+func (q *RequestQueue) Release() {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	close(q.freed)
+	q.freed = make(chan struct{})
+}
+
+// watch returns the channel closed when a model finishes serving the next request.
+// It's taken before picking models, so requests finished in the meantime are not missed
+func (q *RequestQueue) watch() <-chan struct{} {
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.freed
+}
+
+// Stats returns the current queue state
+func (q *RequestQueue) Stats() *schemas.QueueStats {
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return &schemas.QueueStats{Depth: q.depth, MaxDepth: q.config.MaxDepth}
+}
+
+// Wait blocks until a model finishes serving a request or the request has waited for too long
+func (t *queueTicket) Wait(ctx context.Context, freed <-chan struct{}) error {
+	timer := time.NewTimer(min(time.Until(t.joinedAt.Add(time.Duration(*t.queue.config.MaxWait))), queueRecheckInterval))
+	defer timer.Stop()
+
+	select {
+	case <-freed:
+		return nil
+	case <-timer.C:
+		if time.Since(t.joinedAt) < time.Duration(*t.queue.config.MaxWait) {
+			return nil
+		}
+
+		t.queue.tel.M().Counter(t.queue.prefix + ".timeouts").Inc()
+
+		stats := t.queue.Stats()
+		stats.WaitedMs = time.Since(t.joinedAt).Milliseconds()
+
+		return &QueueError{Stats: *stats, TimedOut: true}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Leave frees the place in the queue
+func (t *queueTicket) Leave() {
+	if t == nil {
+		return
+	}
+
+	t.queue.mu.Lock()
+	defer t.queue.mu.Unlock()
+
+	t.queue.depth--
+}
+
+// concurrencyIterator skips models serving as many requests as their concurrency limits allow
+// and remembers if any model was skipped, so the router can tell saturated models from unhealthy ones.
+//
+//	Some strategies keep picking the same model, so the iterator gives up
+//	once the strategy has offered only saturated models for a while
+type concurrencyIterator struct {
+	iterator       routing.LangModelIterator
+	models         int
+	saturated      bool
+	saturatedInRow int
+}
+
+func (r *LangRouter) withinConcurrency(iterator routing.LangModelIterator, models []*providers.LanguageModel) *concurrencyIterator {
+	return &concurrencyIterator{
+		iterator: iterator,
+		models:   len(models),
+	}
+}
+
+func (i *concurrencyIterator) Next() (providers.Model, error) {
+	for i.saturatedInRow <= i.models {
+		model, err := i.iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		langModel, ok := model.(*providers.LanguageModel)
+		if !ok || !langModel.Saturated() {
+			i.saturatedInRow = 0
+
+			return model, nil
+		}
+
+		i.saturated = true
+		i.saturatedInRow++
+	}
+
+	return nil, routing.ErrNoHealthyModels
+}
+
+// Saturated tells if any model was skipped because of its concurrency limit
+func (i *concurrencyIterator) Saturated() bool {
+	return i.saturated
+}
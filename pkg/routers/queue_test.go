@@ -0,0 +1,146 @@
+package routers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/telemetry"
+)
+
+type chatResult struct {
+	resp *schemas.ChatResponse
+	err  error
+}
+
+// newSaturatedRouter creates a router with one model serving one request at a time.
+// The model responds once the test sends to the returned channel
+func newSaturatedRouter(t *testing.T, queue *QueueConfig) (*LangRouter, chan<- struct{}) {
+	chatResp, err := os.ReadFile("../providers/openai/testdata/chat.success.json")
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the request context is cancelled on client disconnects only once the body is read
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(chatResp)
+	}))
+	t.Cleanup(server.Close)
+
+	routerConfig := newLangRouterConfig("queued", "busy")
+	routerConfig.Models[0].OpenAI.BaseURL = server.URL
+	routerConfig.Models[0].MaxConcurrency = 1
+	routerConfig.Queue = queue
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	return router, release
+}
+
+func chatAsync(ctx context.Context, router *LangRouter) <-chan chatResult {
+	resultC := make(chan chatResult, 1)
+
+	go func() {
+		resp, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+		resultC <- chatResult{resp: resp, err: err}
+	}()
+
+	return resultC
+}
+
+func newQueueConfig(maxDepth int, maxWait time.Duration) *QueueConfig {
+	return &QueueConfig{MaxDepth: maxDepth, MaxWait: (*fields.Duration)(&maxWait)}
+}
+
+func TestLangRouter_Chat_SaturatedWithoutQueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router, _ := newSaturatedRouter(t, nil)
+
+	chatAsync(ctx, router)
+	require.Eventually(t, router.chatModels[0].Saturated, time.Second, time.Millisecond)
+
+	_, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.ErrorIs(t, err, ErrRouterSaturated)
+}
+
+func TestLangRouter_Chat_QueuedUntilModelFreesUp(t *testing.T) {
+	router, release := newSaturatedRouter(t, newQueueConfig(10, 5*time.Second))
+
+	firstC := chatAsync(context.Background(), router)
+	require.Eventually(t, router.chatModels[0].Saturated, time.Second, time.Millisecond)
+
+	secondC := chatAsync(context.Background(), router)
+	require.Eventually(t, func() bool { return router.queue.Stats().Depth == 1 }, time.Second, time.Millisecond)
+
+	release <- struct{}{}
+
+	first := <-firstC
+	require.NoError(t, first.err)
+
+	release <- struct{}{}
+
+	second := <-secondC
+	require.NoError(t, second.err)
+	require.Equal(t, "busy", second.resp.ModelID)
+	require.Equal(t, 0, router.queue.Stats().Depth)
+}
+
+func TestLangRouter_Chat_QueueFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router, _ := newSaturatedRouter(t, newQueueConfig(1, 5*time.Second))
+
+	chatAsync(ctx, router)
+	require.Eventually(t, router.chatModels[0].Saturated, time.Second, time.Millisecond)
+
+	chatAsync(ctx, router)
+	require.Eventually(t, func() bool { return router.queue.Stats().Depth == 1 }, time.Second, time.Millisecond)
+
+	_, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+
+	var queueErr *QueueError
+
+	require.ErrorAs(t, err, &queueErr)
+	require.ErrorIs(t, err, ErrRouterSaturated)
+	require.False(t, queueErr.TimedOut)
+	require.Equal(t, schemas.QueueStats{Depth: 1, MaxDepth: 1}, queueErr.Stats)
+}
+
+func TestLangRouter_Chat_QueueTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router, _ := newSaturatedRouter(t, newQueueConfig(10, 50*time.Millisecond))
+
+	chatAsync(ctx, router)
+	require.Eventually(t, router.chatModels[0].Saturated, time.Second, time.Millisecond)
+
+	_, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+
+	var queueErr *QueueError
+
+	require.ErrorAs(t, err, &queueErr)
+	require.True(t, queueErr.TimedOut)
+	require.GreaterOrEqual(t, queueErr.Stats.WaitedMs, int64(50))
+	require.Equal(t, 0, router.queue.Stats().Depth)
+}
@@ -0,0 +1,115 @@
+package routers
+
+import (
+	"fmt"
+
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+// rateLimitIterator deprioritizes models close to or over their advertised rate limits,
+// so they are tried only after the strategy runs out of other models
+// instead of discovering the limits via requests rejected by providers.
+//
+//	Some strategies keep picking the same model (e.g. the fastest one) until it becomes unhealthy,
+//	so when the strategy doesn't offer a model with spare capacity for a while,
+//	healthy models of the router are tried in the order of definition
+type rateLimitIterator struct {
+	routerID      RouterID
+	iterator      routing.LangModelIterator
+	models        []*providers.LanguageModel
+	deferred      []providers.Model
+	deferredInRow int
+	exhausted     bool
+	fallback      bool
+	returned      map[string]struct{}
+	tel           *telemetry.Telemetry
+}
+
+func (r *LangRouter) withinRateLimits(
+	iterator routing.LangModelIterator,
+	models []*providers.LanguageModel,
+) routing.LangModelIterator {
+	return &rateLimitIterator{
+		routerID: r.routerID,
+		iterator: iterator,
+		models:   models,
+		deferred: make([]providers.Model, 0, len(models)),
+		returned: make(map[string]struct{}, len(models)),
+		tel:      r.tel,
+	}
+}
+
+func (i *rateLimitIterator) Next() (providers.Model, error) {
+	for !i.exhausted && !i.fallback {
+		model, err := i.iterator.Next()
+		if err != nil {
+			i.exhausted = true
+
+			break
+		}
+
+		langModel, ok := model.(*providers.LanguageModel)
+		if !ok || !langModel.NearRateLimit() {
+			i.deferredInRow = 0
+			i.returned[model.ID()] = struct{}{}
+
+			return model, nil
+		}
+
+		i.deferredInRow++
+		i.fallback = i.deferredInRow > len(i.models)
+
+		i.deferModel(model)
+	}
+
+	if i.fallback {
+		for _, model := range i.models {
+			if _, found := i.returned[model.ID()]; found || !model.Healthy() {
+				continue
+			}
+
+			if model.NearRateLimit() {
+				i.deferModel(model)
+
+				continue
+			}
+
+			i.returned[model.ID()] = struct{}{}
+
+			return model, nil
+		}
+	}
+
+	for len(i.deferred) > 0 {
+		model := i.deferred[0]
+		i.deferred = i.deferred[1:]
+
+		if !model.Healthy() {
+			continue
+		}
+
+		i.returned[model.ID()] = struct{}{}
+
+		return model, nil
+	}
+
+	return nil, routing.ErrNoHealthyModels
+}
+
+func (i *rateLimitIterator) deferModel(model providers.Model) {
+	if _, found := i.returned[model.ID()]; found {
+		return
+	}
+
+	for _, deferredModel := range i.deferred {
+		if deferredModel.ID() == model.ID() {
+			return
+		}
+	}
+
+	i.tel.M().Counter(fmt.Sprintf("routers.%v.models.%v.rate_limit_deferrals", i.routerID, model.ID())).Inc()
+
+	i.deferred = append(i.deferred, model)
+}
@@ -0,0 +1,65 @@
+package routers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newRateLimitedRouter(t *testing.T) *LangRouter {
+	routerConfig := newLangRouterConfig("limited", "busy", "spare")
+	routerConfig.Models[0].OpenAI.BaseURL = "http://127.0.0.1:1" // nothing listens there
+	routerConfig.Models[0].RateLimits = &providers.RateLimitConfig{RequestsPerMinute: 2, Headroom: 0.5}
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	// requests count towards the limits even if they fail
+	_, err = router.chatModels[0].Chat(context.Background(), schemas.NewChatFromStr("hello"))
+	require.Error(t, err)
+	require.True(t, router.chatModels[0].NearRateLimit())
+
+	return router
+}
+
+func TestLangRouter_RateLimitIterator(t *testing.T) {
+	router := newRateLimitedRouter(t)
+
+	iterator := router.withinRateLimits(router.chatRouting.Iterator(), router.chatModels)
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "spare", model.ID())
+
+	// the busy model is still tried when other models fail
+	model, err = iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "busy", model.ID())
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, routing.ErrNoHealthyModels)
+
+	require.Equal(t, int64(1), router.tel.M().Counter("routers.limited.models.busy.rate_limit_deferrals").Value())
+}
+
+func TestLangRouter_RateLimitFallback(t *testing.T) {
+	router := newRateLimitedRouter(t)
+
+	iterator := router.withinRateLimits(stuckIterator{model: router.chatModels[0]}, router.chatModels)
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "spare", model.ID())
+
+	model, err = iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "busy", model.ID())
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, routing.ErrNoHealthyModels)
+}
@@ -0,0 +1,240 @@
+package routers
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"glide/pkg/api/schemas"
+)
+
+// Reload applies the new routers config without restarting the gateway.
+// Language routers are diffed by ID: new and changed routers are built first, so if any of them fails,
+// the running routers are kept as they are. Unchanged routers keep serving with their health & latency stats.
+// Requests already being served by the replaced or removed routers are finished by them.
+// Other router sections can't be swapped at runtime yet and are reported as requiring restart
+func (r *RouterManager) Reload(cfg *Config) (*schemas.ConfigReload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status, err := r.reloadLangRouters(cfg)
+	if err != nil {
+		r.lastReload = &schemas.ConfigReload{
+			ReloadedAt: int(time.Now().UTC().Unix()),
+			Error:      err.Error(),
+		}
+
+		return r.lastReload, err
+	}
+
+	r.lastReload = status
+
+	return status, nil
+}
+
+// ReloadFailed records a reload that failed before the new config reached the manager (e.g. the config file is invalid)
+func (r *RouterManager) ReloadFailed(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastReload = &schemas.ConfigReload{
+		ReloadedAt: int(time.Now().UTC().Unix()),
+		Error:      err.Error(),
+	}
+}
+
+// LastReload returns the outcome of the last config reload or nil if the config has not been reloaded yet
+func (r *RouterManager) LastReload() *schemas.ConfigReload {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.lastReload
+}
+
+// reloadLangRouters must be called with the manager lock held
+func (r *RouterManager) reloadLangRouters(cfg *Config) (*schemas.ConfigReload, error) {
+	currentConfigs := make(map[string]LangRouterConfig, len(r.Config.LanguageRouters))
+
+	for _, routerConfig := range r.Config.LanguageRouters {
+		currentConfigs[routerConfig.ID] = routerConfig
+	}
+
+	seenIDs := make(map[string]bool, len(cfg.LanguageRouters))
+	builtRouters := make(map[string]*LangRouter)
+
+	shutdownBuilt := func() {
+		for _, router := range builtRouters {
+			router.Shutdown()
+		}
+	}
+
+	for idx, routerConfig := range cfg.LanguageRouters {
+		if seenIDs[routerConfig.ID] {
+			shutdownBuilt()
+
+			return nil, fmt.Errorf("ID \"%v\" is specified for more than one router while each ID should be unique", routerConfig.ID)
+		}
+
+		seenIDs[routerConfig.ID] = true
+
+		if !routerConfig.Enabled {
+			continue
+		}
+
+		_, running := (*r.langRouterMap)[routerConfig.ID]
+		currentConfig, found := currentConfigs[routerConfig.ID]
+
+		if running && found && reflect.DeepEqual(currentConfig, routerConfig) {
+			continue
+		}
+
+		router, err := NewLangRouter(&cfg.LanguageRouters[idx], r.tel)
+		if err != nil {
+			shutdownBuilt()
+
+			return nil, fmt.Errorf("failed to build router \"%v\": %w", routerConfig.ID, err)
+		}
+
+		builtRouters[routerConfig.ID] = router
+
+		if err := withGuardrail(router, *r.moderationRouterMap); err != nil {
+			shutdownBuilt()
+
+			return nil, err
+		}
+	}
+
+	// unchanged nested routers still route between the replaced or removed members, so they are rebuilt too
+	enabledIDs := make(map[string]bool, len(cfg.LanguageRouters))
+
+	for _, routerConfig := range cfg.LanguageRouters {
+		enabledIDs[routerConfig.ID] = routerConfig.Enabled
+	}
+
+	nestingIDs := nestingRouterIDs(cfg.LanguageRouters, func(routerID RouterID) bool {
+		_, built := builtRouters[routerID]
+
+		return built || !enabledIDs[routerID]
+	})
+
+	rewiredIDs := make(map[string]bool, len(nestingIDs))
+
+	for idx, routerConfig := range cfg.LanguageRouters {
+		_, nesting := nestingIDs[routerConfig.ID]
+
+		if _, built := builtRouters[routerConfig.ID]; built || !nesting {
+			continue
+		}
+
+		router, err := NewLangRouter(&cfg.LanguageRouters[idx], r.tel)
+		if err != nil {
+			shutdownBuilt()
+
+			return nil, fmt.Errorf("failed to build router \"%v\": %w", routerConfig.ID, err)
+		}
+
+		builtRouters[routerConfig.ID] = router
+		rewiredIDs[routerConfig.ID] = true
+
+		if err := withGuardrail(router, *r.moderationRouterMap); err != nil {
+			shutdownBuilt()
+
+			return nil, err
+		}
+	}
+
+	langRouters := make([]*LangRouter, 0, len(cfg.LanguageRouters))
+	langRouterMap := make(map[string]*LangRouter, len(cfg.LanguageRouters))
+
+	for _, routerConfig := range cfg.LanguageRouters {
+		if !routerConfig.Enabled {
+			continue
+		}
+
+		router, built := builtRouters[routerConfig.ID]
+		if !built {
+			router = (*r.langRouterMap)[routerConfig.ID]
+		}
+
+		langRouters = append(langRouters, router)
+		langRouterMap[routerConfig.ID] = router
+	}
+
+	resolving := make(map[string]struct{})
+
+	for _, router := range builtRouters {
+		if err := withNestedRouters(router, langRouterMap, resolving); err != nil {
+			shutdownBuilt()
+
+			return nil, err
+		}
+	}
+
+	status := &schemas.ConfigReload{
+		ReloadedAt: int(time.Now().UTC().Unix()),
+		Success:    true,
+	}
+
+	for _, routerConfig := range cfg.LanguageRouters {
+		if !routerConfig.Enabled || rewiredIDs[routerConfig.ID] {
+			continue
+		}
+
+		_, built := builtRouters[routerConfig.ID]
+		_, running := (*r.langRouterMap)[routerConfig.ID]
+
+		switch {
+		case built && running:
+			status.Updated = append(status.Updated, routerConfig.ID)
+		case built:
+			status.Added = append(status.Added, routerConfig.ID)
+		}
+	}
+
+	for _, existingRouter := range r.langRouters {
+		_, built := builtRouters[existingRouter.ID()]
+		_, kept := langRouterMap[existingRouter.ID()]
+
+		if kept && !built {
+			continue
+		}
+
+		if !kept {
+			status.Removed = append(status.Removed, existingRouter.ID())
+		}
+
+		existingRouter.Shutdown()
+		r.metadataCache.Invalidate(existingRouter.ID())
+	}
+
+	r.langRouters = langRouters
+	r.langRouterMap = &langRouterMap
+	r.Config.LanguageRouters = cfg.LanguageRouters
+
+	status.RestartRequired = r.restartRequired(cfg)
+
+	return status, nil
+}
+
+// restartRequired lists router sections that changed, but can't be applied without restart
+func (r *RouterManager) restartRequired(cfg *Config) []string {
+	var sections []string
+
+	if !reflect.DeepEqual(r.Config.ImageRouters, cfg.ImageRouters) {
+		sections = append(sections, "routers.image")
+	}
+
+	if !reflect.DeepEqual(r.Config.AudioRouters, cfg.AudioRouters) {
+		sections = append(sections, "routers.audio")
+	}
+
+	if !reflect.DeepEqual(r.Config.ModerationRouters, cfg.ModerationRouters) {
+		sections = append(sections, "routers.moderation")
+	}
+
+	if !reflect.DeepEqual(r.Config.Metadata, cfg.Metadata) {
+		sections = append(sections, "routers.metadata")
+	}
+
+	return sections
+}
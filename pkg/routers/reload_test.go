@@ -0,0 +1,168 @@
+package routers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers/openai"
+	"glide/pkg/telemetry"
+)
+
+func TestRouterManager_Reload(t *testing.T) {
+	manager, err := NewManager(&Config{
+		LanguageRouters: []LangRouterConfig{
+			newLangRouterConfig("default", "openai"),
+			newLangRouterConfig("updated", "openai"),
+			newLangRouterConfig("removed", "openai"),
+		},
+	}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	defaultRouter, err := manager.GetLangRouter("default")
+	require.NoError(t, err)
+
+	updatedRouter, err := manager.GetLangRouter("updated")
+	require.NoError(t, err)
+
+	status, err := manager.Reload(&Config{
+		LanguageRouters: []LangRouterConfig{
+			newLangRouterConfig("default", "openai"),
+			newLangRouterConfig("updated", "openai", "another_openai"),
+			newLangRouterConfig("added", "openai"),
+		},
+	})
+	require.NoError(t, err)
+
+	require.True(t, status.Success)
+	require.Equal(t, []string{"added"}, status.Added)
+	require.Equal(t, []string{"updated"}, status.Updated)
+	require.Equal(t, []string{"removed"}, status.Removed)
+	require.Empty(t, status.RestartRequired)
+	require.Same(t, status, manager.LastReload())
+
+	foundRouter, err := manager.GetLangRouter("default")
+	require.NoError(t, err)
+	require.Same(t, defaultRouter, foundRouter)
+
+	foundRouter, err = manager.GetLangRouter("updated")
+	require.NoError(t, err)
+	require.NotSame(t, updatedRouter, foundRouter)
+	require.Len(t, foundRouter.chatModels, 2)
+
+	_, err = manager.GetLangRouter("removed")
+	require.ErrorIs(t, err, ErrRouterNotFound)
+
+	require.Len(t, manager.GetLangRouters(), 3)
+	require.Len(t, manager.Config.LanguageRouters, 3)
+}
+
+func TestRouterManager_ReloadDisabledRouter(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	disabledConfig := newLangRouterConfig("default", "openai")
+	disabledConfig.Enabled = false
+
+	status, err := manager.Reload(&Config{
+		LanguageRouters: []LangRouterConfig{disabledConfig, newLangRouterConfig("new", "openai")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"default"}, status.Removed)
+
+	_, err = manager.GetLangRouter("default")
+	require.ErrorIs(t, err, ErrRouterNotFound)
+}
+
+func TestRouterManager_ReloadFailureKeepsRouters(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	defaultRouter, err := manager.GetLangRouter("default")
+	require.NoError(t, err)
+
+	brokenConfig := newLangRouterConfig("default", "openai")
+	brokenConfig.Models[0].OpenAI = (*openai.Config)(nil)
+
+	status, err := manager.Reload(&Config{
+		LanguageRouters: []LangRouterConfig{brokenConfig, newLangRouterConfig("new", "openai")},
+	})
+	require.Error(t, err)
+	require.False(t, status.Success)
+	require.NotEmpty(t, status.Error)
+
+	foundRouter, err := manager.GetLangRouter("default")
+	require.NoError(t, err)
+	require.Same(t, defaultRouter, foundRouter)
+
+	_, err = manager.GetLangRouter("new")
+	require.ErrorIs(t, err, ErrRouterNotFound)
+	require.Len(t, manager.Config.LanguageRouters, 1)
+}
+
+func TestRouterManager_ReloadRestartRequired(t *testing.T) {
+	manager := newAdminManagerMock(t)
+
+	status, err := manager.Reload(&Config{
+		LanguageRouters: []LangRouterConfig{newLangRouterConfig("default", "openai")},
+		Metadata:        DefaultMetadataConfig(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"routers.metadata"}, status.RestartRequired)
+	require.Empty(t, status.Updated)
+}
+
+func TestRouterManager_ReloadNestedRouters(t *testing.T) {
+	manager, err := NewManager(&Config{
+		LanguageRouters: []LangRouterConfig{
+			newLangRouterConfig("pool", "openai"),
+		},
+	}, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	// the nested router is added along with its member
+	status, err := manager.Reload(&Config{
+		LanguageRouters: []LangRouterConfig{
+			newNestedRouterConfig("chain", "pool", "backup"),
+			newLangRouterConfig("pool", "openai"),
+			newLangRouterConfig("backup", "backup-openai"),
+		},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"chain", "backup"}, status.Added)
+
+	chainRouter, err := manager.GetLangRouter("chain")
+	require.NoError(t, err)
+	require.Len(t, chainRouter.chatModels, 2)
+	require.NotNil(t, chainRouter.chatRouting)
+
+	// the changed member is picked up by the unchanged nested router
+	status, err = manager.Reload(&Config{
+		LanguageRouters: []LangRouterConfig{
+			newNestedRouterConfig("chain", "pool", "backup"),
+			newLangRouterConfig("pool", "openai", "another-openai"),
+			newLangRouterConfig("backup", "backup-openai"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pool"}, status.Updated)
+
+	poolRouter, err := manager.GetLangRouter("pool")
+	require.NoError(t, err)
+
+	foundRouter, err := manager.GetLangRouter("chain")
+	require.NoError(t, err)
+	require.NotSame(t, chainRouter, foundRouter)
+	require.Len(t, foundRouter.chatModels, 3)
+	require.Same(t, poolRouter.chatModels[0], foundRouter.chatModels[0])
+
+	// the removed member fails the reload
+	status, err = manager.Reload(&Config{
+		LanguageRouters: []LangRouterConfig{
+			newNestedRouterConfig("chain", "pool", "backup"),
+			newLangRouterConfig("pool", "openai", "another-openai"),
+		},
+	})
+	require.Error(t, err)
+	require.False(t, status.Success)
+
+	_, err = manager.GetLangRouter("backup")
+	require.NoError(t, err)
+}
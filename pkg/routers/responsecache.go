@@ -0,0 +1,370 @@
+package routers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/cache"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// ResponseCacheMode defines which requests are served with the same cached response
+type ResponseCacheMode = string
+
+const (
+	ExactCacheMode    ResponseCacheMode = "exact"    // requests with the same normalized messages
+	SemanticCacheMode ResponseCacheMode = "semantic" // also requests with prompts similar enough by their embeddings
+)
+
+// ResponseCacheConfig defines caching of chat responses, so repeated prompts are not sent to models again.
+// Entries are kept in memory unless Redis or SQLite is configured
+type ResponseCacheConfig struct {
+	Enabled      bool                 `yaml:"enabled" json:"enabled"`
+	Mode         ResponseCacheMode    `yaml:"mode" json:"mode" validate:"oneof=exact semantic"`
+	Semantic     *SemanticCacheConfig `yaml:"semantic,omitempty" json:"semantic,omitempty" validate:"required_if=Mode semantic"` // how similar prompts are found in the semantic mode
+	TTL          *fields.Duration     `yaml:"ttl" json:"ttl" swaggertype:"primitive,string"`
+	MaxEntries   int                  `yaml:"max_entries" json:"max_entries" validate:"gte=0"` // the in-memory cache size, zero means no limit
+	MaxBytes     int64                `yaml:"max_bytes" json:"max_bytes" validate:"gte=0"`     // the in-memory cache memory limit, zero means no limit
+	StreamReplay *StreamReplayConfig  `yaml:"stream_replay" json:"stream_replay"`              // how cached responses are streamed to streaming chat clients
+	Redis        *cache.RedisConfig   `yaml:"redis,omitempty" json:"redis,omitempty"`
+	SQLite       *cache.SQLiteConfig  `yaml:"sqlite,omitempty" json:"sqlite,omitempty"`
+}
+
+func DefaultResponseCacheConfig() *ResponseCacheConfig {
+	defaultTTL := 1 * time.Hour
+
+	return &ResponseCacheConfig{
+		Enabled:      false,
+		Mode:         ExactCacheMode,
+		TTL:          (*fields.Duration)(&defaultTTL),
+		MaxEntries:   10_000,
+		MaxBytes:     128 << 20, // 128MiB
+		StreamReplay: DefaultStreamReplayConfig(),
+	}
+}
+
+func (c *ResponseCacheConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultResponseCacheConfig()
+
+	type plain ResponseCacheConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// responseCacheKey is everything that shapes the chat response, so only identical requests share cached responses.
+// Without messages, it's the scope semantically similar prompts are matched within
+type responseCacheKey struct {
+	Messages []schemas.ChatMessage        `json:"messages,omitempty"`
+	Override *schemas.OverrideChatRequest `json:"override,omitempty"`
+	ModelID  string                       `json:"modelId,omitempty"`
+	Requires []schemas.Capability         `json:"requires,omitempty"`
+	Params   string                       `json:"params,omitempty"`
+}
+
+// cachedChatResponse is the chat response as it's kept in the store
+type cachedChatResponse struct {
+	CachedAt time.Time             `json:"cachedAt"`
+	Response *schemas.ChatResponse `json:"response"`
+}
+
+// ResponseCache serves repeated chat requests with the response to the first one, skipping models entirely.
+// Requests match when their normalized messages & the router model params are the same.
+// In the semantic mode, requests also match when their prompts are similar enough.
+// Clients may bypass or constrain the cache per request (see requestCacheControl)
+type ResponseCache struct {
+	routerID     RouterID
+	store        cache.Store
+	ttl          time.Duration
+	params       string
+	semantic     *SemanticCache
+	replay       *StreamReplayConfig
+	hits         *telemetry.Counter
+	semanticHits *telemetry.Counter
+	misses       *telemetry.Counter
+	bypasses     *telemetry.Counter
+	errors       *telemetry.Counter
+	logger       *zap.Logger
+}
+
+func NewResponseCache(
+	routerID RouterID,
+	cfg *ResponseCacheConfig,
+	models []providers.LangModelConfig,
+	tel *telemetry.Telemetry,
+) (*ResponseCache, error) {
+	var semantic *SemanticCache
+
+	if cfg.Mode == SemanticCacheMode {
+		if cfg.Semantic == nil {
+			return nil, fmt.Errorf("router \"%v\" semantic cache must have the embedding model configured", routerID)
+		}
+
+		var err error
+
+		semantic, err = NewSemanticCache(routerID, cfg.Semantic, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	store, err := newStore(routerID, "response_cache", cfg.MaxEntries, cfg.MaxBytes, cfg.Redis, cfg.SQLite, tel)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := cfg.StreamReplay
+
+	if replay == nil {
+		replay = DefaultStreamReplayConfig()
+	}
+
+	var ttl time.Duration
+
+	if cfg.TTL != nil {
+		ttl = time.Duration(*cfg.TTL)
+	}
+
+	return &ResponseCache{
+		routerID:     routerID,
+		store:        store,
+		ttl:          ttl,
+		params:       modelParams(models),
+		semantic:     semantic,
+		replay:       replay,
+		hits:         tel.M().Counter(fmt.Sprintf("routers.%v.response_cache.hits", routerID)),
+		semanticHits: tel.M().Counter(fmt.Sprintf("routers.%v.response_cache.semantic_hits", routerID)),
+		misses:       tel.M().Counter(fmt.Sprintf("routers.%v.response_cache.misses", routerID)),
+		bypasses:     tel.M().Counter(fmt.Sprintf("routers.%v.response_cache.bypasses", routerID)),
+		errors:       tel.M().Counter(fmt.Sprintf("routers.%v.response_cache.errors", routerID)),
+		logger:       tel.L().With(zap.String("routerID", routerID)),
+	}, nil
+}
+
+// HitRate returns the share of requests served from the cache
+func (c *ResponseCache) HitRate() float64 {
+	hits, misses := c.hits.Value(), c.misses.Value()
+
+	if hits+misses == 0 {
+		return 0
+	}
+
+	return float64(hits) / float64(hits+misses)
+}
+
+// cacheLookup is what's needed to cache the fresh response once the request has missed the cache
+type cacheLookup struct {
+	control cacheControl
+	key     string
+	scope   string
+	vector  []float64
+}
+
+// Chat serves the request from the cache or with the chat func caching the response.
+// Cache store failures are logged and treated as misses, so they never fail the request
+func (c *ResponseCache) Chat(
+	ctx context.Context,
+	req *schemas.ChatRequest,
+	chat func(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error),
+) (*schemas.ChatResponse, error) {
+	cached, lookup := c.lookup(ctx, req)
+	if cached != nil {
+		return cached, nil
+	}
+
+	resp, err := chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.save(ctx, lookup, resp)
+
+	return resp, nil
+}
+
+// lookup returns the cached response to the request or nil if the request misses the cache
+func (c *ResponseCache) lookup(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, *cacheLookup) {
+	lookup := &cacheLookup{control: requestCacheControl(ctx, req)}
+
+	if lookup.control.noCache && lookup.control.noStore {
+		c.bypasses.Inc()
+
+		return nil, lookup
+	}
+
+	messages := normalizeMessages(req)
+	lookup.key = c.key(req, messages)
+
+	if cached := c.get(ctx, lookup.key, lookup.control); cached != nil {
+		c.hits.Inc()
+
+		return cached, lookup
+	}
+
+	if c.semantic != nil {
+		lookup.scope = c.key(req, nil)
+		lookup.vector = c.semantic.Embed(ctx, messages)
+
+		if similarKey, found := c.semantic.Search(lookup.scope, lookup.vector); lookup.vector != nil && found {
+			if cached := c.get(ctx, similarKey, lookup.control); cached != nil {
+				c.hits.Inc()
+				c.semanticHits.Inc()
+
+				return cached, lookup
+			}
+		}
+	}
+
+	if lookup.control.noCache {
+		c.bypasses.Inc()
+	} else {
+		c.misses.Inc()
+	}
+
+	return nil, lookup
+}
+
+// save caches the fresh response to the request that has missed the cache
+func (c *ResponseCache) save(ctx context.Context, lookup *cacheLookup, resp *schemas.ChatResponse) {
+	if lookup.control.noStore {
+		return
+	}
+
+	c.set(ctx, lookup.key, resp)
+
+	if lookup.vector != nil {
+		c.semantic.Add(lookup.scope, lookup.key, lookup.vector)
+	}
+}
+
+// Close releases the cache store
+func (c *ResponseCache) Close() error {
+	if c.semantic != nil {
+		c.semantic.Shutdown()
+	}
+
+	return c.store.Close()
+}
+
+// key returns the cache key of the request with the given messages.
+// The key without messages is the scope of the request in the semantic index
+func (c *ResponseCache) key(req *schemas.ChatRequest, messages []schemas.ChatMessage) string {
+	body, _ := json.Marshal(responseCacheKey{
+		Messages: messages,
+		Override: req.Override,
+		ModelID:  req.ModelID,
+		Requires: req.Requires,
+		Params:   c.params,
+	})
+
+	contentHash := sha256.Sum256(body)
+
+	return fmt.Sprintf("chat:%v:%v", c.routerID, hex.EncodeToString(contentHash[:]))
+}
+
+// get returns the cached response unless the request doesn't accept cached responses or the response is too old
+func (c *ResponseCache) get(ctx context.Context, key string, control cacheControl) *schemas.ChatResponse {
+	if control.noCache {
+		return nil
+	}
+
+	value, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.errors.Inc()
+		c.logger.Warn("failed to read from response cache", zap.Error(err))
+
+		return nil
+	}
+
+	if !found {
+		return nil
+	}
+
+	var cached cachedChatResponse
+
+	if err := json.Unmarshal(value, &cached); err != nil || cached.Response == nil {
+		c.errors.Inc()
+		c.logger.Warn("failed to decode cached chat response", zap.Error(err))
+
+		return nil
+	}
+
+	if !control.fresh(cached.CachedAt) {
+		return nil
+	}
+
+	cached.Response.Cached = true
+
+	if cached.Response.Cost != nil {
+		cached.Response.Cost = new(float64) // cached responses cost nothing
+	}
+
+	return cached.Response
+}
+
+func (c *ResponseCache) set(ctx context.Context, key string, resp *schemas.ChatResponse) {
+	cached := *resp
+	// the routing trace & the timing describe the original request only
+	cached.Routing = nil
+	cached.Timing = nil
+
+	value, err := json.Marshal(&cachedChatResponse{CachedAt: time.Now(), Response: &cached})
+	if err != nil {
+		c.errors.Inc()
+
+		return
+	}
+
+	if err := c.store.Set(ctx, key, value, c.ttl); err != nil {
+		c.errors.Inc()
+		c.logger.Warn("failed to write to response cache", zap.Error(err))
+	}
+}
+
+func normalizeMessages(req *schemas.ChatRequest) []schemas.ChatMessage {
+	messages := make([]schemas.ChatMessage, 0, len(req.MessageHistory)+1)
+
+	for _, message := range append(slices.Clone(req.MessageHistory), req.Message) {
+		messages = append(messages, normalizeMessage(message))
+	}
+
+	return messages
+}
+
+// normalizeMessage drops differences that don't change the meaning of the message (e.g. extra whitespace)
+func normalizeMessage(message schemas.ChatMessage) schemas.ChatMessage {
+	return schemas.ChatMessage{
+		Role:    strings.ToLower(strings.TrimSpace(message.Role)),
+		Content: strings.Join(strings.Fields(message.Content), " "),
+		Name:    message.Name,
+	}
+}
+
+// modelParams fingerprints generation params of the router models, so cached responses expire once the params change
+func modelParams(models []providers.LangModelConfig) string {
+	params := make(map[string]*providers.ParamsConfig, len(models))
+
+	for _, model := range models {
+		if model.Params != nil {
+			params[model.ID] = model.Params
+		}
+	}
+
+	if len(params) == 0 {
+		return ""
+	}
+
+	body, _ := json.Marshal(params)
+	paramsHash := sha256.Sum256(body)
+
+	return hex.EncodeToString(paramsHash[:])
+}
@@ -0,0 +1,198 @@
+package routers
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+// letterEmbedder embeds texts as vectors of their letter counts, so texts with similar words get similar vectors
+type letterEmbedder struct{}
+
+func (p *letterEmbedder) Provider() string { return "letter_embedder" }
+
+func (p *letterEmbedder) SupportChatStream() bool { return false }
+
+func (p *letterEmbedder) SupportEmbed() bool { return true }
+
+func (p *letterEmbedder) Chat(_ context.Context, _ *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	return nil, clients.ErrProviderUnavailable
+}
+
+func (p *letterEmbedder) ChatStream(_ context.Context, _ *schemas.ChatStreamRequest) (clients.ChatStream, error) {
+	return nil, clients.ErrChatStreamNotImplemented
+}
+
+func (p *letterEmbedder) Embed(_ context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	vector := make([]float64, 26)
+
+	for _, letter := range strings.ToLower(req.Input[0]) {
+		if letter >= 'a' && letter <= 'z' {
+			vector[letter-'a']++
+		}
+	}
+
+	return &schemas.EmbedResponse{
+		ModelResponse: schemas.EmbedModelResponse{Embeddings: []schemas.Embedding{{Vector: vector}}},
+	}, nil
+}
+
+func newSemanticResponseCache(t *testing.T) *ResponseCache {
+	responseCache, err := NewResponseCache("router", DefaultResponseCacheConfig(), nil, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	responseCache.semantic = &SemanticCache{
+		model:     providers.NewLangModel("embedder", &letterEmbedder{}, health.DefaultErrorBudget(), *latency.DefaultConfig(), 1),
+		index:     NewMemoryVectorIndex(10),
+		threshold: DefaultSemanticCacheConfig().Threshold,
+		errors:    telemetry.NewTelemetryMock().M().Counter("semantic_errors"),
+		logger:    telemetry.NewLoggerMock(),
+	}
+
+	return responseCache
+}
+
+func TestResponseCache_ServesRepeatedPrompts(t *testing.T) {
+	ctx := context.Background()
+	responseCache, err := NewResponseCache("router", DefaultResponseCacheConfig(), nil, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+
+	resp, err := responseCache.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	// the same prompt up to whitespace
+	resp, err = responseCache.Chat(ctx, schemas.NewChatFromStr("  tell me a\n dad joke "), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Equal(t, "tell me a dad joke", resp.ModelResponse.Message.Content)
+
+	pinnedReq := schemas.NewChatFromStr("tell me a dad joke")
+	pinnedReq.ModelID = "openai"
+
+	resp, err = responseCache.Chat(ctx, pinnedReq, countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	require.Equal(t, int32(2), calls.Load())
+	require.InDelta(t, 1.0/3, responseCache.HitRate(), 0.01)
+}
+
+func TestResponseCache_ModelParamsChangeKeys(t *testing.T) {
+	temperature := 0.2
+	models := []providers.LangModelConfig{{ID: "openai", Params: &providers.ParamsConfig{Temperature: &temperature}}}
+
+	responseCache, err := NewResponseCache("router", DefaultResponseCacheConfig(), models, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	req := schemas.NewChatFromStr("tell me a dad joke")
+
+	key := responseCache.key(req, normalizeMessages(req))
+
+	temperature = 0.9
+	responseCache.params = modelParams(models)
+
+	require.NotEqual(t, key, responseCache.key(req, normalizeMessages(req)))
+	require.Empty(t, modelParams(nil))
+}
+
+func TestResponseCache_ServesSimilarPrompts(t *testing.T) {
+	ctx := context.Background()
+	responseCache := newSemanticResponseCache(t)
+
+	var calls atomic.Int32
+
+	resp, err := responseCache.Chat(ctx, schemas.NewChatFromStr("How do I reset my password?"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	resp, err = responseCache.Chat(ctx, schemas.NewChatFromStr("how do i reset my password"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Equal(t, "How do I reset my password?", resp.ModelResponse.Message.Content)
+
+	resp, err = responseCache.Chat(ctx, schemas.NewChatFromStr("What are your opening hours on weekends?"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	// similar prompts of requests pinned to another model don't match
+	pinnedReq := schemas.NewChatFromStr("how do i reset my password")
+	pinnedReq.ModelID = "openai"
+
+	resp, err = responseCache.Chat(ctx, pinnedReq, countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	require.Equal(t, int32(3), calls.Load())
+	require.Equal(t, int64(1), responseCache.semanticHits.Value())
+}
+
+func TestLangRouter_GuardrailChecksSimilarPrompts(t *testing.T) {
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "Use the forgot password link"}}),
+			health.NewErrorBudget(3, health.SEC),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	router := LangRouter{
+		routerID:      "test_router",
+		Config:        &LangRouterConfig{Guardrail: &GuardrailConfig{Moderation: "moderation_router"}},
+		retry:         retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+		chatRouting:   routing.NewPriority([]providers.Model{langModels[0]}),
+		chatModels:    langModels,
+		responseCache: newSemanticResponseCache(t),
+		tel:           telemetry.NewTelemetryMock(),
+		logger:        telemetry.NewLoggerMock(),
+	}
+
+	router.WithGuardrail(newModerationRouterMock([]ptesting.RespMock{{Msg: ""}, {Msg: "harassment"}}))
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("How do I reset my password?"))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	// the flagged prompt is close enough to the cached one, but it never gets the cached answer
+	_, err = router.Chat(context.Background(), schemas.NewChatFromStr("how do i reset my password!!!"))
+	require.ErrorIs(t, err, ErrContentFlagged)
+	require.Zero(t, router.responseCache.semanticHits.Value())
+}
+
+func TestMemoryVectorIndex_DropsOldestEntries(t *testing.T) {
+	index := NewMemoryVectorIndex(2)
+
+	index.Add("scope", "a", []float64{1, 0})
+	index.Add("scope", "b", []float64{0, 1})
+
+	key, similarity, found := index.Search("scope", []float64{1, 0.1})
+	require.True(t, found)
+	require.Equal(t, "a", key)
+	require.InDelta(t, 0.995, similarity, 0.001)
+
+	index.Add("scope", "c", []float64{0.5, 0.5})
+
+	key, _, found = index.Search("scope", []float64{1, 0.1})
+	require.True(t, found)
+	require.Equal(t, "c", key)
+
+	_, _, found = index.Search("other", []float64{1, 0})
+	require.False(t, found)
+}
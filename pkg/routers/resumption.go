@@ -0,0 +1,54 @@
+package routers
+
+import (
+	"slices"
+
+	"glide/pkg/api/schemas"
+)
+
+// StreamResumptionConfig defines how streaming chats that fail midway are continued by the next router model.
+// The part of the response streamed this far is replayed to the next model, so it continues the response instead of starting over
+type StreamResumptionConfig struct {
+	MaxResumptions int    `yaml:"max_resumptions" json:"max_resumptions" validate:"gte=1"` // how many times one stream may switch models
+	Prompt         string `yaml:"prompt" json:"prompt" validate:"required"`                // asks the next model to continue the streamed response
+}
+
+func DefaultStreamResumptionConfig() *StreamResumptionConfig {
+	return &StreamResumptionConfig{
+		MaxResumptions: 1,
+		Prompt:         "Continue your previous response exactly from where it stopped. Don't repeat anything that has been already said.",
+	}
+}
+
+func (c *StreamResumptionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultStreamResumptionConfig()
+
+	type plain StreamResumptionConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Allows checks if the stream that has switched models the given number of times may be resumed once again
+func (c *StreamResumptionConfig) Allows(resumptions int) bool {
+	return c != nil && resumptions < c.MaxResumptions
+}
+
+// ResumeRequest returns the request that continues the streamed response on the next model.
+// Nothing has to be replayed when the stream failed before the first chunk, so the original request is sent as is
+func (c *StreamResumptionConfig) ResumeRequest(req *schemas.ChatStreamRequest, streamed string) *schemas.ChatStreamRequest {
+	if streamed == "" {
+		return req
+	}
+
+	resumeReq := *req
+
+	resumeReq.MessageHistory = append(
+		slices.Clone(req.MessageHistory),
+		req.Message,
+		schemas.ChatMessage{Role: "assistant", Content: streamed},
+	)
+	resumeReq.Message = schemas.ChatMessage{Role: "user", Content: c.Prompt}
+	resumeReq.Override = nil
+
+	return &resumeReq
+}
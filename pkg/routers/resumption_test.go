@@ -0,0 +1,103 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestStreamResumptionConfig_ResumeRequest(t *testing.T) {
+	config := DefaultStreamResumptionConfig()
+	req := schemas.NewChatStreamFromStr("tell me a dad joke")
+
+	require.Same(t, req, config.ResumeRequest(req, ""))
+
+	resumeReq := config.ResumeRequest(req, "Knock knock")
+
+	require.Equal(t, []schemas.ChatMessage{req.Message, {Role: "assistant", Content: "Knock knock"}}, resumeReq.MessageHistory)
+	require.Equal(t, config.Prompt, resumeReq.Message.Content)
+	require.Empty(t, req.MessageHistory)
+
+	require.True(t, config.Allows(0))
+	require.False(t, config.Allows(1))
+
+	var disabled *StreamResumptionConfig
+
+	require.False(t, disabled.Allows(0))
+}
+
+func TestLangRouter_ChatStream_ResumesOnNextModel(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+	streamErr := clients.ErrProviderUnavailable
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewStreamProviderMock([]ptesting.RespStreamMock{
+				ptesting.NewRespStreamMock(&[]ptesting.RespMock{{Msg: "Knock"}, {Err: &streamErr}}),
+			}),
+			budget,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewStreamProviderMock([]ptesting.RespStreamMock{
+				ptesting.NewRespStreamMock(&[]ptesting.RespMock{{Msg: " knock"}, {Msg: ". Who's there?"}}),
+			}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:          "test_stream_router",
+		Config:            &LangRouterConfig{StreamResumption: DefaultStreamResumptionConfig()},
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatStreamRouting: routing.NewPriority(models),
+		chatStreamModels:  langModels,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+
+	respC := make(chan *schemas.ChatStreamMessage, 10)
+
+	router.ChatStream(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"), respC)
+	close(respC)
+
+	messages := make([]*schemas.ChatStreamMessage, 0, 4)
+
+	for message := range respC {
+		require.Nil(t, message.Error)
+
+		messages = append(messages, message)
+	}
+
+	require.Len(t, messages, 4)
+
+	marker := messages[1].Chunk
+	require.Equal(t, "second", marker.ModelID)
+	require.Equal(t, &schemas.StreamResumption{FailedModelID: "first", Error: streamErr.Error(), StreamedChars: 5}, marker.Resumption)
+
+	require.Equal(t, "Knock", messages[0].Chunk.ModelResponse.Message.Content)
+	require.Equal(t, " knock", messages[2].Chunk.ModelResponse.Message.Content)
+	require.Nil(t, messages[2].Chunk.Resumption)
+}
@@ -3,13 +3,19 @@ package routers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"glide/pkg/routers/retry"
 	"go.uber.org/zap"
 
 	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
 
 	"glide/pkg/api/schemas"
+	"glide/pkg/events"
 	"glide/pkg/routers/routing"
 	"glide/pkg/telemetry"
 )
@@ -17,6 +23,7 @@ import (
 var (
 	ErrNoModels         = errors.New("no models configured for router")
 	ErrNoModelAvailable = errors.New("could not handle request because all providers are not available")
+	ErrInternal         = errors.New("internal error occurred while processing the request")
 )
 
 type RouterID = string
@@ -26,14 +33,34 @@ type LangRouter struct {
 	Config            *LangRouterConfig
 	chatModels        []*providers.LanguageModel
 	chatStreamModels  []*providers.LanguageModel
+	embedModels       []*providers.LanguageModel
 	chatRouting       routing.LangModelRouting
 	chatStreamRouting routing.LangModelRouting
+	embedRouting      routing.LangModelRouting
+	guardrail         *Guardrail
+	classifier        *Classifier
+	rules             *RulesEngine
+	embedCache        *EmbedCache
+	responseCache     *ResponseCache
+	idempotency       *Idempotency
+	shadow            *Shadow
+	hedger            *Hedger
+	queue             *RequestQueue
+	nested            bool // models belong to nested routers
+	spendBudget       *providers.SpendBudget
+	healthWatcher     *HealthWatcher
+	events            *events.Dispatcher
+	fallbackStorm     *FallbackStormDetector
 	retry             *retry.ExpRetry
 	tel               *telemetry.Telemetry
 	logger            *zap.Logger
 }
 
 func NewLangRouter(cfg *LangRouterConfig, tel *telemetry.Telemetry) (*LangRouter, error) {
+	if len(cfg.Routers) > 0 {
+		return newNestedLangRouter(cfg, tel)
+	}
+
 	chatModels, chatStreamModels, err := cfg.BuildModels(tel)
 	if err != nil {
 		return nil, err
@@ -44,34 +71,354 @@ func NewLangRouter(cfg *LangRouterConfig, tel *telemetry.Telemetry) (*LangRouter
 		return nil, err
 	}
 
+	embedModels := make([]*providers.LanguageModel, 0, len(chatModels))
+
+	for _, model := range chatModels {
+		if model.SupportEmbed() {
+			embedModels = append(embedModels, model)
+		}
+	}
+
+	embedRouting, err := cfg.BuildEmbedRouting(embedModels)
+	if err != nil {
+		return nil, err
+	}
+
 	router := &LangRouter{
 		routerID:          cfg.ID,
 		Config:            cfg,
 		chatModels:        chatModels,
 		chatStreamModels:  chatStreamModels,
+		embedModels:       embedModels,
 		retry:             cfg.BuildRetry(),
 		chatRouting:       chatRouting,
 		chatStreamRouting: chatStreamRouting,
+		embedRouting:      embedRouting,
 		tel:               tel,
 		logger:            tel.L().With(zap.String("routerID", cfg.ID)),
 	}
 
+	router.withBudget(cfg, tel)
+
+	router.healthWatcher = NewHealthWatcher(cfg.ID, chatModels, router.events, tel)
+	router.healthWatcher.Start()
+
+	if cfg.Hedging != nil {
+		router.hedger = NewHedger(cfg.ID, cfg.Hedging, tel)
+	}
+
+	if cfg.Queue != nil {
+		router.queue = NewRequestQueue(cfg.ID, cfg.Queue, tel)
+	}
+
+	if cfg.Classification != nil {
+		router.classifier, err = NewClassifier(cfg, chatModels, chatStreamModels, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Rules != nil {
+		router.rules, err = NewRulesEngine(cfg, chatModels, chatStreamModels, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.EmbedCache != nil && cfg.EmbedCache.Enabled {
+		router.embedCache, err = NewEmbedCache(cfg.ID, cfg.EmbedCache, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ResponseCache != nil && cfg.ResponseCache.Enabled {
+		router.responseCache, err = NewResponseCache(cfg.ID, cfg.ResponseCache, cfg.Models, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Idempotency != nil {
+		router.idempotency, err = NewIdempotency(cfg.ID, cfg.Idempotency, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Shadow != nil {
+		router.shadow, err = NewShadow(cfg.ID, cfg.Shadow, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return router, err
 }
 
+// newNestedLangRouter creates the router of routers.
+// Its models & routings are wired once the routers it refers to are built (see withNestedRouters)
+func newNestedLangRouter(cfg *LangRouterConfig, tel *telemetry.Telemetry) (*LangRouter, error) {
+	if len(cfg.Models) > 0 {
+		return nil, fmt.Errorf("router \"%v\" must define either models or nested routers, not both", cfg.ID)
+	}
+
+	if cfg.Classification != nil || cfg.Rules != nil {
+		return nil, fmt.Errorf("router \"%v\" routes between nested routers, so it cannot route by classification or rules", cfg.ID)
+	}
+
+	router := &LangRouter{
+		routerID: cfg.ID,
+		Config:   cfg,
+		nested:   true,
+		retry:    cfg.BuildRetry(),
+		tel:      tel,
+		logger:   tel.L().With(zap.String("routerID", cfg.ID)),
+	}
+
+	router.withBudget(cfg, tel)
+
+	if cfg.Hedging != nil {
+		router.hedger = NewHedger(cfg.ID, cfg.Hedging, tel)
+	}
+
+	if cfg.Queue != nil {
+		router.queue = NewRequestQueue(cfg.ID, cfg.Queue, tel)
+	}
+
+	if cfg.EmbedCache != nil && cfg.EmbedCache.Enabled {
+		var err error
+
+		router.embedCache, err = NewEmbedCache(cfg.ID, cfg.EmbedCache, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ResponseCache != nil && cfg.ResponseCache.Enabled {
+		var err error
+
+		router.responseCache, err = NewResponseCache(cfg.ID, cfg.ResponseCache, cfg.Models, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Idempotency != nil {
+		var err error
+
+		router.idempotency, err = NewIdempotency(cfg.ID, cfg.Idempotency, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Shadow != nil {
+		var err error
+
+		router.shadow, err = NewShadow(cfg.ID, cfg.Shadow, tel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return router, nil
+}
+
+// withBudget sets up the router spend budget & the delivery of router events
+func (r *LangRouter) withBudget(cfg *LangRouterConfig, tel *telemetry.Telemetry) {
+	if cfg.Budget != nil {
+		r.spendBudget = providers.NewSpendBudget(cfg.Budget)
+	}
+
+	webhooks := cfg.Webhooks
+
+	if cfg.Events != nil {
+		webhooks = append([]*events.DeliveryConfig{cfg.Events}, webhooks...)
+	}
+
+	r.events = events.NewDispatcher(webhooks, tel)
+
+	if cfg.FallbackStorm != nil {
+		r.fallbackStorm = NewFallbackStormDetector(cfg.FallbackStorm)
+	}
+}
+
 func (r *LangRouter) ID() RouterID {
 	return r.routerID
 }
 
+// WithGuardrail makes the router check chat requests with the given moderation router
+func (r *LangRouter) WithGuardrail(moderator *ModerationRouter) {
+	r.guardrail = NewGuardrail(r.Config.Guardrail, moderator, r.logger)
+}
+
+// Shutdown stops background activities of the router models
+func (r *LangRouter) Shutdown() {
+	// chat models include all router models, models of nested routers are shut down by their routers
+	if !r.nested {
+		r.healthWatcher.Stop()
+
+		for _, model := range r.chatModels {
+			model.Shutdown()
+		}
+	}
+
+	if r.classifier != nil {
+		r.classifier.Shutdown()
+	}
+
+	if r.shadow != nil {
+		r.shadow.Shutdown()
+	}
+
+	if r.embedCache != nil {
+		if err := r.embedCache.Close(); err != nil {
+			r.logger.Warn("failed to close embedding cache", zap.Error(err))
+		}
+	}
+
+	if r.responseCache != nil {
+		if err := r.responseCache.Close(); err != nil {
+			r.logger.Warn("failed to close response cache", zap.Error(err))
+		}
+	}
+
+	if r.idempotency != nil {
+		if err := r.idempotency.Close(); err != nil {
+			r.logger.Warn("failed to close idempotency store", zap.Error(err))
+		}
+	}
+}
+
+// Chat serves the request with router models.
+// Requests with an idempotency key are served once, retries get the first response
 func (r *LangRouter) Chat(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	if idempotencyKey, found := requestHeader(ctx, IdempotencyKeyHeader); found && idempotencyKey != "" && r.idempotency != nil && !req.DryRun {
+		return r.idempotency.Chat(ctx, idempotencyKey, req, r.cachedChat)
+	}
+
+	return r.cachedChat(ctx, req)
+}
+
+// cachedChat serves repeated requests from the response cache (if it's enabled).
+// The spend budget & the guardrail are checked before the cache, so cached responses are not served past them
+func (r *LangRouter) cachedChat(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error) {
+	if err := r.admitChat(ctx, req); err != nil {
+		r.countFailure(err)
+
+		return nil, err
+	}
+
+	if r.responseCache == nil || req.DryRun {
+		return r.routeChat(ctx, req)
+	}
+
+	return r.responseCache.Chat(ctx, req, r.routeChat)
+}
+
+// admitChat checks if the chat request may be served at all
+func (r *LangRouter) admitChat(ctx context.Context, req *schemas.ChatRequest) error {
+	if r.spendBudget.Exhausted() {
+		return ErrBudgetExhausted
+	}
+
+	if r.guardrail != nil {
+		return r.guardrail.CheckChat(ctx, req)
+	}
+
+	return nil
+}
+
+func (r *LangRouter) routeChat(ctx context.Context, req *schemas.ChatRequest) (resp *schemas.ChatResponse, err error) {
+	receivedAt := time.Now()
+
+	defer func() { r.countFailure(err) }()
+
 	if len(r.chatModels) == 0 {
 		return nil, ErrNoModels
 	}
 
+	messages := append(slices.Clone(req.MessageHistory), req.Message)
+	promptTokens := clients.EstimateTokens(messages)
+
+	if !fitsAnyModel(r.chatModels, promptTokens) {
+		return nil, ErrContextWindowExceeded
+	}
+
+	if !supportedByAnyModel(r.chatModels, req.Requires) {
+		return nil, ErrCapabilityUnsupported
+	}
+
+	needs := requestNeeds{promptTokens: promptTokens, capabilities: req.Requires}
+	chatRouting := r.chatRouting
+
+	pinnedRouting, err := r.pinnedRouting(ctx, req.ModelID, r.chatModels, needs, chatRouting)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		label       string
+		ruleRouting routing.LangModelRouting
+	)
+
+	if r.rules != nil && pinnedRouting == nil {
+		label, ruleRouting = r.rules.ChatRouting(ctx, messages)
+	}
+
+	switch {
+	case pinnedRouting != nil:
+		chatRouting = pinnedRouting
+	case ruleRouting != nil:
+		chatRouting = ruleRouting
+	case r.classifier != nil:
+		label = r.classifier.Classify(ctx, req.Message.Content)
+		chatRouting = r.classifier.chatRouting(label, chatRouting)
+	}
+
+	if req.DryRun {
+		return r.dryRun(ctx, chatRouting, label, req)
+	}
+
+	pool := label
+	if pinnedRouting != nil {
+		pool = "pinned"
+	}
+
+	tracer := r.newTracer(ctx, req, chatRouting, pool, needs)
+	timer := newResponseTimer(ctx, req, receivedAt)
+
+	defer func() { r.logSlowRequest(ctx, tracer, receivedAt, err) }()
+
+	var mirroredReq *schemas.ChatRequest
+
+	if r.shadow != nil && r.shadow.Sample() {
+		// copied before the message may be overridden for the router model
+		reqCopy := *req
+		mirroredReq = &reqCopy
+	}
+
+	startedAt := time.Now()
 	retryIterator := r.retry.Iterator()
 
+	var (
+		ticket   *queueTicket
+		failures modelFailures
+	)
+
+	defer func() { ticket.Leave() }()
+
 	for retryIterator.HasNext() {
-		modelIterator := r.chatRouting.Iterator()
+		freed := r.queue.watch()
+		modelIterator := r.withinConcurrency(
+			r.fittingModels(
+				r.withinRateLimits(routing.SessionIterator(chatRouting, req.SessionID), r.chatModels),
+				r.chatModels,
+				needs,
+			),
+			r.chatModels,
+		)
 
 		for {
 			model, err := modelIterator.Next()
@@ -91,23 +438,64 @@ func (r *LangRouter) Chat(ctx context.Context, req *schemas.ChatRequest) (*schem
 				}
 			}
 
-			resp, err := langModel.Chat(ctx, req)
+			tracer.attempt(langModel, "")
+
+			var resp *schemas.ChatResponse
+
+			if r.hedger != nil {
+				resp, langModel, err = r.hedgedChat(ctx, chatRouting, langModel, modelIterator, req, tracer, timer)
+			} else {
+				resp, err = r.chat(ctx, chatRouting, langModel, req, timer)
+			}
+
 			if err != nil {
-				r.logger.Warn(
-					"Lang model failed processing chat request",
-					zap.String("modelID", langModel.ID()),
-					zap.String("provider", langModel.Provider()),
-					zap.Error(err),
-				)
+				r.chatFailed(chatRouting, langModel, err, tracer)
+				failures.add(err)
+
+				if !r.Config.Fallback.Allows(err) {
+					return nil, err
+				}
+
+				r.trackFallback(langModel, err)
 
 				continue
 			}
 
 			resp.RouterID = r.routerID
+			resp.Deprecation = r.deprecation(langModel)
+			resp.Routing = tracer.served(langModel)
+			resp.Timing = timer.served()
+
+			if mirroredReq != nil {
+				r.shadow.Mirror(mirroredReq, time.Since(startedAt))
+			}
 
 			return resp, nil
 		}
 
+		if modelIterator.Saturated() {
+			if r.queue == nil {
+				return nil, ErrRouterSaturated
+			}
+
+			if ticket == nil {
+				if ticket, err = r.queue.Join(); err != nil {
+					return nil, err
+				}
+			}
+
+			// wait for models to free up without spending retries
+			waitStartedAt := time.Now()
+
+			if err = ticket.Wait(ctx, freed); err != nil {
+				return nil, err
+			}
+
+			timer.waited(waitStartedAt)
+
+			continue
+		}
+
 		// no providers were available to handle the request,
 		//  so we have to wait a bit with a hope there is some available next time
 		r.logger.Warn("No healthy model found to serve chat request, wait and retry")
@@ -117,12 +505,195 @@ func (r *LangRouter) Chat(ctx context.Context, req *schemas.ChatRequest) (*schem
 			// something has cancelled the context
 			return nil, err
 		}
+
+		timer.retried()
 	}
 
 	// if we reach this part, then we are in trouble
 	r.logger.Error("No model was available to handle chat request")
 
-	return nil, ErrNoModelAvailable
+	return nil, failures.Err()
+}
+
+func (r *LangRouter) Embed(ctx context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	if len(r.embedModels) == 0 {
+		return nil, ErrNoModels
+	}
+
+	if r.spendBudget.Exhausted() {
+		return nil, ErrBudgetExhausted
+	}
+
+	retryIterator := r.retry.Iterator()
+
+	var failures modelFailures
+
+	for retryIterator.HasNext() {
+		modelIterator := r.withinRateLimits(r.embedRouting.Iterator(), r.embedModels)
+
+		for {
+			model, err := modelIterator.Next()
+
+			if errors.Is(err, routing.ErrNoHealthyModels) {
+				// no healthy model in the pool. Let's retry after some time
+				break
+			}
+
+			langModel := model.(providers.LangModel)
+
+			var resp *schemas.EmbedResponse
+
+			if r.embedCache != nil {
+				resp, err = r.embedCache.Embed(ctx, langModel, req, r.embed)
+			} else {
+				resp, err = r.embed(ctx, langModel, req)
+			}
+
+			if err != nil {
+				r.logger.Warn(
+					"Lang model failed processing embedding request",
+					zap.String("modelID", langModel.ID()),
+					zap.String("provider", langModel.Provider()),
+					zap.Error(err),
+				)
+
+				r.countModelError(langModel, err)
+				r.observeError(r.embedRouting, langModel, err)
+				failures.add(err)
+
+				if !r.Config.Fallback.Allows(err) {
+					return nil, err
+				}
+
+				r.trackFallback(langModel, err)
+
+				continue
+			}
+
+			resp.RouterID = r.routerID
+			resp.Deprecation = r.deprecation(langModel)
+
+			return resp, nil
+		}
+
+		r.logger.Warn("No healthy model found to serve embedding request, wait and retry")
+
+		err := retryIterator.WaitNext(ctx)
+		if err != nil {
+			// something has cancelled the context
+			return nil, err
+		}
+	}
+
+	r.logger.Error("No model was available to handle embedding request")
+
+	return nil, failures.Err()
+}
+
+// chatFailed reports the model failed to serve the chat request
+func (r *LangRouter) chatFailed(
+	chatRouting routing.LangModelRouting,
+	langModel providers.LangModel,
+	err error,
+	tracer *routingTracer,
+) {
+	r.logger.Warn(
+		"Lang model failed processing chat request",
+		zap.String("modelID", langModel.ID()),
+		zap.String("provider", langModel.Provider()),
+		zap.Error(err),
+	)
+
+	r.countModelError(langModel, err)
+	r.observeError(chatRouting, langModel, err)
+	tracer.failed(langModel, err)
+}
+
+// embed calls the model annotating any panic with the router & model context
+func (r *LangRouter) embed(ctx context.Context, langModel providers.LangModel, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	defer func() {
+		if value := recover(); value != nil {
+			if _, ok := value.(*ModelPanic); ok {
+				panic(value)
+			}
+
+			panic(NewModelPanic(r.routerID, langModel.ID(), langModel.Provider(), value))
+		}
+	}()
+
+	startedAt := time.Now()
+
+	defer r.queue.Release()
+
+	resp, err := langModel.Embed(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	latency := time.Since(startedAt)
+
+	r.observe(r.embedRouting, langModel, latency, resp.ModelResponse.TokenUsage.PromptTokens, resp.ModelResponse.TokenUsage)
+	r.trackSpend(langModel, resp.ModelResponse.TokenUsage)
+	resp.Cost = r.trackUsage(ctx, langModel, resp.ModelResponse.TokenUsage, latency)
+
+	return resp, nil
+}
+
+// chat calls the model annotating any panic with the router & model context
+func (r *LangRouter) chat(
+	ctx context.Context,
+	chatRouting routing.LangModelRouting,
+	langModel providers.LangModel,
+	req *schemas.ChatRequest,
+	timer *responseTimer,
+) (*schemas.ChatResponse, error) {
+	defer func() {
+		if value := recover(); value != nil {
+			if _, ok := value.(*ModelPanic); ok {
+				panic(value)
+			}
+
+			panic(NewModelPanic(r.routerID, langModel.ID(), langModel.Provider(), value))
+		}
+	}()
+
+	startedAt := time.Now()
+
+	defer r.queue.Release()
+
+	modelCtx, attempt := timer.attempt(ctx, langModel)
+
+	resp, err := langModel.Chat(modelCtx, req)
+
+	attempt.done(err)
+
+	if err != nil {
+		return resp, err
+	}
+
+	latency := time.Since(startedAt)
+
+	r.observe(chatRouting, langModel, latency, resp.ModelResponse.TokenUsage.ResponseTokens, resp.ModelResponse.TokenUsage)
+	r.trackSpend(langModel, resp.ModelResponse.TokenUsage)
+	r.trackPromptCache(langModel, resp.ModelResponse.TokenUsage)
+	resp.Cost = r.trackUsage(ctx, langModel, resp.ModelResponse.TokenUsage, latency)
+	resp.Experiment = r.experiment(chatRouting, langModel, latency, &resp.ModelResponse.TokenUsage)
+
+	if r.tel.Generations.Enabled() {
+		r.recordGeneration(ctx, ChatGeneration, append(slices.Clone(req.MessageHistory), req.Message), &telemetry.Generation{
+			ModelID:        langModel.ID(),
+			ModelName:      resp.ModelName,
+			Provider:       langModel.Provider(),
+			SessionID:      req.SessionID,
+			Output:         resp.ModelResponse.Message.Content,
+			StartTime:      startedAt.UTC(),
+			PromptTokens:   resp.ModelResponse.TokenUsage.PromptTokens,
+			ResponseTokens: resp.ModelResponse.TokenUsage.ResponseTokens,
+			Cost:           resp.Cost,
+		})
+	}
+
+	return resp, nil
 }
 
 func (r *LangRouter) ChatStream(
@@ -130,6 +701,54 @@ func (r *LangRouter) ChatStream(
 	req *schemas.ChatStreamRequest,
 	respC chan<- *schemas.ChatStreamMessage,
 ) {
+	if r.spendBudget.Exhausted() {
+		respC <- schemas.NewChatStreamError(
+			req.ID,
+			r.routerID,
+			schemas.BudgetExhausted,
+			ErrBudgetExhausted.Error(),
+			req.Metadata,
+			&schemas.ErrorReason,
+		)
+
+		return
+	}
+
+	if r.guardrail != nil {
+		if err := r.guardrail.CheckChatStream(ctx, req); err != nil {
+			errCode, finishReason := schemas.UnknownError, schemas.ErrorReason
+
+			if errors.Is(err, ErrContentFlagged) {
+				errCode, finishReason = schemas.ContentFlagged, schemas.ContentFiltered
+			}
+
+			respC <- schemas.NewChatStreamError(
+				req.ID,
+				r.routerID,
+				errCode,
+				err.Error(),
+				req.Metadata,
+				&finishReason,
+			)
+
+			return
+		}
+	}
+
+	// cached responses are checked against the spend budget & the guardrail too
+	var lookup *cacheLookup
+
+	if r.responseCache != nil {
+		var cached *schemas.ChatResponse
+
+		cached, lookup = r.responseCache.lookup(ctx, streamChatRequest(req))
+		if cached != nil {
+			r.responseCache.Replay(ctx, req, cached, respC)
+
+			return
+		}
+	}
+
 	if len(r.chatStreamModels) == 0 {
 		respC <- schemas.NewChatStreamError(
 			req.ID,
@@ -143,10 +762,92 @@ func (r *LangRouter) ChatStream(
 		return
 	}
 
+	messages := append(slices.Clone(req.MessageHistory), req.Message)
+	promptTokens := clients.EstimateTokens(messages)
+
+	if !fitsAnyModel(r.chatStreamModels, promptTokens) {
+		respC <- schemas.NewChatStreamError(
+			req.ID,
+			r.routerID,
+			schemas.ContextWindowExceeded,
+			ErrContextWindowExceeded.Error(),
+			req.Metadata,
+			&schemas.ErrorReason,
+		)
+
+		return
+	}
+
+	if !supportedByAnyModel(r.chatStreamModels, req.Requires) {
+		respC <- schemas.NewChatStreamError(
+			req.ID,
+			r.routerID,
+			schemas.CapabilityUnsupported,
+			ErrCapabilityUnsupported.Error(),
+			req.Metadata,
+			&schemas.ErrorReason,
+		)
+
+		return
+	}
+
+	needs := requestNeeds{promptTokens: promptTokens, capabilities: req.Requires}
+	chatStreamRouting := r.chatStreamRouting
+
+	pinnedRouting, err := r.pinnedRouting(ctx, req.ModelID, r.chatStreamModels, needs, chatStreamRouting)
+	if err != nil {
+		errCode := schemas.PinnedModelUnavailable
+
+		if errors.Is(err, ErrPinnedModelNotFound) {
+			errCode = schemas.PinnedModelNotFound
+		}
+
+		respC <- schemas.NewChatStreamError(
+			req.ID,
+			r.routerID,
+			errCode,
+			err.Error(),
+			req.Metadata,
+			&schemas.ErrorReason,
+		)
+
+		return
+	}
+
+	var ruleRouting routing.LangModelRouting
+
+	if r.rules != nil && pinnedRouting == nil {
+		ruleRouting = r.rules.ChatStreamRouting(ctx, messages)
+	}
+
+	switch {
+	case pinnedRouting != nil:
+		chatStreamRouting = pinnedRouting
+	case ruleRouting != nil:
+		chatStreamRouting = ruleRouting
+	case r.classifier != nil:
+		chatStreamRouting = r.classifier.ChatStreamRouting(ctx, req.Message.Content, chatStreamRouting)
+	}
+
+	var (
+		modelReq    = req // the request continuing the stream once it's resumed
+		streamed    strings.Builder
+		resumptions int
+		resumption  *schemas.StreamResumption
+		lastChunk   *schemas.ChatStreamChunk
+		failed      bool // the streamed response is not cached once any model has failed midway
+		failures    modelFailures
+		startedAt   = time.Now()
+	)
+
 	retryIterator := r.retry.Iterator()
 
 	for retryIterator.HasNext() {
-		modelIterator := r.chatStreamRouting.Iterator()
+		modelIterator := r.fittingModels(
+			r.withinRateLimits(routing.SessionIterator(chatStreamRouting, req.SessionID), r.chatStreamModels),
+			r.chatStreamModels,
+			needs,
+		)
 
 	NextModel:
 		for {
@@ -158,7 +859,7 @@ func (r *LangRouter) ChatStream(
 			}
 
 			langModel := model.(providers.LangModel)
-			modelRespC, err := langModel.ChatStream(ctx, req)
+			modelRespC, err := langModel.ChatStream(ctx, modelReq)
 			if err != nil {
 				r.logger.Error(
 					"Lang model failed to create streaming chat request",
@@ -167,18 +868,76 @@ func (r *LangRouter) ChatStream(
 					zap.Error(err),
 				)
 
+				r.countModelError(langModel, err)
+				r.observeError(chatStreamRouting, langModel, err)
+				r.trackFallback(langModel, err)
+				failures.add(err)
+
 				continue
 			}
 
+			if resumption != nil {
+				// let clients know that the rest of the response comes from another model
+				respC <- schemas.NewChatStreamChunk(
+					req.ID,
+					r.routerID,
+					req.Metadata,
+					&schemas.ChatStreamChunk{
+						ModelID:    langModel.ID(),
+						Provider:   langModel.Provider(),
+						Resumption: resumption,
+					},
+				)
+
+				resumption = nil
+			}
+
+			deprecation := r.deprecation(langModel)
+			experiment := r.experiment(chatStreamRouting, langModel, 0, nil)
+
 			for chunkResult := range modelRespC {
 				err = chunkResult.Error()
 				if err != nil {
-					r.logger.Warn(
-						"Lang model failed processing streaming chat request",
-						zap.String("modelID", langModel.ID()),
-						zap.String("provider", langModel.Provider()),
-						zap.Error(err),
-					)
+					var streamPanic *providers.StreamPanic
+
+					if errors.As(err, &streamPanic) {
+						r.logger.Error(
+							"Lang model panicked processing streaming chat request",
+							zap.String("modelID", langModel.ID()),
+							zap.String("provider", langModel.Provider()),
+							zap.Any("panic", streamPanic.Value),
+							zap.ByteString("stacktrace", streamPanic.Stack),
+						)
+					} else {
+						r.logger.Warn(
+							"Lang model failed processing streaming chat request",
+							zap.String("modelID", langModel.ID()),
+							zap.String("provider", langModel.Provider()),
+							zap.Error(err),
+						)
+					}
+
+					r.countModelError(langModel, err)
+					r.observeError(chatStreamRouting, langModel, err)
+					failures.add(err)
+
+					failed = true
+
+					if r.Config.StreamResumption.Allows(resumptions) {
+						// the next model picks up the response where the failed one has stopped
+						resumptions++
+						resumption = &schemas.StreamResumption{
+							FailedModelID: langModel.ID(),
+							Error:         err.Error(),
+							StreamedChars: streamed.Len(),
+						}
+						modelReq = r.Config.StreamResumption.ResumeRequest(req, streamed.String())
+
+						r.tel.M().Counter(fmt.Sprintf("routers.%v.stream_resumptions", r.routerID)).Inc()
+						r.trackFallback(langModel, err)
+
+						continue NextModel
+					}
 
 					// It's challenging to hide an error in case of streaming chat as consumer apps
 					//  may have already used all chunks we streamed this far (e.g. showed them to their users like OpenAI UI does),
@@ -186,7 +945,7 @@ func (r *LangRouter) ChatStream(
 					respC <- schemas.NewChatStreamError(
 						req.ID,
 						r.routerID,
-						schemas.ModelUnavailable,
+						clients.ErrorCode(err),
 						err.Error(),
 						req.Metadata,
 						nil,
@@ -196,6 +955,18 @@ func (r *LangRouter) ChatStream(
 				}
 
 				chunk := chunkResult.Chunk()
+				lastChunk = chunk
+
+				streamed.WriteString(chunk.ModelResponse.Message.Content)
+
+				if deprecation != nil {
+					// one notice per stream is enough
+					chunk.Deprecation, deprecation = deprecation, nil
+				}
+
+				if experiment != nil {
+					chunk.Experiment, experiment = experiment, nil
+				}
 
 				respC <- schemas.NewChatStreamChunk(
 					req.ID,
@@ -205,6 +976,26 @@ func (r *LangRouter) ChatStream(
 				)
 			}
 
+			if lookup != nil && lastChunk != nil && !failed {
+				r.responseCache.save(ctx, lookup, streamedChatResponse(r.routerID, lastChunk, streamed.String()))
+			}
+
+			if lastChunk != nil && r.tel.Generations.Enabled() {
+				r.recordGeneration(ctx, ChatStreamGeneration, messages, &telemetry.Generation{
+					ModelID:         lastChunk.ModelID,
+					ModelName:       lastChunk.ModelName,
+					Provider:        lastChunk.Provider,
+					SessionID:       req.SessionID,
+					Output:          streamed.String(),
+					StartTime:       startedAt.UTC(),
+					PromptTokens:    promptTokens,
+					ResponseTokens:  clients.EstimateTextTokens(streamed.String()),
+					TokensEstimated: true,
+				})
+			}
+
+			r.queue.Release()
+
 			return
 		}
 
@@ -237,7 +1028,7 @@ func (r *LangRouter) ChatStream(
 	respC <- schemas.NewChatStreamError(
 		req.ID,
 		r.routerID,
-		schemas.AllModelsUnavailable,
+		failures.Code(),
 		ErrNoModelAvailable.Error(),
 		req.Metadata,
 		&schemas.ErrorReason,
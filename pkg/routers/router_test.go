@@ -125,6 +125,106 @@ func TestLangRouter_Chat_PickThirdHealthy(t *testing.T) {
 	}
 }
 
+func TestLangRouter_Chat_FallbackOnFailure(t *testing.T) {
+	budget := health.NewErrorBudget(10, health.SEC)
+	latConfig := latency.DefaultConfig()
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"primary",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Err: &ErrNoModelAvailable}, {Msg: "2"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"backup",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              telemetry.NewTelemetryMock(),
+		logger:           telemetry.NewLoggerMock(),
+	}
+
+	ctx := context.Background()
+	req := schemas.NewChatFromStr("tell me a dad joke")
+
+	// the primary model is still healthy after one failure, but the request falls down the chain right away
+	for _, modelID := range []string{"backup", "primary"} {
+		resp, err := router.Chat(ctx, req)
+
+		require.NoError(t, err)
+		require.Equal(t, modelID, resp.ModelID)
+	}
+}
+
+func TestLangRouter_Chat_LeastBusy(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewLeastBusyRouting(providers.InFlight, models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              telemetry.NewTelemetryMock(),
+		logger:           telemetry.NewLoggerMock(),
+	}
+
+	ctx := context.Background()
+	req := schemas.NewChatFromStr("tell me a dad joke")
+
+	// idle models share the traffic
+	for _, modelID := range []string{"first", "second"} {
+		resp, err := router.Chat(ctx, req)
+
+		require.NoError(t, err)
+		require.Equal(t, modelID, resp.ModelID)
+	}
+
+	for _, model := range langModels {
+		require.Zero(t, model.InFlight())
+	}
+}
+
 func TestLangRouter_Chat_SuccessOnRetry(t *testing.T) {
 	budget := health.NewErrorBudget(1, health.MILLI)
 	latConfig := latency.DefaultConfig()
@@ -466,3 +566,62 @@ func TestLangRouter_ChatStream_AllModelsUnavailable(t *testing.T) {
 
 	require.Equal(t, []string{schemas.ModelUnavailable, schemas.ModelUnavailable, schemas.AllModelsUnavailable}, errs)
 }
+
+func TestLangRouter_Embed_PickFistHealthy(t *testing.T) {
+	budget := health.NewErrorBudget(1, health.MIN)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewEmbedProviderMock([]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}, {Msg: "3"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewEmbedProviderMock([]ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:     "test_router",
+		Config:       &LangRouterConfig{},
+		retry:        retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+		embedRouting: routing.NewPriority(models),
+		embedModels:  langModels,
+		tel:          telemetry.NewTelemetryMock(),
+		logger:       telemetry.NewLoggerMock(),
+	}
+
+	for _, modelID := range []string{"second", "second"} {
+		resp, err := router.Embed(context.Background(), schemas.NewEmbedFromStr("tell me a dad joke"))
+
+		require.NoError(t, err)
+		require.Equal(t, modelID, resp.ModelID)
+		require.Equal(t, "test_router", resp.RouterID)
+	}
+}
+
+func TestLangRouter_Embed_NoEmbedModels(t *testing.T) {
+	router := LangRouter{
+		routerID: "test_router",
+		Config:   &LangRouterConfig{},
+		retry:    retry.NewExpRetry(3, 2, 1*time.Millisecond, nil),
+		tel:      telemetry.NewTelemetryMock(),
+		logger:   telemetry.NewLoggerMock(),
+	}
+
+	_, err := router.Embed(context.Background(), schemas.NewEmbedFromStr("tell me a dad joke"))
+
+	require.ErrorIs(t, err, ErrNoModels)
+}
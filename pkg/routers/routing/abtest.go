@@ -0,0 +1,163 @@
+package routing
+
+import (
+	"math/rand/v2"
+
+	"glide/pkg/providers"
+)
+
+const (
+	ABTest Strategy = "ab_test"
+)
+
+// ABVariantConfig defines the model serving a share of experiment traffic
+type ABVariantConfig struct {
+	Name       string  `yaml:"name" json:"name" validate:"required"`
+	Model      string  `yaml:"model" json:"model" validate:"required"`                        // ID of the router model that serves the variant
+	Percentage float64 `yaml:"percentage" json:"percentage" validate:"required,gt=0,lte=100"` // share of traffic routed to the variant
+}
+
+// ABTestConfig defines settings of the A/B testing strategy
+type ABTestConfig struct {
+	Experiment string            `yaml:"experiment" json:"experiment" validate:"required"` // the experiment identifier stamped into responses & metrics
+	Variants   []ABVariantConfig `yaml:"variants" json:"variants" validate:"required,min=2,dive"`
+}
+
+// ExperimentRouting is implemented by routings that split traffic between experiment variants
+type ExperimentRouting interface {
+	// Variant finds out which variant of the experiment the model serves
+	Variant(model providers.Model) (experimentID string, variant string, found bool)
+}
+
+type abVariant struct {
+	name       string
+	model      providers.Model
+	percentage float64
+}
+
+// ABTestRouting splits traffic between model variants by the configured percentages.
+//
+//	The variant is picked randomly for each request, so the traffic split converges to the configured one.
+//	When the variant model is unhealthy or fails, the request goes to other variants
+//	proportionally to their percentages, so the experiment doesn't affect availability
+type ABTestRouting struct {
+	experimentID string
+	variants     []abVariant
+}
+
+// NewABTestRouting creates an A/B test routing. Variant models are expected to be in the model pool
+func NewABTestRouting(config *ABTestConfig, models []providers.Model) *ABTestRouting {
+	modelByID := make(map[string]providers.Model, len(models))
+
+	for _, model := range models {
+		modelByID[model.ID()] = model
+	}
+
+	variants := make([]abVariant, 0, len(config.Variants))
+
+	for _, variant := range config.Variants {
+		model, found := modelByID[variant.Model]
+		if !found {
+			// the model may serve the router action only (e.g. no streaming chat support)
+			continue
+		}
+
+		variants = append(variants, abVariant{
+			name:       variant.Name,
+			model:      model,
+			percentage: variant.Percentage,
+		})
+	}
+
+	return &ABTestRouting{
+		experimentID: config.Experiment,
+		variants:     variants,
+	}
+}
+
+func (r *ABTestRouting) Iterator() LangModelIterator {
+	return &ABTestIterator{
+		routing: r,
+		tried:   make(map[string]struct{}, len(r.variants)),
+	}
+}
+
+// Peek returns the model of the healthy variant with the largest share of traffic
+func (r *ABTestRouting) Peek() (providers.Model, error) {
+	var picked *abVariant
+
+	for idx := range r.variants {
+		variant := &r.variants[idx]
+
+		if variant.model.Healthy() && (picked == nil || variant.percentage > picked.percentage) {
+			picked = variant
+		}
+	}
+
+	if picked == nil {
+		return nil, ErrNoHealthyModels
+	}
+
+	return picked.model, nil
+}
+
+func (r *ABTestRouting) Variant(model providers.Model) (string, string, bool) {
+	for _, variant := range r.variants {
+		if variant.model.ID() == model.ID() {
+			return r.experimentID, variant.name, true
+		}
+	}
+
+	return "", "", false
+}
+
+// pick randomly selects one of healthy untried variants proportionally to their percentages
+func (r *ABTestRouting) pick(tried map[string]struct{}) (*abVariant, error) {
+	candidates := make([]*abVariant, 0, len(r.variants))
+	total := 0.0
+
+	for idx := range r.variants {
+		variant := &r.variants[idx]
+
+		if _, found := tried[variant.name]; found || !variant.model.Healthy() {
+			continue
+		}
+
+		candidates = append(candidates, variant)
+		total += variant.percentage
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoHealthyModels
+	}
+
+	point := rand.Float64() * total
+
+	for _, variant := range candidates {
+		if point < variant.percentage {
+			return variant, nil
+		}
+
+		point -= variant.percentage
+	}
+
+	return candidates[len(candidates)-1], nil
+}
+
+// ABTestIterator picks a variant for the request. Each call to Next() means the previous variant model has failed,
+// so one of the remaining variants is picked
+type ABTestIterator struct {
+	routing *ABTestRouting
+	tried   map[string]struct{}
+}
+
+func (i *ABTestIterator) Next() (providers.Model, error) {
+	variant, err := i.routing.pick(i.tried)
+	if err != nil {
+		return nil, err
+	}
+
+	i.tried[variant.name] = struct{}{}
+
+	return variant.model, nil
+}
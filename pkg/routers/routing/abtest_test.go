@@ -0,0 +1,77 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+)
+
+func newABTestConfig() *ABTestConfig {
+	return &ABTestConfig{
+		Experiment: "new-model",
+		Variants: []ABVariantConfig{
+			{Name: "control", Model: "first", Percentage: 80},
+			{Name: "treatment", Model: "second", Percentage: 20},
+		},
+	}
+}
+
+func TestABTestRouting_TrafficSplit(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+	}
+
+	routing := NewABTestRouting(newABTestConfig(), models)
+	requests := make(map[string]int)
+
+	for range 10_000 {
+		model, err := routing.Iterator().Next()
+		require.NoError(t, err)
+
+		requests[model.ID()]++
+	}
+
+	require.InDelta(t, 8_000, requests["first"], 300)
+	require.InDelta(t, 2_000, requests["second"], 300)
+
+	peeked, err := routing.Peek()
+	require.NoError(t, err)
+	require.Equal(t, "first", peeked.ID())
+}
+
+func TestABTestRouting_Fallback(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", false, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+	}
+
+	iterator := NewABTestRouting(newABTestConfig(), models).Iterator()
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "second", model.ID())
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, ErrNoHealthyModels)
+}
+
+func TestABTestRouting_Variant(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+		ptesting.NewLangModelMock("third", true, 0, 1),
+	}
+
+	routing := NewABTestRouting(newABTestConfig(), models)
+
+	experimentID, variant, found := routing.Variant(models[1])
+	require.True(t, found)
+	require.Equal(t, "new-model", experimentID)
+	require.Equal(t, "treatment", variant)
+
+	_, _, found = routing.Variant(models[2])
+	require.False(t, found)
+}
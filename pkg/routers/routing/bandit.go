@@ -0,0 +1,275 @@
+package routing
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"glide/pkg/providers"
+	"glide/pkg/routers/latency"
+)
+
+const (
+	Bandit Strategy = "bandit"
+)
+
+// BanditAlgorithm defines how the bandit balances exploration of models with exploitation of the best one
+type BanditAlgorithm string
+
+const (
+	EpsilonGreedy BanditAlgorithm = "epsilon_greedy"
+	UCB1          BanditAlgorithm = "ucb1"
+)
+
+// BanditReward defines what the bandit optimizes for
+type BanditReward string
+
+const (
+	LatencyReward  BanditReward = "latency"  // the lowest latency per token
+	CostReward     BanditReward = "cost"     // the cheapest requests (requires model pricing)
+	FeedbackReward BanditReward = "feedback" // the highest feedback score reported by clients
+)
+
+// BanditConfig defines settings of the multi-armed bandit routing
+type BanditConfig struct {
+	Algorithm     BanditAlgorithm `yaml:"algorithm" json:"algorithm" validate:"oneof=epsilon_greedy ucb1"`
+	Reward        BanditReward    `yaml:"reward" json:"reward" validate:"oneof=latency cost feedback"`
+	Epsilon       float64         `yaml:"epsilon" json:"epsilon" validate:"gte=0,lte=1"`         // share of requests routed to a random model (epsilon_greedy)
+	Exploration   float64         `yaml:"exploration" json:"exploration" validate:"gte=0"`       // weight of the confidence bound (ucb1)
+	Decay         float64         `yaml:"decay" json:"decay" validate:"gt=0,lte=1"`              // weight of new rewards, so the bandit adapts to changing model performance
+	WarmupSamples uint8           `yaml:"warmup_samples" json:"warmup_samples" validate:"gte=1"` // the number of rewards averaged to init the model estimate before exploitation starts
+}
+
+func DefaultBanditConfig() *BanditConfig {
+	return &BanditConfig{
+		Algorithm:     EpsilonGreedy,
+		Reward:        LatencyReward,
+		Epsilon:       0.1,
+		Exploration:   1.0,
+		Decay:         0.1,
+		WarmupSamples: 3,
+	}
+}
+
+func (c *BanditConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultBanditConfig()
+
+	type plain BanditConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Outcome describes the result of a request routed to a model
+type Outcome struct {
+	Latency time.Duration // time taken to serve the request
+	Tokens  int           // the number of tokens the latency is normalized by
+	Cost    *float64      // the request cost in USD (if the model pricing is known)
+	Score   *float64      // the client feedback score in [0, 1]
+}
+
+// RewardObserver is implemented by routings that learn from outcomes of the routed requests
+type RewardObserver interface {
+	Observe(model providers.Model, outcome Outcome)
+}
+
+// ErrorObserver is implemented by routings that learn from failures of the routed requests
+type ErrorObserver interface {
+	ObserveError(model providers.Model, err error)
+}
+
+// arm tracks rewards of one model
+type arm struct {
+	model   providers.Model
+	reward  *latency.MovingAverage
+	samples uint64
+}
+
+// BanditRouting treats models as arms of a multi-armed bandit.
+// It keeps exploring all models from time to time (so it notices when their performance changes),
+// while sending most of the traffic to the model with the best reward so far.
+//
+// The algorithm consists of two stages:
+//   - warm up: models without enough rewards are tried in round-robin manner
+//   - exploration/exploitation: depending on the algorithm, either a random model is picked with the epsilon probability (epsilon_greedy)
+//     or the model with the best upper confidence bound of the reward is picked (ucb1)
+type BanditRouting struct {
+	mu        sync.Mutex
+	config    *BanditConfig
+	arms      []*arm
+	warmupIdx uint64
+	random    *rand.Rand
+}
+
+func NewBanditRouting(config *BanditConfig, models []providers.Model) *BanditRouting {
+	arms := make([]*arm, 0, len(models))
+
+	for _, model := range models {
+		arms = append(arms, &arm{
+			model:  model,
+			reward: latency.NewMovingAverage(config.Decay, config.WarmupSamples),
+		})
+	}
+
+	return &BanditRouting{
+		config: config,
+		arms:   arms,
+		random: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+func (r *BanditRouting) Iterator() LangModelIterator {
+	return r
+}
+
+func (r *BanditRouting) Next() (providers.Model, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pick(true)
+}
+
+// Peek returns the model the next request would be routed to if no exploration happens
+func (r *BanditRouting) Peek() (providers.Model, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pick(false)
+}
+
+// Observe converts the request outcome into the configured reward and records it for the model
+func (r *BanditRouting) Observe(model providers.Model, outcome Outcome) {
+	reward, ok := r.reward(outcome)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, arm := range r.arms {
+		if arm.model.ID() == model.ID() {
+			arm.reward.Add(reward)
+			arm.samples++
+
+			return
+		}
+	}
+}
+
+// reward maps the outcome to a value where the higher is the better
+func (r *BanditRouting) reward(outcome Outcome) (float64, bool) {
+	switch r.config.Reward {
+	case LatencyReward:
+		if outcome.Latency <= 0 {
+			return 0, false
+		}
+
+		return -outcome.Latency.Seconds() / float64(max(outcome.Tokens, 1)), true
+	case CostReward:
+		if outcome.Cost == nil {
+			return 0, false
+		}
+
+		return -*outcome.Cost, true
+	case FeedbackReward:
+		if outcome.Score == nil {
+			return 0, false
+		}
+
+		return *outcome.Score, true
+	}
+
+	return 0, false
+}
+
+func (r *BanditRouting) pick(explore bool) (providers.Model, error) {
+	healthyArms := make([]*arm, 0, len(r.arms))
+	coldArms := make([]*arm, 0, len(r.arms))
+
+	for _, arm := range r.arms {
+		if !arm.model.Healthy() {
+			continue
+		}
+
+		healthyArms = append(healthyArms, arm)
+
+		if !arm.reward.WarmedUp() {
+			coldArms = append(coldArms, arm)
+		}
+	}
+
+	if len(healthyArms) == 0 {
+		return nil, ErrNoHealthyModels
+	}
+
+	if len(coldArms) > 0 {
+		arm := coldArms[r.warmupIdx%uint64(len(coldArms))]
+
+		if explore {
+			r.warmupIdx++
+		}
+
+		return arm.model, nil
+	}
+
+	if r.config.Algorithm == UCB1 {
+		return r.upperConfidenceBound(healthyArms).model, nil
+	}
+
+	if explore && r.random.Float64() < r.config.Epsilon {
+		return healthyArms[r.random.IntN(len(healthyArms))].model, nil
+	}
+
+	return r.best(healthyArms).model, nil
+}
+
+// best finds the arm with the highest average reward
+func (r *BanditRouting) best(arms []*arm) *arm {
+	bestArm := arms[0]
+
+	for _, arm := range arms[1:] {
+		if arm.reward.Value() > bestArm.reward.Value() {
+			bestArm = arm
+		}
+	}
+
+	return bestArm
+}
+
+// upperConfidenceBound finds the arm with the highest UCB1 score.
+// The confidence bound is scaled by the spread of average rewards, so the exploration works the same way
+// no matter what units rewards are measured in (e.g. seconds or USD)
+func (r *BanditRouting) upperConfidenceBound(arms []*arm) *arm {
+	var totalSamples uint64
+
+	minReward, maxReward := math.Inf(1), math.Inf(-1)
+
+	for _, arm := range arms {
+		totalSamples += arm.samples
+
+		minReward = min(minReward, arm.reward.Value())
+		maxReward = max(maxReward, arm.reward.Value())
+	}
+
+	spread := maxReward - minReward
+	if spread == 0 {
+		spread = max(math.Abs(maxReward), 1)
+	}
+
+	var (
+		bestArm   *arm
+		bestScore = math.Inf(-1)
+	)
+
+	for _, arm := range arms {
+		bound := math.Sqrt(2 * math.Log(float64(totalSamples)) / float64(arm.samples))
+		score := arm.reward.Value() + r.config.Exploration*spread*bound
+
+		if score > bestScore {
+			bestArm, bestScore = arm, score
+		}
+	}
+
+	return bestArm
+}
@@ -0,0 +1,150 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+)
+
+func newBanditModels(healthy ...bool) []providers.Model {
+	modelIDs := []string{"first", "second", "third"}
+	models := make([]providers.Model, 0, len(healthy))
+
+	for idx, isHealthy := range healthy {
+		models = append(models, ptesting.NewLangModelMock(modelIDs[idx], isHealthy, 0, 1))
+	}
+
+	return models
+}
+
+func score(value float64) *float64 {
+	return &value
+}
+
+func TestBanditRouting_Warmup(t *testing.T) {
+	models := newBanditModels(true, false, true)
+	routing := NewBanditRouting(DefaultBanditConfig(), models)
+	iterator := routing.Iterator()
+
+	for _, expectedModelID := range []string{"first", "third", "first", "third"} {
+		model, err := iterator.Next()
+
+		require.NoError(t, err)
+		require.Equal(t, expectedModelID, model.ID())
+	}
+}
+
+func TestBanditRouting_EpsilonGreedyExploitsTheBestModel(t *testing.T) {
+	config := DefaultBanditConfig()
+	config.Epsilon = 0
+	config.WarmupSamples = 1
+
+	models := newBanditModels(true, true, true)
+	routing := NewBanditRouting(config, models)
+
+	for _, model := range models {
+		latency := 100 * time.Millisecond
+
+		if model.ID() == "second" {
+			latency = 10 * time.Millisecond
+		}
+
+		routing.Observe(model, Outcome{Latency: latency, Tokens: 1})
+		routing.Observe(model, Outcome{Latency: latency, Tokens: 1})
+	}
+
+	for i := 0; i < 5; i++ {
+		model, err := routing.Next()
+
+		require.NoError(t, err)
+		require.Equal(t, "second", model.ID())
+	}
+
+	// the best model has become slow
+	for i := 0; i < 50; i++ {
+		routing.Observe(models[1], Outcome{Latency: time.Second, Tokens: 1})
+	}
+
+	model, err := routing.Peek()
+	require.NoError(t, err)
+	require.NotEqual(t, "second", model.ID())
+}
+
+func TestBanditRouting_EpsilonGreedyExplores(t *testing.T) {
+	config := DefaultBanditConfig()
+	config.Epsilon = 1
+	config.WarmupSamples = 1
+	config.Reward = FeedbackReward
+
+	models := newBanditModels(true, true)
+	routing := NewBanditRouting(config, models)
+
+	for i := 0; i < 2; i++ {
+		routing.Observe(models[0], Outcome{Score: score(1)})
+		routing.Observe(models[1], Outcome{Score: score(0)})
+	}
+
+	pickedModels := make(map[string]int)
+
+	for i := 0; i < 200; i++ {
+		model, err := routing.Next()
+		require.NoError(t, err)
+
+		pickedModels[model.ID()]++
+	}
+
+	require.Positive(t, pickedModels["first"])
+	require.Positive(t, pickedModels["second"])
+}
+
+func TestBanditRouting_UCB1(t *testing.T) {
+	config := DefaultBanditConfig()
+	config.Algorithm = UCB1
+	config.Reward = FeedbackReward
+	config.WarmupSamples = 1
+
+	models := newBanditModels(true, true)
+	routing := NewBanditRouting(config, models)
+
+	// the first model is rated better, but the second one was tried only twice
+	for i := 0; i < 100; i++ {
+		routing.Observe(models[0], Outcome{Score: score(0.9)})
+	}
+
+	routing.Observe(models[1], Outcome{Score: score(0.7)})
+	routing.Observe(models[1], Outcome{Score: score(0.7)})
+
+	model, err := routing.Next()
+	require.NoError(t, err)
+	require.Equal(t, "second", model.ID())
+
+	for i := 0; i < 100; i++ {
+		routing.Observe(models[1], Outcome{Score: score(0.7)})
+	}
+
+	model, err = routing.Next()
+	require.NoError(t, err)
+	require.Equal(t, "first", model.ID())
+}
+
+func TestBanditRouting_IgnoresUnrelatedOutcomes(t *testing.T) {
+	config := DefaultBanditConfig()
+	config.Reward = CostReward
+
+	models := newBanditModels(true)
+	routing := NewBanditRouting(config, models)
+
+	routing.Observe(models[0], Outcome{Latency: time.Second, Score: score(1)})
+
+	require.Zero(t, routing.arms[0].samples)
+}
+
+func TestBanditRouting_NoHealthyModels(t *testing.T) {
+	routing := NewBanditRouting(DefaultBanditConfig(), newBanditModels(false, false))
+
+	_, err := routing.Next()
+	require.ErrorIs(t, err, ErrNoHealthyModels)
+}
@@ -0,0 +1,242 @@
+package routing
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+)
+
+const (
+	Canary Strategy = "canary"
+)
+
+// CanaryState defines the stage of the canary rollout
+type CanaryState string
+
+const (
+	CanaryInProgress CanaryState = "in_progress"
+	CanaryPromoted   CanaryState = "promoted" // the canary serves all traffic
+	CanaryEjected    CanaryState = "ejected"  // the canary serves no traffic
+)
+
+// CanaryConfig defines settings of the canary rollout
+type CanaryConfig struct {
+	Baseline          string          `yaml:"baseline" json:"baseline" validate:"required"`                             // ID of the model currently serving traffic
+	Canary            string          `yaml:"canary" json:"canary" validate:"required"`                                 // ID of the new model
+	InitialShare      float64         `yaml:"initial_share" json:"initial_share" validate:"gt=0,lte=100"`               // percentage of traffic the canary starts with
+	PromotionStep     float64         `yaml:"promotion_step" json:"promotion_step" validate:"gt=0,lte=100"`             // percentage of traffic added to the canary share on each promotion
+	Window            fields.Duration `yaml:"window" json:"window" swaggertype:"primitive,integer" validate:"required"` // how long the canary is compared to the baseline before each promotion
+	MinRequests       int             `yaml:"min_requests" json:"min_requests" validate:"gte=1"`                        // the window is extended until the canary serves at least this many requests
+	MaxErrorRateDelta float64         `yaml:"max_error_rate_delta" json:"max_error_rate_delta" validate:"gte=0,lte=1"`  // how much the canary error rate may exceed the baseline one
+	MaxLatencyRatio   float64         `yaml:"max_latency_ratio" json:"max_latency_ratio" validate:"gte=1"`              // how many times the canary latency may exceed the baseline one
+}
+
+func DefaultCanaryConfig() *CanaryConfig {
+	return &CanaryConfig{
+		InitialShare:      5,
+		PromotionStep:     20,
+		Window:            fields.Duration(5 * time.Minute),
+		MinRequests:       50,
+		MaxErrorRateDelta: 0.02,
+		MaxLatencyRatio:   1.5,
+	}
+}
+
+func (c *CanaryConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultCanaryConfig()
+
+	type plain CanaryConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// canaryStats aggregates request outcomes of the model over the evaluation window
+type canaryStats struct {
+	requests int
+	errors   int
+	latency  float64 // the sum of latencies per token of successful requests
+}
+
+func (s *canaryStats) errorRate() float64 {
+	if s.requests == 0 {
+		return 0
+	}
+
+	return float64(s.errors) / float64(s.requests)
+}
+
+func (s *canaryStats) avgLatency() float64 {
+	successes := s.requests - s.errors
+	if successes == 0 {
+		return 0
+	}
+
+	return s.latency / float64(successes)
+}
+
+// CanaryRouting sends a small share of traffic to the canary model and the rest to the baseline model.
+//
+//	The canary is compared to the baseline at the end of every evaluation window.
+//	If the canary error rate or latency are worse than the baseline ones over the allowed margins, the canary is ejected.
+//	Otherwise, the canary share is increased until it serves all traffic.
+//	When the picked model is unhealthy or fails, the request falls back to the other model
+type CanaryRouting struct {
+	mu            sync.Mutex
+	config        *CanaryConfig
+	baseline      providers.Model
+	canary        providers.Model
+	state         CanaryState
+	share         float64
+	windowStarted time.Time
+	baselineStats canaryStats
+	canaryStats   canaryStats
+}
+
+// NewCanaryRouting creates a canary rollout. The baseline & canary models are expected to be in the model pool
+func NewCanaryRouting(config *CanaryConfig, models []providers.Model) *CanaryRouting {
+	routing := &CanaryRouting{
+		config:        config,
+		state:         CanaryInProgress,
+		share:         config.InitialShare,
+		windowStarted: time.Now(),
+	}
+
+	for _, model := range models {
+		switch model.ID() {
+		case config.Baseline:
+			routing.baseline = model
+		case config.Canary:
+			routing.canary = model
+		}
+	}
+
+	return routing
+}
+
+// State returns the rollout stage & the current canary share of traffic
+func (r *CanaryRouting) State() (CanaryState, float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.state, r.share
+}
+
+func (r *CanaryRouting) Iterator() LangModelIterator {
+	r.mu.Lock()
+	share := r.share
+	r.mu.Unlock()
+
+	models := []providers.Model{r.baseline, r.canary}
+
+	if rand.Float64()*100 < share {
+		models[0], models[1] = models[1], models[0]
+	}
+
+	return &CanaryIterator{models: models}
+}
+
+// Peek returns the model serving the majority of traffic
+func (r *CanaryRouting) Peek() (providers.Model, error) {
+	r.mu.Lock()
+	share := r.share
+	r.mu.Unlock()
+
+	models := []providers.Model{r.baseline, r.canary}
+
+	if share > 50 {
+		models[0], models[1] = models[1], models[0]
+	}
+
+	return (&CanaryIterator{models: models}).Next()
+}
+
+// Observe records successful request outcomes of the baseline & canary models
+func (r *CanaryRouting) Observe(model providers.Model, outcome Outcome) {
+	if outcome.Latency <= 0 {
+		// not a request outcome (e.g. client feedback)
+		return
+	}
+
+	r.record(model, false, float64(outcome.Latency)/float64(max(outcome.Tokens, 1)))
+}
+
+// ObserveError records failed requests of the baseline & canary models
+func (r *CanaryRouting) ObserveError(model providers.Model, _ error) {
+	r.record(model, true, 0)
+}
+
+func (r *CanaryRouting) record(model providers.Model, failed bool, latency float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stats *canaryStats
+
+	switch {
+	case model == nil || r.canary == nil || r.baseline == nil:
+		return
+	case model.ID() == r.canary.ID():
+		stats = &r.canaryStats
+	case model.ID() == r.baseline.ID():
+		stats = &r.baselineStats
+	default:
+		return
+	}
+
+	stats.requests++
+
+	if failed {
+		stats.errors++
+	} else {
+		stats.latency += latency
+	}
+
+	r.evaluate()
+}
+
+// evaluate promotes or ejects the canary once the evaluation window is over
+func (r *CanaryRouting) evaluate() {
+	if r.state != CanaryInProgress ||
+		time.Since(r.windowStarted) < time.Duration(r.config.Window) ||
+		r.canaryStats.requests < r.config.MinRequests {
+		return
+	}
+
+	errorRateExceeded := r.canaryStats.errorRate() > r.baselineStats.errorRate()+r.config.MaxErrorRateDelta
+
+	baselineLatency, canaryLatency := r.baselineStats.avgLatency(), r.canaryStats.avgLatency()
+	latencyExceeded := baselineLatency > 0 && canaryLatency > baselineLatency*r.config.MaxLatencyRatio
+
+	switch {
+	case errorRateExceeded || latencyExceeded:
+		r.state, r.share = CanaryEjected, 0
+	case r.share+r.config.PromotionStep >= 100:
+		r.state, r.share = CanaryPromoted, 100
+	default:
+		r.share += r.config.PromotionStep
+	}
+
+	r.windowStarted = time.Now()
+	r.baselineStats, r.canaryStats = canaryStats{}, canaryStats{}
+}
+
+// CanaryIterator tries the picked model first and the other one when the first fails
+type CanaryIterator struct {
+	models []providers.Model
+	idx    int
+}
+
+func (i *CanaryIterator) Next() (providers.Model, error) {
+	for i.idx < len(i.models) {
+		model := i.models[i.idx]
+		i.idx++
+
+		if model != nil && model.Healthy() {
+			return model, nil
+		}
+	}
+
+	return nil, ErrNoHealthyModels
+}
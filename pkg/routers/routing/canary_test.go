@@ -0,0 +1,98 @@
+package routing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+)
+
+func newCanaryRouting(canaryHealthy bool) (*CanaryRouting, providers.Model, providers.Model) {
+	config := DefaultCanaryConfig()
+	config.Baseline, config.Canary = "baseline", "canary"
+	config.Window = 0
+	config.MinRequests = 10
+
+	baseline := ptesting.NewLangModelMock("baseline", true, 0, 1)
+	canary := ptesting.NewLangModelMock("canary", canaryHealthy, 0, 1)
+
+	return NewCanaryRouting(config, []providers.Model{baseline, canary}), baseline, canary
+}
+
+func TestCanaryRouting_Promotion(t *testing.T) {
+	routing, baseline, canary := newCanaryRouting(true)
+
+	state, share := routing.State()
+	require.Equal(t, CanaryInProgress, state)
+	require.InDelta(t, 5.0, share, 0.001)
+
+	for _, expectedShare := range []float64{25, 45, 65, 85, 100} {
+		for range 10 {
+			routing.Observe(baseline, Outcome{Latency: 100 * time.Millisecond, Tokens: 10})
+			routing.Observe(canary, Outcome{Latency: 110 * time.Millisecond, Tokens: 10})
+		}
+
+		_, share = routing.State()
+		require.InDelta(t, expectedShare, share, 0.001)
+	}
+
+	state, _ = routing.State()
+	require.Equal(t, CanaryPromoted, state)
+
+	model, err := routing.Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "canary", model.ID())
+}
+
+func TestCanaryRouting_EjectOnErrors(t *testing.T) {
+	routing, baseline, canary := newCanaryRouting(true)
+
+	for idx := range 10 {
+		routing.Observe(baseline, Outcome{Latency: 100 * time.Millisecond, Tokens: 10})
+
+		if idx%2 == 0 {
+			routing.ObserveError(canary, errors.New("upstream failure"))
+			continue
+		}
+
+		routing.Observe(canary, Outcome{Latency: 100 * time.Millisecond, Tokens: 10})
+	}
+
+	state, share := routing.State()
+	require.Equal(t, CanaryEjected, state)
+	require.Zero(t, share)
+
+	for range 10 {
+		model, err := routing.Iterator().Next()
+		require.NoError(t, err)
+		require.Equal(t, "baseline", model.ID())
+	}
+}
+
+func TestCanaryRouting_EjectOnLatency(t *testing.T) {
+	routing, baseline, canary := newCanaryRouting(true)
+
+	for range 10 {
+		routing.Observe(baseline, Outcome{Latency: 100 * time.Millisecond, Tokens: 10})
+		routing.Observe(canary, Outcome{Latency: 300 * time.Millisecond, Tokens: 10})
+	}
+
+	state, _ := routing.State()
+	require.Equal(t, CanaryEjected, state)
+}
+
+func TestCanaryRouting_Fallback(t *testing.T) {
+	routing, _, _ := newCanaryRouting(false)
+
+	iterator := routing.Iterator()
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "baseline", model.ID())
+
+	_, err = iterator.Next()
+	require.ErrorIs(t, err, ErrNoHealthyModels)
+}
@@ -0,0 +1,144 @@
+package routing
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+)
+
+const (
+	CostAware Strategy = "cost_aware"
+)
+
+// PricingGetter defines where to find token prices of the specific model
+type PricingGetter = func(model providers.Model) *providers.Pricing
+
+// CostAwareConfig defines settings of the cost-aware routing
+type CostAwareConfig struct {
+	// LatencySLO is the highest average latency the model may have to be preferred by its cost.
+	// Latency is measured per token for chat & embedding requests and per chunk for streaming chat requests
+	LatencySLO fields.Duration `yaml:"latency_slo" json:"latency_slo" swaggertype:"primitive,integer" validate:"required"`
+}
+
+func DefaultCostAwareConfig() *CostAwareConfig {
+	return &CostAwareConfig{
+		LatencySLO: fields.Duration(100 * time.Millisecond),
+	}
+}
+
+func (c *CostAwareConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultCostAwareConfig()
+
+	type plain CostAwareConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// CostAwareRouting routes requests to the cheapest healthy model that responds within the latency SLO.
+//
+//	Models are ranked by their blended token price (the prompt & response token prices summed up).
+//	Models that have not collected enough latency samples yet are assumed to meet the SLO, so they receive traffic
+//	to learn about their latencies. When no model meets the SLO, requests go to the fastest models first.
+//	Each request falls down the ranking only when the model fails
+type CostAwareRouting struct {
+	config        *CostAwareConfig
+	latencyGetter LatencyGetter
+	action        providers.Action
+	pricingGetter PricingGetter
+	models        []providers.Model
+}
+
+func NewCostAwareRouting(
+	config *CostAwareConfig,
+	latencyGetter LatencyGetter,
+	action providers.Action,
+	pricingGetter PricingGetter,
+	models []providers.Model,
+) *CostAwareRouting {
+	return &CostAwareRouting{
+		config:        config,
+		latencyGetter: latencyGetter,
+		action:        action,
+		pricingGetter: pricingGetter,
+		models:        models,
+	}
+}
+
+func (r *CostAwareRouting) Iterator() LangModelIterator {
+	return &CostAwareIterator{
+		routing: r,
+		tried:   make(map[string]struct{}, len(r.models)),
+	}
+}
+
+// Peek returns the model the next request would be routed to
+func (r *CostAwareRouting) Peek() (providers.Model, error) {
+	return r.pick(nil)
+}
+
+// pick ranks healthy models that have not been tried yet and returns the best one
+func (r *CostAwareRouting) pick(tried map[string]struct{}) (providers.Model, error) {
+	withinSLO := make([]providers.Model, 0, len(r.models))
+	outsideSLO := make([]providers.Model, 0, len(r.models))
+
+	for _, model := range r.models {
+		if _, found := tried[model.ID()]; found || !model.Healthy() {
+			continue
+		}
+
+		if r.meetsSLO(model) {
+			withinSLO = append(withinSLO, model)
+			continue
+		}
+
+		outsideSLO = append(outsideSLO, model)
+	}
+
+	if len(withinSLO) > 0 {
+		return slices.MinFunc(withinSLO, func(a, b providers.Model) int {
+			return cmp.Compare(r.cost(a), r.cost(b))
+		}), nil
+	}
+
+	if len(outsideSLO) > 0 {
+		return slices.MinFunc(outsideSLO, func(a, b providers.Model) int {
+			return cmp.Compare(r.latencyGetter(a, r.action).Value(), r.latencyGetter(b, r.action).Value())
+		}), nil
+	}
+
+	return nil, ErrNoHealthyModels
+}
+
+func (r *CostAwareRouting) meetsSLO(model providers.Model) bool {
+	modelLatency := r.latencyGetter(model, r.action)
+
+	return !modelLatency.WarmedUp() || modelLatency.Value() <= float64(r.config.LatencySLO)
+}
+
+// cost is the blended token price of the model
+func (r *CostAwareRouting) cost(model providers.Model) float64 {
+	pricing := r.pricingGetter(model)
+
+	return pricing.PromptTokens + pricing.ResponseTokens
+}
+
+// CostAwareIterator walks the model ranking once. Each call to Next() means the previous model has failed,
+// so the next best untried model is returned. The ranking is re-evaluated on every call as model health changes
+type CostAwareIterator struct {
+	routing *CostAwareRouting
+	tried   map[string]struct{}
+}
+
+func (i *CostAwareIterator) Next() (providers.Model, error) {
+	model, err := i.routing.pick(i.tried)
+	if err != nil {
+		return nil, err
+	}
+
+	i.tried[model.ID()] = struct{}{}
+
+	return model, nil
+}
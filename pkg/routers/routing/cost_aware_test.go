@@ -0,0 +1,93 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+)
+
+func newCostAwareRouting(models []providers.Model, prices map[string]float64) *CostAwareRouting {
+	config := &CostAwareConfig{LatencySLO: fields.Duration(100 * time.Nanosecond)}
+
+	pricingGetter := func(model providers.Model) *providers.Pricing {
+		return &providers.Pricing{PromptTokens: prices[model.ID()], ResponseTokens: prices[model.ID()]}
+	}
+
+	return NewCostAwareRouting(config, ptesting.ChatMockLatency, providers.ChatAction, pricingGetter, models)
+}
+
+func TestCostAwareRouting_Routing(t *testing.T) {
+	type Model struct {
+		modelID string
+		healthy bool
+		latency float64
+		price   float64
+	}
+
+	type TestCase struct {
+		models           []Model
+		expectedModelIDs []string
+	}
+
+	tests := map[string]TestCase{
+		"cheapest model within SLO": {
+			[]Model{{"first", true, 90.0, 2.0}, {"second", true, 50.0, 1.0}, {"third", true, 10.0, 3.0}},
+			[]string{"second", "first", "third"},
+		},
+		"cheap model is too slow": {
+			[]Model{{"first", true, 90.0, 2.0}, {"second", true, 150.0, 1.0}, {"third", true, 10.0, 3.0}},
+			[]string{"first", "third", "second"},
+		},
+		"cold models are assumed to meet SLO": {
+			[]Model{{"first", true, 90.0, 2.0}, {"second", true, 0.0, 1.0}},
+			[]string{"second", "first"},
+		},
+		"no models within SLO": {
+			[]Model{{"first", true, 300.0, 1.0}, {"second", true, 200.0, 2.0}},
+			[]string{"second", "first"},
+		},
+		"unhealthy model": {
+			[]Model{{"first", true, 90.0, 2.0}, {"second", false, 50.0, 1.0}},
+			[]string{"first"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			models := make([]providers.Model, 0, len(tc.models))
+			prices := make(map[string]float64, len(tc.models))
+
+			for _, model := range tc.models {
+				models = append(models, ptesting.NewLangModelMock(model.modelID, model.healthy, model.latency, 1))
+				prices[model.modelID] = model.price
+			}
+
+			routing := newCostAwareRouting(models, prices)
+
+			peeked, err := routing.Peek()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedModelIDs[0], peeked.ID())
+
+			iterator := routing.Iterator()
+
+			for _, modelID := range tc.expectedModelIDs {
+				model, err := iterator.Next()
+
+				require.NoError(t, err)
+				require.Equal(t, modelID, model.ID())
+			}
+
+			_, err = iterator.Next()
+			require.ErrorIs(t, err, ErrNoHealthyModels)
+
+			// a new request starts from the best model again
+			model, err := routing.Iterator().Next()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedModelIDs[0], model.ID())
+		})
+	}
+}
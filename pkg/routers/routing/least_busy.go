@@ -0,0 +1,93 @@
+package routing
+
+import (
+	"sync/atomic"
+
+	"glide/pkg/providers"
+)
+
+const (
+	LeastBusy Strategy = "least_busy"
+)
+
+// InFlightGetter defines where to find the number of requests the model is serving at the moment
+type InFlightGetter = func(model providers.Model) int64
+
+// LeastBusyRouting routes requests to the healthy model with the fewest in-flight requests.
+//
+//	Unlike latency averages, in-flight counters react to bursts of traffic immediately.
+//	Models with the same load are picked in round-robin manner, so traffic is spread evenly when models are idle.
+//	Each request falls down to the next least loaded model only when the model fails
+type LeastBusyRouting struct {
+	idx            atomic.Uint64
+	inFlightGetter InFlightGetter
+	models         []providers.Model
+}
+
+func NewLeastBusyRouting(inFlightGetter InFlightGetter, models []providers.Model) *LeastBusyRouting {
+	return &LeastBusyRouting{
+		inFlightGetter: inFlightGetter,
+		models:         models,
+	}
+}
+
+func (r *LeastBusyRouting) Iterator() LangModelIterator {
+	return &LeastBusyIterator{
+		routing: r,
+		tried:   make(map[string]struct{}, len(r.models)),
+	}
+}
+
+// Peek returns the model the next request would be routed to
+func (r *LeastBusyRouting) Peek() (providers.Model, error) {
+	return r.pick(r.idx.Load(), nil)
+}
+
+// pick finds the least loaded healthy model that has not been tried yet.
+// The search starts from the given offset to break ties in round-robin manner
+func (r *LeastBusyRouting) pick(offset uint64, tried map[string]struct{}) (providers.Model, error) {
+	var (
+		leastBusy         providers.Model
+		leastBusyInFlight int64
+	)
+
+	modelLen := uint64(len(r.models))
+
+	for i := uint64(0); i < modelLen; i++ {
+		model := r.models[(offset+i)%modelLen]
+
+		if _, found := tried[model.ID()]; found || !model.Healthy() {
+			continue
+		}
+
+		inFlight := r.inFlightGetter(model)
+
+		if leastBusy == nil || inFlight < leastBusyInFlight {
+			leastBusy, leastBusyInFlight = model, inFlight
+		}
+	}
+
+	if leastBusy == nil {
+		return nil, ErrNoHealthyModels
+	}
+
+	return leastBusy, nil
+}
+
+// LeastBusyIterator walks models from the least to the most loaded one.
+// Each call to Next() means the previous model has failed, so the least loaded untried model is returned
+type LeastBusyIterator struct {
+	routing *LeastBusyRouting
+	tried   map[string]struct{}
+}
+
+func (i *LeastBusyIterator) Next() (providers.Model, error) {
+	model, err := i.routing.pick(i.routing.idx.Add(1)-1, i.tried)
+	if err != nil {
+		return nil, err
+	}
+
+	i.tried[model.ID()] = struct{}{}
+
+	return model, nil
+}
@@ -0,0 +1,80 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+)
+
+func TestLeastBusyRouting_Routing(t *testing.T) {
+	type Model struct {
+		modelID  string
+		healthy  bool
+		inFlight int64
+	}
+
+	type TestCase struct {
+		models           []Model
+		expectedModelIDs []string
+	}
+
+	tests := map[string]TestCase{
+		"least loaded model first": {
+			[]Model{{"first", true, 5}, {"second", true, 1}, {"third", true, 3}},
+			[]string{"second", "third", "first"},
+		},
+		"unhealthy model": {
+			[]Model{{"first", true, 5}, {"second", false, 1}, {"third", true, 3}},
+			[]string{"third", "first"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			models := make([]providers.Model, 0, len(tc.models))
+			inFlight := make(map[string]int64, len(tc.models))
+
+			for _, model := range tc.models {
+				models = append(models, ptesting.NewLangModelMock(model.modelID, model.healthy, 0, 1))
+				inFlight[model.modelID] = model.inFlight
+			}
+
+			routing := NewLeastBusyRouting(func(model providers.Model) int64 { return inFlight[model.ID()] }, models)
+
+			peeked, err := routing.Peek()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedModelIDs[0], peeked.ID())
+
+			iterator := routing.Iterator()
+
+			for _, modelID := range tc.expectedModelIDs {
+				model, err := iterator.Next()
+
+				require.NoError(t, err)
+				require.Equal(t, modelID, model.ID())
+			}
+
+			_, err = iterator.Next()
+			require.ErrorIs(t, err, ErrNoHealthyModels)
+		})
+	}
+}
+
+func TestLeastBusyRouting_SpreadsIdleTraffic(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+		ptesting.NewLangModelMock("third", true, 0, 1),
+	}
+
+	routing := NewLeastBusyRouting(func(_ providers.Model) int64 { return 0 }, models)
+
+	for _, expectedModelID := range []string{"first", "second", "third", "first"} {
+		model, err := routing.Iterator().Next()
+
+		require.NoError(t, err)
+		require.Equal(t, expectedModelID, model.ID())
+	}
+}
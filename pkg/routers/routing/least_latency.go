@@ -1,6 +1,7 @@
 package routing
 
 import (
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -14,8 +15,71 @@ const (
 	LeastLatency Strategy = "least_latency"
 )
 
+// WarmupPattern defines how warm-up requests are spread over cold models
+type WarmupPattern string
+
+const (
+	// WarmupRoundRobin alternates warm-up requests between all cold models
+	WarmupRoundRobin WarmupPattern = "round_robin"
+	// WarmupSequential warms up cold models one at a time in the order they are defined
+	WarmupSequential WarmupPattern = "sequential"
+)
+
+// LatencySignal defines which latency of streaming chats the least latency routing compares models by
+type LatencySignal string
+
+const (
+	// ChunkLatencySignal compares models by the latency of streamed chunks
+	ChunkLatencySignal LatencySignal = "latency"
+	// TTFTSignal compares models by the time to the first token, so interactive apps start showing responses sooner
+	TTFTSignal LatencySignal = "ttft"
+)
+
 // LatencyGetter defines where to find latency for the specific model action
-type LatencyGetter = func(model providers.Model) *latency.MovingAverage
+type LatencyGetter = func(model providers.Model, action providers.Action) *latency.MovingAverage
+
+// QuantilesGetter defines where to find latency percentiles for the specific model action
+type QuantilesGetter = func(model providers.Model, action providers.Action) *latency.Quantiles
+
+// ErrorRateGetter defines where to find the share of requests the model has failed recently
+type ErrorRateGetter = func(model providers.Model) float64
+
+// LeastLatencyConfig defines settings of the least latency routing
+type LeastLatencyConfig struct {
+	// Percentile of the model latency to route on (e.g. 95 or 99), so models with long tail latency are avoided.
+	// The mean latency is used when zero
+	Percentile float64 `yaml:"percentile" json:"percentile" validate:"gte=0,lte=100"`
+	// ErrorPenalty inflates model latencies by their error rates (latency * (1 + penalty * error rate)),
+	// so a fast but flaky model doesn't monopolize traffic. Zero disables the penalty
+	ErrorPenalty float64 `yaml:"error_penalty" json:"error_penalty" validate:"gte=0"`
+	// WarmupSamples is the number of latency samples each model should collect before the routing compares
+	// its latency with other models. Zero relies on warm-up samples of the model latency config
+	WarmupSamples uint `yaml:"warmup_samples" json:"warmup_samples"`
+	// WarmupPattern defines how warm-up requests are spread over cold models
+	WarmupPattern WarmupPattern `yaml:"warmup_pattern" json:"warmup_pattern" validate:"omitempty,oneof=round_robin sequential"`
+	// WarmupShare is the share of traffic (0..1] routed to cold models while some models are already warmed up,
+	// so the fastest known model keeps serving the rest. All traffic warms up models until any of them is warmed up
+	WarmupShare float64 `yaml:"warmup_share" json:"warmup_share" validate:"gte=0,lte=1"`
+	// Signal is the latency streaming chats are routed on. Other actions are always routed on their regular latencies
+	Signal LatencySignal `yaml:"signal" json:"signal" validate:"omitempty,oneof=latency ttft"`
+}
+
+func DefaultLeastLatencyConfig() *LeastLatencyConfig {
+	return &LeastLatencyConfig{
+		ErrorPenalty:  10, // a model failing 10% of requests looks twice as slow
+		WarmupPattern: WarmupRoundRobin,
+		WarmupShare:   1,
+		Signal:        ChunkLatencySignal,
+	}
+}
+
+func (c *LeastLatencyConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultLeastLatencyConfig()
+
+	type plain LeastLatencyConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
 
 // ModelSchedule defines latency update schedule for models
 type ModelSchedule struct {
@@ -62,22 +126,70 @@ func (s *ModelSchedule) Update() {
 // other model latency may improve over time overperform the best one),
 // so we need to send some traffic to other models from time to time to update their latency stats
 type LeastLatencyRouting struct {
-	latencyGetter LatencyGetter
-	warmupIdx     atomic.Uint32
-	schedules     []*ModelSchedule
+	latencyGetter   LatencyGetter
+	quantilesGetter QuantilesGetter
+	action          providers.Action
+	percentile      float64
+	errorRateGetter ErrorRateGetter
+	errorPenalty    float64
+	warmupSamples   uint64
+	warmupPattern   WarmupPattern
+	warmupShare     float64
+	warmupIdx       atomic.Uint32
+	requests        atomic.Uint64
+	schedules       []*ModelSchedule
 }
 
-func NewLeastLatencyRouting(latencyGetter LatencyGetter, models []providers.Model) *LeastLatencyRouting {
+// NewLeastLatencyRouting creates a routing by the mean model latency of the action
+func NewLeastLatencyRouting(latencyGetter LatencyGetter, action providers.Action, models []providers.Model) *LeastLatencyRouting {
+	return &LeastLatencyRouting{
+		latencyGetter: latencyGetter,
+		action:        action,
+		schedules:     newSchedules(models),
+	}
+}
+
+// NewPercentileLatencyRouting creates a routing by the given percentile of the model latency of the action
+func NewPercentileLatencyRouting(
+	quantilesGetter QuantilesGetter,
+	action providers.Action,
+	percentile float64,
+	models []providers.Model,
+) *LeastLatencyRouting {
+	return &LeastLatencyRouting{
+		quantilesGetter: quantilesGetter,
+		action:          action,
+		percentile:      percentile,
+		schedules:       newSchedules(models),
+	}
+}
+
+// PenalizeErrors makes the routing treat models as slower proportionally to their error rates
+func (r *LeastLatencyRouting) PenalizeErrors(errorRateGetter ErrorRateGetter, penalty float64) *LeastLatencyRouting {
+	r.errorRateGetter = errorRateGetter
+	r.errorPenalty = penalty
+
+	return r
+}
+
+// WithWarmup defines how many latency samples models should collect before their latencies are compared
+// & how warm-up requests are spread over cold models and the rest of traffic
+func (r *LeastLatencyRouting) WithWarmup(samples uint, pattern WarmupPattern, share float64) *LeastLatencyRouting {
+	r.warmupSamples = uint64(samples)
+	r.warmupPattern = pattern
+	r.warmupShare = share
+
+	return r
+}
+
+func newSchedules(models []providers.Model) []*ModelSchedule {
 	schedules := make([]*ModelSchedule, 0, len(models))
 
 	for _, model := range models {
 		schedules = append(schedules, NewSchedule(model))
 	}
 
-	return &LeastLatencyRouting{
-		latencyGetter: latencyGetter,
-		schedules:     schedules,
-	}
+	return schedules
 }
 
 func (r *LeastLatencyRouting) Iterator() LangModelIterator {
@@ -88,6 +200,7 @@ func (r *LeastLatencyRouting) Iterator() LangModelIterator {
 // The algorithm consists of two stages:
 //   - warm up: Before considering model latencies we may want to collect more than one sample to make better decisions.
 //     To learn about latencies, we route requests to all "cold" models in round-robin manner
+//     (or one at a time with the sequential warm-up pattern)
 //   - least latency selection: Once all models are warmed, we pick one with the least latency
 //
 // Additionally, we should update our stats as response latency is a dynamic distribution,
@@ -95,25 +208,52 @@ func (r *LeastLatencyRouting) Iterator() LangModelIterator {
 // other model latencies that might have improved over time).
 // For that, we introduced expiration time after which the model receives a request
 // even if it was not the fastest to respond
-func (r *LeastLatencyRouting) Next() (providers.Model, error) { //nolint:cyclop
+func (r *LeastLatencyRouting) Next() (providers.Model, error) {
 	coldSchedules := r.getColdModelSchedules()
 
-	if len(coldSchedules) > 0 {
+	if len(coldSchedules) > 0 && r.warmupTurn(r.requests.Add(1), len(coldSchedules)) {
 		// warm up models
-		idx := r.warmupIdx.Add(1) - 1
-
-		schedule := coldSchedules[idx%uint32(len(coldSchedules))]
+		schedule := r.warmupSchedule(coldSchedules, r.warmupIdx.Add(1)-1)
 		schedule.Update()
 
 		return schedule.model, nil
 	}
 
 	// latency-based routing
+	nextSchedule := r.pickSchedule()
+
+	if nextSchedule != nil {
+		nextSchedule.Update()
+
+		return nextSchedule.model, nil
+	}
+
+	return nil, ErrNoHealthyModels
+}
+
+// Peek returns the model the next request would be routed to without updating the model schedules
+func (r *LeastLatencyRouting) Peek() (providers.Model, error) {
+	coldSchedules := r.getColdModelSchedules()
+
+	if len(coldSchedules) > 0 && r.warmupTurn(r.requests.Load()+1, len(coldSchedules)) {
+		return r.warmupSchedule(coldSchedules, r.warmupIdx.Load()).model, nil
+	}
+
+	if nextSchedule := r.pickSchedule(); nextSchedule != nil {
+		return nextSchedule.model, nil
+	}
+
+	return nil, ErrNoHealthyModels
+}
+
+// pickSchedule finds the healthy warmed model with either the earliest expired schedule or the least response latency
+func (r *LeastLatencyRouting) pickSchedule() *ModelSchedule {
 	var nextSchedule *ModelSchedule
 
 	for _, schedule := range r.schedules {
-		if !schedule.model.Healthy() {
-			// cannot do much with unavailable model
+		if !schedule.model.Healthy() || r.cold(schedule.model) {
+			// cannot do much with unavailable model,
+			// while cold models are left to the warm-up share of traffic
 			continue
 		}
 
@@ -132,28 +272,109 @@ func (r *LeastLatencyRouting) Next() (providers.Model, error) { //nolint:cyclop
 		}
 
 		if !schedule.Expired() && !nextSchedule.Expired() &&
-			r.latencyGetter(nextSchedule.model).Value() > r.latencyGetter(schedule.model).Value() {
+			r.score(nextSchedule.model) > r.score(schedule.model) {
 			nextSchedule = schedule
 		}
 	}
 
-	if nextSchedule != nil {
-		nextSchedule.Update()
+	return nextSchedule
+}
 
-		return nextSchedule.model, nil
+// warmupTurn decides if the request is the one to warm up cold models with.
+// Requests are counted, so the warm-up share is spread evenly over traffic
+func (r *LeastLatencyRouting) warmupTurn(request uint64, coldModels int) bool {
+	if r.warmupShare <= 0 || r.warmupShare >= 1 {
+		return true
 	}
 
-	return nil, ErrNoHealthyModels
+	healthyModels := 0
+
+	for _, schedule := range r.schedules {
+		if schedule.model.Healthy() {
+			healthyModels++
+		}
+	}
+
+	if coldModels == healthyModels {
+		// there is no warmed model to route the rest of traffic to
+		return true
+	}
+
+	return math.Floor(float64(request)*r.warmupShare) > math.Floor(float64(request-1)*r.warmupShare)
+}
+
+func (r *LeastLatencyRouting) warmupSchedule(coldSchedules []*ModelSchedule, idx uint32) *ModelSchedule {
+	if r.warmupPattern == WarmupSequential {
+		return coldSchedules[0]
+	}
+
+	return coldSchedules[idx%uint32(len(coldSchedules))]
 }
 
 func (r *LeastLatencyRouting) getColdModelSchedules() []*ModelSchedule {
 	coldModels := make([]*ModelSchedule, 0, len(r.schedules))
 
 	for _, schedule := range r.schedules {
-		if schedule.model.Healthy() && !r.latencyGetter(schedule.model).WarmedUp() {
+		if schedule.model.Healthy() && r.cold(schedule.model) {
 			coldModels = append(coldModels, schedule)
 		}
 	}
 
 	return coldModels
 }
+
+// cold checks if the model has not collected enough latency samples to be compared with other models yet
+func (r *LeastLatencyRouting) cold(model providers.Model) bool {
+	estimator := r.latency(model)
+
+	return !estimator.WarmedUp() || estimator.Samples() < r.warmupSamples
+}
+
+// Explain tells why the model has been picked
+func (r *LeastLatencyRouting) Explain(model providers.Model) string {
+	if r.cold(model) {
+		return "warming up latency stats of cold models"
+	}
+
+	var fastest providers.Model
+
+	for _, schedule := range r.schedules {
+		if !schedule.model.Healthy() || r.cold(schedule.model) {
+			continue
+		}
+
+		if fastest == nil || r.score(schedule.model) < r.score(fastest) {
+			fastest = schedule.model
+		}
+	}
+
+	if fastest == nil || fastest.ID() != model.ID() {
+		return "refreshing expired latency stats"
+	}
+
+	if r.errorRateGetter != nil {
+		return "the least latency penalized by the error rate"
+	}
+
+	return "the least latency"
+}
+
+// score returns the model latency penalized by the model error rate
+func (r *LeastLatencyRouting) score(model providers.Model) float64 {
+	score := r.latency(model).Value()
+
+	if r.errorRateGetter != nil {
+		score *= 1 + r.errorPenalty*r.errorRateGetter(model)
+	}
+
+	return score
+}
+
+// latency returns the latency estimation the routing compares models by
+func (r *LeastLatencyRouting) latency(model providers.Model) latency.Estimator {
+	if r.quantilesGetter != nil {
+		return r.quantilesGetter(model, r.action).Percentile(r.percentile)
+	}
+
+	return r.latencyGetter(model, r.action)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/latency"
 
 	"github.com/stretchr/testify/require"
 	"glide/pkg/providers"
@@ -38,7 +39,7 @@ func TestLeastLatencyRouting_Warmup(t *testing.T) {
 				models = append(models, ptesting.NewLangModelMock(model.modelID, model.healthy, model.latency, 1))
 			}
 
-			routing := NewLeastLatencyRouting(ptesting.ChatMockLatency, models)
+			routing := NewLeastLatencyRouting(ptesting.ChatMockLatency, providers.ChatAction, models)
 			iterator := routing.Iterator()
 
 			// loop three times over the whole pool to check if we return back to the begging of the list
@@ -149,7 +150,7 @@ func TestLeastLatencyRouting_NoHealthyModels(t *testing.T) {
 				models = append(models, ptesting.NewLangModelMock(strconv.Itoa(idx), false, latency, 1))
 			}
 
-			routing := NewLeastLatencyRouting(providers.ChatLatency, models)
+			routing := NewLeastLatencyRouting(providers.Latency, providers.ChatAction, models)
 			iterator := routing.Iterator()
 
 			_, err := iterator.Next()
@@ -157,3 +158,172 @@ func TestLeastLatencyRouting_NoHealthyModels(t *testing.T) {
 		})
 	}
 }
+
+func TestLeastLatencyRouting_PeekDoesNotAdvance(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+		ptesting.NewLangModelMock("third", true, 0, 1),
+	}
+
+	routing := NewLeastLatencyRouting(ptesting.ChatMockLatency, providers.ChatAction, models)
+
+	for range 6 {
+		peeked, err := routing.Peek()
+		require.NoError(t, err)
+
+		model, err := routing.Next()
+		require.NoError(t, err)
+
+		require.Equal(t, model.ID(), peeked.ID())
+	}
+
+	warmedModels := []providers.Model{
+		ptesting.NewLangModelMock("slow", true, 200, 1),
+		ptesting.NewLangModelMock("fast", true, 100, 1),
+	}
+
+	model, err := NewLeastLatencyRouting(ptesting.ChatMockLatency, providers.ChatAction, warmedModels).Peek()
+	require.NoError(t, err)
+	require.Equal(t, "fast", model.ID())
+}
+
+func TestLeastLatencyRouting_Percentile(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("spiky", true, 0, 1),
+		ptesting.NewLangModelMock("steady", true, 0, 1),
+	}
+
+	quantiles := map[string]*latency.Quantiles{
+		"spiky":  latency.NewQuantiles(100, 3),
+		"steady": latency.NewQuantiles(100, 3),
+	}
+
+	for idx := range 100 {
+		// the spiky model is faster on average, but every tenth request is very slow
+		spikyLatency := 10.0
+		if idx%10 == 0 {
+			spikyLatency = 200.0
+		}
+
+		quantiles["spiky"].Add(spikyLatency)
+		quantiles["steady"].Add(40.0)
+	}
+
+	quantilesGetter := func(model providers.Model, _ providers.Action) *latency.Quantiles {
+		return quantiles[model.ID()]
+	}
+
+	model, err := NewPercentileLatencyRouting(quantilesGetter, providers.ChatAction, 50, models).Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "spiky", model.ID())
+
+	model, err = NewPercentileLatencyRouting(quantilesGetter, providers.ChatAction, 95, models).Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "steady", model.ID())
+}
+
+func TestLeastLatencyRouting_PenalizeErrors(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("flaky", true, 100, 1),
+		ptesting.NewLangModelMock("reliable", true, 150, 1),
+	}
+
+	errorRates := map[string]float64{"flaky": 0.2, "reliable": 0}
+	errorRateGetter := func(model providers.Model) float64 {
+		return errorRates[model.ID()]
+	}
+
+	model, err := NewLeastLatencyRouting(ptesting.ChatMockLatency, providers.ChatAction, models).Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "flaky", model.ID())
+
+	routing := NewLeastLatencyRouting(ptesting.ChatMockLatency, providers.ChatAction, models).PenalizeErrors(errorRateGetter, 10)
+
+	model, err = routing.Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "reliable", model.ID())
+
+	// the flaky model wins back traffic once it recovers
+	errorRates["flaky"] = 0.01
+
+	model, err = routing.Iterator().Next()
+	require.NoError(t, err)
+	require.Equal(t, "flaky", model.ID())
+}
+
+func TestLeastLatencyRouting_Explain(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("fast", true, 100, 1),
+		ptesting.NewLangModelMock("slow", true, 150, 1),
+		ptesting.NewLangModelMock("cold", true, 0, 1),
+	}
+
+	routing := NewLeastLatencyRouting(ptesting.ChatMockLatency, providers.ChatAction, models)
+
+	require.Equal(t, "the least latency", routing.Explain(models[0]))
+	require.Equal(t, "refreshing expired latency stats", routing.Explain(models[1]))
+	require.Equal(t, "warming up latency stats of cold models", routing.Explain(models[2]))
+
+	routing.PenalizeErrors(func(_ providers.Model) float64 { return 0 }, 10)
+
+	require.Equal(t, "the least latency penalized by the error rate", routing.Explain(models[0]))
+}
+
+func TestLeastLatencyRouting_WarmupSamples(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+	}
+
+	latencies := map[string]*latency.MovingAverage{
+		"first":  latency.NewMovingAverage(0.06, 1),
+		"second": latency.NewMovingAverage(0.06, 1),
+	}
+
+	latencyGetter := func(model providers.Model, _ providers.Action) *latency.MovingAverage {
+		return latencies[model.ID()]
+	}
+
+	routing := NewLeastLatencyRouting(latencyGetter, providers.ChatAction, models).WithWarmup(3, WarmupSequential, 1)
+	iterator := routing.Iterator()
+
+	// the first model collects all of its samples before the second one is warmed up
+	for _, expectedModelID := range []string{"first", "first", "first", "second", "second", "second"} {
+		model, err := iterator.Next()
+		require.NoError(t, err)
+		require.Equal(t, expectedModelID, model.ID())
+
+		latencies[model.ID()].Add(map[string]float64{"first": 100, "second": 200}[model.ID()])
+	}
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "first", model.ID())
+	require.Equal(t, "the least latency", routing.Explain(model))
+}
+
+func TestLeastLatencyRouting_WarmupShare(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("warmed", true, 100, 1),
+		ptesting.NewLangModelMock("cold", true, 0, 1),
+	}
+
+	routing := NewLeastLatencyRouting(ptesting.ChatMockLatency, providers.ChatAction, models).WithWarmup(0, WarmupRoundRobin, 0.25)
+	iterator := routing.Iterator()
+
+	routedModels := make(map[string]int)
+
+	for range 8 {
+		peekedModel, err := routing.Peek()
+		require.NoError(t, err)
+
+		model, err := iterator.Next()
+		require.NoError(t, err)
+		require.Equal(t, peekedModel.ID(), model.ID())
+
+		routedModels[model.ID()]++
+	}
+
+	require.Equal(t, map[string]int{"warmed": 6, "cold": 2}, routedModels)
+}
@@ -0,0 +1,137 @@
+package routing
+
+import (
+	"math/rand/v2"
+
+	"glide/pkg/providers"
+)
+
+const (
+	PowerOfTwo Strategy = "power_of_two"
+)
+
+// PowerOfTwoRouting samples two random healthy models and routes the request to the less loaded one of them
+// (the power of two choices).
+//
+//	It gives most of the benefit of picking the least loaded or the fastest model without scanning all models,
+//	while the random sampling prevents all requests from herding onto the same model in between stats updates.
+//	Models are compared by the number of in-flight requests first & by the latency when loads are equal.
+//	Models that have not warmed up yet win latency comparisons, so their latency gets measured
+type PowerOfTwoRouting struct {
+	inFlightGetter InFlightGetter
+	latencyGetter  LatencyGetter
+	action         providers.Action
+	models         []providers.Model
+}
+
+func NewPowerOfTwoRouting(
+	inFlightGetter InFlightGetter,
+	latencyGetter LatencyGetter,
+	action providers.Action,
+	models []providers.Model,
+) *PowerOfTwoRouting {
+	return &PowerOfTwoRouting{
+		inFlightGetter: inFlightGetter,
+		latencyGetter:  latencyGetter,
+		action:         action,
+		models:         models,
+	}
+}
+
+func (r *PowerOfTwoRouting) Iterator() LangModelIterator {
+	return &PowerOfTwoIterator{
+		routing: r,
+		tried:   make(map[string]struct{}, len(r.models)),
+	}
+}
+
+// Peek returns the model that would win comparisons with all other healthy models.
+// The actual pick depends on which models are sampled for the request
+func (r *PowerOfTwoRouting) Peek() (providers.Model, error) {
+	var best providers.Model
+
+	for _, model := range r.models {
+		if !model.Healthy() {
+			continue
+		}
+
+		if best == nil || r.better(model, best) {
+			best = model
+		}
+	}
+
+	if best == nil {
+		return nil, ErrNoHealthyModels
+	}
+
+	return best, nil
+}
+
+// pick samples two healthy untried models & returns the better one of them
+func (r *PowerOfTwoRouting) pick(tried map[string]struct{}) (providers.Model, error) {
+	candidates := make([]providers.Model, 0, len(r.models))
+
+	for _, model := range r.models {
+		if _, found := tried[model.ID()]; found || !model.Healthy() {
+			continue
+		}
+
+		candidates = append(candidates, model)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, ErrNoHealthyModels
+	case 1:
+		return candidates[0], nil
+	}
+
+	firstIdx := rand.IntN(len(candidates))
+	secondIdx := rand.IntN(len(candidates) - 1)
+
+	if secondIdx >= firstIdx {
+		secondIdx++
+	}
+
+	first, second := candidates[firstIdx], candidates[secondIdx]
+
+	if r.better(second, first) {
+		return second, nil
+	}
+
+	return first, nil
+}
+
+// better checks if the model should be preferred over the other one
+func (r *PowerOfTwoRouting) better(model providers.Model, other providers.Model) bool {
+	inFlight, otherInFlight := r.inFlightGetter(model), r.inFlightGetter(other)
+
+	if inFlight != otherInFlight {
+		return inFlight < otherInFlight
+	}
+
+	latency, otherLatency := r.latencyGetter(model, r.action), r.latencyGetter(other, r.action)
+
+	if !latency.WarmedUp() || !otherLatency.WarmedUp() {
+		return !latency.WarmedUp() && otherLatency.WarmedUp()
+	}
+
+	return latency.Value() < otherLatency.Value()
+}
+
+// PowerOfTwoIterator samples a new pair of models on each call to Next(), as each call means the previous model has failed
+type PowerOfTwoIterator struct {
+	routing *PowerOfTwoRouting
+	tried   map[string]struct{}
+}
+
+func (i *PowerOfTwoIterator) Next() (providers.Model, error) {
+	model, err := i.routing.pick(i.tried)
+	if err != nil {
+		return nil, err
+	}
+
+	i.tried[model.ID()] = struct{}{}
+
+	return model, nil
+}
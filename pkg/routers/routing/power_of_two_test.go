@@ -0,0 +1,69 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+)
+
+func TestPowerOfTwoRouting_PicksLessLoadedModel(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("busy", true, 100, 1),
+		ptesting.NewLangModelMock("idle", true, 100, 1),
+		ptesting.NewLangModelMock("loaded", true, 100, 1),
+		ptesting.NewLangModelMock("unhealthy", false, 100, 1),
+	}
+	inFlight := map[string]int64{"busy": 10, "idle": 0, "loaded": 5, "unhealthy": 0}
+
+	routing := NewPowerOfTwoRouting(func(model providers.Model) int64 { return inFlight[model.ID()] }, ptesting.ChatMockLatency, providers.ChatAction, models)
+
+	peeked, err := routing.Peek()
+	require.NoError(t, err)
+	require.Equal(t, "idle", peeked.ID())
+
+	for i := 0; i < 100; i++ {
+		iterator := routing.Iterator()
+		modelIDs := make([]string, 0, 3)
+
+		for {
+			model, err := iterator.Next()
+			if err != nil {
+				require.ErrorIs(t, err, ErrNoHealthyModels)
+
+				break
+			}
+
+			modelIDs = append(modelIDs, model.ID())
+		}
+
+		// the most loaded model never wins a comparison, so it's tried only when others have failed
+		require.Len(t, modelIDs, 3)
+		require.NotEqual(t, "busy", modelIDs[0])
+		require.Equal(t, "busy", modelIDs[2])
+	}
+}
+
+func TestPowerOfTwoRouting_ComparesLatencyOnEqualLoad(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("slow", true, 200, 1),
+		ptesting.NewLangModelMock("fast", true, 100, 1),
+	}
+
+	routing := NewPowerOfTwoRouting(func(_ providers.Model) int64 { return 0 }, ptesting.ChatMockLatency, providers.ChatAction, models)
+
+	for i := 0; i < 10; i++ {
+		model, err := routing.Iterator().Next()
+		require.NoError(t, err)
+		require.Equal(t, "fast", model.ID())
+	}
+
+	// cold models are preferred to measure their latency
+	models = append(models, ptesting.NewLangModelMock("cold", true, 0, 1))
+	routing = NewPowerOfTwoRouting(func(_ providers.Model) int64 { return 0 }, ptesting.ChatMockLatency, providers.ChatAction, models)
+
+	peeked, err := routing.Peek()
+	require.NoError(t, err)
+	require.Equal(t, "cold", peeked.ID())
+}
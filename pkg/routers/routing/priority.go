@@ -13,7 +13,9 @@ const (
 // PriorityRouting routes request to the first healthy model defined in the routing config
 //
 //	Priority of models are defined as position of the model on the list
-//	(e.g. the first model definition has the highest priority, then the second model definition and so on)
+//	(e.g. the first model definition has the highest priority, then the second model definition and so on).
+//	Each request starts from the highest-priority healthy model and falls down the chain only when the model fails
+//	(e.g. the primary provider with a backup one)
 type PriorityRouting struct {
 	models []providers.Model
 }
@@ -33,6 +35,19 @@ func (r *PriorityRouting) Iterator() LangModelIterator {
 	return iterator
 }
 
+// Peek returns the first healthy model
+func (r *PriorityRouting) Peek() (providers.Model, error) {
+	for _, model := range r.models {
+		if model.Healthy() {
+			return model, nil
+		}
+	}
+
+	return nil, ErrNoHealthyModels
+}
+
+// PriorityIterator walks the model chain once. Each call to Next() means the previous model has failed,
+// so the next healthy model down the chain is returned
 type PriorityIterator struct {
 	idx    *atomic.Uint64
 	models []providers.Model
@@ -41,15 +56,19 @@ type PriorityIterator struct {
 func (r PriorityIterator) Next() (providers.Model, error) {
 	models := r.models
 
-	for idx := int(r.idx.Load()); idx < len(models); idx = int(r.idx.Add(1)) {
-		model := models[idx]
+	for {
+		idx := r.idx.Load()
 
-		if !model.Healthy() {
+		if idx >= uint64(len(models)) {
+			return nil, ErrNoHealthyModels
+		}
+
+		if !r.idx.CompareAndSwap(idx, idx+1) {
 			continue
 		}
 
-		return model, nil
+		if model := models[idx]; model.Healthy() {
+			return model, nil
+		}
 	}
-
-	return nil, ErrNoHealthyModels
 }
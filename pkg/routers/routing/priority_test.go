@@ -21,9 +21,9 @@ func TestPriorityRouting_PickModelsInOrder(t *testing.T) {
 	}
 
 	tests := map[string]TestCase{
-		"all healthy":         {[]Model{{"first", true}, {"second", true}, {"third", true}}, []string{"first", "first", "first"}},
-		"first unhealthy":     {[]Model{{"first", false}, {"second", true}, {"third", true}}, []string{"second", "second", "second"}},
-		"first two unhealthy": {[]Model{{"first", false}, {"second", false}, {"third", true}}, []string{"third", "third", "third"}},
+		"all healthy":         {[]Model{{"first", true}, {"second", true}, {"third", true}}, []string{"first", "second", "third"}},
+		"first unhealthy":     {[]Model{{"first", false}, {"second", true}, {"third", true}}, []string{"second", "third"}},
+		"first two unhealthy": {[]Model{{"first", false}, {"second", false}, {"third", true}}, []string{"third"}},
 	}
 
 	for name, tc := range tests {
@@ -35,13 +35,20 @@ func TestPriorityRouting_PickModelsInOrder(t *testing.T) {
 			}
 
 			routing := NewPriority(models)
-			iterator := routing.Iterator()
 
-			// loop three times over the whole pool to check if we return back to the begging of the list
-			for _, modelID := range tc.expectedModelIDs {
-				model, err := iterator.Next()
-				require.NoError(t, err)
-				require.Equal(t, modelID, model.ID())
+			// every request starts from the top of the chain
+			for i := 0; i < 3; i++ {
+				iterator := routing.Iterator()
+
+				// each next model is requested when the previous one has failed
+				for _, modelID := range tc.expectedModelIDs {
+					model, err := iterator.Next()
+					require.NoError(t, err)
+					require.Equal(t, modelID, model.ID())
+				}
+
+				_, err := iterator.Next()
+				require.ErrorIs(t, err, ErrNoHealthyModels)
 			}
 		})
 	}
@@ -60,3 +67,19 @@ func TestPriorityRouting_NoHealthyModels(t *testing.T) {
 	_, err := iterator.Next()
 	require.Error(t, err)
 }
+
+func TestPriorityRouting_Peek(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", false, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+	}
+
+	routing := NewPriority(models)
+
+	model, err := routing.Peek()
+	require.NoError(t, err)
+	require.Equal(t, "second", model.ID())
+
+	_, err = NewPriority(models[:1]).Peek()
+	require.ErrorIs(t, err, ErrNoHealthyModels)
+}
@@ -0,0 +1,80 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"glide/pkg/providers"
+)
+
+var ErrStrategyRegistered = errors.New("routing strategy is already registered")
+
+// Factory builds the routing of a custom strategy over the router model pool.
+// The latency getter gives access to model latencies of the action the routing serves (e.g. chat or image generation)
+type Factory func(models []providers.Model, latencyGetter LatencyGetter, action providers.Action) (LangModelRouting, error)
+
+var builtinStrategies = []Strategy{
+	Priority,
+	RoundRobin,
+	WeightedRoundRobin,
+	LeastLatency,
+	Bandit,
+	CostAware,
+	LeastBusy,
+	PowerOfTwo,
+	Sticky,
+	ABTest,
+	Canary,
+}
+
+var registry = struct {
+	sync.RWMutex
+	factories map[Strategy]Factory
+}{
+	factories: make(map[Strategy]Factory),
+}
+
+// Register makes the custom routing strategy available in router configs under the given name,
+// so org-specific routing policies could be plugged in without changing the gateway code.
+// It's meant to be called on startup (e.g. from the init function of the package that defines the strategy)
+func Register(strategy Strategy, factory Factory) error {
+	for _, builtinStrategy := range builtinStrategies {
+		if strategy == builtinStrategy {
+			return fmt.Errorf("%w: \"%v\" is a built-in strategy", ErrStrategyRegistered, strategy)
+		}
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, found := registry.factories[strategy]; found {
+		return fmt.Errorf("%w: \"%v\"", ErrStrategyRegistered, strategy)
+	}
+
+	registry.factories[strategy] = factory
+
+	return nil
+}
+
+// Lookup finds the factory of the registered custom strategy
+func Lookup(strategy Strategy) (Factory, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	factory, found := registry.factories[strategy]
+
+	return factory, found
+}
+
+// Supported checks if the strategy is built-in or registered as a custom one
+func Supported(strategy Strategy) bool {
+	if slices.Contains(builtinStrategies, strategy) {
+		return true
+	}
+
+	_, found := Lookup(strategy)
+
+	return found
+}
@@ -0,0 +1,28 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+)
+
+func TestRegister(t *testing.T) {
+	factory := func(models []providers.Model, _ LatencyGetter, _ providers.Action) (LangModelRouting, error) {
+		return NewRoundRobinRouting(models), nil
+	}
+
+	_, found := Lookup("custom_registry_test")
+	require.False(t, found)
+	require.False(t, Supported("custom_registry_test"))
+
+	require.NoError(t, Register("custom_registry_test", factory))
+
+	_, found = Lookup("custom_registry_test")
+	require.True(t, found)
+	require.True(t, Supported("custom_registry_test"))
+	require.True(t, Supported(Priority))
+
+	require.ErrorIs(t, Register("custom_registry_test", factory), ErrStrategyRegistered)
+	require.ErrorIs(t, Register(Priority, factory), ErrStrategyRegistered)
+}
@@ -44,3 +44,19 @@ func (r *RoundRobinRouting) Next() (providers.Model, error) {
 
 	return nil, ErrNoHealthyModels
 }
+
+// Peek returns the model the next request would be routed to
+func (r *RoundRobinRouting) Peek() (providers.Model, error) {
+	modelLen := len(r.models)
+	startIdx := r.idx.Load()
+
+	for i := 0; i < modelLen; i++ {
+		model := r.models[(startIdx+uint64(i))%uint64(modelLen)]
+
+		if model.Healthy() {
+			return model, nil
+		}
+	}
+
+	return nil, ErrNoHealthyModels
+}
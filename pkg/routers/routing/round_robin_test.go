@@ -63,3 +63,24 @@ func TestRoundRobinRouting_NoHealthyModels(t *testing.T) {
 	_, err := iterator.Next()
 	require.Error(t, err)
 }
+
+func TestRoundRobinRouting_PeekDoesNotAdvance(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", false, 0, 1),
+		ptesting.NewLangModelMock("third", true, 0, 1),
+	}
+
+	routing := NewRoundRobinRouting(models)
+
+	for _, modelID := range []string{"first", "third", "first"} {
+		peeked, err := routing.Peek()
+		require.NoError(t, err)
+
+		model, err := routing.Next()
+		require.NoError(t, err)
+
+		require.Equal(t, modelID, peeked.ID())
+		require.Equal(t, modelID, model.ID())
+	}
+}
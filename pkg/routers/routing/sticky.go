@@ -0,0 +1,106 @@
+package routing
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/cespare/xxhash/v2"
+	"glide/pkg/providers"
+)
+
+const (
+	Sticky Strategy = "sticky"
+)
+
+// stickyVirtualNodes is the number of ring points per unit of model weight,
+// so sessions are spread evenly & only sessions of the removed model move to other models
+const stickyVirtualNodes = 100
+
+type ringPoint struct {
+	hash  uint64
+	model providers.Model
+}
+
+// StickyRouting pins sessions to models via consistent hashing,
+// so multi-turn conversations keep hitting the same provider (e.g. to benefit from provider-side prompt caching).
+//
+//	Session IDs are hashed onto a ring of model points (weighted by the model weight).
+//	When the session model is unhealthy or fails, the session is rehashed to the next model on the ring,
+//	while sessions of other models stay where they are.
+//	Requests without a session ID are routed in round-robin manner
+type StickyRouting struct {
+	ring       []ringPoint
+	roundRobin *RoundRobinRouting
+}
+
+func NewStickyRouting(models []providers.Model) *StickyRouting {
+	ring := make([]ringPoint, 0, len(models)*stickyVirtualNodes)
+
+	for _, model := range models {
+		for idx := range max(model.Weight(), 1) * stickyVirtualNodes {
+			ring = append(ring, ringPoint{
+				hash:  xxhash.Sum64String(model.ID() + "#" + strconv.Itoa(idx)),
+				model: model,
+			})
+		}
+	}
+
+	slices.SortFunc(ring, func(a, b ringPoint) int {
+		return cmp.Compare(a.hash, b.hash)
+	})
+
+	return &StickyRouting{
+		ring:       ring,
+		roundRobin: NewRoundRobinRouting(models),
+	}
+}
+
+func (r *StickyRouting) Iterator() LangModelIterator {
+	return r.roundRobin.Iterator()
+}
+
+// Peek returns the model the next request without a session would be routed to
+func (r *StickyRouting) Peek() (providers.Model, error) {
+	return r.roundRobin.Peek()
+}
+
+// SessionIterator walks the ring from the session point
+func (r *StickyRouting) SessionIterator(sessionID string) LangModelIterator {
+	sessionHash := xxhash.Sum64String(sessionID)
+
+	idx, _ := slices.BinarySearchFunc(r.ring, sessionHash, func(point ringPoint, hash uint64) int {
+		return cmp.Compare(point.hash, hash)
+	})
+
+	return &StickyIterator{
+		ring:  r.ring,
+		idx:   idx,
+		tried: make(map[string]struct{}),
+	}
+}
+
+// StickyIterator returns healthy models in the order they follow the session point on the ring.
+// Each call to Next() means the previous model has failed, so the next untried model on the ring is returned
+type StickyIterator struct {
+	ring  []ringPoint
+	idx   int
+	tried map[string]struct{}
+}
+
+func (i *StickyIterator) Next() (providers.Model, error) {
+	for range len(i.ring) {
+		model := i.ring[i.idx%len(i.ring)].model
+
+		if _, found := i.tried[model.ID()]; found || !model.Healthy() {
+			i.idx++
+			continue
+		}
+
+		i.tried[model.ID()] = struct{}{}
+
+		return model, nil
+	}
+
+	return nil, ErrNoHealthyModels
+}
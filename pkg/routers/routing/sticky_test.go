@@ -0,0 +1,101 @@
+package routing
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+)
+
+func newStickyModels(healthy ...bool) []providers.Model {
+	modelIDs := []string{"first", "second", "third"}
+	models := make([]providers.Model, 0, len(healthy))
+
+	for idx, isHealthy := range healthy {
+		models = append(models, ptesting.NewLangModelMock(modelIDs[idx], isHealthy, 0, 1))
+	}
+
+	return models
+}
+
+func sessionModel(t *testing.T, routing *StickyRouting, sessionID string) string {
+	model, err := routing.SessionIterator(sessionID).Next()
+	require.NoError(t, err)
+
+	return model.ID()
+}
+
+func TestStickyRouting_SameSessionSameModel(t *testing.T) {
+	routing := NewStickyRouting(newStickyModels(true, true, true))
+	sessionModels := make(map[string]int)
+
+	for idx := range 300 {
+		sessionID := "session-" + strconv.Itoa(idx)
+		modelID := sessionModel(t, routing, sessionID)
+
+		for range 3 {
+			require.Equal(t, modelID, sessionModel(t, routing, sessionID))
+		}
+
+		sessionModels[modelID]++
+	}
+
+	// sessions are spread across all models
+	require.Len(t, sessionModels, 3)
+
+	for _, sessions := range sessionModels {
+		require.Greater(t, sessions, 50)
+	}
+}
+
+func TestStickyRouting_RehashOnUnhealthy(t *testing.T) {
+	healthyRouting := NewStickyRouting(newStickyModels(true, true, true))
+	degradedRouting := NewStickyRouting(newStickyModels(true, false, true))
+
+	for idx := range 100 {
+		sessionID := "session-" + strconv.Itoa(idx)
+
+		modelID := sessionModel(t, healthyRouting, sessionID)
+		degradedModelID := sessionModel(t, degradedRouting, sessionID)
+
+		if modelID == "second" {
+			require.NotEqual(t, "second", degradedModelID)
+			continue
+		}
+
+		// sessions of healthy models are not moved
+		require.Equal(t, modelID, degradedModelID)
+	}
+}
+
+func TestStickyRouting_SessionFallback(t *testing.T) {
+	routing := NewStickyRouting(newStickyModels(true, false, true))
+	iterator := routing.SessionIterator("session")
+
+	seenModelIDs := make([]string, 0, 2)
+
+	for range 2 {
+		model, err := iterator.Next()
+		require.NoError(t, err)
+
+		seenModelIDs = append(seenModelIDs, model.ID())
+	}
+
+	require.ElementsMatch(t, []string{"first", "third"}, seenModelIDs)
+
+	_, err := iterator.Next()
+	require.ErrorIs(t, err, ErrNoHealthyModels)
+}
+
+func TestStickyRouting_NoSession(t *testing.T) {
+	routing := NewStickyRouting(newStickyModels(true, true))
+
+	for _, expectedModelID := range []string{"first", "second", "first"} {
+		model, err := SessionIterator(routing, "").Next()
+
+		require.NoError(t, err)
+		require.Equal(t, expectedModelID, model.ID())
+	}
+}
@@ -18,3 +18,29 @@ type LangModelRouting interface {
 type LangModelIterator interface {
 	Next() (providers.Model, error)
 }
+
+// ModelPeeker is implemented by routings that can tell which model would serve the next request
+// without changing the routing state (e.g. for debugging routing decisions)
+type ModelPeeker interface {
+	Peek() (providers.Model, error)
+}
+
+// DecisionExplainer is implemented by routings that can tell why the model was picked (e.g. for routing traces)
+type DecisionExplainer interface {
+	Explain(model providers.Model) string
+}
+
+// SessionRouting is implemented by routings that route requests of the same session to the same model.
+// Session iterators don't change the routing state
+type SessionRouting interface {
+	SessionIterator(sessionID string) LangModelIterator
+}
+
+// SessionIterator creates the model iterator for the request session if the routing supports that
+func SessionIterator(modelRouting LangModelRouting, sessionID string) LangModelIterator {
+	if sessionRouting, ok := modelRouting.(SessionRouting); ok && sessionID != "" {
+		return sessionRouting.SessionIterator(sessionID)
+	}
+
+	return modelRouting.Iterator()
+}
@@ -89,3 +89,32 @@ func (r *WRoundRobinRouting) Next() (providers.Model, error) {
 
 	return nil, ErrNoHealthyModels
 }
+
+// Peek returns the model the next request would be routed to without updating the current weights
+func (r *WRoundRobinRouting) Peek() (providers.Model, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		maxWeighter *Weighter
+		maxWeight   int
+	)
+
+	for _, weighter := range r.weights {
+		if !weighter.model.Healthy() {
+			continue
+		}
+
+		nextWeight := weighter.Current() + weighter.Weight()
+
+		if maxWeighter == nil || nextWeight > maxWeight {
+			maxWeighter, maxWeight = weighter, nextWeight
+		}
+	}
+
+	if maxWeighter != nil {
+		return maxWeighter.model, nil
+	}
+
+	return nil, ErrNoHealthyModels
+}
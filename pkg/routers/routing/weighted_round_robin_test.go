@@ -153,3 +153,23 @@ func TestWRoundRobinRouting_NoHealthyModels(t *testing.T) {
 	_, err := iterator.Next()
 	require.Error(t, err)
 }
+
+func TestWRoundRobinRouting_PeekDoesNotAdvance(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 2),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+		ptesting.NewLangModelMock("third", false, 0, 5),
+	}
+
+	routing := NewWeightedRoundRobin(models)
+
+	for range 6 {
+		peeked, err := routing.Peek()
+		require.NoError(t, err)
+
+		model, err := routing.Next()
+		require.NoError(t, err)
+
+		require.Equal(t, model.ID(), peeked.ID())
+	}
+}
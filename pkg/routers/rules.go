@@ -0,0 +1,179 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+// AnyHeaderValue matches any value of the header as long as the header is present
+const AnyHeaderValue = "*"
+
+type requestHeadersKey struct{}
+
+// WithRequestHeaders attaches headers of the client request to the context, so routing rules can match them
+func WithRequestHeaders(ctx context.Context, headers map[string][]string) context.Context {
+	normalized := make(map[string]string, len(headers))
+
+	for name, values := range headers {
+		if len(values) > 0 {
+			normalized[strings.ToLower(name)] = values[0]
+		}
+	}
+
+	return context.WithValue(ctx, requestHeadersKey{}, normalized)
+}
+
+func requestHeader(ctx context.Context, name string) (string, bool) {
+	headers, _ := ctx.Value(requestHeadersKey{}).(map[string]string)
+	value, found := headers[strings.ToLower(name)]
+
+	return value, found
+}
+
+// RuleConfig defines which requests the rule matches and the router models that serve them.
+// All specified conditions should be met for the request to match
+type RuleConfig struct {
+	Name      string            `yaml:"name" json:"name" validate:"required"`
+	Headers   map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`                        // header name -> value, "*" matches any value
+	Tenants   []string          `yaml:"tenants,omitempty" json:"tenants,omitempty"`                        // tenant IDs passed in the tenant header
+	MinTokens int               `yaml:"min_tokens,omitempty" json:"min_tokens,omitempty" validate:"gte=0"` // the least estimated number of prompt tokens
+	MaxTokens int               `yaml:"max_tokens,omitempty" json:"max_tokens,omitempty" validate:"gte=0"` // the largest estimated number of prompt tokens, zero means no limit
+	Models    []string          `yaml:"models" json:"models" validate:"required,min=1"`                    // IDs of router models that serve matched requests
+}
+
+// RulesConfig defines ordered rules that route chat requests to model pools by request attributes.
+// The first matching rule wins, requests that match no rule are routed by the router strategy
+type RulesConfig struct {
+	TenantHeader string       `yaml:"tenant_header" json:"tenant_header" validate:"required"` // the header that identifies the client tenant
+	Rules        []RuleConfig `yaml:"rules" json:"rules" validate:"required,min=1,dive"`
+}
+
+func DefaultRulesConfig() *RulesConfig {
+	return &RulesConfig{
+		TenantHeader: "X-Tenant-ID",
+	}
+}
+
+func (c *RulesConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultRulesConfig()
+
+	type plain RulesConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+type rule struct {
+	config  *RuleConfig
+	routing *poolRouting
+}
+
+// matches checks the request attributes against the rule conditions
+func (r *rule) matches(ctx context.Context, tenantHeader string, promptTokens int) bool {
+	for name, expectedValue := range r.config.Headers {
+		value, found := requestHeader(ctx, name)
+
+		if !found || (expectedValue != AnyHeaderValue && value != expectedValue) {
+			return false
+		}
+	}
+
+	if len(r.config.Tenants) > 0 {
+		tenantID, _ := requestHeader(ctx, tenantHeader)
+
+		if !slices.Contains(r.config.Tenants, tenantID) {
+			return false
+		}
+	}
+
+	if promptTokens < r.config.MinTokens {
+		return false
+	}
+
+	if r.config.MaxTokens > 0 && promptTokens > r.config.MaxTokens {
+		return false
+	}
+
+	return true
+}
+
+// RulesEngine picks routings of chat requests by the first matching rule
+type RulesEngine struct {
+	routerID     RouterID
+	tenantHeader string
+	rules        []*rule
+	tel          *telemetry.Telemetry
+}
+
+func NewRulesEngine(
+	cfg *LangRouterConfig,
+	chatModels []*providers.LanguageModel,
+	chatStreamModels []*providers.LanguageModel,
+	tel *telemetry.Telemetry,
+) (*RulesEngine, error) {
+	rules := make([]*rule, 0, len(cfg.Rules.Rules))
+
+	for idx := range cfg.Rules.Rules {
+		ruleConfig := &cfg.Rules.Rules[idx]
+
+		ruleRouting, err := newPoolRouting(
+			cfg,
+			fmt.Sprintf("routing rule \"%v\"", ruleConfig.Name),
+			ruleConfig.Models,
+			chatModels,
+			chatStreamModels,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, &rule{config: ruleConfig, routing: ruleRouting})
+	}
+
+	return &RulesEngine{
+		routerID:     cfg.ID,
+		tenantHeader: cfg.Rules.TenantHeader,
+		rules:        rules,
+		tel:          tel,
+	}, nil
+}
+
+// match finds the first rule the request matches. Nil is returned if no rule matches
+func (e *RulesEngine) match(ctx context.Context, messages []schemas.ChatMessage) *rule {
+	promptTokens := clients.EstimateTokens(messages)
+
+	for _, rule := range e.rules {
+		if rule.matches(ctx, e.tenantHeader, promptTokens) {
+			e.tel.M().Counter(fmt.Sprintf("routers.%v.rules.%v.matches", e.routerID, rule.config.Name)).Inc()
+
+			return rule
+		}
+	}
+
+	return nil
+}
+
+// ChatRouting returns the routing of the matched rule or the default routing if no rule matches
+func (e *RulesEngine) ChatRouting(ctx context.Context, messages []schemas.ChatMessage) (string, routing.LangModelRouting) {
+	if rule := e.match(ctx, messages); rule != nil {
+		return rule.config.Name, rule.routing.chat
+	}
+
+	return "", nil
+}
+
+// ChatStreamRouting is the streaming chat counterpart of ChatRouting
+func (e *RulesEngine) ChatStreamRouting(ctx context.Context, messages []schemas.ChatMessage) routing.LangModelRouting {
+	if rule := e.match(ctx, messages); rule != nil && rule.routing.chatStream != nil {
+		return rule.routing.chatStream
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package routers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newRulesRouter(t *testing.T) *LangRouter {
+	routerConfig := newLangRouterConfig("ruled", "default", "premium", "long_context")
+	routerConfig.Rules = DefaultRulesConfig()
+	routerConfig.Rules.Rules = []RuleConfig{
+		{Name: "premium_tenants", Tenants: []string{"acme"}, Models: []string{"premium"}},
+		{Name: "beta", Headers: map[string]string{"X-Beta": AnyHeaderValue}, Models: []string{"premium"}},
+		{Name: "long_prompts", MinTokens: 1000, Models: []string{"long_context"}},
+	}
+
+	router, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	return router
+}
+
+func TestRulesEngine_ChatRouting(t *testing.T) {
+	router := newRulesRouter(t)
+	shortPrompt := []schemas.ChatMessage{{Role: "user", Content: "hi"}}
+	longPrompt := []schemas.ChatMessage{{Role: "user", Content: strings.Repeat("long text ", 1000)}}
+
+	tests := map[string]struct {
+		headers  map[string][]string
+		messages []schemas.ChatMessage
+		rule     string
+		modelID  string
+	}{
+		"tenant":          {map[string][]string{"X-Tenant-Id": {"acme"}}, shortPrompt, "premium_tenants", "premium"},
+		"header":          {map[string][]string{"x-beta": {"1"}}, shortPrompt, "beta", "premium"},
+		"token count":     {map[string][]string{"X-Tenant-Id": {"other"}}, longPrompt, "long_prompts", "long_context"},
+		"first rule wins": {map[string][]string{"X-Tenant-Id": {"acme"}}, longPrompt, "premium_tenants", "premium"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := WithRequestHeaders(context.Background(), tc.headers)
+
+			rule, ruleRouting := router.rules.ChatRouting(ctx, tc.messages)
+			require.Equal(t, tc.rule, rule)
+
+			model, err := ruleRouting.(routing.ModelPeeker).Peek()
+			require.NoError(t, err)
+			require.Equal(t, tc.modelID, model.ID())
+		})
+	}
+
+	rule, ruleRouting := router.rules.ChatRouting(context.Background(), shortPrompt)
+	require.Empty(t, rule)
+	require.Nil(t, ruleRouting)
+
+	require.Equal(t, int64(2), router.tel.M().Counter("routers.ruled.rules.premium_tenants.matches").Value())
+}
+
+func TestRulesEngine_UnknownModel(t *testing.T) {
+	routerConfig := newLangRouterConfig("ruled", "default")
+	routerConfig.Rules = DefaultRulesConfig()
+	routerConfig.Rules.Rules = []RuleConfig{{Name: "premium", Tenants: []string{"acme"}, Models: []string{"premium"}}}
+
+	_, err := NewLangRouter(&routerConfig, telemetry.NewTelemetryMock())
+	require.ErrorContains(t, err, "routing rule \"premium\" refers to model \"premium\"")
+}
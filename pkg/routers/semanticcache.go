@@ -0,0 +1,192 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// SemanticCacheConfig defines how prompts similar to the cached ones are found
+type SemanticCacheConfig struct {
+	Model      *providers.LangModelConfig `yaml:"model" json:"model" validate:"required"`                      // the model that embeds prompts (e.g. a small embedding model)
+	Threshold  float64                    `yaml:"threshold" json:"threshold" validate:"gt=0,lte=1"`            // the least cosine similarity of prompts to share the response
+	MaxEntries int                        `yaml:"max_index_entries" json:"max_index_entries" validate:"gte=0"` // the vector index size, zero means no limit
+}
+
+func DefaultSemanticCacheConfig() *SemanticCacheConfig {
+	return &SemanticCacheConfig{
+		Threshold:  0.95,
+		MaxEntries: 10_000,
+	}
+}
+
+func (c *SemanticCacheConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultSemanticCacheConfig()
+
+	type plain SemanticCacheConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// VectorIndex finds cached prompts by their embeddings.
+// Entries are scoped, so prompts are matched only with prompts of requests that expect the same kind of response
+type VectorIndex interface {
+	// Add indexes the cache key by the prompt embedding
+	Add(scope string, key string, vector []float64)
+	// Search returns the key of the most similar prompt in the scope and its cosine similarity
+	Search(scope string, vector []float64) (string, float64, bool)
+}
+
+type vectorEntry struct {
+	scope  string
+	key    string
+	vector []float64
+}
+
+// MemoryVectorIndex searches vectors by brute force, dropping the oldest ones when the index is full.
+// It's fast enough for indexes of thousands of prompts
+type MemoryVectorIndex struct {
+	mu         sync.RWMutex
+	maxEntries int
+	entries    []vectorEntry
+	next       int // the entry to replace once the index is full
+}
+
+var _ VectorIndex = (*MemoryVectorIndex)(nil)
+
+// NewMemoryVectorIndex creates the index that holds at most maxEntries vectors (zero means no limit)
+func NewMemoryVectorIndex(maxEntries int) *MemoryVectorIndex {
+	return &MemoryVectorIndex{maxEntries: maxEntries}
+}
+
+func (i *MemoryVectorIndex) Add(scope string, key string, vector []float64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	entry := vectorEntry{scope: scope, key: key, vector: vector}
+
+	if i.maxEntries == 0 || len(i.entries) < i.maxEntries {
+		i.entries = append(i.entries, entry)
+
+		return
+	}
+
+	i.entries[i.next] = entry
+	i.next = (i.next + 1) % i.maxEntries
+}
+
+func (i *MemoryVectorIndex) Search(scope string, vector []float64) (string, float64, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	bestKey, bestSimilarity, found := "", 0.0, false
+
+	for _, entry := range i.entries {
+		if entry.scope != scope {
+			continue
+		}
+
+		if similarity := cosineSimilarity(entry.vector, vector); !found || similarity > bestSimilarity {
+			bestKey, bestSimilarity, found = entry.key, similarity, true
+		}
+	}
+
+	return bestKey, bestSimilarity, found
+}
+
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+
+	for idx := range a {
+		dot += a[idx] * b[idx]
+		normA += a[idx] * a[idx]
+		normB += b[idx] * b[idx]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// SemanticCache finds cached responses to prompts that are worded differently, but mean the same
+type SemanticCache struct {
+	model     providers.LangModel
+	index     VectorIndex
+	threshold float64
+	errors    *telemetry.Counter
+	logger    *zap.Logger
+}
+
+func NewSemanticCache(routerID RouterID, cfg *SemanticCacheConfig, tel *telemetry.Telemetry) (*SemanticCache, error) {
+	model, err := cfg.Model.ToModel(tel)
+	if err != nil {
+		return nil, fmt.Errorf("router \"%v\" semantic cache: %w", routerID, err)
+	}
+
+	if !model.SupportEmbed() {
+		return nil, fmt.Errorf("router \"%v\" semantic cache: model \"%v\" doesn't support embeddings", routerID, cfg.Model.ID)
+	}
+
+	return &SemanticCache{
+		model:     model,
+		index:     NewMemoryVectorIndex(cfg.MaxEntries),
+		threshold: cfg.Threshold,
+		errors:    tel.M().Counter(fmt.Sprintf("routers.%v.response_cache.semantic_errors", routerID)),
+		logger:    tel.L().With(zap.String("routerID", routerID)),
+	}, nil
+}
+
+// Embed returns the prompt embedding or nil if the model has failed to embed it
+func (c *SemanticCache) Embed(ctx context.Context, messages []schemas.ChatMessage) []float64 {
+	prompt := make([]string, 0, len(messages))
+
+	for _, message := range messages {
+		prompt = append(prompt, fmt.Sprintf("%v: %v", message.Role, message.Content))
+	}
+
+	resp, err := c.model.Embed(ctx, schemas.NewEmbedFromStr(strings.Join(prompt, "\n")))
+	if err == nil && len(resp.ModelResponse.Embeddings) == 0 {
+		err = errors.New("no embeddings returned")
+	}
+
+	if err != nil {
+		c.errors.Inc()
+		c.logger.Warn("failed to embed prompt for semantic cache", zap.Error(err))
+
+		return nil
+	}
+
+	return resp.ModelResponse.Embeddings[0].Vector
+}
+
+// Search returns the cache key of the most similar prompt if it's similar enough
+func (c *SemanticCache) Search(scope string, vector []float64) (string, bool) {
+	key, similarity, found := c.index.Search(scope, vector)
+
+	return key, found && similarity >= c.threshold
+}
+
+func (c *SemanticCache) Add(scope string, key string, vector []float64) {
+	c.index.Add(scope, key, vector)
+}
+
+// Shutdown stops background activities of the embedding model
+func (c *SemanticCache) Shutdown() {
+	if model, ok := c.model.(*providers.LanguageModel); ok {
+		model.Shutdown()
+	}
+}
@@ -0,0 +1,110 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// ShadowConfig defines mirroring of chat requests to a model that doesn't serve traffic,
+// so a new provider can be evaluated against production traffic without user impact
+type ShadowConfig struct {
+	Model    *providers.LangModelConfig `yaml:"model" json:"model" validate:"required"`
+	Fraction float64                    `yaml:"fraction" json:"fraction" validate:"gte=0,lte=1"`       // share of chat requests to mirror
+	Timeout  *fields.Duration           `yaml:"timeout" json:"timeout" swaggertype:"primitive,string"` // how long mirrored requests may take
+}
+
+func DefaultShadowConfig() *ShadowConfig {
+	defaultTimeout := 30 * time.Second
+
+	return &ShadowConfig{
+		Fraction: 0.1,
+		Timeout:  (*fields.Duration)(&defaultTimeout),
+	}
+}
+
+func (c *ShadowConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultShadowConfig()
+
+	type plain ShadowConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Shadow mirrors a fraction of chat requests to the shadow model asynchronously.
+// Shadow responses are discarded, while their latency & usage are counted next to the primary ones
+// of the same requests, so models can be compared
+type Shadow struct {
+	config *ShadowConfig
+	model  *providers.LanguageModel
+	prefix string
+	wg     sync.WaitGroup
+	tel    *telemetry.Telemetry
+	logger *zap.Logger
+}
+
+func NewShadow(routerID RouterID, config *ShadowConfig, tel *telemetry.Telemetry) (*Shadow, error) {
+	model, err := config.Model.ToModel(tel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init the shadow model of router \"%v\": %w", routerID, err)
+	}
+
+	return &Shadow{
+		config: config,
+		model:  model,
+		prefix: fmt.Sprintf("routers.%v.shadow.%v", routerID, model.ID()),
+		tel:    tel,
+		logger: tel.L().With(zap.String("routerID", routerID), zap.String("shadowModelID", model.ID())),
+	}, nil
+}
+
+// Sample decides whether the request should be mirrored
+func (s *Shadow) Sample() bool {
+	return rand.Float64() < s.config.Fraction
+}
+
+// Mirror sends the request to the shadow model in background.
+// The primary latency is the time the request took to be served by the router models
+func (s *Shadow) Mirror(req *schemas.ChatRequest, primaryLatency time.Duration) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*s.config.Timeout))
+		defer cancel()
+
+		meter := s.tel.M()
+
+		meter.Counter(s.prefix + ".requests").Inc()
+		meter.Counter(s.prefix + ".primary_latency_ms").Add(primaryLatency.Milliseconds())
+
+		startedAt := time.Now()
+
+		resp, err := s.model.Chat(ctx, req)
+		if err != nil {
+			meter.Counter(s.prefix + ".errors").Inc()
+			s.logger.Debug("Shadow model failed processing chat request", zap.Error(err))
+
+			return
+		}
+
+		meter.Counter(s.prefix + ".latency_ms").Add(time.Since(startedAt).Milliseconds())
+		meter.Counter(s.prefix + ".prompt_tokens").Add(int64(resp.ModelResponse.TokenUsage.PromptTokens))
+		meter.Counter(s.prefix + ".response_tokens").Add(int64(resp.ModelResponse.TokenUsage.ResponseTokens))
+	}()
+}
+
+// Shutdown waits for mirrored requests in flight and stops the shadow model
+func (s *Shadow) Shutdown() {
+	s.wg.Wait()
+	s.model.Shutdown()
+}
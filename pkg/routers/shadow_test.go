@@ -0,0 +1,76 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_Chat_ShadowTraffic(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"primary",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			budget,
+			*latConfig,
+			1,
+		),
+	}
+
+	shadowModel := providers.NewLangModel(
+		"candidate",
+		ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}, {Err: &ErrNoModelAvailable}}),
+		budget,
+		*latConfig,
+		1,
+	)
+
+	tel := telemetry.NewTelemetryMock()
+	shadowConfig := DefaultShadowConfig()
+	shadowConfig.Fraction = 1
+
+	router := LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority([]providers.Model{langModels[0]}),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		shadow: &Shadow{
+			config: shadowConfig,
+			model:  shadowModel,
+			prefix: "routers.test_router.shadow.candidate",
+			tel:    tel,
+			logger: telemetry.NewLoggerMock(),
+		},
+		tel:    tel,
+		logger: telemetry.NewLoggerMock(),
+	}
+
+	for range 2 {
+		resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+
+		// clients get primary responses only
+		require.NoError(t, err)
+		require.Equal(t, "primary", resp.ModelID)
+	}
+
+	router.shadow.wg.Wait()
+
+	counters := tel.M().Counters()
+
+	require.Equal(t, int64(2), counters["routers.test_router.shadow.candidate.requests"])
+	require.Equal(t, int64(1), counters["routers.test_router.shadow.candidate.errors"])
+}
@@ -0,0 +1,125 @@
+package routers
+
+import (
+	"context"
+	"errors"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+// singleActionRouter routes requests of one action (e.g. image generation) across its models
+type singleActionRouter[Req any, Resp schemas.RoutedResponse] struct {
+	routerID RouterID
+	action   providers.Action
+	models   []*providers.SingleActionModel[Req, Resp]
+	routing  routing.LangModelRouting
+	retry    *retry.ExpRetry
+	tel      *telemetry.Telemetry
+	logger   *zap.Logger
+}
+
+func newSingleActionRouter[Req any, Resp schemas.RoutedResponse](
+	routerID RouterID,
+	action providers.Action,
+	models []*providers.SingleActionModel[Req, Resp],
+	modelRouting routing.LangModelRouting,
+	retry *retry.ExpRetry,
+	tel *telemetry.Telemetry,
+) *singleActionRouter[Req, Resp] {
+	return &singleActionRouter[Req, Resp]{
+		routerID: routerID,
+		action:   action,
+		models:   models,
+		routing:  modelRouting,
+		retry:    retry,
+		tel:      tel,
+		logger:   tel.L().With(zap.String("routerID", routerID)),
+	}
+}
+
+func (r *singleActionRouter[Req, Resp]) ID() RouterID {
+	return r.routerID
+}
+
+// Shutdown stops background activities of the router models
+func (r *singleActionRouter[Req, Resp]) Shutdown() {
+	for _, model := range r.models {
+		model.Shutdown()
+	}
+}
+
+func (r *singleActionRouter[Req, Resp]) serve(ctx context.Context, req Req) (Resp, error) {
+	var noResp Resp
+
+	if len(r.models) == 0 {
+		return noResp, ErrNoModels
+	}
+
+	retryIterator := r.retry.Iterator()
+
+	for retryIterator.HasNext() {
+		modelIterator := r.routing.Iterator()
+
+		for {
+			model, err := modelIterator.Next()
+
+			if errors.Is(err, routing.ErrNoHealthyModels) {
+				// no healthy model in the pool. Let's retry after some time
+				break
+			}
+
+			actionModel := model.(*providers.SingleActionModel[Req, Resp])
+
+			resp, err := r.call(ctx, actionModel, req)
+			if err != nil {
+				r.logger.Warn(
+					"Model failed processing request",
+					zap.String("action", string(r.action)),
+					zap.String("modelID", actionModel.ID()),
+					zap.String("provider", actionModel.Provider()),
+					zap.Error(err),
+				)
+
+				continue
+			}
+
+			resp.SetRouterID(r.routerID)
+
+			return resp, nil
+		}
+
+		// no providers were available to handle the request,
+		//  so we have to wait a bit with a hope there is some available next time
+		r.logger.Warn("No healthy model found to serve request, wait and retry", zap.String("action", string(r.action)))
+
+		err := retryIterator.WaitNext(ctx)
+		if err != nil {
+			// something has cancelled the context
+			return noResp, err
+		}
+	}
+
+	r.logger.Error("No model was available to handle request", zap.String("action", string(r.action)))
+
+	return noResp, ErrNoModelAvailable
+}
+
+// call serves the request by the model annotating any panic with the router & model context
+func (r *singleActionRouter[Req, Resp]) call(
+	ctx context.Context,
+	model *providers.SingleActionModel[Req, Resp],
+	req Req,
+) (Resp, error) {
+	defer func() {
+		if value := recover(); value != nil {
+			panic(NewModelPanic(r.routerID, model.ID(), model.Provider(), value))
+		}
+	}()
+
+	return model.Serve(ctx, req)
+}
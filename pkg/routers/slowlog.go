@@ -0,0 +1,48 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// logSlowRequest warns about the chat request that took longer than the router threshold
+// with its routing trace & timings, so latency could be investigated without reproducing the request
+func (r *LangRouter) logSlowRequest(ctx context.Context, tracer *routingTracer, receivedAt time.Time, err error) {
+	threshold := r.Config.SlowRequestThreshold
+	duration := time.Since(receivedAt)
+
+	if threshold == nil || duration < *threshold {
+		return
+	}
+
+	r.tel.M().Counter(fmt.Sprintf("routers.%v.slow_requests", r.routerID)).Inc()
+
+	trace := tracer.snapshot()
+
+	var providerMs float64
+
+	for _, attempt := range trace.Attempts {
+		providerMs += attempt.LatencyMs
+	}
+
+	durationMs := float64(duration) / float64(time.Millisecond)
+
+	fields := []zap.Field{
+		zap.Float64("durationMs", durationMs),
+		zap.Duration("threshold", *threshold),
+		zap.String("caller", requestCaller(ctx)),
+		// hedged attempts overlap, so the provider time could exceed the request duration
+		zap.Float64("providerMs", providerMs),
+		zap.Float64("overheadMs", max(durationMs-providerMs, 0)),
+		zap.Any("routing", trace),
+	}
+
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	r.logger.Warn("slow chat request", fields...)
+}
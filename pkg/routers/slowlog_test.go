@@ -0,0 +1,57 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLangRouter_Chat_LogsSlowRequests(t *testing.T) {
+	router := newTracingRouter()
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	router.logger = zap.New(core)
+
+	threshold := time.Nanosecond
+	router.Config.SlowRequestThreshold = &threshold
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Nil(t, resp.Routing) // the trace is collected for the log only
+
+	slowLogs := logs.FilterMessage("slow chat request").All()
+	require.Len(t, slowLogs, 1)
+
+	fields := slowLogs[0].ContextMap()
+	require.Contains(t, fields, "durationMs")
+	require.Contains(t, fields, "providerMs")
+
+	trace, ok := fields["routing"].(*schemas.RoutingTrace)
+	require.True(t, ok)
+	require.Len(t, trace.Attempts, 2)
+	require.NotEmpty(t, trace.Attempts[0].Error)
+	require.Equal(t, "second", trace.Attempts[1].ModelID)
+
+	require.Equal(t, int64(1), router.tel.M().Counter("routers.test_router.slow_requests").Value())
+}
+
+func TestLangRouter_Chat_SkipsFastRequests(t *testing.T) {
+	router := newTracingRouter()
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	router.logger = zap.New(core)
+
+	threshold := time.Minute
+	router.Config.SlowRequestThreshold = &threshold
+
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+
+	require.Empty(t, logs.FilterMessage("slow chat request").All())
+}
@@ -0,0 +1,46 @@
+package routers
+
+import (
+	"glide/pkg/api/schemas"
+	"glide/pkg/routers/routing"
+)
+
+// Health reports the router models health & latencies and which models the routing strategy would pick next
+func (r *LangRouter) Health() *schemas.RouterHealth {
+	routerHealth := &schemas.RouterHealth{
+		RouterID: r.routerID,
+		Strategy: string(r.Config.RoutingStrategy),
+		Models:   make([]schemas.ModelHealth, 0, len(r.chatModels)),
+		Queue:    r.queue.Stats(),
+		NextModel: schemas.NextModels{
+			Chat:       peekModelID(r.chatRouting),
+			ChatStream: peekModelID(r.chatStreamRouting),
+			Embed:      peekModelID(r.embedRouting),
+		},
+	}
+
+	for _, model := range r.chatModels {
+		modelHealth := model.Health()
+
+		routerHealth.Healthy = routerHealth.Healthy || modelHealth.Healthy
+		routerHealth.Models = append(routerHealth.Models, modelHealth)
+	}
+
+	return routerHealth
+}
+
+// peekModelID returns ID of the model the routing would pick next
+// or an empty string if there is no healthy models or the routing cannot tell that upfront
+func peekModelID(modelRouting routing.LangModelRouting) string {
+	peeker, ok := modelRouting.(routing.ModelPeeker)
+	if !ok {
+		return ""
+	}
+
+	model, err := peeker.Peek()
+	if err != nil {
+		return ""
+	}
+
+	return model.ID()
+}
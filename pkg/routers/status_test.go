@@ -0,0 +1,74 @@
+package routers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_Health(t *testing.T) {
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock([]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}}),
+			health.NewErrorBudget(1, health.HOUR),
+			*latency.DefaultConfig(),
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock(nil),
+			health.NewErrorBudget(3, health.HOUR),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:          "test_router",
+		Config:            &LangRouterConfig{RoutingStrategy: routing.Priority},
+		chatRouting:       routing.NewPriority(models),
+		chatStreamRouting: routing.NewPriority(models),
+		embedRouting:      routing.NewPriority(nil),
+		chatModels:        langModels,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+
+	routerHealth := router.Health()
+
+	require.Equal(t, "test_router", routerHealth.RouterID)
+	require.Equal(t, "priority", routerHealth.Strategy)
+	require.True(t, routerHealth.Healthy)
+	require.Equal(t, "first", routerHealth.NextModel.Chat)
+	require.Empty(t, routerHealth.NextModel.Embed)
+	require.Len(t, routerHealth.Models, 2)
+	require.Equal(t, uint(1), routerHealth.Models[0].ErrorBudgetLeft)
+	require.Equal(t, uint(3), routerHealth.Models[1].ErrorBudgetLeft)
+
+	// the first model runs out of its error budget
+	_, err := langModels[0].Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.Error(t, err)
+
+	routerHealth = router.Health()
+
+	require.True(t, routerHealth.Healthy)
+	require.False(t, routerHealth.Models[0].Healthy)
+	require.Equal(t, uint(0), routerHealth.Models[0].ErrorBudgetLeft)
+	require.Equal(t, "second", routerHealth.NextModel.Chat)
+	require.Equal(t, "second", routerHealth.NextModel.ChatStream)
+}
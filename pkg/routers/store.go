@@ -0,0 +1,42 @@
+package routers
+
+import (
+	"fmt"
+
+	"glide/pkg/cache"
+	"glide/pkg/telemetry"
+)
+
+// newStore creates the store router state is kept in: Redis or SQLite if configured, memory otherwise
+func newStore(
+	routerID RouterID,
+	feature string,
+	maxEntries int,
+	maxBytes int64,
+	redisCfg *cache.RedisConfig,
+	sqliteCfg *cache.SQLiteConfig,
+	tel *telemetry.Telemetry,
+) (cache.Store, error) {
+	switch {
+	case redisCfg != nil && sqliteCfg != nil:
+		return nil, fmt.Errorf("router \"%v\" %v must use either redis or sqlite, not both", routerID, feature)
+	case redisCfg != nil:
+		redisStore, err := cache.NewRedisStore(redisCfg)
+		if err != nil {
+			return nil, fmt.Errorf("router \"%v\" redis store: %w", routerID, err)
+		}
+
+		return redisStore, nil
+	case sqliteCfg != nil:
+		sqliteStore, err := cache.NewSQLiteStore(sqliteCfg)
+		if err != nil {
+			return nil, fmt.Errorf("router \"%v\" sqlite store: %w", routerID, err)
+		}
+
+		return sqliteStore, nil
+	}
+
+	return cache.NewMemoryStore(maxEntries).
+		WithMaxBytes(maxBytes).
+		WithMetrics(tel.M(), fmt.Sprintf("routers.%v.%v.memory", routerID, feature)), nil
+}
@@ -0,0 +1,121 @@
+package routers
+
+import (
+	"context"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+)
+
+// StreamReplayConfig defines how cached responses are streamed to streaming chat clients.
+// Paced chunks make cached responses look like generated ones, so clients don't have to tell them apart
+type StreamReplayConfig struct {
+	ChunkSize int              `yaml:"chunk_size" json:"chunk_size" validate:"gte=0"`           // characters per chunk, zero sends the whole response in one chunk
+	Interval  *fields.Duration `yaml:"interval" json:"interval" swaggertype:"primitive,string"` // the pause between chunks, zero sends chunks all at once
+}
+
+func DefaultStreamReplayConfig() *StreamReplayConfig {
+	defaultInterval := 20 * time.Millisecond
+
+	return &StreamReplayConfig{
+		ChunkSize: 16,
+		Interval:  (*fields.Duration)(&defaultInterval),
+	}
+}
+
+func (c *StreamReplayConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultStreamReplayConfig()
+
+	type plain StreamReplayConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// splitContent splits the response content into chunks of the given number of characters (not bytes)
+func (c *StreamReplayConfig) splitContent(content string) []string {
+	runes := []rune(content)
+
+	if c.ChunkSize == 0 || len(runes) <= c.ChunkSize {
+		return []string{content}
+	}
+
+	chunks := make([]string, 0, len(runes)/c.ChunkSize+1)
+
+	for start := 0; start < len(runes); start += c.ChunkSize {
+		chunks = append(chunks, string(runes[start:min(start+c.ChunkSize, len(runes))]))
+	}
+
+	return chunks
+}
+
+// streamChatRequest is the chat request the streaming one would be if it wasn't streamed,
+// so streaming & regular chat requests share cached responses
+func streamChatRequest(req *schemas.ChatStreamRequest) *schemas.ChatRequest {
+	return &schemas.ChatRequest{
+		Message:        req.Message,
+		MessageHistory: req.MessageHistory,
+		Override:       req.Override,
+		SessionID:      req.SessionID,
+		Requires:       req.Requires,
+		ModelID:        req.ModelID,
+		Cache:          req.Cache,
+	}
+}
+
+// streamedChatResponse assembles the chat response from the streamed chunks, so it could be cached
+func streamedChatResponse(routerID RouterID, chunk *schemas.ChatStreamChunk, content string) *schemas.ChatResponse {
+	return &schemas.ChatResponse{
+		Created:   int(time.Now().UTC().Unix()),
+		Provider:  chunk.Provider,
+		RouterID:  routerID,
+		ModelID:   chunk.ModelID,
+		ModelName: chunk.ModelName,
+		ModelResponse: schemas.ModelResponse{
+			Message: schemas.ChatMessage{Role: "assistant", Content: content},
+		},
+	}
+}
+
+// Replay streams the cached response in chunks at the configured pace.
+// It stops early if the client goes away
+func (c *ResponseCache) Replay(
+	ctx context.Context,
+	req *schemas.ChatStreamRequest,
+	resp *schemas.ChatResponse,
+	respC chan<- *schemas.ChatStreamMessage,
+) {
+	var interval time.Duration
+
+	if c.replay.Interval != nil {
+		interval = time.Duration(*c.replay.Interval)
+	}
+
+	contentChunks := c.replay.splitContent(resp.ModelResponse.Message.Content)
+
+	for idx, content := range contentChunks {
+		if idx > 0 && interval > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+
+		chunk := &schemas.ChatStreamChunk{
+			ModelID:   resp.ModelID,
+			Provider:  resp.Provider,
+			ModelName: resp.ModelName,
+			Cached:    true,
+			ModelResponse: schemas.ModelChunkResponse{
+				Message: schemas.ChatMessage{Role: resp.ModelResponse.Message.Role, Content: content},
+			},
+		}
+
+		if idx == len(contentChunks)-1 {
+			chunk.FinishReason = &schemas.Complete
+		}
+
+		respC <- schemas.NewChatStreamChunk(req.ID, c.routerID, req.Metadata, chunk)
+	}
+}
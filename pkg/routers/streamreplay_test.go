@@ -0,0 +1,133 @@
+package routers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/config/fields"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func TestStreamReplayConfig_SplitContent(t *testing.T) {
+	config := &StreamReplayConfig{ChunkSize: 3}
+
+	require.Equal(t, []string{"Knö", "ck ", "kno", "ck"}, config.splitContent("Knöck knock"))
+	require.Equal(t, []string{""}, config.splitContent(""))
+
+	config.ChunkSize = 0
+
+	require.Equal(t, []string{"Knöck knock"}, config.splitContent("Knöck knock"))
+}
+
+func TestResponseCache_Replay(t *testing.T) {
+	interval := 5 * time.Millisecond
+	config := DefaultResponseCacheConfig()
+	config.StreamReplay = &StreamReplayConfig{ChunkSize: 6, Interval: (*fields.Duration)(&interval)}
+
+	responseCache, err := NewResponseCache("router", config, nil, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	resp := &schemas.ChatResponse{
+		ModelID:       "openai",
+		ModelResponse: schemas.ModelResponse{Message: schemas.ChatMessage{Role: "assistant", Content: "Knock knock"}},
+	}
+
+	respC := make(chan *schemas.ChatStreamMessage, 10)
+	startedAt := time.Now()
+
+	responseCache.Replay(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"), resp, respC)
+	close(respC)
+
+	require.GreaterOrEqual(t, time.Since(startedAt), interval)
+
+	messages := make([]*schemas.ChatStreamMessage, 0, 2)
+
+	for message := range respC {
+		require.True(t, message.Chunk.Cached)
+		require.Equal(t, "openai", message.Chunk.ModelID)
+
+		messages = append(messages, message)
+	}
+
+	require.Len(t, messages, 2)
+	require.Equal(t, "Knock ", messages[0].Chunk.ModelResponse.Message.Content)
+	require.Nil(t, messages[0].Chunk.FinishReason)
+	require.Equal(t, "knock", messages[1].Chunk.ModelResponse.Message.Content)
+	require.Equal(t, &schemas.Complete, messages[1].Chunk.FinishReason)
+}
+
+func TestLangRouter_ChatStream_ReplaysCachedResponses(t *testing.T) {
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewStreamProviderMock([]ptesting.RespStreamMock{
+				ptesting.NewRespStreamMock(&[]ptesting.RespMock{{Msg: "Knock"}, {Msg: " knock"}}),
+			}),
+			health.NewErrorBudget(3, health.SEC),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	config := DefaultResponseCacheConfig()
+	config.StreamReplay = &StreamReplayConfig{}
+
+	responseCache, err := NewResponseCache("test_stream_router", config, nil, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	router := LangRouter{
+		routerID:          "test_stream_router",
+		Config:            &LangRouterConfig{},
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatStreamRouting: routing.NewPriority([]providers.Model{langModels[0]}),
+		chatStreamModels:  langModels,
+		responseCache:     responseCache,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+
+	stream := func() []*schemas.ChatStreamMessage {
+		respC := make(chan *schemas.ChatStreamMessage, 10)
+
+		router.ChatStream(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"), respC)
+		close(respC)
+
+		messages := make([]*schemas.ChatStreamMessage, 0, 2)
+
+		for message := range respC {
+			require.Nil(t, message.Error)
+
+			messages = append(messages, message)
+		}
+
+		return messages
+	}
+
+	messages := stream()
+	require.Len(t, messages, 2)
+	require.False(t, messages[0].Chunk.Cached)
+
+	messages = stream()
+	require.Len(t, messages, 1)
+	require.True(t, messages[0].Chunk.Cached)
+	require.Equal(t, "first", messages[0].Chunk.ModelID)
+	require.Equal(t, "Knock knock", messages[0].Chunk.ModelResponse.Message.Content)
+
+	// regular chat requests are served with the streamed response too
+	var calls atomic.Int32
+
+	resp, err := responseCache.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"), countingChat(&calls, 0))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Zero(t, calls.Load())
+}
@@ -0,0 +1,221 @@
+package routers
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+)
+
+// TimingHeader lets clients request the latency breakdown when they cannot set the request field
+const TimingHeader = "X-Glide-Timing"
+
+// responseTimer breaks the chat request latency down.
+// Nil timer measures nothing, so timing costs nothing unless requested
+type responseTimer struct {
+	mu         sync.Mutex
+	receivedAt time.Time
+	queueWait  time.Duration
+	retries    int
+	attempts   []*attemptTimer
+}
+
+func newResponseTimer(ctx context.Context, req *schemas.ChatRequest, receivedAt time.Time) *responseTimer {
+	timingHeader, _ := requestHeader(ctx, TimingHeader)
+
+	if !req.Timing && !strings.EqualFold(timingHeader, "true") {
+		return nil
+	}
+
+	return &responseTimer{receivedAt: receivedAt}
+}
+
+// waited records the time the request has waited in the router queue
+func (t *responseTimer) waited(startedAt time.Time) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.queueWait += time.Since(startedAt)
+}
+
+// retried records the router has waited for a healthy model
+func (t *responseTimer) retried() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.retries++
+}
+
+// attempt starts timing the call to the model. The returned context traces the provider HTTP requests
+func (t *responseTimer) attempt(ctx context.Context, model providers.LangModel) (context.Context, *attemptTimer) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	attempt := &attemptTimer{
+		timer:     t,
+		startedAt: time.Now(),
+		timing: schemas.AttemptTiming{
+			ModelID:  model.ID(),
+			Provider: model.Provider(),
+		},
+	}
+
+	t.mu.Lock()
+	t.attempts = append(t.attempts, attempt)
+	t.mu.Unlock()
+
+	return httptrace.WithClientTrace(ctx, attempt.clientTrace()), attempt
+}
+
+// served finishes the timing once the model has served the request
+func (t *responseTimer) served() *schemas.ResponseTiming {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total := time.Since(t.receivedAt)
+	timing := &schemas.ResponseTiming{
+		TotalMs:     milliseconds(total),
+		QueueWaitMs: milliseconds(t.queueWait),
+		Retries:     t.retries,
+		Attempts:    make([]schemas.AttemptTiming, 0, len(t.attempts)),
+	}
+
+	for _, attempt := range t.attempts {
+		timing.Attempts = append(timing.Attempts, attempt.timing)
+	}
+
+	timing.GatewayMs = milliseconds(max(total-t.providerTime(), 0))
+
+	return timing
+}
+
+// providerTime is the time spent in provider calls. Hedged calls overlap, so overlapping time is counted once
+func (t *responseTimer) providerTime() time.Duration {
+	calls := make([][2]time.Time, 0, len(t.attempts))
+
+	for _, attempt := range t.attempts {
+		finishedAt := attempt.finishedAt
+		if finishedAt.IsZero() {
+			finishedAt = time.Now() // the losing hedged call could still be in flight
+		}
+
+		calls = append(calls, [2]time.Time{attempt.startedAt, finishedAt})
+	}
+
+	slices.SortFunc(calls, func(a, b [2]time.Time) int {
+		return a[0].Compare(b[0])
+	})
+
+	var (
+		providerTime time.Duration
+		coveredUntil time.Time
+	)
+
+	for _, call := range calls {
+		startedAt := call[0]
+		if startedAt.Before(coveredUntil) {
+			startedAt = coveredUntil
+		}
+
+		if call[1].After(startedAt) {
+			providerTime += call[1].Sub(startedAt)
+			coveredUntil = call[1]
+		}
+	}
+
+	return providerTime
+}
+
+// attemptTimer measures the call to the model
+type attemptTimer struct {
+	timer      *responseTimer
+	startedAt  time.Time
+	finishedAt time.Time
+	timing     schemas.AttemptTiming
+}
+
+// done finishes the attempt timing
+func (a *attemptTimer) done(err error) {
+	if a == nil {
+		return
+	}
+
+	a.timer.mu.Lock()
+	defer a.timer.mu.Unlock()
+
+	a.finishedAt = time.Now()
+	a.timing.TotalMs = milliseconds(a.finishedAt.Sub(a.startedAt))
+
+	if err != nil {
+		a.timing.Error = err.Error()
+	}
+}
+
+// clientTrace measures phases of provider HTTP requests. Phases of repeated requests (e.g. paginated ones) add up
+func (a *attemptTimer) clientTrace() *httptrace.ClientTrace {
+	var dnsStartedAt, connectStartedAt, tlsStartedAt time.Time
+
+	// hooks could be called from different goroutines (e.g. dialing several addresses at once)
+	locked := func(hook func()) {
+		a.timer.mu.Lock()
+		defer a.timer.mu.Unlock()
+
+		if a.finishedAt.IsZero() {
+			hook()
+		}
+	}
+
+	started := func(startedAt *time.Time) {
+		locked(func() { *startedAt = time.Now() })
+	}
+
+	finished := func(startedAt *time.Time, phase *float64) {
+		locked(func() {
+			if !startedAt.IsZero() {
+				*phase += milliseconds(time.Since(*startedAt))
+			}
+		})
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { started(&dnsStartedAt) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { finished(&dnsStartedAt, &a.timing.DNSMs) },
+		ConnectStart:      func(string, string) { started(&connectStartedAt) },
+		ConnectDone:       func(string, string, error) { finished(&connectStartedAt, &a.timing.ConnectMs) },
+		TLSHandshakeStart: func() { started(&tlsStartedAt) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { finished(&tlsStartedAt, &a.timing.TLSMs) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			locked(func() { a.timing.ConnReused = info.Reused })
+		},
+		GotFirstResponseByte: func() {
+			locked(func() {
+				if a.timing.TTFBMs == 0 {
+					a.timing.TTFBMs = milliseconds(time.Since(a.startedAt))
+				}
+			})
+		},
+	}
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
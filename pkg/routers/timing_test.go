@@ -0,0 +1,97 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+)
+
+func TestLangRouter_Chat_Timing(t *testing.T) {
+	router := newTracingRouter()
+
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.Timing = true
+
+	resp, err := router.Chat(context.Background(), req)
+	require.NoError(t, err)
+	require.Nil(t, resp.Routing)
+
+	timing := resp.Timing
+	require.NotNil(t, timing)
+	require.Positive(t, timing.TotalMs)
+	require.GreaterOrEqual(t, timing.TotalMs, timing.GatewayMs)
+	require.Zero(t, timing.Retries)
+
+	require.Len(t, timing.Attempts, 2)
+	require.Equal(t, "first", timing.Attempts[0].ModelID)
+	require.NotEmpty(t, timing.Attempts[0].Error)
+	require.Equal(t, "second", timing.Attempts[1].ModelID)
+	require.Empty(t, timing.Attempts[1].Error)
+
+	// the timing is requested by the header
+	ctx := WithRequestHeaders(context.Background(), map[string][]string{TimingHeader: {"true"}})
+
+	resp, err = router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.NotNil(t, resp.Timing)
+}
+
+func TestLangRouter_Chat_NoTiming(t *testing.T) {
+	router := newTracingRouter()
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Nil(t, resp.Timing)
+}
+
+func TestResponseTimer_TracesProviderRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+
+	defer server.Close()
+
+	model := providers.NewLangModel(
+		"first",
+		ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "1"}}),
+		health.NewErrorBudget(3, health.SEC),
+		*latency.DefaultConfig(),
+		1,
+	)
+
+	timer := &responseTimer{receivedAt: time.Now()}
+
+	for range 2 {
+		ctx, attempt := timer.attempt(context.Background(), model)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		attempt.done(nil)
+	}
+
+	timing := timer.served()
+
+	require.Len(t, timing.Attempts, 2)
+	require.Equal(t, "provider_mock", timing.Attempts[0].Provider)
+	require.Positive(t, timing.Attempts[0].ConnectMs)
+	require.Positive(t, timing.Attempts[0].TTFBMs)
+	require.False(t, timing.Attempts[0].ConnReused)
+
+	// the connection is kept alive
+	require.True(t, timing.Attempts[1].ConnReused)
+	require.Positive(t, timing.Attempts[1].TTFBMs)
+}
@@ -0,0 +1,46 @@
+package routers
+
+import (
+	"context"
+	"errors"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+)
+
+var ErrModelNotFound = errors.New("no model found with given ID in the router")
+
+// CountTokens counts prompt tokens for the requested router model or the first router model if none was requested
+func (r *LangRouter) CountTokens(ctx context.Context, req *schemas.TokenizeRequest) (*schemas.TokenizeResponse, error) {
+	if len(r.chatModels) == 0 {
+		return nil, ErrNoModels
+	}
+
+	model := r.chatModels[0]
+
+	if req.ModelID != "" {
+		model = r.findModel(req.ModelID)
+		if model == nil {
+			return nil, ErrModelNotFound
+		}
+	}
+
+	resp, err := model.CountTokens(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.RouterID = r.routerID
+
+	return resp, nil
+}
+
+func (r *LangRouter) findModel(modelID string) *providers.LanguageModel {
+	for _, model := range r.chatModels {
+		if model.ID() == modelID {
+			return model
+		}
+	}
+
+	return nil
+}
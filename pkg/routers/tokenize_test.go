@@ -0,0 +1,69 @@
+package routers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/telemetry"
+)
+
+func newTokenizeRouterMock() *LangRouter {
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil),
+			health.NewErrorBudget(3, health.SEC),
+			*latency.DefaultConfig(),
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock(nil),
+			health.NewErrorBudget(3, health.SEC),
+			*latency.DefaultConfig(),
+			1,
+		),
+	}
+
+	return &LangRouter{
+		routerID:   "test_router",
+		Config:     &LangRouterConfig{},
+		chatModels: langModels,
+		tel:        telemetry.NewTelemetryMock(),
+		logger:     telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_CountTokens(t *testing.T) {
+	router := newTokenizeRouterMock()
+
+	resp, err := router.CountTokens(context.Background(), schemas.NewTokenizeFromStr("Hello world"))
+	require.NoError(t, err)
+
+	require.Equal(t, "test_router", resp.RouterID)
+	require.Equal(t, "first", resp.ModelID)
+	require.True(t, resp.Estimated)
+	require.Positive(t, resp.Tokens)
+}
+
+func TestLangRouter_CountTokensForModel(t *testing.T) {
+	router := newTokenizeRouterMock()
+
+	req := schemas.NewTokenizeFromStr("Hello world")
+	req.ModelID = "second"
+
+	resp, err := router.CountTokens(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	req.ModelID = "unknown"
+
+	_, err = router.CountTokens(context.Background(), req)
+	require.ErrorIs(t, err, ErrModelNotFound)
+}
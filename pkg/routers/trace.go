@@ -0,0 +1,178 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/routers/routing"
+)
+
+// TraceHeader lets clients request the routing trace when they cannot set the request field
+const TraceHeader = "X-Glide-Trace"
+
+// routingTracer collects the routing trace of the chat request.
+// Nil tracer collects nothing, so tracing costs nothing unless requested
+type routingTracer struct {
+	mu         sync.Mutex
+	requested  bool // by the client, otherwise the trace is only collected for the slow request log
+	trace      *schemas.RoutingTrace
+	routing    routing.LangModelRouting
+	sessionID  string
+	startedAts []time.Time
+}
+
+func (r *LangRouter) newTracer(
+	ctx context.Context,
+	req *schemas.ChatRequest,
+	modelRouting routing.LangModelRouting,
+	pool string,
+	needs requestNeeds,
+) *routingTracer {
+	traceHeader, _ := requestHeader(ctx, TraceHeader)
+	requested := req.Trace || strings.EqualFold(traceHeader, "true")
+
+	if !requested && r.Config.SlowRequestThreshold == nil {
+		return nil
+	}
+
+	candidates := make([]schemas.RoutingCandidate, 0, len(r.chatModels))
+
+	for _, model := range r.chatModels {
+		candidate := schemas.RoutingCandidate{
+			ModelID:   model.ID(),
+			Healthy:   model.Healthy(),
+			Latency:   model.ChatLatency().Value(),
+			InFlight:  model.InFlight(),
+			ErrorRate: model.ErrorRate(),
+		}
+
+		switch {
+		case !model.FitsContextWindow(needs.promptTokens):
+			candidate.Skipped = "context_window"
+		case !model.Supports(needs.capabilities):
+			candidate.Skipped = "capability"
+		case model.NearRateLimit():
+			candidate.Skipped = "near_rate_limit"
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return &routingTracer{
+		requested: requested,
+		trace: &schemas.RoutingTrace{
+			Strategy:   string(r.Config.RoutingStrategy),
+			Pool:       pool,
+			Candidates: candidates,
+			Attempts:   make([]schemas.RoutingAttempt, 0, 1),
+		},
+		routing:   modelRouting,
+		sessionID: req.SessionID,
+	}
+}
+
+// attempt records the model picked to serve the request
+func (t *routingTracer) attempt(model providers.Model, reason string) {
+	if t == nil {
+		return
+	}
+
+	if reason == "" {
+		reason = t.reason(t.routing, model)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.startedAts = append(t.startedAts, time.Now())
+	t.trace.Attempts = append(t.trace.Attempts, schemas.RoutingAttempt{
+		ModelID: model.ID(),
+		Reason:  reason,
+	})
+}
+
+// failed records the failure of the latest attempt of the model
+func (t *routingTracer) failed(model providers.Model, err error) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if attempt := t.finish(model); attempt != nil {
+		attempt.Error = err.Error()
+	}
+}
+
+// served finishes the trace once the model has served the request. The trace is returned only if the client has requested it
+func (t *routingTracer) served(model providers.Model) *schemas.RoutingTrace {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.finish(model)
+
+	if !t.requested {
+		return nil
+	}
+
+	return t.trace
+}
+
+// snapshot copies the trace collected so far (e.g. hedged attempts could still be finishing)
+func (t *routingTracer) snapshot() *schemas.RoutingTrace {
+	if t == nil {
+		return &schemas.RoutingTrace{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trace := *t.trace
+	trace.Attempts = slices.Clone(t.trace.Attempts)
+
+	return &trace
+}
+
+// finish records the latency of the latest attempt of the model
+func (t *routingTracer) finish(model providers.Model) *schemas.RoutingAttempt {
+	for idx := len(t.trace.Attempts) - 1; idx >= 0; idx-- {
+		if attempt := &t.trace.Attempts[idx]; attempt.ModelID == model.ID() {
+			attempt.LatencyMs = float64(time.Since(t.startedAts[idx])) / float64(time.Millisecond)
+
+			return attempt
+		}
+	}
+
+	return nil
+}
+
+func (t *routingTracer) reason(modelRouting routing.LangModelRouting, model providers.Model) string {
+	if pinned, ok := modelRouting.(*pinnedRouting); ok {
+		if pinned.model.ID() == model.ID() {
+			return "pinned by the client"
+		}
+
+		return "fallback from the pinned model: " + t.reason(pinned.fallback, model)
+	}
+
+	if _, ok := modelRouting.(routing.SessionRouting); ok && t.sessionID != "" {
+		return "the session model"
+	}
+
+	if explainer, ok := modelRouting.(routing.DecisionExplainer); ok {
+		return explainer.Explain(model)
+	}
+
+	return fmt.Sprintf("picked by the %v strategy", t.trace.Strategy)
+}
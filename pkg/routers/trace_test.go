@@ -0,0 +1,84 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/providers/clients"
+	ptesting "glide/pkg/providers/testing"
+	"glide/pkg/routers/health"
+	"glide/pkg/routers/latency"
+	"glide/pkg/routers/retry"
+	"glide/pkg/routers/routing"
+	"glide/pkg/telemetry"
+)
+
+func newTracingRouter() *LangRouter {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock([]ptesting.RespMock{{Err: &clients.ErrProviderUnavailable}, {Msg: "1"}}), budget, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock([]ptesting.RespMock{{Msg: "2"}, {Msg: "2"}}), budget, *latConfig, 1),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{RoutingStrategy: routing.Priority},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              telemetry.NewTelemetryMock(),
+		logger:           telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_Trace(t *testing.T) {
+	router := newTracingRouter()
+
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.Trace = true
+
+	resp, err := router.Chat(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	trace := resp.Routing
+	require.NotNil(t, trace)
+	require.Equal(t, string(routing.Priority), trace.Strategy)
+	require.Len(t, trace.Candidates, 2)
+	require.Equal(t, "first", trace.Candidates[0].ModelID)
+	require.True(t, trace.Candidates[0].Healthy)
+
+	require.Len(t, trace.Attempts, 2)
+	require.Equal(t, "first", trace.Attempts[0].ModelID)
+	require.Equal(t, "picked by the priority strategy", trace.Attempts[0].Reason)
+	require.NotEmpty(t, trace.Attempts[0].Error)
+	require.Equal(t, "second", trace.Attempts[1].ModelID)
+	require.Empty(t, trace.Attempts[1].Error)
+
+	// the trace is requested by the header
+	ctx := WithRequestHeaders(context.Background(), map[string][]string{TraceHeader: {"true"}})
+
+	resp, err = router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.NotNil(t, resp.Routing)
+}
+
+func TestLangRouter_Chat_NoTrace(t *testing.T) {
+	router := newTracingRouter()
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Nil(t, resp.Routing)
+}
@@ -0,0 +1,59 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+)
+
+type callerKey struct{}
+
+// WithCaller passes the ID of the client sending the request (e.g. the API key hash), so usage is accounted per client
+func WithCaller(ctx context.Context, callerID string) context.Context {
+	return context.WithValue(ctx, callerKey{}, callerID)
+}
+
+func requestCaller(ctx context.Context) string {
+	callerID, _ := ctx.Value(callerKey{}).(string)
+
+	return callerID
+}
+
+// trackUsage accounts the usage, the cost & the latency of the served request per router, model & caller.
+// It returns the request cost or nil if the model has no pricing configured
+func (r *LangRouter) trackUsage(ctx context.Context, langModel providers.LangModel, usage schemas.TokenUsage, latency time.Duration) *float64 {
+	var cost *float64
+
+	if model, ok := langModel.(*providers.LanguageModel); ok && model.Pricing() != nil {
+		requestCost := model.Pricing().Cost(usage)
+		cost = &requestCost
+
+		// counters are integer, so costs are counted in micro dollars
+		r.tel.M().Counter(fmt.Sprintf("routers.%v.models.%v.cost_micro_usd", r.routerID, langModel.ID())).Add(int64(requestCost * 1_000_000))
+	}
+
+	var recordedCost float64
+
+	if cost != nil {
+		recordedCost = *cost
+	}
+
+	r.tel.Usage.Record(&telemetry.UsageEvent{
+		Time:               time.Now().UTC(),
+		RouterID:           r.routerID,
+		ModelID:            langModel.ID(),
+		Provider:           langModel.Provider(),
+		Caller:             requestCaller(ctx),
+		PromptTokens:       usage.PromptTokens,
+		ResponseTokens:     usage.ResponseTokens,
+		CachedPromptTokens: usage.CachedPromptTokens,
+		Cost:               recordedCost,
+		LatencyMs:          float64(latency) / float64(time.Millisecond),
+	})
+
+	return cost
+}
@@ -0,0 +1,57 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers"
+	"glide/pkg/telemetry"
+)
+
+func TestLangRouter_TracksUsagePerCaller(t *testing.T) {
+	chatResp, err := os.ReadFile(filepath.Clean("../providers/openai/testdata/chat.success.json"))
+	require.NoError(t, err)
+
+	providerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(chatResp)
+	}))
+	defer providerServer.Close()
+
+	routerConfig := newLangRouterConfig("usage", "priced", "free")
+
+	for idx := range routerConfig.Models {
+		routerConfig.Models[idx].OpenAI.BaseURL = providerServer.URL
+	}
+
+	// each response costs $0.9 (9 prompt tokens)
+	routerConfig.Models[0].Pricing = &providers.Pricing{PromptTokens: 100_000}
+
+	tel := telemetry.NewTelemetryMock()
+
+	router, err := NewLangRouter(&routerConfig, tel)
+	require.NoError(t, err)
+
+	defer router.Shutdown()
+
+	resp, err := router.Chat(WithCaller(context.Background(), "key:abc"), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "priced", resp.ModelID)
+	require.NotNil(t, resp.Cost)
+	require.InDelta(t, 0.9, *resp.Cost, 0.0001)
+
+	records := tel.Usage.Records(telemetry.UsageFilter{RouterID: "usage"})
+	require.Len(t, records, 1)
+	require.Equal(t, "key:abc", records[0].Caller)
+	require.Equal(t, int64(1), records[0].Requests)
+	require.Equal(t, int64(9), records[0].PromptTokens)
+	require.InDelta(t, 0.9, records[0].Cost, 0.0001)
+
+	require.Equal(t, int64(900_000), tel.M().Counter("routers.usage.models.priced.cost_micro_usd").Value())
+}
@@ -0,0 +1,168 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"glide/pkg/config/fields"
+)
+
+const AWSScheme = "aws-sm"
+
+// AWSConfig defines how AWS Secrets Manager is accessed.
+// Credentials are picked up via the standard AWS credential chain (env vars, shared config files, IAM roles)
+type AWSConfig struct {
+	Region          string           `yaml:"region,omitempty"`                                // defaults to the region of the credential chain (e.g. AWS_REGION)
+	Profile         string           `yaml:"profile,omitempty"`                               // the shared config profile
+	Endpoint        string           `yaml:"endpoint,omitempty" validate:"omitempty,url"`     // e.g. a VPC endpoint or LocalStack
+	VersionStage    string           `yaml:"version_stage,omitempty"`                         // defaults to AWSCURRENT
+	RefreshInterval *fields.Duration `yaml:"refresh_interval" swaggertype:"primitive,string"` // how often secrets are fetched again to pick up rotated ones, zero disables it
+}
+
+func DefaultAWSConfig() *AWSConfig {
+	refreshInterval := time.Hour
+
+	return &AWSConfig{
+		RefreshInterval: (*fields.Duration)(&refreshInterval),
+	}
+}
+
+func (c *AWSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultAWSConfig()
+
+	type plain AWSConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// AWSBackend reads secrets via the Secrets Manager API. Secrets are referenced by their names or ARNs,
+// e.g. aws-sm:glide/openai for the plain secret or aws-sm:glide/llm-keys#openai for the key of the JSON secret
+type AWSBackend struct {
+	cfg         *AWSConfig
+	region      string
+	endpoint    string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	httpClient  *http.Client
+}
+
+func NewAWSBackend(cfg *AWSConfig) (*AWSBackend, error) {
+	options := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(cfg.Region)}
+
+	if cfg.Profile != "" {
+		options = append(options, awsconfig.WithSharedConfigProfile(cfg.Profile))
+	}
+
+	// credentials are not retrieved until they are needed, so no network calls are made here
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	if awsCfg.Region == "" {
+		return nil, errors.New("AWS region is required, set it in the config or via AWS_REGION env var")
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%v.amazonaws.com", awsCfg.Region)
+	}
+
+	return &AWSBackend{
+		cfg:         cfg,
+		region:      awsCfg.Region,
+		endpoint:    endpoint,
+		credentials: awsCfg.Credentials,
+		signer:      v4.NewSigner(),
+		httpClient:  &http.Client{},
+	}, nil
+}
+
+func (b *AWSBackend) Scheme() string {
+	return AWSScheme
+}
+
+type getSecretValueRequest struct {
+	SecretID     string `json:"SecretId"`
+	VersionStage string `json:"VersionStage,omitempty"`
+}
+
+type getSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+	SecretBinary []byte `json:"SecretBinary"` // base64 encoded in JSON
+}
+
+type awsErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (b *AWSBackend) Fetch(ctx context.Context, path string) (*Secret, error) {
+	body, err := json.Marshal(getSecretValueRequest{SecretID: path, VersionStage: b.cfg.VersionStage})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	credentials, err := b.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	bodyHash := sha256.Sum256(body)
+
+	if err := b.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(bodyHash[:]), "secretsmanager", b.region, time.Now()); err != nil {
+		return nil, err
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp awsErrorResponse
+
+		_ = json.Unmarshal(respBody, &errResp)
+
+		return nil, fmt.Errorf("secrets manager responded with status %v: %v %v", resp.StatusCode, errResp.Type, errResp.Message)
+	}
+
+	var secretValue getSecretValueResponse
+
+	if err := json.Unmarshal(respBody, &secretValue); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets manager response: %w", err)
+	}
+
+	value := secretValue.SecretString
+	if value == "" {
+		value = string(secretValue.SecretBinary)
+	}
+
+	return plainSecret(value, renewAfter(b.cfg.RefreshInterval))
+}
@@ -0,0 +1,98 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAWSBackend_Fetch(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		require.True(t, strings.HasPrefix(
+			r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/",
+		))
+		require.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/secretsmanager/aws4_request")
+
+		var req getSecretValueRequest
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "AWSPENDING", req.VersionStage)
+
+		switch req.SecretID {
+		case "glide/openai":
+			_, _ = w.Write([]byte(`{"Name": "glide/openai", "SecretString": "sk-openai"}`))
+		case "glide/llm-keys":
+			_, _ = w.Write([]byte(`{"Name": "glide/llm-keys", "SecretString": "{\"cohere\": \"co-key\", \"port\": 8080}"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type": "ResourceNotFoundException", "message": "Secrets Manager can't find the specified secret."}`))
+		}
+	}))
+
+	defer server.Close()
+
+	cfg := DefaultAWSConfig()
+	cfg.Region = "eu-west-1"
+	cfg.Endpoint = server.URL
+	cfg.VersionStage = "AWSPENDING"
+
+	backend, err := NewAWSBackend(cfg)
+	require.NoError(t, err)
+
+	resolver := newResolver(time.Second, backend)
+	ctx := context.Background()
+
+	value, err := resolver.Resolve(ctx, "aws-sm:glide/openai")
+	require.NoError(t, err)
+	require.Equal(t, "sk-openai", value)
+
+	value, err = resolver.Resolve(ctx, "aws-sm:glide/llm-keys#cohere")
+	require.NoError(t, err)
+	require.Equal(t, "co-key", value)
+
+	value, err = resolver.Resolve(ctx, "aws-sm:glide/llm-keys#port")
+	require.NoError(t, err)
+	require.Equal(t, "8080", value)
+
+	// rotated secrets are picked up periodically
+	require.WithinDuration(t, time.Now().Add(time.Hour), resolver.RenewAt(), time.Second)
+
+	_, err = backend.Fetch(ctx, "glide/anthropic")
+	require.ErrorContains(t, err, "ResourceNotFoundException")
+}
+
+func TestNewAWSBackend_RequiresRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+
+	_, err := NewAWSBackend(DefaultAWSConfig())
+	require.Error(t, err)
+}
+
+func TestAWSConfig_RefreshInterval(t *testing.T) {
+	var cfg AWSConfig
+
+	require.NoError(t, yaml.Unmarshal([]byte("region: eu-west-1"), &cfg))
+	require.Equal(t, time.Hour, renewAfter(cfg.RefreshInterval))
+
+	require.NoError(t, yaml.Unmarshal([]byte("refresh_interval: 15m"), &cfg))
+	require.Equal(t, 15*time.Minute, renewAfter(cfg.RefreshInterval))
+
+	require.NoError(t, yaml.Unmarshal([]byte("refresh_interval: null"), &cfg))
+	require.Zero(t, renewAfter(cfg.RefreshInterval))
+}
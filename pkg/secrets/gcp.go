@@ -0,0 +1,151 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"glide/pkg/config/fields"
+)
+
+const GCPScheme = "gcp-sm"
+
+// GCPConfig defines how GCP Secret Manager is accessed.
+// Credentials are picked up via Application Default Credentials (GOOGLE_APPLICATION_CREDENTIALS, gcloud, the metadata server)
+type GCPConfig struct {
+	Project         string           `yaml:"project,omitempty"`                               // the project of secrets referenced by their short names
+	CredentialsFile string           `yaml:"credentials_file,omitempty"`                      // the service account key file, overrides the default credentials
+	Endpoint        string           `yaml:"endpoint,omitempty" validate:"omitempty,url"`     // e.g. a regional endpoint
+	RefreshInterval *fields.Duration `yaml:"refresh_interval" swaggertype:"primitive,string"` // how often secrets are fetched again to pick up rotated ones, zero disables it
+}
+
+func DefaultGCPConfig() *GCPConfig {
+	refreshInterval := time.Hour
+
+	return &GCPConfig{
+		Endpoint:        "https://secretmanager.googleapis.com",
+		RefreshInterval: (*fields.Duration)(&refreshInterval),
+	}
+}
+
+func (c *GCPConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultGCPConfig()
+
+	type plain GCPConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// GCPBackend reads secret versions via the Secret Manager API. Secrets are referenced by their resource names
+// (e.g. gcp-sm:projects/my-project/secrets/openai/versions/3) or their short names in the configured project (e.g. gcp-sm:openai).
+// The latest version is read unless the version is specified
+type GCPBackend struct {
+	cfg         *GCPConfig
+	credentials *gcpCredentials
+	httpClient  *http.Client
+}
+
+func NewGCPBackend(cfg *GCPConfig) *GCPBackend {
+	httpClient := &http.Client{}
+
+	return &GCPBackend{
+		cfg:         cfg,
+		credentials: newGCPCredentials(cfg.CredentialsFile, httpClient),
+		httpClient:  httpClient,
+	}
+}
+
+func (b *GCPBackend) Scheme() string {
+	return GCPScheme
+}
+
+type accessSecretVersionResponse struct {
+	Payload struct {
+		Data string `json:"data"` // base64 encoded
+	} `json:"payload"`
+}
+
+type gcpErrorResponse struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (b *GCPBackend) Fetch(ctx context.Context, path string) (*Secret, error) {
+	name, err := b.versionName(path)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := b.credentials.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GCP access token: %w", err)
+	}
+
+	url := strings.TrimRight(b.cfg.Endpoint, "/") + "/v1/" + name + ":access"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp gcpErrorResponse
+
+		_ = json.Unmarshal(body, &errResp)
+
+		return nil, fmt.Errorf("secret manager responded with status %v: %v %v", resp.StatusCode, errResp.Error.Status, errResp.Error.Message)
+	}
+
+	var secretVersion accessSecretVersionResponse
+
+	if err := json.Unmarshal(body, &secretVersion); err != nil {
+		return nil, fmt.Errorf("failed to parse secret manager response: %w", err)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(secretVersion.Payload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the secret payload: %w", err)
+	}
+
+	return plainSecret(string(value), renewAfter(b.cfg.RefreshInterval))
+}
+
+// versionName turns the referenced secret into the resource name of its version
+func (b *GCPBackend) versionName(path string) (string, error) {
+	name := strings.Trim(path, "/")
+
+	if !strings.HasPrefix(name, "projects/") {
+		if b.cfg.Project == "" {
+			return "", fmt.Errorf("secret %v is referenced by its short name, but the GCP project is not configured", path)
+		}
+
+		name = fmt.Sprintf("projects/%v/secrets/%v", b.cfg.Project, name)
+	}
+
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	return name, nil
+}
@@ -0,0 +1,139 @@
+package secrets
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSecretManagerMock(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/v1/projects/glide-prod/secrets/openai/versions/latest:access":
+			payload := base64.StdEncoding.EncodeToString([]byte("sk-openai"))
+			_, _ = w.Write([]byte(`{"name": "projects/glide-prod/secrets/openai/versions/2", "payload": {"data": "` + payload + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "status": "NOT_FOUND", "message": "Secret not found"}}`))
+		}
+	}))
+}
+
+func TestGCPBackend_FetchWithServiceAccount(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tokenRequests := 0
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		require.Len(t, parts, 3)
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		require.NoError(t, rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, digest[:], signature))
+
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		require.Contains(t, string(claims), `"iss":"glide@glide-prod.iam.gserviceaccount.com"`)
+
+		_, _ = w.Write([]byte(`{"access_token": "sa-token", "expires_in": 3600, "token_type": "Bearer"}`))
+	}))
+
+	defer tokenServer.Close()
+
+	secretServer := newSecretManagerMock(t, "sa-token")
+	defer secretServer.Close()
+
+	keyFile, err := json.Marshal(gcpCredentialsFile{
+		Type:        "service_account",
+		ClientEmail: "glide@glide-prod.iam.gserviceaccount.com",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+		})),
+		TokenURI: tokenServer.URL,
+	})
+	require.NoError(t, err)
+
+	credentialsFile := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(credentialsFile, keyFile, 0o600))
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsFile)
+
+	cfg := DefaultGCPConfig()
+	cfg.Project = "glide-prod"
+	cfg.Endpoint = secretServer.URL
+
+	backend := NewGCPBackend(cfg)
+	ctx := context.Background()
+
+	secret, err := backend.Fetch(ctx, "openai")
+	require.NoError(t, err)
+	require.Equal(t, "sk-openai", secret.Data[""])
+	require.Equal(t, time.Hour, secret.RenewAfter)
+
+	// the access token is reused
+	_, err = backend.Fetch(ctx, "projects/glide-prod/secrets/openai")
+	require.NoError(t, err)
+	require.Equal(t, 1, tokenRequests)
+
+	_, err = backend.Fetch(ctx, "projects/glide-prod/secrets/anthropic/versions/1")
+	require.ErrorContains(t, err, "NOT_FOUND")
+}
+
+func TestGCPBackend_FetchWithMetadataServer(t *testing.T) {
+	metadataServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		require.Equal(t, "/computeMetadata/v1/instance/service-accounts/default/token", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"access_token": "metadata-token", "expires_in": 3599, "token_type": "Bearer"}`))
+	}))
+
+	defer metadataServer.Close()
+
+	secretServer := newSecretManagerMock(t, "metadata-token")
+	defer secretServer.Close()
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("CLOUDSDK_CONFIG", t.TempDir())
+	t.Setenv("GCE_METADATA_HOST", strings.TrimPrefix(metadataServer.URL, "http://"))
+
+	cfg := DefaultGCPConfig()
+	cfg.Endpoint = secretServer.URL
+
+	backend := NewGCPBackend(cfg)
+
+	secret, err := backend.Fetch(context.Background(), "projects/glide-prod/secrets/openai/versions/latest")
+	require.NoError(t, err)
+	require.Equal(t, "sk-openai", secret.Data[""])
+
+	_, err = backend.Fetch(context.Background(), "openai")
+	require.ErrorContains(t, err, "project is not configured")
+}
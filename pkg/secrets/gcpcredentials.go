@@ -0,0 +1,264 @@
+package secrets
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gcpScope         = "https://www.googleapis.com/auth/cloud-platform"
+	gcpTokenURL      = "https://oauth2.googleapis.com/token"
+	gcpMetadataHost  = "metadata.google.internal"
+	gcpTokenLifetime = time.Hour
+	// gcpTokenExpiryDelta is how long before the expiry the access token is refreshed
+	gcpTokenExpiryDelta = time.Minute
+)
+
+// gcpCredentials gets access tokens the way Application Default Credentials do:
+// the credentials file (GOOGLE_APPLICATION_CREDENTIALS or the one created by gcloud) or the metadata server on GCP
+type gcpCredentials struct {
+	file       string
+	tokenURL   string // where authorized user credentials are exchanged for access tokens
+	httpClient *http.Client
+	mu         sync.Mutex
+	token      string
+	expiresAt  time.Time
+}
+
+func newGCPCredentials(file string, httpClient *http.Client) *gcpCredentials {
+	return &gcpCredentials{
+		file:       file,
+		tokenURL:   gcpTokenURL,
+		httpClient: httpClient,
+	}
+}
+
+type gcpCredentialsFile struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type gcpTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"` // in seconds
+}
+
+// Token returns the cached access token until it's about to expire
+func (c *gcpCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expiresAt) > gcpTokenExpiryDelta {
+		return c.token, nil
+	}
+
+	token, err := c.fetchToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = token.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return c.token, nil
+}
+
+func (c *gcpCredentials) fetchToken(ctx context.Context) (*gcpTokenResponse, error) {
+	file := c.credentialsFile()
+	if file == "" {
+		return c.metadataToken(ctx)
+	}
+
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCP credentials file: %w", err)
+	}
+
+	var credentials gcpCredentialsFile
+
+	if err := json.Unmarshal(content, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to parse GCP credentials file %v: %w", file, err)
+	}
+
+	switch credentials.Type {
+	case "service_account":
+		return c.serviceAccountToken(ctx, &credentials)
+	case "authorized_user":
+		return c.requestToken(ctx, c.tokenURL, url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {credentials.ClientID},
+			"client_secret": {credentials.ClientSecret},
+			"refresh_token": {credentials.RefreshToken},
+		})
+	default:
+		return nil, fmt.Errorf("unsupported GCP credentials type %q in %v", credentials.Type, file)
+	}
+}
+
+// credentialsFile finds the credentials file in the order Application Default Credentials do
+func (c *gcpCredentials) credentialsFile() string {
+	if c.file != "" {
+		return c.file
+	}
+
+	if file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); file != "" {
+		return file
+	}
+
+	configDir := os.Getenv("CLOUDSDK_CONFIG")
+
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+
+		configDir = filepath.Join(homeDir, ".config", "gcloud")
+	}
+
+	file := filepath.Join(configDir, "application_default_credentials.json")
+
+	if _, err := os.Stat(file); err != nil {
+		return ""
+	}
+
+	return file
+}
+
+// serviceAccountToken exchanges the JWT signed by the service account key for the access token
+func (c *gcpCredentials) serviceAccountToken(ctx context.Context, credentials *gcpCredentialsFile) (*gcpTokenResponse, error) {
+	block, _ := pem.Decode([]byte(credentials.PrivateKey))
+	if block == nil {
+		return nil, errors.New("failed to decode the service account private key")
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsedKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the service account private key: %w", err)
+		}
+	}
+
+	privateKey, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("service account private key is not an RSA key")
+	}
+
+	tokenURI := credentials.TokenURI
+	if tokenURI == "" {
+		tokenURI = gcpTokenURL
+	}
+
+	issuedAt := time.Now()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": credentials.PrivateKeyID})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss":   credentials.ClientEmail,
+		"scope": gcpScope,
+		"aud":   tokenURI,
+		"iat":   issuedAt.Unix(),
+		"exp":   issuedAt.Add(gcpTokenLifetime).Unix(),
+	})
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return c.requestToken(ctx, tokenURI, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)},
+	})
+}
+
+func (c *gcpCredentials) requestToken(ctx context.Context, tokenURL string, form url.Values) (*gcpTokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.doTokenRequest(req)
+}
+
+// metadataToken gets the access token of the attached service account on GCP (e.g. GCE, GKE, Cloud Run)
+func (c *gcpCredentials) metadataToken(ctx context.Context) (*gcpTokenResponse, error) {
+	host := os.Getenv("GCE_METADATA_HOST")
+	if host == "" {
+		host = gcpMetadataHost
+	}
+
+	tokenURL := fmt.Sprintf("http://%v/computeMetadata/v1/instance/service-accounts/default/token?scopes=%v", host, url.QueryEscape(gcpScope))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	token, err := c.doTokenRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("no GCP credentials file is found and the metadata server is not available: %w", err)
+	}
+
+	return token, nil
+}
+
+func (c *gcpCredentials) doTokenRequest(req *http.Request) (*gcpTokenResponse, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed with status %v: %s", resp.StatusCode, body)
+	}
+
+	var token gcpTokenResponse
+
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse the token response: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,247 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"glide/pkg/config/fields"
+)
+
+var (
+	ErrBackendNotConfigured = errors.New("secret backend is not configured")
+	ErrSecretKeyNotFound    = errors.New("secret key is not found")
+)
+
+// Config defines backends secrets referenced in the config (e.g. vault:secret/data/openai#api_key) are fetched from
+type Config struct {
+	Timeout time.Duration `yaml:"timeout" validate:"required,gt=0"`
+	Vault   *VaultConfig  `yaml:"vault,omitempty"`
+	AWS     *AWSConfig    `yaml:"aws,omitempty"` // AWS Secrets Manager
+	GCP     *GCPConfig    `yaml:"gcp,omitempty"` // GCP Secret Manager
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Ref points to the secret key in the backend, e.g. vault:secret/data/openai#api_key.
+// The key is optional for backends storing plain values, e.g. aws-sm:openai-api-key
+type Ref struct {
+	Scheme string
+	Path   string
+	Key    string
+}
+
+func (r Ref) String() string {
+	if r.Key == "" {
+		return fmt.Sprintf("%v:%v", r.Scheme, r.Path)
+	}
+
+	return fmt.Sprintf("%v:%v#%v", r.Scheme, r.Path, r.Key)
+}
+
+// ParseRef checks if the config value is a secret reference
+func ParseRef(value string) (Ref, bool) {
+	scheme, location, found := strings.Cut(value, ":")
+	if !found || !slices.Contains(knownSchemes, scheme) {
+		return Ref{}, false
+	}
+
+	path, key, _ := strings.Cut(location, "#")
+	if path == "" {
+		return Ref{}, false
+	}
+
+	return Ref{Scheme: scheme, Path: path, Key: key}, true
+}
+
+var knownSchemes = []string{VaultScheme, AWSScheme, GCPScheme}
+
+// Secret is the key-value data stored under the backend path.
+// Backends storing plain values keep the whole value under the empty key (and its fields if it's a JSON object)
+type Secret struct {
+	Data       map[string]string
+	RenewAfter time.Duration // when the secret should be fetched again (e.g. before its lease expires), zero if never
+}
+
+// Backend fetches secrets from the secret store
+type Backend interface {
+	Scheme() string
+	Fetch(ctx context.Context, path string) (*Secret, error)
+}
+
+// Resolver replaces secret references with secrets. Secrets under the same path are fetched once
+type Resolver struct {
+	backends map[string]Backend
+	timeout  time.Duration
+	secrets  map[string]*Secret
+	renewAt  time.Time
+}
+
+func NewResolver(cfg *Config) (*Resolver, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	backends := make([]Backend, 0, 3)
+
+	if cfg.Vault != nil {
+		vault, err := NewVaultBackend(cfg.Vault)
+		if err != nil {
+			return nil, err
+		}
+
+		backends = append(backends, vault)
+	}
+
+	if cfg.AWS != nil {
+		aws, err := NewAWSBackend(cfg.AWS)
+		if err != nil {
+			return nil, err
+		}
+
+		backends = append(backends, aws)
+	}
+
+	if cfg.GCP != nil {
+		backends = append(backends, NewGCPBackend(cfg.GCP))
+	}
+
+	return newResolver(cfg.Timeout, backends...), nil
+}
+
+func newResolver(timeout time.Duration, backends ...Backend) *Resolver {
+	resolver := &Resolver{
+		backends: make(map[string]Backend, len(backends)),
+		timeout:  timeout,
+		secrets:  make(map[string]*Secret),
+	}
+
+	for _, backend := range backends {
+		resolver.backends[backend.Scheme()] = backend
+	}
+
+	return resolver
+}
+
+// Resolve returns the secret the value refers to or the value itself if it's not a secret reference
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	ref, isRef := ParseRef(value)
+	if !isRef {
+		return value, nil
+	}
+
+	secret, err := r.fetch(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	secretValue, found := secret.Data[ref.Key]
+	if !found {
+		return "", fmt.Errorf("%w: %v", ErrSecretKeyNotFound, ref)
+	}
+
+	return secretValue, nil
+}
+
+// RenewAt returns when resolved secrets should be fetched again before the earliest lease expires.
+// Zero time means no secret expires
+func (r *Resolver) RenewAt() time.Time {
+	return r.renewAt
+}
+
+func (r *Resolver) fetch(ctx context.Context, ref Ref) (*Secret, error) {
+	cacheKey := ref.Scheme + ":" + ref.Path
+
+	if secret, found := r.secrets[cacheKey]; found {
+		return secret, nil
+	}
+
+	backend, found := r.backends[ref.Scheme]
+	if !found {
+		return nil, fmt.Errorf("%w: %v (referenced by %v)", ErrBackendNotConfigured, ref.Scheme, ref)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	secret, err := backend.Fetch(ctx, ref.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %v: %w", ref, err)
+	}
+
+	r.secrets[cacheKey] = secret
+
+	if secret.RenewAfter > 0 {
+		renewAt := time.Now().Add(secret.RenewAfter)
+
+		if r.renewAt.IsZero() || renewAt.Before(r.renewAt) {
+			r.renewAt = renewAt
+		}
+	}
+
+	return secret, nil
+}
+
+// plainSecret keeps the plain value under the empty key. Fields of JSON objects could be referenced by their keys too
+// renewAfter tells when secrets should be fetched again given the refresh interval, zero if never
+func renewAfter(refreshInterval *fields.Duration) time.Duration {
+	if refreshInterval == nil {
+		return 0
+	}
+
+	return time.Duration(*refreshInterval)
+}
+
+func plainSecret(value string, renewAfter time.Duration) (*Secret, error) {
+	secret := &Secret{
+		Data:       map[string]string{"": value},
+		RenewAfter: renewAfter,
+	}
+
+	var fields map[string]interface{}
+
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return secret, nil //nolint:nilerr // not a JSON object, so only the whole value is referenced
+	}
+
+	for key, field := range fields {
+		str, err := stringify(field)
+		if err != nil {
+			return nil, err
+		}
+
+		secret.Data[key] = str
+	}
+
+	return secret, nil
+}
+
+// stringify keeps strings as they are & encodes other values as JSON
+func stringify(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
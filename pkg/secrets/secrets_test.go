@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type backendMock struct {
+	secrets map[string]*Secret
+	fetches int
+}
+
+func (b *backendMock) Scheme() string {
+	return VaultScheme
+}
+
+func (b *backendMock) Fetch(_ context.Context, path string) (*Secret, error) {
+	b.fetches++
+
+	return b.secrets[path], nil
+}
+
+func TestParseRef(t *testing.T) {
+	ref, isRef := ParseRef("vault:secret/data/openai#api_key")
+	require.True(t, isRef)
+	require.Equal(t, Ref{Scheme: VaultScheme, Path: "secret/data/openai", Key: "api_key"}, ref)
+	require.Equal(t, "vault:secret/data/openai#api_key", ref.String())
+
+	ref, isRef = ParseRef("aws-sm:arn:aws:secretsmanager:us-east-1:123456789012:secret:openai-AbCdEf")
+	require.True(t, isRef)
+	require.Equal(t, Ref{Scheme: AWSScheme, Path: "arn:aws:secretsmanager:us-east-1:123456789012:secret:openai-AbCdEf"}, ref)
+
+	for _, value := range []string{"sk-openai", "vault:", "vault:#api_key", "https://api.openai.com/v1#top"} {
+		_, isRef = ParseRef(value)
+		require.False(t, isRef, value)
+	}
+}
+
+func TestResolver_FetchesPathOnce(t *testing.T) {
+	backend := &backendMock{secrets: map[string]*Secret{
+		"secret/data/llm": {Data: map[string]string{"openai": "sk-openai", "cohere": "co-key"}},
+		"database/creds":  {Data: map[string]string{"password": "pass"}, RenewAfter: 2 * time.Hour},
+		"aws/creds":       {Data: map[string]string{"secret_key": "key"}, RenewAfter: 20 * time.Minute},
+	}}
+
+	resolver := newResolver(time.Second, backend)
+	ctx := context.Background()
+
+	value, err := resolver.Resolve(ctx, "plain value")
+	require.NoError(t, err)
+	require.Equal(t, "plain value", value)
+	require.True(t, resolver.RenewAt().IsZero())
+
+	value, err = resolver.Resolve(ctx, "vault:secret/data/llm#openai")
+	require.NoError(t, err)
+	require.Equal(t, "sk-openai", value)
+
+	value, err = resolver.Resolve(ctx, "vault:secret/data/llm#cohere")
+	require.NoError(t, err)
+	require.Equal(t, "co-key", value)
+	require.Equal(t, 1, backend.fetches)
+	require.True(t, resolver.RenewAt().IsZero())
+
+	_, err = resolver.Resolve(ctx, "vault:secret/data/llm#anthropic")
+	require.ErrorIs(t, err, ErrSecretKeyNotFound)
+
+	// renewed before the earliest lease expires
+	_, err = resolver.Resolve(ctx, "vault:database/creds#password")
+	require.NoError(t, err)
+
+	_, err = resolver.Resolve(ctx, "vault:aws/creds#secret_key")
+	require.NoError(t, err)
+
+	require.WithinDuration(t, time.Now().Add(20*time.Minute), resolver.RenewAt(), time.Second)
+}
+
+func TestResolver_BackendNotConfigured(t *testing.T) {
+	resolver, err := NewResolver(nil)
+	require.NoError(t, err)
+
+	_, err = resolver.Resolve(context.Background(), "vault:secret/data/openai#api_key")
+	require.ErrorIs(t, err, ErrBackendNotConfigured)
+}
@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"glide/pkg/config/fields"
+)
+
+const VaultScheme = "vault"
+
+// vaultRenewalShare is how much of the lease passes before the secret is fetched again,
+// so the new value is in place before the old one expires
+const vaultRenewalShare = 2.0 / 3.0
+
+// VaultConfig defines how HashiCorp Vault is accessed. The address & the token default to VAULT_ADDR & VAULT_TOKEN env vars
+type VaultConfig struct {
+	Address   string        `yaml:"address" validate:"required,url"`
+	Token     fields.Secret `yaml:"token" validate:"required"`
+	Namespace string        `yaml:"namespace,omitempty"` // Vault Enterprise namespace
+}
+
+func DefaultVaultConfig() *VaultConfig {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		address = "http://127.0.0.1:8200"
+	}
+
+	return &VaultConfig{
+		Address: address,
+		Token:   fields.Secret(os.Getenv("VAULT_TOKEN")),
+	}
+}
+
+func (c *VaultConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultVaultConfig()
+
+	type plain VaultConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// VaultBackend reads secrets via the Vault HTTP API. Both KV engines & dynamic secret engines are supported
+type VaultBackend struct {
+	cfg        *VaultConfig
+	httpClient *http.Client
+}
+
+func NewVaultBackend(cfg *VaultConfig) (*VaultBackend, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("vault token is required, set it in the config or via VAULT_TOKEN env var")
+	}
+
+	return &VaultBackend{
+		cfg:        cfg,
+		httpClient: &http.Client{},
+	}, nil
+}
+
+func (b *VaultBackend) Scheme() string {
+	return VaultScheme
+}
+
+type vaultResponse struct {
+	LeaseDuration int                    `json:"lease_duration"` // in seconds
+	Data          map[string]interface{} `json:"data"`
+	Errors        []string               `json:"errors"`
+}
+
+func (b *VaultBackend) Fetch(ctx context.Context, path string) (*Secret, error) {
+	url := strings.TrimRight(b.cfg.Address, "/") + "/v1/" + strings.TrimLeft(path, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", string(b.cfg.Token))
+
+	if b.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", b.cfg.Namespace)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var vaultResp vaultResponse
+
+	if err := json.Unmarshal(body, &vaultResp); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("failed to parse vault response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault responded with status %v: %v", resp.StatusCode, strings.Join(vaultResp.Errors, "; "))
+	}
+
+	data := vaultResp.Data
+
+	// KV v2 engine nests the secret data next to its metadata
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, versioned := data["metadata"]; versioned {
+			data = nested
+		}
+	}
+
+	lease := time.Duration(vaultResp.LeaseDuration) * time.Second
+
+	secret := &Secret{
+		Data:       make(map[string]string, len(data)),
+		RenewAfter: time.Duration(float64(lease) * vaultRenewalShare),
+	}
+
+	for key, value := range data {
+		if secret.Data[key], err = stringify(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultBackend_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+		require.Equal(t, "team-a", r.Header.Get("X-Vault-Namespace"))
+
+		switch r.URL.Path {
+		case "/v1/secret/data/openai":
+			_, _ = w.Write([]byte(`{"lease_duration": 0, "data": {"data": {"api_key": "sk-openai", "org": 42}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/openai":
+			_, _ = w.Write([]byte(`{"lease_duration": 2764800, "data": {"api_key": "sk-kv1"}}`))
+		case "/v1/database/creds/glide":
+			_, _ = w.Write([]byte(`{"lease_duration": 3600, "renewable": true, "data": {"username": "glide", "password": "pass"}}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+		}
+	}))
+
+	defer server.Close()
+
+	backend, err := NewVaultBackend(&VaultConfig{Address: server.URL + "/", Token: "vault-token", Namespace: "team-a"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	secret, err := backend.Fetch(ctx, "secret/data/openai")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"api_key": "sk-openai", "org": "42"}, secret.Data)
+	require.Zero(t, secret.RenewAfter)
+
+	secret, err = backend.Fetch(ctx, "kv/openai")
+	require.NoError(t, err)
+	require.Equal(t, "sk-kv1", secret.Data["api_key"])
+
+	secret, err = backend.Fetch(ctx, "/database/creds/glide")
+	require.NoError(t, err)
+	require.Equal(t, "pass", secret.Data["password"])
+	require.Equal(t, 40*time.Minute, secret.RenewAfter)
+
+	_, err = backend.Fetch(ctx, "secret/data/anthropic")
+	require.ErrorContains(t, err, "permission denied")
+}
+
+func TestNewVaultBackend_RequiresToken(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "https://vault.example.com")
+	t.Setenv("VAULT_TOKEN", "")
+
+	cfg := DefaultVaultConfig()
+	require.Equal(t, "https://vault.example.com", cfg.Address)
+
+	_, err := NewVaultBackend(cfg)
+	require.Error(t, err)
+}
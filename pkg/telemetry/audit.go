@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// auditLogSize is how many recent audit entries are kept in memory
+const auditLogSize = 500
+
+// SystemActor is the actor of changes the gateway makes on its own (e.g. model health transitions)
+const SystemActor = "system"
+
+// AuditEntry records who has done what & when (e.g. an admin has replaced a router or a model has become unhealthy)
+type AuditEntry struct {
+	Time    time.Time              `json:"time"`
+	Actor   string                 `json:"actor"`            // the API key hash, the client IP or "system"
+	Action  string                 `json:"action"`           // e.g. "config.reloaded" or "model.unhealthy"
+	Target  string                 `json:"target,omitempty"` // e.g. the router or the model ID
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// AuditSink stores audit entries outside of the gateway (e.g. in a file or an external service)
+type AuditSink interface {
+	Write(entry *AuditEntry) error
+}
+
+// AuditLog keeps the most recent audit entries in memory and appends all of them to the configured sinks
+type AuditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+	next    int
+	full    bool
+	sinks   []AuditSink
+	logger  *zap.Logger
+}
+
+func NewAuditLog(size int, logger *zap.Logger) *AuditLog {
+	return &AuditLog{
+		entries: make([]AuditEntry, size),
+		logger:  logger,
+	}
+}
+
+// AddSink makes the log append entries to the sink
+func (l *AuditLog) AddSink(sink AuditSink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sinks = append(l.sinks, sink)
+}
+
+// Record appends the entry to the log. Sink failures are logged, so they never fail the audited action
+func (l *AuditLog) Record(actor string, action string, target string, details map[string]interface{}) {
+	entry := AuditEntry{
+		Time:    time.Now().UTC(),
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		Details: details,
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.entries) > 0 {
+		l.entries[l.next] = entry
+		l.next = (l.next + 1) % len(l.entries)
+
+		if l.next == 0 {
+			l.full = true
+		}
+	}
+
+	for _, sink := range l.sinks {
+		if err := sink.Write(&entry); err != nil {
+			l.logger.Error("failed to write audit entry", zap.String("action", action), zap.Error(err))
+		}
+	}
+}
+
+// Entries returns the recent entries, the newest first
+func (l *AuditLog) Entries() []AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+
+	if l.full {
+		count = len(l.entries)
+	}
+
+	entries := make([]AuditEntry, 0, count)
+
+	for i := 1; i <= count; i++ {
+		entries = append(entries, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+
+	return entries
+}
+
+// AuditFile appends audit entries to a JSON Lines file. The file is only ever appended to
+type AuditFile struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewAuditFile(path string) *AuditFile {
+	return &AuditFile{
+		path: path,
+	}
+}
+
+func (f *AuditFile) Write(entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(filepath.Clean(f.path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
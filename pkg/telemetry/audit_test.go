@@ -0,0 +1,53 @@
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog_KeepsRecentEntries(t *testing.T) {
+	auditLog := NewAuditLog(2, NewLoggerMock())
+
+	auditLog.Record(SystemActor, "config.reloaded", "config.yaml", nil)
+	auditLog.Record("key:abc", "admin.put", "/v1/admin/language/default/", nil)
+	auditLog.Record(SystemActor, "model.unhealthy", "openai", map[string]interface{}{"reason": "unauthorized"})
+
+	entries := auditLog.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "model.unhealthy", entries[0].Action)
+	require.Equal(t, "unauthorized", entries[0].Details["reason"])
+	require.Equal(t, "key:abc", entries[1].Actor)
+}
+
+func TestAuditFile_AppendsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	auditLog := NewAuditLog(10, NewLoggerMock())
+	auditLog.AddSink(NewAuditFile(path))
+
+	auditLog.Record(SystemActor, "config.reloaded", "config.yaml", map[string]interface{}{"trigger": "signal"})
+	auditLog.Record("key:abc", "admin.delete", "/v1/admin/cache/", nil)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	actions := make([]string, 0, 2)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		var entry AuditEntry
+
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+
+		actions = append(actions, entry.Action)
+	}
+
+	require.Equal(t, []string{"config.reloaded", "admin.delete"}, actions)
+}
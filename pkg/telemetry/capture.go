@@ -0,0 +1,222 @@
+package telemetry
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CaptureConfig defines how raw provider traffic is captured to debug mapping issues (e.g. truncated token counts).
+// Capturing is off until a sample rate or request IDs are set in the config or via the admin API
+type CaptureConfig struct {
+	SampleRate  float64          `yaml:"sample_rate" validate:"gte=0,lte=1"`     // share of provider calls captured
+	RequestIDs  []string         `yaml:"request_ids"`                            // provider calls made to serve these requests are always captured
+	Size        int              `yaml:"size" validate:"required,gt=0"`          // how many recent captures are kept in memory
+	MaxBodySize int              `yaml:"max_body_size" validate:"required,gt=0"` // request & response bodies are truncated to this size (in bytes)
+	Redaction   *RedactionConfig `yaml:"redaction" validate:"required"`          // captures are always sanitized
+}
+
+func DefaultCaptureConfig() *CaptureConfig {
+	return &CaptureConfig{
+		Size:        100,
+		MaxBodySize: 64 * 1024,
+		Redaction:   DefaultRedactionConfig(),
+	}
+}
+
+func (c *CaptureConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultCaptureConfig()
+
+	type plain CaptureConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Capture is the sanitized provider HTTP request with its response
+type Capture struct {
+	Time              time.Time         `json:"time"`
+	RequestID         string            `json:"requestId,omitempty"`
+	Provider          string            `json:"provider"`
+	Method            string            `json:"method"`
+	URL               string            `json:"url"`
+	RequestHeaders    map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody       string            `json:"requestBody,omitempty"`
+	StatusCode        int               `json:"statusCode,omitempty"`
+	ResponseHeaders   map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody      string            `json:"responseBody,omitempty"`
+	DurationMs        int64             `json:"durationMs"`
+	Error             string            `json:"error,omitempty"`
+	RequestTruncated  bool              `json:"requestTruncated,omitempty"`
+	ResponseTruncated bool              `json:"responseTruncated,omitempty"`
+}
+
+// CaptureFilter selects captures. Empty fields match everything
+type CaptureFilter struct {
+	RequestID string
+	Provider  string
+}
+
+// CaptureTargets defines which provider calls are captured
+type CaptureTargets struct {
+	SampleRate float64  `json:"sampleRate"`
+	RequestIDs []string `json:"requestIds"`
+}
+
+type requestIDKey struct{}
+
+// WithRequestID passes the ID of the incoming request, so provider calls made to serve it could be captured
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+
+	return requestID
+}
+
+// CaptureLog keeps the most recent captures of raw provider traffic in memory
+type CaptureLog struct {
+	mu          sync.Mutex
+	targets     CaptureTargets
+	maxBodySize int
+	redactor    *Redactor
+	captures    []Capture
+	next        int
+	full        bool
+}
+
+func NewCaptureLog(cfg *CaptureConfig) (*CaptureLog, error) {
+	redactor, err := NewRedactor(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CaptureLog{
+		targets: CaptureTargets{
+			SampleRate: cfg.SampleRate,
+			RequestIDs: slices.Clone(cfg.RequestIDs),
+		},
+		maxBodySize: cfg.MaxBodySize,
+		redactor:    redactor,
+		captures:    make([]Capture, cfg.Size),
+	}, nil
+}
+
+// MaxBodySize is how many bytes of request & response bodies are kept
+func (l *CaptureLog) MaxBodySize() int {
+	return l.maxBodySize
+}
+
+func (l *CaptureLog) Targets() CaptureTargets {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return CaptureTargets{
+		SampleRate: l.targets.SampleRate,
+		RequestIDs: slices.Clone(l.targets.RequestIDs),
+	}
+}
+
+// SetTargets changes which provider calls are captured until the restart
+func (l *CaptureLog) SetTargets(targets CaptureTargets) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.targets = CaptureTargets{
+		SampleRate: targets.SampleRate,
+		RequestIDs: slices.Clone(targets.RequestIDs),
+	}
+}
+
+// Wanted checks if the provider call made in the context should be captured
+func (l *CaptureLog) Wanted(ctx context.Context) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if requestID := RequestID(ctx); requestID != "" && slices.Contains(l.targets.RequestIDs, requestID) {
+		return true
+	}
+
+	return l.targets.SampleRate > 0 && rand.Float64() < l.targets.SampleRate //nolint:gosec
+}
+
+// Record sanitizes the capture & keeps it
+func (l *CaptureLog) Record(capture *Capture, requestHeaders http.Header, responseHeaders http.Header) {
+	sanitized := *capture
+
+	sanitized.RequestHeaders = l.headers(requestHeaders)
+	sanitized.ResponseHeaders = l.headers(responseHeaders)
+	sanitized.URL = l.redactor.Scrub(capture.URL)
+	sanitized.RequestBody = l.redactor.Text(capture.RequestBody)
+	sanitized.ResponseBody = l.redactor.Text(capture.ResponseBody)
+	sanitized.Error = l.redactor.Scrub(capture.Error)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.captures) == 0 {
+		return
+	}
+
+	l.captures[l.next] = sanitized
+	l.next = (l.next + 1) % len(l.captures)
+
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Captures returns the recent captures matching the filter, the newest first
+func (l *CaptureLog) Captures(filter CaptureFilter) []Capture {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+
+	if l.full {
+		count = len(l.captures)
+	}
+
+	captures := make([]Capture, 0, count)
+
+	for i := 1; i <= count; i++ {
+		capture := l.captures[(l.next-i+len(l.captures))%len(l.captures)]
+
+		if filter.RequestID != "" && capture.RequestID != filter.RequestID {
+			continue
+		}
+
+		if filter.Provider != "" && capture.Provider != filter.Provider {
+			continue
+		}
+
+		captures = append(captures, capture)
+	}
+
+	return captures
+}
+
+// headers drops values of deny-listed headers (e.g. Authorization) & scrubs the rest
+func (l *CaptureLog) headers(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(header))
+
+	for name := range header {
+		if l.redactor.denied(name) {
+			headers[name] = redactedValue
+
+			continue
+		}
+
+		headers[name] = l.redactor.Scrub(header.Get(name))
+	}
+
+	return headers
+}
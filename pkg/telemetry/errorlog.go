@@ -0,0 +1,120 @@
+package telemetry
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LogEntry is a warning or an error logged by the gateway
+type LogEntry struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// ErrorLog keeps the most recent warnings & errors in memory, so they could be inspected without access to the log storage
+type ErrorLog struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int
+	full    bool
+}
+
+func NewErrorLog(size int) *ErrorLog {
+	return &ErrorLog{
+		entries: make([]LogEntry, size),
+	}
+}
+
+// Entries returns the recent entries, the newest first
+func (l *ErrorLog) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+
+	if l.full {
+		count = len(l.entries)
+	}
+
+	entries := make([]LogEntry, 0, count)
+
+	for i := 1; i <= count; i++ {
+		entries = append(entries, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+
+	return entries
+}
+
+func (l *ErrorLog) add(entry LogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.entries) == 0 {
+		return
+	}
+
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Core returns a zap core that records warnings & errors into the log
+func (l *ErrorLog) Core() zapcore.Core {
+	return &errorLogCore{log: l}
+}
+
+type errorLogCore struct {
+	log    *ErrorLog
+	fields []zapcore.Field
+}
+
+func (c *errorLogCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.WarnLevel
+}
+
+func (c *errorLogCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorLogCore{
+		log:    c.log,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *errorLogCore) Check(entry zapcore.Entry, checkedEntry *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checkedEntry.AddCore(entry, c)
+	}
+
+	return checkedEntry
+}
+
+func (c *errorLogCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	encoder := zapcore.NewMapObjectEncoder()
+
+	for _, field := range c.fields {
+		field.AddTo(encoder)
+	}
+
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	c.log.add(LogEntry{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+		Fields:  encoder.Fields,
+	})
+
+	return nil
+}
+
+func (c *errorLogCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package telemetry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestErrorLog_RecordsWarningsAndErrors(t *testing.T) {
+	errorLog := NewErrorLog(2)
+	logger := zap.New(errorLog.Core()).With(zap.String("routerID", "default"))
+
+	logger.Info("router started")
+	logger.Warn("model failed", zap.Error(errors.New("timeout")))
+
+	entries := errorLog.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "warn", entries[0].Level)
+	require.Equal(t, "model failed", entries[0].Message)
+	require.Equal(t, "default", entries[0].Fields["routerID"])
+	require.Equal(t, "timeout", entries[0].Fields["error"])
+}
+
+func TestErrorLog_KeepsMostRecent(t *testing.T) {
+	errorLog := NewErrorLog(2)
+	logger := zap.New(errorLog.Core())
+
+	logger.Error("first")
+	logger.Error("second")
+	logger.Error("third")
+
+	entries := errorLog.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "third", entries[0].Message)
+	require.Equal(t, "second", entries[1].Message)
+}
@@ -0,0 +1,425 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var ErrNoErrorSinks = errors.New("error reporting is enabled, but neither sentry nor webhook is configured")
+
+// reportTags are log fields that become report tags, so reports could be searched & grouped by them in Sentry
+var reportTags = []string{"requestID", "streamRequestID", "routerID", "modelID", "provider", "method", "path"}
+
+// ErrorReportingConfig defines where unexpected errors & recovered panics (everything logged at the error level or above)
+// are reported to. At least one sink should be configured
+type ErrorReportingConfig struct {
+	SampleRate  float64             `yaml:"sample_rate" validate:"gte=0,lte=1"`   // share of errors reported, panics are always reported
+	BufferSize  int                 `yaml:"buffer_size" validate:"required,gt=0"` // reports are dropped once the buffer is full
+	Timeout     time.Duration       `yaml:"timeout" validate:"required,gt=0"`
+	Environment string              `yaml:"environment,omitempty"` // e.g. "production"
+	Release     string              `yaml:"release,omitempty"`     // e.g. the gateway version
+	Sentry      *SentryConfig       `yaml:"sentry,omitempty"`
+	Webhook     *ErrorWebhookConfig `yaml:"webhook,omitempty"` // a generic sink reports are posted to as JSON
+}
+
+func DefaultErrorReportingConfig() *ErrorReportingConfig {
+	return &ErrorReportingConfig{
+		SampleRate: 1,
+		BufferSize: 100,
+		Timeout:    5 * time.Second,
+	}
+}
+
+func (c *ErrorReportingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultErrorReportingConfig()
+
+	type plain ErrorReportingConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+type SentryConfig struct {
+	DSN string `yaml:"dsn" validate:"required,url"` // e.g. https://<key>@o0.ingest.sentry.io/<project>
+}
+
+type ErrorWebhookConfig struct {
+	URL     string            `yaml:"url" validate:"required,url"`
+	Headers map[string]string `yaml:"headers,omitempty"` // e.g. the authorization header
+}
+
+// ErrorReport is the error logged by the gateway with its context (e.g. the request, the router & the model)
+type ErrorReport struct {
+	ID          string                 `json:"id"`
+	Time        time.Time              `json:"time"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger,omitempty"`
+	Message     string                 `json:"message"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+	Stacktrace  string                 `json:"stacktrace,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+}
+
+// ErrorSink delivers error reports to an external service
+type ErrorSink interface {
+	Name() string
+	Send(ctx context.Context, report *ErrorReport) error
+}
+
+// ErrorReporter delivers error reports to sinks in the background, so logging an error never waits for the network
+type ErrorReporter struct {
+	cfg      *ErrorReportingConfig
+	sinks    []ErrorSink
+	reportC  chan *ErrorReport
+	stopC    chan struct{}
+	doneC    chan struct{}
+	started  bool
+	stopOnce sync.Once
+	logger   *zap.Logger
+}
+
+func NewErrorReporter(cfg *ErrorReportingConfig, logger *zap.Logger) (*ErrorReporter, error) {
+	sinks := make([]ErrorSink, 0, 2)
+
+	if cfg.Sentry != nil {
+		sentry, err := NewSentrySink(cfg.Sentry)
+		if err != nil {
+			return nil, err
+		}
+
+		sinks = append(sinks, sentry)
+	}
+
+	if cfg.Webhook != nil {
+		sinks = append(sinks, NewErrorWebhookSink(cfg.Webhook))
+	}
+
+	if len(sinks) == 0 {
+		return nil, ErrNoErrorSinks
+	}
+
+	return newErrorReporter(cfg, sinks, logger), nil
+}
+
+func newErrorReporter(cfg *ErrorReportingConfig, sinks []ErrorSink, logger *zap.Logger) *ErrorReporter {
+	return &ErrorReporter{
+		cfg:     cfg,
+		sinks:   sinks,
+		reportC: make(chan *ErrorReport, cfg.BufferSize),
+		stopC:   make(chan struct{}),
+		doneC:   make(chan struct{}),
+		logger:  logger,
+	}
+}
+
+func (r *ErrorReporter) Start() {
+	r.started = true
+
+	go r.run()
+}
+
+// Stop delivers the buffered reports & waits until it's done
+func (r *ErrorReporter) Stop() {
+	if r == nil || !r.started {
+		return
+	}
+
+	r.stopOnce.Do(func() {
+		close(r.stopC)
+	})
+
+	<-r.doneC
+}
+
+// Report queues the report for delivery. The report is dropped if the buffer is full
+func (r *ErrorReporter) Report(report *ErrorReport) {
+	report.Environment = r.cfg.Environment
+	report.Release = r.cfg.Release
+
+	select {
+	case r.reportC <- report:
+	default:
+	}
+}
+
+func (r *ErrorReporter) run() {
+	defer close(r.doneC)
+
+	for {
+		select {
+		case report := <-r.reportC:
+			r.send(report)
+		case <-r.stopC:
+			for {
+				select {
+				case report := <-r.reportC:
+					r.send(report)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *ErrorReporter) send(report *ErrorReport) {
+	for _, sink := range r.sinks {
+		ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+		err := sink.Send(ctx, report)
+
+		cancel()
+
+		if err != nil {
+			// logged as a warning, so the failure is not reported again
+			r.logger.Warn("failed to report error", zap.String("sink", sink.Name()), zap.Error(err))
+		}
+	}
+}
+
+// sampled checks if the logged entry should be reported. Panics are too rare & important to be sampled out
+func (r *ErrorReporter) sampled(entry zapcore.Entry) bool {
+	if entry.Level > zapcore.ErrorLevel || strings.Contains(entry.Message, "panic") {
+		return true
+	}
+
+	return r.cfg.SampleRate >= 1 || mathrand.Float64() < r.cfg.SampleRate //nolint:gosec
+}
+
+// Core returns a zap core that reports errors & more severe entries
+func (r *ErrorReporter) Core() zapcore.Core {
+	return &errorReportCore{reporter: r}
+}
+
+type errorReportCore struct {
+	reporter *ErrorReporter
+	fields   []zapcore.Field
+}
+
+func (c *errorReportCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.ErrorLevel
+}
+
+func (c *errorReportCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorReportCore{
+		reporter: c.reporter,
+		fields:   append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *errorReportCore) Check(entry zapcore.Entry, checkedEntry *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) && c.reporter.sampled(entry) {
+		return checkedEntry.AddCore(entry, c)
+	}
+
+	return checkedEntry
+}
+
+func (c *errorReportCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	encoder := zapcore.NewMapObjectEncoder()
+
+	for _, field := range c.fields {
+		field.AddTo(encoder)
+	}
+
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	report := &ErrorReport{
+		ID:      newReportID(),
+		Time:    entry.Time.UTC(),
+		Level:   entry.Level.String(),
+		Logger:  entry.LoggerName,
+		Message: entry.Message,
+		Tags:    make(map[string]string),
+		Fields:  encoder.Fields,
+	}
+
+	for _, tag := range reportTags {
+		if value, ok := encoder.Fields[tag].(string); ok && value != "" {
+			report.Tags[tag] = value
+			delete(report.Fields, tag)
+		}
+	}
+
+	// panics carry their own stack trace, the rest is reported with the one of the logging call
+	if stacktrace, ok := encoder.Fields["stacktrace"]; ok {
+		report.Stacktrace = fmt.Sprint(stacktrace)
+		delete(report.Fields, "stacktrace")
+	} else {
+		report.Stacktrace = entry.Stack
+	}
+
+	c.reporter.Report(report)
+
+	return nil
+}
+
+func (c *errorReportCore) Sync() error {
+	return nil
+}
+
+func newReportID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// SentrySink sends reports as events via the Sentry store API
+type SentrySink struct {
+	storeURL   string
+	auth       string
+	httpClient *http.Client
+}
+
+func NewSentrySink(cfg *SentryConfig) (*SentrySink, error) {
+	dsn, err := url.Parse(cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentry DSN: %w", err)
+	}
+
+	projectPath, projectID := "", strings.Trim(dsn.Path, "/")
+
+	if idx := strings.LastIndex(projectID, "/"); idx >= 0 {
+		projectPath, projectID = "/"+projectID[:idx], projectID[idx+1:]
+	}
+
+	if dsn.User == nil || dsn.User.Username() == "" || projectID == "" {
+		return nil, errors.New("invalid sentry DSN: the public key or the project ID is missing")
+	}
+
+	return &SentrySink{
+		storeURL:   fmt.Sprintf("%v://%v%v/api/%v/store/", dsn.Scheme, dsn.Host, projectPath, projectID),
+		auth:       fmt.Sprintf("Sentry sentry_version=7, sentry_client=glide, sentry_key=%v", dsn.User.Username()),
+		httpClient: &http.Client{},
+	}, nil
+}
+
+func (s *SentrySink) Name() string {
+	return "sentry"
+}
+
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   float64                `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger,omitempty"`
+	Platform    string                 `json:"platform"`
+	Message     map[string]string      `json:"message"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	ServerName  string                 `json:"server_name,omitempty"`
+}
+
+func (s *SentrySink) Send(ctx context.Context, report *ErrorReport) error {
+	extra := make(map[string]interface{}, len(report.Fields)+1)
+
+	for key, value := range report.Fields {
+		extra[key] = value
+	}
+
+	if report.Stacktrace != "" {
+		extra["stacktrace"] = report.Stacktrace
+	}
+
+	level := report.Level
+	if level == zapcore.DPanicLevel.String() || level == zapcore.PanicLevel.String() {
+		level = zapcore.FatalLevel.String()
+	}
+
+	event := sentryEvent{
+		EventID:     report.ID,
+		Timestamp:   float64(report.Time.UnixMicro()) / math.Pow10(6),
+		Level:       level,
+		Logger:      report.Logger,
+		Platform:    "go",
+		Message:     map[string]string{"formatted": report.Message},
+		Tags:        report.Tags,
+		Extra:       extra,
+		Environment: report.Environment,
+		Release:     report.Release,
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return postReport(ctx, s.httpClient, s.storeURL, body, map[string]string{"X-Sentry-Auth": s.auth})
+}
+
+// ErrorWebhookSink posts reports as they are to the configured URL
+type ErrorWebhookSink struct {
+	cfg        *ErrorWebhookConfig
+	httpClient *http.Client
+}
+
+func NewErrorWebhookSink(cfg *ErrorWebhookConfig) *ErrorWebhookSink {
+	return &ErrorWebhookSink{
+		cfg:        cfg,
+		httpClient: &http.Client{},
+	}
+}
+
+func (s *ErrorWebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *ErrorWebhookSink) Send(ctx context.Context, report *ErrorReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	return postReport(ctx, s.httpClient, s.cfg.URL, body, s.cfg.Headers)
+}
+
+func postReport(ctx context.Context, httpClient *http.Client, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	return fmt.Errorf("unexpected response status %v: %s", resp.StatusCode, respBody)
+}
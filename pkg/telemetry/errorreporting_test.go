@@ -0,0 +1,153 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type errorSinkMock struct {
+	mu      sync.Mutex
+	reports []*ErrorReport
+}
+
+func (s *errorSinkMock) Name() string {
+	return "mock"
+}
+
+func (s *errorSinkMock) Send(_ context.Context, report *ErrorReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports = append(s.reports, report)
+
+	return nil
+}
+
+func TestErrorReporter_ReportsErrorsWithRequestContext(t *testing.T) {
+	cfg := DefaultErrorReportingConfig()
+	cfg.Environment = "test"
+
+	sink := &errorSinkMock{}
+	reporter := newErrorReporter(cfg, []ErrorSink{sink}, NewLoggerMock())
+	reporter.Start()
+
+	logger := zap.New(reporter.Core()).With(zap.String("provider", "openai"))
+
+	logger.Warn("retrying the request")
+	logger.Error(
+		"failed to unmarshal chat response",
+		zap.String("requestID", "req-1"),
+		zap.Int("status", 200),
+		zap.Error(errors.New("unexpected EOF")),
+	)
+
+	reporter.Stop()
+
+	require.Len(t, sink.reports, 1)
+
+	report := sink.reports[0]
+
+	require.Len(t, report.ID, 32)
+	require.Equal(t, "error", report.Level)
+	require.Equal(t, "failed to unmarshal chat response", report.Message)
+	require.Equal(t, map[string]string{"provider": "openai", "requestID": "req-1"}, report.Tags)
+	require.Equal(t, map[string]interface{}{"status": int64(200), "error": "unexpected EOF"}, report.Fields)
+	require.Equal(t, "test", report.Environment)
+}
+
+func TestErrorReporter_AlwaysReportsPanics(t *testing.T) {
+	cfg := DefaultErrorReportingConfig()
+	cfg.SampleRate = 0
+
+	sink := &errorSinkMock{}
+	reporter := newErrorReporter(cfg, []ErrorSink{sink}, NewLoggerMock())
+	reporter.Start()
+
+	logger := zap.New(reporter.Core())
+
+	logger.Error("provider is not available")
+	logger.Error("panic recovered", zap.String("stacktrace", "goroutine 1 [running]"))
+
+	reporter.Stop()
+
+	require.Len(t, sink.reports, 1)
+	require.Equal(t, "panic recovered", sink.reports[0].Message)
+	require.Equal(t, "goroutine 1 [running]", sink.reports[0].Stacktrace)
+	require.Empty(t, sink.reports[0].Fields)
+}
+
+func TestNewErrorReporter_RequiresSink(t *testing.T) {
+	_, err := NewErrorReporter(DefaultErrorReportingConfig(), NewLoggerMock())
+	require.ErrorIs(t, err, ErrNoErrorSinks)
+}
+
+func TestSentrySink_SendsEvents(t *testing.T) {
+	var (
+		path, auth string
+		event      map[string]interface{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("X-Sentry-Auth")
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+	}))
+
+	defer server.Close()
+
+	sink, err := NewSentrySink(&SentryConfig{DSN: "http://public-key@" + server.Listener.Addr().String() + "/42"})
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), &ErrorReport{
+		ID:         "0123456789abcdef0123456789abcdef",
+		Level:      "error",
+		Message:    "panic recovered",
+		Tags:       map[string]string{"routerID": "myrouter"},
+		Fields:     map[string]interface{}{"error": "nil map"},
+		Stacktrace: "goroutine 1 [running]",
+		Release:    "0.1.0",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "/api/42/store/", path)
+	require.Contains(t, auth, "sentry_key=public-key")
+	require.Equal(t, "0123456789abcdef0123456789abcdef", event["event_id"])
+	require.Equal(t, map[string]interface{}{"formatted": "panic recovered"}, event["message"])
+	require.Equal(t, map[string]interface{}{"routerID": "myrouter"}, event["tags"])
+	require.Equal(t, map[string]interface{}{"error": "nil map", "stacktrace": "goroutine 1 [running]"}, event["extra"])
+	require.Equal(t, "0.1.0", event["release"])
+}
+
+func TestNewSentrySink_InvalidDSN(t *testing.T) {
+	_, err := NewSentrySink(&SentryConfig{DSN: "https://o0.ingest.sentry.io/42"})
+	require.Error(t, err)
+
+	_, err = NewSentrySink(&SentryConfig{DSN: "https://key@o0.ingest.sentry.io/"})
+	require.Error(t, err)
+}
+
+func TestErrorWebhookSink_FailsOnErrorStatus(t *testing.T) {
+	var token string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	defer server.Close()
+
+	sink := NewErrorWebhookSink(&ErrorWebhookConfig{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+
+	err := sink.Send(context.Background(), &ErrorReport{Message: "failed"})
+	require.ErrorContains(t, err, "502")
+	require.Equal(t, "Bearer token", token)
+}
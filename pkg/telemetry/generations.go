@@ -0,0 +1,74 @@
+package telemetry
+
+import (
+	"sync"
+	"time"
+)
+
+// GenerationMessage is the prompt message of the generation
+type GenerationMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Generation is the chat request served by the router model with its prompt & completion
+type Generation struct {
+	ID              string              `json:"id"`
+	Kind            string              `json:"kind"` // chat or chat_stream
+	RouterID        string              `json:"routerId"`
+	ModelID         string              `json:"modelId"`
+	ModelName       string              `json:"modelName"`
+	Provider        string              `json:"provider"`
+	Caller          string              `json:"caller,omitempty"`
+	SessionID       string              `json:"sessionId,omitempty"`
+	Input           []GenerationMessage `json:"input"`
+	Output          string              `json:"output"`
+	StartTime       time.Time           `json:"startTime"`
+	EndTime         time.Time           `json:"endTime"`
+	PromptTokens    int                 `json:"promptTokens"`
+	ResponseTokens  int                 `json:"responseTokens"`
+	TokensEstimated bool                `json:"tokensEstimated,omitempty"` // streaming responses don't report their usage
+	Cost            *float64            `json:"cost,omitempty"`            // in USD, set when the model pricing is configured
+}
+
+// GenerationSink receives served generations (e.g. to export them to an LLM observability service).
+// Add is called on the request path, so sinks must not block
+type GenerationSink interface {
+	Add(generation *Generation)
+}
+
+// GenerationLog passes served generations to the registered sinks.
+// Generations carry whole prompts & completions, so they are built only when there are sinks
+type GenerationLog struct {
+	mu    sync.RWMutex
+	sinks []GenerationSink
+}
+
+func NewGenerationLog() *GenerationLog {
+	return &GenerationLog{}
+}
+
+// AddSink makes the log pass every recorded generation to the sink
+func (l *GenerationLog) AddSink(sink GenerationSink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sinks = append(l.sinks, sink)
+}
+
+// Enabled checks if any sink receives generations
+func (l *GenerationLog) Enabled() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.sinks) > 0
+}
+
+func (l *GenerationLog) Record(generation *Generation) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, sink := range l.sinks {
+		sink.Add(generation)
+	}
+}
@@ -9,6 +9,9 @@ type LogConfig struct {
 	// Level is the minimum enabled logging level.
 	Level zapcore.Level `yaml:"level"`
 
+	// Levels override the minimum enabled logging level per module (providers, routing, http, health).
+	Levels map[string]zapcore.Level `yaml:"levels"`
+
 	// Encoding sets the logger's encoding. Valid values are "json", "console"
 	Encoding string `yaml:"encoding"`
 
@@ -60,10 +63,14 @@ func (c *LogConfig) ToZapConfig() *zap.Config {
 	return &zapConfig
 }
 
-func NewLogger(cfg *LogConfig) (*zap.Logger, error) {
+// NewLogger builds the logger whose levels are controlled by the log levels, so they could be changed at runtime
+func NewLogger(cfg *LogConfig, levels *LogLevels) (*zap.Logger, error) {
 	zapConfig := cfg.ToZapConfig()
 
-	logger, err := zapConfig.Build()
+	// the module level core filters entries, so the underlying core lets everything through
+	zapConfig.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+
+	logger, err := zapConfig.Build(zap.WrapCore(levels.Core))
 	if err != nil {
 		return nil, err
 	}
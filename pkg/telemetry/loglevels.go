@@ -0,0 +1,155 @@
+package telemetry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Modules are subsystems whose log levels could be set separately (e.g. to debug providers without router noise)
+const (
+	ModuleProviders = "providers"
+	ModuleRouting   = "routing"
+	ModuleHTTP      = "http"
+	ModuleHealth    = "health"
+)
+
+var Modules = []string{ModuleProviders, ModuleRouting, ModuleHTTP, ModuleHealth}
+
+// LogLevels holds the default log level & per module overrides. Levels could be changed at runtime
+type LogLevels struct {
+	mu      sync.RWMutex
+	level   zapcore.Level
+	modules map[string]zapcore.Level
+}
+
+func NewLogLevels(cfg *LogConfig) (*LogLevels, error) {
+	levels := &LogLevels{
+		level:   cfg.Level,
+		modules: make(map[string]zapcore.Level, len(cfg.Levels)),
+	}
+
+	for module, level := range cfg.Levels {
+		if err := levels.SetModuleLevel(module, &level); err != nil {
+			return nil, err
+		}
+	}
+
+	return levels, nil
+}
+
+// Level returns the default level of all modules without overrides
+func (l *LogLevels) Level() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.level
+}
+
+// SetLevel changes the default level of all modules without overrides
+func (l *LogLevels) SetLevel(level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = level
+}
+
+// ModuleLevels returns the per module overrides
+func (l *LogLevels) ModuleLevels() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	levels := make(map[string]zapcore.Level, len(l.modules))
+
+	for module, level := range l.modules {
+		levels[module] = level
+	}
+
+	return levels
+}
+
+// SetModuleLevel overrides the level of the module or resets it to the default level if the level is nil
+func (l *LogLevels) SetModuleLevel(module string, level *zapcore.Level) error {
+	if !slices.Contains(Modules, module) {
+		return fmt.Errorf("unknown log module %q, supported modules: %v", module, strings.Join(Modules, ", "))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if level == nil {
+		delete(l.modules, module)
+
+		return nil
+	}
+
+	l.modules[module] = *level
+
+	return nil
+}
+
+// Enabled checks if the level is enabled for at least one module
+func (l *LogLevels) Enabled(level zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if level >= l.level {
+		return true
+	}
+
+	for _, moduleLevel := range l.modules {
+		if level >= moduleLevel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ModuleEnabled checks if the level is enabled for the module. Logs of unknown modules follow the default level
+func (l *LogLevels) ModuleEnabled(module string, level zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if moduleLevel, found := l.modules[module]; found {
+		return level >= moduleLevel
+	}
+
+	return level >= l.level
+}
+
+// Core wraps the zap core, so it only writes entries enabled for the module of the logger
+func (l *LogLevels) Core(core zapcore.Core) zapcore.Core {
+	return &moduleLevelCore{Core: core, levels: l}
+}
+
+// loggerModule returns the module of the named logger (e.g. "providers" of "providers.openai")
+func loggerModule(loggerName string) string {
+	module, _, _ := strings.Cut(loggerName, ".")
+
+	return module
+}
+
+type moduleLevelCore struct {
+	zapcore.Core
+	levels *LogLevels
+}
+
+func (c *moduleLevelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.Enabled(level)
+}
+
+func (c *moduleLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &moduleLevelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *moduleLevelCore) Check(entry zapcore.Entry, checkedEntry *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.ModuleEnabled(loggerModule(entry.LoggerName), entry.Level) {
+		return checkedEntry
+	}
+
+	return c.Core.Check(entry, checkedEntry)
+}
@@ -0,0 +1,75 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogLevels_FilterLogsPerModule(t *testing.T) {
+	cfg := DefaultLogConfig()
+	cfg.Levels = map[string]zapcore.Level{ModuleProviders: zapcore.DebugLevel}
+
+	levels, err := NewLogLevels(cfg)
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(levels.Core(core))
+
+	logger.Named(ModuleProviders).Named("openai").Debug("provider request")
+	logger.Named(ModuleRouting).Debug("router decision")
+	logger.Named(ModuleRouting).Info("router is ready")
+	logger.Debug("gateway debug")
+
+	require.Equal(t, []string{"provider request", "router is ready"}, messages(logs))
+
+	// levels are changed at runtime
+	level := zapcore.ErrorLevel
+
+	require.NoError(t, levels.SetModuleLevel(ModuleProviders, &level))
+	levels.SetLevel(zapcore.DebugLevel)
+
+	logs.TakeAll()
+
+	logger.Named(ModuleProviders).Warn("provider warning")
+	logger.Named(ModuleHTTP).Debug("http request")
+
+	require.Equal(t, []string{"http request"}, messages(logs))
+
+	// resetting the module makes it follow the default level
+	require.NoError(t, levels.SetModuleLevel(ModuleProviders, nil))
+	require.Empty(t, levels.ModuleLevels())
+}
+
+func TestLogLevels_RejectUnknownModules(t *testing.T) {
+	cfg := DefaultLogConfig()
+	cfg.Levels = map[string]zapcore.Level{"provider": zapcore.DebugLevel}
+
+	_, err := NewLogLevels(cfg)
+	require.ErrorContains(t, err, "unknown log module")
+}
+
+func TestTelemetry_ModuleLoggersAreNotNested(t *testing.T) {
+	tel := NewTelemetryMock()
+
+	routingTel := tel.Module(ModuleRouting)
+	providersTel := routingTel.Module(ModuleProviders)
+
+	require.Equal(t, ModuleRouting, routingTel.L().Name())
+	require.Equal(t, ModuleProviders, providersTel.L().Name())
+	require.Equal(t, "", tel.L().Name())
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	entries := logs.All()
+	messages := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		messages = append(messages, entry.Message)
+	}
+
+	return messages
+}
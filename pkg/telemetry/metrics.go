@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing metric
+type Counter struct {
+	name  string
+	value atomic.Int64
+}
+
+func (c *Counter) Name() string {
+	return c.name
+}
+
+// Add increments the counter by the given delta
+func (c *Counter) Add(delta int64) {
+	c.value.Add(delta)
+}
+
+// Inc increments the counter by one
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+// Meter holds all metrics the gateway emits
+//
+//	Metrics are created lazily on the first access and live as long as the meter does
+type Meter struct {
+	mu       sync.RWMutex
+	counters map[string]*Counter
+}
+
+func NewMeter() *Meter {
+	return &Meter{
+		counters: make(map[string]*Counter),
+	}
+}
+
+// Counter returns a counter by its name creating it if it doesn't exist yet
+func (m *Meter) Counter(name string) *Counter {
+	m.mu.RLock()
+	counter, found := m.counters[name]
+	m.mu.RUnlock()
+
+	if found {
+		return counter
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if counter, found = m.counters[name]; found {
+		return counter
+	}
+
+	counter = &Counter{name: name}
+	m.counters[name] = counter
+
+	return counter
+}
+
+// Counters returns current values of all counters
+func (m *Meter) Counters() map[string]int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	values := make(map[string]int64, len(m.counters))
+
+	for name, counter := range m.counters {
+		values[name] = counter.Value()
+	}
+
+	return values
+}
@@ -0,0 +1,17 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeter_CounterIsCreatedOnce(t *testing.T) {
+	meter := NewMeter()
+
+	meter.Counter("http.panics").Inc()
+	meter.Counter("http.panics").Add(2)
+
+	require.Equal(t, int64(3), meter.Counter("http.panics").Value())
+	require.Equal(t, map[string]int64{"http.panics": 3}, meter.Counters())
+}
@@ -0,0 +1,311 @@
+package telemetry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactedValue replaces values of deny-listed fields & text matching sensitive patterns
+const redactedValue = "[REDACTED]"
+
+// RedactionConfig defines how sensitive data is removed from logs (e.g. API keys & message contents in provider request logs)
+type RedactionConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Fields are names of fields whose values are dropped entirely (matched case-insensitively at any depth of logged values)
+	Fields []string `yaml:"fields"`
+
+	// Patterns are regexes of sensitive text (e.g. emails, tokens) scrubbed from logged messages & values
+	Patterns []string `yaml:"patterns"`
+
+	// HashContent replaces message contents with their hashes, so equal messages could still be correlated in logs
+	HashContent bool `yaml:"hash_content"`
+
+	// ContentFields are names of fields that hold message contents
+	ContentFields []string `yaml:"content_fields"`
+}
+
+func DefaultRedactionConfig() *RedactionConfig {
+	return &RedactionConfig{
+		Enabled: true,
+		Fields: []string{
+			"api_key",
+			"apiKey",
+			"x-api-key",
+			"api-key",
+			"authorization",
+			"cookie",
+			"set-cookie",
+			"password",
+			"secret",
+		},
+		Patterns: []string{
+			`(?i)bearer\s+[a-z0-9._~+/-]+=*`,                 // bearer tokens
+			`\bsk-[A-Za-z0-9_-]{16,}`,                        // OpenAI & Anthropic API keys
+			`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`, // emails
+		},
+		HashContent: true,
+		ContentFields: []string{
+			"content",
+			"text",
+			"prompt",
+			"message",
+			"input",
+			"system",
+			"preamble",
+		},
+	}
+}
+
+func (c *RedactionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultRedactionConfig()
+
+	type plain RedactionConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// Redactor removes sensitive data from logged values
+type Redactor struct {
+	fields        map[string]struct{}
+	contentFields map[string]struct{}
+	patterns      []*regexp.Regexp
+	hashContent   bool
+}
+
+func NewRedactor(cfg *RedactionConfig) (*Redactor, error) {
+	patterns := make([]*regexp.Regexp, 0, len(cfg.Patterns))
+
+	for _, pattern := range cfg.Patterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+
+		patterns = append(patterns, compiled)
+	}
+
+	return &Redactor{
+		fields:        fieldSet(cfg.Fields),
+		contentFields: fieldSet(cfg.ContentFields),
+		patterns:      patterns,
+		hashContent:   cfg.HashContent,
+	}, nil
+}
+
+func fieldSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+
+	for _, name := range names {
+		set[strings.ToLower(name)] = struct{}{}
+	}
+
+	return set
+}
+
+// Core wraps the zap core, so everything it writes is redacted first
+func (r *Redactor) Core(core zapcore.Core) zapcore.Core {
+	return &redactingCore{Core: core, redactor: r}
+}
+
+// Scrub replaces text matching sensitive patterns
+func (r *Redactor) Scrub(text string) string {
+	for _, pattern := range r.patterns {
+		text = pattern.ReplaceAllString(text, redactedValue)
+	}
+
+	return text
+}
+
+// Content redacts the message content. It's hashed if content hashing is enabled, otherwise it's only scrubbed
+func (r *Redactor) Content(content string) string {
+	if r.hashContent {
+		return r.hash(content)
+	}
+
+	return r.Scrub(content)
+}
+
+// Text redacts the raw payload. JSON documents & server-sent event data are redacted field by field,
+// the rest of the text is only scrubbed
+func (r *Redactor) Text(text string) string {
+	trimmed := strings.TrimSpace(text)
+
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var value interface{}
+
+		if err := json.Unmarshal([]byte(trimmed), &value); err == nil {
+			redacted, _ := json.Marshal(r.redact("", value))
+
+			return string(redacted)
+		}
+	}
+
+	if !strings.Contains(text, "data:") {
+		return r.Scrub(text)
+	}
+
+	lines := strings.Split(text, "\n")
+
+	for idx, line := range lines {
+		if data, found := strings.CutPrefix(line, "data:"); found {
+			lines[idx] = "data: " + r.Text(data)
+
+			continue
+		}
+
+		lines[idx] = r.Scrub(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Value redacts the logged value (e.g. a provider request payload) by its JSON representation
+func (r *Redactor) Value(value interface{}) interface{} {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return redactedValue // the value can't be inspected, so it can't be logged safely
+	}
+
+	var decoded interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&decoded); err != nil {
+		return redactedValue
+	}
+
+	return r.redact("", decoded)
+}
+
+func (r *Redactor) redact(key string, value interface{}) interface{} {
+	if r.denied(key) {
+		return redactedValue
+	}
+
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for fieldKey, fieldValue := range typedValue {
+			typedValue[fieldKey] = r.redact(fieldKey, fieldValue)
+		}
+
+		return typedValue
+	case []interface{}:
+		for idx, item := range typedValue {
+			// array items inherit the field name, so lists of contents (e.g. embedding inputs) are hashed too
+			typedValue[idx] = r.redact(key, item)
+		}
+
+		return typedValue
+	case string:
+		if r.isContent(key) {
+			return r.hash(typedValue)
+		}
+
+		return r.Scrub(typedValue)
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) denied(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	_, found := r.fields[strings.ToLower(key)]
+
+	return found
+}
+
+func (r *Redactor) isContent(key string) bool {
+	if !r.hashContent || key == "" {
+		return false
+	}
+
+	_, found := r.contentFields[strings.ToLower(key)]
+
+	return found
+}
+
+func (r *Redactor) hash(content string) string {
+	contentHash := sha256.Sum256([]byte(content))
+
+	return "sha256:" + hex.EncodeToString(contentHash[:8])
+}
+
+// field returns the redacted copy of the log field
+func (r *Redactor) field(field zapcore.Field) zapcore.Field {
+	if r.denied(field.Key) {
+		return zap.String(field.Key, redactedValue)
+	}
+
+	switch field.Type { //nolint:exhaustive
+	case zapcore.StringType:
+		if r.isContent(field.Key) {
+			return zap.String(field.Key, r.hash(field.String))
+		}
+
+		return zap.String(field.Key, r.Text(field.String))
+	case zapcore.ByteStringType:
+		if body, ok := field.Interface.([]byte); ok {
+			return zap.ByteString(field.Key, []byte(r.Text(string(body))))
+		}
+	case zapcore.StringerType:
+		if stringer, ok := field.Interface.(fmt.Stringer); ok {
+			return zap.String(field.Key, r.Scrub(stringer.String()))
+		}
+	case zapcore.ReflectType:
+		return zap.Any(field.Key, r.Value(field.Interface))
+	}
+
+	return field
+}
+
+func (r *Redactor) fieldList(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, 0, len(fields))
+
+	for _, field := range fields {
+		redacted = append(redacted, r.field(field))
+	}
+
+	return redacted
+}
+
+type redactingCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{
+		Core:     c.Core.With(c.redactor.fieldList(fields)),
+		redactor: c.redactor,
+	}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checkedEntry *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checkedEntry.AddCore(entry, c)
+	}
+
+	return checkedEntry
+}
+
+// Write passes the redacted entry through the wrapped core checks, so its level filters & sampling still apply
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.redactor.Scrub(entry.Message)
+
+	c.Core.Check(entry, nil).Write(c.redactor.fieldList(fields)...)
+
+	return nil
+}
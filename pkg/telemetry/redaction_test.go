@@ -0,0 +1,102 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type chatPayload struct {
+	Model    string              `json:"model"`
+	APIKey   string              `json:"api_key"`
+	Messages []map[string]string `json:"messages"`
+	Input    []string            `json:"input"`
+}
+
+func newTestRedactor(t *testing.T) *Redactor {
+	redactor, err := NewRedactor(DefaultRedactionConfig())
+	require.NoError(t, err)
+
+	return redactor
+}
+
+func TestRedactor_RedactsPayloads(t *testing.T) {
+	redactor := newTestRedactor(t)
+
+	redacted := redactor.Value(&chatPayload{
+		Model:    "gpt-4",
+		APIKey:   "ABC",
+		Messages: []map[string]string{{"role": "user", "content": "my email is john@example.com"}},
+		Input:    []string{"first", "second"},
+	}).(map[string]interface{})
+
+	require.Equal(t, "gpt-4", redacted["model"])
+	require.Equal(t, redactedValue, redacted["api_key"])
+
+	message := redacted["messages"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "user", message["role"])
+	require.Equal(t, redactor.hash("my email is john@example.com"), message["content"])
+
+	input := redacted["input"].([]interface{})
+	require.Equal(t, redactor.hash("first"), input[0])
+	require.NotEqual(t, input[0], input[1])
+}
+
+func TestRedactor_RedactsText(t *testing.T) {
+	redactor := newTestRedactor(t)
+
+	require.Equal(t, "contact [REDACTED] with [REDACTED]", redactor.Text("contact john@example.com with Bearer abc.def"))
+	require.Equal(t, "key [REDACTED]", redactor.Text("key sk-abcdefghijklmnopqrstuvwxyz"))
+
+	require.Equal(
+		t,
+		`data: {"delta":{"content":"`+redactor.hash("Knock")+`"}}`,
+		redactor.Text(`data: {"delta":{"content":"Knock"}}`),
+	)
+
+	require.Equal(t, `{"error":{"message":"`+redactor.hash("invalid key")+`"}}`, redactor.Text(`{"error": {"message": "invalid key"}}`))
+
+	cfg := DefaultRedactionConfig()
+	cfg.HashContent = false
+
+	redactor, err := NewRedactor(cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, `{"content":"hi [REDACTED]"}`, redactor.Text(`{"content":"hi john@example.com"}`))
+}
+
+func TestRedactor_InvalidPattern(t *testing.T) {
+	cfg := DefaultRedactionConfig()
+	cfg.Patterns = []string{"("}
+
+	_, err := NewRedactor(cfg)
+	require.Error(t, err)
+}
+
+func TestRedactor_Core(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(newTestRedactor(t).Core(core)).With(zap.String("authorization", "Bearer abc"))
+
+	logger.Debug("skipped", zap.String("content", "hi"))
+	logger.Info(
+		"Chat request from john@example.com",
+		zap.Any("payload", map[string]string{"prompt": "hi", "model": "gpt-4"}),
+		zap.ByteString("rawChunk", []byte(`data: {"text":"hi"}`)),
+		zap.Int("attempt", 1),
+	)
+
+	require.Equal(t, 1, logs.Len())
+
+	entry := logs.All()[0]
+	fields := entry.ContextMap()
+
+	require.Equal(t, "Chat request from [REDACTED]", entry.Message)
+	require.Equal(t, redactedValue, fields["authorization"])
+	require.Equal(t, "gpt-4", fields["payload"].(map[string]interface{})["model"])
+	require.NotEqual(t, "hi", fields["payload"].(map[string]interface{})["prompt"])
+	require.NotContains(t, fields["rawChunk"], `"hi"`)
+	require.Equal(t, int64(1), fields["attempt"])
+}
@@ -0,0 +1,161 @@
+package telemetry
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxStatsDPacketSize keeps packets under the common network MTU, so they are not fragmented
+const maxStatsDPacketSize = 1432
+
+// StatsDConfig defines the StatsD (or the Datadog agent's DogStatsD) server metrics are pushed to
+type StatsDConfig struct {
+	Address       string        `yaml:"address" validate:"required,hostname_port"`
+	Prefix        string        `yaml:"prefix"`                                  // prepended to all metric names (e.g. "glide.")
+	FlushInterval time.Duration `yaml:"flush_interval" validate:"required,gt=0"` // how often counter increments are pushed
+	DogStatsD     bool          `yaml:"dogstatsd"`                               // enables the Datadog tag extension
+	Tags          []string      `yaml:"tags,omitempty"`                          // DogStatsD tags added to all metrics (e.g. "env:prod")
+}
+
+func DefaultStatsDConfig() *StatsDConfig {
+	return &StatsDConfig{
+		Address:       "127.0.0.1:8125",
+		Prefix:        "glide.",
+		FlushInterval: 10 * time.Second,
+	}
+}
+
+func (c *StatsDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultStatsDConfig()
+
+	type plain StatsDConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// StatsDExporter periodically pushes counter increments since the previous flush as StatsD counters over UDP
+type StatsDExporter struct {
+	cfg      *StatsDConfig
+	meter    *Meter
+	conn     net.Conn
+	suffix   string
+	sent     map[string]int64
+	logger   *zap.Logger
+	started  bool
+	stopC    chan struct{}
+	doneC    chan struct{}
+	stopOnce sync.Once
+}
+
+func NewStatsDExporter(cfg *StatsDConfig, meter *Meter, logger *zap.Logger) (*StatsDExporter, error) {
+	conn, err := net.Dial("udp", cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to statsd: %w", err)
+	}
+
+	suffix := "|c"
+
+	if cfg.DogStatsD && len(cfg.Tags) > 0 {
+		suffix += "|#" + strings.Join(cfg.Tags, ",")
+	}
+
+	return &StatsDExporter{
+		cfg:    cfg,
+		meter:  meter,
+		conn:   conn,
+		suffix: suffix,
+		sent:   make(map[string]int64),
+		logger: logger,
+		stopC:  make(chan struct{}),
+		doneC:  make(chan struct{}),
+	}, nil
+}
+
+func (e *StatsDExporter) Start() {
+	e.started = true
+
+	go func() {
+		defer close(e.doneC)
+
+		ticker := time.NewTicker(e.cfg.FlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				e.Flush()
+			case <-e.stopC:
+				e.Flush()
+
+				return
+			}
+		}
+	}()
+}
+
+// Stop pushes the last increments & closes the connection
+func (e *StatsDExporter) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stopC)
+
+		if e.started {
+			<-e.doneC
+		}
+
+		_ = e.conn.Close()
+	})
+}
+
+// Flush sends increments of counters that have changed since the previous flush
+func (e *StatsDExporter) Flush() {
+	counters := e.meter.Counters()
+	names := make([]string, 0, len(counters))
+
+	for name := range counters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var packet bytes.Buffer
+
+	for _, name := range names {
+		delta := counters[name] - e.sent[name]
+		if delta == 0 {
+			continue
+		}
+
+		e.sent[name] = counters[name]
+
+		line := fmt.Sprintf("%v%v:%v%v", e.cfg.Prefix, name, delta, e.suffix)
+
+		if packet.Len() > 0 && packet.Len()+len(line)+1 > maxStatsDPacketSize {
+			e.send(packet.Bytes())
+			packet.Reset()
+		}
+
+		if packet.Len() > 0 {
+			packet.WriteByte('\n')
+		}
+
+		packet.WriteString(line)
+	}
+
+	if packet.Len() > 0 {
+		e.send(packet.Bytes())
+	}
+}
+
+func (e *StatsDExporter) send(packet []byte) {
+	if _, err := e.conn.Write(packet); err != nil {
+		// metrics are best effort, the increments are not resent
+		e.logger.Warn("failed to send metrics to statsd", zap.String("address", e.cfg.Address), zap.Error(err))
+	}
+}
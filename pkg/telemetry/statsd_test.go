@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readPacket(t *testing.T, conn net.PacketConn) []string {
+	t.Helper()
+
+	buffer := make([]byte, maxStatsDPacketSize)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+	size, _, err := conn.ReadFrom(buffer)
+	require.NoError(t, err)
+
+	return strings.Split(string(buffer[:size]), "\n")
+}
+
+func TestStatsDExporter_PushesCounterIncrements(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer server.Close()
+
+	cfg := DefaultStatsDConfig()
+	cfg.Address = server.LocalAddr().String()
+	cfg.DogStatsD = true
+	cfg.Tags = []string{"env:test"}
+
+	meter := NewMeter()
+
+	exporter, err := NewStatsDExporter(cfg, meter, NewLoggerMock())
+	require.NoError(t, err)
+
+	defer exporter.Stop()
+
+	meter.Counter("http.panics").Add(2)
+	meter.Counter("events.delivered").Inc()
+
+	exporter.Flush()
+
+	require.Equal(t, []string{
+		"glide.events.delivered:1|c|#env:test",
+		"glide.http.panics:2|c|#env:test",
+	}, readPacket(t, server))
+
+	// only increments since the previous flush are pushed
+	meter.Counter("http.panics").Inc()
+
+	exporter.Flush()
+
+	require.Equal(t, []string{"glide.http.panics:1|c|#env:test"}, readPacket(t, server))
+}
@@ -1,40 +1,164 @@
 package telemetry
 
-import "go.uber.org/zap"
+import (
+	"glide/pkg/version"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// errorLogSize is how many recent warnings & errors are kept in memory
+const errorLogSize = 100
 
 type Config struct {
-	LogConfig *LogConfig `yaml:"logging" validate:"required"`
+	LogConfig *LogConfig       `yaml:"logging" validate:"required"`
+	Redaction *RedactionConfig `yaml:"redaction"` // removes sensitive data from logs, disabled when nil
+	StatsD    *StatsDConfig    `yaml:"statsd"`    // pushes metrics to StatsD or DogStatsD, disabled when nil
+	Capture   *CaptureConfig   `yaml:"capture"`   // captures raw provider traffic for debugging, can be enabled via the admin API too
+	// ErrorReporting sends errors & recovered panics to Sentry or a webhook, disabled when nil
+	ErrorReporting *ErrorReportingConfig `yaml:"error_reporting"`
 	// TODO: add OTEL config
 }
 
 type Telemetry struct {
 	Config *Config
 	Logger *zap.Logger
-	// TODO: add OTEL meter, tracer
+	Levels *LogLevels
+	Meter  *Meter
+	Errors *ErrorLog
+	Usage  *UsageLedger
+	Audit  *AuditLog
+	// Generations passes prompts & completions of served requests to LLM observability exporters
+	Generations *GenerationLog
+	// Capture keeps sanitized raw provider traffic of sampled or selected requests
+	Capture       *CaptureLog
+	statsD        *StatsDExporter
+	errorReporter *ErrorReporter
+	root          *zap.Logger // the logger modules are named from
+	// TODO: add OTEL tracer
 }
 
 func (t Telemetry) L() *zap.Logger {
 	return t.Logger
 }
 
+func (t Telemetry) M() *Meter {
+	return t.Meter
+}
+
+// Module returns the telemetry whose logger is named after the module, so its log level could be set separately
+func (t Telemetry) Module(module string) *Telemetry {
+	root := t.root
+
+	if root == nil {
+		root = t.Logger
+	}
+
+	t.root = root
+	t.Logger = root.Named(module)
+
+	return &t
+}
+
 func DefaultConfig() *Config {
 	return &Config{
 		LogConfig: DefaultLogConfig(),
+		Redaction: DefaultRedactionConfig(),
 	}
 }
 
 func NewTelemetry(cfg *Config) (*Telemetry, error) {
-	logger, err := NewLogger(cfg.LogConfig)
+	levels, err := NewLogLevels(cfg.LogConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	logger, err := NewLogger(cfg.LogConfig, levels)
+	if err != nil {
+		return nil, err
+	}
+
+	errorLog := NewErrorLog(errorLogSize)
+
+	logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, errorLog.Core())
+	}))
+
+	var errorReporter *ErrorReporter
+
+	if cfg.ErrorReporting != nil {
+		if cfg.ErrorReporting.Release == "" {
+			cfg.ErrorReporting.Release = version.Version
+		}
+
+		// the reporter logs its failures without the reporting core, so they are never reported back
+		errorReporter, err = NewErrorReporter(cfg.ErrorReporting, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, errorReporter.Core())
+		}))
+
+		errorReporter.Start()
+	}
+
+	if cfg.Redaction != nil && cfg.Redaction.Enabled {
+		redactor, err := NewRedactor(cfg.Redaction)
+		if err != nil {
+			return nil, err
+		}
+
+		logger = logger.WithOptions(zap.WrapCore(redactor.Core))
+	}
+
+	captureConfig := cfg.Capture
+	if captureConfig == nil {
+		captureConfig = DefaultCaptureConfig()
+	}
+
+	captureLog, err := NewCaptureLog(captureConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	meter := NewMeter()
+
+	var statsD *StatsDExporter
+
+	if cfg.StatsD != nil {
+		statsD, err = NewStatsDExporter(cfg.StatsD, meter, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		statsD.Start()
+	}
+
 	return &Telemetry{
-		Config: cfg,
-		Logger: logger,
+		Config:        cfg,
+		Logger:        logger,
+		Levels:        levels,
+		Meter:         meter,
+		Errors:        errorLog,
+		Usage:         NewUsageLedger(),
+		Audit:         NewAuditLog(auditLogSize, logger),
+		Generations:   NewGenerationLog(),
+		Capture:       captureLog,
+		statsD:        statsD,
+		errorReporter: errorReporter,
 	}, nil
 }
 
+// Shutdown pushes the remaining metrics & error reports to the configured exporters
+func (t *Telemetry) Shutdown() {
+	if t.statsD != nil {
+		t.statsD.Stop()
+	}
+
+	t.errorReporter.Stop()
+}
+
 func NewLoggerMock() *zap.Logger {
 	return zap.NewNop()
 }
@@ -42,7 +166,20 @@ func NewLoggerMock() *zap.Logger {
 // NewTelemetryMock returns Telemetry object with NoOp loggers, meters, tracers
 func NewTelemetryMock() *Telemetry {
 	return &Telemetry{
-		Config: DefaultConfig(),
-		Logger: NewLoggerMock(),
+		Config:      DefaultConfig(),
+		Logger:      NewLoggerMock(),
+		Levels:      &LogLevels{level: zapcore.InfoLevel, modules: make(map[string]zapcore.Level)},
+		Meter:       NewMeter(),
+		Errors:      NewErrorLog(errorLogSize),
+		Usage:       NewUsageLedger(),
+		Audit:       NewAuditLog(auditLogSize, NewLoggerMock()),
+		Generations: NewGenerationLog(),
+		Capture:     newCaptureLogMock(),
 	}
 }
+
+func newCaptureLogMock() *CaptureLog {
+	captureLog, _ := NewCaptureLog(DefaultCaptureConfig())
+
+	return captureLog
+}
@@ -0,0 +1,124 @@
+package telemetry
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// UsageEvent is the usage & the cost of one served request
+type UsageEvent struct {
+	Time               time.Time `json:"time"`
+	RouterID           string    `json:"routerId"`
+	ModelID            string    `json:"modelId"`
+	Provider           string    `json:"provider"`
+	Caller             string    `json:"caller,omitempty"` // the API key hash or the client IP
+	PromptTokens       int       `json:"promptTokens"`
+	ResponseTokens     int       `json:"responseTokens"`
+	CachedPromptTokens int       `json:"cachedPromptTokens"`
+	Cost               float64   `json:"cost"`
+	LatencyMs          float64   `json:"latencyMs"`
+}
+
+// UsageSink receives every recorded usage event (e.g. to export them to a data warehouse).
+// Add is called on the request path, so sinks must not block
+type UsageSink interface {
+	Add(event *UsageEvent)
+}
+
+// UsageRecord is the token usage & the cost of requests the router model has served for the caller
+type UsageRecord struct {
+	RouterID           string  `json:"routerId"`
+	ModelID            string  `json:"modelId"`
+	Caller             string  `json:"caller,omitempty"` // the API key hash or the client IP
+	Requests           int64   `json:"requests"`
+	PromptTokens       int64   `json:"promptTokens"`
+	ResponseTokens     int64   `json:"responseTokens"`
+	CachedPromptTokens int64   `json:"cachedPromptTokens"`
+	Cost               float64 `json:"cost"` // in USD, requests to models without pricing cost nothing
+}
+
+// UsageFilter selects usage records, empty fields match any value
+type UsageFilter struct {
+	RouterID string
+	ModelID  string
+	Caller   string
+}
+
+func (f UsageFilter) matches(record *UsageRecord) bool {
+	return (f.RouterID == "" || f.RouterID == record.RouterID) &&
+		(f.ModelID == "" || f.ModelID == record.ModelID) &&
+		(f.Caller == "" || f.Caller == record.Caller)
+}
+
+type usageKey struct {
+	routerID string
+	modelID  string
+	caller   string
+}
+
+// UsageLedger aggregates usage & costs of served requests per router, model & caller since the gateway has started
+type UsageLedger struct {
+	mu      sync.Mutex
+	records map[usageKey]*UsageRecord
+	sinks   []UsageSink
+}
+
+func NewUsageLedger() *UsageLedger {
+	return &UsageLedger{
+		records: make(map[usageKey]*UsageRecord),
+	}
+}
+
+// AddSink makes the ledger pass every recorded event to the sink
+func (l *UsageLedger) AddSink(sink UsageSink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sinks = append(l.sinks, sink)
+}
+
+// Record adds the served request to the ledger
+func (l *UsageLedger) Record(event *UsageEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := usageKey{routerID: event.RouterID, modelID: event.ModelID, caller: event.Caller}
+
+	record, found := l.records[key]
+	if !found {
+		record = &UsageRecord{RouterID: event.RouterID, ModelID: event.ModelID, Caller: event.Caller}
+		l.records[key] = record
+	}
+
+	record.Requests++
+	record.PromptTokens += int64(event.PromptTokens)
+	record.ResponseTokens += int64(event.ResponseTokens)
+	record.CachedPromptTokens += int64(event.CachedPromptTokens)
+	record.Cost += event.Cost
+
+	for _, sink := range l.sinks {
+		sink.Add(event)
+	}
+}
+
+// Records returns copies of the records the filter selects ordered by router, model & caller
+func (l *UsageLedger) Records(filter UsageFilter) []UsageRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	records := make([]UsageRecord, 0, len(l.records))
+
+	for _, record := range l.records {
+		if filter.matches(record) {
+			records = append(records, *record)
+		}
+	}
+
+	slices.SortFunc(records, func(a, b UsageRecord) int {
+		return cmp.Or(cmp.Compare(a.RouterID, b.RouterID), cmp.Compare(a.ModelID, b.ModelID), cmp.Compare(a.Caller, b.Caller))
+	})
+
+	return records
+}
@@ -0,0 +1,56 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageLedger_AggregatesRecords(t *testing.T) {
+	ledger := NewUsageLedger()
+
+	ledger.Record(&UsageEvent{RouterID: "router", ModelID: "openai", Caller: "key:abc", PromptTokens: 10, ResponseTokens: 20, Cost: 0.5})
+	ledger.Record(&UsageEvent{RouterID: "router", ModelID: "openai", Caller: "key:abc", PromptTokens: 5, ResponseTokens: 5, CachedPromptTokens: 4, Cost: 0.25})
+	ledger.Record(&UsageEvent{RouterID: "router", ModelID: "anthropic", Caller: "ip:127.0.0.1", PromptTokens: 1, ResponseTokens: 2})
+
+	records := ledger.Records(UsageFilter{})
+	require.Len(t, records, 2)
+	require.Equal(t, "anthropic", records[0].ModelID)
+
+	require.Equal(t, UsageRecord{
+		RouterID:           "router",
+		ModelID:            "openai",
+		Caller:             "key:abc",
+		Requests:           2,
+		PromptTokens:       15,
+		ResponseTokens:     25,
+		CachedPromptTokens: 4,
+		Cost:               0.75,
+	}, records[1])
+
+	records = ledger.Records(UsageFilter{Caller: "ip:127.0.0.1"})
+	require.Len(t, records, 1)
+	require.Equal(t, "anthropic", records[0].ModelID)
+
+	require.Empty(t, ledger.Records(UsageFilter{RouterID: "another"}))
+}
+
+type usageSinkMock struct {
+	events []*UsageEvent
+}
+
+func (s *usageSinkMock) Add(event *UsageEvent) {
+	s.events = append(s.events, event)
+}
+
+func TestUsageLedger_PassesEventsToSinks(t *testing.T) {
+	ledger := NewUsageLedger()
+	sink := &usageSinkMock{}
+
+	ledger.AddSink(sink)
+	ledger.Record(&UsageEvent{RouterID: "router", ModelID: "openai", PromptTokens: 10, LatencyMs: 120})
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, "openai", sink.events[0].ModelID)
+	require.InDelta(t, 120, sink.events[0].LatencyMs, 0.001)
+}
@@ -0,0 +1,216 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"glide/pkg/telemetry"
+)
+
+const (
+	defaultBigQueryEndpoint = "https://bigquery.googleapis.com"
+	// gceTokenURL issues access tokens of the service account attached to the GCE instance, GKE pod or Cloud Run service
+	gceTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// BigQueryConfig defines the BigQuery table records are streamed into.
+// The gateway authenticates as the service account of the GCP workload it runs on
+type BigQueryConfig struct {
+	Endpoint string `yaml:"endpoint,omitempty" validate:"omitempty,url"`
+	TokenURL string `yaml:"token_url,omitempty" validate:"omitempty,url"`
+	Project  string `yaml:"project" validate:"required"`
+	Dataset  string `yaml:"dataset" validate:"required"`
+	Table    string `yaml:"table" validate:"required"`
+}
+
+type bigQueryRow struct {
+	InsertID string                `json:"insertId"` // lets BigQuery deduplicate rows of retried inserts
+	JSON     *telemetry.UsageEvent `json:"json"`
+}
+
+type bigQueryInsertRequest struct {
+	Rows []bigQueryRow `json:"rows"`
+}
+
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+type accessToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// BigQuerySink streams batches into the table via the insertAll API
+type BigQuerySink struct {
+	insertURL  string
+	tokenURL   string
+	httpClient *http.Client
+
+	mu             sync.Mutex
+	token          string
+	tokenExpiresAt time.Time
+}
+
+func NewBigQuerySink(cfg *BigQueryConfig) *BigQuerySink {
+	endpoint := cfg.Endpoint
+
+	if endpoint == "" {
+		endpoint = defaultBigQueryEndpoint
+	}
+
+	tokenURL := cfg.TokenURL
+
+	if tokenURL == "" {
+		tokenURL = gceTokenURL
+	}
+
+	insertURL, _ := url.JoinPath(
+		endpoint,
+		"bigquery/v2/projects", cfg.Project,
+		"datasets", cfg.Dataset,
+		"tables", cfg.Table,
+		"insertAll",
+	)
+
+	return &BigQuerySink{
+		insertURL:  insertURL,
+		tokenURL:   tokenURL,
+		httpClient: &http.Client{},
+	}
+}
+
+func (s *BigQuerySink) Name() string {
+	return "bigquery"
+}
+
+func (s *BigQuerySink) Export(ctx context.Context, records []*telemetry.UsageEvent) error {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the access token: %w", err)
+	}
+
+	insertRequest := bigQueryInsertRequest{Rows: make([]bigQueryRow, 0, len(records))}
+
+	for _, record := range records {
+		insertID, err := bigQueryInsertID(record)
+		if err != nil {
+			return err
+		}
+
+		insertRequest.Rows = append(insertRequest.Rows, bigQueryRow{InsertID: insertID, JSON: record})
+	}
+
+	body, err := json.Marshal(insertRequest)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.insertURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %v: %s", resp.StatusCode, respBody)
+	}
+
+	// rows could be rejected one by one even if the request has succeeded
+	var insertResponse bigQueryInsertResponse
+
+	if err := json.Unmarshal(respBody, &insertResponse); err != nil {
+		return err
+	}
+
+	// the whole batch is retried then, rows accepted already are deduplicated by their insert IDs
+	if len(insertResponse.InsertErrors) > 0 {
+		insertErr := insertResponse.InsertErrors[0]
+
+		return fmt.Errorf("%v rows are rejected, the first one (#%v): %+v", len(insertResponse.InsertErrors), insertErr.Index, insertErr.Errors)
+	}
+
+	return nil
+}
+
+// bigQueryInsertID is the record content hash, so retried inserts of the same record get the same ID
+func bigQueryInsertID(record *telemetry.UsageEvent) (string, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	contentHash := sha256.Sum256(body)
+
+	return hex.EncodeToString(contentHash[:]), nil
+}
+
+// accessToken returns the cached token refreshing it a minute before it expires
+func (s *BigQuerySink) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.tokenExpiresAt) {
+		return s.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return "", fmt.Errorf("unexpected response status %v: %s", resp.StatusCode, body)
+	}
+
+	var token accessToken
+
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	s.token = token.AccessToken
+	s.tokenExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+
+	return s.token, nil
+}
@@ -0,0 +1,81 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigQuerySink_StreamsRows(t *testing.T) {
+	tokenRequests := 0
+
+	var insertRequest bigQueryInsertRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+
+			require.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+
+			_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 3600, "token_type": "Bearer"}`))
+		case "/bigquery/v2/projects/project/datasets/glide/tables/usage/insertAll":
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&insertRequest))
+
+			_, _ = w.Write([]byte(`{"kind": "bigquery#tableDataInsertAllResponse"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewBigQuerySink(&BigQueryConfig{
+		Endpoint: server.URL,
+		TokenURL: server.URL + "/token",
+		Project:  "project",
+		Dataset:  "glide",
+		Table:    "usage",
+	})
+
+	records := []*telemetry.UsageEvent{{ModelID: "openai"}, {ModelID: "anthropic"}}
+
+	require.NoError(t, sink.Export(context.Background(), records))
+	require.NoError(t, sink.Export(context.Background(), records))
+
+	require.Equal(t, 1, tokenRequests) // the token is cached until it expires
+	require.Len(t, insertRequest.Rows, 2)
+	require.NotEmpty(t, insertRequest.Rows[0].InsertID)
+	require.NotEqual(t, insertRequest.Rows[0].InsertID, insertRequest.Rows[1].InsertID)
+	require.Equal(t, "anthropic", insertRequest.Rows[1].JSON.ModelID)
+
+	firstInsertID := insertRequest.Rows[0].InsertID
+
+	// retried exports are deduplicated by BigQuery
+	require.NoError(t, sink.Export(context.Background(), records))
+	require.Equal(t, firstInsertID, insertRequest.Rows[0].InsertID)
+}
+
+func TestBigQuerySink_FailsOnRejectedRows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"insertErrors": [{"index": 0, "errors": [{"reason": "invalid", "message": "no such field: latencyMs"}]}]}`))
+	}))
+	defer server.Close()
+
+	sink := NewBigQuerySink(&BigQueryConfig{Endpoint: server.URL, TokenURL: server.URL + "/token", Project: "p", Dataset: "d", Table: "t"})
+
+	err := sink.Export(context.Background(), []*telemetry.UsageEvent{{ModelID: "openai"}})
+	require.ErrorContains(t, err, "no such field: latencyMs")
+}
@@ -0,0 +1,76 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/telemetry"
+)
+
+// ClickHouseConfig defines the ClickHouse table records are inserted into via the HTTP interface.
+// The table should have columns named after record fields (e.g. routerId, promptTokens, latencyMs)
+type ClickHouseConfig struct {
+	URL      string        `yaml:"url" validate:"required,url"` // e.g. http://localhost:8123
+	Database string        `yaml:"database,omitempty"`
+	Table    string        `yaml:"table" validate:"required"`
+	Username string        `yaml:"username,omitempty"`
+	Password fields.Secret `yaml:"password,omitempty"`
+}
+
+// ClickHouseSink inserts batches as JSONEachRow rows
+type ClickHouseSink struct {
+	cfg        *ClickHouseConfig
+	query      string
+	httpClient *http.Client
+}
+
+func NewClickHouseSink(cfg *ClickHouseConfig) *ClickHouseSink {
+	table := cfg.Table
+
+	if cfg.Database != "" {
+		table = cfg.Database + "." + table
+	}
+
+	return &ClickHouseSink{
+		cfg:        cfg,
+		query:      fmt.Sprintf("INSERT INTO %v FORMAT JSONEachRow", table),
+		httpClient: &http.Client{},
+	}
+}
+
+func (s *ClickHouseSink) Name() string {
+	return "clickhouse"
+}
+
+func (s *ClickHouseSink) Export(ctx context.Context, records []*telemetry.UsageEvent) error {
+	body, err := EncodeJSONLines(records)
+	if err != nil {
+		return err
+	}
+
+	insertURL, err := url.Parse(s.cfg.URL)
+	if err != nil {
+		return err
+	}
+
+	query := insertURL.Query()
+	query.Set("query", s.query)
+	query.Set("date_time_input_format", "best_effort") // record times are RFC 3339
+	insertURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, insertURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	if s.cfg.Username != "" {
+		req.Header.Set("X-ClickHouse-User", s.cfg.Username)
+		req.Header.Set("X-ClickHouse-Key", string(s.cfg.Password))
+	}
+
+	return doRequest(s.httpClient, req)
+}
@@ -0,0 +1,47 @@
+package usage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClickHouseSink_InsertsRows(t *testing.T) {
+	var (
+		query string
+		user  string
+		body  string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		user = r.Header.Get("X-ClickHouse-User")
+
+		payload, _ := io.ReadAll(r.Body)
+		body = string(payload)
+	}))
+	defer server.Close()
+
+	sink := NewClickHouseSink(&ClickHouseConfig{
+		URL:      server.URL,
+		Database: "glide",
+		Table:    "usage",
+		Username: "gateway",
+		Password: "secret",
+	})
+
+	err := sink.Export(context.Background(), []*telemetry.UsageEvent{{ModelID: "openai", Cost: 0.5}})
+	require.NoError(t, err)
+
+	require.Equal(t, "INSERT INTO glide.usage FORMAT JSONEachRow", query)
+	require.Equal(t, "gateway", user)
+	require.Len(t, strings.Split(strings.TrimSpace(body), "\n"), 1)
+	require.Contains(t, body, `"cost":0.5`)
+}
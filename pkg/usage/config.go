@@ -0,0 +1,39 @@
+package usage
+
+import (
+	"time"
+
+	"glide/pkg/routers/retry"
+)
+
+// ExportConfig defines how per-request usage records are batched & where they are exported to (e.g. for chargeback & BI).
+// At least one sink should be configured
+type ExportConfig struct {
+	BatchSize      int                   `yaml:"batch_size" validate:"required,gt=0"`     // records are exported once the batch is full
+	FlushInterval  time.Duration         `yaml:"flush_interval" validate:"required,gt=0"` // or once the interval has passed
+	BufferSize     int                   `yaml:"buffer_size" validate:"required,gt=0"`    // records are dropped once the buffer is full
+	Timeout        time.Duration         `yaml:"timeout" validate:"required,gt=0"`        // timeout of one export attempt
+	Retry          *retry.ExpRetryConfig `yaml:"retry" validate:"required"`               // failed exports are retried with exponential backoff
+	DeadLetterFile string                `yaml:"dead_letter_file,omitempty"`              // JSON Lines file records of failed exports are appended to
+	S3             *S3Config             `yaml:"s3,omitempty"`                            // S3-compatible object storage (AWS S3, GCS, MinIO)
+	ClickHouse     *ClickHouseConfig     `yaml:"clickhouse,omitempty"`
+	BigQuery       *BigQueryConfig       `yaml:"bigquery,omitempty"`
+}
+
+func DefaultExportConfig() *ExportConfig {
+	return &ExportConfig{
+		BatchSize:     500,
+		FlushInterval: 30 * time.Second,
+		BufferSize:    10_000,
+		Timeout:       30 * time.Second,
+		Retry:         retry.DefaultExpRetryConfig(),
+	}
+}
+
+func (c *ExportConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultExportConfig()
+
+	type plain ExportConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -0,0 +1,229 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"glide/pkg/events"
+	"glide/pkg/routers/retry"
+	"glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+var ErrNoSinks = errors.New("usage export is enabled, but no sinks are configured")
+
+// Sink stores a batch of usage records in an external system (e.g. an object storage or a data warehouse)
+type Sink interface {
+	Name() string
+	Export(ctx context.Context, records []*telemetry.UsageEvent) error
+}
+
+// Exporter collects usage records of served requests & exports them in batches to all configured sinks.
+// Records are buffered in memory, so the request path is never blocked by exports
+type Exporter struct {
+	cfg        *ExportConfig
+	sinks      []Sink
+	retry      *retry.ExpRetry
+	deadLetter *events.DeadLetterFile // nil if not configured
+	recordC    chan *telemetry.UsageEvent
+	stopC      chan struct{}
+	doneC      chan struct{}
+	started    bool
+	stopOnce   sync.Once
+	tel        *telemetry.Telemetry
+	logger     *zap.Logger
+}
+
+var _ telemetry.UsageSink = (*Exporter)(nil)
+
+func NewExporter(cfg *ExportConfig, tel *telemetry.Telemetry) (*Exporter, error) {
+	sinks := make([]Sink, 0, 3)
+
+	if cfg.S3 != nil {
+		sinks = append(sinks, NewS3Sink(cfg.S3))
+	}
+
+	if cfg.ClickHouse != nil {
+		sinks = append(sinks, NewClickHouseSink(cfg.ClickHouse))
+	}
+
+	if cfg.BigQuery != nil {
+		sinks = append(sinks, NewBigQuerySink(cfg.BigQuery))
+	}
+
+	if len(sinks) == 0 {
+		return nil, ErrNoSinks
+	}
+
+	return newExporter(cfg, sinks, tel), nil
+}
+
+func newExporter(cfg *ExportConfig, sinks []Sink, tel *telemetry.Telemetry) *Exporter {
+	var deadLetter *events.DeadLetterFile
+
+	if cfg.DeadLetterFile != "" {
+		deadLetter = events.NewDeadLetterFile(cfg.DeadLetterFile)
+	}
+
+	return &Exporter{
+		cfg:   cfg,
+		sinks: sinks,
+		retry: retry.NewExpRetry(
+			cfg.Retry.MaxRetries,
+			cfg.Retry.BaseMultiplier,
+			cfg.Retry.MinDelay,
+			cfg.Retry.MaxDelay,
+		),
+		deadLetter: deadLetter,
+		recordC:    make(chan *telemetry.UsageEvent, cfg.BufferSize),
+		stopC:      make(chan struct{}),
+		doneC:      make(chan struct{}),
+		tel:        tel,
+		logger:     tel.L().With(zap.String("component", "usage_exporter")),
+	}
+}
+
+// Add queues the record for export. The record is dropped if the buffer is full (e.g. when sinks are down for long)
+func (e *Exporter) Add(record *telemetry.UsageEvent) {
+	select {
+	case e.recordC <- record:
+	default:
+		e.tel.M().Counter("usage_export.dropped").Inc()
+	}
+}
+
+func (e *Exporter) Start() {
+	e.started = true
+
+	go e.run()
+}
+
+// Stop exports the buffered records & waits until it's done
+func (e *Exporter) Stop() {
+	if e == nil || !e.started {
+		return
+	}
+
+	e.stopOnce.Do(func() {
+		close(e.stopC)
+	})
+
+	<-e.doneC
+}
+
+func (e *Exporter) run() {
+	defer close(e.doneC)
+
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*telemetry.UsageEvent, 0, e.cfg.BatchSize)
+
+	for {
+		select {
+		case record := <-e.recordC:
+			batch = append(batch, record)
+
+			if len(batch) >= e.cfg.BatchSize {
+				e.flush(batch)
+				batch = make([]*telemetry.UsageEvent, 0, e.cfg.BatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				e.flush(batch)
+				batch = make([]*telemetry.UsageEvent, 0, e.cfg.BatchSize)
+			}
+		case <-e.stopC:
+			for {
+				select {
+				case record := <-e.recordC:
+					batch = append(batch, record)
+				default:
+					if len(batch) > 0 {
+						e.flush(batch)
+					}
+
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush exports the batch to every sink, so one failing sink doesn't hold records back from others
+func (e *Exporter) flush(batch []*telemetry.UsageEvent) {
+	for _, sink := range e.sinks {
+		if err := e.export(sink, batch); err != nil {
+			e.tel.M().Counter(fmt.Sprintf("usage_export.%v.failed", sink.Name())).Inc()
+			e.logger.Error(
+				"failed to export usage records",
+				zap.String("sink", sink.Name()),
+				zap.Int("records", len(batch)),
+				zap.Error(err),
+			)
+
+			e.writeDeadLetter(sink, batch)
+
+			continue
+		}
+
+		e.tel.M().Counter(fmt.Sprintf("usage_export.%v.exported", sink.Name())).Add(int64(len(batch)))
+	}
+}
+
+func (e *Exporter) export(sink Sink, batch []*telemetry.UsageEvent) error {
+	retryIterator := e.retry.Iterator()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Timeout)
+		err := sink.Export(ctx, batch)
+
+		cancel()
+
+		if err == nil || !retryIterator.HasNext() {
+			return err
+		}
+
+		e.logger.Warn("failed to export usage records, retrying", zap.String("sink", sink.Name()), zap.Error(err))
+
+		if waitErr := retryIterator.WaitNext(context.Background()); waitErr != nil {
+			return err
+		}
+	}
+}
+
+// writeDeadLetter appends records of the failed export to the dead-letter file, so they could be re-imported later
+func (e *Exporter) writeDeadLetter(sink Sink, batch []*telemetry.UsageEvent) {
+	if e.deadLetter == nil {
+		return
+	}
+
+	body, err := EncodeJSONLines(batch)
+	if err == nil {
+		err = e.deadLetter.Append(body)
+	}
+
+	if err != nil {
+		e.logger.Error("failed to write usage records to the dead-letter file", zap.String("sink", sink.Name()), zap.Error(err))
+	}
+}
+
+// EncodeJSONLines encodes records one JSON document per line
+func EncodeJSONLines(records []*telemetry.UsageEvent) ([]byte, error) {
+	body := make([]byte, 0, len(records)*256)
+
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+
+		body = append(append(body, line...), '\n')
+	}
+
+	return body, nil
+}
@@ -0,0 +1,115 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sinkMock struct {
+	mu      sync.Mutex
+	batches [][]*telemetry.UsageEvent
+	err     error
+}
+
+func (s *sinkMock) Name() string {
+	return "mock"
+}
+
+func (s *sinkMock) Export(_ context.Context, records []*telemetry.UsageEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, records)
+
+	return s.err
+}
+
+func (s *sinkMock) Batches() [][]*telemetry.UsageEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.batches
+}
+
+func testExportConfig() *ExportConfig {
+	cfg := DefaultExportConfig()
+	cfg.BatchSize = 2
+	cfg.FlushInterval = time.Hour
+	cfg.Retry.MaxRetries = 0
+
+	return cfg
+}
+
+func TestExporter_ExportsFullBatches(t *testing.T) {
+	sink := &sinkMock{}
+	exporter := newExporter(testExportConfig(), []Sink{sink}, telemetry.NewTelemetryMock())
+
+	exporter.Start()
+
+	exporter.Add(&telemetry.UsageEvent{ModelID: "openai", PromptTokens: 10})
+	exporter.Add(&telemetry.UsageEvent{ModelID: "openai", PromptTokens: 20})
+	exporter.Add(&telemetry.UsageEvent{ModelID: "anthropic", PromptTokens: 30})
+
+	require.Eventually(t, func() bool { return len(sink.Batches()) == 1 }, time.Second, 5*time.Millisecond)
+	require.Len(t, sink.Batches()[0], 2)
+
+	// the remaining records are exported on stop
+	exporter.Stop()
+
+	require.Len(t, sink.Batches(), 2)
+	require.Equal(t, "anthropic", sink.Batches()[1][0].ModelID)
+}
+
+func TestExporter_FlushesByInterval(t *testing.T) {
+	cfg := testExportConfig()
+	cfg.FlushInterval = 10 * time.Millisecond
+
+	sink := &sinkMock{}
+	exporter := newExporter(cfg, []Sink{sink}, telemetry.NewTelemetryMock())
+
+	exporter.Start()
+	defer exporter.Stop()
+
+	exporter.Add(&telemetry.UsageEvent{ModelID: "openai"})
+
+	require.Eventually(t, func() bool { return len(sink.Batches()) == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestExporter_WritesFailedBatchesToDeadLetterFile(t *testing.T) {
+	cfg := testExportConfig()
+	cfg.DeadLetterFile = filepath.Join(t.TempDir(), "usage.jsonl")
+
+	failingSink := &sinkMock{err: errors.New("bucket is not available")}
+	sink := &sinkMock{}
+	exporter := newExporter(cfg, []Sink{failingSink, sink}, telemetry.NewTelemetryMock())
+
+	exporter.Start()
+	exporter.Add(&telemetry.UsageEvent{ModelID: "openai"})
+	exporter.Stop()
+
+	// the failing sink doesn't prevent other sinks from exporting
+	require.Len(t, sink.Batches(), 1)
+
+	deadLetters, err := os.ReadFile(cfg.DeadLetterFile)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(deadLetters)), "\n")
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], `"modelId":"openai"`)
+}
+
+func TestExporter_RequiresSinks(t *testing.T) {
+	_, err := NewExporter(DefaultExportConfig(), telemetry.NewTelemetryMock())
+
+	require.ErrorIs(t, err, ErrNoSinks)
+}
@@ -0,0 +1,119 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"glide/pkg/config/fields"
+	"glide/pkg/telemetry"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/google/uuid"
+)
+
+// S3Config defines the S3-compatible bucket batches are uploaded to as JSON Lines objects.
+// GCS buckets are supported via the interoperability API (endpoint "https://storage.googleapis.com" & HMAC keys)
+type S3Config struct {
+	Endpoint  string        `yaml:"endpoint,omitempty" validate:"omitempty,url"` // defaults to the AWS S3 endpoint of the region
+	Region    string        `yaml:"region" validate:"required"`
+	Bucket    string        `yaml:"bucket" validate:"required"`
+	Prefix    string        `yaml:"prefix,omitempty"` // objects are partitioned by day under the prefix (e.g. "usage/dt=2024-05-01/")
+	AccessKey string        `yaml:"access_key" validate:"required"`
+	SecretKey fields.Secret `yaml:"secret_key" validate:"required"`
+}
+
+// S3Sink uploads every batch as a new object, so objects are never overwritten
+type S3Sink struct {
+	cfg        *S3Config
+	endpoint   string
+	signer     *v4.Signer
+	httpClient *http.Client
+}
+
+func NewS3Sink(cfg *S3Config) *S3Sink {
+	endpoint := cfg.Endpoint
+
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%v.amazonaws.com", cfg.Region)
+	}
+
+	return &S3Sink{
+		cfg:        cfg,
+		endpoint:   endpoint,
+		signer:     v4.NewSigner(),
+		httpClient: &http.Client{},
+	}
+}
+
+func (s *S3Sink) Name() string {
+	return "s3"
+}
+
+func (s *S3Sink) Export(ctx context.Context, records []*telemetry.UsageEvent) error {
+	body, err := EncodeJSONLines(records)
+	if err != nil {
+		return err
+	}
+
+	objectURL, err := url.JoinPath(s.endpoint, s.cfg.Bucket, s.objectKey(time.Now().UTC()))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	bodyHash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(bodyHash[:])
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	credentials := aws.Credentials{AccessKeyID: s.cfg.AccessKey, SecretAccessKey: string(s.cfg.SecretKey)}
+
+	if err := s.signer.SignHTTP(ctx, credentials, req, payloadHash, "s3", s.cfg.Region, time.Now()); err != nil {
+		return err
+	}
+
+	return doRequest(s.httpClient, req)
+}
+
+// objectKey partitions objects by day, so they could be queried as a Hive-partitioned table (e.g. by Athena or BigQuery)
+func (s *S3Sink) objectKey(now time.Time) string {
+	return path.Join(
+		s.cfg.Prefix,
+		"dt="+now.Format(time.DateOnly),
+		fmt.Sprintf("%v-%v.jsonl", now.Format("150405"), uuid.NewString()),
+	)
+}
+
+// doRequest sends the export request & turns unsuccessful responses into errors
+func doRequest(httpClient *http.Client, req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	return fmt.Errorf("unexpected response status %v: %s", resp.StatusCode, body)
+}
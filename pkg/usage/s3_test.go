@@ -0,0 +1,72 @@
+package usage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Sink_UploadsSignedObjects(t *testing.T) {
+	var (
+		path          string
+		authorization string
+		body          string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+
+		path = r.URL.Path
+		authorization = r.Header.Get("Authorization")
+
+		payload, _ := io.ReadAll(r.Body)
+		body = string(payload)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewS3Sink(&S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "glide-usage",
+		Prefix:    "usage",
+		AccessKey: "access-key",
+		SecretKey: "secret-key",
+	})
+
+	err := sink.Export(context.Background(), []*telemetry.UsageEvent{
+		{RouterID: "router", ModelID: "openai", Caller: "key:abc", PromptTokens: 10, LatencyMs: 120},
+		{RouterID: "router", ModelID: "anthropic", Caller: "key:abc", PromptTokens: 5},
+	})
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(path, "/glide-usage/usage/dt="))
+	require.True(t, strings.HasSuffix(path, ".jsonl"))
+	require.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access-key/"))
+	require.Contains(t, authorization, "/us-east-1/s3/aws4_request")
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"latencyMs":120`)
+}
+
+func TestS3Sink_FailsOnErrorResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}))
+	defer server.Close()
+
+	sink := NewS3Sink(&S3Config{Endpoint: server.URL, Region: "auto", Bucket: "usage", AccessKey: "key", SecretKey: "secret"})
+
+	err := sink.Export(context.Background(), []*telemetry.UsageEvent{{ModelID: "openai"}})
+	require.ErrorContains(t, err, "AccessDenied")
+}
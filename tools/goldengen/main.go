@@ -0,0 +1,184 @@
+// goldengen generates golden mapping tests for all recorded provider responses.
+//
+//	Each pkg/providers/{provider}/testdata/{action}.success.json recording gets a test that replays it
+//	through the provider client & compares the unified response with the {action}.success.golden.json file.
+//
+//	Usage (from the repository root):
+//
+//	go run ./tools/goldengen
+//	UPDATE_GOLDEN=1 go test ./pkg/providers/... -run Golden
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// provider defines how to create a client pointed to the recording server
+type provider struct {
+	// Setup creates the client variable using the server variable
+	Setup string
+	// VolatileFields are top-level response fields that change from run to run
+	VolatileFields []string
+}
+
+// action defines how to call the client for the given recording type
+type action struct {
+	Name string
+	Call string
+}
+
+const defaultSetup = `providerCfg := DefaultConfig()
+	providerCfg.BaseURL = server.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)`
+
+var providers = map[string]provider{
+	"anthropic":   {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	"azureopenai": {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	"classifier":  {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	"cohere":      {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	"deepgram":    {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	"octoml":      {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	"ollama":      {Setup: defaultSetup, VolatileFields: []string{"id", "created"}},
+	"openai":      {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	"stability":   {Setup: defaultSetup, VolatileFields: []string{"created"}},
+	// bedrock is not covered as the client talks to AWS via the SDK rather than the configured base URL
+}
+
+var actions = map[string]action{
+	"chat":          {Name: "Chat", Call: `client.Chat(context.Background(), schemas.NewChatFromStr("What's the capital of the United Kingdom?"))`},
+	"embed":         {Name: "Embed", Call: `client.Embed(context.Background(), schemas.NewEmbedFromStr("The food was delicious"))`},
+	"image":         {Name: "GenerateImage", Call: `client.GenerateImage(context.Background(), schemas.NewImageGenerateFromStr("a baby sea otter"))`},
+	"moderation":    {Name: "Moderate", Call: `client.Moderate(context.Background(), schemas.NewModerationFromStr("first input", "second input"))`},
+	"classify":      {Name: "Moderate", Call: `client.Moderate(context.Background(), schemas.NewModerationFromStr("first input", "second input"))`},
+	"tokenize":      {Name: "CountTokens", Call: `client.CountTokens(context.Background(), schemas.NewTokenizeFromStr("What's the capital of the United Kingdom?"))`},
+	"transcription": {Name: "Transcribe", Call: `client.Transcribe(context.Background(), &schemas.TranscriptionRequest{File: []byte("RIFF"), Filename: "speech.wav", ContentType: "audio/wav"})`},
+}
+
+type goldenTest struct {
+	Name          string
+	RecordingPath string
+	Call          string
+}
+
+type testFile struct {
+	Package        string
+	VolatileFields string
+	Setup          string
+	Tests          []goldenTest
+}
+
+var testFileTemplate = template.Must(template.New("golden").Parse(`// Code generated by goldengen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"glide/pkg/api/schemas"
+	"glide/pkg/providers/clients"
+	"glide/pkg/providers/testing/golden"
+	"glide/pkg/telemetry"
+)
+{{ range .Tests }}
+func TestGolden_{{ .Name }}(t *testing.T) {
+	server := golden.ServeRecording(t, "{{ .RecordingPath }}")
+
+	{{ $.Setup }}
+
+	response, err := {{ .Call }}
+	require.NoError(t, err)
+
+	golden.Assert(t, golden.Path("{{ .RecordingPath }}"), response{{ $.VolatileFields }})
+}
+{{ end }}`))
+
+func main() {
+	providersDir := flag.String("dir", "./pkg/providers", "directory with provider packages")
+	flag.Parse()
+
+	providerNames := make([]string, 0, len(providers))
+
+	for name := range providers {
+		providerNames = append(providerNames, name)
+	}
+
+	sort.Strings(providerNames)
+
+	for _, name := range providerNames {
+		if err := generate(*providersDir, name, providers[name]); err != nil {
+			log.Fatalf("failed to generate golden tests for %v: %v", name, err)
+		}
+	}
+}
+
+func generate(providersDir string, name string, p provider) error {
+	recordings, err := filepath.Glob(filepath.Join(providersDir, name, "testdata", "*.success.json"))
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(recordings)
+
+	file := testFile{
+		Package: name,
+		Setup:   p.Setup,
+	}
+
+	for _, field := range p.VolatileFields {
+		file.VolatileFields += fmt.Sprintf(", %q", field)
+	}
+
+	for _, recording := range recordings {
+		if strings.HasSuffix(recording, ".golden.json") {
+			continue
+		}
+
+		actionName := strings.TrimSuffix(filepath.Base(recording), ".success.json")
+
+		a, found := actions[actionName]
+		if !found {
+			log.Printf("skipping %v: unknown action %q", recording, actionName)
+			continue
+		}
+
+		file.Tests = append(file.Tests, goldenTest{
+			Name:          a.Name,
+			RecordingPath: "./testdata/" + filepath.Base(recording),
+			Call:          a.Call,
+		})
+	}
+
+	if len(file.Tests) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	if err := testFileTemplate.Execute(&buf, file); err != nil {
+		return err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(providersDir, name, "golden_test.go")
+
+	log.Printf("generating %v with %v test(s)", outputPath, len(file.Tests))
+
+	return os.WriteFile(outputPath, source, 0o600)
+}